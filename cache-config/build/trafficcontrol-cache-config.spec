@@ -107,6 +107,22 @@ go_t3c_diff_dir="$ccpath"/t3c-diff
 	cp "$TC_DIR"/"$ccdir"/t3c-diff/t3c-diff.1 .
 ) || { echo "Could not copy go program at $(pwd): $!"; exit 1; }
 
+# copy t3c-lint binary
+go_t3c_lint_dir="$ccpath"/t3c-lint
+( mkdir -p "$go_t3c_lint_dir" && \
+	cd "$go_t3c_lint_dir" && \
+	cp "$TC_DIR"/"$ccdir"/t3c-lint/t3c-lint .
+	cp "$TC_DIR"/"$ccdir"/t3c-lint/t3c-lint.1 .
+) || { echo "Could not copy go program at $(pwd): $!"; exit 1; }
+
+# copy t3c-preview binary
+go_t3c_preview_dir="$ccpath"/t3c-preview
+( mkdir -p "$go_t3c_preview_dir" && \
+	cd "$go_t3c_preview_dir" && \
+	cp "$TC_DIR"/"$ccdir"/t3c-preview/t3c-preview .
+	cp "$TC_DIR"/"$ccdir"/t3c-preview/t3c-preview.1 .
+) || { echo "Could not copy go program at $(pwd): $!"; exit 1; }
+
 # copy t3c-diff binary
 go_t3c_check_reload_dir="$ccpath"/t3c-check-reload
 ( mkdir -p "$go_t3c_check_reload_dir" && \
@@ -166,6 +182,14 @@ t3c_diff_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-diff
 cp -p "$t3c_diff_src"/t3c-diff ${RPM_BUILD_ROOT}/"$installdir"
 gzip -c -9 "$src"/t3c-diff/t3c-diff.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-diff.1.gz
 
+t3c_lint_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-lint
+cp -p "$t3c_lint_src"/t3c-lint ${RPM_BUILD_ROOT}/"$installdir"
+gzip -c -9 "$src"/t3c-lint/t3c-lint.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-lint.1.gz
+
+t3c_preview_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-preview
+cp -p "$t3c_preview_src"/t3c-preview ${RPM_BUILD_ROOT}/"$installdir"
+gzip -c -9 "$src"/t3c-preview/t3c-preview.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-preview.1.gz
+
 t3c_check_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-check
 cp -p "$t3c_check_src"/t3c-check ${RPM_BUILD_ROOT}/"$installdir"
 gzip -c -9 "$src"/t3c-check/t3c-check.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-check.1.gz
@@ -223,7 +247,9 @@ fi
 /usr/bin/t3c-check-reload
 /usr/bin/t3c-diff
 /usr/bin/t3c-generate
+/usr/bin/t3c-lint
 /usr/bin/t3c-preprocess
+/usr/bin/t3c-preview
 /usr/bin/t3c-request
 /usr/bin/t3c-update
 /usr/share/man/man1/t3c.1.gz
@@ -233,7 +259,9 @@ fi
 /usr/share/man/man1/t3c-check-reload.1.gz
 /usr/share/man/man1/t3c-diff.1.gz
 /usr/share/man/man1/t3c-generate.1.gz
+/usr/share/man/man1/t3c-lint.1.gz
 /usr/share/man/man1/t3c-preprocess.1.gz
+/usr/share/man/man1/t3c-preview.1.gz
 /usr/share/man/man1/t3c-request.1.gz
 /usr/share/man/man1/t3c-update.1.gz
 
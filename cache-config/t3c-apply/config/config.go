@@ -23,6 +23,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"os/exec"
@@ -37,6 +38,15 @@ import (
 
 var TSHome string = "/opt/trafficserver"
 var TSConfigDir string = "/opt/trafficserver/etc/trafficserver"
+var TSPluginDir string = "/opt/trafficserver/libexec/trafficserver"
+
+// The ATS directories of the Debian and Ubuntu trafficserver package, which
+// doesn't install under a single home directory.
+const (
+	DebianTSHome      = "/usr"
+	DebianTSConfigDir = "/etc/trafficserver"
+	DebianTSPluginDir = "/usr/lib/trafficserver/modules"
+)
 
 const (
 	StatusDir          = "/var/lib/trafficcontrol-cache-config/status"
@@ -44,6 +54,8 @@ const (
 	Chkconfig          = "/sbin/chkconfig"
 	Service            = "/sbin/service"
 	SystemCtl          = "/bin/systemctl"
+	UpdateRCD          = "/usr/sbin/update-rc.d"
+	OSReleaseFile      = "/etc/os-release"
 	TmpBase            = "/tmp/trafficcontrol-cache-config"
 	TrafficCtl         = "/bin/traffic_ctl"
 	TrafficServerOwner = "ats"
@@ -69,6 +81,26 @@ func (s SvcManagement) String() string {
 	return "Unknown"
 }
 
+// OSFamily is the family of Linux distributions the cache runs, which
+// determines its package manager and the default ATS directories.
+type OSFamily int
+
+const (
+	OSFamilyUnknown OSFamily = 0
+	OSFamilyRHEL    OSFamily = 1 // RHEL, CentOS, Rocky, Fedora, and others using rpm and yum.
+	OSFamilyDebian  OSFamily = 2 // Debian, Ubuntu, and others using dpkg and apt.
+)
+
+func (f OSFamily) String() string {
+	switch f {
+	case OSFamilyRHEL:
+		return "RHEL"
+	case OSFamilyDebian:
+		return "Debian"
+	}
+	return "Unknown"
+}
+
 type Cfg struct {
 	Dispersion          time.Duration
 	LogLocationDebug    string
@@ -78,6 +110,7 @@ type Cfg struct {
 	LoginDispersion     time.Duration
 	CacheHostName       string
 	SvcManagement       SvcManagement
+	OSFamily            OSFamily
 	Retries             int
 	RevalWaitTime       time.Duration
 	ReverseProxyDisable bool
@@ -106,8 +139,40 @@ type Cfg struct {
 	MaxMindLocation string
 	TsHome          string
 	TsConfigDir     string
+	// NoRollback is whether to leave new config in place when reloading ATS
+	// or the post-apply health check fails, rather than restoring the previous
+	// config from git.
+	NoRollback bool
+	// SkipHealthCheck is whether to skip the health check of ATS after it's
+	// reloaded or restarted.
+	SkipHealthCheck bool
+	// HealthCheckURL is a URL requested on this cache by the post-apply health
+	// check. If empty, no request is made.
+	HealthCheckURL string
+	// HealthCheckWait is how long to wait after ATS is reloaded or restarted
+	// before checking its health.
+	HealthCheckWait time.Duration
+	// Daemon is whether to run continuously, applying updates as soon as
+	// Traffic Ops flags them, rather than once.
+	Daemon bool
+	// DaemonPollInterval is how often the daemon checks the update status in
+	// Traffic Ops.
+	DaemonPollInterval time.Duration
+	// DaemonMaxBackoff is the longest the daemon waits to retry an update
+	// which failed.
+	DaemonMaxBackoff time.Duration
+	// StatusSocket is the path of the Unix socket the daemon serves its status
+	// on. If empty, the status isn't served.
+	StatusSocket string
+	// Bundle is the path of the local data bundle t3c-request saves Traffic
+	// Ops data to, and falls back to when Traffic Ops can't be reached. If
+	// empty, no bundle is kept.
+	Bundle string
 }
 
+// DefaultStatusSocket is the default path of the daemon's status socket.
+const DefaultStatusSocket = "/var/run/t3c-apply.sock"
+
 type UseGitFlag string
 
 const (
@@ -170,9 +235,51 @@ func directoryExists(dir string) (bool, os.FileInfo) {
 	return info.IsDir(), info
 }
 
-// derives the ATS Installation directory from
-// the rpm config file list.
-func GetTSPackageHome() string {
+// GetOSFamily returns the family of the running OS, from /etc/os-release.
+// If the file can't be read, the family is unknown.
+func GetOSFamily() OSFamily {
+	osRelease, err := ioutil.ReadFile(OSReleaseFile)
+	if err != nil {
+		return OSFamilyUnknown
+	}
+	return parseOSFamily(string(osRelease))
+}
+
+// parseOSFamily returns the OS family of the given os-release file, from its
+// ID and ID_LIKE fields.
+func parseOSFamily(osRelease string) OSFamily {
+	ids := []string{}
+	for _, line := range strings.Split(osRelease, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "ID=") && !strings.HasPrefix(line, "ID_LIKE=") {
+			continue
+		}
+		val := line[strings.Index(line, "=")+1:]
+		val = strings.Trim(val, `"'`)
+		ids = append(ids, strings.Fields(strings.ToLower(val))...)
+	}
+	for _, id := range ids {
+		switch id {
+		case "rhel", "centos", "fedora", "rocky", "almalinux":
+			return OSFamilyRHEL
+		case "debian", "ubuntu":
+			return OSFamilyDebian
+		}
+	}
+	return OSFamilyUnknown
+}
+
+// GetTSPackageHome derives the ATS installation directory from the config
+// files of the installed trafficserver package.
+//
+// The Debian package doesn't install under a single directory, so on Debian
+// the package directories are the defaults, and this always returns an empty
+// string.
+func GetTSPackageHome(family OSFamily) string {
+	if family == OSFamilyDebian {
+		return ""
+	}
+
 	var dir []string
 	var output bytes.Buffer
 	var tsHome string = ""
@@ -237,6 +344,15 @@ func GetCfg() (Cfg, error) {
 	maxmindLocationPtr := getopt.StringLong("maxmind-location", 'M', "", "URL of a maxmind gzipped database file, to be installed into the trafficserver etc directory.")
 	verbosePtr := getopt.CounterLong("verbose", 'v', `Log verbosity. Logging is output to stderr. By default, errors are logged. To log warnings, pass '-v'. To log info, pass '-vv'. To omit error logging, see '-s'`)
 	silentPtr := getopt.BoolLong("silent", 's', `Silent. Errors are not logged, and the 'verbose' flag is ignored. If a fatal error occurs, the return code will be non-zero but no text will be output to stderr`)
+	noRollbackPtr := getopt.BoolLong("no-rollback", 0, "Whether to leave the new config in place if reloading ATS or the post-apply health check fails. By default, the previous config is restored from git and ATS is reloaded again. Rollback requires git.")
+	skipHealthCheckPtr := getopt.BoolLong("skip-health-check", 0, "Whether to skip checking ATS is healthy after it is reloaded or restarted. Default is false.")
+	healthCheckURLPtr := getopt.StringLong("health-check-url", 0, "", "URL requested on this cache by the post-apply health check, which fails if it can't be requested or returns a 5xx. If omitted, no request is made.")
+	healthCheckWaitPtr := getopt.IntLong("health-check-wait", 0, 5, "[seconds] wait after ATS is reloaded or restarted before checking its health, default is 5")
+	daemonPtr := getopt.BoolLong("daemon", 0, "Run continuously, applying updates in the run mode and revalidations as soon as Traffic Ops flags them. The dispersion is ignored.")
+	daemonPollIntervalPtr := getopt.IntLong("daemon-poll-interval", 0, 10, "[seconds] how often the daemon checks Traffic Ops for queued updates, with jitter, default is 10")
+	daemonMaxBackoffPtr := getopt.IntLong("daemon-max-backoff", 0, 600, "[seconds] the longest the daemon waits to retry a failed update, default is 600")
+	statusSocketPtr := getopt.StringLong("status-socket", 0, DefaultStatusSocket, "Path of the Unix socket the daemon serves its status on. If empty, the status isn't served. Default is "+DefaultStatusSocket)
+	bundlePtr := getopt.StringLong("bundle", 0, t3cutil.DefaultBundlePath, "Path of the local data bundle, which Traffic Ops data is saved to, and used if Traffic Ops can't be reached. If empty, no bundle is kept. Default is "+t3cutil.DefaultBundlePath)
 
 	getopt.Parse()
 
@@ -306,6 +422,15 @@ func GetCfg() (Cfg, error) {
 	if runMode == t3cutil.ModeInvalid {
 		return Cfg{}, errors.New(*runModePtr + " is an invalid mode.")
 	}
+	if *daemonPtr && runMode == t3cutil.ModeReport {
+		return Cfg{}, errors.New("daemon can't be run in report mode, it must be syncds, badass, or revalidate.")
+	}
+	if *daemonPtr && *daemonPollIntervalPtr <= 0 {
+		return Cfg{}, errors.New("daemon-poll-interval must be positive.")
+	}
+	if *daemonPtr && *daemonMaxBackoffPtr < *daemonPollIntervalPtr {
+		return Cfg{}, errors.New("daemon-max-backoff must be at least daemon-poll-interval.")
+	}
 
 	urlSourceStr := "argument" // for error messages
 	if toURL == "" {
@@ -325,6 +450,14 @@ func GetCfg() (Cfg, error) {
 		os.Setenv("TO_PASS", toPass)
 	}
 
+	osFamily := GetOSFamily()
+	fmt.Printf("OS family: %s\n", osFamily)
+	if osFamily == OSFamilyDebian {
+		TSHome = DebianTSHome
+		TSConfigDir = DebianTSConfigDir
+		TSPluginDir = DebianTSPluginDir
+	}
+
 	// set TSHome
 	var tsHome = ""
 	if *tsHomePtr != "" {
@@ -336,7 +469,7 @@ func GetCfg() (Cfg, error) {
 		if tsHome != "" {
 			fmt.Printf("set TSHome from TS_HOME environment variable '%s'\n", TSHome)
 		} else { // finally check using the config file listing from the rpm package.
-			tsHome = GetTSPackageHome()
+			tsHome = GetTSPackageHome(osFamily)
 			if tsHome != "" {
 				fmt.Printf("set TSHome from the RPM config file  list '%s'\n", tsHome)
 			} else {
@@ -347,6 +480,7 @@ func GetCfg() (Cfg, error) {
 	if tsHome != "" {
 		TSHome = tsHome
 		TSConfigDir = tsHome + "/etc/trafficserver"
+		TSPluginDir = tsHome + "/libexec/trafficserver"
 		fmt.Printf("TSHome: %s, TSConfigDir: %s\n", TSHome, TSConfigDir)
 	}
 
@@ -371,7 +505,7 @@ func GetCfg() (Cfg, error) {
 		return Cfg{}, errors.New("invalid Traffic Ops URL from " + urlSourceStr + " '" + toURL + "': " + err.Error())
 	}
 
-	svcManagement := getOSSvcManagement()
+	svcManagement := getOSSvcManagement(osFamily)
 	yumOptions := os.Getenv("YUM_OPTIONS")
 
 	cfg := Cfg{
@@ -383,6 +517,7 @@ func GetCfg() (Cfg, error) {
 		LoginDispersion:             loginDispersion,
 		CacheHostName:               cacheHostName,
 		SvcManagement:               svcManagement,
+		OSFamily:                    osFamily,
 		Retries:                     retries,
 		RevalWaitTime:               revalWaitTime,
 		ReverseProxyDisable:         reverseProxyDisable,
@@ -406,6 +541,15 @@ func GetCfg() (Cfg, error) {
 		MaxMindLocation:             maxmindLocation,
 		TsHome:                      TSHome,
 		TsConfigDir:                 TSConfigDir,
+		NoRollback:                  *noRollbackPtr,
+		SkipHealthCheck:             *skipHealthCheckPtr,
+		HealthCheckURL:              *healthCheckURLPtr,
+		HealthCheckWait:             time.Second * time.Duration(*healthCheckWaitPtr),
+		Daemon:                      *daemonPtr,
+		DaemonPollInterval:          time.Second * time.Duration(*daemonPollIntervalPtr),
+		DaemonMaxBackoff:            time.Second * time.Duration(*daemonMaxBackoffPtr),
+		StatusSocket:                *statusSocketPtr,
+		Bundle:                      *bundlePtr,
 	}
 
 	if err = log.InitCfg(cfg); err != nil {
@@ -440,7 +584,7 @@ func isCommandAvailable(name string) bool {
 	return true
 }
 
-func getOSSvcManagement() SvcManagement {
+func getOSSvcManagement(family OSFamily) SvcManagement {
 	var _svcManager SvcManagement
 
 	if isCommandAvailable(SystemCtl) {
@@ -448,7 +592,12 @@ func getOSSvcManagement() SvcManagement {
 	} else if isCommandAvailable(Service) {
 		_svcManager = SystemV
 	}
-	if !isCommandAvailable(Chkconfig) {
+	// services are enabled with chkconfig on RHEL, and update-rc.d on Debian.
+	if family == OSFamilyDebian {
+		if _, err := os.Stat(UpdateRCD); err != nil {
+			return Unknown
+		}
+	} else if !isCommandAvailable(Chkconfig) {
 		return Unknown
 	}
 
@@ -465,6 +614,7 @@ func printConfig(cfg Cfg) {
 	log.Debugf("LoginDispersion: %d\n", cfg.LoginDispersion)
 	log.Debugf("CacheHostName: %s\n", cfg.CacheHostName)
 	log.Debugf("SvcManagement: %s\n", cfg.SvcManagement)
+	log.Debugf("OSFamily: %s\n", cfg.OSFamily)
 	log.Debugf("Retries: %d\n", cfg.Retries)
 	log.Debugf("RevalWaitTime: %d\n", cfg.RevalWaitTime)
 	log.Debugf("ReverseProxyDisable: %t\n", cfg.ReverseProxyDisable)
@@ -476,9 +626,20 @@ func printConfig(cfg Cfg) {
 	log.Debugf("TOPass: Pass len: '%d'\n", len(cfg.TOPass))
 	log.Debugf("TOURL: %s\n", cfg.TOURL)
 	log.Debugf("TSHome: %s\n", TSHome)
+	log.Debugf("TSConfigDir: %s\n", TSConfigDir)
+	log.Debugf("TSPluginDir: %s\n", TSPluginDir)
 	log.Debugf("WaitForParents: %v\n", cfg.WaitForParents)
 	log.Debugf("YumOptions: %s\n", cfg.YumOptions)
 	log.Debugf("MaxmindLocation: %s\n", cfg.MaxMindLocation)
+	log.Debugf("NoRollback: %t\n", cfg.NoRollback)
+	log.Debugf("SkipHealthCheck: %t\n", cfg.SkipHealthCheck)
+	log.Debugf("HealthCheckURL: %s\n", cfg.HealthCheckURL)
+	log.Debugf("HealthCheckWait: %v\n", cfg.HealthCheckWait)
+	log.Debugf("Daemon: %t\n", cfg.Daemon)
+	log.Debugf("DaemonPollInterval: %v\n", cfg.DaemonPollInterval)
+	log.Debugf("DaemonMaxBackoff: %v\n", cfg.DaemonMaxBackoff)
+	log.Debugf("StatusSocket: %s\n", cfg.StatusSocket)
+	log.Debugf("Bundle: %s\n", cfg.Bundle)
 }
 
 func Usage() {
@@ -0,0 +1,41 @@
+package config
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+)
+
+func TestParseOSFamily(t *testing.T) {
+	tests := map[string]OSFamily{
+		"NAME=\"CentOS Linux\"\nID=\"centos\"\nID_LIKE=\"rhel fedora\"\n":      OSFamilyRHEL,
+		"NAME=\"Rocky Linux\"\nID=\"rocky\"\nID_LIKE=\"rhel centos fedora\"\n": OSFamilyRHEL,
+		"PRETTY_NAME=\"Debian GNU/Linux 11 (bullseye)\"\nID=debian\n":          OSFamilyDebian,
+		"NAME=\"Ubuntu\"\nID=ubuntu\nID_LIKE=debian\nVERSION_ID=\"22.04\"\n":   OSFamilyDebian,
+		"NAME=\"Linux Mint\"\nID=linuxmint\nID_LIKE=\"ubuntu debian\"\n":       OSFamilyDebian,
+		"NAME=\"Alpine Linux\"\nID=alpine\n":                                   OSFamilyUnknown,
+		"":                                                                     OSFamilyUnknown,
+	}
+	for osRelease, expected := range tests {
+		if actual := parseOSFamily(osRelease); actual != expected {
+			t.Errorf("os-release %q: expected %s, actual %s", osRelease, expected, actual)
+		}
+	}
+}
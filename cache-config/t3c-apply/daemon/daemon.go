@@ -0,0 +1,301 @@
+// Package daemon runs t3c-apply continuously, applying queued updates and
+// revalidations as soon as Traffic Ops flags them, rather than when cron
+// next runs t3c-apply.
+package daemon
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-apply/config"
+	"github.com/apache/trafficcontrol/cache-config/t3c-generate/toreq"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// UserAgent is the user agent of the daemon's Traffic Ops session.
+const UserAgent = "t3c-apply-daemon"
+
+// RunFunc applies updates once in the given mode, as t3c-apply does when it
+// isn't a daemon.
+type RunFunc func(mode t3cutil.Mode) error
+
+// Daemon watches the update status of the cache in Traffic Ops, and applies
+// updates when they're queued.
+type Daemon struct {
+	cfg    config.Cfg
+	run    RunFunc
+	check  func() (tc.ServerUpdateStatus, error)
+	status ThreadsafeStatus
+}
+
+// New returns a Daemon watching the cache of the given config, which calls
+// run to apply updates.
+func New(cfg config.Cfg, run RunFunc) *Daemon {
+	checker := &toChecker{cfg: cfg}
+	return &Daemon{
+		cfg:    cfg,
+		run:    run,
+		check:  checker.check,
+		status: NewThreadsafeStatus(),
+	}
+}
+
+// Status returns the status of the daemon.
+func (d *Daemon) Status() ThreadsafeStatus {
+	return d.status
+}
+
+// Run watches and applies updates until stop is closed. A run in progress
+// when stop is closed is finished first.
+//
+// The update status is checked every poll interval, with jitter so caches
+// don't make their requests together. When a run fails, or doesn't clear
+// the pending update, it's retried with exponential backoff, up to the max
+// backoff.
+func (d *Daemon) Run(stop <-chan struct{}) error {
+	if d.cfg.StatusSocket != "" {
+		listener, err := ServeStatus(d.cfg.StatusSocket, d.status)
+		if err != nil {
+			return err
+		}
+		defer listener.Close()
+		log.Infoln("serving daemon status on '" + d.cfg.StatusSocket + "'")
+	}
+
+	log.Infof("daemon started, checking for updates every %v in %s mode\n", d.cfg.DaemonPollInterval, d.cfg.RunMode)
+
+	// attempts is the number of runs since the last time no update was
+	// pending, and checkFailures the number of consecutive failed checks.
+	attempts := 0
+	checkFailures := 0
+	wait := time.Duration(0)
+	for {
+		next := time.Now().Add(wait)
+		d.status.Update(func(s *Status) { s.NextCheck = &next })
+		select {
+		case <-stop:
+			log.Infoln("daemon stopping")
+			return nil
+		case <-time.After(wait):
+		}
+
+		updateStatus, err := d.check()
+		if err != nil {
+			checkFailures++
+			log.Errorln("daemon checking update status: " + err.Error())
+			d.setError("checking update status: " + err.Error())
+			wait = Jitter(Backoff(checkFailures, d.cfg.DaemonPollInterval, d.cfg.DaemonMaxBackoff))
+			continue
+		}
+		checkFailures = 0
+		d.setUpdateStatus(updateStatus)
+
+		mode := ModeFor(updateStatus, d.cfg)
+		if mode == t3cutil.ModeInvalid {
+			if attempts > 0 {
+				log.Infoln("daemon: no update pending")
+			}
+			attempts = 0
+			d.status.Update(func(s *Status) { s.ConsecutiveFailures = 0 })
+			wait = Jitter(d.cfg.DaemonPollInterval)
+			continue
+		}
+
+		if attempts > 0 {
+			log.Warnf("daemon: update still pending after %d runs, retrying\n", attempts)
+			d.status.Update(func(s *Status) { s.ConsecutiveFailures = attempts })
+		}
+		attempts++
+		d.runOnce(mode)
+		// If the run cleared the pending update, the next check resets attempts,
+		// so a successful run waits about the poll interval.
+		wait = Jitter(Backoff(attempts, d.cfg.DaemonPollInterval, d.cfg.DaemonMaxBackoff))
+	}
+}
+
+// runOnce runs the given mode and records the result in the status.
+func (d *Daemon) runOnce(mode t3cutil.Mode) {
+	start := time.Now()
+	log.Infof("daemon: applying update in %s mode\n", mode)
+	d.status.Update(func(s *Status) {
+		s.Running = true
+		s.RunMode = mode.String()
+		s.LastRun = &start
+	})
+
+	err := d.run(mode)
+
+	d.status.Update(func(s *Status) {
+		s.Running = false
+		s.LastRunDurationMS = int64(time.Since(start) / time.Millisecond)
+		if err == nil {
+			s.LastSuccess = &start
+		}
+	})
+	if err != nil {
+		log.Errorf("daemon: %s run failed: %s\n", mode, err.Error())
+		d.setError(mode.String() + " run: " + err.Error())
+		return
+	}
+	log.Infof("daemon: %s run succeeded\n", mode)
+}
+
+func (d *Daemon) setError(msg string) {
+	now := time.Now()
+	d.status.Update(func(s *Status) {
+		s.LastError = msg
+		s.LastErrorTime = &now
+	})
+}
+
+func (d *Daemon) setUpdateStatus(us tc.ServerUpdateStatus) {
+	now := time.Now()
+	d.status.Update(func(s *Status) {
+		s.LastCheck = &now
+		s.UpdatePending = us.UpdatePending
+		s.RevalPending = us.RevalPending
+		s.ParentPending = us.ParentPending
+		s.ParentRevalPending = us.ParentRevalPending
+	})
+}
+
+// ModeFor returns the mode to apply the given update status in, or
+// ModeInvalid if no update should be applied.
+//
+// Queued updates are applied in the configured mode, and revalidations in
+// revalidate mode. Updates waiting on parents, per the wait-for-parents
+// config, aren't applied until the parents are updated, as t3c-apply would
+// bail out anyway.
+func ModeFor(us tc.ServerUpdateStatus, cfg config.Cfg) t3cutil.Mode {
+	if us.UpdatePending && cfg.RunMode != t3cutil.ModeRevalidate {
+		waitForParents := cfg.WaitForParents == config.WaitForParentsTrue ||
+			(cfg.WaitForParents == config.WaitForParentsReval && !us.UseRevalPending)
+		if !us.ParentPending || !waitForParents {
+			return cfg.RunMode
+		}
+	}
+	if us.UseRevalPending && us.RevalPending {
+		waitForParents := cfg.WaitForParents == config.WaitForParentsTrue || cfg.WaitForParents == config.WaitForParentsReval
+		if !us.ParentRevalPending || !waitForParents {
+			return t3cutil.ModeRevalidate
+		}
+	}
+	return t3cutil.ModeInvalid
+}
+
+// Backoff returns how long to wait before the given attempt, starting at 1,
+// which is min, doubled for each attempt after, up to max.
+func Backoff(attempt int, min time.Duration, max time.Duration) time.Duration {
+	wait := min
+	for i := 1; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait
+}
+
+// Jitter returns a random duration between half the given duration and the
+// given duration.
+func Jitter(d time.Duration) time.Duration {
+	return jitter(d, rand.Float64())
+}
+
+func jitter(d time.Duration, rnd float64) time.Duration {
+	return d/2 + time.Duration(float64(d/2)*rnd)
+}
+
+// toChecker checks the update status of a cache, keeping a Traffic Ops
+// session between checks.
+type toChecker struct {
+	cfg    config.Cfg
+	client *toreq.TOClient
+	// last is the last update status, and lastModified its Last-Modified
+	// header, if Traffic Ops sent one, to make conditional requests.
+	last         *tc.ServerUpdateStatus
+	lastModified string
+}
+
+func (c *toChecker) check() (tc.ServerUpdateStatus, error) {
+	if c.client == nil {
+		if err := c.login(); err != nil {
+			return tc.ServerUpdateStatus{}, err
+		}
+	}
+	cacheName := tc.CacheName(c.cfg.CacheHostName)
+
+	if c.client.FellBack() {
+		status, _, err := c.client.GetServerUpdateStatus(cacheName, nil)
+		if err != nil {
+			c.client = nil // log in again next time, in case the session expired
+			return tc.ServerUpdateStatus{}, err
+		}
+		return status, nil
+	}
+
+	hdr := http.Header{}
+	if c.last != nil && c.lastModified != "" {
+		hdr.Set(rfc.IfModifiedSince, c.lastModified)
+	}
+	status, reqInf, err := c.client.C.GetServerUpdateStatusWithHdr(c.cfg.CacheHostName, hdr)
+	if reqInf.StatusCode == http.StatusNotModified && c.last != nil {
+		return *c.last, nil
+	}
+	if err != nil {
+		c.client = nil // log in again next time, in case the session expired
+		return tc.ServerUpdateStatus{}, errors.New("getting server update status: " + err.Error())
+	}
+	c.last = &status
+	c.lastModified = reqInf.RespHeaders.Get(rfc.LastModified)
+	return status, nil
+}
+
+func (c *toChecker) login() error {
+	toURL, err := url.Parse(c.cfg.TOURL)
+	if err != nil {
+		return errors.New("parsing Traffic Ops URL '" + c.cfg.TOURL + "': " + err.Error())
+	}
+	tcCfg, err := t3cutil.TOConnect(&t3cutil.TCCfg{
+		CacheHostName: c.cfg.CacheHostName,
+		TOInsecure:    c.cfg.TOInsecure,
+		TOTimeoutMS:   c.cfg.TOTimeoutMS,
+		TOUser:        c.cfg.TOUser,
+		TOPass:        c.cfg.TOPass,
+		TOURL:         toURL,
+		UserAgent:     UserAgent,
+	})
+	if err != nil {
+		return err
+	}
+	tcCfg.TOClient.NumRetries = c.cfg.Retries
+	c.client = tcCfg.TOClient
+	c.last = nil
+	c.lastModified = ""
+	return nil
+}
@@ -0,0 +1,202 @@
+package daemon
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-apply/config"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestModeFor(t *testing.T) {
+	syncCfg := config.Cfg{RunMode: t3cutil.ModeSyncDS, WaitForParents: config.WaitForParentsReval}
+	badassCfg := config.Cfg{RunMode: t3cutil.ModeBadAss, WaitForParents: config.WaitForParentsFalse}
+	revalCfg := config.Cfg{RunMode: t3cutil.ModeRevalidate, WaitForParents: config.WaitForParentsTrue}
+
+	tests := []struct {
+		name     string
+		status   tc.ServerUpdateStatus
+		cfg      config.Cfg
+		expected t3cutil.Mode
+	}{
+		{"nothing pending", tc.ServerUpdateStatus{UseRevalPending: true}, syncCfg, t3cutil.ModeInvalid},
+		{"update", tc.ServerUpdateStatus{UpdatePending: true, UseRevalPending: true}, syncCfg, t3cutil.ModeSyncDS},
+		{"update badass", tc.ServerUpdateStatus{UpdatePending: true}, badassCfg, t3cutil.ModeBadAss},
+		{"update and reval", tc.ServerUpdateStatus{UpdatePending: true, RevalPending: true, UseRevalPending: true}, syncCfg, t3cutil.ModeSyncDS},
+		{"reval", tc.ServerUpdateStatus{RevalPending: true, UseRevalPending: true}, syncCfg, t3cutil.ModeRevalidate},
+		{"reval not used", tc.ServerUpdateStatus{RevalPending: true}, syncCfg, t3cutil.ModeInvalid},
+		{"update in reval mode", tc.ServerUpdateStatus{UpdatePending: true, UseRevalPending: true}, revalCfg, t3cutil.ModeInvalid},
+		{"update waiting for parents", tc.ServerUpdateStatus{UpdatePending: true, ParentPending: true}, syncCfg, t3cutil.ModeInvalid},
+		{"update not waiting for parents with reval", tc.ServerUpdateStatus{UpdatePending: true, ParentPending: true, UseRevalPending: true}, syncCfg, t3cutil.ModeSyncDS},
+		{"update not waiting for parents", tc.ServerUpdateStatus{UpdatePending: true, ParentPending: true}, badassCfg, t3cutil.ModeBadAss},
+		{"reval waiting for parents", tc.ServerUpdateStatus{RevalPending: true, UseRevalPending: true, ParentRevalPending: true}, revalCfg, t3cutil.ModeInvalid},
+		{"reval not waiting for parents", tc.ServerUpdateStatus{RevalPending: true, UseRevalPending: true, ParentRevalPending: true}, badassCfg, t3cutil.ModeRevalidate},
+	}
+	for _, test := range tests {
+		if actual := ModeFor(test.status, test.cfg); actual != test.expected {
+			t.Errorf("%s: expected mode '%s', actual '%s'", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	min := 10 * time.Second
+	max := time.Minute
+	expected := []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute}
+	for i, exp := range expected {
+		if actual := Backoff(i+1, min, max); actual != exp {
+			t.Errorf("attempt %d: expected %v, actual %v", i+1, exp, actual)
+		}
+	}
+	if actual := Backoff(1000, min, max); actual != max {
+		t.Errorf("attempt 1000: expected %v, actual %v", max, actual)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	if actual := jitter(10*time.Second, 0); actual != 5*time.Second {
+		t.Errorf("expected no jitter to be half, actual %v", actual)
+	}
+	if actual := jitter(10*time.Second, 1); actual != 10*time.Second {
+		t.Errorf("expected max jitter to be whole, actual %v", actual)
+	}
+	for i := 0; i < 100; i++ {
+		if actual := Jitter(time.Second); actual < time.Second/2 || actual > time.Second {
+			t.Fatalf("expected jitter between 500ms and 1s, actual %v", actual)
+		}
+	}
+}
+
+func TestDaemonRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "t3c-apply-daemon")
+	if err != nil {
+		t.Fatalf("creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "t3c-apply.sock")
+
+	cfg := config.Cfg{
+		RunMode:            t3cutil.ModeSyncDS,
+		WaitForParents:     config.WaitForParentsReval,
+		DaemonPollInterval: time.Millisecond,
+		DaemonMaxBackoff:   4 * time.Millisecond,
+		StatusSocket:       socket,
+	}
+
+	// the update is pending until the second run succeeds, and then a
+	// revalidation is queued.
+	m := sync.Mutex{}
+	updatePending := true
+	revalPending := false
+	runs := []t3cutil.Mode{}
+	done := make(chan struct{})
+
+	d := New(cfg, func(mode t3cutil.Mode) error {
+		m.Lock()
+		defer m.Unlock()
+		runs = append(runs, mode)
+		switch len(runs) {
+		case 1:
+			return errors.New("reload failed")
+		case 2:
+			updatePending = false
+			revalPending = true
+		case 3:
+			revalPending = false
+			close(done)
+		}
+		return nil
+	})
+	d.check = func() (tc.ServerUpdateStatus, error) {
+		m.Lock()
+		defer m.Unlock()
+		return tc.ServerUpdateStatus{UpdatePending: updatePending, RevalPending: revalPending, UseRevalPending: true}, nil
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan error)
+	go func() { stopped <- d.Run(stop) }()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("daemon didn't apply the updates")
+	}
+
+	client := http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	resp, err := client.Get("http://t3c-apply" + StatusPath)
+	if err != nil {
+		t.Fatalf("getting status from socket: %v", err)
+	}
+	status := Status{}
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("decoding status: %v", err)
+	}
+
+	close(stop)
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Errorf("expected daemon to stop without error, actual %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("daemon didn't stop")
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	expected := []t3cutil.Mode{t3cutil.ModeSyncDS, t3cutil.ModeSyncDS, t3cutil.ModeRevalidate}
+	if len(runs) != len(expected) {
+		t.Fatalf("expected runs %v, actual %v", expected, runs)
+	}
+	for i, mode := range expected {
+		if runs[i] != mode {
+			t.Errorf("expected runs %v, actual %v", expected, runs)
+			break
+		}
+	}
+	if status.LastError != "syncds run: reload failed" {
+		t.Errorf("expected status last error 'syncds run: reload failed', actual '%s'", status.LastError)
+	}
+	if status.LastRun == nil || status.LastSuccess == nil {
+		t.Errorf("expected status last run and success, actual %+v", status)
+	}
+	if _, err := os.Stat(socket); err == nil {
+		t.Errorf("expected status socket to be removed when the daemon stops")
+	}
+}
@@ -0,0 +1,143 @@
+package daemon
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+)
+
+// StatusPath is the path of the status endpoint of the status socket.
+const StatusPath = "/status"
+
+// Status is the local status of the daemon, served on its status socket.
+type Status struct {
+	// Started is when the daemon started.
+	Started time.Time `json:"started"`
+	// Running is whether an update is being applied now.
+	Running bool `json:"running"`
+	// RunMode is the mode of the current run, or the last run if none is
+	// running.
+	RunMode string `json:"runMode,omitempty"`
+	// LastRun is when the last run started.
+	LastRun *time.Time `json:"lastRun,omitempty"`
+	// LastRunDurationMS is how long the last run took, in milliseconds.
+	LastRunDurationMS int64 `json:"lastRunDurationMS"`
+	// LastSuccess is when the last successful run started.
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+	// LastError is the error of the last failed run or update status check.
+	LastError string `json:"lastError,omitempty"`
+	// LastErrorTime is when LastError happened.
+	LastErrorTime *time.Time `json:"lastErrorTime,omitempty"`
+	// ConsecutiveFailures is the number of runs which failed, or didn't clear
+	// the pending update, since the last time no update was pending.
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+	// LastCheck is when the update status was last successfully checked.
+	LastCheck *time.Time `json:"lastCheck,omitempty"`
+	// NextCheck is when the update status will next be checked.
+	NextCheck *time.Time `json:"nextCheck,omitempty"`
+
+	// The update status of the server in Traffic Ops, at the last check.
+	UpdatePending      bool `json:"updatePending"`
+	RevalPending       bool `json:"revalPending"`
+	ParentPending      bool `json:"parentPending"`
+	ParentRevalPending bool `json:"parentRevalPending"`
+}
+
+// ThreadsafeStatus is a Status safe for multiple goroutines.
+type ThreadsafeStatus struct {
+	s *Status
+	m *sync.RWMutex
+}
+
+// NewThreadsafeStatus returns a ThreadsafeStatus of a daemon started now.
+func NewThreadsafeStatus() ThreadsafeStatus {
+	return ThreadsafeStatus{s: &Status{Started: time.Now()}, m: &sync.RWMutex{}}
+}
+
+// Get returns a copy of the status.
+func (s ThreadsafeStatus) Get() Status {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return *s.s
+}
+
+// Update calls f with the status, which it may change.
+func (s ThreadsafeStatus) Update(f func(s *Status)) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	f(s.s)
+}
+
+// ServeStatus serves the status as JSON over HTTP on a Unix socket at the
+// given path, until the returned listener is closed. Any existing file at
+// the path, left by a daemon which didn't shut down cleanly, is removed.
+//
+// The socket is only accessible by the user and group of the daemon.
+func ServeStatus(path string, status ThreadsafeStatus) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, errors.New("removing existing status socket: " + err.Error())
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, errors.New("listening on status socket: " + err.Error())
+	}
+	if err := os.Chmod(path, 0660); err != nil {
+		listener.Close()
+		return nil, errors.New("setting status socket permissions: " + err.Error())
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(StatusPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		bts, err := json.Marshal(status.Get())
+		if err != nil {
+			log.Errorln("marshalling daemon status: " + err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set(rfc.ContentType, rfc.ApplicationJSON)
+		w.Write(bts)
+	})
+	go func() {
+		// Serve returns an error when the listener is closed, which is how the daemon stops it.
+		if err := http.Serve(listener, mux); err != nil && !isClosedErr(err) {
+			log.Errorln("serving status socket: " + err.Error())
+		}
+	}()
+	return listener, nil
+}
+
+// isClosedErr returns whether err is the error of using a closed listener.
+func isClosedErr(err error) bool {
+	opErr, ok := err.(*net.OpError)
+	return ok && opErr.Err != nil && opErr.Err.Error() == "use of closed network connection"
+}
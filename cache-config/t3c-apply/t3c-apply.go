@@ -22,9 +22,12 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/apache/trafficcontrol/cache-config/t3c-apply/config"
+	"github.com/apache/trafficcontrol/cache-config/t3c-apply/daemon"
 	"github.com/apache/trafficcontrol/cache-config/t3c-apply/torequest"
 	"github.com/apache/trafficcontrol/cache-config/t3c-apply/util"
 	"github.com/apache/trafficcontrol/cache-config/t3cutil"
@@ -43,6 +46,8 @@ const (
 	ServicesError     = 138
 	SyncDSError       = 139
 	UserCheckError    = 140
+	HealthCheckError  = 141
+	RollbackError     = 142
 )
 
 func runSysctl(cfg config.Cfg) {
@@ -57,8 +62,6 @@ func runSysctl(cfg config.Cfg) {
 }
 
 func main() {
-	var syncdsUpdate torequest.UpdateStatus
-	var lock util.FileLock
 	cfg, err := config.GetCfg()
 	if err != nil {
 		fmt.Println(err)
@@ -66,6 +69,53 @@ func main() {
 	} else if cfg == (config.Cfg{}) { // user used the --help option
 		os.Exit(Success)
 	}
+	if cfg.Daemon {
+		os.Exit(RunDaemon(cfg))
+	}
+	os.Exit(Run(cfg))
+}
+
+// RunDaemon runs t3c-apply continuously, applying updates as soon as Traffic
+// Ops flags them, until it's sent SIGINT or SIGTERM. It returns the exit code.
+func RunDaemon(cfg config.Cfg) int {
+	// The daemon spreads out requests with jitter and backoff, so its runs
+	// don't sleep before applying updates.
+	runCfg := cfg
+	runCfg.Dispersion = 0
+	runCfg.LoginDispersion = 0
+
+	d := daemon.New(cfg, func(mode t3cutil.Mode) error {
+		modeCfg := runCfg
+		modeCfg.RunMode = mode
+		if code := Run(modeCfg); code != Success {
+			return fmt.Errorf("t3c-apply failed with exit code %d", code)
+		}
+		return nil
+	})
+
+	stop := make(chan struct{})
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigs
+		log.Infoln("received " + sig.String() + ", stopping daemon after any update in progress")
+		close(stop)
+	}()
+
+	if err := d.Run(stop); err != nil {
+		log.Errorln("running daemon: " + err.Error())
+		return GeneralFailure
+	}
+	return Success
+}
+
+// Run applies updates once, in the mode of the given config, and returns the
+// exit code.
+func Run(cfg config.Cfg) int {
+	var syncdsUpdate torequest.UpdateStatus
+	var lock util.FileLock
+	var err error
+	defer lock.Unlock()
 
 	if cfg.UseGit == config.UseGitYes {
 		err := util.EnsureConfigDirIsGitRepo(config.TSConfigDir)
@@ -86,31 +136,48 @@ func main() {
 		}
 	}
 
+	// the commit of the config before this run changes it, to roll back to if
+	// applying the new config fails.
+	preChangeCommit := ""
+	if cfg.NoRollback {
+		log.Infoln("no-rollback set, not rolling back config on failure")
+	} else if cfg.UseGit == config.UseGitYes || cfg.UseGit == config.UseGitAuto {
+		if preChangeCommit, err = util.GitHead(config.TSConfigDir); err != nil {
+			log.Errorln("getting git commit of existing config, dir '" + config.TSConfigDir + "', config will not be rolled back on failure: " + err.Error())
+			preChangeCommit = ""
+		}
+	} else {
+		log.Infoln("UseGit is 'no', config will not be rolled back on failure")
+	}
+
 	trops := torequest.NewTrafficOpsReq(cfg)
 
-	// if doing os checks, insure there is a 'systemctl' or 'service' and 'chkconfig' commands.
+	// if doing os checks, insure the OS is supported, and there is a 'systemctl' or 'service' and 'chkconfig' or 'update-rc.d' commands.
+	if !cfg.SkipOSCheck && cfg.OSFamily == config.OSFamilyUnknown {
+		log.Errorln("OS checks are enabled and unable to determine the OS family from " + config.OSReleaseFile + ", assuming RHEL packaging.")
+	}
 	if !cfg.SkipOSCheck && cfg.SvcManagement == config.Unknown {
-		log.Errorln("OS checks are enabled and unable to find any know service management tools.")
+		log.Errorln("OS checks are enabled and unable to find any know service management tools for the " + cfg.OSFamily.String() + " OS family.")
 	}
 
 	// create and clean the config.TmpBase (/tmp/ort)
 	if !util.MkDir(config.TmpBase, cfg) {
 		log.Errorln("mkdir TmpBase '" + config.TmpBase + "' failed, cannot continue")
-		os.Exit(GeneralFailure)
+		return GeneralFailure
 	} else if !util.CleanTmpDir(cfg) {
 		log.Errorln("CleanTmpDir failed, cannot continue")
-		os.Exit(GeneralFailure)
+		return GeneralFailure
 	}
 	if cfg.RunMode != t3cutil.ModeReport {
 		if !lock.GetLock(config.TmpBase + "/to_ort.lock") {
-			os.Exit(AlreadyRunning)
+			return AlreadyRunning
 		}
 	}
 
 	fmt.Println(time.Now().Format(time.UnixDate))
 
 	if !util.CheckUser(cfg) {
-		lock.UnlockAndExit(UserCheckError)
+		return UserCheckError
 	}
 
 	toolName := trops.GetHeaderComment()
@@ -126,18 +193,18 @@ func main() {
 			} else {
 				log.Infoln("Checking revalidate state: returned UpdateTropsNotNeeded")
 			}
-			GitCommitAndExit(RevalidationError, cfg)
+			return GitCommit(RevalidationError, cfg)
 		}
 	} else {
 		syncdsUpdate, err = trops.CheckSyncDSState()
 		if err != nil {
 			log.Errorln(err)
-			GitCommitAndExit(SyncDSError, cfg)
+			return GitCommit(SyncDSError, cfg)
 		}
 		if cfg.RunMode == t3cutil.ModeSyncDS && syncdsUpdate == torequest.UpdateTropsNotNeeded {
 			// check for maxmind db updates even if we have no other updates
 			CheckMaxmindUpdate(cfg)
-			GitCommitAndExit(Success, cfg)
+			return GitCommit(Success, cfg)
 		}
 	}
 
@@ -148,14 +215,14 @@ func main() {
 		err = trops.ProcessPackages()
 		if err != nil {
 			log.Errorf("Error processing packages: %s\n", err)
-			GitCommitAndExit(PackagingError, cfg)
+			return GitCommit(PackagingError, cfg)
 		}
 
 		// check and make sure packages are enabled for startup
 		err = trops.CheckSystemServices()
 		if err != nil {
 			log.Errorf("Error verifying system services: %s\n", err.Error())
-			GitCommitAndExit(ServicesError, cfg)
+			return GitCommit(ServicesError, cfg)
 		}
 	}
 
@@ -163,7 +230,7 @@ func main() {
 	err = trops.GetConfigFileList()
 	if err != nil {
 		log.Errorf("Unable to continue: %s\n", err)
-		GitCommitAndExit(ConfigFilesError, cfg)
+		return GitCommit(ConfigFilesError, cfg)
 	}
 	syncdsUpdate, err = trops.ProcessConfigFiles()
 	if err != nil {
@@ -185,7 +252,12 @@ func main() {
 
 	if err := trops.StartServices(&syncdsUpdate); err != nil {
 		log.Errorln("failed to start services: " + err.Error())
-		GitCommitAndExit(ServicesError, cfg)
+		return Rollback(ServicesError, cfg, trops, preChangeCommit, &syncdsUpdate)
+	}
+
+	if err := trops.CheckHealth(); err != nil {
+		log.Errorln("ATS health check failed after applying config: " + err.Error())
+		return Rollback(HealthCheckError, cfg, trops, preChangeCommit, &syncdsUpdate)
 	}
 
 	// start 'teakd' if installed.
@@ -218,22 +290,61 @@ func main() {
 		log.Infoln("Traffic Ops has been updated.")
 	}
 
-	GitCommitAndExit(Success, cfg)
+	return GitCommit(Success, cfg)
 }
 
 // TODO change code to always create git commits, if the dir is a repo
 // We only want --use-git to init the repo. If someone init'd the repo, t3c-apply should _always_ commit.
 // We don't want someone doing manual badass's and not having that log
 
-// GitCommitAndExit attempts to git commit all changes, logs any error, and calls os.Exit with the given code.
-func GitCommitAndExit(exitCode int, cfg config.Cfg) {
+// GitCommit attempts to git commit all changes, logs any error, and returns the given exit code.
+func GitCommit(exitCode int, cfg config.Cfg) int {
 	success := exitCode == Success
 	if cfg.UseGit == config.UseGitYes || cfg.UseGit == config.UseGitAuto {
 		if err := util.MakeGitCommitAll(config.TSConfigDir, util.GitChangeIsSelf, cfg.RunMode, success); err != nil {
 			log.Errorln("git committing existing changes, dir '" + config.TSConfigDir + "': " + err.Error())
 		}
 	}
-	os.Exit(exitCode)
+	return exitCode
+}
+
+// Rollback commits the failed config, restores the config of the given
+// pre-change commit, reloads ATS, and tells Traffic Ops the update failed, so
+// its queued update isn't cleared. It then returns the given exit code, or
+// RollbackError if the rollback failed.
+//
+// The update is failed whatever the given update status, even if no config
+// changes were needed: Traffic Ops may still have an update pending, which
+// would otherwise be cleared although the apply failed.
+//
+// If preChangeCommit is empty, the config isn't rolled back.
+func Rollback(exitCode int, cfg config.Cfg, trops *torequest.TrafficOpsReq, preChangeCommit string, syncdsUpdate *torequest.UpdateStatus) int {
+	*syncdsUpdate = torequest.UpdateTropsFailed
+	if _, err := trops.UpdateTrafficOps(syncdsUpdate); err != nil {
+		log.Errorf("failed to update Traffic Ops: %s\n", err.Error())
+	}
+
+	if preChangeCommit == "" {
+		log.Errorln("no pre-change config to roll back to, leaving failed config in place")
+		return GitCommit(exitCode, cfg)
+	}
+
+	// commit the failed config first, so it's in the history to diagnose
+	if err := util.MakeGitCommitAll(config.TSConfigDir, util.GitChangeIsSelf, cfg.RunMode, false); err != nil {
+		log.Errorln("git committing failed config, dir '" + config.TSConfigDir + "', not rolling back: " + err.Error())
+		return RollbackError
+	}
+	if err := util.MakeGitRollback(config.TSConfigDir, preChangeCommit, cfg.RunMode); err != nil {
+		log.Errorln("rolling back config to git commit '" + preChangeCommit + "', dir '" + config.TSConfigDir + "': " + err.Error())
+		return RollbackError
+	}
+	log.Errorln("config rolled back to git commit '" + preChangeCommit + "'")
+
+	if err := trops.RollbackServices(); err != nil {
+		log.Errorln("reloading ATS after rollback: " + err.Error())
+		return RollbackError
+	}
+	return exitCode
 }
 
 // CheckMaxmindUpdate will (if a url is set) check for a db on disk.
@@ -373,6 +373,9 @@ func request(cfg config.Cfg, command string) ([]byte, error) {
 		"--cache-host-name=" + cfg.CacheHostName,
 		`--get-data=` + command,
 	}
+	if cfg.Bundle != "" {
+		args = append(args, "--bundle="+cfg.Bundle)
+	}
 
 	if cfg.LogLocationErr == log.LogLocationNull {
 		args = append(args, "-s")
@@ -432,6 +435,9 @@ func requestConfig(cfg config.Cfg) ([]byte, error) {
 	if len(cacheBts) > 0 {
 		args = append(args, `--old-config=stdin`)
 	}
+	if cfg.Bundle != "" {
+		args = append(args, "--bundle="+cfg.Bundle)
+	}
 
 	if cfg.LogLocationWarn != log.LogLocationNull {
 		args = append(args, "-v")
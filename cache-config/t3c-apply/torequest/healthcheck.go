@@ -0,0 +1,136 @@
+package torequest
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// healthcheck.go has funcs to check ATS after applying config, and to reload it after rolling config back.
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-apply/config"
+	"github.com/apache/trafficcontrol/cache-config/t3c-apply/util"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+// healthCheckTimeout is the timeout of the health check request to the
+// HealthCheckURL.
+const healthCheckTimeout = 10 * time.Second
+
+// CheckHealth checks that ATS is healthy, if StartServices reloaded or
+// restarted it: that the trafficserver service is running, that
+// 'traffic_ctl config status' succeeds, and that the configured
+// HealthCheckURL can be requested without a server error.
+//
+// Returns nil if ATS is healthy, or wasn't reloaded or restarted, or the
+// health check is disabled.
+func (r *TrafficOpsReq) CheckHealth() error {
+	if r.Cfg.SkipHealthCheck {
+		log.Infoln("skipping ATS health check")
+		return nil
+	}
+	if !r.atsReloaded {
+		log.Infoln("ATS was not reloaded or restarted, not checking its health")
+		return nil
+	}
+	log.Infof("waiting %v to check ATS health\n", r.Cfg.HealthCheckWait)
+	time.Sleep(r.Cfg.HealthCheckWait)
+	if err := checkATSHealth(r.Cfg.HealthCheckURL); err != nil {
+		return err
+	}
+	log.Infoln("ATS health check succeeded")
+	return nil
+}
+
+func checkATSHealth(healthCheckURL string) error {
+	svcStatus, _, err := util.GetServiceStatus("trafficserver")
+	if err != nil {
+		return errors.New("getting trafficserver service status: " + err.Error())
+	}
+	if svcStatus != util.SvcRunning {
+		return errors.New("trafficserver is not running")
+	}
+	if _, _, err := util.ExecCommand(config.TSHome+config.TrafficCtl, "config", "status"); err != nil {
+		return errors.New("'traffic_ctl config status' failed: " + err.Error())
+	}
+	if healthCheckURL != "" {
+		if err := requestHealthCheckURL(healthCheckURL); err != nil {
+			return errors.New("requesting health check URL '" + healthCheckURL + "': " + err.Error())
+		}
+	}
+	return nil
+}
+
+// requestHealthCheckURL requests the given URL, and returns an error if the
+// request fails or the response is a server error.
+func requestHealthCheckURL(healthCheckURL string) error {
+	client := &http.Client{Timeout: healthCheckTimeout}
+	resp, err := client.Get(healthCheckURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return errors.New("returned status " + strconv.Itoa(resp.StatusCode))
+	}
+	return nil
+}
+
+// RollbackServices restarts ATS in badass mode, or starts it if it's not
+// running, or otherwise reloads it, to load config which was rolled back.
+// It then checks the health of ATS again.
+func (r *TrafficOpsReq) RollbackServices() error {
+	svcStatus, _, err := util.GetServiceStatus("trafficserver")
+	if err != nil {
+		return errors.New("getting trafficserver service status: " + err.Error())
+	}
+
+	if r.Cfg.RunMode == t3cutil.ModeBadAss || svcStatus != util.SvcRunning {
+		startStr := "restart"
+		if svcStatus != util.SvcRunning {
+			startStr = "start"
+		}
+		if _, err := util.ServiceStart("trafficserver", startStr); err != nil {
+			return errors.New("failed to " + startStr + " trafficserver with rolled back config: " + err.Error())
+		}
+		log.Infoln("trafficserver has been " + startStr + "ed with rolled back config")
+	} else {
+		if _, _, err := util.ExecCommand(config.TSHome+config.TrafficCtl, "config", "reload"); err != nil {
+			return errors.New("'traffic_ctl config reload' of rolled back config failed, check ATS logs: " + err.Error())
+		}
+		log.Infoln("ATS 'traffic_ctl config reload' of rolled back config was successful")
+	}
+
+	if r.Cfg.SkipHealthCheck {
+		return nil
+	}
+	time.Sleep(r.Cfg.HealthCheckWait)
+	if err := checkATSHealth(r.Cfg.HealthCheckURL); err != nil {
+		return errors.New("ATS is unhealthy with rolled back config: " + err.Error())
+	}
+	log.Infoln("ATS health check of rolled back config succeeded")
+	return nil
+}
@@ -0,0 +1,52 @@
+package torequest
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestHealthCheckURL(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	for _, code := range []int{http.StatusOK, http.StatusFound, http.StatusNotFound} {
+		status = code
+		if err := requestHealthCheckURL(srv.URL); err != nil {
+			t.Errorf("expected status %d to pass health check, actual error: %v", code, err)
+		}
+	}
+	for _, code := range []int{http.StatusInternalServerError, http.StatusBadGateway} {
+		status = code
+		if err := requestHealthCheckURL(srv.URL); err == nil {
+			t.Errorf("expected status %d to fail health check, actual nil error", code)
+		}
+	}
+
+	srv.Close()
+	if err := requestHealthCheckURL(srv.URL); err == nil {
+		t.Errorf("expected unreachable URL to fail health check, actual nil error")
+	}
+}
@@ -55,8 +55,9 @@ type Package struct {
 
 type TrafficOpsReq struct {
 	Cfg     config.Cfg
-	pkgs    map[string]bool // map of packages which are installed, either already installed or newly installed by this run.
-	plugins map[string]bool // map of verified plugins
+	pkgMgr  util.PackageManager // the package manager of the cache's OS.
+	pkgs    map[string]bool     // map of packages which are installed, either already installed or newly installed by this run.
+	plugins map[string]bool     // map of verified plugins
 
 	installedPkgs map[string]struct{} // map of packages which were installed by us.
 	pluginPkgs    map[string]struct{} // map of packages
@@ -70,6 +71,7 @@ type TrafficOpsReq struct {
 	TrafficServerRestart bool   // a trafficserver restart is required
 	RemapConfigReload    bool   // remap.config should be reloaded
 	unixTimeStr          string // unix time string at program startup.
+	atsReloaded          bool   // ATS was reloaded or restarted by StartServices
 }
 
 type ConfigFile struct {
@@ -172,6 +174,7 @@ func NewTrafficOpsReq(cfg config.Cfg) *TrafficOpsReq {
 
 	return &TrafficOpsReq{
 		Cfg:           cfg,
+		pkgMgr:        util.NewPackageManager(cfg.OSFamily),
 		pkgs:          map[string]bool{},
 		plugins:       map[string]bool{},
 		configFiles:   map[string]*ConfigFile{},
@@ -242,8 +245,8 @@ func (r *TrafficOpsReq) checkPlugin(plugin string) error {
 	if r.plugins[plugin] == true {
 		return nil
 	}
-	pluginFile := filepath.Join(config.TSHome, "/libexec/trafficserver/", plugin)
-	pkgs, err := util.PackageInfo("pkg-provides", pluginFile)
+	pluginFile := filepath.Join(config.TSPluginDir, plugin)
+	pkgs, err := r.pkgMgr.Provides(pluginFile)
 	if err != nil {
 		return errors.New("unable to verify plugin " + pluginFile + ": " + err.Error())
 	}
@@ -252,7 +255,7 @@ func (r *TrafficOpsReq) checkPlugin(plugin string) error {
 		return errors.New(plugin + ": Package for plugin: " + plugin + ", is not installed.")
 	}
 
-	// TODO verify: this only checks packages that have been installed via Paramters, not any package on the system? Does this need to call util.PackageInfo("pkg-query" if it isn't in pkgs??
+	// TODO verify: this only checks packages that have been installed via Paramters, not any package on the system? Does this need to call r.pkgMgr.Query if it isn't in pkgs??
 	// TODO iterate over pkgs, because maybe one is installed that isn't the first
 	pkg := pkgs[0]
 	if _, ok := r.pkgs[pkg]; !ok {
@@ -613,6 +616,14 @@ func (r *TrafficOpsReq) CheckSystemServices() error {
 			if rc == 0 {
 				log.Infof("The %s service has been enabled\n", name)
 			}
+		} else if r.Cfg.SvcManagement == config.SystemV && r.Cfg.OSFamily == config.OSFamilyDebian {
+			// update-rc.d only enables one runlevel at a time.
+			for _, lvl := range level {
+				if _, _, err := util.ExecCommand(config.UpdateRCD, name, "enable", lvl); err != nil {
+					return errors.New("Unable to enable service " + name + " for runlevel " + lvl + ": " + err.Error())
+				}
+			}
+			log.Infof("The %s service has been enabled\n", name)
 		} else if r.Cfg.SvcManagement == config.SystemV {
 			levelValue := strings.Join(level, "")
 			_, rc, err := util.ExecCommand("/bin/chkconfig", "--level", levelValue, name, "on")
@@ -629,7 +640,7 @@ func (r *TrafficOpsReq) CheckSystemServices() error {
 	return nil
 }
 
-// IsPackageInstalled returns true/false if the named rpm package is installed.
+// IsPackageInstalled returns true/false if the named package is installed.
 // the prefix before the version is matched.
 func (r *TrafficOpsReq) IsPackageInstalled(name string) bool {
 	for k, v := range r.pkgs {
@@ -639,20 +650,20 @@ func (r *TrafficOpsReq) IsPackageInstalled(name string) bool {
 		}
 	}
 
-	log.Infof("IsPackageInstalled '%v' not found in cache, querying rpm", name)
-	pkgArr, err := util.PackageInfo("pkg-query", name)
+	log.Infof("IsPackageInstalled '%v' not found in cache, querying %v", name, r.pkgMgr.Name())
+	pkgArr, err := r.pkgMgr.Query(name)
 	if err != nil {
-		log.Errorf(`IsPackageInstalled PackageInfo(pkg-query, %v) failed, caching as not installed and returning false! Error: %v\n`, name, err.Error())
+		log.Errorf(`IsPackageInstalled Query(%v) failed, caching as not installed and returning false! Error: %v\n`, name, err.Error())
 		r.pkgs[name] = false
 		return false
 	}
 	if len(pkgArr) > 0 {
 		pkgAndVersion := pkgArr[0]
-		log.Infof("IsPackageInstalled '%v' found in rpm, adding '%v' to cache", name, pkgAndVersion)
+		log.Infof("IsPackageInstalled '%v' found in %v, adding '%v' to cache", name, r.pkgMgr.Name(), pkgAndVersion)
 		r.pkgs[pkgAndVersion] = true
 		return true
 	}
-	log.Infof("IsPackageInstalled '%v' not found in rpm, adding '%v'=false to cache", name, name)
+	log.Infof("IsPackageInstalled '%v' not found in %v, adding '%v'=false to cache", name, r.pkgMgr.Name(), name)
 	r.pkgs[name] = false
 	return false
 }
@@ -927,9 +938,9 @@ func (r *TrafficOpsReq) ProcessPackages() error {
 		var reqpkg string  // required package
 		log.Infof("Processing package %s-%s\n", pkgs[ii].Name, pkgs[ii].Version)
 		// check to see if any package by name is installed.
-		arr, err := util.PackageInfo("pkg-query", pkgs[ii].Name)
+		arr, err := r.pkgMgr.Query(pkgs[ii].Name)
 		if err != nil {
-			return errors.New("PackgeInfo pkg-query: " + err.Error())
+			return errors.New("package query: " + err.Error())
 		}
 		// go needs the ternary operator :)
 		if len(arr) == 1 {
@@ -938,7 +949,7 @@ func (r *TrafficOpsReq) ProcessPackages() error {
 			instpkg = ""
 		}
 		// check if the full package version is installed
-		fullPackage := pkgs[ii].Name + "-" + pkgs[ii].Version
+		fullPackage := r.pkgMgr.FullName(pkgs[ii].Name, pkgs[ii].Version)
 
 		if r.Cfg.RunMode == t3cutil.ModeBadAss {
 			if instpkg == fullPackage {
@@ -953,9 +964,9 @@ func (r *TrafficOpsReq) ProcessPackages() error {
 				install = append(install, fullPackage)
 				// get a list of packages that depend on this one and mark dependencies
 				// for deletion.
-				arr, err = util.PackageInfo("pkg-requires", instpkg)
+				arr, err = r.pkgMgr.Requires(instpkg)
 				if err != nil {
-					return errors.New("PackgeInfo pkg-requires: " + err.Error())
+					return errors.New("package requires query: " + err.Error())
 				}
 				if len(arr) > 0 {
 					for jj := range arr {
@@ -1006,9 +1017,9 @@ func (r *TrafficOpsReq) ProcessPackages() error {
 
 		if len(install) > 0 {
 			for ii := range install {
-				result, err := util.PackageAction("info", install[ii])
+				result, err := r.pkgMgr.Available(install[ii])
 				if err != nil || result != true {
-					return errors.New("Package " + install[ii] + " is not available to install: " + err.Error())
+					return fmt.Errorf("Package %s is not available to install: %v", install[ii], err)
 				}
 			}
 			log.Infoln("All packages available.. proceding..")
@@ -1016,11 +1027,11 @@ func (r *TrafficOpsReq) ProcessPackages() error {
 			// uninstall packages marked for removal
 			if len(install) > 0 && r.Cfg.RunMode == t3cutil.ModeBadAss {
 				for jj := range uninstall {
-					log.Infof("Uninstalling %s\n", install[jj])
-					r, err := util.PackageAction("remove", uninstall[jj])
+					log.Infof("Uninstalling %s\n", uninstall[jj])
+					removed, err := r.pkgMgr.Remove(uninstall[jj])
 					if err != nil {
 						return errors.New("Unable to uninstall " + uninstall[jj] + " : " + err.Error())
-					} else if r == true {
+					} else if removed == true {
 						log.Infof("Package %s was uninstalled\n", uninstall[jj])
 					}
 				}
@@ -1029,7 +1040,7 @@ func (r *TrafficOpsReq) ProcessPackages() error {
 				for jj := range install {
 					pkg := install[jj]
 					log.Infof("Installing %s\n", pkg)
-					result, err := util.PackageAction("install", pkg)
+					result, err := r.pkgMgr.Install(pkg)
 					if err != nil {
 						return errors.New("Unable to install " + pkg + " : " + err.Error())
 					} else if result == true {
@@ -1114,6 +1125,7 @@ func (r *TrafficOpsReq) StartServices(syncdsUpdate *UpdateStatus) error {
 			return errors.New("failed to restart trafficserver")
 		}
 		log.Infoln("trafficserver has been " + startStr + "ed")
+		r.atsReloaded = true
 		if *syncdsUpdate == UpdateTropsNeeded {
 			*syncdsUpdate = UpdateTropsSuccessful
 		}
@@ -1145,6 +1157,7 @@ func (r *TrafficOpsReq) StartServices(syncdsUpdate *UpdateStatus) error {
 				*syncdsUpdate = UpdateTropsSuccessful
 			}
 			log.Infoln("ATS 'traffic_ctl config reload' was successful")
+			r.atsReloaded = true
 		}
 		if *syncdsUpdate == UpdateTropsNeeded {
 			*syncdsUpdate = UpdateTropsSuccessful
@@ -134,6 +134,39 @@ const GitChangeNotSelf = false
 
 // makeGitCommitAll makes a git commit of all changes in atsConfigDir, including untracked files.
 func MakeGitCommitAll(atsConfigDir string, self bool, mode t3cutil.Mode, success bool) error {
+	now := time.Now() // TODO get a single consistent time when ORT starts?
+	return gitCommitAll(atsConfigDir, makeGitCommitMsg(now, self, mode, success))
+}
+
+// GitHead returns the hash of the current commit of the git repo in atsConfigDir.
+func GitHead(atsConfigDir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = atsConfigDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse error: in config dir '%v' returned err %v msg '%v'", atsConfigDir, err, string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// MakeGitRollback restores all files in atsConfigDir to their state in the
+// given commit, removing files added since, and commits the restored files.
+//
+// Any changes since the last commit are lost, so they should be committed
+// first, to keep the failed config in the history.
+func MakeGitRollback(atsConfigDir string, commit string, mode t3cutil.Mode) error {
+	cmd := exec.Command("git", "read-tree", "-u", "--reset", commit)
+	cmd.Dir = atsConfigDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git read-tree error: in config dir '%v' returned err %v msg '%v'", atsConfigDir, err, string(output))
+	}
+	return gitCommitAll(atsConfigDir, makeGitRollbackMsg(time.Now(), mode))
+}
+
+// gitCommitAll makes a git commit with the given message of all changes in
+// atsConfigDir, including untracked files. If there are no changes, no commit
+// is made.
+func gitCommitAll(atsConfigDir string, msg string) error {
 	{
 		// if there are no changes, don't do anything
 		cmd := exec.Command("git", "status", "--porcelain")
@@ -156,9 +189,6 @@ func MakeGitCommitAll(atsConfigDir string, self bool, mode t3cutil.Mode, success
 		}
 	}
 
-	now := time.Now() // TODO get a single consistent time when ORT starts?
-	msg := makeGitCommitMsg(now, self, mode, success)
-
 	{
 		cmd := exec.Command("git", "commit", "--message", msg)
 		cmd.Dir = atsConfigDir
@@ -185,3 +215,13 @@ func makeGitCommitMsg(now time.Time, self bool, mode t3cutil.Mode, success bool)
 	const sep = " "
 	return strings.Join([]string{appStr, selfStr, modeStr, successStr, timeStr}, sep)
 }
+
+func makeGitRollbackMsg(now time.Time, mode t3cutil.Mode) string {
+	const appStr = "t3c"
+	const selfStr = "self"
+	timeStr := now.UTC().Format(time.RFC3339)
+	modeStr := strings.ToLower(mode.String())
+	const rollbackStr = "rollback"
+	const sep = " "
+	return strings.Join([]string{appStr, selfStr, modeStr, rollbackStr, timeStr}, sep)
+}
@@ -0,0 +1,87 @@
+package util
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+)
+
+func TestMakeGitRollback(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir, err := ioutil.TempDir("", "t3c-apply-git")
+	if err != nil {
+		t.Fatalf("creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name string, body string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(body), 0644); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+	read := func(name string) (string, bool) {
+		body, err := ioutil.ReadFile(filepath.Join(dir, name))
+		return string(body), err == nil
+	}
+
+	write("remap.config", "old remap")
+	write("removed.config", "removed")
+	if err := EnsureConfigDirIsGitRepo(dir); err != nil {
+		t.Fatalf("creating git repo: %v", err)
+	}
+	preChange, err := GitHead(dir)
+	if err != nil {
+		t.Fatalf("getting git head: %v", err)
+	}
+
+	write("remap.config", "new remap")
+	write("added.config", "added")
+	os.Remove(filepath.Join(dir, "removed.config"))
+	if err := MakeGitCommitAll(dir, GitChangeIsSelf, t3cutil.ModeSyncDS, false); err != nil {
+		t.Fatalf("committing failed config: %v", err)
+	}
+
+	if err := MakeGitRollback(dir, preChange, t3cutil.ModeSyncDS); err != nil {
+		t.Fatalf("rolling back: %v", err)
+	}
+	if body, _ := read("remap.config"); body != "old remap" {
+		t.Errorf("expected rolled back remap.config 'old remap', actual '%s'", body)
+	}
+	if body, ok := read("removed.config"); !ok || body != "removed" {
+		t.Errorf("expected removed.config to be restored, actual exists %t body '%s'", ok, body)
+	}
+	if _, ok := read("added.config"); ok {
+		t.Errorf("expected added.config to be removed by rollback, actual exists")
+	}
+
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil || len(output) != 0 {
+		t.Errorf("expected rollback to be committed, actual git status '%s' err %v", string(output), err)
+	}
+}
@@ -0,0 +1,350 @@
+package util
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-apply/config"
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+// PackageManager queries and changes the packages installed on the cache.
+//
+// Packages are identified by their full name, which includes the version, as
+// returned by FullName and Query.
+type PackageManager interface {
+	// Name returns the name of the package manager, for logging.
+	Name() string
+
+	// FullName returns the full name of the given version of the named package.
+	FullName(name string, version string) string
+
+	// Query returns the full name of the installed package with the given name,
+	// or nil if it isn't installed.
+	Query(name string) ([]string, error)
+
+	// Requires returns the full names of the installed packages which depend on
+	// the given installed package.
+	Requires(pkg string) ([]string, error)
+
+	// Provides returns the full names of the installed packages which provide the
+	// given file.
+	Provides(file string) ([]string, error)
+
+	// Available returns whether the given package can be installed.
+	Available(pkg string) (bool, error)
+
+	// Install installs the given package, returning whether it was installed.
+	Install(pkg string) (bool, error)
+
+	// Remove removes the given package, returning whether it was removed.
+	Remove(pkg string) (bool, error)
+}
+
+// NewPackageManager returns the PackageManager of the given OS family. If the
+// family is unknown, rpm and yum are assumed.
+func NewPackageManager(family config.OSFamily) PackageManager {
+	if family == config.OSFamilyDebian {
+		return AptPackageManager{}
+	}
+	return RPMPackageManager{}
+}
+
+// RPMPackageManager manages packages with rpm and yum.
+type RPMPackageManager struct{}
+
+func (RPMPackageManager) Name() string { return "rpm" }
+
+func (RPMPackageManager) FullName(name string, version string) string {
+	return name + "-" + version
+}
+
+func (RPMPackageManager) Query(name string) ([]string, error) {
+	output, rc, err := ExecCommand("/bin/rpm", "-q", name)
+	if rc == 1 { // the package is not installed.
+		return nil, nil
+	} else if rc == 0 { // add the rpm name
+		return []string{strings.TrimSpace(string(output))}, nil
+	} else if err != nil {
+		return nil, errors.New("rpm -q '" + name + "' returned: " + err.Error())
+	}
+	return nil, nil
+}
+
+func (RPMPackageManager) Requires(pkg string) ([]string, error) {
+	output, rc, err := ExecCommand("/bin/rpm", "-q", "--whatrequires", pkg)
+	if rc == 1 { // no package reuires package 'name'
+		return nil, nil
+	} else if rc == 0 {
+		return splitPackageLines(string(output)), nil
+	} else if err != nil {
+		return nil, errors.New("rpm -q --whatrequires '" + pkg + "' returned: " + err.Error())
+	}
+	return nil, nil
+}
+
+func (RPMPackageManager) Provides(file string) ([]string, error) {
+	output, rc, err := ExecCommand("/bin/rpm", "-q", "--whatprovides", file)
+	log.Debugf("pkg-provides - name: %s, output: %s\n", file, output)
+	if rc == 1 { // no package provides 'name'
+		return nil, nil
+	} else if rc == 0 {
+		return splitPackageLines(string(output)), nil
+	} else if err != nil {
+		return nil, errors.New("rpm -q --whatprovides '" + file + "' returned: " + err.Error())
+	}
+	return nil, nil
+}
+
+func (RPMPackageManager) Available(pkg string) (bool, error) {
+	return yumAction("info", pkg)
+}
+
+func (RPMPackageManager) Install(pkg string) (bool, error) {
+	return yumAction("install", pkg)
+}
+
+func (RPMPackageManager) Remove(pkg string) (bool, error) {
+	return yumAction("remove", pkg)
+}
+
+func yumAction(cmd string, pkg string) (bool, error) {
+	_, rc, err := ExecCommand("/usr/bin/yum", cmd, "-y", pkg)
+	if rc == 0 {
+		return true, nil
+	}
+	return false, err
+}
+
+// AptPackageManager manages packages with dpkg and apt.
+//
+// Installed packages are held with apt-mark, so they stay at the version in
+// Traffic Ops when the rest of the system is upgraded.
+type AptPackageManager struct{}
+
+// aptEnv is the environment apt is run with, to never prompt, and to keep
+// existing config files, which t3c manages, when packages are installed.
+var aptEnv = []string{"DEBIAN_FRONTEND=noninteractive"}
+
+// aptInstallOpts are the apt-get options to install packages with.
+var aptInstallOpts = []string{"-y", "--allow-downgrades", "--allow-change-held-packages", "-o", "Dpkg::Options::=--force-confold"}
+
+// dpkgQueryFormat is the dpkg-query format of a package: its status
+// abbreviation, full name, and dependencies.
+const dpkgQueryFormat = `${db:Status-Abbrev}\t${Package}=${Version}\t${Pre-Depends}, ${Depends}\n`
+
+func (AptPackageManager) Name() string { return "apt" }
+
+func (AptPackageManager) FullName(name string, version string) string {
+	return name + "=" + version
+}
+
+func (AptPackageManager) Query(name string) ([]string, error) {
+	output, rc, err := ExecCommand("/usr/bin/dpkg-query", "-W", "-f="+dpkgQueryFormat, name)
+	if rc == 1 { // the package is unknown.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.New("dpkg-query -W '" + name + "' returned: " + err.Error())
+	}
+	pkgs := []string{}
+	for _, pkg := range parseDpkgQuery(string(output)) {
+		if pkg.Installed {
+			pkgs = append(pkgs, pkg.FullName)
+		}
+	}
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+	return pkgs, nil
+}
+
+func (AptPackageManager) Requires(pkg string) ([]string, error) {
+	output, _, err := ExecCommand("/usr/bin/dpkg-query", "-W", "-f="+dpkgQueryFormat)
+	if err != nil {
+		return nil, errors.New("dpkg-query -W returned: " + err.Error())
+	}
+	name := aptPackageName(pkg)
+	requires := []string{}
+	for _, installed := range parseDpkgQuery(string(output)) {
+		if !installed.Installed {
+			continue
+		}
+		for _, dep := range installed.Depends {
+			if dep == name {
+				requires = append(requires, installed.FullName)
+				break
+			}
+		}
+	}
+	if len(requires) == 0 {
+		return nil, nil
+	}
+	return requires, nil
+}
+
+func (apt AptPackageManager) Provides(file string) ([]string, error) {
+	output, rc, err := ExecCommand("/usr/bin/dpkg-query", "-S", file)
+	if rc == 1 { // no package provides the file.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.New("dpkg-query -S '" + file + "' returned: " + err.Error())
+	}
+	pkgs := []string{}
+	for _, name := range parseDpkgSearch(string(output)) {
+		installed, err := apt.Query(name)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, installed...)
+	}
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+	return pkgs, nil
+}
+
+func (AptPackageManager) Available(pkg string) (bool, error) {
+	_, rc, err := ExecCommand("/usr/bin/apt-cache", "show", pkg)
+	if rc == 0 {
+		return true, nil
+	}
+	return false, err
+}
+
+func (AptPackageManager) Install(pkg string) (bool, error) {
+	name := aptPackageName(pkg)
+	args := append([]string{"install"}, aptInstallOpts...)
+	if _, rc, err := ExecCommandEnv(aptEnv, "/usr/bin/apt-get", append(args, pkg)...); rc != 0 {
+		return false, err
+	}
+	if _, _, err := ExecCommand("/usr/bin/apt-mark", "hold", name); err != nil {
+		return true, errors.New("holding package '" + name + "': " + err.Error())
+	}
+	return true, nil
+}
+
+func (AptPackageManager) Remove(pkg string) (bool, error) {
+	name := aptPackageName(pkg)
+	if _, _, err := ExecCommand("/usr/bin/apt-mark", "unhold", name); err != nil {
+		log.Warnln("unholding package '" + name + "' before removing it: " + err.Error())
+	}
+	if _, rc, err := ExecCommandEnv(aptEnv, "/usr/bin/apt-get", "remove", "-y", name); rc != 0 {
+		return false, err
+	}
+	return true, nil
+}
+
+// aptPackageName returns the name of the given full package name, without
+// its version.
+func aptPackageName(pkg string) string {
+	if i := strings.Index(pkg, "="); i >= 0 {
+		return pkg[:i]
+	}
+	return pkg
+}
+
+// dpkgPackage is a package listed by dpkg-query with dpkgQueryFormat.
+type dpkgPackage struct {
+	FullName  string
+	Installed bool
+	Depends   []string
+}
+
+// parseDpkgQuery parses the output of dpkg-query with dpkgQueryFormat.
+func parseDpkgQuery(output string) []dpkgPackage {
+	pkgs := []dpkgPackage{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		pkg := dpkgPackage{
+			FullName: strings.TrimSpace(fields[1]),
+			// the second letter of the status abbreviation is the current state,
+			// which is 'i' if the package is installed.
+			Installed: len(fields[0]) >= 2 && fields[0][1] == 'i',
+		}
+		if len(fields) > 2 {
+			pkg.Depends = parseDpkgDepends(fields[2])
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs
+}
+
+// parseDpkgDepends returns the package names in the given dpkg dependency
+// list, such as 'libc6 (>= 2.14), libssl1.1 | libssl3, perl:any'.
+func parseDpkgDepends(depends string) []string {
+	names := []string{}
+	for _, dep := range strings.FieldsFunc(depends, func(r rune) bool { return r == ',' || r == '|' }) {
+		fields := strings.Fields(dep)
+		if len(fields) == 0 {
+			continue
+		}
+		name := fields[0]
+		if i := strings.Index(name, ":"); i >= 0 {
+			name = name[:i] // remove any architecture qualifier
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// parseDpkgSearch returns the package names in the output of dpkg-query -S,
+// which lists each matching file as 'pkg1, pkg2: /path'.
+func parseDpkgSearch(output string) []string {
+	names := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		i := strings.Index(line, ": ")
+		if i < 0 || strings.HasPrefix(line, "diversion by") {
+			continue
+		}
+		for _, name := range strings.Split(line[:i], ",") {
+			name = strings.TrimSpace(name)
+			if j := strings.Index(name, ":"); j >= 0 {
+				name = name[:j] // remove any architecture qualifier
+			}
+			if name != "" && !stringsContain(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// splitPackageLines returns the trimmed lines of the given package list.
+func splitPackageLines(output string) []string {
+	pkgs := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		pkgs = append(pkgs, strings.TrimSpace(line))
+	}
+	return pkgs
+}
+
+func stringsContain(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
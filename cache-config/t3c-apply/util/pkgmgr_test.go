@@ -0,0 +1,62 @@
+package util
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDpkgQuery(t *testing.T) {
+	output := "ii \ttrafficserver=9.1.2-1\tlibc6 (>= 2.14), adduser, libssl3 | libssl1.1, perl:any\n" +
+		"rc \told-plugin=1.0\t, trafficserver (= 9.0.0-1)\n" +
+		"ii \tastats-plugin=2.0\t, trafficserver\n" +
+		"\n"
+	expected := []dpkgPackage{
+		{FullName: "trafficserver=9.1.2-1", Installed: true, Depends: []string{"libc6", "adduser", "libssl3", "libssl1.1", "perl"}},
+		{FullName: "old-plugin=1.0", Installed: false, Depends: []string{"trafficserver"}},
+		{FullName: "astats-plugin=2.0", Installed: true, Depends: []string{"trafficserver"}},
+	}
+	if actual := parseDpkgQuery(output); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, actual %+v", expected, actual)
+	}
+}
+
+func TestParseDpkgSearch(t *testing.T) {
+	output := "trafficserver: /usr/lib/trafficserver/modules/header_rewrite.so\n" +
+		"diversion by foo from: /usr/lib/trafficserver/modules/header_rewrite.so\n" +
+		"ats-plugins:amd64, trafficserver: /usr/lib/trafficserver/modules/header_rewrite.so\n"
+	expected := []string{"trafficserver", "ats-plugins"}
+	if actual := parseDpkgSearch(output); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, actual %+v", expected, actual)
+	}
+}
+
+func TestPackageManagerFullName(t *testing.T) {
+	if actual := (RPMPackageManager{}).FullName("trafficserver", "9.1.2-1.el8"); actual != "trafficserver-9.1.2-1.el8" {
+		t.Errorf("expected rpm full name 'trafficserver-9.1.2-1.el8', actual '%s'", actual)
+	}
+	if actual := (AptPackageManager{}).FullName("trafficserver", "9.1.2-1"); actual != "trafficserver=9.1.2-1" {
+		t.Errorf("expected apt full name 'trafficserver=9.1.2-1', actual '%s'", actual)
+	}
+	if actual := aptPackageName("trafficserver=9.1.2-1"); actual != "trafficserver" {
+		t.Errorf("expected apt package name 'trafficserver', actual '%s'", actual)
+	}
+}
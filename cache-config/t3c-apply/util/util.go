@@ -88,12 +88,12 @@ func (f *FileLock) GetLock(lockFile string) bool {
 	return f.is_locked
 }
 
-// Releases a file lock and exits with the given status code.
-func (f *FileLock) UnlockAndExit(code int) {
+// Releases a file lock, if it's held.
+func (f *FileLock) Unlock() {
 	if f.is_locked {
 		f.f_lock.Unlock()
+		f.is_locked = false
 	}
-	os.Exit(code)
 }
 
 func DirectoryExists(dir string) (bool, os.FileInfo) {
@@ -105,9 +105,18 @@ func DirectoryExists(dir string) (bool, os.FileInfo) {
 }
 
 func ExecCommand(fullCommand string, arg ...string) ([]byte, int, error) {
+	return ExecCommandEnv(nil, fullCommand, arg...)
+}
+
+// ExecCommandEnv is like ExecCommand, but runs the command with the given
+// variables added to the environment.
+func ExecCommandEnv(env []string, fullCommand string, arg ...string) ([]byte, int, error) {
 	var outbuf bytes.Buffer
 	var errbuf bytes.Buffer
 	cmd := exec.Command(fullCommand, arg...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	cmd.Stdout = &outbuf
 	cmd.Stderr = &errbuf
 	err := cmd.Run()
@@ -220,101 +229,6 @@ func WriteFileWithOwner(fn string, data []byte, uid *int, gid *int, perm os.File
 	return c, nil
 }
 
-func PackageAction(cmdstr string, name string) (bool, error) {
-	var rc int = -1
-	var err error = nil
-	var result bool = false
-
-	switch cmdstr {
-	case "info":
-		_, rc, err = ExecCommand("/usr/bin/yum", "info", "-y", name)
-	case "install":
-		_, rc, err = ExecCommand("/usr/bin/yum", "install", "-y", name)
-	case "remove":
-		_, rc, err = ExecCommand("/usr/bin/yum", "remove", "-y", name)
-	}
-
-	if rc == 0 {
-		result = true
-		err = nil
-	}
-	return result, err
-}
-
-// runs the rpm command.
-// if the return code from rpm == 0, then a valid package list is returned.
-//
-// if the return code is 1, the the 'name' queried for is not part of a
-//   package or is not installed.
-//
-// otherwise, if the return code is not 0 or 1 and error is set, a general
-// rpm command execution error is assumed and the error is returned.
-func PackageInfo(cmdstr string, name string) ([]string, error) {
-	var result []string
-	switch cmdstr {
-	case "cfg-files": // returns a list of the package configuration files.
-		output, rc, err := ExecCommand("/bin/rpm", "-q", "-c", name)
-		if rc == 1 { // rpm package for 'name' was not found.
-			return nil, nil
-		} else if rc == 0 { // add the package name the file belongs to.
-			log.Debugf("output from cfg-files query: %s\n", string(output))
-			files := strings.Split(string(output), "\n")
-			for ii := range files {
-				result = append(result, strings.TrimSpace(files[ii]))
-			}
-			log.Debugf("result length: %d, result: %s\n", len(result), string(output))
-		} else if err != nil {
-			return nil, err
-		}
-	case "file-query": // returns the rpm name that owns the file 'name'
-		output, rc, err := ExecCommand("/bin/rpm", "-q", "-f", name)
-		if rc == 1 { // file is not part of any package.
-			return nil, nil
-		} else if rc == 0 { // add the package name the file belongs to.
-			log.Debugf("output from file-query: %s\n", string(output))
-			result = append(result, string(strings.TrimSpace(string(output))))
-			log.Debugf("result length: %d, result: %s\n", len(result), string(output))
-		} else if err != nil {
-			return nil, err
-		}
-	case "pkg-provides": // returns the package name that provides 'name'
-		output, rc, err := ExecCommand("/bin/rpm", "-q", "--whatprovides", name)
-		log.Debugf("pkg-provides - name: %s, output: %s\n", name, output)
-		if rc == 1 { // no package provides 'name'
-			return nil, nil
-		} else if rc == 0 {
-			pkgs := strings.Split(string(output), "\n")
-			for ii := range pkgs {
-				result = append(result, strings.TrimSpace(pkgs[ii]))
-			}
-		} else if err != nil {
-			return nil, errors.New("rpm -q --whatprovides '" + name + "' returned: " + err.Error())
-		}
-	case "pkg-query": // returns the package name for 'name'.
-		output, rc, err := ExecCommand("/bin/rpm", "-q", name)
-		if rc == 1 { // the package is not installed.
-			return nil, nil
-		} else if rc == 0 { // add the rpm name
-			result = append(result, string(strings.TrimSpace(string(output))))
-		} else if err != nil {
-			return nil, errors.New("rpm -q '" + name + "' returned: " + err.Error())
-		}
-	case "pkg-requires": // returns a list of packages that requires package 'name'
-		output, rc, err := ExecCommand("/bin/rpm", "-q", "--whatrequires", name)
-		if rc == 1 { // no package reuires package 'name'
-			return nil, nil
-		} else if rc == 0 {
-			pkgs := strings.Split(string(output), "\n")
-			for ii := range pkgs {
-				result = append(result, strings.TrimSpace(pkgs[ii]))
-			}
-		} else if err != nil {
-			return nil, errors.New("rpm -q --whatrequires '" + name + "' returned: " + err.Error())
-		}
-	}
-	return result, nil
-}
-
 func RandomDuration(max time.Duration) time.Duration {
 	rand.Seed(time.Now().UnixNano())
 	return time.Duration(rand.Int63n(int64(max)))
@@ -27,7 +27,7 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
 	"github.com/kylelemons/godebug/diff"
 	"github.com/pborman/getopt/v2"
 )
@@ -65,16 +65,16 @@ func main() {
 	}
 
 	fileALines := strings.Split(string(fileA), "\n")
-	fileALines = t3cutil.UnencodeFilter(fileALines)
-	fileALines = t3cutil.CommentsFilter(fileALines)
+	fileALines = atscfg.UnencodeFilter(fileALines)
+	fileALines = atscfg.CommentsFilter(fileALines)
 	fileA = strings.Join(fileALines, "\n")
-	fileA = t3cutil.NewLineFilter(fileA)
+	fileA = atscfg.NewLineFilter(fileA)
 
 	fileBLines := strings.Split(string(fileB), "\n")
-	fileBLines = t3cutil.UnencodeFilter(fileBLines)
-	fileBLines = t3cutil.CommentsFilter(fileBLines)
+	fileBLines = atscfg.UnencodeFilter(fileBLines)
+	fileBLines = atscfg.CommentsFilter(fileBLines)
 	fileB = strings.Join(fileBLines, "\n")
-	fileB = t3cutil.NewLineFilter(fileB)
+	fileB = atscfg.NewLineFilter(fileB)
 
 	if fileA != fileB {
 		match := regexp.MustCompile(`(?m)^\+.*|^-.*`)
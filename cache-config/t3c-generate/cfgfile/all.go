@@ -43,29 +43,20 @@ func GetAllConfigs(
 		return nil, errors.New("server hostname is nil")
 	}
 
-	configFiles, warnings, err := MakeConfigFilesList(toData, cfg.Dir)
-	logWarnings("generating config files list: ", warnings)
-	if err != nil {
-		return nil, errors.New("creating meta: " + err.Error())
-	}
-
 	genTime := time.Now()
 	hdrCommentTxt := makeHeaderComment(*toData.Server.HostName, appVersion, toData.TrafficOpsURL, toData.TrafficOpsAddresses, genTime)
 
+	configs, warnings, err := atscfg.MakeConfigFiles(toData, hdrCommentTxt, configFilesOpts(cfg))
+	logWarnings("", warnings)
+	if err != nil {
+		return nil, err
+	}
+
 	hasSSLMultiCertConfig := false
-	configs := []t3cutil.ATSConfigFile{}
-	for _, fi := range configFiles {
-		if cfg.RevalOnly && fi.Name != atscfg.RegexRevalidateFileName {
-			continue
-		}
-		txt, contentType, lineComment, err := GetConfigFile(toData, fi, hdrCommentTxt, cfg)
-		if err != nil {
-			return nil, errors.New("getting config file '" + fi.Name + "': " + err.Error())
-		}
+	for _, fi := range configs {
 		if fi.Name == atscfg.SSLMultiCertConfigFileName {
 			hasSSLMultiCertConfig = true
 		}
-		configs = append(configs, t3cutil.ATSConfigFile{Name: fi.Name, Path: fi.Path, Text: txt, ContentType: contentType, LineComment: lineComment})
 	}
 
 	if hasSSLMultiCertConfig {
@@ -79,6 +70,20 @@ func GetAllConfigs(
 	return configs, nil
 }
 
+// configFilesOpts returns the options to generate config files with, from the
+// given t3c-generate config.
+func configFilesOpts(cfg config.Cfg) atscfg.ConfigFilesOpts {
+	return atscfg.ConfigFilesOpts{
+		Dir:                cfg.Dir,
+		RevalOnly:          cfg.RevalOnly,
+		ViaRelease:         cfg.ViaRelease,
+		SetDNSLocalBind:    cfg.SetDNSLocalBind,
+		ParentComments:     cfg.ParentComments,
+		DefaultEnableH2:    cfg.DefaultEnableH2,
+		DefaultTLSVersions: cfg.DefaultTLSVersions,
+	}
+}
+
 const HdrConfigFilePath = "Path"
 const HdrLineComment = "Line-Comment"
 
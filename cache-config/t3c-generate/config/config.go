@@ -25,6 +25,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-atscfg"
 	"github.com/apache/trafficcontrol/lib/go-log"
@@ -42,6 +43,13 @@ const ExitCodeErrGeneric = 1
 const ExitCodeNotFound = 104
 const ExitCodeBadRequest = 100
 
+// DefaultPluginDir is the directory of external plugins, if none is given.
+const DefaultPluginDir = "/etc/trafficcontrol-cache-config/t3c-generate/plugins"
+
+// DefaultPluginTimeout is how long each external plugin may run, if no
+// timeout is given.
+const DefaultPluginTimeout = 10 * time.Second
+
 var ErrNotFound = errors.New("not found")
 var ErrBadRequest = errors.New("bad request")
 
@@ -58,6 +66,8 @@ type Cfg struct {
 	ParentComments     bool
 	DefaultEnableH2    bool
 	DefaultTLSVersions []atscfg.TLSVersion
+	PluginDir          string
+	PluginTimeout      time.Duration
 }
 
 func (cfg Cfg) ErrorLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationErr) }
@@ -80,6 +90,8 @@ func GetCfg() (Cfg, error) {
 	defaultTLSVersionsStr := getopt.StringLong("default-client-tls-versions", 'T', "", "Comma-delimited list of default TLS versions for Delivery Services with no Parameter, e.g. '--default-tls-versions=1.1,1.2,1.3'. If omitted, all versions are enabled.")
 	verbosePtr := getopt.CounterLong("verbose", 'v', `Log verbosity. Logging is output to stderr. By default, errors are logged. To log warnings, pass '-v'. To log info, pass '-vv'. To omit error logging, see '-s'`)
 	silentPtr := getopt.BoolLong("silent", 's', `Silent. Errors are not logged, and the 'verbose' flag is ignored. If a fatal error occurs, the return code will be non-zero but no text will be output to stderr`)
+	pluginDir := getopt.StringLong("plugin-dir", 0, DefaultPluginDir, "Directory of external plugin executables, run in name order after compiled plugins to modify the generated files. If empty or it doesn't exist, no external plugins are run. Default is "+DefaultPluginDir)
+	pluginTimeoutSecs := getopt.IntLong("plugin-timeout", 0, int(DefaultPluginTimeout/time.Second), "[seconds] how long each external plugin may run before it's killed and its changes ignored, default is 10")
 
 	getopt.Parse()
 
@@ -90,7 +102,11 @@ func GetCfg() (Cfg, error) {
 		getopt.PrintUsage(os.Stdout)
 		os.Exit(0)
 	} else if *listPlugins {
-		return Cfg{ListPlugins: true}, nil
+		return Cfg{ListPlugins: true, PluginDir: *pluginDir}, nil
+	}
+
+	if *pluginTimeoutSecs <= 0 {
+		return Cfg{}, errors.New("plugin timeout must be positive")
 	}
 
 	logLocationError := log.LogLocationStderr
@@ -142,6 +158,8 @@ func GetCfg() (Cfg, error) {
 		ParentComments:     !(*disableParentConfigComments),
 		DefaultEnableH2:    *defaultEnableH2,
 		DefaultTLSVersions: defaultTLSVersions,
+		PluginDir:          *pluginDir,
+		PluginTimeout:      time.Duration(*pluginTimeoutSecs) * time.Second,
 	}
 	if err := log.InitCfg(cfg); err != nil {
 		return Cfg{}, errors.New("Initializing loggers: " + err.Error() + "\n")
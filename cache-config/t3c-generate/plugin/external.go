@@ -0,0 +1,186 @@
+package plugin
+
+/*
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+// ExternalProtocolVersion is the version of the protocol t3c-generate uses
+// to talk to external plugins. It's incremented when the protocol changes
+// incompatibly.
+const ExternalProtocolVersion = 1
+
+// ExternalInput is the JSON t3c-generate writes to the stdin of an external
+// plugin.
+type ExternalInput struct {
+	// Version is the ExternalProtocolVersion.
+	Version int `json:"version"`
+	// Dir is the ATS config directory t3c-generate was given.
+	Dir string `json:"dir"`
+	// RevalOnly is whether t3c-generate is only generating revalidation files.
+	RevalOnly bool `json:"revalidateOnly"`
+	// TOData is the Traffic Ops data the files were generated from, as given
+	// to t3c-generate.
+	TOData *t3cutil.ConfigData `json:"toData"`
+	// Files are the files generated so far, including changes made by
+	// plugins which ran before.
+	Files []t3cutil.ATSConfigFile `json:"files"`
+}
+
+// ListExternal returns the names of the external plugins in the given
+// directory, in the order they're run: every executable regular file whose
+// name doesn't start with a '.', sorted by name. If dir is empty or doesn't
+// exist, there are no external plugins.
+func ListExternal(dir string) ([]string, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.New("reading external plugin directory: " + err.Error())
+	}
+	names := []string{}
+	for _, info := range infos {
+		if strings.HasPrefix(info.Name(), ".") || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// modifyFilesExternal runs each external plugin in dir in turn, returning the
+// files as modified by all of them.
+//
+// A plugin which fails, times out, or writes invalid output is logged and
+// skipped, so the next plugin gets the files as they were before it ran.
+func modifyFilesExternal(dir string, timeout time.Duration, d ModifyFilesData) []t3cutil.ATSConfigFile {
+	names, err := ListExternal(dir)
+	if err != nil {
+		log.Errorln("plugins.ModifyFiles: " + err.Error() + ", not running external plugins")
+		return d.Files
+	}
+	log.Infof("plugins.ModifyFiles calling %+v external plugins\n", len(names))
+	for _, name := range names {
+		log.Infoln("plugins.ModifyFiles plugging external " + name)
+		files, err := runExternal(filepath.Join(dir, name), timeout, d)
+		if err != nil {
+			log.Errorln("external plugin '" + name + "' failed, ignoring its changes: " + err.Error())
+			continue
+		}
+		d.Files = files
+	}
+	return d.Files
+}
+
+// runExternal runs the external plugin at the given path, and returns the
+// files with its changes.
+func runExternal(path string, timeout time.Duration, d ModifyFilesData) ([]t3cutil.ATSConfigFile, error) {
+	input, err := json.Marshal(ExternalInput{
+		Version:   ExternalProtocolVersion,
+		Dir:       d.Cfg.Dir,
+		RevalOnly: d.Cfg.RevalOnly,
+		TOData:    d.TOData,
+		Files:     d.Files,
+	})
+	if err != nil {
+		return nil, errors.New("encoding input: " + err.Error())
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// The plugin is run in its own process group, so any processes it starts
+	// are killed with it if it times out, rather than holding its output open.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.New("starting: " + err.Error())
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	timedOut := false
+	select {
+	case err = <-done:
+	case <-time.After(timeout):
+		timedOut = true
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		err = <-done
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		log.Warnln("external plugin '" + filepath.Base(path) + "' stderr: " + msg)
+	}
+	if timedOut {
+		return nil, errors.New("timed out after " + timeout.String())
+	}
+	if err != nil {
+		return nil, errors.New("running: " + err.Error())
+	}
+
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return d.Files, nil
+	}
+	changed := []t3cutil.ATSConfigFile{}
+	if err := json.Unmarshal(stdout.Bytes(), &changed); err != nil {
+		return nil, errors.New("decoding output: " + err.Error())
+	}
+	for _, fi := range changed {
+		if fi.Name == "" || fi.Path == "" {
+			return nil, errors.New("output has a file without a name or path")
+		}
+	}
+	return mergeFiles(d.Files, changed), nil
+}
+
+// mergeFiles returns files with each of the changed files replacing the file
+// of the same path and name, or added if there is none.
+func mergeFiles(files []t3cutil.ATSConfigFile, changed []t3cutil.ATSConfigFile) []t3cutil.ATSConfigFile {
+	merged := make([]t3cutil.ATSConfigFile, len(files))
+	copy(merged, files)
+	indexes := make(map[string]int, len(merged))
+	for i, fi := range merged {
+		indexes[filepath.Join(fi.Path, fi.Name)] = i
+	}
+	for _, fi := range changed {
+		key := filepath.Join(fi.Path, fi.Name)
+		if i, ok := indexes[key]; ok {
+			merged[i] = fi
+			continue
+		}
+		indexes[key] = len(merged)
+		merged = append(merged, fi)
+	}
+	return merged
+}
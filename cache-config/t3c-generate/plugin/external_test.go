@@ -0,0 +1,174 @@
+package plugin
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-generate/config"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func writeExternalPlugin(t *testing.T, dir string, name string, script string, mode os.FileMode) {
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExternalPlugins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "t3c-generate-plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// plugins run in name order, and failing ones are skipped.
+	writeExternalPlugin(t, dir, "10-add", `cat > /dev/null
+printf '%s\n' '[{"name":"added.config","path":"/etc/trafficserver","content_type":"text/plain","line_comment":"#","text":"added\n"}]'
+`, 0755)
+	writeExternalPlugin(t, dir, "20-fail", `cat > /dev/null
+printf '%s\n' '[{"name":"remap.config","path":"/etc/trafficserver","text":"broken\n"}]'
+echo 'failing' >&2
+exit 1
+`, 0755)
+	writeExternalPlugin(t, dir, "30-invalid", `cat > /dev/null
+echo 'not json'
+`, 0755)
+	writeExternalPlugin(t, dir, "40-modify", `grep -q '"name":"added.config"' || exit 1
+printf '%s\n' '[{"name":"remap.config","path":"/etc/trafficserver","content_type":"text/plain","line_comment":"#","text":"modified\n"}]'
+`, 0755)
+	writeExternalPlugin(t, dir, "50-unchanged", `cat > /dev/null
+`, 0755)
+	writeExternalPlugin(t, dir, "60-slow", `cat > /dev/null
+sleep 5
+printf '%s\n' '[{"name":"slow.config","path":"/etc/trafficserver","text":"slow\n"}]'
+`, 0755)
+	writeExternalPlugin(t, dir, "70-not-executable", `cat > /dev/null
+printf '%s\n' '[{"name":"nonexec.config","path":"/etc/trafficserver","text":"nonexec\n"}]'
+`, 0644)
+	writeExternalPlugin(t, dir, ".hidden", `cat > /dev/null
+printf '%s\n' '[{"name":"hidden.config","path":"/etc/trafficserver","text":"hidden\n"}]'
+`, 0755)
+
+	names, err := ListExternal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"10-add", "20-fail", "30-invalid", "40-modify", "50-unchanged", "60-slow"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected external plugins %v, actual %v", expected, names)
+	}
+
+	plugins := Get(config.Cfg{PluginDir: dir, PluginTimeout: 500 * time.Millisecond})
+	files := plugins.ModifyFiles(ModifyFilesData{
+		TOData: &t3cutil.ConfigData{Server: &atscfg.Server{}},
+		Files: []t3cutil.ATSConfigFile{
+			{Name: "remap.config", Path: "/etc/trafficserver", ContentType: "text/plain", LineComment: "#", Text: "original\n"},
+		},
+	})
+	expected := []t3cutil.ATSConfigFile{
+		{Name: "remap.config", Path: "/etc/trafficserver", ContentType: "text/plain", LineComment: "#", Text: "modified\n"},
+		{Name: "added.config", Path: "/etc/trafficserver", ContentType: "text/plain", LineComment: "#", Text: "added\n"},
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected files %+v, actual %+v", expected, files)
+	}
+}
+
+func TestListExternalMissingDir(t *testing.T) {
+	names, err := ListExternal(filepath.Join(os.TempDir(), "t3c-generate-plugins-nonexistent"))
+	if err != nil {
+		t.Errorf("expected a missing directory to have no plugins, actual error: %v", err)
+	}
+	if len(names) != 0 {
+		t.Errorf("expected a missing directory to have no plugins, actual %v", names)
+	}
+}
+
+func TestRunExternalInput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "t3c-generate-plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the plugin saves its input next to itself, and changes nothing.
+	writeExternalPlugin(t, dir, "save-input", `cat > "$(dirname "$0")/input.json"
+`, 0755)
+	server := &atscfg.Server{}
+	server.HostName = util.StrPtr("edge")
+	files, err := runExternal(filepath.Join(dir, "save-input"), time.Second, ModifyFilesData{
+		Cfg:    config.Cfg{Dir: "/etc/trafficserver", RevalOnly: true},
+		TOData: &t3cutil.ConfigData{Server: server},
+		Files:  []t3cutil.ATSConfigFile{{Name: "a", Path: "/x"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected a plugin with no output to not change the files, actual %+v", files)
+	}
+	bts, err := ioutil.ReadFile(filepath.Join(dir, "input.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := ExternalInput{}
+	if err := json.Unmarshal(bts, &input); err != nil {
+		t.Fatalf("expected the plugin input to be JSON, actual error: %v, input: %s", err, string(bts))
+	}
+	if input.Version != ExternalProtocolVersion || input.Dir != "/etc/trafficserver" || !input.RevalOnly {
+		t.Errorf("expected input version %d, dir /etc/trafficserver, and revalidate only, actual %+v", ExternalProtocolVersion, input)
+	}
+	if len(input.Files) != 1 || input.Files[0].Name != "a" {
+		t.Errorf("expected input files [a], actual %+v", input.Files)
+	}
+	if input.TOData == nil || input.TOData.Server == nil || input.TOData.Server.HostName == nil || *input.TOData.Server.HostName != "edge" {
+		t.Errorf("expected input TO data with server 'edge', actual %+v", input.TOData)
+	}
+}
+
+func TestMergeFiles(t *testing.T) {
+	files := []t3cutil.ATSConfigFile{
+		{Name: "a", Path: "/x", Text: "a"},
+		{Name: "b", Path: "/x", Text: "b"},
+	}
+	merged := mergeFiles(files, []t3cutil.ATSConfigFile{
+		{Name: "b", Path: "/x", Text: "b2"},
+		{Name: "a", Path: "/y", Text: "ya"},
+	})
+	expected := []t3cutil.ATSConfigFile{
+		{Name: "a", Path: "/x", Text: "a"},
+		{Name: "b", Path: "/x", Text: "b2"},
+		{Name: "a", Path: "/y", Text: "ya"},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected merged files %+v, actual %+v", expected, merged)
+	}
+	if files[1].Text != "b" {
+		t.Error("expected merging to not change the original files")
+	}
+}
@@ -39,7 +39,7 @@ func List() []string {
 
 func Get(appCfg config.Cfg) Plugins {
 	pluginSlice := getAll()
-	return plugins{slice: pluginSlice}
+	return plugins{slice: pluginSlice, externalDir: appCfg.PluginDir, externalTimeout: appCfg.PluginTimeout}
 }
 
 func getAll() pluginsSlice {
@@ -103,6 +103,10 @@ type plugins struct {
 	slice pluginsSlice
 	cfg   map[string]interface{}
 	ctx   map[string]*interface{}
+	// externalDir is the directory of external plugins, which are run after
+	// the compiled plugins, each for at most externalTimeout.
+	externalDir     string
+	externalTimeout time.Duration
 }
 
 type pluginsSlice []pluginObj
@@ -124,6 +128,7 @@ func (ps plugins) OnStartup(d StartupData) {
 }
 
 // ModifyFiles returns a slice of config files to use. May return d.Files unmodified, or may add, remove, or modify files in d.Files.
+// Compiled plugins are called first, then external plugins.
 func (ps plugins) ModifyFiles(d ModifyFilesData) []t3cutil.ATSConfigFile {
 	log.Infof("plugins.ModifyFiles calling %+v plugins\n", len(ps.slice))
 	for _, p := range ps.slice {
@@ -134,5 +139,8 @@ func (ps plugins) ModifyFiles(d ModifyFilesData) []t3cutil.ATSConfigFile {
 		log.Infoln("plugins.ModifyFiles plugging " + p.name)
 		d.Files = p.funcs.modifyFiles(d)
 	}
+	if ps.externalDir != "" {
+		d.Files = modifyFilesExternal(ps.externalDir, ps.externalTimeout, d)
+	}
 	return d.Files
 }
@@ -44,6 +44,14 @@ func main() {
 
 	if cfg.ListPlugins {
 		fmt.Println(strings.Join(plugin.List(), "\n"))
+		externalPlugins, err := plugin.ListExternal(cfg.PluginDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Listing external plugins: "+err.Error())
+			os.Exit(config.ExitCodeErrGeneric)
+		}
+		for _, name := range externalPlugins {
+			fmt.Println(name + " (external)")
+		}
 		os.Exit(0)
 	}
 
@@ -21,6 +21,7 @@ package toreq
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/url"
@@ -268,15 +269,28 @@ func (cl *TOClient) GetServerProfileParameters(profileName string, reqHdr http.H
 	return serverProfileParameters, reqInf, nil
 }
 
-// GetCDNDeliveryServices returns the data, the Traffic Ops address, and any error.
-func (cl *TOClient) GetCDNDeliveryServices(cdnID int, reqHdr http.Header) ([]atscfg.DeliveryService, toclientlib.ReqInf, error) {
+// GetCDNDeliveryServices returns the data, the Delivery Service TLS policies, the Traffic Ops address, and any error.
+//
+// The Delivery Services are requested from API 4.0 if Traffic Ops supports it, because only 4.0 has the TLS policy fields.
+// If Traffic Ops doesn't support 4.0, they're requested from API 3 and the returned TLS policies are nil.
+func (cl *TOClient) GetCDNDeliveryServices(cdnID int, reqHdr http.Header) ([]atscfg.DeliveryService, map[tc.DeliveryServiceName]atscfg.DeliveryServiceTLSPolicy, toclientlib.ReqInf, error) {
 	if cl.C == nil {
-		return cl.Old.GetCDNDeliveryServices(cdnID)
+		dses, reqInf, err := cl.Old.GetCDNDeliveryServices(cdnID)
+		return dses, nil, reqInf, err
 	}
 
+	dsesV4, reqInf, supported, err := cl.getCDNDeliveryServicesV40(cdnID, reqHdr)
+	if err != nil {
+		return nil, nil, reqInf, err
+	}
+	if supported {
+		return atscfg.V40ToDeliveryServices(dsesV4), atscfg.ToDeliveryServiceTLSPolicies(dsesV4), reqInf, nil
+	}
+	log.Infoln("Traffic Ops doesn't support API 4.0 delivery services, falling back to API 3 without TLS policies")
+
 	deliveryServices := []atscfg.DeliveryService{}
-	reqInf := toclientlib.ReqInf{}
-	err := torequtil.GetRetry(cl.NumRetries, "cdn_"+strconv.Itoa(cdnID)+"_deliveryservices", &deliveryServices, func(obj interface{}) error {
+	reqInf = toclientlib.ReqInf{}
+	err = torequtil.GetRetry(cl.NumRetries, "cdn_"+strconv.Itoa(cdnID)+"_deliveryservices", &deliveryServices, func(obj interface{}) error {
 		params := url.Values{}
 		params.Set("cdn", strconv.Itoa(cdnID))
 		toDSes, toReqInf, err := cl.C.GetDeliveryServicesV30WithHdr(reqHdr, params)
@@ -289,9 +303,57 @@ func (cl *TOClient) GetCDNDeliveryServices(cdnID int, reqHdr http.Header) ([]ats
 		return nil
 	})
 	if err != nil {
-		return nil, reqInf, errors.New("getting delivery services: " + err.Error())
+		return nil, nil, reqInf, errors.New("getting delivery services: " + err.Error())
+	}
+	return deliveryServices, nil, reqInf, nil
+}
+
+// getCDNDeliveryServicesV40 requests the CDN's Delivery Services from API 4.0.
+// The client only supports API 3, so this makes the request directly.
+// Returns whether Traffic Ops supports API 4.0 Delivery Services, which is false if it returned a 404.
+func (cl *TOClient) getCDNDeliveryServicesV40(cdnID int, reqHdr http.Header) ([]tc.DeliveryServiceV4, toclientlib.ReqInf, bool, error) {
+	deliveryServices := []tc.DeliveryServiceV4{}
+	reqInf := toclientlib.ReqInf{}
+	supported := true
+	err := torequtil.GetRetry(cl.NumRetries, "cdn_"+strconv.Itoa(cdnID)+"_deliveryservices_v40", &deliveryServices, func(obj interface{}) error {
+		path := "/api/4.0/deliveryservices?cdn=" + strconv.Itoa(cdnID)
+		resp, remoteAddr, err := cl.C.RawRequestWithHdr(http.MethodGet, path, nil, reqHdr)
+		if err != nil {
+			return errors.New("getting delivery services from Traffic Ops '" + torequtil.MaybeIPStr(remoteAddr) + "': " + err.Error())
+		}
+		defer log.Close(resp.Body, "unable to close delivery services response body")
+
+		toReqInf := toclientlib.ReqInf{
+			CacheHitStatus: toclientlib.CacheHitStatusMiss,
+			RemoteAddr:     remoteAddr,
+			StatusCode:     resp.StatusCode,
+			RespHeaders:    resp.Header.Clone(),
+		}
+		switch resp.StatusCode {
+		case http.StatusNotModified:
+			reqInf = toReqInf
+			return nil
+		case http.StatusNotFound:
+			supported = false
+			return nil
+		case http.StatusOK:
+		default:
+			return errors.New("getting delivery services from Traffic Ops '" + torequtil.MaybeIPStr(remoteAddr) + "': unexpected status code " + strconv.Itoa(resp.StatusCode))
+		}
+
+		toResp := tc.DeliveryServicesResponseV4{}
+		if err := json.NewDecoder(resp.Body).Decode(&toResp); err != nil {
+			return errors.New("decoding delivery services from Traffic Ops '" + torequtil.MaybeIPStr(remoteAddr) + "': " + err.Error())
+		}
+		dses := obj.(*[]tc.DeliveryServiceV4)
+		*dses = toResp.Response
+		reqInf = toReqInf
+		return nil
+	})
+	if err != nil {
+		return nil, reqInf, false, errors.New("getting delivery services: " + err.Error())
 	}
-	return deliveryServices, reqInf, nil
+	return deliveryServices, reqInf, supported, nil
 }
 
 // GetTopologies returns the data, the Traffic Ops address, and any error.
@@ -0,0 +1,136 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/pborman/getopt/v2"
+)
+
+const ExitCodeSuccess = 0
+const ExitCodeProblems = 1
+const ExitCodeReadErr = 2
+
+func main() {
+	help := getopt.BoolLong("help", 'h', "Print usage info and exit")
+	dns := getopt.BoolLong("dns", 'd', "Resolve parent hosts with DNS, rather than only checking they're fully qualified")
+	jsonOut := getopt.BoolLong("json", 'j', "Print the problems as a JSON array")
+	getopt.ParseV2()
+	if *help {
+		fmt.Println(usageStr)
+		os.Exit(ExitCodeSuccess)
+	}
+
+	files := []atscfg.CfgFile{}
+	err := error(nil)
+	if args := getopt.Args(); len(args) > 0 {
+		files, err = readFiles(args)
+	} else {
+		files, err = readGeneratedFiles()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error reading config files: "+err.Error())
+		os.Exit(ExitCodeReadErr)
+	}
+
+	opt := &atscfg.LintOpts{}
+	if *dns {
+		opt.ResolveHost = resolveHostDNS
+	}
+	problems := atscfg.LintConfigFiles(files, opt)
+
+	if *jsonOut {
+		if err := json.NewEncoder(os.Stdout).Encode(problems); err != nil {
+			fmt.Fprintln(os.Stderr, "error writing problems: "+err.Error())
+		}
+	} else {
+		for _, problem := range problems {
+			fmt.Println(problem.String())
+		}
+	}
+	if len(problems) > 0 {
+		os.Exit(ExitCodeProblems)
+	}
+	os.Exit(ExitCodeSuccess)
+}
+
+const usageStr = `usage: t3c-lint [--help] [--dns] [--json]
+       [<file>...]
+
+Lints ATS config files, printing any problems to stdout.
+
+If no files are given, reads the JSON output of t3c-generate from stdin.
+Files given by path are linted by their file name, such as 'parent.config'.
+
+Returns the exit code 0 if there were no problems, 1 if there were problems,
+and 2 if the files couldn't be read.`
+
+// readGeneratedFiles reads the config files from t3c-generate output on stdin.
+func readGeneratedFiles() ([]atscfg.CfgFile, error) {
+	genFiles := []t3cutil.ATSConfigFile{}
+	if err := json.NewDecoder(os.Stdin).Decode(&genFiles); err != nil {
+		return nil, errors.New("decoding stdin: " + err.Error())
+	}
+	files := []atscfg.CfgFile{}
+	for _, genFile := range genFiles {
+		files = append(files, atscfg.CfgFile{
+			Name: genFile.Name,
+			Path: genFile.Path,
+			Cfg: atscfg.Cfg{
+				Text:        genFile.Text,
+				ContentType: genFile.ContentType,
+				LineComment: genFile.LineComment,
+			},
+		})
+	}
+	return files, nil
+}
+
+// readFiles reads the config files at the given paths.
+func readFiles(paths []string) ([]atscfg.CfgFile, error) {
+	files := []atscfg.CfgFile{}
+	for _, path := range paths {
+		bts, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.New("reading file '" + path + "': " + err.Error())
+		}
+		files = append(files, atscfg.CfgFile{
+			Name: filepath.Base(path),
+			Path: filepath.Dir(path),
+			Cfg:  atscfg.Cfg{Text: string(bts)},
+		})
+	}
+	return files, nil
+}
+
+// resolveHostDNS returns whether the host resolves with the system resolver.
+func resolveHostDNS(host string) bool {
+	addrs, err := net.LookupHost(host)
+	return err == nil && len(addrs) > 0
+}
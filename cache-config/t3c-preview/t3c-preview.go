@@ -0,0 +1,140 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-atscfg/preview"
+	"github.com/pborman/getopt/v2"
+)
+
+const ExitCodeSuccess = 0
+const ExitCodeChanges = 1
+const ExitCodeUsage = 2
+const ExitCodeReadErr = 3
+const ExitCodeChangeErr = 4
+const ExitCodeGenerateErr = 5
+
+func main() {
+	help := getopt.BoolLong("help", 'h', "Print usage info and exit")
+	changesPath := getopt.StringLong("changes", 'c', "", "Path of the JSON change set file to preview")
+	dir := getopt.StringLong("dir", 'D', preview.DefaultDir, "ATS config directory, used for config files without location parameters")
+	jsonOut := getopt.BoolLong("json", 'j', "Print the report as JSON")
+	parallel := getopt.IntLong("parallel", 'p', 0, "Number of caches to generate config for at once. Default is the number of CPUs")
+	getopt.ParseV2()
+	if *help {
+		fmt.Println(usageStr)
+		os.Exit(ExitCodeSuccess)
+	}
+	if *changesPath == "" {
+		fmt.Fprintln(os.Stderr, "missing required --changes")
+		fmt.Fprintln(os.Stderr, usageStr)
+		os.Exit(ExitCodeUsage)
+	}
+
+	changes, err := readChanges(*changesPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error reading changes: "+err.Error())
+		os.Exit(ExitCodeReadErr)
+	}
+	before := &t3cutil.CDNConfigData{}
+	if err := json.NewDecoder(os.Stdin).Decode(before); err != nil {
+		fmt.Fprintln(os.Stderr, "error reading CDN config data from stdin: "+err.Error())
+		os.Exit(ExitCodeReadErr)
+	}
+
+	after, err := changes.Apply(before)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error applying changes: "+err.Error())
+		os.Exit(ExitCodeChangeErr)
+	}
+
+	opts := preview.DefaultOpts(*dir)
+	opts.Parallel = *parallel
+	report := preview.Preview(before, after, opts)
+
+	if *jsonOut {
+		if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+			fmt.Fprintln(os.Stderr, "error writing report: "+err.Error())
+		}
+	} else {
+		printReport(report)
+	}
+
+	if len(report.Errors) > 0 {
+		os.Exit(ExitCodeGenerateErr)
+	}
+	if len(report.Changes) > 0 {
+		os.Exit(ExitCodeChanges)
+	}
+	os.Exit(ExitCodeSuccess)
+}
+
+const usageStr = `usage: t3c-preview [--help] [--json] [--dir=<dir>] [--parallel=<n>]
+       --changes=<file>
+
+Previews the config changes a change set of Parameters and Delivery Services
+would make to every cache of a CDN.
+
+Reads the CDN config data from stdin, as output by
+'t3c-request --get-data=cdn-config'.
+
+Prints the caches whose config would change, with the unified diffs of their
+files, grouping caches with identical changes.
+
+Returns the exit code 0 if no config would change, 1 if config would change,
+2 for invalid usage, 3 if the input couldn't be read, 4 if the changes couldn't
+be applied, and 5 if any cache's config failed to generate.`
+
+// readChanges reads the change set at the given path.
+func readChanges(path string) (preview.ChangeSet, error) {
+	changes := preview.ChangeSet{}
+	file, err := os.Open(path)
+	if err != nil {
+		return changes, errors.New("opening file: " + err.Error())
+	}
+	defer file.Close()
+	if err := json.NewDecoder(file).Decode(&changes); err != nil {
+		return changes, errors.New("decoding file: " + err.Error())
+	}
+	return changes, nil
+}
+
+// printReport prints the report as text to stdout.
+func printReport(report preview.Report) {
+	fmt.Println(strconv.Itoa(report.ChangedServers) + " of " + strconv.Itoa(report.Servers) + " caches changed")
+	for _, change := range report.Changes {
+		fmt.Println()
+		fmt.Println("=== " + strconv.Itoa(len(change.Servers)) + " caches: " + strings.Join(change.Servers, " "))
+		for _, file := range change.Files {
+			fmt.Print(file.Diff)
+		}
+	}
+	for _, svErr := range report.Errors {
+		fmt.Fprintln(os.Stderr, "error generating config for '"+svErr.Server+"': "+svErr.Error)
+	}
+}
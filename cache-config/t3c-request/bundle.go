@@ -0,0 +1,179 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-request/config"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+// readLocalBundle reads the local bundle, and checks that it's for this cache.
+// The local bundle isn't signature verified: it's written by t3c-request
+// itself, or by --import-bundle after verifying.
+func readLocalBundle(cfg config.Cfg) (*t3cutil.Bundle, error) {
+	bundle, _, err := t3cutil.ReadBundle(cfg.Bundle, nil)
+	if err != nil {
+		return nil, errors.New("reading bundle '" + cfg.Bundle + "': " + err.Error())
+	}
+	if bundle.CacheHostName != cfg.CacheHostName {
+		return nil, errors.New("bundle '" + cfg.Bundle + "' is for cache '" + bundle.CacheHostName + "', not '" + cfg.CacheHostName + "'")
+	}
+	return bundle, nil
+}
+
+// writeFromBundle writes the data of cfg.GetData from the local bundle to
+// output, warning how old it is.
+func writeFromBundle(cfg config.Cfg, output io.Writer) error {
+	if !t3cutil.IsBundleData(cfg.GetData) {
+		return errors.New("data '" + cfg.GetData + "' isn't kept in bundles, and can't be served offline")
+	}
+	bundle, err := readLocalBundle(cfg)
+	if err != nil {
+		return err
+	}
+	data, err := bundle.Get(cfg.GetData, cfg.BundleMaxAge, time.Now())
+	if err != nil {
+		return err
+	}
+	log.Warnf("serving '%s' from bundle '%s', fetched from Traffic Ops '%s' at %s, %v ago\n", cfg.GetData, cfg.Bundle, bundle.TrafficOpsURL, data.Fetched.Format(time.RFC3339), time.Since(data.Fetched).Round(time.Second))
+	// Bundles store data trimmed, so write the newline t3c-request writes after live data.
+	if _, err := output.Write(append(data.Data, '\n')); err != nil {
+		return errors.New("writing data: " + err.Error())
+	}
+	return nil
+}
+
+// fallBackToBundle writes the data of cfg.GetData from the local bundle to
+// stdout, after Traffic Ops failed with toErr. It returns whether it did; if
+// it didn't, the caller should fail with toErr.
+func fallBackToBundle(cfg config.Cfg, toErr error) bool {
+	if cfg.Bundle == "" || !t3cutil.IsBundleData(cfg.GetData) {
+		return false
+	}
+	log.Errorln("getting '" + cfg.GetData + "' from Traffic Ops failed, falling back to bundle: " + toErr.Error())
+	if err := writeFromBundle(cfg, os.Stdout); err != nil {
+		log.Errorln("falling back to bundle: " + err.Error())
+		return false
+	}
+	return true
+}
+
+// saveToBundle saves the data of cfg.GetData, just fetched from Traffic Ops,
+// to the local bundle. Failures are logged, not returned, because the data
+// was fetched successfully.
+func saveToBundle(cfg config.Cfg, data []byte) {
+	if cfg.Bundle == "" || !t3cutil.IsBundleData(cfg.GetData) {
+		return
+	}
+	bundle, err := readLocalBundle(cfg)
+	if err != nil {
+		if !isNotExist(cfg.Bundle) {
+			log.Warnln("replacing unusable bundle: " + err.Error())
+		}
+		bundle = t3cutil.NewBundle(cfg.CacheHostName, cfg.TOURL.String())
+	}
+	bundle.TrafficOpsURL = cfg.TOURL.String()
+	bundle.Set(cfg.GetData, time.Now(), bytes.TrimSpace(data))
+	if err := t3cutil.WriteBundle(cfg.Bundle, bundle, nil); err != nil {
+		log.Errorln("saving '" + cfg.GetData + "' to bundle '" + cfg.Bundle + "': " + err.Error())
+		return
+	}
+	log.Infoln("saved '" + cfg.GetData + "' to bundle '" + cfg.Bundle + "'")
+}
+
+func isNotExist(path string) bool {
+	_, err := os.Stat(path)
+	return os.IsNotExist(err)
+}
+
+// exportBundle fetches all bundle data from Traffic Ops, and writes it to
+// output as a bundle, signed if there's a sign key.
+func exportBundle(cfg config.Cfg, tccfg t3cutil.TCCfg, output io.Writer) error {
+	bundle := t3cutil.NewBundle(cfg.CacheHostName, cfg.TOURL.String())
+	for _, name := range t3cutil.BundleDataNames() {
+		tccfg.GetData = name
+		buf := &bytes.Buffer{}
+		if err := t3cutil.WriteDataTo(tccfg, buf); err != nil {
+			return errors.New("getting '" + name + "': " + err.Error())
+		}
+		bundle.Set(name, time.Now(), bytes.TrimSpace(buf.Bytes()))
+	}
+	bts, err := t3cutil.EncodeBundle(bundle, cfg.BundleSignKey)
+	if err != nil {
+		return err
+	}
+	if _, err := output.Write(append(bts, '\n')); err != nil {
+		return errors.New("writing bundle: " + err.Error())
+	}
+	return nil
+}
+
+// importBundle verifies the bundle at cfg.ImportBundle, and merges it into the
+// local bundle, keeping whichever data is newer.
+func importBundle(cfg config.Cfg) error {
+	imported, signed, err := t3cutil.ReadBundle(cfg.ImportBundle, cfg.BundleVerifyKey)
+	if err != nil {
+		return errors.New("reading bundle '" + cfg.ImportBundle + "': " + err.Error())
+	}
+	if imported.CacheHostName != cfg.CacheHostName {
+		return errors.New("bundle '" + cfg.ImportBundle + "' is for cache '" + imported.CacheHostName + "', not '" + cfg.CacheHostName + "'")
+	}
+	if cfg.BundleVerifyKey == nil {
+		log.Warnln("importing bundle without verifying its signature, because no verify key was given")
+	} else if signed {
+		log.Infoln("verified bundle '" + cfg.ImportBundle + "' signature")
+	}
+
+	bundle, err := readLocalBundle(cfg)
+	if err != nil {
+		if !isNotExist(cfg.Bundle) {
+			log.Warnln("replacing unusable bundle: " + err.Error())
+		}
+		bundle = t3cutil.NewBundle(imported.CacheHostName, imported.TrafficOpsURL)
+	}
+	bundle.Merge(imported)
+	if err := t3cutil.WriteBundle(cfg.Bundle, bundle, nil); err != nil {
+		return errors.New("writing bundle '" + cfg.Bundle + "': " + err.Error())
+	}
+	log.Infoln("imported bundle '" + cfg.ImportBundle + "' into '" + cfg.Bundle + "'")
+	return nil
+}
+
+// writeBundleStatus writes the staleness of the local bundle to output as
+// JSON.
+func writeBundleStatus(cfg config.Cfg, output io.Writer) error {
+	bundle, err := readLocalBundle(cfg)
+	if err != nil {
+		return err
+	}
+	status := bundle.Status(cfg.Bundle, cfg.BundleMaxAge, time.Now())
+	if err := json.NewEncoder(output).Encode(status); err != nil {
+		return errors.New("encoding bundle status: " + err.Error())
+	}
+	return nil
+}
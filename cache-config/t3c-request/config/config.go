@@ -20,6 +20,7 @@ package config
  */
 
 import (
+	"crypto/ed25519"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -44,9 +45,41 @@ type Cfg struct {
 	LogLocationError string
 	LogLocationInfo  string
 	LoginDispersion  time.Duration
+
+	// Bundle is the path of the local data bundle. If it isn't empty, data
+	// successfully fetched from Traffic Ops is saved to it, and served from
+	// it when Traffic Ops can't be reached.
+	Bundle string
+	// Offline is whether to serve data from the bundle without contacting
+	// Traffic Ops at all.
+	Offline bool
+	// BundleMaxAge is the max age of bundle data to serve. If 0, data of any
+	// age is served.
+	BundleMaxAge time.Duration
+	// BundleSignKey is the key to sign exported bundles with. May be nil.
+	BundleSignKey ed25519.PrivateKey
+	// BundleVerifyKey is the key imported bundles must be signed with. May be
+	// nil, in which case imported bundles aren't verified.
+	BundleVerifyKey ed25519.PublicKey
+	// ImportBundle is the path of a bundle to import into the local bundle.
+	ImportBundle string
+
 	t3cutil.TCCfg
 }
 
+// GetDataBundle is the get-data value to fetch all bundle data from Traffic
+// Ops and write it as a bundle, to be imported on another host.
+const GetDataBundle = "bundle"
+
+// GetDataBundleStatus is the get-data value to write the staleness of the
+// local bundle, without contacting Traffic Ops.
+const GetDataBundleStatus = "bundle-status"
+
+// NeedsTrafficOps returns whether the config requires contacting Traffic Ops.
+func (cfg Cfg) NeedsTrafficOps() bool {
+	return !cfg.Offline && cfg.ImportBundle == "" && cfg.GetData != GetDataBundleStatus
+}
+
 func (cfg Cfg) DebugLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationDebug) }
 func (cfg Cfg) ErrorLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationError) }
 func (cfg Cfg) InfoLog() log.LogLocation    { return log.LogLocation(cfg.LogLocationInfo) }
@@ -63,7 +96,7 @@ func Usage() {
 func InitConfig() (Cfg, error) {
 	dispersionPtr := getopt.IntLong("login-dispersion", 'l', 0, "[seconds] wait a random number of seconds between 0 and [seconds] before login to traffic ops, default 0")
 	cacheHostNamePtr := getopt.StringLong("cache-host-name", 'H', "", "Host name of the cache to generate config for. Must be the server host name in Traffic Ops, not a URL, and not the FQDN")
-	getDataPtr := getopt.StringLong("get-data", 'D', "system-info", "non-config-file Traffic Ops Data to get. Valid values are update-status, packages, chkconfig, system-info, and statuses")
+	getDataPtr := getopt.StringLong("get-data", 'D', "system-info", "non-config-file Traffic Ops Data to get. Valid values are update-status, packages, chkconfig, system-info, statuses, config, cdn-config, bundle, and bundle-status")
 	toInsecurePtr := getopt.BoolLong("traffic-ops-insecure", 'I', "[true | false] ignore certificate errors from Traffic Ops")
 	toTimeoutMSPtr := getopt.IntLong("traffic-ops-timeout-milliseconds", 't', 30000, "Timeout in milli-seconds for Traffic Ops requests, default is 30000")
 	toURLPtr := getopt.StringLong("traffic-ops-url", 'u', "", "Traffic Ops URL. Must be the full URL, including the scheme. Required. May also be set with     the environment variable TO_URL")
@@ -72,6 +105,12 @@ func InitConfig() (Cfg, error) {
 	disableProxyPtr := getopt.BoolLong("traffic-ops-disable-proxy", 'p', "[true | false] whether to not use any configure Traffic Ops proxy parameter. Only used if get-data is config")
 	toPassPtr := getopt.StringLong("traffic-ops-password", 'P', "", "Traffic Ops password. Required. May also be set with the environment variable TO_PASS    ")
 	oldCfgPtr := getopt.StringLong("old-config", 'c', "", "Old config from a previous config request. Optional. May be a file path, or 'stdin' to read from stdin. Used to make conditional requests.")
+	bundlePtr := getopt.StringLong("bundle", 'b', "", "Local data bundle path. Optional. If set, data fetched from Traffic Ops is saved to the bundle, and served from it if Traffic Ops can't be reached")
+	offlinePtr := getopt.BoolLong("offline", 'o', "[true | false] serve data from the bundle without contacting Traffic Ops. Requires --bundle")
+	bundleMaxAgePtr := getopt.IntLong("bundle-max-age", 0, 0, "[seconds] max age of bundle data to serve. Default is 0, to serve data of any age")
+	bundleSignKeyPtr := getopt.StringLong("bundle-sign-key", 0, "", "Ed25519 PEM private key file to sign bundles written with --get-data=bundle. Optional")
+	bundleVerifyKeyPtr := getopt.StringLong("bundle-verify-key", 0, "", "Ed25519 PEM public key file. If set, bundles imported with --import-bundle must be signed by its private key")
+	importBundlePtr := getopt.StringLong("import-bundle", 0, "", "Path of a bundle, as written by --get-data=bundle, to verify and import into the --bundle. Traffic Ops isn't contacted")
 	helpPtr := getopt.BoolLong("help", 'h', "Print usage information and exit")
 	versionPtr := getopt.BoolLong("version", 'V', "Print the app version")
 	verbosePtr := getopt.CounterLong("verbose", 'v', `Log verbosity. Logging is output to stderr. By default, errors are logged. To log warnings, pass '-v'. To log info, pass '-vv'. To omit error logging, see '-s'`)
@@ -125,13 +164,34 @@ func InitConfig() (Cfg, error) {
 		toPass = os.Getenv("TO_PASS")
 	}
 
+	if (*offlinePtr || *importBundlePtr != "" || *getDataPtr == GetDataBundleStatus) && *bundlePtr == "" {
+		return Cfg{}, errors.New("--offline, --import-bundle, and --get-data=" + GetDataBundleStatus + " require --bundle")
+	}
+	if *bundleMaxAgePtr < 0 {
+		return Cfg{}, errors.New("--bundle-max-age must not be negative")
+	}
+	needsTO := Cfg{Offline: *offlinePtr, ImportBundle: *importBundlePtr, TCCfg: t3cutil.TCCfg{GetData: *getDataPtr}}.NeedsTrafficOps()
+
 	toURLParsed, err := url.Parse(toURL)
 	if err != nil {
 		return Cfg{}, errors.New("parsing Traffic Ops URL from " + urlSourceStr + " '" + toURL + "': " + err.Error())
-	} else if err := t3cutil.ValidateURL(toURLParsed); err != nil {
+	} else if err := t3cutil.ValidateURL(toURLParsed); err != nil && needsTO {
 		return Cfg{}, errors.New("invalid Traffic Ops URL from " + urlSourceStr + " '" + toURL + "': " + err.Error())
 	}
 
+	signKey := ed25519.PrivateKey(nil)
+	if *bundleSignKeyPtr != "" {
+		if signKey, err = t3cutil.LoadBundleSignKey(*bundleSignKeyPtr); err != nil {
+			return Cfg{}, errors.New("loading bundle sign key: " + err.Error())
+		}
+	}
+	verifyKey := ed25519.PublicKey(nil)
+	if *bundleVerifyKeyPtr != "" {
+		if verifyKey, err = t3cutil.LoadBundleVerifyKey(*bundleVerifyKeyPtr); err != nil {
+			return Cfg{}, errors.New("loading bundle verify key: " + err.Error())
+		}
+	}
+
 	var cacheHostName string
 	if len(*cacheHostNamePtr) > 0 {
 		cacheHostName = *cacheHostNamePtr
@@ -149,6 +209,12 @@ func InitConfig() (Cfg, error) {
 		LogLocationInfo:  logLocationInfo,
 		LogLocationWarn:  logLocationWarn,
 		LoginDispersion:  dispersion,
+		Bundle:           *bundlePtr,
+		Offline:          *offlinePtr,
+		BundleMaxAge:     time.Second * time.Duration(*bundleMaxAgePtr),
+		BundleSignKey:    signKey,
+		BundleVerifyKey:  verifyKey,
+		ImportBundle:     *importBundlePtr,
 		TCCfg: t3cutil.TCCfg{
 			CacheHostName:  cacheHostName,
 			GetData:        *getDataPtr,
@@ -190,6 +256,10 @@ func (cfg Cfg) PrintConfig() {
 	fmt.Printf("TOUser: %s\n", cfg.TOUser)
 	fmt.Printf("TOPass: xxxxxx\n")
 	fmt.Printf("TOURL: %s\n", cfg.TOURL)
+	fmt.Printf("Bundle: %s\n", cfg.Bundle)
+	fmt.Printf("Offline: %v\n", cfg.Offline)
+	fmt.Printf("BundleMaxAge: %s\n", cfg.BundleMaxAge)
+	fmt.Printf("ImportBundle: %s\n", cfg.ImportBundle)
 }
 
 func LoadOldCfg(path string) (*t3cutil.ConfigData, error) {
@@ -20,6 +20,7 @@ package main
  */
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 
@@ -36,17 +37,61 @@ func main() {
 	}
 	log.Infoln("configuration initialized")
 
+	if cfg.ImportBundle != "" {
+		if err := importBundle(cfg); err != nil {
+			log.Errorf("importing bundle: %s\n", err.Error())
+			os.Exit(4)
+		}
+		return
+	}
+	if cfg.GetData == config.GetDataBundleStatus {
+		if err := writeBundleStatus(cfg, os.Stdout); err != nil {
+			log.Errorf("writing bundle status: %s\n", err.Error())
+			os.Exit(4)
+		}
+		return
+	}
+	if cfg.Offline {
+		if err := writeFromBundle(cfg, os.Stdout); err != nil {
+			log.Errorf("writing data from bundle: %s\n", err.Error())
+			os.Exit(4)
+		}
+		return
+	}
+
 	// login to traffic ops.
 	tccfg, err := t3cutil.TOConnect(&cfg.TCCfg)
 	if err != nil {
+		if fallBackToBundle(cfg, err) {
+			return
+		}
 		log.Errorf("%s\n", err)
 		os.Exit(2)
 	}
 
+	if cfg.GetData == config.GetDataBundle {
+		if err := exportBundle(cfg, *tccfg, os.Stdout); err != nil {
+			log.Errorf("exporting bundle: %s\n", err.Error())
+			os.Exit(3)
+		}
+		return
+	}
+
 	if cfg.GetData != "" {
-		if err := t3cutil.WriteData(*tccfg); err != nil {
+		// Buffer the data, so if fetching it fails part way, nothing is written
+		// before falling back to the bundle.
+		buf := &bytes.Buffer{}
+		if err := t3cutil.WriteDataTo(*tccfg, buf); err != nil {
+			if fallBackToBundle(cfg, err) {
+				return
+			}
+			log.Errorf("writing data: %s\n", err.Error())
+			os.Exit(3)
+		}
+		if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
 			log.Errorf("writing data: %s\n", err.Error())
 			os.Exit(3)
 		}
+		saveToBundle(cfg, buf.Bytes())
 	}
 }
@@ -33,7 +33,9 @@ var commands = map[string]struct{}{
 	"check":      struct{}{},
 	"diff":       struct{}{},
 	"generate":   struct{}{},
+	"lint":       struct{}{},
 	"preprocess": struct{}{},
+	"preview":    struct{}{},
 	"request":    struct{}{},
 	"update":     struct{}{},
 }
@@ -95,7 +97,9 @@ These are the available commands:
   check      check that new config can be applied
   diff       diff config files, with logic like ignoring comments
   generate   generate configuration from Traffic Ops data
+  lint       lint config files for problems, without ATS
   preprocess preprocess generated config files
+  preview    preview the config changes of Traffic Ops data changes
   request    request Traffic Ops data
   update     update a cache's queue and reval status in Traffic Ops
 `
@@ -0,0 +1,332 @@
+package t3cutil
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// BundleVersion is the version of the bundle file format. Bundles of any
+// other version are rejected.
+const BundleVersion = 1
+
+// DefaultBundlePath is the default path of the local data bundle t3c keeps
+// from its last successful requests to Traffic Ops.
+const DefaultBundlePath = `/var/lib/trafficcontrol-cache-config/bundle.json`
+
+// BundleDataNames returns the get-data names kept in bundles, which can be
+// served offline.
+//
+// The update status isn't kept, because it's the live state of the server in
+// Traffic Ops, and acting on a stale one would be wrong.
+func BundleDataNames() []string {
+	return []string{`packages`, `chkconfig`, `system-info`, `statuses`, `config`, `cdn-config`}
+}
+
+// IsBundleData returns whether the get-data name is kept in bundles.
+func IsBundleData(name string) bool {
+	for _, bundleName := range BundleDataNames() {
+		if name == bundleName {
+			return true
+		}
+	}
+	return false
+}
+
+// Bundle is a snapshot of the Traffic Ops data of a cache, from which config
+// can be generated without Traffic Ops.
+type Bundle struct {
+	// CacheHostName is the cache the data is for.
+	CacheHostName string `json:"cacheHostName"`
+	// TrafficOpsURL is the Traffic Ops the data was fetched from.
+	TrafficOpsURL string `json:"trafficOpsURL"`
+	// Data is the data of each get-data name, as t3c-request writes it.
+	Data map[string]BundleData `json:"data"`
+}
+
+// BundleData is the data of a single get-data name in a Bundle.
+type BundleData struct {
+	// Fetched is when the data was fetched from Traffic Ops.
+	Fetched time.Time       `json:"fetched"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// bundleFile is the file format of a Bundle.
+//
+// The digest and signature are of the payload bytes as they are in the file,
+// so verifying doesn't depend on re-encoding the payload identically.
+type bundleFile struct {
+	Version int `json:"version"`
+	// Digest is the hex SHA-256 of the payload, to detect corruption.
+	Digest string `json:"digest"`
+	// Signature is the base64 Ed25519 signature of the payload, if the bundle
+	// was signed.
+	Signature string          `json:"signature,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// NewBundle returns an empty Bundle for the given cache.
+func NewBundle(cacheHostName string, toURL string) *Bundle {
+	return &Bundle{CacheHostName: cacheHostName, TrafficOpsURL: toURL, Data: map[string]BundleData{}}
+}
+
+// Set sets the data of the given get-data name, fetched at the given time.
+func (b *Bundle) Set(name string, fetched time.Time, data []byte) {
+	b.Data[name] = BundleData{Fetched: fetched, Data: json.RawMessage(data)}
+}
+
+// Get returns the data of the given get-data name, or an error if the bundle
+// doesn't have it, or it's older than maxAge. A maxAge of 0 allows any age.
+func (b *Bundle) Get(name string, maxAge time.Duration, now time.Time) (BundleData, error) {
+	data, ok := b.Data[name]
+	if !ok {
+		return BundleData{}, errors.New("bundle has no '" + name + "' data")
+	}
+	if age := now.Sub(data.Fetched); maxAge > 0 && age > maxAge {
+		return BundleData{}, errors.New("bundle '" + name + "' data fetched " + data.Fetched.Format(time.RFC3339) + " is " + age.Round(time.Second).String() + " old, older than the max age " + maxAge.String())
+	}
+	return data, nil
+}
+
+// Merge adds the data of other to b, keeping whichever data of each name was
+// fetched most recently.
+func (b *Bundle) Merge(other *Bundle) {
+	for name, data := range other.Data {
+		if existing, ok := b.Data[name]; !ok || data.Fetched.After(existing.Fetched) {
+			b.Data[name] = data
+		}
+	}
+}
+
+// BundleStatus is the staleness of a Bundle, as reported by
+// 't3c-request --get-data=bundle-status'.
+type BundleStatus struct {
+	Path          string                      `json:"path"`
+	CacheHostName string                      `json:"cacheHostName"`
+	TrafficOpsURL string                      `json:"trafficOpsURL"`
+	Data          map[string]BundleDataStatus `json:"data"`
+	Missing       []string                    `json:"missing,omitempty"`
+}
+
+// BundleDataStatus is the staleness of the data of a single get-data name.
+type BundleDataStatus struct {
+	Fetched    time.Time `json:"fetched"`
+	AgeSeconds int64     `json:"ageSeconds"`
+	// Stale is whether the data is older than the max age, if there is one.
+	Stale bool `json:"stale"`
+}
+
+// Status returns the staleness of the bundle at the given time. Data older
+// than maxAge is reported stale; a maxAge of 0 allows any age.
+func (b *Bundle) Status(path string, maxAge time.Duration, now time.Time) BundleStatus {
+	st := BundleStatus{
+		Path:          path,
+		CacheHostName: b.CacheHostName,
+		TrafficOpsURL: b.TrafficOpsURL,
+		Data:          map[string]BundleDataStatus{},
+	}
+	for name, data := range b.Data {
+		age := now.Sub(data.Fetched)
+		st.Data[name] = BundleDataStatus{
+			Fetched:    data.Fetched,
+			AgeSeconds: int64(age / time.Second),
+			Stale:      maxAge > 0 && age > maxAge,
+		}
+	}
+	for _, name := range BundleDataNames() {
+		if _, ok := b.Data[name]; !ok {
+			st.Missing = append(st.Missing, name)
+		}
+	}
+	sort.Strings(st.Missing)
+	return st
+}
+
+// EncodeBundle returns the file bytes of the bundle. If signKey isn't nil,
+// the bundle is signed with it.
+func EncodeBundle(b *Bundle, signKey ed25519.PrivateKey) ([]byte, error) {
+	payload, err := json.Marshal(b)
+	if err != nil {
+		return nil, errors.New("encoding bundle payload: " + err.Error())
+	}
+	digest := sha256.Sum256(payload)
+	bf := bundleFile{
+		Version: BundleVersion,
+		Digest:  hex.EncodeToString(digest[:]),
+		Payload: payload,
+	}
+	if signKey != nil {
+		bf.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(signKey, payload))
+	}
+	bts, err := json.Marshal(bf)
+	if err != nil {
+		return nil, errors.New("encoding bundle: " + err.Error())
+	}
+	return bts, nil
+}
+
+// DecodeBundle decodes and verifies the bundle file bytes, and returns the
+// bundle and whether it was signed.
+//
+// If verifyKey isn't nil, the bundle must be signed by its private key.
+// Otherwise, a signature isn't required and isn't checked.
+func DecodeBundle(bts []byte, verifyKey ed25519.PublicKey) (*Bundle, bool, error) {
+	bf := bundleFile{}
+	if err := json.Unmarshal(bts, &bf); err != nil {
+		return nil, false, errors.New("decoding bundle: " + err.Error())
+	}
+	if bf.Version != BundleVersion {
+		return nil, false, errors.New("unsupported bundle version " + strconv.Itoa(bf.Version) + ", expected " + strconv.Itoa(BundleVersion))
+	}
+	digest := sha256.Sum256(bf.Payload)
+	if hex.EncodeToString(digest[:]) != bf.Digest {
+		return nil, false, errors.New("bundle digest doesn't match its payload, the bundle is corrupt")
+	}
+	signed := bf.Signature != ""
+	if verifyKey != nil {
+		if !signed {
+			return nil, false, errors.New("bundle isn't signed")
+		}
+		sig, err := base64.StdEncoding.DecodeString(bf.Signature)
+		if err != nil {
+			return nil, false, errors.New("decoding bundle signature: " + err.Error())
+		}
+		if !ed25519.Verify(verifyKey, bf.Payload, sig) {
+			return nil, false, errors.New("bundle signature is invalid")
+		}
+	}
+	b := &Bundle{}
+	if err := json.Unmarshal(bf.Payload, b); err != nil {
+		return nil, false, errors.New("decoding bundle payload: " + err.Error())
+	}
+	if b.Data == nil {
+		b.Data = map[string]BundleData{}
+	}
+	return b, signed, nil
+}
+
+// ReadBundle reads and verifies the bundle file at path, as DecodeBundle.
+// If the file doesn't exist, the returned error satisfies os.IsNotExist.
+func ReadBundle(path string, verifyKey ed25519.PublicKey) (*Bundle, bool, error) {
+	bts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	return DecodeBundle(bts, verifyKey)
+}
+
+// WriteBundle writes the bundle to path, signed if signKey isn't nil.
+//
+// The bundle is written to a temp file and renamed, so readers never see a
+// partially written bundle. It's only readable by the owner, because config
+// data includes secrets like SSL keys and URL signing keys.
+func WriteBundle(path string, b *Bundle, signKey ed25519.PrivateKey) error {
+	bts, err := EncodeBundle(b, signKey)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".")
+	if err != nil {
+		return errors.New("creating temp bundle file: " + err.Error())
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(bts); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return errors.New("writing temp bundle file: " + err.Error())
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return errors.New("closing temp bundle file: " + err.Error())
+	}
+	if err := os.Chmod(tmpPath, 0600); err != nil {
+		os.Remove(tmpPath)
+		return errors.New("setting temp bundle file permissions: " + err.Error())
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return errors.New("renaming temp bundle file: " + err.Error())
+	}
+	return nil
+}
+
+// LoadBundleSignKey loads an Ed25519 private key to sign bundles with, from a
+// PEM PKCS#8 file, as created by 'openssl genpkey -algorithm ed25519'.
+func LoadBundleSignKey(path string) (ed25519.PrivateKey, error) {
+	der, err := readPEM(path, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.New("parsing private key '" + path + "': " + err.Error())
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key '" + path + "' isn't an Ed25519 key")
+	}
+	return edKey, nil
+}
+
+// LoadBundleVerifyKey loads an Ed25519 public key to verify bundles with, from
+// a PEM PKIX file, as created by 'openssl pkey -pubout'.
+func LoadBundleVerifyKey(path string) (ed25519.PublicKey, error) {
+	der, err := readPEM(path, "PUBLIC KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, errors.New("parsing public key '" + path + "': " + err.Error())
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("public key '" + path + "' isn't an Ed25519 key")
+	}
+	return edKey, nil
+}
+
+func readPEM(path string, blockType string) ([]byte, error) {
+	bts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("reading key file: " + err.Error())
+	}
+	block, _ := pem.Decode(bts)
+	if block == nil {
+		return nil, errors.New("key file '" + path + "' has no PEM data")
+	}
+	if block.Type != blockType {
+		return nil, errors.New("key file '" + path + "' has PEM type '" + block.Type + "', expected '" + blockType + "'")
+	}
+	return block.Bytes, nil
+}
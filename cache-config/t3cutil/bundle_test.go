@@ -0,0 +1,197 @@
+package t3cutil
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testBundle() *Bundle {
+	b := NewBundle("mycache", "https://to.example.net")
+	b.Set("config", time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC), []byte(`{"servers":[{"hostName":"mycache"}],"remap":"a <b> & c"}`))
+	b.Set("packages", time.Date(2021, 8, 2, 0, 0, 0, 0, time.UTC), []byte(`[]`))
+	return b
+}
+
+func TestBundleEncodeDecode(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bts, err := EncodeBundle(testBundle(), priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, signed, err := DecodeBundle(bts, pub)
+	if err != nil {
+		t.Fatalf("expected signed bundle to verify, actual: %v", err)
+	}
+	if !signed {
+		t.Error("expected bundle to be signed")
+	}
+	if b.CacheHostName != "mycache" || b.TrafficOpsURL != "https://to.example.net" {
+		t.Errorf("expected bundle for mycache from https://to.example.net, actual %s from %s", b.CacheHostName, b.TrafficOpsURL)
+	}
+	if cfg := string(b.Data["config"].Data); !strings.Contains(cfg, `mycache`) {
+		t.Errorf("expected config data to round trip, actual '%s'", cfg)
+	}
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := DecodeBundle(bts, otherPub); err == nil {
+		t.Error("expected bundle signed by another key to fail verification")
+	}
+
+	unsigned, err := EncodeBundle(testBundle(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, signed, err := DecodeBundle(unsigned, nil); err != nil || signed {
+		t.Errorf("expected unsigned bundle to decode without a key, actual signed %v err %v", signed, err)
+	}
+	if _, _, err := DecodeBundle(unsigned, pub); err == nil {
+		t.Error("expected unsigned bundle to fail verification with a key")
+	}
+
+	tampered := bytes.Replace(bts, []byte(`mycache"}]`), []byte(`evilcache"}]`), 1)
+	if bytes.Equal(tampered, bts) {
+		t.Fatal("failed to tamper with bundle")
+	}
+	if _, _, err := DecodeBundle(tampered, nil); err == nil {
+		t.Error("expected tampered bundle to fail its digest")
+	}
+}
+
+func TestBundleGetMaxAge(t *testing.T) {
+	b := testBundle()
+	now := time.Date(2021, 8, 3, 0, 0, 0, 0, time.UTC)
+
+	if _, err := b.Get("config", 0, now); err != nil {
+		t.Errorf("expected data of any age with no max age, actual: %v", err)
+	}
+	if _, err := b.Get("config", 24*time.Hour, now); err == nil {
+		t.Error("expected data 2 days old to exceed a max age of 1 day")
+	}
+	if _, err := b.Get("packages", 24*time.Hour, now); err != nil {
+		t.Errorf("expected data 1 day old to be within a max age of 1 day, actual: %v", err)
+	}
+	if _, err := b.Get("statuses", 0, now); err == nil {
+		t.Error("expected missing data to fail")
+	}
+
+	st := b.Status("/bundle.json", 24*time.Hour, now)
+	if !st.Data["config"].Stale || st.Data["packages"].Stale {
+		t.Errorf("expected config stale and packages not, actual %+v", st.Data)
+	}
+	if len(st.Missing) != len(BundleDataNames())-2 {
+		t.Errorf("expected %d missing data, actual %v", len(BundleDataNames())-2, st.Missing)
+	}
+}
+
+func TestBundleMerge(t *testing.T) {
+	b := testBundle()
+	other := NewBundle("mycache", "https://to.example.net")
+	other.Set("config", time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC), []byte(`{"old":true}`))
+	other.Set("packages", time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC), []byte(`[{"name":"new"}]`))
+	other.Set("statuses", time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC), []byte(`[]`))
+	b.Merge(other)
+
+	if string(b.Data["config"].Data) == `{"old":true}` {
+		t.Error("expected older config not to replace newer")
+	}
+	if string(b.Data["packages"].Data) != `[{"name":"new"}]` {
+		t.Errorf("expected newer packages to replace older, actual '%s'", b.Data["packages"].Data)
+	}
+	if _, ok := b.Data["statuses"]; !ok {
+		t.Error("expected statuses to be added")
+	}
+}
+
+func TestBundleFileAndKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "t3c-bundle-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPath := filepath.Join(dir, "bundle.key")
+	pubPath := filepath.Join(dir, "bundle.pub")
+	if err := ioutil.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	signKey, err := LoadBundleSignKey(privPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyKey, err := LoadBundleVerifyKey(pubPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBundleVerifyKey(privPath); err == nil {
+		t.Error("expected loading a private key as a public key to fail")
+	}
+
+	path := filepath.Join(dir, "bundle.json")
+	if err := WriteBundle(path, testBundle(), signKey); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected bundle mode 0600, actual %o", perm)
+	}
+	if _, signed, err := ReadBundle(path, verifyKey); err != nil || !signed {
+		t.Errorf("expected written bundle to verify, actual signed %v err %v", signed, err)
+	}
+	if _, _, err := ReadBundle(filepath.Join(dir, "nonexistent.json"), nil); !os.IsNotExist(err) {
+		t.Errorf("expected nonexistent bundle to be a not exist error, actual %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package t3cutil
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-generate/toreq"
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// CDNConfigData is the Traffic Ops data necessary to generate config for
+// every cache on a CDN. See atscfg.CDNConfigData.
+type CDNConfigData = atscfg.CDNConfigData
+
+// GetCDNConfigData gets all the data from Traffic Ops needed to generate config
+// for every cache on the CDN of the given cache.
+//
+// See GetConfigData.
+func GetCDNConfigData(toClient *toreq.TOClient, disableProxy bool, cacheHostName string) (*CDNConfigData, error) {
+	start := time.Now()
+	defer func() { log.Infof("GetCDNConfigData took %v\n", time.Since(start)) }()
+
+	toData, err := GetConfigData(toClient, disableProxy, cacheHostName, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	cdnData := &CDNConfigData{
+		ConfigData:    *toData,
+		ProfileParams: map[string][]tc.Parameter{},
+		Profiles:      map[string]tc.Profile{},
+	}
+
+	profileNames := map[string]struct{}{}
+	for _, sv := range cdnData.CacheServers() {
+		profileNames[*sv.Profile] = struct{}{}
+	}
+
+	m := sync.Mutex{}
+	fs := []func() error{}
+	for profileName := range profileNames {
+		profileName := profileName
+		fs = append(fs, func() error {
+			params, _, err := toClient.GetServerProfileParameters(profileName, nil)
+			if err != nil {
+				return errors.New("getting profile '" + profileName + "' parameters: " + err.Error())
+			}
+			profile, _, err := toClient.GetProfileByName(profileName, nil)
+			if err != nil {
+				return errors.New("getting profile '" + profileName + "': " + err.Error())
+			}
+			m.Lock()
+			defer m.Unlock()
+			cdnData.ProfileParams[profileName] = params
+			cdnData.Profiles[profileName] = profile
+			return nil
+		})
+	}
+	if err := util.JoinErrs(runParallel(fs)); err != nil {
+		return nil, err
+	}
+	return cdnData, nil
+}
+
+// WriteCDNConfig writes the Traffic Ops data necessary to generate config for
+// every cache on the CDN of cfg.CacheHostName to output.
+func WriteCDNConfig(cfg TCCfg, output io.Writer) error {
+	cdnData, err := GetCDNConfigData(cfg.TOClient, cfg.TODisableProxy, cfg.CacheHostName)
+	if err != nil {
+		return errors.New("getting cdn config data: " + err.Error())
+	}
+	if err := json.NewEncoder(output).Encode(cdnData); err != nil {
+		return errors.New("encoding cdn config data: " + err.Error())
+	}
+	return nil
+}
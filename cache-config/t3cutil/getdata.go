@@ -67,6 +67,7 @@ func GetDataFuncs() map[string]func(TCCfg, io.Writer) error {
 		`system-info`:   WriteSystemInfo,
 		`statuses`:      WriteStatuses,
 		`config`:        WriteConfig,
+		`cdn-config`:    WriteCDNConfig,
 	}
 }
 
@@ -79,12 +80,17 @@ func GetServerUpdateStatus(cfg TCCfg) (*tc.ServerUpdateStatus, error) {
 }
 
 func WriteData(cfg TCCfg) error {
+	return WriteDataTo(cfg, os.Stdout)
+}
+
+// WriteDataTo writes the data of cfg.GetData to output.
+func WriteDataTo(cfg TCCfg, output io.Writer) error {
 	log.Infoln("Getting data '" + cfg.GetData + "'")
 	dataF, ok := GetDataFuncs()[cfg.GetData]
 	if !ok {
 		return errors.New("unknown data request '" + cfg.GetData + "'")
 	}
-	return dataF(cfg, os.Stdout)
+	return dataF(cfg, output)
 }
 
 const SystemInfoParamConfigFile = `global`
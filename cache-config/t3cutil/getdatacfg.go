@@ -37,100 +37,13 @@ import (
 
 const TrafficOpsProxyParameterName = `tm.rev_proxy.url`
 
-type ConfigData struct {
-	// Servers must be all the servers from Traffic Ops. May include servers not on the current cdn.
-	Servers []atscfg.Server `json:"servers,omitempty"`
+// ConfigData is the Traffic Ops data necessary to generate config for a
+// cache. See atscfg.ConfigData.
+type ConfigData = atscfg.ConfigData
 
-	// CacheGroups must be all cachegroups in Traffic Ops with Servers on the current server's cdn. May also include CacheGroups without servers on the current cdn.
-	CacheGroups []tc.CacheGroupNullable `json:"cache_groups,omitempty"`
+type ConfigDataMetaData = atscfg.ConfigDataMetaData
 
-	// GlobalParams must be all Parameters in Traffic Ops on the tc.GlobalProfileName Profile. Must not include other parameters.
-	GlobalParams []tc.Parameter `json:"global_parameters,omitempty"`
-
-	// ServerParams must be all Parameters on the Profile of the current server. Must not include other Parameters.
-	ServerParams []tc.Parameter `json:"server_parameters,omitempty"`
-
-	// CacheKeyParams must be all Parameters with the ConfigFile atscfg.CacheKeyParameterConfigFile.
-	CacheKeyParams []tc.Parameter `json:"cache_key_parameters,omitempty"`
-
-	// ParentConfigParams must be all Parameters with the ConfigFile "parent.config.
-	ParentConfigParams []tc.Parameter `json:"parent_config_parameters,omitempty"`
-
-	// DeliveryServices must include all Delivery Services on the current server's cdn, including those not assigned to the server. Must not include delivery services on other cdns.
-	DeliveryServices []atscfg.DeliveryService `json:"delivery_services,omitempty"`
-
-	// DeliveryServiceServers must include all delivery service servers in Traffic Ops for all delivery services on the current cdn, including those not assigned to the current server.
-	DeliveryServiceServers []atscfg.DeliveryServiceServer `json:"delivery_service_servers,omitempty"`
-
-	// Server must be the server we're fetching configs from
-	Server *atscfg.Server `json:"server,omitempty"`
-
-	// Jobs must be all Jobs on the server's CDN. May include jobs on other CDNs.
-	Jobs []tc.InvalidationJob `json:"jobs,omitempty"`
-
-	// CDN must be the CDN of the server.
-	CDN *tc.CDN `json:"cdn,omitempty"`
-
-	// DeliveryServiceRegexes must be all regexes on all delivery services on this server's cdn.
-	DeliveryServiceRegexes []tc.DeliveryServiceRegexes `json:"delivery_service_regexes,omitempty"`
-
-	// Profile must be the Profile of the server being requested.
-	Profile tc.Profile `json:"profile,omitempty"`
-
-	// URISigningKeys must be a map of every delivery service which is URI Signed, to its keys.
-	URISigningKeys map[tc.DeliveryServiceName][]byte `json:"uri_signing_keys,omitempty"`
-
-	// URLSigKeys must be a map of every delivery service which uses URL Sig, to its keys.
-	URLSigKeys map[tc.DeliveryServiceName]tc.URLSigKeys `json:"url_sig_keys,omitempty"`
-
-	// ServerCapabilities must be a map of all server IDs on this server's CDN, to a set of their capabilities. May also include servers from other cdns.
-	ServerCapabilities map[int]map[atscfg.ServerCapability]struct{} `json:"server_capabilities,omitempty"`
-
-	// DSRequiredCapabilities must be a map of all delivery service IDs on this server's CDN, to a set of their required capabilities. Delivery Services with no required capabilities may not have an entry in the map.
-	DSRequiredCapabilities map[int]map[atscfg.ServerCapability]struct{} `json:"delivery_service_required_capabilities,omitempty"`
-
-	// SSLKeys must be all the ssl keys for the server's cdn.
-	SSLKeys []tc.CDNSSLKeys `json:"ssl_keys,omitempty"`
-
-	// Topologies must be all the topologies for the server's cdn.
-	// May incude topologies of other cdns.
-	Topologies []tc.Topology `json:"topologies,omitempty"`
-
-	// TrafficOpsAddresses is the list of IP addresses used to request data. Because of proxies and load balancers,
-	// multiple addresses may be used for the multiple requests necessary to fetch all data.
-	TrafficOpsAddresses []string `json:"traffic_ops_addresses,omitempty"`
-	TrafficOpsURL       string   `json:"traffic_ops_url,omitempty"`
-
-	MetaData ConfigDataMetaData `json:"metadata"`
-}
-
-type ConfigDataMetaData struct {
-	Servers                ReqMetaData                            `json:"servers"`
-	CacheGroups            ReqMetaData                            `json:"cache_groups"`
-	GlobalParams           ReqMetaData                            `json:"global_parameters"`
-	ServerParams           ReqMetaData                            `json:"server_parameters"`
-	CacheKeyParams         ReqMetaData                            `json:"cache_key_parameters"`
-	ParentConfigParams     ReqMetaData                            `json:"parent_config_parameters"`
-	DeliveryServices       ReqMetaData                            `json:"delivery_services"`
-	DeliveryServiceServers ReqMetaData                            `json:"delivery_service_servers"`
-	Jobs                   ReqMetaData                            `json:"jobs"`
-	CDN                    ReqMetaData                            `json:"cdn"`
-	DeliveryServiceRegexes ReqMetaData                            `json:"delivery_service_regexes"`
-	Profile                ReqMetaData                            `json:"profile"`
-	URISigningKeys         map[tc.DeliveryServiceName]ReqMetaData `json:"uri_signing_keys"`
-	URLSigKeys             map[tc.DeliveryServiceName]ReqMetaData `json:"url_sig_keys"`
-	ServerCapabilities     ReqMetaData                            `json:"server_capabilities"`
-	DSRequiredCapabilities ReqMetaData                            `json:"delivery_service_required_capabilities"`
-	SSLKeys                ReqMetaData                            `json:"ssl_keys"`
-	Topologies             ReqMetaData                            `json:"topologies"`
-}
-
-// ReqMetaData has response headers for Conditional Requests.
-type ReqMetaData struct {
-	LastModified string `json:"last_modified"`
-	Date         string `json:"date"`
-	ETag         string `json:"etag"`
-}
+type ReqMetaData = atscfg.ReqMetaData
 
 func MakeReqHdr(md ReqMetaData) http.Header {
 	if md.LastModified == "" && md.Date == "" && md.ETag == "" {
@@ -292,7 +205,7 @@ func GetConfigData(toClient *toreq.TOClient, disableProxy bool, cacheHostName st
 				if oldCfg != nil {
 					reqHdr = MakeReqHdr(oldCfg.MetaData.DeliveryServices)
 				}
-				dses, reqInf, err := toClient.GetCDNDeliveryServices(*server.CDNID, reqHdr)
+				dses, dsTLSPolicies, reqInf, err := toClient.GetCDNDeliveryServices(*server.CDNID, reqHdr)
 				if err != nil {
 					return errors.New("getting delivery services: " + err.Error())
 				}
@@ -300,9 +213,11 @@ func GetConfigData(toClient *toreq.TOClient, disableProxy bool, cacheHostName st
 				if reqInf.StatusCode == http.StatusNotModified {
 					log.Infof("Getting config: %v not modified, using old config", "DeliveryServices")
 					toData.DeliveryServices = oldCfg.DeliveryServices
+					toData.DeliveryServiceTLSPolicies = oldCfg.DeliveryServiceTLSPolicies
 				} else {
 					log.Infof("Getting config: %v is modified, using new response", "DeliveryServices")
 					toData.DeliveryServices = dses
+					toData.DeliveryServiceTLSPolicies = dsTLSPolicies
 				}
 				toData.MetaData.DeliveryServices = MakeReqMetaData(reqInf.RespHeaders)
 				toIPs.Store(reqInf.RemoteAddr, nil)
@@ -684,6 +599,29 @@ func GetConfigData(toClient *toreq.TOClient, disableProxy bool, cacheHostName st
 		}
 		return nil
 	}
+	loggingYAMLParamsF := func() error {
+		defer func(start time.Time) { log.Infof("loggingYAMLParamsF took %v\n", time.Since(start)) }(time.Now())
+		{
+			reqHdr := (http.Header)(nil)
+			if oldCfg != nil {
+				reqHdr = MakeReqHdr(oldCfg.MetaData.LoggingYAMLParams)
+			}
+			params, reqInf, err := toClient.GetConfigFileParameters(atscfg.LoggingYAMLFileName, reqHdr)
+			if err != nil {
+				return errors.New("getting logging.yaml parameters: " + err.Error())
+			}
+			if reqInf.StatusCode == http.StatusNotModified {
+				log.Infof("Getting config: %v not modified, using old config", "LoggingYAMLParams")
+				toData.LoggingYAMLParams = oldCfg.LoggingYAMLParams
+			} else {
+				log.Infof("Getting config: %v is modified, using new response", "LoggingYAMLParams")
+				toData.LoggingYAMLParams = params
+			}
+			toData.MetaData.LoggingYAMLParams = MakeReqMetaData(reqInf.RespHeaders)
+			toIPs.Store(reqInf.RemoteAddr, nil)
+		}
+		return nil
+	}
 
 	topologiesF := func() error {
 		defer func(start time.Time) { log.Infof("topologiesF took %v\n", time.Since(start)) }(time.Now())
@@ -712,7 +650,7 @@ func GetConfigData(toClient *toreq.TOClient, disableProxy bool, cacheHostName st
 	fs := []func() error{serversF, cgF, jobsF}
 	if !revalOnly {
 		// skip data not needed for reval, if we're reval-only
-		fs = append([]func() error{dsrF, cacheKeyParamsF, parentConfigParamsF, capsF, dsCapsF, topologiesF}, fs...)
+		fs = append([]func() error{dsrF, cacheKeyParamsF, parentConfigParamsF, loggingYAMLParamsF, capsF, dsCapsF, topologiesF}, fs...)
 	}
 	errs := runParallel(fs)
 
@@ -22,21 +22,15 @@ package t3cutil
 import (
 	"bytes"
 	"fmt"
-	"html"
 	"io/ioutil"
 	"os"
 	"os/exec"
-	"regexp"
-	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
 )
 
-type ATSConfigFile struct {
-	Name        string `json:"name"`
-	Path        string `json:"path"`
-	ContentType string `json:"content_type"`
-	LineComment string `json:"line_comment"`
-	Text        string `json:"text"`
-}
+// ATSConfigFile is a generated config file. See atscfg.ConfigFile.
+type ATSConfigFile = atscfg.ConfigFile
 
 // ATSConfigFiles implements sort.Interface and sorts by the Location and then FileNameOnDisk, i.e. the full file path.
 type ATSConfigFiles []ATSConfigFile
@@ -50,32 +44,6 @@ func (fs ATSConfigFiles) Less(i, j int) bool {
 }
 func (fs ATSConfigFiles) Swap(i, j int) { fs[i], fs[j] = fs[j], fs[i] }
 
-// commentsFilter is used to remove comment
-// lines from config files while making
-// comparisons.
-func CommentsFilter(body []string) []string {
-	var newlines []string
-
-	newlines = make([]string, 0)
-
-	for ii := range body {
-		line := body[ii]
-		if strings.HasPrefix(line, "#") {
-			continue
-		}
-		newlines = append(newlines, line)
-	}
-
-	return newlines
-}
-
-// NewLineFilter removes carriage returns
-// from config files while making comparisons.
-func NewLineFilter(str string) string {
-	str = strings.ReplaceAll(str, "\r\n", "\n")
-	return strings.TrimSpace(str)
-}
-
 // ReadFile reads a file and returns the
 // file contents.
 func ReadFile(f string) []byte {
@@ -87,27 +55,6 @@ func ReadFile(f string) []byte {
 	return data
 }
 
-// UnencodeFilter translates HTML escape
-// sequences while making config file comparisons.
-func UnencodeFilter(body []string) []string {
-	var newlines []string
-
-	newlines = make([]string, 0)
-	sp := regexp.MustCompile(`\s+`)
-	el := regexp.MustCompile(`^\s+|\s+$`)
-
-	for ii := range body {
-		s := body[ii]
-		s = sp.ReplaceAllString(s, " ")
-		s = el.ReplaceAllString(s, "")
-		s = html.UnescapeString(s)
-		s = strings.TrimSpace(s)
-		newlines = append(newlines, s)
-	}
-
-	return newlines
-}
-
 // Do executes the given command and returns the stdout, stderr, and exit code.
 
 // This is a convenience wrapper around os/exec.
@@ -77,6 +77,59 @@ func V30ToDeliveryServices(dses []tc.DeliveryServiceNullableV30) []DeliveryServi
 	return ad
 }
 
+// V40ToDeliveryServices converts a slice of the traffic_ops/v4-client type to
+// the local alias. Fields only in 4.x are dropped; see
+// ToDeliveryServiceTLSPolicies.
+func V40ToDeliveryServices(dses []tc.DeliveryServiceV4) []DeliveryService {
+	ad := []DeliveryService{}
+	for _, ds := range dses {
+		ad = append(ad, DeliveryService(ds.DowngradeToV3()))
+	}
+	return ad
+}
+
+// DeliveryServiceTLSPolicy is the TLS policy of a Delivery Service, for
+// client connections to its cache servers.
+//
+// These are fields of tc.DeliveryServiceV4 which DeliveryService doesn't have,
+// so they're passed separately to the functions which use them.
+type DeliveryServiceTLSPolicy struct {
+	// TLSVersions overrides the tls_versions Parameter, if not empty.
+	TLSVersions   []string `json:"tlsVersions,omitempty"`
+	Ciphers       []string `json:"ciphers,omitempty"`
+	CipherSuites  []string `json:"cipherSuites,omitempty"`
+	VerifyClient  string   `json:"verifyClient,omitempty"`
+	HostSNIPolicy string   `json:"hostSNIPolicy,omitempty"`
+	OCSPStapling  bool     `json:"ocspStapling,omitempty"`
+}
+
+// ToDeliveryServiceTLSPolicies returns the TLS policies of the given Delivery
+// Services, by name. Delivery Services without any policy aren't included.
+func ToDeliveryServiceTLSPolicies(dses []tc.DeliveryServiceV4) map[tc.DeliveryServiceName]DeliveryServiceTLSPolicy {
+	policies := map[tc.DeliveryServiceName]DeliveryServiceTLSPolicy{}
+	for _, ds := range dses {
+		if ds.XMLID == nil || (len(ds.TLSVersions) == 0 && !ds.HasTLSPolicy()) {
+			continue
+		}
+		policy := DeliveryServiceTLSPolicy{
+			TLSVersions:  ds.TLSVersions,
+			Ciphers:      ds.TLSCiphers,
+			CipherSuites: ds.TLSCipherSuites,
+		}
+		if ds.TLSVerifyClient != nil {
+			policy.VerifyClient = *ds.TLSVerifyClient
+		}
+		if ds.TLSHostSNIPolicy != nil {
+			policy.HostSNIPolicy = *ds.TLSHostSNIPolicy
+		}
+		if ds.TLSOCSPStapling != nil {
+			policy.OCSPStapling = *ds.TLSOCSPStapling
+		}
+		policies[tc.DeliveryServiceName(*ds.XMLID)] = policy
+	}
+	return policies
+}
+
 // ToServers converts a slice of the latest lib/go-tc and traffic_ops/vx-client type to the local alias.
 func ToServers(servers []tc.ServerV30) []Server {
 	as := []Server{}
@@ -0,0 +1,207 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// ConfigData is the Traffic Ops data necessary to generate config for a
+// cache, as fetched by t3c.
+type ConfigData struct {
+	// Servers must be all the servers from Traffic Ops. May include servers not on the current cdn.
+	Servers []Server `json:"servers,omitempty"`
+
+	// CacheGroups must be all cachegroups in Traffic Ops with Servers on the current server's cdn. May also include CacheGroups without servers on the current cdn.
+	CacheGroups []tc.CacheGroupNullable `json:"cache_groups,omitempty"`
+
+	// GlobalParams must be all Parameters in Traffic Ops on the tc.GlobalProfileName Profile. Must not include other parameters.
+	GlobalParams []tc.Parameter `json:"global_parameters,omitempty"`
+
+	// ServerParams must be all Parameters on the Profile of the current server. Must not include other Parameters.
+	ServerParams []tc.Parameter `json:"server_parameters,omitempty"`
+
+	// CacheKeyParams must be all Parameters with the ConfigFile CacheKeyParameterConfigFile.
+	CacheKeyParams []tc.Parameter `json:"cache_key_parameters,omitempty"`
+
+	// ParentConfigParams must be all Parameters with the ConfigFile "parent.config.
+	ParentConfigParams []tc.Parameter `json:"parent_config_parameters,omitempty"`
+
+	// LoggingYAMLParams must be all Parameters with the ConfigFile LoggingYAMLFileName. This includes the Delivery Service Profile Parameters for Delivery Service logs.
+	LoggingYAMLParams []tc.Parameter `json:"logging_yaml_parameters,omitempty"`
+
+	// DeliveryServices must include all Delivery Services on the current server's cdn, including those not assigned to the server. Must not include delivery services on other cdns.
+	DeliveryServices []DeliveryService `json:"delivery_services,omitempty"`
+
+	// DeliveryServiceTLSPolicies must be the TLS policies of DeliveryServices, by name. It's nil if Traffic Ops doesn't support them.
+	DeliveryServiceTLSPolicies map[tc.DeliveryServiceName]DeliveryServiceTLSPolicy `json:"delivery_service_tls_policies,omitempty"`
+
+	// DeliveryServiceServers must include all delivery service servers in Traffic Ops for all delivery services on the current cdn, including those not assigned to the current server.
+	DeliveryServiceServers []DeliveryServiceServer `json:"delivery_service_servers,omitempty"`
+
+	// Server must be the server we're fetching configs from
+	Server *Server `json:"server,omitempty"`
+
+	// Jobs must be all Jobs on the server's CDN. May include jobs on other CDNs.
+	Jobs []tc.InvalidationJob `json:"jobs,omitempty"`
+
+	// CDN must be the CDN of the server.
+	CDN *tc.CDN `json:"cdn,omitempty"`
+
+	// DeliveryServiceRegexes must be all regexes on all delivery services on this server's cdn.
+	DeliveryServiceRegexes []tc.DeliveryServiceRegexes `json:"delivery_service_regexes,omitempty"`
+
+	// Profile must be the Profile of the server being requested.
+	Profile tc.Profile `json:"profile,omitempty"`
+
+	// URISigningKeys must be a map of every delivery service which is URI Signed, to its keys.
+	URISigningKeys map[tc.DeliveryServiceName][]byte `json:"uri_signing_keys,omitempty"`
+
+	// URLSigKeys must be a map of every delivery service which uses URL Sig, to its keys.
+	URLSigKeys map[tc.DeliveryServiceName]tc.URLSigKeys `json:"url_sig_keys,omitempty"`
+
+	// ServerCapabilities must be a map of all server IDs on this server's CDN, to a set of their capabilities. May also include servers from other cdns.
+	ServerCapabilities map[int]map[ServerCapability]struct{} `json:"server_capabilities,omitempty"`
+
+	// DSRequiredCapabilities must be a map of all delivery service IDs on this server's CDN, to a set of their required capabilities. Delivery Services with no required capabilities may not have an entry in the map.
+	DSRequiredCapabilities map[int]map[ServerCapability]struct{} `json:"delivery_service_required_capabilities,omitempty"`
+
+	// SSLKeys must be all the ssl keys for the server's cdn.
+	SSLKeys []tc.CDNSSLKeys `json:"ssl_keys,omitempty"`
+
+	// Topologies must be all the topologies for the server's cdn.
+	// May incude topologies of other cdns.
+	Topologies []tc.Topology `json:"topologies,omitempty"`
+
+	// TrafficOpsAddresses is the list of IP addresses used to request data. Because of proxies and load balancers,
+	// multiple addresses may be used for the multiple requests necessary to fetch all data.
+	TrafficOpsAddresses []string `json:"traffic_ops_addresses,omitempty"`
+	TrafficOpsURL       string   `json:"traffic_ops_url,omitempty"`
+
+	MetaData ConfigDataMetaData `json:"metadata"`
+}
+
+type ConfigDataMetaData struct {
+	Servers                ReqMetaData                            `json:"servers"`
+	CacheGroups            ReqMetaData                            `json:"cache_groups"`
+	GlobalParams           ReqMetaData                            `json:"global_parameters"`
+	ServerParams           ReqMetaData                            `json:"server_parameters"`
+	CacheKeyParams         ReqMetaData                            `json:"cache_key_parameters"`
+	ParentConfigParams     ReqMetaData                            `json:"parent_config_parameters"`
+	LoggingYAMLParams      ReqMetaData                            `json:"logging_yaml_parameters"`
+	DeliveryServices       ReqMetaData                            `json:"delivery_services"`
+	DeliveryServiceServers ReqMetaData                            `json:"delivery_service_servers"`
+	Jobs                   ReqMetaData                            `json:"jobs"`
+	CDN                    ReqMetaData                            `json:"cdn"`
+	DeliveryServiceRegexes ReqMetaData                            `json:"delivery_service_regexes"`
+	Profile                ReqMetaData                            `json:"profile"`
+	URISigningKeys         map[tc.DeliveryServiceName]ReqMetaData `json:"uri_signing_keys"`
+	URLSigKeys             map[tc.DeliveryServiceName]ReqMetaData `json:"url_sig_keys"`
+	ServerCapabilities     ReqMetaData                            `json:"server_capabilities"`
+	DSRequiredCapabilities ReqMetaData                            `json:"delivery_service_required_capabilities"`
+	SSLKeys                ReqMetaData                            `json:"ssl_keys"`
+	Topologies             ReqMetaData                            `json:"topologies"`
+}
+
+// ReqMetaData has response headers for Conditional Requests.
+type ReqMetaData struct {
+	LastModified string `json:"last_modified"`
+	Date         string `json:"date"`
+	ETag         string `json:"etag"`
+}
+
+// CDNConfigData is the Traffic Ops data necessary to generate config for
+// every cache on a CDN.
+//
+// The embedded ConfigData has the CDN-wide data. Its Server, ServerParams, and
+// Profile are those of the cache the data was requested for, and should not be
+// used directly; use ServerConfigData to get the ConfigData of each cache.
+type CDNConfigData struct {
+	ConfigData
+
+	// ProfileParams must be all Parameters on the Profile of each cache on the CDN, by Profile name.
+	ProfileParams map[string][]tc.Parameter `json:"profile_parameters"`
+
+	// Profiles must be the Profile of each cache on the CDN, by name.
+	Profiles map[string]tc.Profile `json:"profiles"`
+}
+
+// IsCache returns whether the server is a cache, whose config is generated by
+// t3c.
+func IsCache(sv *Server) bool {
+	return strings.HasPrefix(sv.Type, tc.EdgeTypePrefix) || strings.HasPrefix(sv.Type, tc.MidTypePrefix)
+}
+
+// CacheServers returns the caches on the CDN, sorted by host name.
+func (cd *CDNConfigData) CacheServers() []Server {
+	caches := []Server{}
+	for _, sv := range cd.Servers {
+		if sv.HostName == nil || sv.Profile == nil || sv.CDNID == nil || cd.CDN == nil || *sv.CDNID != cd.CDN.ID || !IsCache(&sv) {
+			continue
+		}
+		caches = append(caches, sv)
+	}
+	sort.Slice(caches, func(i, j int) bool { return *caches[i].HostName < *caches[j].HostName })
+	return caches
+}
+
+// ServerConfigData returns the ConfigData to generate config for the cache
+// with the given host name.
+//
+// The returned ConfigData shares its CDN-wide data with cd, so it must not be
+// modified.
+func (cd *CDNConfigData) ServerConfigData(cacheHostName string) (*ConfigData, error) {
+	server := (*Server)(nil)
+	for _, sv := range cd.Servers {
+		if sv.HostName != nil && *sv.HostName == cacheHostName {
+			sv := sv
+			server = &sv
+			break
+		}
+	}
+	if server == nil {
+		return nil, errors.New("server '" + cacheHostName + "' not found in servers")
+	} else if server.Profile == nil {
+		return nil, errors.New("server '" + cacheHostName + "' missing Profile")
+	}
+	params, ok := cd.ProfileParams[*server.Profile]
+	if !ok {
+		return nil, errors.New("server '" + cacheHostName + "' profile '" + *server.Profile + "' parameters not found")
+	}
+
+	toData := cd.ConfigData
+	toData.Server = server
+	toData.ServerParams = params
+	toData.Profile = cd.Profiles[*server.Profile]
+	return &toData, nil
+}
+
+// ConfigFile is a generated config file, with its location on the cache.
+type ConfigFile struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	ContentType string `json:"content_type"`
+	LineComment string `json:"line_comment"`
+	Text        string `json:"text"`
+}
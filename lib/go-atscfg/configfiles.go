@@ -0,0 +1,494 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"strings"
+)
+
+// ConfigFilesOpts contains settings to configure generating the config files
+// of a cache from its ConfigData.
+type ConfigFilesOpts struct {
+	// Dir is the ATS config directory, used for config files without
+	// location Parameters.
+	Dir string
+
+	// RevalOnly is whether to only generate regex_revalidate.config.
+	RevalOnly bool
+
+	// ViaRelease is whether to set the ATS release version in the Via header.
+	// See RecordsConfigOpts.ReleaseViaStr.
+	ViaRelease bool
+
+	// SetDNSLocalBind is whether to bind DNS requests to the service address.
+	// See RecordsConfigOpts.DNSLocalBindServiceAddr.
+	SetDNSLocalBind bool
+
+	// ParentComments is whether to add comments to parent.config.
+	ParentComments bool
+
+	// DefaultEnableH2 is whether HTTP/2 is enabled for Delivery Services
+	// without a Parameter enabling or disabling it.
+	DefaultEnableH2 bool
+
+	// DefaultTLSVersions are the TLS versions enabled for Delivery Services
+	// without a Parameter setting them.
+	DefaultTLSVersions []TLSVersion
+}
+
+// MakeConfigFiles generates all config files of the cache of toData, with the
+// given header comment. It returns the config files, any warnings, and any
+// error.
+//
+// SSL certificates and keys are not generated.
+func MakeConfigFiles(toData *ConfigData, hdrCommentTxt string, opts ConfigFilesOpts) ([]ConfigFile, []string, error) {
+	warnings := []string{}
+	if toData.Server == nil || toData.Server.HostName == nil {
+		return nil, warnings, errors.New("server hostname is nil")
+	}
+
+	configFiles, listWarns, err := MakeConfigFilesList(
+		opts.Dir,
+		toData.Server,
+		toData.ServerParams,
+		toData.DeliveryServices,
+		toData.DeliveryServiceServers,
+		toData.GlobalParams,
+		toData.CacheGroups,
+		toData.Topologies,
+	)
+	warnings = append(warnings, makeWarnings("generating config files list: ", listWarns)...)
+	if err != nil {
+		return nil, warnings, errors.New("creating meta: " + err.Error())
+	}
+
+	configFiles, sampleWarns := addLogSampleConfigFile(toData, configFiles)
+	warnings = append(warnings, sampleWarns...)
+
+	configs := []ConfigFile{}
+	for _, fi := range configFiles {
+		if opts.RevalOnly && fi.Name != RegexRevalidateFileName {
+			continue
+		}
+		cfg, err := MakeConfigFile(toData, fi, hdrCommentTxt, opts)
+		warnings = append(warnings, makeWarnings("getting config file '"+fi.Name+"': ", cfg.Warnings)...)
+		if err != nil {
+			return nil, warnings, errors.New("getting config file '" + fi.Name + "': " + err.Error())
+		}
+		configs = append(configs, ConfigFile{Name: fi.Name, Path: fi.Path, Text: cfg.Text, ContentType: cfg.ContentType, LineComment: cfg.LineComment})
+	}
+	return configs, warnings, nil
+}
+
+// MakeConfigFile generates the given config file of the cache of toData, with
+// the given header comment.
+//
+// This dispatches to the Make func of the file by its name, so callers with
+// all the data of a cache don't need to know which data each file needs.
+func MakeConfigFile(toData *ConfigData, fileInfo CfgMeta, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return getConfigFileFunc(fileInfo.Name)(toData, fileInfo.Name, hdrCommentTxt, opts)
+}
+
+// addLogSampleConfigFile returns the configFiles with the LogSampleFileName, in the directory of plugin.config which loads it,
+// if the server has sampled Delivery Service logs, and any warnings.
+func addLogSampleConfigFile(toData *ConfigData, configFiles []CfgMeta) ([]CfgMeta, []string) {
+	warnings := []string{}
+	pluginDir := ""
+	for _, fi := range configFiles {
+		if fi.Name == PluginFileName {
+			pluginDir = fi.Path
+		}
+	}
+	if len(GetLogSampleRates(toData.Server, toData.ServerParams, loggingDotYAMLOpts(toData, ""))) == 0 {
+		return configFiles, warnings
+	}
+	if pluginDir == "" {
+		warnings = append(warnings, "server has sampled Delivery Service logs but no "+PluginFileName+" to load "+LogSampleFileName+", sampled logs will log nothing!")
+		return configFiles, warnings
+	}
+	return append(configFiles, CfgMeta{Name: LogSampleFileName, Path: pluginDir}), warnings
+}
+
+func makeWarnings(context string, warnings []string) []string {
+	prefixed := make([]string, 0, len(warnings))
+	for _, warn := range warnings {
+		prefixed = append(prefixed, context+warn)
+	}
+	return prefixed
+}
+
+type configFileFunc func(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error)
+
+type configFilePrefixSuffixFunc struct {
+	Prefix string
+	Suffix string
+	Func   configFileFunc
+}
+
+type configFileLiteralFunc struct {
+	Name string
+	Func configFileFunc
+}
+
+func getConfigFileFunc(fileName string) configFileFunc {
+	for _, lf := range configFileLiteralFuncs {
+		if fileName == lf.Name {
+			return lf.Func
+		}
+	}
+	for _, psf := range configFilePrefixSuffixFuncs {
+		if strings.HasPrefix(fileName, psf.Prefix) && strings.HasSuffix(fileName, psf.Suffix) {
+			return psf.Func
+		}
+	}
+	return makeUnknownConfig
+}
+
+var configFileLiteralFuncs = []configFileLiteralFunc{
+	{"12M_facts", make12MFacts},
+	{"50-ats.rules", makeATSDotRules},
+	{"astats.config", makeAstatsDotConfig},
+	{"bg_fetch.config", makeBGFetchDotConfig},
+	{"cache.config", makeCacheDotConfig},
+	{"chkconfig", makeChkconfig},
+	{"drop_qstring.config", makeDropQStringDotConfig},
+	{"hosting.config", makeHostingDotConfig},
+	{"ip_allow.config", makeIPAllowDotConfig},
+	{"ip_allow.yaml", makeIPAllowDotYAML},
+	{"logging.config", makeLoggingDotConfig},
+	{"logging.yaml", makeLoggingDotYAML},
+	{LogSampleFileName, makeLogSampleDotConfig},
+	{"logs_xml.config", makeLogsXMLDotConfig},
+	{"packages", makePackages},
+	{"parent.config", makeParentDotConfig},
+	{"plugin.config", makePluginDotConfig},
+	{"records.config", makeRecordsDotConfig},
+	{"regex_revalidate.config", makeRegexRevalidateDotConfig},
+	{"remap.config", makeRemapDotConfig},
+	{"ssl_multicert.config", makeSSLMultiCertDotConfig},
+	{"ssl_server_name.yaml", makeSSLServerNameYAML},
+	{"sni.yaml", makeSNIDotYAML},
+	{"storage.config", makeStorageDotConfig},
+	{"sysctl.conf", makeSysCtlDotConf},
+	{"volume.config", makeVolumeDotConfig},
+}
+
+var configFilePrefixSuffixFuncs = []configFilePrefixSuffixFunc{
+	{HeaderRewriteFirstPrefix, ".config", makeHeaderRewrite},
+	{HeaderRewriteInnerPrefix, ".config", makeHeaderRewrite},
+	{HeaderRewriteLastPrefix, ".config", makeHeaderRewrite},
+	{"hdr_rw_mid_", ".config", makeHeaderRewrite},
+	{"hdr_rw_", ".config", makeHeaderRewrite},
+	{"regex_remap_", ".config", makeRegexRemap},
+	{"set_dscp_", ".config", makeSetDSCP},
+	{"url_sig_", ".config", makeURLSigConfigFile},
+	{"uri_signing_", ".config", makeURISigningConfigFile},
+}
+
+// The funcs below wrap the Make funcs into configFileFuncs.
+//
+// The Make funcs don't take a ConfigData, because then users wanting to generate a single file would have to fetch all kinds of data that file doesn't need, or else pass objects they know it doesn't currently need as nil and risk it crashing if that func is changed to use it in the future.
+//
+// But it's useful to map filenames to functions for dispatch. Hence these wrappers.
+
+func make12MFacts(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return Make12MFacts(toData.Server, hdrCommentTxt)
+}
+
+func makeATSDotRules(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeATSDotRules(toData.Server, toData.ServerParams, hdrCommentTxt)
+}
+
+func makeAstatsDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeAStatsDotConfig(toData.Server, toData.ServerParams, hdrCommentTxt)
+}
+
+func makeBGFetchDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeBGFetchDotConfig(toData.Server, hdrCommentTxt)
+}
+
+func makeCacheDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeCacheDotConfig(toData.Server, toData.Servers, toData.DeliveryServices, toData.DeliveryServiceServers, hdrCommentTxt)
+}
+
+func makeChkconfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeChkconfig(toData.ServerParams)
+}
+
+func makeDropQStringDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeDropQStringDotConfig(toData.Server, toData.ServerParams, hdrCommentTxt)
+}
+
+func makeHostingDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeHostingDotConfig(toData.Server, toData.Servers, toData.ServerParams, toData.DeliveryServices, toData.DeliveryServiceServers, toData.Topologies, hdrCommentTxt)
+}
+
+func makeIPAllowDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeIPAllowDotConfig(
+		toData.ServerParams,
+		toData.Server,
+		toData.Servers,
+		toData.CacheGroups,
+		toData.Topologies,
+		hdrCommentTxt,
+	)
+}
+
+func makeIPAllowDotYAML(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeIPAllowDotYAML(
+		toData.ServerParams,
+		toData.Server,
+		toData.Servers,
+		toData.CacheGroups,
+		toData.Topologies,
+		hdrCommentTxt,
+	)
+}
+
+func makeLoggingDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeLoggingDotConfig(toData.Server, toData.ServerParams, hdrCommentTxt)
+}
+
+func makeLoggingDotYAML(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeLoggingDotYAML(toData.Server, toData.ServerParams, loggingDotYAMLOpts(toData, hdrCommentTxt))
+}
+
+func makeLogSampleDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeLogSampleDotConfig(toData.Server, toData.ServerParams, loggingDotYAMLOpts(toData, hdrCommentTxt))
+}
+
+func loggingDotYAMLOpts(toData *ConfigData, hdrCommentTxt string) LoggingDotYAMLOpts {
+	return LoggingDotYAMLOpts{
+		HdrComment:             hdrCommentTxt,
+		DeliveryServices:       toData.DeliveryServices,
+		DeliveryServiceServers: toData.DeliveryServiceServers,
+		DeliveryServiceRegexes: toData.DeliveryServiceRegexes,
+		DSLoggingParams:        toData.LoggingYAMLParams,
+		CDN:                    toData.CDN,
+		Topologies:             toData.Topologies,
+		CacheGroups:            toData.CacheGroups,
+		ServerCapabilities:     toData.ServerCapabilities,
+		DSRequiredCapabilities: toData.DSRequiredCapabilities,
+	}
+}
+
+func makeSSLServerNameYAML(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeSSLServerNameYAML(
+		toData.Server,
+		toData.DeliveryServices,
+		toData.DeliveryServiceServers,
+		toData.DeliveryServiceRegexes,
+		toData.ParentConfigParams,
+		toData.CDN,
+		toData.Topologies,
+		toData.CacheGroups,
+		toData.ServerCapabilities,
+		toData.DSRequiredCapabilities,
+		toData.DeliveryServiceTLSPolicies,
+		SSLServerNameYAMLOpts{
+			HdrComment:         hdrCommentTxt,
+			VerboseComments:    true, // TODO add a CLI flag
+			DefaultTLSVersions: opts.DefaultTLSVersions,
+			DefaultEnableH2:    opts.DefaultEnableH2,
+		},
+	)
+}
+
+func makeSNIDotYAML(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeSNIDotYAML(
+		toData.Server,
+		toData.DeliveryServices,
+		toData.DeliveryServiceServers,
+		toData.DeliveryServiceRegexes,
+		toData.ParentConfigParams,
+		toData.CDN,
+		toData.Topologies,
+		toData.CacheGroups,
+		toData.ServerCapabilities,
+		toData.DSRequiredCapabilities,
+		toData.DeliveryServiceTLSPolicies,
+		SNIDotYAMLOpts{
+			HdrComment:         hdrCommentTxt,
+			VerboseComments:    true, // TODO add a CLI flag
+			DefaultTLSVersions: opts.DefaultTLSVersions,
+			DefaultEnableH2:    opts.DefaultEnableH2,
+		},
+	)
+}
+
+func makeLogsXMLDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeLogsXMLDotConfig(toData.Server, toData.ServerParams, hdrCommentTxt)
+}
+
+func makePackages(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakePackages(toData.ServerParams)
+}
+
+func makeParentDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeParentDotConfig(
+		toData.DeliveryServices,
+		toData.Server,
+		toData.Servers,
+		toData.Topologies,
+		toData.ServerParams,
+		toData.ParentConfigParams,
+		toData.ServerCapabilities,
+		toData.DSRequiredCapabilities,
+		toData.CacheGroups,
+		toData.DeliveryServiceServers,
+		toData.CDN,
+		ParentConfigOpts{
+			HdrComment:  hdrCommentTxt,
+			AddComments: opts.ParentComments, // TODO add a CLI flag?
+		},
+	)
+}
+
+func makePluginDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	cfg, err := MakePluginDotConfig(toData.Server, toData.ServerParams, hdrCommentTxt)
+	if err == nil && len(GetLogSampleRates(toData.Server, toData.ServerParams, loggingDotYAMLOpts(toData, ""))) > 0 {
+		cfg.Text = strings.TrimSuffix(cfg.Text, "\n") + "\n" + LogSamplePluginLine + "\n"
+	}
+	return cfg, err
+}
+
+func makeRecordsDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	// ATS can only enable OCSP stapling globally, so it's enabled if any DS on the server wants it.
+	ocspStapling, ocspErr := serverHasOCSPStapling(toData, opts)
+	recordsCfg, err := MakeRecordsDotConfig(
+		toData.Server,
+		toData.ServerParams,
+		hdrCommentTxt,
+		RecordsConfigOpts{
+			ReleaseViaStr:           opts.ViaRelease,
+			DNSLocalBindServiceAddr: opts.SetDNSLocalBind,
+			OCSPStapling:            ocspStapling,
+		},
+	)
+	if err == nil && ocspErr != nil {
+		recordsCfg.Warnings = append(recordsCfg.Warnings, "getting server delivery service TLS policies, not enabling OCSP stapling: "+ocspErr.Error())
+	}
+	return recordsCfg, err
+}
+
+// serverHasOCSPStapling returns whether any Delivery Service with TLS on the server has OCSP stapling in its TLS policy.
+func serverHasOCSPStapling(toData *ConfigData, opts ConfigFilesOpts) (bool, error) {
+	if len(toData.DeliveryServiceTLSPolicies) == 0 {
+		return false, nil
+	}
+	sslDatas, _, err := GetServerSSLData(
+		toData.Server,
+		toData.DeliveryServices,
+		toData.DeliveryServiceServers,
+		toData.DeliveryServiceRegexes,
+		toData.ParentConfigParams,
+		toData.CDN,
+		toData.Topologies,
+		toData.CacheGroups,
+		toData.ServerCapabilities,
+		toData.DSRequiredCapabilities,
+		toData.DeliveryServiceTLSPolicies,
+		opts.DefaultTLSVersions,
+		opts.DefaultEnableH2,
+	)
+	if err != nil {
+		return false, err
+	}
+	for _, sslData := range sslDatas {
+		if sslData.OCSPStapling {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func makeRegexRevalidateDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeRegexRevalidateDotConfig(toData.Server, toData.DeliveryServices, toData.GlobalParams, toData.Jobs, hdrCommentTxt)
+}
+
+func makeRemapDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeRemapDotConfig(
+		toData.Server,
+		toData.DeliveryServices,
+		toData.DeliveryServiceServers,
+		toData.DeliveryServiceRegexes,
+		toData.ServerParams,
+		toData.CDN,
+		toData.CacheKeyParams,
+		toData.Topologies,
+		toData.CacheGroups,
+		toData.ServerCapabilities,
+		toData.DSRequiredCapabilities,
+		hdrCommentTxt,
+	)
+}
+
+func makeSSLMultiCertDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeSSLMultiCertDotConfig(toData.Server, toData.DeliveryServices, hdrCommentTxt)
+}
+
+func makeStorageDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeStorageDotConfig(toData.Server, toData.ServerParams, hdrCommentTxt)
+}
+
+func makeSysCtlDotConf(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeSysCtlDotConf(toData.Server, toData.ServerParams, hdrCommentTxt)
+}
+
+func makeVolumeDotConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeVolumeDotConfig(toData.Server, toData.ServerParams, hdrCommentTxt)
+}
+
+func makeHeaderRewrite(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeHeaderRewriteDotConfig(
+		fileName,
+		toData.DeliveryServices,
+		toData.DeliveryServiceServers,
+		toData.Server,
+		toData.Servers,
+		toData.CacheGroups,
+		toData.ServerParams,
+		toData.ServerCapabilities,
+		toData.DSRequiredCapabilities,
+		toData.Topologies,
+		hdrCommentTxt,
+	)
+}
+
+func makeRegexRemap(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeRegexRemapDotConfig(fileName, toData.Server, toData.DeliveryServices, hdrCommentTxt)
+}
+
+func makeSetDSCP(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeSetDSCPDotConfig(fileName, toData.Server, hdrCommentTxt)
+}
+
+func makeURLSigConfigFile(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeURLSigConfig(fileName, toData.Server, toData.ServerParams, toData.URLSigKeys, hdrCommentTxt)
+}
+
+func makeURISigningConfigFile(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeURISigningConfig(fileName, toData.URISigningKeys)
+}
+
+func makeUnknownConfig(toData *ConfigData, fileName string, hdrCommentTxt string, opts ConfigFilesOpts) (Cfg, error) {
+	return MakeServerUnknown(fileName, toData.Server, toData.ServerParams, hdrCommentTxt)
+}
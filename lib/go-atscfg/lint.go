@@ -0,0 +1,677 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LintProblem is a problem found in an ATS config file by LintConfigFiles.
+type LintProblem struct {
+	File string `json:"file"`
+	// Line is the line of the file the problem is on, starting at 1, or 0 if
+	// the problem isn't on a particular line.
+	Line int    `json:"line"`
+	Msg  string `json:"msg"`
+}
+
+func (p LintProblem) String() string {
+	if p.Line == 0 {
+		return p.File + ": " + p.Msg
+	}
+	return p.File + ":" + strconv.Itoa(p.Line) + ": " + p.Msg
+}
+
+// LintOpts contains settings to configure linting.
+type LintOpts struct {
+	// ResolveHost returns whether a parent host name resolves.
+	// If nil, host names are only checked to be valid, with DefaultLintResolveHost.
+	ResolveHost func(host string) bool
+}
+
+// LintHostsFileName is the name of a hosts file, in the format of /etc/hosts,
+// whose hosts are resolvable when linting parent hosts. This is the file ATS
+// HostDB loads with the proxy.config.hostdb.host_file.path record.
+const LintHostsFileName = "hosts"
+
+// LintConfigFiles parses the given config files, and returns the problems in
+// them which would make ATS fail to load them or behave unexpectedly.
+//
+// This lints remap.config, parent.config, records.config,
+// ssl_multicert.config, ip_allow.yaml, sni.yaml, and logging.yaml files.
+// Other files are ignored, except a hosts file, see LintHostsFileName.
+//
+// Problems with references between files, such as parent hosts, are only found
+// if all the files are given together.
+func LintConfigFiles(files []CfgFile, opt *LintOpts) []LintProblem {
+	if opt == nil {
+		opt = &LintOpts{}
+	}
+	resolveHost := opt.ResolveHost
+	if resolveHost == nil {
+		resolveHost = DefaultLintResolveHost
+	}
+
+	hosts := map[string]struct{}{}
+	for _, file := range files {
+		if file.Name == LintHostsFileName {
+			for host := range lintHostsFileHosts(file.Text) {
+				hosts[host] = struct{}{}
+			}
+		}
+	}
+	isResolvable := func(host string) bool {
+		if _, ok := hosts[strings.ToLower(host)]; ok {
+			return true
+		}
+		return resolveHost(host)
+	}
+
+	problems := []LintProblem{}
+	for _, file := range files {
+		fileProblems := []LintProblem{}
+		switch file.Name {
+		case RemapFile:
+			fileProblems = lintRemapDotConfig(file.Text)
+		case ParentConfigFileName:
+			fileProblems = lintParentDotConfig(file.Text, isResolvable)
+		case RecordsFileName:
+			fileProblems = lintRecordsDotConfig(file.Text)
+		case SSLMultiCertConfigFileName:
+			fileProblems = lintSSLMultiCertDotConfig(file.Text)
+		case IPAllowYamlFileName:
+			fileProblems = lintIPAllowDotYAML(file.Text)
+		case SNIDotYAMLFileName:
+			fileProblems = lintSNIDotYAML(file.Text)
+		case LoggingYAMLFileName:
+			fileProblems = lintLoggingDotYAML(file.Text)
+		}
+		for _, problem := range fileProblems {
+			problem.File = file.Name
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+// validHostNameRegex matches a valid RFC 1123 host name.
+var validHostNameRegex = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*\.?$`)
+
+// DefaultLintResolveHost is the LintOpts.ResolveHost used if none is given.
+// It doesn't resolve the host, but returns whether it's a fully qualified
+// domain name, which can be resolved without a search domain.
+func DefaultLintResolveHost(host string) bool {
+	return len(host) <= 253 && strings.Contains(host, ".") && validHostNameRegex.MatchString(host)
+}
+
+// lintHostsFileHosts returns the (lowercase) host names in the given hosts
+// file text.
+func lintHostsFileHosts(txt string) map[string]struct{} {
+	hosts := map[string]struct{}{}
+	for _, line := range strings.Split(txt, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			continue
+		}
+		for _, host := range fields[1:] {
+			hosts[strings.ToLower(host)] = struct{}{}
+		}
+	}
+	return hosts
+}
+
+// lintLine is a logical line of a config file, after removing comments and
+// joining continued lines.
+type lintLine struct {
+	Num    int // the number of the first physical line, starting at 1
+	Fields []string
+}
+
+// lintLines splits the given config file text into its logical lines, removing
+// blank lines and '#' comments, and joining lines continued with a trailing
+// '\'. If quoted is true, fields may be double-quoted to contain whitespace,
+// as in key="a b".
+func lintLines(txt string, quoted bool) []lintLine {
+	lines := []lintLine{}
+	cur := ""
+	curNum := 0
+	for i, line := range strings.Split(txt, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if cur == "" {
+			curNum = i + 1
+		}
+		if strings.HasSuffix(line, `\`) {
+			cur += strings.TrimSuffix(line, `\`) + " "
+			continue
+		}
+		cur += line
+		fields := []string{}
+		if quoted {
+			fields = splitQuotedFields(cur)
+		} else {
+			fields = strings.Fields(cur)
+		}
+		if len(fields) > 0 {
+			lines = append(lines, lintLine{Num: curNum, Fields: fields})
+		}
+		cur = ""
+	}
+	return lines
+}
+
+// splitQuotedFields splits the given string on whitespace, except whitespace
+// within double quotes.
+func splitQuotedFields(s string) []string {
+	fields := []string{}
+	field := strings.Builder{}
+	inQuote := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			field.WriteRune(r)
+		case !inQuote && (r == ' ' || r == '\t'):
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteRune(r)
+		}
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// splitKeyVal splits a key=value field, removing any quotes around the value.
+func splitKeyVal(field string) (string, string, bool) {
+	i := strings.Index(field, "=")
+	if i < 0 {
+		return field, "", false
+	}
+	return field[:i], strings.Trim(field[i+1:], `"`), true
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var remapMapDirectives = map[string]struct{}{
+	"map":                      {},
+	"map_with_recv_port":       {},
+	"map_with_referer":         {},
+	"reverse_map":              {},
+	"redirect":                 {},
+	"redirect_temporary":       {},
+	"regex_map":                {},
+	"regex_map_with_recv_port": {},
+	"regex_redirect":           {},
+}
+
+var remapFilterDirectives = map[string]struct{}{
+	".definefilter":     {},
+	".activatefilter":   {},
+	".deactivatefilter": {},
+	".deletefilter":     {},
+	".include":          {},
+}
+
+var remapOptions = map[string]struct{}{
+	"@plugin":          {},
+	"@pparam":          {},
+	"@action":          {},
+	"@src_ip":          {},
+	"@in_ip":           {},
+	"@method":          {},
+	"@internal":        {},
+	"@src_ip_category": {},
+}
+
+// remapRuleClass returns the class of rules a remap directive conflicts with,
+// or the empty string if it doesn't conflict with other rules.
+func remapRuleClass(directive string) string {
+	switch directive {
+	case "map", "map_with_recv_port", "map_with_referer", "regex_map", "regex_map_with_recv_port":
+		return "map"
+	case "redirect", "redirect_temporary", "regex_redirect":
+		return "redirect"
+	case "reverse_map":
+		return "reverse_map"
+	}
+	return ""
+}
+
+// splitRemapURL splits a remap.config URL into its scheme, host (including any
+// port), and path. Unlike url.Parse, this doesn't fail on regex hosts.
+func splitRemapURL(u string) (string, string, string, bool) {
+	i := strings.Index(u, "://")
+	if i <= 0 {
+		return "", "", "", false
+	}
+	scheme := strings.ToLower(u[:i])
+	rest := u[i+len("://"):]
+	host, path := rest, "/"
+	if j := strings.Index(rest, "/"); j >= 0 {
+		host, path = rest[:j], rest[j:]
+	}
+	return scheme, host, path, true
+}
+
+// isCatchAllRegex returns whether the given remap host regex matches any host.
+func isCatchAllRegex(re string) bool {
+	switch strings.TrimSuffix(strings.TrimPrefix(re, "^"), "$") {
+	case ".*", "(.*)", ".+", "(.+)":
+		return true
+	}
+	return false
+}
+
+func lintRemapDotConfig(txt string) []LintProblem {
+	problems := []LintProblem{}
+	add := func(line int, msg string) { problems = append(problems, LintProblem{Line: line, Msg: msg}) }
+
+	type regexRule struct {
+		Line   int
+		Scheme string
+		Host   string
+		Path   string
+	}
+
+	seenRules := map[string]int{} // rule class and from URL -> line
+	definedFilters := map[string]struct{}{}
+	regexRules := []regexRule{}
+
+	for _, line := range lintLines(txt, false) {
+		directive := line.Fields[0]
+		if _, ok := remapFilterDirectives[directive]; ok {
+			if len(line.Fields) < 2 {
+				add(line.Num, "'"+directive+"' missing argument")
+				continue
+			}
+			switch directive {
+			case ".definefilter":
+				definedFilters[line.Fields[1]] = struct{}{}
+			case ".activatefilter", ".deactivatefilter", ".deletefilter":
+				if _, ok := definedFilters[line.Fields[1]]; !ok {
+					add(line.Num, "'"+directive+"' of undefined filter '"+line.Fields[1]+"'")
+				}
+			}
+			continue
+		}
+		if _, ok := remapMapDirectives[directive]; !ok {
+			add(line.Num, "unknown directive '"+directive+"'")
+			continue
+		}
+		if len(line.Fields) < 3 {
+			add(line.Num, "'"+directive+"' rule must have a from URL and a to URL")
+			continue
+		}
+
+		fromURL, toURL := line.Fields[1], line.Fields[2]
+		scheme, host, path, ok := splitRemapURL(fromURL)
+		if !ok {
+			add(line.Num, "from URL '"+fromURL+"' has no scheme")
+			continue
+		}
+		if _, _, _, ok := splitRemapURL(toURL); !ok {
+			add(line.Num, "to URL '"+toURL+"' has no scheme")
+		}
+
+		isRegex := strings.HasPrefix(directive, "regex_")
+		if isRegex {
+			hostRegex := host
+			if i := strings.LastIndex(hostRegex, ":"); i >= 0 && !strings.Contains(hostRegex[i:], "]") {
+				hostRegex = hostRegex[:i]
+			}
+			if _, err := regexp.Compile(hostRegex); err != nil {
+				add(line.Num, "invalid host regex '"+hostRegex+"', rule can never match: "+err.Error())
+			}
+			for _, prev := range regexRules {
+				if prev.Scheme == scheme && isCatchAllRegex(prev.Host) && strings.HasPrefix(path, prev.Path) {
+					add(line.Num, "unreachable rule, every host of it is matched first by the rule on line "+strconv.Itoa(prev.Line))
+					break
+				}
+			}
+			regexRules = append(regexRules, regexRule{Line: line.Num, Scheme: scheme, Host: hostRegex, Path: path})
+		}
+
+		if class := remapRuleClass(directive); class != "" {
+			key := class + " " + strings.ToLower(scheme+"://"+host) + path
+			if prevLine, ok := seenRules[key]; ok {
+				add(line.Num, "duplicate rule for '"+fromURL+"', the rule on line "+strconv.Itoa(prevLine)+" is used instead")
+			} else {
+				seenRules[key] = line.Num
+			}
+		}
+
+		inPlugin := false
+		for _, opt := range line.Fields[3:] {
+			name, _, _ := splitKeyVal(opt)
+			if _, ok := remapOptions[name]; !ok {
+				add(line.Num, "unknown option '"+name+"'")
+				continue
+			}
+			switch name {
+			case "@plugin":
+				inPlugin = true
+			case "@pparam":
+				if !inPlugin {
+					add(line.Num, "'@pparam' not after a '@plugin'")
+				}
+			}
+		}
+	}
+	return problems
+}
+
+var parentPrimaryDestinations = map[string]struct{}{
+	"dest_domain": {},
+	"dest_host":   {},
+	"dest_ip":     {},
+	"url_regex":   {},
+}
+
+// parentKeyValues is the valid values of parent.config keys with a fixed set of
+// values.
+var parentKeyValues = map[string][]string{
+	"round_robin":     {"true", "strict", "false", "consistent_hash", "latched"},
+	"go_direct":       {"true", "false"},
+	"parent_is_proxy": {"true", "false"},
+	"qstring":         {"consider", "ignore"},
+	"parent_retry":    {"simple_retry", "unavailable_server_retry", "both"},
+	"secondary_mode":  {"1", "2", "3"},
+	"scheme":          {"http", "https"},
+}
+
+// parentIntKeys is the parent.config keys whose values are integers.
+var parentIntKeys = map[string]struct{}{
+	"port":                           {},
+	"max_simple_retries":             {},
+	"max_unavailable_server_retries": {},
+}
+
+// parentOtherKeys is the valid parent.config keys which aren't primary
+// destinations, and aren't checked beyond being known.
+var parentOtherKeys = map[string]struct{}{
+	"prefix":                             {},
+	"suffix":                             {},
+	"method":                             {},
+	"time":                               {},
+	"src_ip":                             {},
+	"internal":                           {},
+	"parent":                             {},
+	"secondary_parent":                   {},
+	"unavailable_server_retry_responses": {},
+	"simple_server_retry_responses":      {},
+	"ignore_self_detect":                 {},
+}
+
+func lintParentDotConfig(txt string, isResolvable func(string) bool) []LintProblem {
+	problems := []LintProblem{}
+	add := func(line int, msg string) { problems = append(problems, LintProblem{Line: line, Msg: msg}) }
+
+	seenDests := map[string]int{} // primary destination and port -> line
+
+	for _, line := range lintLines(txt, true) {
+		vals := map[string]string{}
+		for _, field := range line.Fields {
+			key, val, ok := splitKeyVal(field)
+			if !ok {
+				add(line.Num, "'"+field+"' is not a key=value pair")
+				continue
+			}
+			if _, ok := vals[key]; ok {
+				add(line.Num, "'"+key+"' set more than once")
+			}
+			vals[key] = val
+
+			if validVals, ok := parentKeyValues[key]; ok {
+				if !stringsContain(validVals, val) {
+					add(line.Num, "invalid "+key+" '"+val+"', must be one of: "+strings.Join(validVals, ", "))
+				}
+				continue
+			}
+			if _, ok := parentIntKeys[key]; ok {
+				if _, err := strconv.ParseUint(val, 10, 32); err != nil {
+					add(line.Num, "invalid "+key+" '"+val+"', must be a non-negative integer")
+				}
+				continue
+			}
+			_, isDest := parentPrimaryDestinations[key]
+			_, isOther := parentOtherKeys[key]
+			if !isDest && !isOther {
+				add(line.Num, "unknown key '"+key+"'")
+			}
+		}
+
+		dests := []string{}
+		for key := range parentPrimaryDestinations {
+			if val, ok := vals[key]; ok {
+				dests = append(dests, key+"="+val)
+			}
+		}
+		sort.Strings(dests)
+		if len(dests) != 1 {
+			add(line.Num, "must have exactly one of dest_domain, dest_host, dest_ip, url_regex, has "+strconv.Itoa(len(dests)))
+		} else {
+			destKey := dests[0] + " port=" + vals["port"] + " scheme=" + vals["scheme"]
+			if prevLine, ok := seenDests[destKey]; ok {
+				add(line.Num, "duplicate destination '"+dests[0]+"', the line "+strconv.Itoa(prevLine)+" is used instead")
+			} else {
+				seenDests[destKey] = line.Num
+			}
+		}
+
+		if _, ok := vals["parent"]; !ok && vals["go_direct"] != "true" {
+			add(line.Num, "no parent, and go_direct is not true")
+		}
+
+		for _, key := range []string{"parent", "secondary_parent"} {
+			for _, msg := range lintParentList(vals[key], isResolvable) {
+				add(line.Num, key+" "+msg)
+			}
+		}
+	}
+	return problems
+}
+
+func stringsContain(strs []string, s string) bool {
+	for _, str := range strs {
+		if str == s {
+			return true
+		}
+	}
+	return false
+}
+
+// lintParentList returns the problems with a parent.config list of parents, in
+// the form 'host:port|weight;host:port|weight'.
+func lintParentList(parents string, isResolvable func(string) bool) []string {
+	msgs := []string{}
+	if parents == "" {
+		return msgs
+	}
+	for _, parent := range strings.FieldsFunc(parents, func(r rune) bool { return r == ';' || r == ',' }) {
+		hostPort := parent
+		if i := strings.Index(parent, "|"); i >= 0 {
+			hostPort = parent[:i]
+			weight := parent[i+1:]
+			if _, err := strconv.ParseFloat(weight, 64); err != nil {
+				msgs = append(msgs, "'"+parent+"' has invalid weight '"+weight+"'")
+			}
+		}
+		host, port, err := net.SplitHostPort(hostPort)
+		if err != nil {
+			msgs = append(msgs, "'"+parent+"' is not host:port")
+			continue
+		}
+		if portNum, err := strconv.ParseUint(port, 10, 16); err != nil || portNum == 0 {
+			msgs = append(msgs, "'"+parent+"' has invalid port '"+port+"'")
+		}
+		if net.ParseIP(host) == nil && !isResolvable(host) {
+			msgs = append(msgs, "host '"+host+"' is not resolvable")
+		}
+	}
+	return msgs
+}
+
+// recordTypes is the valid types of records.config records.
+var recordTypes = map[string]struct{}{
+	"INT":     {},
+	"FLOAT":   {},
+	"STRING":  {},
+	"COUNTER": {},
+}
+
+// parseRecordInt parses a records.config INT value, which may have a K, M, G,
+// or T suffix.
+func parseRecordInt(val string) error {
+	if len(val) > 1 {
+		switch val[len(val)-1] {
+		case 'K', 'M', 'G', 'T', 'k', 'm', 'g', 't':
+			val = val[:len(val)-1]
+		}
+	}
+	_, err := strconv.ParseInt(val, 0, 64)
+	return err
+}
+
+func lintRecordsDotConfig(txt string) []LintProblem {
+	problems := []LintProblem{}
+	add := func(line int, msg string) { problems = append(problems, LintProblem{Line: line, Msg: msg}) }
+
+	seenRecords := map[string]int{} // name -> line
+
+	for _, line := range lintLines(txt, false) {
+		directive := line.Fields[0]
+		if directive != "CONFIG" && directive != "LOCAL" {
+			add(line.Num, "unknown directive '"+directive+"', must be CONFIG or LOCAL")
+			continue
+		}
+		if len(line.Fields) < 3 {
+			add(line.Num, "record must have a name, type, and value")
+			continue
+		}
+		name, typ := line.Fields[1], line.Fields[2]
+		val := strings.Join(line.Fields[3:], " ")
+
+		if !strings.HasPrefix(name, "proxy.") {
+			add(line.Num, "unknown record '"+name+"', records start with 'proxy.'")
+		}
+		if prevLine, ok := seenRecords[name]; ok {
+			add(line.Num, "record '"+name+"' already set on line "+strconv.Itoa(prevLine)+", this value is used instead")
+		}
+		seenRecords[name] = line.Num
+
+		if _, ok := recordTypes[typ]; !ok {
+			add(line.Num, "record '"+name+"' has unknown type '"+typ+"'")
+			continue
+		}
+		if typ == "STRING" {
+			continue
+		}
+		if val == "" {
+			add(line.Num, "record '"+name+"' missing "+typ+" value")
+			continue
+		}
+		switch typ {
+		case "INT", "COUNTER":
+			if err := parseRecordInt(val); err != nil {
+				add(line.Num, "record '"+name+"' value '"+val+"' is not an INT")
+			}
+		case "FLOAT":
+			if _, err := strconv.ParseFloat(val, 64); err != nil {
+				add(line.Num, "record '"+name+"' value '"+val+"' is not a FLOAT")
+			}
+		}
+	}
+	return problems
+}
+
+var sslMultiCertKeys = map[string]struct{}{
+	"ssl_cert_name":      {},
+	"ssl_key_name":       {},
+	"ssl_ca_name":        {},
+	"ssl_ocsp_name":      {},
+	"ssl_key_dialog":     {},
+	"ssl_ticket_enabled": {},
+	"ssl_ticket_number":  {},
+	"dest_ip":            {},
+	"dest_fqdn":          {},
+	"action":             {},
+}
+
+func lintSSLMultiCertDotConfig(txt string) []LintProblem {
+	problems := []LintProblem{}
+	add := func(line int, msg string) { problems = append(problems, LintProblem{Line: line, Msg: msg}) }
+
+	for _, line := range lintLines(txt, true) {
+		vals := map[string]string{}
+		for _, field := range line.Fields {
+			key, val, ok := splitKeyVal(field)
+			if !ok {
+				add(line.Num, "'"+field+"' is not a key=value pair")
+				continue
+			}
+			if _, ok := sslMultiCertKeys[key]; !ok {
+				add(line.Num, "unknown key '"+key+"'")
+				continue
+			}
+			vals[key] = val
+		}
+		if vals["ssl_cert_name"] == "" && vals["action"] != "tunnel" {
+			add(line.Num, "missing ssl_cert_name")
+		}
+		if ip, ok := vals["dest_ip"]; ok && ip != "*" && net.ParseIP(strings.Trim(ip, "[]")) == nil {
+			if host, _, err := net.SplitHostPort(ip); err != nil || (host != "*" && net.ParseIP(strings.Trim(host, "[]")) == nil) {
+				add(line.Num, "invalid dest_ip '"+ip+"'")
+			}
+		}
+		if val, ok := vals["ssl_ticket_enabled"]; ok && val != "0" && val != "1" {
+			add(line.Num, "invalid ssl_ticket_enabled '"+val+"', must be 0 or 1")
+		}
+		if val, ok := vals["ssl_ticket_number"]; ok {
+			if _, err := strconv.ParseUint(val, 10, 32); err != nil {
+				add(line.Num, "invalid ssl_ticket_number '"+val+"', must be a non-negative integer")
+			}
+		}
+		if val, ok := vals["action"]; ok && val != "tunnel" {
+			add(line.Num, "invalid action '"+val+"', must be tunnel")
+		}
+	}
+	return problems
+}
@@ -0,0 +1,303 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+)
+
+// lintProblemMsgs returns the messages of the given problems, with their lines.
+func lintProblemMsgs(problems []LintProblem) []string {
+	msgs := []string{}
+	for _, problem := range problems {
+		msgs = append(msgs, problem.String())
+	}
+	return msgs
+}
+
+// testLint lints the given file, and fails if its problems don't contain each
+// of the expected substrings, in order, or there are more problems than expected.
+func testLint(t *testing.T, fileName string, txt string, expected ...string) {
+	t.Helper()
+	problems := LintConfigFiles([]CfgFile{{Name: fileName, Cfg: Cfg{Text: txt}}}, nil)
+	msgs := lintProblemMsgs(problems)
+	if len(msgs) != len(expected) {
+		t.Fatalf("%s: expected %d problems, actual %d: %+v", fileName, len(expected), len(msgs), msgs)
+	}
+	for i, msg := range msgs {
+		if !strings.Contains(msg, expected[i]) {
+			t.Errorf("%s: expected problem %d to contain '%s', actual: '%s'", fileName, i, expected[i], msg)
+		}
+	}
+}
+
+func TestLintRemapDotConfig(t *testing.T) {
+	testLint(t, RemapFile, `# DO NOT EDIT
+map http://a.example.net/ http://origin.example.net/ @plugin=header_rewrite.so @pparam=hdr.config
+map http://b.example.net/ \
+    http://origin.example.net/
+regex_map http://(.*)\.example\.org/ http://origin.example.net/
+.definefilter internal_only @action=allow @src_ip=10.0.0.0-10.255.255.255
+.activatefilter internal_only
+`)
+
+	testLint(t, RemapFile, `
+map http://a.example.net/ http://origin.example.net/
+map http://A.example.net/ http://other.example.net/
+mapp http://c.example.net/ http://origin.example.net/
+map http://d.example.net/ http://origin.example.net/ @pparam=foo @plugin=bar.so @bad=1
+regex_map http://.*/ http://origin.example.net/
+regex_map http://c.example.net/ http://origin.example.net/
+regex_map http://(c.example.net/ http://origin.example.net/
+.activatefilter nonexistent
+map http://e.example.net/
+`,
+		":3: duplicate rule for 'http://A.example.net/', the rule on line 2",
+		":4: unknown directive 'mapp'",
+		":5: '@pparam' not after a '@plugin'",
+		":5: unknown option '@bad'",
+		":7: unreachable rule",
+		":8: invalid host regex '(c.example.net'",
+		":8: unreachable rule",
+		":9: '.activatefilter' of undefined filter 'nonexistent'",
+		":10: 'map' rule must have a from URL and a to URL",
+	)
+}
+
+func TestLintParentDotConfig(t *testing.T) {
+	testLint(t, ParentConfigFileName, `# DO NOT EDIT
+dest_domain=origin.example.net port=80 parent="mid0.example.net:80|0.999;192.0.2.1:80|0.999" secondary_parent="mid1.example.net:80|0.999" round_robin=consistent_hash go_direct=false qstring=ignore parent_is_proxy=true
+dest_domain=. parent="mid0.example.net:80|0.999" round_robin=consistent_hash go_direct=false qstring=consider
+dest_domain=direct.example.net port=443 go_direct=true
+`)
+
+	testLint(t, ParentConfigFileName, `
+dest_domain=origin.example.net port=80 parent="mid0.example.net:80|0.999" round_robin=random go_direct=maybe
+dest_domain=origin.example.net port=80 parent="mid0:80|0.999;mid1.example.net:0|x" go_direct=false
+dest_host=a.example.net dest_domain=b.example.net go_direct=true
+dest_domain=c.example.net parentt="mid0.example.net:80" go_direct=true
+dest_domain=d.example.net
+`,
+		":2: invalid round_robin 'random'",
+		":2: invalid go_direct 'maybe'",
+		":3: duplicate destination 'dest_domain=origin.example.net', the line 2",
+		":3: parent host 'mid0' is not resolvable",
+		":3: parent 'mid1.example.net:0|x' has invalid weight 'x'",
+		":3: parent 'mid1.example.net:0|x' has invalid port '0'",
+		":4: must have exactly one of dest_domain, dest_host, dest_ip, url_regex, has 2",
+		":5: unknown key 'parentt'",
+		":6: no parent, and go_direct is not true",
+	)
+}
+
+func TestLintParentDotConfigHosts(t *testing.T) {
+	parent := CfgFile{Name: ParentConfigFileName, Cfg: Cfg{Text: `dest_domain=. parent="mid0:80|0.999;mid1:80|0.999" go_direct=false` + "\n"}}
+	hosts := CfgFile{Name: LintHostsFileName, Cfg: Cfg{Text: "192.0.2.1 mid0 # comment\n"}}
+
+	msgs := lintProblemMsgs(LintConfigFiles([]CfgFile{parent, hosts}, nil))
+	if len(msgs) != 1 || !strings.Contains(msgs[0], "'mid1' is not resolvable") {
+		t.Errorf("expected only mid1 to not resolve with hosts file, actual: %+v", msgs)
+	}
+
+	msgs = lintProblemMsgs(LintConfigFiles([]CfgFile{parent}, &LintOpts{ResolveHost: func(string) bool { return true }}))
+	if len(msgs) != 0 {
+		t.Errorf("expected no problems with resolver resolving all hosts, actual: %+v", msgs)
+	}
+}
+
+func TestLintRecordsDotConfig(t *testing.T) {
+	testLint(t, RecordsFileName, `# DO NOT EDIT
+CONFIG proxy.config.http.server_ports STRING 80 80:ipv6
+CONFIG proxy.config.cache.ram_cache.size INT 16G
+CONFIG proxy.config.http.cache.heuristic_lm_factor FLOAT 0.10
+LOCAL proxy.local.cluster.type INT 0x3
+`)
+
+	testLint(t, RecordsFileName, `
+CONFIG proxy.config.cache.ram_cache.size INT lots
+CONFIG proxy.config.http.cache.heuristic_lm_factor FLOAT 1/10
+CONFIG proxy.config.cache.ram_cache.size INT 1G
+CONFIG proxy.config.foo BOOL true
+CONFG proxy.config.bar INT 1
+CONFIG config.baz INT 1
+CONFIG proxy.config.qux INT
+`,
+		":2: record 'proxy.config.cache.ram_cache.size' value 'lots' is not an INT",
+		":3: record 'proxy.config.http.cache.heuristic_lm_factor' value '1/10' is not a FLOAT",
+		":4: record 'proxy.config.cache.ram_cache.size' already set on line 2",
+		":5: record 'proxy.config.foo' has unknown type 'BOOL'",
+		":6: unknown directive 'CONFG'",
+		":7: unknown record 'config.baz'",
+		":8: record 'proxy.config.qux' missing INT value",
+	)
+}
+
+func TestLintSSLMultiCertDotConfig(t *testing.T) {
+	testLint(t, SSLMultiCertConfigFileName, `# DO NOT EDIT
+ssl_cert_name=a_example_net_cert.cer ssl_key_name=a_example_net_cert.key
+dest_ip=* ssl_cert_name=default.cer ssl_key_name=default.key
+dest_ip=192.0.2.1 action=tunnel
+`)
+
+	testLint(t, SSLMultiCertConfigFileName, `
+ssl_key_name=a.key
+ssl_cert_name=b.cer dest_ip=notanip ssl_ticket_enabled=yes ssl_cert=b.cer
+`,
+		":2: missing ssl_cert_name",
+		":3: unknown key 'ssl_cert'",
+		":3: invalid dest_ip 'notanip'",
+		":3: invalid ssl_ticket_enabled 'yes'",
+	)
+}
+
+func TestLintIPAllowDotYAML(t *testing.T) {
+	testLint(t, IPAllowYamlFileName, `# DO NOT EDIT
+ip_allow:
+  - apply: in
+    ip_addrs: 127.0.0.1
+    action: allow
+    methods: ALL
+  - apply: in
+    ip_addrs: 10.0.0.0-10.255.255.255
+    action: set_deny
+    methods:
+      - PUSH
+      - PURGE
+  - apply: in
+    ip_addrs: ::/0
+    action: deny
+`)
+
+	testLint(t, IPAllowYamlFileName, `
+ip_allow:
+  - apply: sideways
+    ip_addrs: 300.0.0.1
+    action: allow
+    method: ALL
+  - apply: in
+    ip_addrs: 10.0.0.0/8
+`,
+		"ip_allow entry 0 has unknown key 'method'",
+		"ip_allow entry 0 has invalid apply 'sideways'",
+		"ip_allow entry 0 has invalid ip_addrs '300.0.0.1'",
+		"ip_allow entry 1 missing action",
+	)
+
+	testLint(t, IPAllowYamlFileName, "ip_allow:\n  - apply: in\n   action: allow\n", "invalid YAML")
+}
+
+func TestLintSNIDotYAML(t *testing.T) {
+	testLint(t, SNIDotYAMLFileName, `# DO NOT EDIT
+sni:
+- fqdn: 'a.example.net'
+  disable_h2: false
+  valid_tls_versions_in: ['TLSv1_2','TLSv1_3']
+- fqdn: '*.example.org'
+  verify_client: STRICT
+  http2: off
+`)
+
+	testLint(t, SNIDotYAMLFileName, `
+sni:
+- fqdn: 'a.example.net'
+  disable_h2: nope
+  valid_tls_versions_in: ['TLSv1_4']
+- fqdn: 'A.example.net'
+  verify_client: SOMETIMES
+  disable_http2: true
+- disable_h2: true
+`,
+		"sni entry 0 'a.example.net' has invalid disable_h2 'nope'",
+		"sni entry 0 'a.example.net' has invalid valid_tls_versions_in 'TLSv1_4'",
+		"sni entry 1 has unknown key 'disable_http2'",
+		"sni entry 1 'A.example.net' has the same fqdn as entry 0",
+		"sni entry 1 'A.example.net' has invalid verify_client 'SOMETIMES'",
+		"sni entry 2 missing fqdn",
+	)
+}
+
+func TestLintLoggingDotYAML(t *testing.T) {
+	valid := `
+  formats:
+   - name: custom
+     format: '%<cqtq> %<ttms>'
+  filters:
+   - name: no_health
+     action: reject
+     condition: cqup MATCH /_astats
+  logs:
+   - mode: ascii
+     filename: custom
+     format: custom
+     rolling_enabled: 3
+     rolling_size_mb: 1024
+     filters: [no_health]
+   - mode: binary
+     filename: squid
+     format: squid
+`
+	testLint(t, LoggingYAMLFileName, "# DO NOT EDIT\nlogging:"+valid)
+	testLint(t, LoggingYAMLFileName, strings.Replace(valid, "\n  ", "\n", -1))
+
+	testLint(t, LoggingYAMLFileName, `
+logging:
+  formats:
+   - name: custom
+  filters:
+   - name: f
+     action: drop
+     condition: cqup MATCH /
+  logs:
+   - mode: pipe
+     filename: custom
+     format: other
+     rolling_size_mb: big
+     filters: [g]
+   - format: custom
+     roll: true
+`,
+		"format 0 missing format",
+		"filter 0 has invalid action 'drop'",
+		"log 0 'custom' has invalid mode 'pipe'",
+		"log 0 'custom' has undefined format 'other'",
+		"log 0 'custom' has undefined filter 'g'",
+		"log 0 'custom' has invalid rolling_size_mb 'big'",
+		"log 1 has unknown key 'roll'",
+		"log 1 missing filename",
+	)
+}
+
+func TestLintConfigFilesGenerated(t *testing.T) {
+	server := makeGenericServer()
+	profileName := "myProfile"
+	server.Profile = &profileName
+	params := makeParamsFromMap("serverProfile", LoggingYAMLFileName, map[string]string{
+		"LogFormat.Name":     "myFormatName",
+		"LogFormat.Format":   "myFormat",
+		"LogObject.Filename": "myFilename",
+		"LogObject.Format":   "myFormatName",
+	})
+	cfg, err := MakeLoggingDotYAML(server, params, LoggingDotYAMLOpts{HdrComment: "myHeaderComment"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testLint(t, LoggingYAMLFileName, cfg.Text)
+}
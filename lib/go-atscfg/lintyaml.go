@@ -0,0 +1,370 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// lintYAMLEntries is a YAML list of maps, which most ATS YAML config files are.
+type lintYAMLEntries []map[string]interface{}
+
+// lintYAMLParseProblem returns the problem of a YAML file which failed to parse.
+// The yaml library includes the line in its errors, but not in a way which can
+// be got, so the line is left in the message.
+func lintYAMLParseProblem(err error) []LintProblem {
+	return []LintProblem{{Msg: "invalid YAML: " + strings.TrimPrefix(err.Error(), "yaml: ")}}
+}
+
+// lintYAMLEntryKeys returns the problems with unknown keys of the given YAML
+// entry, sorted.
+func lintYAMLEntryKeys(entryName string, entry map[string]interface{}, knownKeys map[string]struct{}) []LintProblem {
+	unknown := []string{}
+	for key := range entry {
+		if _, ok := knownKeys[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	problems := []LintProblem{}
+	for _, key := range unknown {
+		problems = append(problems, LintProblem{Msg: entryName + " has unknown key '" + key + "'"})
+	}
+	return problems
+}
+
+// lintYAMLEnum returns the problem with the entry's value of key, if it's set
+// and isn't one of the given values, or nil if it's valid.
+func lintYAMLEnum(entryName string, entry map[string]interface{}, key string, vals ...string) []LintProblem {
+	val, ok := entry[key]
+	if !ok {
+		return nil
+	}
+	if str, ok := val.(string); ok && stringsContain(vals, str) {
+		return nil
+	}
+	return []LintProblem{{Msg: entryName + " has invalid " + key + " '" + fmt.Sprint(val) + "', must be one of: " + strings.Join(vals, ", ")}}
+}
+
+// lintYAMLStrings returns the entry's value of key as a list of strings. YAML
+// allows a single value in place of a list of one, which ATS accepts as well.
+func lintYAMLStrings(entry map[string]interface{}, key string) ([]string, bool) {
+	switch val := entry[key].(type) {
+	case string:
+		return []string{val}, true
+	case []interface{}:
+		strs := []string{}
+		for _, item := range val {
+			str, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			strs = append(strs, str)
+		}
+		return strs, true
+	}
+	return nil, false
+}
+
+var ipAllowKeys = map[string]struct{}{
+	"apply":    {},
+	"ip_addrs": {},
+	"action":   {},
+	"methods":  {},
+}
+
+func lintIPAllowDotYAML(txt string) []LintProblem {
+	cfg := map[string]lintYAMLEntries{}
+	if err := yaml.UnmarshalStrict([]byte(txt), &cfg); err != nil {
+		return lintYAMLParseProblem(err)
+	}
+	problems := []LintProblem{}
+	for key := range cfg {
+		if key != "ip_allow" {
+			problems = append(problems, LintProblem{Msg: "unknown key '" + key + "'"})
+		}
+	}
+	for i, entry := range cfg["ip_allow"] {
+		entryName := "ip_allow entry " + strconv.Itoa(i)
+		problems = append(problems, lintYAMLEntryKeys(entryName, entry, ipAllowKeys)...)
+		for _, key := range []string{"apply", "ip_addrs", "action"} {
+			if _, ok := entry[key]; !ok {
+				problems = append(problems, LintProblem{Msg: entryName + " missing " + key})
+			}
+		}
+		problems = append(problems, lintYAMLEnum(entryName, entry, "apply", "in", "out")...)
+		problems = append(problems, lintYAMLEnum(entryName, entry, "action", "allow", "deny", "set_allow", "set_deny")...)
+
+		if _, ok := entry["ip_addrs"]; ok {
+			addrs, ok := lintYAMLStrings(entry, "ip_addrs")
+			if !ok {
+				problems = append(problems, LintProblem{Msg: entryName + " ip_addrs must be a string or list of strings"})
+			}
+			for _, addr := range addrs {
+				if !isIPAllowAddr(addr) {
+					problems = append(problems, LintProblem{Msg: entryName + " has invalid ip_addrs '" + addr + "'"})
+				}
+			}
+		}
+		if _, ok := entry["methods"]; ok {
+			if _, ok := lintYAMLStrings(entry, "methods"); !ok {
+				problems = append(problems, LintProblem{Msg: entryName + " methods must be a string or list of strings"})
+			}
+		}
+	}
+	return problems
+}
+
+// isIPAllowAddr returns whether addr is a valid ip_allow.yaml address, which
+// is an IP, a CIDR, or a range of IPs separated by a '-'.
+func isIPAllowAddr(addr string) bool {
+	if _, _, err := net.ParseCIDR(addr); err == nil {
+		return true
+	}
+	if i := strings.Index(addr, "-"); i >= 0 {
+		return net.ParseIP(strings.TrimSpace(addr[:i])) != nil && net.ParseIP(strings.TrimSpace(addr[i+1:])) != nil
+	}
+	return net.ParseIP(addr) != nil
+}
+
+var sniKeys = map[string]struct{}{
+	"fqdn":                     {},
+	"ip_allow":                 {},
+	"verify_client":            {},
+	"verify_client_ca_certs":   {},
+	"verify_server_policy":     {},
+	"verify_server_properties": {},
+	"host_sni_policy":          {},
+	"valid_tls_versions_in":    {},
+	"client_cert":              {},
+	"client_key":               {},
+	"client_sni_policy":        {},
+	"http2":                    {},
+	"disable_h2":               {},
+	"tunnel_route":             {},
+	"forward_route":            {},
+	"partial_blind_route":      {},
+	"tunnel_alpn":              {},
+	// The cipher keys are only in ATS 10 and later.
+	"server_cipher_suite":          {},
+	"server_TLSv1_3_cipher_suites": {},
+}
+
+var sniTLSVersions = []string{"TLSv1", "TLSv1_1", "TLSv1_2", "TLSv1_3"}
+
+func lintSNIDotYAML(txt string) []LintProblem {
+	cfg := map[string]lintYAMLEntries{}
+	if err := yaml.UnmarshalStrict([]byte(txt), &cfg); err != nil {
+		return lintYAMLParseProblem(err)
+	}
+	problems := []LintProblem{}
+	for key := range cfg {
+		if key != "sni" {
+			problems = append(problems, LintProblem{Msg: "unknown key '" + key + "'"})
+		}
+	}
+	seenFQDNs := map[string]int{} // fqdn -> entry index
+	for i, entry := range cfg["sni"] {
+		entryName := "sni entry " + strconv.Itoa(i)
+		problems = append(problems, lintYAMLEntryKeys(entryName, entry, sniKeys)...)
+
+		fqdn, ok := entry["fqdn"].(string)
+		if !ok || fqdn == "" {
+			problems = append(problems, LintProblem{Msg: entryName + " missing fqdn"})
+		} else {
+			entryName = "sni entry " + strconv.Itoa(i) + " '" + fqdn + "'"
+			if prevI, ok := seenFQDNs[strings.ToLower(fqdn)]; ok {
+				problems = append(problems, LintProblem{Msg: entryName + " has the same fqdn as entry " + strconv.Itoa(prevI) + ", which is used instead"})
+			} else {
+				seenFQDNs[strings.ToLower(fqdn)] = i
+			}
+		}
+
+		problems = append(problems, lintYAMLEnum(entryName, entry, "verify_client", "NONE", "MODERATE", "STRICT")...)
+		problems = append(problems, lintYAMLEnum(entryName, entry, "verify_server_policy", "DISABLED", "PERMISSIVE", "ENFORCED")...)
+		problems = append(problems, lintYAMLEnum(entryName, entry, "verify_server_properties", "NONE", "SIGNATURE", "NAME", "ALL")...)
+		problems = append(problems, lintYAMLEnum(entryName, entry, "host_sni_policy", "DISABLED", "PERMISSIVE", "ENFORCED")...)
+
+		if val, ok := entry["disable_h2"]; ok {
+			if _, ok := val.(bool); !ok {
+				problems = append(problems, LintProblem{Msg: entryName + " has invalid disable_h2 '" + fmt.Sprint(val) + "', must be true or false"})
+			}
+		}
+		if val, ok := entry["http2"]; ok {
+			// YAML 1.1, which the yaml library follows, reads on and off as booleans.
+			if _, ok := val.(bool); !ok {
+				problems = append(problems, LintProblem{Msg: entryName + " has invalid http2 '" + fmt.Sprint(val) + "', must be on or off"})
+			}
+		}
+		if _, ok := entry["valid_tls_versions_in"]; ok {
+			versions, ok := lintYAMLStrings(entry, "valid_tls_versions_in")
+			if !ok {
+				problems = append(problems, LintProblem{Msg: entryName + " valid_tls_versions_in must be a list of strings"})
+			}
+			for _, version := range versions {
+				if !stringsContain(sniTLSVersions, version) {
+					problems = append(problems, LintProblem{Msg: entryName + " has invalid valid_tls_versions_in '" + version + "', must be one of: " + strings.Join(sniTLSVersions, ", ")})
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// loggingYAML is the logging.yaml config of ATS 8, or the logging object of
+// ATS 9 and later.
+type loggingYAML struct {
+	Formats lintYAMLEntries `yaml:"formats"`
+	Filters lintYAMLEntries `yaml:"filters"`
+	Logs    lintYAMLEntries `yaml:"logs"`
+}
+
+var loggingFormatKeys = map[string]struct{}{
+	"name":     {},
+	"format":   {},
+	"interval": {},
+}
+
+var loggingFilterKeys = map[string]struct{}{
+	"name":      {},
+	"action":    {},
+	"condition": {},
+}
+
+var loggingLogKeys = map[string]struct{}{
+	"mode":                 {},
+	"filename":             {},
+	"format":               {},
+	"header":               {},
+	"rolling_enabled":      {},
+	"rolling_interval_sec": {},
+	"rolling_offset_hr":    {},
+	"rolling_size_mb":      {},
+	"rolling_min_count":    {},
+	"rolling_max_count":    {},
+	"rolling_allow_empty":  {},
+	"filters":              {},
+	"collation_hosts":      {},
+}
+
+func lintLoggingDotYAML(txt string) []LintProblem {
+	cfg := struct {
+		Logging     *loggingYAML `yaml:"logging"`
+		loggingYAML `yaml:",inline"`
+	}{}
+	if err := yaml.UnmarshalStrict([]byte(txt), &cfg); err != nil {
+		return lintYAMLParseProblem(err)
+	}
+	logging := cfg.loggingYAML
+	if cfg.Logging != nil {
+		if len(logging.Formats) > 0 || len(logging.Filters) > 0 || len(logging.Logs) > 0 {
+			return []LintProblem{{Msg: "has both a logging object and top-level formats, filters, or logs"}}
+		}
+		logging = *cfg.Logging
+	}
+
+	problems := []LintProblem{}
+	formats := map[string]struct{}{}
+	for i, format := range logging.Formats {
+		entryName := "format " + strconv.Itoa(i)
+		problems = append(problems, lintYAMLEntryKeys(entryName, format, loggingFormatKeys)...)
+		name, _ := format["name"].(string)
+		if name == "" {
+			problems = append(problems, LintProblem{Msg: entryName + " missing name"})
+		}
+		if _, ok := format["format"].(string); !ok {
+			problems = append(problems, LintProblem{Msg: entryName + " missing format"})
+		}
+		formats[name] = struct{}{}
+	}
+
+	filters := map[string]struct{}{}
+	for i, filter := range logging.Filters {
+		entryName := "filter " + strconv.Itoa(i)
+		problems = append(problems, lintYAMLEntryKeys(entryName, filter, loggingFilterKeys)...)
+		name, _ := filter["name"].(string)
+		if name == "" {
+			problems = append(problems, LintProblem{Msg: entryName + " missing name"})
+		}
+		if _, ok := filter["condition"].(string); !ok {
+			problems = append(problems, LintProblem{Msg: entryName + " missing condition"})
+		}
+		problems = append(problems, lintYAMLEnum(entryName, filter, "action", "accept", "reject", "wipe_field_value")...)
+		filters[name] = struct{}{}
+	}
+
+	for i, log := range logging.Logs {
+		entryName := "log " + strconv.Itoa(i)
+		problems = append(problems, lintYAMLEntryKeys(entryName, log, loggingLogKeys)...)
+		if filename, _ := log["filename"].(string); filename == "" {
+			problems = append(problems, LintProblem{Msg: entryName + " missing filename"})
+		} else {
+			entryName = "log " + strconv.Itoa(i) + " '" + filename + "'"
+		}
+		problems = append(problems, lintYAMLEnum(entryName, log, "mode", "ascii", "binary", "ascii_pipe")...)
+
+		if format, ok := log["format"]; ok && format != nil {
+			if _, ok := formats[fmt.Sprint(format)]; !ok && !isLoggingPredefinedFormat(fmt.Sprint(format)) {
+				problems = append(problems, LintProblem{Msg: entryName + " has undefined format '" + fmt.Sprint(format) + "'"})
+			}
+		} else {
+			problems = append(problems, LintProblem{Msg: entryName + " missing format"})
+		}
+
+		if _, ok := log["filters"]; ok {
+			logFilters, ok := lintYAMLStrings(log, "filters")
+			if !ok {
+				problems = append(problems, LintProblem{Msg: entryName + " filters must be a list of strings"})
+			}
+			for _, filter := range logFilters {
+				if _, ok := filters[filter]; !ok {
+					problems = append(problems, LintProblem{Msg: entryName + " has undefined filter '" + filter + "'"})
+				}
+			}
+		}
+
+		for _, key := range []string{"rolling_interval_sec", "rolling_offset_hr", "rolling_size_mb", "rolling_min_count", "rolling_max_count"} {
+			val, ok := log[key]
+			if !ok {
+				continue
+			}
+			if _, err := strconv.ParseUint(fmt.Sprint(val), 10, 64); err != nil {
+				problems = append(problems, LintProblem{Msg: entryName + " has invalid " + key + " '" + fmt.Sprint(val) + "', must be a non-negative integer"})
+			}
+		}
+	}
+	return problems
+}
+
+// isLoggingPredefinedFormat returns whether the given logging.yaml format is
+// one ATS defines itself, which logs may use without defining it.
+func isLoggingPredefinedFormat(format string) bool {
+	switch format {
+	case "squid", "common", "extended", "extended2":
+		return true
+	}
+	return false
+}
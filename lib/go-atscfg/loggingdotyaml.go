@@ -20,7 +20,10 @@ package atscfg
  */
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -31,10 +34,61 @@ const LoggingYAMLFileName = "logging.yaml"
 const ContentTypeLoggingDotYAML = "application/yaml; charset=us-ascii" // Note YAML has no IANA standard mime type. This is one of several common usages, and is likely to be the standardized value. If you're reading this, please check IANA to see if YAML has been added, and change this to the IANA definition if so. Also note we include 'charset=us-ascii' because YAML is commonly UTF-8, but ATS is likely to be unable to handle UTF.
 const LineCommentLoggingDotYAML = LineCommentHash
 
+// LoggingYAMLParamDSFormat is the Delivery Service Profile Parameter with the log format of the DS's log objects.
+// The format is named "ds_" plus the DS XMLID.
+const LoggingYAMLParamDSFormat = "DSLogFormat.Format"
+
+// LoggingYAMLParamDSLogObject is the prefix of the Delivery Service Profile Parameters of the DS's log objects.
+// Like the server LogObject Parameters, up to MaxLogObjects may be numbered, e.g. DSLogObject.Filename, DSLogObject1.Filename.
+const LoggingYAMLParamDSLogObject = "DSLogObject"
+
+// LoggingYAMLParamDSSampleRate is the suffix of the Delivery Service Profile Parameters with the fraction of requests a DS log object logs, e.g. DSLogObject.SampleRate 0.1.
+// Rates are rounded to thousandths.
+const LoggingYAMLParamDSSampleRate = ".SampleRate"
+
+// LogSampleFileName is the global header_rewrite config which marks the requests sampled for Delivery Service logs with sample rates.
+// It's loaded by plugin.config, and only generated for servers with sampled Delivery Service logs.
+const LogSampleFileName = "log_sample.config"
+const ContentTypeLogSampleDotConfig = ContentTypeTextASCII
+const LineCommentLogSampleDotConfig = LineCommentHash
+
+// LogSamplePluginLine is the plugin.config line which loads the LogSampleFileName.
+const LogSamplePluginLine = "header_rewrite.so " + LogSampleFileName
+
+// logSampleDenominator is the number of parts sample rates are rounded to, and the range of the random number requests are sampled with.
+const logSampleDenominator = 1000
+
+// LoggingDotYAMLOpts contains settings to configure logging.yaml generation options.
+//
+// The Delivery Service data is only needed for Delivery Service logs. Without it, only the server's logs are generated.
+type LoggingDotYAMLOpts struct {
+	// HdrComment is the header comment to include at the beginning of the file.
+	// This should be the text desired, without comment syntax (like # or //). The file's comment syntax will be added.
+	// To omit the header comment, pass the empty string.
+	HdrComment string
+
+	DeliveryServices       []DeliveryService
+	DeliveryServiceServers []DeliveryServiceServer
+	DeliveryServiceRegexes []tc.DeliveryServiceRegexes
+
+	// DSLoggingParams must include the logging.yaml Parameters of the Profiles of all Delivery Services on the server.
+	DSLoggingParams []tc.Parameter
+
+	CDN                    *tc.CDN
+	Topologies             []tc.Topology
+	CacheGroups            []tc.CacheGroupNullable
+	ServerCapabilities     map[int]map[ServerCapability]struct{}
+	DSRequiredCapabilities map[int]map[ServerCapability]struct{}
+}
+
+// MakeLoggingDotYAML makes the logging.yaml from the server Profile Parameters, and from the Profile Parameters of the Delivery Services on the server.
+//
+// Each DS log object only logs requests for the DS, with a filter on the DS's request FQDNs.
+// DS logs with a sample rate also have a filter on the header set by MakeLogSampleDotConfig.
 func MakeLoggingDotYAML(
 	server *Server,
 	serverParams []tc.Parameter,
-	hdrComment string,
+	opts LoggingDotYAMLOpts,
 ) (Cfg, error) {
 	warnings := []string{}
 	requiredIndent := 0
@@ -46,7 +100,7 @@ func MakeLoggingDotYAML(
 	paramData, paramWarns := paramsToMap(filterParams(serverParams, LoggingYAMLFileName, "", "", "location"))
 	warnings = append(warnings, paramWarns...)
 
-	hdr := makeHdrComment(hdrComment)
+	hdr := makeHdrComment(opts.HdrComment)
 
 	version, vWarn := getATSMajorVersion(serverParams)
 	warnings = append(warnings, vWarn...)
@@ -60,6 +114,10 @@ func MakeLoggingDotYAML(
 	}
 
 	indentSpaces := strings.Repeat(" ", requiredIndent)
+
+	dsLogging, dsWarns := makeDSLoggingDotYAML(server, opts, indentSpaces, getLogFilenames(paramData))
+	warnings = append(warnings, dsWarns...)
+
 	text += "\n" + indentSpaces + "formats: \n"
 	for i := 0; i < maxLogObjects; i++ {
 		logFormatField := "LogFormat"
@@ -77,6 +135,7 @@ func MakeLoggingDotYAML(
 			text += indentSpaces + "   format: '" + format + "'\n"
 		}
 	}
+	text += dsLogging.Formats
 
 	text += indentSpaces + "filters:\n"
 	for i := 0; i < maxLogObjects; i++ {
@@ -99,6 +158,7 @@ func MakeLoggingDotYAML(
 			text += indentSpaces + "   condition: " + filter + "\n"
 		}
 	}
+	text += dsLogging.Filters
 
 	var firstObject = true
 	for i := 0; i < maxLogObjects; i++ {
@@ -148,6 +208,13 @@ func MakeLoggingDotYAML(
 		}
 	}
 
+	if dsLogging.Logs != "" {
+		if firstObject {
+			text += "\n" + indentSpaces + "logs:\n"
+		}
+		text += dsLogging.Logs
+	}
+
 	return Cfg{
 		Text:        text,
 		ContentType: ContentTypeLoggingDotYAML,
@@ -155,3 +222,285 @@ func MakeLoggingDotYAML(
 		Warnings:    warnings,
 	}, nil
 }
+
+// dsLogging is the logging.yaml text of the Delivery Service logs on a server.
+type dsLogging struct {
+	Formats string
+	Filters string
+	Logs    string
+	// SampleRates are the sample rates of the DS logs, in thousandths, excluding logs of every request.
+	SampleRates []int
+}
+
+// getLogFilenames returns the filenames of the server's log objects, from its logging.yaml Parameters.
+func getLogFilenames(paramData map[string]string) map[string]struct{} {
+	logFilenames := map[string]struct{}{}
+	for i := 0; i < MaxLogObjects; i++ {
+		logObjectField := "LogObject"
+		if i > 0 {
+			logObjectField += strconv.Itoa(i)
+		}
+		if logObjectFilename := paramData[logObjectField+".Filename"]; logObjectFilename != "" {
+			logFilenames[logObjectFilename] = struct{}{}
+		}
+	}
+	return logFilenames
+}
+
+// logSampleHeader returns the private header MakeLogSampleDotConfig sets on requests sampled at the given rate, in thousandths.
+// Private headers, prefixed with '@', aren't sent to origins or clients, but can be logged.
+func logSampleHeader(rate int) string {
+	return "@TC-Log-Sample-" + strconv.Itoa(rate)
+}
+
+// logSampleFilterName returns the name of the logging.yaml filter of the requests sampled at the given rate, in thousandths.
+func logSampleFilterName(rate int) string {
+	return "ds_sample_" + strconv.Itoa(rate)
+}
+
+// parseLogSampleRate returns the given sample rate Parameter value in thousandths, or an error if it isn't a number in (0, 1] or rounds to 0.
+func parseLogSampleRate(val string) (int, error) {
+	rate, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+	if err != nil {
+		return 0, errors.New("not a number")
+	}
+	if !(rate > 0 && rate <= 1) {
+		return 0, errors.New("not greater than 0 and at most 1")
+	}
+	parts := int(math.Round(rate * logSampleDenominator))
+	if parts == 0 {
+		return 0, errors.New("rounds to 0")
+	}
+	return parts, nil
+}
+
+// makeDSLoggingDotYAML returns the logging.yaml formats, filters, and logs of the Delivery Services on the server, and any warnings.
+// The logFilenames must be the filenames of the server's log objects, and the DS log filenames are added to it.
+func makeDSLoggingDotYAML(
+	server *Server,
+	opts LoggingDotYAMLOpts,
+	indentSpaces string,
+	logFilenames map[string]struct{},
+) (dsLogging, []string) {
+	warnings := []string{}
+	if len(opts.DSLoggingParams) == 0 {
+		return dsLogging{}, warnings
+	}
+
+	paramsWithProfiles, err := tcParamsToParamsWithProfiles(filterParams(opts.DSLoggingParams, LoggingYAMLFileName, "", "", ""))
+	if err != nil {
+		warnings = append(warnings, "error getting profiles from Traffic Ops Parameters, Delivery Service logs will not be generated! : "+err.Error())
+		return dsLogging{}, warnings
+	}
+	profileParams := map[string]map[string]string{} // map[profileName][paramName]paramVal
+	for _, param := range paramsWithProfiles {
+		for _, profile := range param.ProfileNames {
+			if _, ok := profileParams[profile]; !ok {
+				profileParams[profile] = map[string]string{}
+			}
+			profileParams[profile][param.Name] = param.Value
+		}
+	}
+
+	cdnDomain := ""
+	if opts.CDN != nil {
+		cdnDomain = opts.CDN.DomainName
+	}
+
+	dsRegexes := makeDSRegexMap(opts.DeliveryServiceRegexes)
+
+	cacheGroups, err := makeCGMap(opts.CacheGroups)
+	if err != nil {
+		warnings = append(warnings, "making cachegroup map, Delivery Service logs will not be generated! : "+err.Error())
+		return dsLogging{}, warnings
+	}
+
+	nameTopologies := makeTopologyNameMap(opts.Topologies)
+
+	dses := append([]DeliveryService{}, opts.DeliveryServices...)
+	sort.Sort(dsesSortByName(dses))
+
+	formats := ""
+	filters := ""
+	logs := ""
+	sampleRates := map[int]struct{}{}
+	for _, ds := range dses {
+		if ds.XMLID == nil || ds.ProfileName == nil {
+			continue
+		}
+		params := profileParams[*ds.ProfileName]
+		if len(params) == 0 {
+			continue
+		}
+
+		logObjectFields := []string{}
+		for i := 0; i < MaxLogObjects; i++ {
+			logObjectField := LoggingYAMLParamDSLogObject
+			if i > 0 {
+				logObjectField += strconv.Itoa(i)
+			}
+			if params[logObjectField+".Filename"] != "" {
+				logObjectFields = append(logObjectFields, logObjectField)
+			}
+		}
+		if len(logObjectFields) == 0 {
+			continue
+		}
+
+		hasDS, err := dsUsesServer(&ds, server, opts.DeliveryServiceServers, nameTopologies, cacheGroups, opts.ServerCapabilities, opts.DSRequiredCapabilities)
+		if err != nil {
+			warnings = append(warnings, "error checking if ds uses this server, considering false! Error: "+err.Error())
+			continue
+		}
+		if !hasDS {
+			continue
+		}
+
+		requestFQDNs, err := getDSRequestFQDNs(&ds, dsRegexes[tc.DeliveryServiceName(*ds.XMLID)], server, cdnDomain)
+		if err != nil {
+			warnings = append(warnings, "error getting ds '"+*ds.XMLID+"' request fqdns, not adding ds logs! Error: "+err.Error())
+			continue
+		}
+		if len(requestFQDNs) == 0 {
+			warnings = append(warnings, "ds '"+*ds.XMLID+"' has log Parameters but no host regexes, not adding ds logs!")
+			continue
+		}
+
+		formatName := ""
+		if params[LoggingYAMLParamDSFormat] != "" {
+			formatName = "ds_" + *ds.XMLID
+		}
+		filterName := "ds_" + *ds.XMLID + "_host"
+
+		dsLogs := ""
+		for _, logObjectField := range logObjectFields {
+			filename := params[logObjectField+".Filename"]
+			if _, ok := logFilenames[filename]; ok {
+				warnings = append(warnings, "ds '"+*ds.XMLID+"' log '"+logObjectField+"' filename '"+filename+"' is already used by another log, skipping!")
+				continue
+			}
+
+			format := params[logObjectField+".Format"]
+			if format == "" {
+				format = formatName
+			}
+			if format == "" {
+				warnings = append(warnings, "ds '"+*ds.XMLID+"' log '"+logObjectField+"' has no Format and the ds has no "+LoggingYAMLParamDSFormat+" Parameter, skipping!")
+				continue
+			}
+
+			sampleRate := logSampleDenominator
+			if val := params[logObjectField+LoggingYAMLParamDSSampleRate]; val != "" {
+				if sampleRate, err = parseLogSampleRate(val); err != nil {
+					warnings = append(warnings, "ds '"+*ds.XMLID+"' log '"+logObjectField+"' has invalid "+LoggingYAMLParamDSSampleRate[1:]+" '"+val+"': "+err.Error()+", skipping!")
+					continue
+				}
+			}
+
+			logType := params[logObjectField+".Type"]
+			if logType == "" {
+				logType = "ascii"
+			}
+			if logType != "ascii" && logType != "binary" && logType != "ascii_pipe" {
+				warnings = append(warnings, "ds '"+*ds.XMLID+"' log '"+logObjectField+"' has unknown Type '"+logType+"', skipping!")
+				continue
+			}
+
+			logFilenames[filename] = struct{}{}
+
+			dsLogs += indentSpaces + " - mode: " + logType + "\n"
+			dsLogs += indentSpaces + "   filename: " + filename + "\n"
+			dsLogs += indentSpaces + "   format: " + format + "\n"
+			if logType != "ascii_pipe" {
+				for _, rolling := range []struct {
+					param string
+					key   string
+				}{
+					{param: ".RollingEnabled", key: "rolling_enabled"},
+					{param: ".RollingIntervalSec", key: "rolling_interval_sec"},
+					{param: ".RollingOffsetHr", key: "rolling_offset_hr"},
+					{param: ".RollingSizeMb", key: "rolling_size_mb"},
+				} {
+					if val := params[logObjectField+rolling.param]; val != "" {
+						dsLogs += indentSpaces + "   " + rolling.key + ": " + val + "\n"
+					}
+				}
+			}
+
+			logFilters := filterName
+			if sampleRate != logSampleDenominator {
+				sampleRates[sampleRate] = struct{}{}
+				logFilters += ", " + logSampleFilterName(sampleRate)
+			}
+			if extraFilters := strings.Replace(params[logObjectField+".Filters"], "\v", "", -1); extraFilters != "" {
+				logFilters += ", " + extraFilters
+			}
+			dsLogs += indentSpaces + "   filters: [" + logFilters + "]\n"
+		}
+		if dsLogs == "" {
+			continue
+		}
+
+		if formatName != "" {
+			formats += indentSpaces + " - name: " + formatName + "\n"
+			formats += indentSpaces + "   format: '" + strings.Replace(params[LoggingYAMLParamDSFormat], "'", "''", -1) + "'\n"
+		}
+
+		// ATS log filters can't match regexes, so the filter is on the FQDNs the DS's host regexes are requested with on this server.
+		filters += indentSpaces + " - name: " + filterName + "\n"
+		filters += indentSpaces + "   action: accept\n"
+		filters += indentSpaces + "   condition: '{Host}cqh CASE_INSENSITIVE_MATCH " + strings.ToLower(strings.Join(requestFQDNs, ",")) + "'\n"
+
+		logs += dsLogs
+	}
+
+	rates := make([]int, 0, len(sampleRates))
+	for rate := range sampleRates {
+		rates = append(rates, rate)
+	}
+	sort.Ints(rates)
+	for _, rate := range rates {
+		filters += indentSpaces + " - name: " + logSampleFilterName(rate) + "\n"
+		filters += indentSpaces + "   action: accept\n"
+		filters += indentSpaces + "   condition: '{" + logSampleHeader(rate) + "}cqh MATCH 1'\n"
+	}
+
+	return dsLogging{Formats: formats, Filters: filters, Logs: logs, SampleRates: rates}, warnings
+}
+
+// GetLogSampleRates returns the sample rates of the Delivery Service logs in the server's logging.yaml, in thousandths, excluding logs of every request.
+// If there are any, the server needs the LogSampleFileName, loaded by plugin.config.
+func GetLogSampleRates(server *Server, serverParams []tc.Parameter, opts LoggingDotYAMLOpts) []int {
+	paramData, _ := paramsToMap(filterParams(serverParams, LoggingYAMLFileName, "", "", "location"))
+	dsLogging, _ := makeDSLoggingDotYAML(server, opts, "", getLogFilenames(paramData))
+	return dsLogging.SampleRates
+}
+
+// MakeLogSampleDotConfig makes the global header_rewrite config which marks the requests sampled for the Delivery Service logs with sample rates.
+//
+// ATS logs have no sampling, so each sampled request gets a private header, which the logging.yaml filters of logs with that sample rate accept.
+// The random number is drawn separately for each rate, so logs with different rates sample different requests.
+func MakeLogSampleDotConfig(
+	server *Server,
+	serverParams []tc.Parameter,
+	opts LoggingDotYAMLOpts,
+) (Cfg, error) {
+	warnings := []string{}
+	if server.Profile == nil {
+		return Cfg{}, makeErr(warnings, "this server missing Profile")
+	}
+
+	text := makeHdrComment(opts.HdrComment)
+	for _, rate := range GetLogSampleRates(server, serverParams, opts) {
+		text += "cond %{READ_REQUEST_HDR_HOOK} [AND]\n"
+		text += "cond %{RANDOM:" + strconv.Itoa(logSampleDenominator) + "} <" + strconv.Itoa(rate) + "\n"
+		text += "    set-header " + logSampleHeader(rate) + " 1\n"
+	}
+
+	return Cfg{
+		Text:        text,
+		ContentType: ContentTypeLogSampleDotConfig,
+		LineComment: LineCommentLogSampleDotConfig,
+		Warnings:    warnings,
+	}, nil
+}
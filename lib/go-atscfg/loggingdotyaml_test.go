@@ -24,6 +24,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
 	yaml "gopkg.in/yaml.v2"
 )
 
@@ -43,7 +46,7 @@ func TestMakeLoggingDotYAML(t *testing.T) {
 		"LogObject.Invalid":        "ShouldNotBeHere",
 	})
 
-	cfg, err := MakeLoggingDotYAML(server, params, hdr)
+	cfg, err := MakeLoggingDotYAML(server, params, LoggingDotYAMLOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -103,7 +106,7 @@ func TestMakeLoggingDotYAMLMultiFormat(t *testing.T) {
 	server := makeGenericServer()
 	server.Profile = &profileName
 
-	cfg, err := MakeLoggingDotYAML(server, paramData, hdr)
+	cfg, err := MakeLoggingDotYAML(server, paramData, LoggingDotYAMLOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -172,3 +175,198 @@ func TestMakeLoggingDotYAMLMultiFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestMakeLoggingDotYAMLDeliveryServices(t *testing.T) {
+	hdr := "myHeaderComment"
+
+	server := makeTestParentServer()
+	serverParams := makeParamsFromMap("serverprofile", LoggingYAMLFileName, map[string]string{
+		"LogFormat.Name":     "myFormatName",
+		"LogFormat.Format":   "myFormat",
+		"LogObject.Filename": "myFilename",
+		"LogObject.Format":   "myFormatName",
+		"LogFilter.Name":     "myExtraFilter",
+		"LogFilter.Filter":   "'cqhm MATCH GET'",
+	})
+
+	ds0 := makeParentDS()
+	ds0.XMLID = util.StrPtr("ds0")
+	ds0.Protocol = util.IntPtr(int(tc.DSProtocolHTTPAndHTTPS))
+	ds0.RoutingName = util.StrPtr("cdn")
+	ds0.ProfileName = util.StrPtr("ds0profile")
+
+	ds1 := makeParentDS()
+	ds1.ID = util.IntPtr(43)
+	ds1.Protocol = util.IntPtr(int(tc.DSProtocolHTTP))
+	ds1.RoutingName = util.StrPtr("cdn")
+	ds1.ProfileName = util.StrPtr("ds1profile")
+
+	ds2 := makeParentDS()
+	ds2.ID = util.IntPtr(44)
+	ds2.XMLID = util.StrPtr("ds2-not-on-server")
+	ds2.Protocol = util.IntPtr(int(tc.DSProtocolHTTP))
+	ds2.RoutingName = util.StrPtr("cdn")
+	ds2.ProfileName = util.StrPtr("ds2profile")
+
+	dses := []DeliveryService{*ds2, *ds1, *ds0}
+
+	dss := []DeliveryServiceServer{
+		{Server: *server.ID, DeliveryService: *ds0.ID},
+		{Server: *server.ID, DeliveryService: *ds1.ID},
+	}
+
+	dsr := []tc.DeliveryServiceRegexes{}
+	for _, ds := range dses {
+		dsr = append(dsr, tc.DeliveryServiceRegexes{
+			DSName:  *ds.XMLID,
+			Regexes: []tc.DeliveryServiceRegex{{Type: string(tc.DSMatchTypeHostRegex), Pattern: `.*\.` + *ds.XMLID + `\..*`}},
+		})
+	}
+
+	dsParams := makeParamsFromMap("ds0profile", LoggingYAMLFileName, map[string]string{
+		LoggingYAMLParamDSFormat:         "%<cqtq> %<chi> %<pssc> it's",
+		"DSLogObject.Filename":           "ds0",
+		"DSLogObject.RollingEnabled":     "3",
+		"DSLogObject1.Filename":          "ds0_pipe",
+		"DSLogObject1.Type":              "ascii_pipe",
+		"DSLogObject1.Format":            "myFormatName",
+		"DSLogObject1.RollingEnabled":    "shouldNotBeHere",
+		"DSLogObject2.Filename":          "myFilename",
+		"DSLogObject3.Filename":          "ds0_invalid_type",
+		"DSLogObject3.Type":              "shouldNotBeHere",
+		"DSLogObject11.Filename":         "shouldNotBeHere11",
+		"DSLogObject.Filters":            "myExtraFilter",
+		"DSLogObject.RollingIntervalSec": "86400",
+		"DSLogObject.SampleRate":         "0.25",
+		"DSLogObject4.Filename":          "shouldNotBeHereRate",
+		"DSLogObject4.SampleRate":        "2",
+	})
+	dsParams = append(dsParams, makeParamsFromMap("ds1profile", LoggingYAMLFileName, map[string]string{
+		"DSLogObject.Filename": "ds1_no_format",
+	})...)
+	dsParams = append(dsParams, makeParamsFromMap("ds2profile", LoggingYAMLFileName, map[string]string{
+		LoggingYAMLParamDSFormat: "%<cqtq>",
+		"DSLogObject.Filename":   "shouldNotBeHereDS2",
+	})...)
+	dsParams = append(dsParams, serverParams...)
+
+	cdn := &tc.CDN{DomainName: "cdndomain.example", Name: "my-cdn-name"}
+
+	eCG := &tc.CacheGroupNullable{}
+	eCG.Name = server.Cachegroup
+	eCG.ID = server.CachegroupID
+	eCG.ParentName = util.StrPtr("midCG")
+	eCG.ParentCachegroupID = util.IntPtr(423)
+	eCGType := tc.CacheGroupEdgeTypeName
+	eCG.Type = &eCGType
+
+	mCG := &tc.CacheGroupNullable{}
+	mCG.Name = util.StrPtr("midCG")
+	mCG.ID = util.IntPtr(423)
+	mCGType := tc.CacheGroupMidTypeName
+	mCG.Type = &mCGType
+
+	cgs := []tc.CacheGroupNullable{*eCG, *mCG}
+
+	opts := LoggingDotYAMLOpts{
+		HdrComment:             hdr,
+		DeliveryServices:       dses,
+		DeliveryServiceServers: dss,
+		DeliveryServiceRegexes: dsr,
+		DSLoggingParams:        dsParams,
+		CDN:                    cdn,
+		CacheGroups:            cgs,
+	}
+	cfg, err := MakeLoggingDotYAML(server, serverParams, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	testComment(t, txt, hdr)
+	testLint(t, LoggingYAMLFileName, txt)
+
+	if strings.Contains(txt, "shouldNotBeHere") {
+		t.Errorf("expected config to omit invalid and unused ds logs, actual: '%v' warnings '%+v'", txt, cfg.Warnings)
+	}
+
+	v := struct {
+		Formats []struct {
+			Name   string
+			Format string
+		}
+		Filters []struct {
+			Name      string
+			Action    string
+			Condition string
+		}
+		Logs []struct {
+			Mode                 string
+			Filename             string
+			Format               string
+			Rolling_enabled      string
+			Rolling_interval_sec int
+			Filters              []string
+		}
+	}{}
+	if err := yaml.Unmarshal([]byte(txt), &v); err != nil {
+		t.Fatalf("expected config to parse as yaml document '%v', actual: '%v'", err, txt)
+	}
+
+	if len(v.Formats) != 2 || v.Formats[1].Name != "ds_ds0" || v.Formats[1].Format != "%<cqtq> %<chi> %<pssc> it's" {
+		t.Errorf("expected ds0 format after the server format, actual: '%+v' full: '%v'", v.Formats, txt)
+	}
+	if len(v.Filters) != 3 {
+		t.Fatalf("expected config to contain the server filter, the ds0 host filter, and the sample filter, actual: '%+v' full: '%v'", v.Filters, txt)
+	}
+	if v.Filters[1].Name != "ds_ds0_host" || v.Filters[1].Action != "accept" || v.Filters[1].Condition != "{Host}cqh CASE_INSENSITIVE_MATCH cdn.ds0.cdndomain.example" {
+		t.Errorf("expected ds0 host filter, actual: '%+v' full: '%v'", v.Filters[1], txt)
+	}
+	if v.Filters[2].Name != "ds_sample_250" || v.Filters[2].Action != "accept" || v.Filters[2].Condition != "{@TC-Log-Sample-250}cqh MATCH 1" {
+		t.Errorf("expected sample filter, actual: '%+v' full: '%v'", v.Filters[2], txt)
+	}
+
+	if len(v.Logs) != 3 {
+		t.Fatalf("expected config to contain the server log and 2 ds0 logs, actual: '%+v' full: '%v' warnings '%+v'", v.Logs, txt, cfg.Warnings)
+	}
+	if v.Logs[0].Filename != "myFilename" {
+		t.Errorf("expected server log first, actual: '%+v'", v.Logs[0])
+	}
+	if log := v.Logs[1]; log.Filename != "ds0" || log.Mode != "ascii" || log.Format != "ds_ds0" || log.Rolling_enabled != "3" || log.Rolling_interval_sec != 86400 ||
+		len(log.Filters) != 3 || log.Filters[0] != "ds_ds0_host" || log.Filters[1] != "ds_sample_250" || log.Filters[2] != "myExtraFilter" {
+		t.Errorf("expected ds0 ascii log, actual: '%+v' full: '%v'", log, txt)
+	}
+	if log := v.Logs[2]; log.Filename != "ds0_pipe" || log.Mode != "ascii_pipe" || log.Format != "myFormatName" || len(log.Filters) != 1 || log.Filters[0] != "ds_ds0_host" {
+		t.Errorf("expected ds0 pipe log, actual: '%+v' full: '%v'", log, txt)
+	}
+
+	warnings := strings.Join(cfg.Warnings, "\n")
+	for _, expected := range []string{"'myFilename' is already used", "unknown Type 'shouldNotBeHere'", "ds 'ds1' log 'DSLogObject' has no Format", "invalid SampleRate '2'"} {
+		if !strings.Contains(warnings, expected) {
+			t.Errorf("expected warning containing '%v', actual: '%+v'", expected, cfg.Warnings)
+		}
+	}
+
+	sampleCfg, err := MakeLogSampleDotConfig(server, serverParams, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testComment(t, sampleCfg.Text, hdr)
+	expected := "cond %{READ_REQUEST_HDR_HOOK} [AND]\ncond %{RANDOM:1000} <250\n    set-header @TC-Log-Sample-250 1\n"
+	if !strings.HasSuffix(sampleCfg.Text, expected) {
+		t.Errorf("expected log sample config '%v', actual: '%v'", expected, sampleCfg.Text)
+	}
+}
+
+func TestParseLogSampleRate(t *testing.T) {
+	for val, expected := range map[string]int{"1": 1000, "0.25": 250, " 0.0015 ": 2} {
+		if actual, err := parseLogSampleRate(val); err != nil || actual != expected {
+			t.Errorf("expected rate '%v' to be %v thousandths, actual: %v error %v", val, expected, actual, err)
+		}
+	}
+	for _, val := range []string{"", "abc", "0", "-0.5", "1.5", "0.0001"} {
+		if _, err := parseLogSampleRate(val); err == nil {
+			t.Errorf("expected rate '%v' to be invalid, actual: no error", val)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package preview
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// ChangeSet is a set of proposed changes to Traffic Ops data.
+type ChangeSet struct {
+	Parameters []ParameterChange `json:"parameters"`
+
+	// DeliveryServices are Delivery Services to change or add. Each is merged
+	// over the existing Delivery Service with the same xmlId, so only changed
+	// fields need be given. If there is no such Delivery Service, it's added,
+	// with the default Host regex.
+	DeliveryServices []json.RawMessage `json:"deliveryServices"`
+
+	// DeleteDeliveryServices are the xmlIds of Delivery Services to delete.
+	DeleteDeliveryServices []string `json:"deleteDeliveryServices"`
+}
+
+// ParameterChange is a change to a Parameter on a Profile.
+//
+// The Parameter is identified by its Profile, ConfigFile, and Name. If the
+// Profile has no such Parameter, it's added. If it has more than one, OldValue
+// must be given to choose one.
+type ParameterChange struct {
+	Profile    string  `json:"profile"`
+	ConfigFile string  `json:"configFile"`
+	Name       string  `json:"name"`
+	OldValue   *string `json:"oldValue,omitempty"`
+	Value      string  `json:"value"`
+	Delete     bool    `json:"delete"`
+}
+
+// Apply returns a copy of the given data with the ChangeSet applied. The given
+// data is not modified.
+func (cs ChangeSet) Apply(data *atscfg.CDNConfigData) (*atscfg.CDNConfigData, error) {
+	changed := *data
+	changed.GlobalParams = append([]tc.Parameter{}, data.GlobalParams...)
+	changed.CacheKeyParams = append([]tc.Parameter{}, data.CacheKeyParams...)
+	changed.ParentConfigParams = append([]tc.Parameter{}, data.ParentConfigParams...)
+	changed.LoggingYAMLParams = append([]tc.Parameter{}, data.LoggingYAMLParams...)
+	changed.ProfileParams = map[string][]tc.Parameter{}
+	for profile, params := range data.ProfileParams {
+		changed.ProfileParams[profile] = append([]tc.Parameter{}, params...)
+	}
+	changed.DeliveryServices = append([]atscfg.DeliveryService{}, data.DeliveryServices...)
+	changed.DeliveryServiceServers = append([]atscfg.DeliveryServiceServer{}, data.DeliveryServiceServers...)
+	changed.DeliveryServiceRegexes = append([]tc.DeliveryServiceRegexes{}, data.DeliveryServiceRegexes...)
+
+	for _, pc := range cs.Parameters {
+		if err := applyParameterChange(&changed, pc); err != nil {
+			return nil, errors.New("parameter '" + pc.Name + "' config file '" + pc.ConfigFile + "' on profile '" + pc.Profile + "': " + err.Error())
+		}
+	}
+	for i, dsPatch := range cs.DeliveryServices {
+		if err := applyDeliveryServiceChange(&changed, dsPatch); err != nil {
+			return nil, errors.New("delivery service " + strconv.Itoa(i) + ": " + err.Error())
+		}
+	}
+	for _, xmlID := range cs.DeleteDeliveryServices {
+		if err := deleteDeliveryService(&changed, xmlID); err != nil {
+			return nil, errors.New("deleting delivery service '" + xmlID + "': " + err.Error())
+		}
+	}
+	return &changed, nil
+}
+
+// applyParameterChange applies the change to the Profile's Parameters, and to
+// the Parameters of all Profiles with the same config file, which the data
+// also has for some config files.
+func applyParameterChange(data *atscfg.CDNConfigData, pc ParameterChange) error {
+	if pc.Profile == "" || pc.ConfigFile == "" || pc.Name == "" {
+		return errors.New("profile, configFile, and name are required")
+	}
+	if pc.Profile == tc.GlobalProfileName {
+		params, err := changeParams(data.GlobalParams, pc, false)
+		if err != nil {
+			return err
+		}
+		data.GlobalParams = params
+	} else {
+		params, ok := data.ProfileParams[pc.Profile]
+		if !ok {
+			return errors.New("profile is not the profile of any cache on the CDN")
+		}
+		params, err := changeParams(params, pc, false)
+		if err != nil {
+			return err
+		}
+		data.ProfileParams[pc.Profile] = params
+	}
+
+	switch pc.ConfigFile {
+	case atscfg.CacheKeyParameterConfigFile:
+		params, err := changeParams(data.CacheKeyParams, pc, true)
+		if err != nil {
+			return err
+		}
+		data.CacheKeyParams = params
+	case atscfg.ParentConfigFileName:
+		params, err := changeParams(data.ParentConfigParams, pc, true)
+		if err != nil {
+			return err
+		}
+		data.ParentConfigParams = params
+	case atscfg.LoggingYAMLFileName:
+		params, err := changeParams(data.LoggingYAMLParams, pc, true)
+		if err != nil {
+			return err
+		}
+		data.LoggingYAMLParams = params
+	}
+	return nil
+}
+
+// changeParams returns a copy of params with the change applied.
+//
+// If byProfiles is true, params are the Parameters of any Profile, and the
+// Profiles of each are in its Profiles. Otherwise, params are the Parameters
+// of the changed Profile. A Parameter shared with other Profiles is split, so
+// the change only applies to the changed Profile, as in Traffic Ops.
+func changeParams(params []tc.Parameter, pc ParameterChange, byProfiles bool) ([]tc.Parameter, error) {
+	match := -1
+	matchProfiles := []string{}
+	for i, param := range params {
+		if param.ConfigFile != pc.ConfigFile || param.Name != pc.Name || (pc.OldValue != nil && param.Value != *pc.OldValue) {
+			continue
+		}
+		profiles := []string{}
+		if byProfiles {
+			if err := json.Unmarshal(param.Profiles, &profiles); err != nil {
+				return nil, errors.New("unmarshalling parameter " + strconv.Itoa(param.ID) + " profiles: " + err.Error())
+			}
+			if !stringsContain(profiles, pc.Profile) {
+				continue
+			}
+		}
+		if match >= 0 {
+			return nil, errors.New("profile has multiple parameters with the name and config file, and oldValue doesn't choose one")
+		}
+		match = i
+		matchProfiles = profiles
+	}
+
+	changed := append([]tc.Parameter{}, params...)
+	if match >= 0 {
+		otherProfiles := []string{}
+		for _, profile := range matchProfiles {
+			if profile != pc.Profile {
+				otherProfiles = append(otherProfiles, profile)
+			}
+		}
+		if len(otherProfiles) == 0 {
+			changed = append(changed[:match], changed[match+1:]...)
+		} else {
+			profilesJSON, err := json.Marshal(otherProfiles)
+			if err != nil {
+				return nil, errors.New("marshalling profiles: " + err.Error())
+			}
+			changed[match].Profiles = profilesJSON
+		}
+	} else if pc.Delete {
+		return nil, errors.New("profile has no such parameter to delete")
+	}
+	if pc.Delete {
+		return changed, nil
+	}
+
+	newParam := tc.Parameter{ConfigFile: pc.ConfigFile, Name: pc.Name, Value: pc.Value}
+	if match >= 0 {
+		newParam.ID = params[match].ID
+		newParam.Secure = params[match].Secure
+	}
+	profilesJSON, err := json.Marshal([]string{pc.Profile})
+	if err != nil {
+		return nil, errors.New("marshalling profiles: " + err.Error())
+	}
+	newParam.Profiles = profilesJSON
+	return append(changed, newParam), nil
+}
+
+// applyDeliveryServiceChange merges the given partial Delivery Service over the
+// existing Delivery Service with its xmlId, or adds it if none exists.
+func applyDeliveryServiceChange(data *atscfg.CDNConfigData, dsPatch json.RawMessage) error {
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(dsPatch, &patch); err != nil {
+		return errors.New("decoding: " + err.Error())
+	}
+	xmlID, _ := patch["xmlId"].(string)
+	if xmlID == "" {
+		return errors.New("missing xmlId")
+	}
+
+	existing := -1
+	for i, ds := range data.DeliveryServices {
+		if ds.XMLID != nil && *ds.XMLID == xmlID {
+			existing = i
+			break
+		}
+	}
+
+	merged := map[string]interface{}{}
+	if existing >= 0 {
+		existingJSON, err := json.Marshal(data.DeliveryServices[existing])
+		if err != nil {
+			return errors.New("encoding existing delivery service '" + xmlID + "': " + err.Error())
+		}
+		if err := json.Unmarshal(existingJSON, &merged); err != nil {
+			return errors.New("decoding existing delivery service '" + xmlID + "': " + err.Error())
+		}
+	}
+	for key, val := range patch {
+		merged[key] = val
+	}
+	mergedJSON, err := json.Marshal(merged)
+	if err != nil {
+		return errors.New("encoding delivery service '" + xmlID + "': " + err.Error())
+	}
+	ds := atscfg.DeliveryService{}
+	if err := json.Unmarshal(mergedJSON, &ds); err != nil {
+		return errors.New("decoding delivery service '" + xmlID + "': " + err.Error())
+	}
+
+	if existing >= 0 {
+		data.DeliveryServices[existing] = ds
+		return nil
+	}
+
+	// A new Delivery Service gets an ID no other has, and the CDN of the data.
+	id := 0
+	for _, other := range data.DeliveryServices {
+		if other.ID != nil && *other.ID >= id {
+			id = *other.ID + 1
+		}
+	}
+	ds.ID = &id
+	if data.CDN != nil {
+		ds.CDNID = &data.CDN.ID
+		cdnName := string(data.CDN.Name)
+		ds.CDNName = &cdnName
+	}
+	data.DeliveryServices = append(data.DeliveryServices, ds)
+	data.DeliveryServiceRegexes = append(data.DeliveryServiceRegexes, tc.DeliveryServiceRegexes{
+		DSName: xmlID,
+		Regexes: []tc.DeliveryServiceRegex{{
+			Type:      string(tc.DSMatchTypeHostRegex),
+			SetNumber: 0,
+			Pattern:   `.*\.` + xmlID + `\..*`,
+		}},
+	})
+	return nil
+}
+
+// deleteDeliveryService removes the Delivery Service with the given xmlId, and
+// its server assignments and regexes.
+func deleteDeliveryService(data *atscfg.CDNConfigData, xmlID string) error {
+	dsID := (*int)(nil)
+	dses := []atscfg.DeliveryService{}
+	for _, ds := range data.DeliveryServices {
+		if ds.XMLID != nil && *ds.XMLID == xmlID {
+			dsID = ds.ID
+			continue
+		}
+		dses = append(dses, ds)
+	}
+	if dsID == nil {
+		return errors.New("not found")
+	}
+	data.DeliveryServices = dses
+
+	dsses := []atscfg.DeliveryServiceServer{}
+	for _, dss := range data.DeliveryServiceServers {
+		if dss.DeliveryService != *dsID {
+			dsses = append(dsses, dss)
+		}
+	}
+	data.DeliveryServiceServers = dsses
+
+	regexes := []tc.DeliveryServiceRegexes{}
+	for _, dsRegexes := range data.DeliveryServiceRegexes {
+		if dsRegexes.DSName != xmlID {
+			regexes = append(regexes, dsRegexes)
+		}
+	}
+	data.DeliveryServiceRegexes = regexes
+	return nil
+}
+
+func stringsContain(strs []string, s string) bool {
+	for _, str := range strs {
+		if str == s {
+			return true
+		}
+	}
+	return false
+}
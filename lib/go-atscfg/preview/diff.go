@@ -0,0 +1,129 @@
+package preview
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kylelemons/godebug/diff"
+)
+
+// DiffContextLines is the number of unchanged lines around each change in a
+// unified diff.
+const DiffContextLines = 3
+
+// UnifiedDiff returns the unified diff of the text of the file at the given
+// path, before and after a change, or the empty string if it didn't change.
+// Whether the file existed before and after is given, so a created or deleted
+// file is a change, even if it's empty.
+func UnifiedDiff(path string, before string, after string, beforeExisted bool, afterExisted bool) string {
+	if before == after && beforeExisted == afterExisted {
+		return ""
+	}
+	beforeLines := []string{}
+	if beforeExisted {
+		beforeLines = strings.Split(before, "\n")
+	}
+	afterLines := []string{}
+	if afterExisted {
+		afterLines = strings.Split(after, "\n")
+	}
+
+	type diffLine struct {
+		Op   byte
+		Text string
+	}
+	lines := []diffLine{}
+	for _, chunk := range diff.DiffChunks(beforeLines, afterLines) {
+		for _, line := range chunk.Deleted {
+			lines = append(lines, diffLine{Op: '-', Text: line})
+		}
+		for _, line := range chunk.Added {
+			lines = append(lines, diffLine{Op: '+', Text: line})
+		}
+		for _, line := range chunk.Equal {
+			lines = append(lines, diffLine{Op: ' ', Text: line})
+		}
+	}
+
+	txt := strings.Builder{}
+	if beforeExisted {
+		txt.WriteString("--- a" + path + "\n")
+	} else {
+		txt.WriteString("--- /dev/null\n")
+	}
+	if afterExisted {
+		txt.WriteString("+++ b" + path + "\n")
+	} else {
+		txt.WriteString("+++ /dev/null\n")
+	}
+
+	changed := []int{}
+	for i, line := range lines {
+		if line.Op != ' ' {
+			changed = append(changed, i)
+		}
+	}
+
+	// beforeNums and afterNums are the number of lines of each file before each
+	// diff line.
+	beforeNums, afterNums := make([]int, len(lines)+1), make([]int, len(lines)+1)
+	for i, line := range lines {
+		beforeNums[i+1], afterNums[i+1] = beforeNums[i], afterNums[i]
+		if line.Op != '+' {
+			beforeNums[i+1]++
+		}
+		if line.Op != '-' {
+			afterNums[i+1]++
+		}
+	}
+
+	for c := 0; c < len(changed); {
+		// A hunk includes every change whose context overlaps the previous one's.
+		first, last := changed[c], changed[c]
+		for c++; c < len(changed) && changed[c]-last-1 <= 2*DiffContextLines; c++ {
+			last = changed[c]
+		}
+		start := first - DiffContextLines
+		if start < 0 {
+			start = 0
+		}
+		end := last + DiffContextLines + 1
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		txt.WriteString("@@ -" + hunkRange(beforeNums[start], beforeNums[end]-beforeNums[start]) + " +" + hunkRange(afterNums[start], afterNums[end]-afterNums[start]) + " @@\n")
+		for _, line := range lines[start:end] {
+			txt.WriteString(string(line.Op) + line.Text + "\n")
+		}
+	}
+	return txt.String()
+}
+
+// hunkRange returns the range of a unified diff hunk header, of the given
+// number of lines after the given number of preceding lines.
+func hunkRange(preceding int, length int) string {
+	if length == 0 {
+		return strconv.Itoa(preceding) + ",0"
+	}
+	return strconv.Itoa(preceding+1) + "," + strconv.Itoa(length)
+}
@@ -0,0 +1,241 @@
+package preview
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+)
+
+// Report is the result of previewing a ChangeSet.
+type Report struct {
+	// Servers is the number of caches whose config was generated.
+	Servers int `json:"servers"`
+
+	// ChangedServers is the number of caches whose config changed.
+	ChangedServers int `json:"changedServers"`
+
+	// Changes are the config changes, each with the caches it applies to.
+	// Caches with identical changes are grouped into a single Change. Changes
+	// are sorted by the number of caches, most first.
+	Changes []Change `json:"changes"`
+
+	// Errors are the caches whose config failed to generate, which are not in
+	// Changes.
+	Errors []ServerError `json:"errors"`
+}
+
+// Change is a set of config file changes, and the caches they apply to.
+type Change struct {
+	Servers []string   `json:"servers"`
+	Files   []FileDiff `json:"files"`
+}
+
+// FileDiff is the change to a single config file.
+type FileDiff struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Diff is the unified diff of the file, ignoring comments, as t3c-diff does.
+	Diff string `json:"diff"`
+}
+
+// ServerError is an error generating a cache's config.
+type ServerError struct {
+	Server string `json:"server"`
+	Error  string `json:"error"`
+}
+
+// DefaultDir is the default ATS config directory, used for config files
+// without location Parameters.
+const DefaultDir = "/opt/trafficserver/etc/trafficserver"
+
+// Opts contains settings to configure previewing.
+type Opts struct {
+	// ConfigFilesOpts are the options to generate config files with.
+	ConfigFilesOpts atscfg.ConfigFilesOpts
+
+	// Parallel is the number of caches to generate config for at once.
+	// If 0, the number of CPUs is used.
+	Parallel int
+}
+
+// DefaultOpts returns the Opts to preview config as t3c-generate generates it
+// by default, with the given ATS config directory.
+func DefaultOpts(dir string) Opts {
+	return Opts{
+		ConfigFilesOpts: atscfg.ConfigFilesOpts{
+			Dir:                dir,
+			ParentComments:     true,
+			DefaultTLSVersions: atscfg.DefaultDefaultTLSVersions,
+		},
+	}
+}
+
+// Preview generates the config of every cache on the CDN with the data before
+// and after a change, and returns the caches whose config differs and how.
+func Preview(before *atscfg.CDNConfigData, after *atscfg.CDNConfigData, opts Opts) Report {
+	caches := before.CacheServers()
+	parallel := opts.Parallel
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+
+	type result struct {
+		Server string
+		Files  []FileDiff
+		Err    error
+	}
+	results := make([]result, len(caches))
+
+	idxs := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range idxs {
+				hostName := *caches[idx].HostName
+				files, err := previewServer(before, after, hostName, opts.ConfigFilesOpts)
+				results[idx] = result{Server: hostName, Files: files, Err: err}
+			}
+		}()
+	}
+	for i := range caches {
+		idxs <- i
+	}
+	close(idxs)
+	wg.Wait()
+
+	report := Report{Servers: len(caches), Changes: []Change{}, Errors: []ServerError{}}
+	changes := map[string]*Change{}
+	for _, res := range results {
+		if res.Err != nil {
+			report.Errors = append(report.Errors, ServerError{Server: res.Server, Error: res.Err.Error()})
+			continue
+		}
+		if len(res.Files) == 0 {
+			continue
+		}
+		report.ChangedServers++
+		key := changeKey(res.Files)
+		change, ok := changes[key]
+		if !ok {
+			change = &Change{Files: res.Files}
+			changes[key] = change
+		}
+		change.Servers = append(change.Servers, res.Server)
+	}
+	for _, change := range changes {
+		report.Changes = append(report.Changes, *change)
+	}
+	sort.Slice(report.Changes, func(i, j int) bool {
+		ci, cj := report.Changes[i], report.Changes[j]
+		if len(ci.Servers) != len(cj.Servers) {
+			return len(ci.Servers) > len(cj.Servers)
+		}
+		return ci.Servers[0] < cj.Servers[0]
+	})
+	return report
+}
+
+// changeKey returns a key identifying the given file changes, so identical
+// changes of different caches can be grouped.
+func changeKey(files []FileDiff) string {
+	key := strings.Builder{}
+	for _, file := range files {
+		key.WriteString(file.Path + "/" + file.Name + "\x00" + file.Diff + "\x00")
+	}
+	return key.String()
+}
+
+// previewServer generates the config of the given cache before and after a
+// change, and returns the files which differ, sorted by path.
+func previewServer(before *atscfg.CDNConfigData, after *atscfg.CDNConfigData, hostName string, opts atscfg.ConfigFilesOpts) ([]FileDiff, error) {
+	beforeFiles, err := generate(before, hostName, opts)
+	if err != nil {
+		return nil, errors.New("generating config before change: " + err.Error())
+	}
+	afterFiles, err := generate(after, hostName, opts)
+	if err != nil {
+		return nil, errors.New("generating config after change: " + err.Error())
+	}
+
+	paths := map[string]struct{}{}
+	for path := range beforeFiles {
+		paths[path] = struct{}{}
+	}
+	for path := range afterFiles {
+		paths[path] = struct{}{}
+	}
+	sortedPaths := make([]string, 0, len(paths))
+	for path := range paths {
+		sortedPaths = append(sortedPaths, path)
+	}
+	sort.Strings(sortedPaths)
+
+	diffs := []FileDiff{}
+	for _, path := range sortedPaths {
+		beforeFile, beforeOK := beforeFiles[path]
+		afterFile, afterOK := afterFiles[path]
+		diff := UnifiedDiff(path, normalize(beforeFile.Text), normalize(afterFile.Text), beforeOK, afterOK)
+		if diff == "" {
+			continue
+		}
+		file := afterFile
+		if !afterOK {
+			file = beforeFile
+		}
+		diffs = append(diffs, FileDiff{Name: file.Name, Path: file.Path, Diff: diff})
+	}
+	return diffs, nil
+}
+
+// generate generates the config files of the given cache, by their full path.
+func generate(data *atscfg.CDNConfigData, hostName string, opts atscfg.ConfigFilesOpts) (map[string]atscfg.ConfigFile, error) {
+	toData, err := data.ServerConfigData(hostName)
+	if err != nil {
+		return nil, err
+	}
+	// Warnings aren't part of the preview, and generated comments are removed
+	// before comparing, so no header comment is needed.
+	files, _, err := atscfg.MakeConfigFiles(toData, "", opts)
+	if err != nil {
+		return nil, err
+	}
+	filesByPath := make(map[string]atscfg.ConfigFile, len(files))
+	for _, file := range files {
+		filesByPath[strings.TrimSuffix(file.Path, "/")+"/"+file.Name] = file
+	}
+	return filesByPath, nil
+}
+
+// normalize removes the semantically irrelevant text of a config file, as
+// t3c-diff does, so generated comments don't show up as changes.
+func normalize(text string) string {
+	lines := strings.Split(text, "\n")
+	lines = atscfg.UnencodeFilter(lines)
+	lines = atscfg.CommentsFilter(lines)
+	return atscfg.NewLineFilter(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,256 @@
+package preview
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func makeTestServer(id int, hostName string, profile string, svType string) atscfg.Server {
+	sv := atscfg.Server{}
+	sv.ID = util.IntPtr(id)
+	sv.HostName = util.StrPtr(hostName)
+	sv.DomainName = util.StrPtr("example.net")
+	sv.Profile = util.StrPtr(profile)
+	sv.Type = svType
+	sv.CDNID = util.IntPtr(1)
+	sv.CDNName = util.StrPtr("mycdn")
+	sv.Cachegroup = util.StrPtr("cg0")
+	sv.CachegroupID = util.IntPtr(1)
+	sv.ProfileID = util.IntPtr(id)
+	sv.TypeID = util.IntPtr(1)
+	sv.HTTPSPort = util.IntPtr(443)
+	sv.Status = util.StrPtr(string(tc.CacheStatusReported))
+	sv.TCPPort = util.IntPtr(80)
+	sv.Interfaces = []tc.ServerInterfaceInfo{{
+		Name:        "eth0",
+		IPAddresses: []tc.ServerIPAddress{{Address: "192.0.2." + hostName[len(hostName)-1:], ServiceAddress: true}},
+	}}
+	return sv
+}
+
+func makeTestParam(profile string, configFile string, name string, value string) tc.Parameter {
+	return tc.Parameter{ConfigFile: configFile, Name: name, Value: value, Profiles: json.RawMessage(`["` + profile + `"]`)}
+}
+
+// makeTestRecordsParams returns records.config parameters which are the same
+// for every server, so diffs of the first aren't made unique by the context of
+// server-specific lines.
+func makeTestRecordsParams(profile string) []tc.Parameter {
+	return []tc.Parameter{
+		makeTestParam(profile, atscfg.RecordsFileName, "CONFIG proxy.config.http.insert_age_in_response", "INT 0"),
+		makeTestParam(profile, atscfg.RecordsFileName, "CONFIG proxy.config.http.keep_alive_enabled_in", "INT 1"),
+		makeTestParam(profile, atscfg.RecordsFileName, "CONFIG proxy.config.http.keep_alive_enabled_out", "INT 1"),
+		makeTestParam(profile, atscfg.RecordsFileName, "CONFIG proxy.config.http.server_ports", "STRING 80"),
+	}
+}
+
+func makeTestData() *atscfg.CDNConfigData {
+	data := &atscfg.CDNConfigData{
+		ProfileParams: map[string][]tc.Parameter{
+			"EDGE_A": append(makeTestRecordsParams("EDGE_A"),
+				makeTestParam("EDGE_A", atscfg.ParentConfigFileName, atscfg.ParentConfigParamQStringHandling, "ignore"),
+			),
+			"EDGE_B": makeTestRecordsParams("EDGE_B"),
+		},
+		Profiles: map[string]tc.Profile{
+			"EDGE_A": {Name: "EDGE_A"},
+			"EDGE_B": {Name: "EDGE_B"},
+		},
+	}
+	data.CDN = &tc.CDN{ID: 1, Name: "mycdn", DomainName: "mycdn.example.net"}
+	data.Servers = []atscfg.Server{
+		makeTestServer(1, "edge1", "EDGE_A", tc.EdgeTypePrefix),
+		makeTestServer(2, "edge2", "EDGE_A", tc.EdgeTypePrefix),
+		makeTestServer(3, "edge3", "EDGE_B", tc.EdgeTypePrefix),
+		makeTestServer(4, "router4", "CCR", tc.RouterTypeName),
+	}
+	data.CacheGroups = []tc.CacheGroupNullable{{ID: util.IntPtr(1), Name: util.StrPtr("cg0"), Type: util.StrPtr(tc.CacheGroupEdgeTypeName)}}
+	data.ParentConfigParams = []tc.Parameter{
+		makeTestParam("EDGE_A", atscfg.ParentConfigFileName, atscfg.ParentConfigParamQStringHandling, "ignore"),
+	}
+	data.CacheKeyParams = []tc.Parameter{}
+	data.GlobalParams = []tc.Parameter{}
+	return data
+}
+
+func TestApplyParameterChange(t *testing.T) {
+	data := makeTestData()
+	changes := ChangeSet{Parameters: []ParameterChange{
+		{Profile: "EDGE_A", ConfigFile: atscfg.RecordsFileName, Name: "CONFIG proxy.config.http.insert_age_in_response", Value: "INT 1"},
+		{Profile: "EDGE_B", ConfigFile: atscfg.ParentConfigFileName, Name: atscfg.ParentConfigParamQStringHandling, Value: "ignore"},
+		{Profile: "EDGE_A", ConfigFile: atscfg.ParentConfigFileName, Name: atscfg.ParentConfigParamQStringHandling, Delete: true},
+	}}
+	changed, err := changes.Apply(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if val := data.ProfileParams["EDGE_A"][0].Value; val != "INT 0" {
+		t.Errorf("expected Apply to not modify the original data, actual param value '%s'", val)
+	}
+	edgeA := changed.ProfileParams["EDGE_A"]
+	if len(edgeA) != 4 || edgeA[0].Value != "INT 1" {
+		t.Errorf("expected EDGE_A params to be the records.config params with the changed param, actual: %+v", edgeA)
+	}
+	if len(changed.ProfileParams["EDGE_B"]) != 5 {
+		t.Errorf("expected EDGE_B params to have the added param, actual: %+v", changed.ProfileParams["EDGE_B"])
+	}
+	if len(changed.ParentConfigParams) != 1 || string(changed.ParentConfigParams[0].Profiles) != `["EDGE_B"]` {
+		t.Errorf("expected parent.config params to only have the EDGE_B param, actual: %+v", changed.ParentConfigParams)
+	}
+
+	if _, err := (ChangeSet{Parameters: []ParameterChange{{Profile: "NOPE", ConfigFile: "a", Name: "b"}}}).Apply(data); err == nil {
+		t.Error("expected changing a parameter on a profile without caches to fail")
+	}
+	if _, err := (ChangeSet{Parameters: []ParameterChange{{Profile: "EDGE_B", ConfigFile: "a", Name: "b", Delete: true}}}).Apply(data); err == nil {
+		t.Error("expected deleting a nonexistent parameter to fail")
+	}
+}
+
+func TestApplyParameterChangeShared(t *testing.T) {
+	params := []tc.Parameter{{ID: 42, ConfigFile: "parent.config", Name: "foo", Value: "bar", Profiles: json.RawMessage(`["A","B"]`)}}
+	changed, err := changeParams(params, ParameterChange{Profile: "A", ConfigFile: "parent.config", Name: "foo", Value: "baz"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 2 {
+		t.Fatalf("expected changing a shared parameter to split it, actual: %+v", changed)
+	}
+	if changed[0].Value != "bar" || string(changed[0].Profiles) != `["B"]` {
+		t.Errorf("expected the shared parameter to keep its value for the other profile, actual: %+v", changed[0])
+	}
+	if changed[1].Value != "baz" || string(changed[1].Profiles) != `["A"]` {
+		t.Errorf("expected the changed profile to get a new parameter, actual: %+v", changed[1])
+	}
+}
+
+func TestApplyDeliveryServiceChange(t *testing.T) {
+	data := makeTestData()
+	ds := atscfg.DeliveryService{}
+	ds.ID = util.IntPtr(7)
+	ds.XMLID = util.StrPtr("ds0")
+	ds.OrgServerFQDN = util.StrPtr("http://origin.example.net")
+	ds.CCRDNSTTL = util.IntPtr(30)
+	data.DeliveryServices = []atscfg.DeliveryService{ds}
+	data.DeliveryServiceServers = []atscfg.DeliveryServiceServer{{Server: 1, DeliveryService: 7}}
+	data.DeliveryServiceRegexes = []tc.DeliveryServiceRegexes{{DSName: "ds0"}}
+
+	changes := ChangeSet{DeliveryServices: []json.RawMessage{
+		json.RawMessage(`{"xmlId": "ds0", "orgServerFqdn": "http://origin2.example.net"}`),
+		json.RawMessage(`{"xmlId": "ds1", "active": true}`),
+	}}
+	changed, err := changes.Apply(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed.DeliveryServices) != 2 {
+		t.Fatalf("expected 2 delivery services, actual: %d", len(changed.DeliveryServices))
+	}
+	ds0 := changed.DeliveryServices[0]
+	if *ds0.OrgServerFQDN != "http://origin2.example.net" || ds0.CCRDNSTTL == nil || *ds0.CCRDNSTTL != 30 || *ds0.ID != 7 {
+		t.Errorf("expected the change to be merged over the existing delivery service, actual: %+v", ds0)
+	}
+	ds1 := changed.DeliveryServices[1]
+	if ds1.ID == nil || *ds1.ID != 8 || ds1.CDNID == nil || *ds1.CDNID != 1 {
+		t.Errorf("expected a new delivery service to get an unused ID and the CDN, actual: %+v", ds1)
+	}
+	if len(changed.DeliveryServiceRegexes) != 2 || changed.DeliveryServiceRegexes[1].Regexes[0].Pattern != `.*\.ds1\..*` {
+		t.Errorf("expected a new delivery service to get the default regex, actual: %+v", changed.DeliveryServiceRegexes)
+	}
+
+	deleted, err := ChangeSet{DeleteDeliveryServices: []string{"ds0"}}.Apply(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted.DeliveryServices) != 0 || len(deleted.DeliveryServiceServers) != 0 || len(deleted.DeliveryServiceRegexes) != 0 {
+		t.Errorf("expected deleting a delivery service to remove it with its servers and regexes, actual: %+v", deleted)
+	}
+}
+
+func TestPreview(t *testing.T) {
+	before := makeTestData()
+	after, err := ChangeSet{Parameters: []ParameterChange{
+		{Profile: "EDGE_A", ConfigFile: atscfg.RecordsFileName, Name: "CONFIG proxy.config.http.insert_age_in_response", Value: "INT 1"},
+	}}.Apply(before)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := Preview(before, after, Opts{ConfigFilesOpts: atscfg.ConfigFilesOpts{Dir: "/etc/trafficserver"}, Parallel: 2})
+	if len(report.Errors) != 0 {
+		t.Fatalf("expected no errors, actual: %+v", report.Errors)
+	}
+	if report.Servers != 3 {
+		t.Errorf("expected config of 3 caches to be generated, actual: %d", report.Servers)
+	}
+	if report.ChangedServers != 2 || len(report.Changes) != 1 {
+		t.Fatalf("expected 2 changed servers with 1 change, actual: %+v", report)
+	}
+	change := report.Changes[0]
+	if strings.Join(change.Servers, ",") != "edge1,edge2" {
+		t.Errorf("expected the change to apply to the EDGE_A servers, actual: %+v", change.Servers)
+	}
+	if len(change.Files) != 1 || change.Files[0].Name != atscfg.RecordsFileName {
+		t.Fatalf("expected only records.config to change, actual: %+v", change.Files)
+	}
+	expectedDiff := "-CONFIG proxy.config.http.insert_age_in_response INT 0\n+CONFIG proxy.config.http.insert_age_in_response INT 1\n"
+	if !strings.Contains(change.Files[0].Diff, expectedDiff) {
+		t.Errorf("expected records.config diff to contain '%s', actual: '%s'", expectedDiff, change.Files[0].Diff)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl"
+	expected := `--- a/etc/foo.config
++++ b/etc/foo.config
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -9,3 +9,4 @@
+ i
+ j
+ k
++l
+`
+	if actual := UnifiedDiff("/etc/foo.config", before, after, true, true); actual != expected {
+		t.Errorf("expected diff '''%s''', actual '''%s'''", expected, actual)
+	}
+
+	if actual := UnifiedDiff("/etc/foo.config", before, before, true, true); actual != "" {
+		t.Errorf("expected no diff of the same file, actual '''%s'''", actual)
+	}
+
+	expected = "--- /dev/null\n+++ b/etc/foo.config\n@@ -0,0 +1,1 @@\n+\n"
+	if actual := UnifiedDiff("/etc/foo.config", "", "", false, true); actual != expected {
+		t.Errorf("expected diff of created empty file '''%s''', actual '''%s'''", expected, actual)
+	}
+}
@@ -41,6 +41,11 @@ type RecordsConfigOpts struct {
 	// DNSLocalBindServiceAddr is whether to set the server's service addresses
 	// as the records.config proxy.config.dns.local_ipv* settings.
 	DNSLocalBindServiceAddr bool
+
+	// OCSPStapling is whether to enable OCSP stapling in records.config.
+	// This should be set if any Delivery Service on the server has OCSP stapling
+	// in its TLS policy, because ATS only supports enabling it globally.
+	OCSPStapling bool
 }
 
 func MakeRecordsDotConfig(
@@ -95,6 +100,12 @@ func addRecordsDotConfigOverrides(txt string, server *Server, opt RecordsConfigO
 		warnings = append(warnings, dnsWarns...)
 	}
 
+	if opt.OCSPStapling {
+		ocspWarns := []string{}
+		txt, ocspWarns = addRecordsDotConfigOCSPStapling(txt)
+		warnings = append(warnings, ocspWarns...)
+	}
+
 	return txt, warnings
 }
 
@@ -187,6 +198,20 @@ func addRecordsDotConfigDNSLocal(txt string, server *Server) (string, []string)
 	return txt, warnings
 }
 
+// addRecordsDotConfigOCSPStapling returns the config text with OCSP stapling enabled, and any warnings.
+func addRecordsDotConfigOCSPStapling(txt string) (string, []string) {
+	warnings := []string{}
+
+	const ocspEnabled = `proxy.config.ssl.ocsp.enabled`
+	if strings.Contains(txt, ocspEnabled) {
+		warnings = append(warnings, "records.config had a "+ocspEnabled+" Parameter! Using Parameter, not enabling OCSP stapling for Delivery Service TLS policies")
+		return txt, warnings
+	}
+
+	txt += `CONFIG ` + ocspEnabled + ` INT 1` + "\n"
+	return txt, warnings
+}
+
 func replaceLineSuffixes(txt string, suffix string, newSuffix string) string {
 	lines := strings.Split(txt, "\n")
 	newLines := make([]string, 0, len(lines))
@@ -79,6 +79,37 @@ func TestMakeRecordsDotConfig(t *testing.T) {
 	}
 }
 
+func TestMakeRecordsDotConfigOCSPStapling(t *testing.T) {
+	server := makeTestRemapServer()
+	server.Profile = util.StrPtr("myProfile")
+
+	paramData := makeParamsFromMap("serverProfile", RecordsFileName, map[string]string{
+		"param0": "val0",
+	})
+	opt := RecordsConfigOpts{OCSPStapling: true}
+	cfg, err := MakeRecordsDotConfig(server, paramData, "myHeaderComment", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(cfg.Text, "CONFIG proxy.config.ssl.ocsp.enabled INT 1") {
+		t.Errorf("expected config to enable OCSP stapling, actual: '%v'", cfg.Text)
+	}
+
+	paramData = makeParamsFromMap("serverProfile", RecordsFileName, map[string]string{
+		"CONFIG proxy.config.ssl.ocsp.enabled": "INT 0",
+	})
+	cfg, err = MakeRecordsDotConfig(server, paramData, "myHeaderComment", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(cfg.Text, "ocsp.enabled INT 1") {
+		t.Errorf("expected OCSP stapling Parameter to take precedence, actual: '%v'", cfg.Text)
+	}
+	if len(cfg.Warnings) == 0 {
+		t.Errorf("expected a warning for the OCSP stapling Parameter, actual: none")
+	}
+}
+
 func TestReplaceLineSuffixes(t *testing.T) {
 	{
 		input := `
@@ -61,6 +61,7 @@ func MakeSNIDotYAML(
 	cacheGroupArr []tc.CacheGroupNullable,
 	serverCapabilities map[int]map[ServerCapability]struct{},
 	dsRequiredCapabilities map[int]map[ServerCapability]struct{},
+	dsTLSPolicies map[tc.DeliveryServiceName]DeliveryServiceTLSPolicy,
 	opt SNIDotYAMLOpts,
 ) (Cfg, error) {
 	if len(opt.DefaultTLSVersions) == 0 {
@@ -78,6 +79,7 @@ func MakeSNIDotYAML(
 		cacheGroupArr,
 		serverCapabilities,
 		dsRequiredCapabilities,
+		dsTLSPolicies,
 		opt.DefaultTLSVersions,
 		opt.DefaultEnableH2,
 	)
@@ -115,6 +117,18 @@ func MakeSNIDotYAML(
 			dsTxt += `- fqdn: '` + requestFQDN + `'`
 			dsTxt += "\n" + `  disable_h2: ` + strconv.FormatBool(!sslData.EnableH2)
 			dsTxt += "\n" + `  valid_tls_versions_in: [` + strings.Join(tlsVersionsATS, `,`) + `]`
+			if len(sslData.Ciphers) > 0 {
+				dsTxt += "\n" + `  server_cipher_suite: '` + strings.Join(sslData.Ciphers, `:`) + `'`
+			}
+			if len(sslData.CipherSuites) > 0 {
+				dsTxt += "\n" + `  server_TLSv1_3_cipher_suites: '` + strings.Join(sslData.CipherSuites, `:`) + `'`
+			}
+			if sslData.VerifyClient != "" {
+				dsTxt += "\n" + `  verify_client: ` + sslData.VerifyClient
+			}
+			if sslData.HostSNIPolicy != "" {
+				dsTxt += "\n" + `  host_sni_policy: ` + sslData.HostSNIPolicy
+			}
 
 			txt += dsTxt + "\n"
 		}
@@ -0,0 +1,192 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestMakeSNIDotYAMLTLSPolicy(t *testing.T) {
+	opts := SNIDotYAMLOpts{VerboseComments: false, HdrComment: "myHeaderComment"}
+
+	ds0 := makeParentDS()
+	ds0Type := tc.DSTypeHTTP
+	ds0.Type = &ds0Type
+	ds0.Protocol = util.IntPtr(int(tc.DSProtocolHTTPAndHTTPS))
+	ds0.QStringIgnore = util.IntPtr(int(tc.QStringIgnoreUseInCacheKeyAndPassUp))
+	ds0.OrgServerFQDN = util.StrPtr("http://ds0.example.net")
+
+	ds1 := makeParentDS()
+	ds1.ID = util.IntPtr(43)
+	ds1Type := tc.DSTypeDNS
+	ds1.Type = &ds1Type
+	ds1.QStringIgnore = util.IntPtr(int(tc.QStringIgnoreDrop))
+	ds1.OrgServerFQDN = util.StrPtr("http://ds1.example.net")
+
+	dses := []DeliveryService{*ds0, *ds1}
+
+	parentConfigParams := []tc.Parameter{
+		tc.Parameter{
+			Name:       ParentConfigParamQStringHandling,
+			ConfigFile: "parent.config",
+			Value:      "myQStringHandlingParam",
+			Profiles:   []byte(`["serverprofile"]`),
+		},
+		tc.Parameter{
+			Name:       ParentConfigParamAlgorithm,
+			ConfigFile: "parent.config",
+			Value:      tc.AlgorithmConsistentHash,
+			Profiles:   []byte(`["serverprofile"]`),
+		},
+		tc.Parameter{
+			Name:       ParentConfigParamQString,
+			ConfigFile: "parent.config",
+			Value:      "myQstringParam",
+			Profiles:   []byte(`["serverprofile"]`),
+		},
+	}
+
+	server := makeTestParentServer()
+
+	mid0 := makeTestParentServer()
+	mid0.Cachegroup = util.StrPtr("midCG")
+	mid0.HostName = util.StrPtr("mymid0")
+	mid0.ID = util.IntPtr(45)
+	setIP(mid0, "192.168.2.2")
+
+	mid1 := makeTestParentServer()
+	mid1.Cachegroup = util.StrPtr("midCG")
+	mid1.HostName = util.StrPtr("mymid1")
+	mid1.ID = util.IntPtr(46)
+	setIP(mid1, "192.168.2.3")
+
+	topologies := []tc.Topology{}
+	serverCapabilities := map[int]map[ServerCapability]struct{}{}
+	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
+
+	eCG := &tc.CacheGroupNullable{}
+	eCG.Name = server.Cachegroup
+	eCG.ID = server.CachegroupID
+	eCG.ParentName = mid0.Cachegroup
+	eCG.ParentCachegroupID = mid0.CachegroupID
+	eCGType := tc.CacheGroupEdgeTypeName
+	eCG.Type = &eCGType
+
+	mCG := &tc.CacheGroupNullable{}
+	mCG.Name = mid0.Cachegroup
+	mCG.ID = mid0.CachegroupID
+	mCGType := tc.CacheGroupMidTypeName
+	mCG.Type = &mCGType
+
+	cgs := []tc.CacheGroupNullable{*eCG, *mCG}
+
+	dss := []DeliveryServiceServer{
+		DeliveryServiceServer{
+			Server:          *server.ID,
+			DeliveryService: *ds0.ID,
+		},
+		DeliveryServiceServer{
+			Server:          *server.ID,
+			DeliveryService: *ds1.ID,
+		},
+	}
+	cdn := &tc.CDN{
+		DomainName: "cdndomain.example",
+		Name:       "my-cdn-name",
+	}
+
+	dsr := []tc.DeliveryServiceRegexes{
+		tc.DeliveryServiceRegexes{
+			DSName: *ds0.XMLID,
+			Regexes: []tc.DeliveryServiceRegex{
+				tc.DeliveryServiceRegex{
+					Type:      string(tc.DSMatchTypeHostRegex),
+					SetNumber: 0,
+					Pattern:   `.*\.ds0\..*`,
+				},
+			},
+		},
+	}
+
+	dsTLSPolicies := map[tc.DeliveryServiceName]DeliveryServiceTLSPolicy{
+		tc.DeliveryServiceName(*ds0.XMLID): DeliveryServiceTLSPolicy{
+			TLSVersions:   []string{"1.2", "1.3", "9.9"},
+			Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256", "ECDHE-RSA-AES256-GCM-SHA384"},
+			CipherSuites:  []string{"TLS_AES_128_GCM_SHA256"},
+			VerifyClient:  tc.TLSVerifyClientStrict,
+			HostSNIPolicy: tc.TLSHostSNIPolicyEnforced,
+			OCSPStapling:  true,
+		},
+	}
+
+	cfg, err := MakeSNIDotYAML(server, dses, dss, dsr, parentConfigParams, cdn, topologies, cgs, serverCapabilities, dsRequiredCapabilities, dsTLSPolicies, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	if !strings.Contains(txt, `fqdn: 'myserver.ds0.cdndomain.example'`) {
+		t.Errorf("expected ds0 fqdn, actual ''%+v'' warnings ''%+v''", txt, cfg.Warnings)
+	}
+	if !strings.Contains(txt, `valid_tls_versions_in: ['TLSv1_2','TLSv1_3']`) {
+		t.Errorf("expected DS TLS versions to be used, actual ''%+v'' warnings ''%+v''", txt, cfg.Warnings)
+	}
+	if !strings.Contains(txt, `server_cipher_suite: 'ECDHE-RSA-AES128-GCM-SHA256:ECDHE-RSA-AES256-GCM-SHA384'`) {
+		t.Errorf("expected DS ciphers, actual ''%+v'' warnings ''%+v''", txt, cfg.Warnings)
+	}
+	if !strings.Contains(txt, `server_TLSv1_3_cipher_suites: 'TLS_AES_128_GCM_SHA256'`) {
+		t.Errorf("expected DS cipher suites, actual ''%+v'' warnings ''%+v''", txt, cfg.Warnings)
+	}
+	if !strings.Contains(txt, `verify_client: STRICT`) {
+		t.Errorf("expected DS verify_client, actual ''%+v'' warnings ''%+v''", txt, cfg.Warnings)
+	}
+	if !strings.Contains(txt, `host_sni_policy: ENFORCED`) {
+		t.Errorf("expected DS host_sni_policy, actual ''%+v'' warnings ''%+v''", txt, cfg.Warnings)
+	}
+	if !strings.Contains(strings.Join(cfg.Warnings, " "), `'9.9'`) {
+		t.Errorf("expected warning for unknown TLS version, actual warnings ''%+v''", cfg.Warnings)
+	}
+}
+
+func TestMakeSNIDotYAMLNoTLSPolicy(t *testing.T) {
+	ds := makeParentDS()
+	ds.Protocol = util.IntPtr(int(tc.DSProtocolHTTPAndHTTPS))
+	server := makeTestParentServer()
+	dss := []DeliveryServiceServer{{Server: *server.ID, DeliveryService: *ds.ID}}
+	cdn := &tc.CDN{DomainName: "cdndomain.example", Name: "my-cdn-name"}
+	dsr := []tc.DeliveryServiceRegexes{{
+		DSName:  *ds.XMLID,
+		Regexes: []tc.DeliveryServiceRegex{{Type: string(tc.DSMatchTypeHostRegex), Pattern: `.*\.ds0\..*`}},
+	}}
+
+	cfg, err := MakeSNIDotYAML(server, []DeliveryService{*ds}, dss, dsr, nil, cdn, nil, nil, nil, nil, nil, SNIDotYAMLOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"server_cipher_suite", "server_TLSv1_3_cipher_suites", "verify_client", "host_sni_policy"} {
+		if strings.Contains(cfg.Text, key) {
+			t.Errorf("expected no '%s' for ds with no TLS policy, actual ''%+v''", key, cfg.Text)
+		}
+	}
+}
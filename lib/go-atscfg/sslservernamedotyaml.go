@@ -115,6 +115,7 @@ func MakeSSLServerNameYAML(
 	cacheGroupArr []tc.CacheGroupNullable,
 	serverCapabilities map[int]map[ServerCapability]struct{},
 	dsRequiredCapabilities map[int]map[ServerCapability]struct{},
+	dsTLSPolicies map[tc.DeliveryServiceName]DeliveryServiceTLSPolicy,
 	opt SSLServerNameYAMLOpts,
 ) (Cfg, error) {
 	if len(opt.DefaultTLSVersions) == 0 {
@@ -132,6 +133,7 @@ func MakeSSLServerNameYAML(
 		cacheGroupArr,
 		serverCapabilities,
 		dsRequiredCapabilities,
+		dsTLSPolicies,
 		opt.DefaultTLSVersions,
 		opt.DefaultEnableH2,
 	)
@@ -168,6 +170,9 @@ func MakeSSLServerNameYAML(
 			dsTxt += `- fqdn: '` + requestFQDN + `'`
 			dsTxt += "\n" + `  disable_h2: ` + strconv.FormatBool(!sslData.EnableH2)
 			dsTxt += "\n" + `  valid_tls_versions_in: [` + strings.Join(tlsVersionsATS, `,`) + `]`
+			if sslData.VerifyClient != "" {
+				dsTxt += "\n" + `  verify_client: ` + sslData.VerifyClient
+			}
 
 			txt += dsTxt + "\n"
 		}
@@ -188,6 +193,14 @@ type SSLData struct {
 	RequestFQDNs []string
 	EnableH2     bool
 	TLSVersions  []TLSVersion
+
+	// The TLS policy of the DS. Empty values are the ATS defaults, and
+	// shouldn't be added to the config.
+	Ciphers       []string
+	CipherSuites  []string
+	VerifyClient  string
+	HostSNIPolicy string
+	OCSPStapling  bool
 }
 
 // GetServerSSLData gets the SSLData for all Delivery Services assigned to the given Server, any warnings, and any error.
@@ -202,6 +215,7 @@ func GetServerSSLData(
 	cacheGroupArr []tc.CacheGroupNullable,
 	serverCapabilities map[int]map[ServerCapability]struct{},
 	dsRequiredCapabilities map[int]map[ServerCapability]struct{},
+	dsTLSPolicies map[tc.DeliveryServiceName]DeliveryServiceTLSPolicy,
 	defaultTLSVersions []TLSVersion,
 	defaultEnableH2 bool,
 ) ([]SSLData, []string, error) {
@@ -304,11 +318,34 @@ func GetServerSSLData(
 			tlsVersions = paramTLSVersions
 		}
 
+		policy := dsTLSPolicies[tc.DeliveryServiceName(*ds.XMLID)]
+
+		// The DS's own TLS versions take precedence over the older Parameter.
+		policyTLSVersions := []TLSVersion{}
+		for _, tlsVersion := range policy.TLSVersions {
+			if _, ok := tlsVersionsToATS[TLSVersion(tlsVersion)]; !ok {
+				warnings = append(warnings, "ds '"+*ds.XMLID+"' had unknown TLS version '"+tlsVersion+"' - ignoring!")
+				continue
+			}
+			policyTLSVersions = append(policyTLSVersions, TLSVersion(tlsVersion))
+		}
+		if len(policyTLSVersions) != 0 {
+			if len(paramTLSVersions) != 0 {
+				warnings = append(warnings, "ds '"+*ds.XMLID+"' had both TLS versions and a "+SSLServerNameYAMLParamTLSVersions+" parameter, using the TLS versions")
+			}
+			tlsVersions = policyTLSVersions
+		}
+
 		sslDatas = append(sslDatas, SSLData{
-			DSName:       *ds.XMLID,
-			RequestFQDNs: requestFQDNs,
-			EnableH2:     enableH2,
-			TLSVersions:  tlsVersions,
+			DSName:        *ds.XMLID,
+			RequestFQDNs:  requestFQDNs,
+			EnableH2:      enableH2,
+			TLSVersions:   tlsVersions,
+			Ciphers:       policy.Ciphers,
+			CipherSuites:  policy.CipherSuites,
+			VerifyClient:  policy.VerifyClient,
+			HostSNIPolicy: policy.HostSNIPolicy,
+			OCSPStapling:  policy.OCSPStapling,
 		})
 	}
 
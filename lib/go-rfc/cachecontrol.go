@@ -1,6 +1,8 @@
 package rfc
 
 import (
+	"crypto/sha512"
+	"encoding/base64"
 	"errors"
 	"net/http"
 	"strconv"
@@ -32,6 +34,7 @@ const (
 	LastModified      = "Last-Modified"     // RFC7232§2.2
 	ETagHeader        = "ETag"
 	IfMatch           = "If-Match"
+	IfNoneMatch       = "If-None-Match"
 	IfUnmodifiedSince = "If-Unmodified-Since"
 	Date              = "Date"
 	ETagVersion       = 1
@@ -110,3 +113,46 @@ func ParseETags(eTags []string) (time.Time, bool) {
 	}
 	return latestTime, latestTime != time.Time{}
 }
+
+// ContentETag takes the content of a representation, and returns a strong ETag string of it. Note the string is the complete header value, including quotes.
+// Unlike ETag, the returned ETag changes whenever the content does, no matter how little time passed between the changes.
+func ContentETag(content []byte) string {
+	sum := sha512.Sum512_256(content)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:]) + `"`
+}
+
+// ETagsMatch returns whether the given If-Match or If-None-Match header value - a comma-separated list of ETags, or "*" - matches the given ETag.
+// If weak is false, weak ETags (prefixed with "W/") never match, as required for If-Match; else they're compared as if they were strong, as required for If-None-Match.
+func ETagsMatch(header string, etag string, weak bool) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = tag[len("W/"):]
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasContentETags returns whether the given If-Match or If-None-Match header value has any ETags which weren't made by ETag, or is "*".
+// Those ETags can only be compared with the content of the current representation of a resource, rather than its last modified time.
+func HasContentETags(header string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if _, err := ParseETag(tag); err != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -42,3 +42,32 @@ func TestETag(t *testing.T) {
 		t.Errorf("Expected time %v, actual %v", "2020-08-06 18:11:22.278418 +0000 UTC", ans.UTC().String())
 	}
 }
+
+func TestContentETag(t *testing.T) {
+	etag := ContentETag([]byte(`[{"id":1}]`))
+	if etag != ContentETag([]byte(`[{"id":1}]`)) {
+		t.Errorf("Expected the same content to have the same ETag")
+	}
+	if etag == ContentETag([]byte(`[{"id":2}]`)) {
+		t.Errorf("Expected different content to have different ETags")
+	}
+	if !HasContentETags(etag) {
+		t.Errorf("Expected %v to be a content ETag", etag)
+	}
+	if HasContentETags(ETag(time.Now())) {
+		t.Errorf("Expected a time ETag not to be a content ETag")
+	}
+
+	if !ETagsMatch(`"foo", `+etag, etag, false) {
+		t.Errorf("Expected a list containing %v to match it", etag)
+	}
+	if !ETagsMatch("*", etag, false) {
+		t.Errorf("Expected * to match any ETag")
+	}
+	if ETagsMatch("W/"+etag, etag, false) {
+		t.Errorf("Expected a weak ETag not to match for If-Match")
+	}
+	if !ETagsMatch("W/"+etag, etag, true) {
+		t.Errorf("Expected a weak ETag to match for If-None-Match")
+	}
+}
@@ -0,0 +1,82 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+)
+
+// BatchOperation is a single request to another Traffic Ops API endpoint,
+// made as part of a batch.
+//
+// Strings in the Path and Body of an operation may refer to the responses of
+// earlier operations in the batch with the syntax "${id.path}", where id is
+// the ID of the earlier operation, and path is a dot-separated path of object
+// keys and array indices into its response. For example, "${ds.0.id}" is the
+// ID of the first Delivery Service in the response of the operation with the
+// ID "ds". A string which is nothing but a reference is replaced by the
+// referenced value itself, preserving its JSON type.
+type BatchOperation struct {
+	// ID identifies the operation, so later operations may refer to its
+	// response. It's optional, but must be unique within a batch.
+	ID string `json:"id,omitempty"`
+	// Method is the HTTP method of the request.
+	Method string `json:"method"`
+	// Path is the path of the request, relative to the API version of the
+	// batch, optionally with a query string, e.g. "servers?hostName=edge".
+	Path string `json:"path"`
+	// Body is the request body, if any.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchRequest is the request body of the /batch API endpoint.
+type BatchRequest struct {
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperationResult is the result of a single BatchOperation.
+type BatchOperationResult struct {
+	ID     string `json:"id,omitempty"`
+	Method string `json:"method"`
+	// Path is the path of the request, with any references to other
+	// operations resolved.
+	Path string `json:"path"`
+	// Status is the HTTP status code of the operation's response.
+	Status int `json:"status"`
+	// Response is the "response" property of the operation's response, if
+	// any.
+	Response json.RawMessage `json:"response,omitempty"`
+	// Alerts are the alerts of the operation's response, if any.
+	Alerts []Alert `json:"alerts,omitempty"`
+}
+
+// BatchResponse is the response object of the /batch API endpoint.
+type BatchResponse struct {
+	// Results are the results of the operations, in order. If an operation
+	// failed, it's the last result, and none of the operations' changes were
+	// made.
+	Results []BatchOperationResult `json:"results"`
+}
+
+// BatchAPIResponse is the type of a response from the /batch API endpoint.
+type BatchAPIResponse struct {
+	Response BatchResponse `json:"response"`
+	Alerts
+}
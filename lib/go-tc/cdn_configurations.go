@@ -0,0 +1,193 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// CDNConfigurationVersion is the version of the CDNConfiguration document
+// format. It's incremented when the format changes incompatibly.
+const CDNConfigurationVersion = 1
+
+// CDNConfiguration is the declarative configuration of a CDN, as exported by
+// and applied to Traffic Ops.
+//
+// Objects are identified by name, not by database ID, so a configuration can
+// be kept in source control and applied to any Traffic Ops. Lists are sorted
+// by name, so exports of the same configuration are identical.
+type CDNConfiguration struct {
+	// Version is the CDNConfigurationVersion of the document.
+	Version int `json:"version"`
+	// CDN is the CDN itself.
+	CDN CDNConfigurationCDN `json:"cdn"`
+	// ServerCapabilities are the Server Capabilities required by the CDN's
+	// Delivery Services.
+	ServerCapabilities []string `json:"serverCapabilities"`
+	// CacheGroups are the Cache Groups with servers on the CDN or in its
+	// Delivery Services' Topologies, and their parents.
+	CacheGroups []CDNConfigurationCacheGroup `json:"cacheGroups"`
+	// Topologies are the Topologies used by the CDN's Delivery Services.
+	Topologies []CDNConfigurationTopology `json:"topologies"`
+	// Profiles are the Profiles of the CDN, with their Parameters.
+	Profiles []CDNConfigurationProfile `json:"profiles"`
+	// DeliveryServices are the Delivery Services of the CDN.
+	DeliveryServices []CDNConfigurationDeliveryService `json:"deliveryServices"`
+	// Federations are the Federations of the CDN's Delivery Services.
+	Federations []CDNConfigurationFederation `json:"federations"`
+}
+
+// CDNConfigurationCDN is the CDN of a CDNConfiguration.
+type CDNConfigurationCDN struct {
+	Name          string `json:"name"`
+	DomainName    string `json:"domainName"`
+	DNSSECEnabled bool   `json:"dnssecEnabled"`
+}
+
+// CDNConfigurationCacheGroup is a Cache Group in a CDNConfiguration.
+type CDNConfigurationCacheGroup struct {
+	Name                      string               `json:"name"`
+	ShortName                 string               `json:"shortName"`
+	Type                      string               `json:"type"`
+	Latitude                  float64              `json:"latitude"`
+	Longitude                 float64              `json:"longitude"`
+	ParentCacheGroup          *string              `json:"parentCacheGroup"`
+	SecondaryParentCacheGroup *string              `json:"secondaryParentCacheGroup"`
+	FallbackToClosest         bool                 `json:"fallbackToClosest"`
+	Fallbacks                 []string             `json:"fallbacks"`
+	LocalizationMethods       []LocalizationMethod `json:"localizationMethods"`
+}
+
+// CDNConfigurationTopology is a Topology in a CDNConfiguration. Unlike
+// Topology, its nodes' parents are Cache Group names, not node indices.
+type CDNConfigurationTopology struct {
+	Name        string                         `json:"name"`
+	Description string                         `json:"description"`
+	Nodes       []CDNConfigurationTopologyNode `json:"nodes"`
+}
+
+// CDNConfigurationTopologyNode is a node of a CDNConfigurationTopology.
+type CDNConfigurationTopologyNode struct {
+	CacheGroup string   `json:"cacheGroup"`
+	Parents    []string `json:"parents"`
+}
+
+// CDNConfigurationProfile is a Profile in a CDNConfiguration.
+type CDNConfigurationProfile struct {
+	Name            string                      `json:"name"`
+	Description     string                      `json:"description"`
+	Type            string                      `json:"type"`
+	RoutingDisabled bool                        `json:"routingDisabled"`
+	Parameters      []CDNConfigurationParameter `json:"parameters"`
+}
+
+// CDNConfigurationParameter is a Parameter of a CDNConfigurationProfile.
+type CDNConfigurationParameter struct {
+	ConfigFile string `json:"configFile"`
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	Secure     bool   `json:"secure"`
+}
+
+// CDNConfigurationDeliveryService is a Delivery Service in a
+// CDNConfiguration.
+//
+// The DeliveryServiceV4 has no database IDs, CDN, or read-only fields like
+// exampleURLs; its type, tenant, and profile are identified by name.
+type CDNConfigurationDeliveryService struct {
+	DeliveryServiceV4
+	Regexes              []CDNConfigurationRegex          `json:"regexes"`
+	RequiredCapabilities []string                         `json:"requiredCapabilities"`
+	SteeringTargets      []CDNConfigurationSteeringTarget `json:"steeringTargets"`
+}
+
+// CDNConfigurationRegex is a regular expression of a
+// CDNConfigurationDeliveryService.
+type CDNConfigurationRegex struct {
+	Type      string `json:"type"`
+	Pattern   string `json:"pattern"`
+	SetNumber int    `json:"setNumber"`
+}
+
+// CDNConfigurationSteeringTarget is a target of a steering
+// CDNConfigurationDeliveryService.
+type CDNConfigurationSteeringTarget struct {
+	// Target is the XMLID of the target Delivery Service.
+	Target string `json:"target"`
+	Type   string `json:"type"`
+	Value  int    `json:"value"`
+}
+
+// CDNConfigurationFederation is a Federation in a CDNConfiguration.
+type CDNConfigurationFederation struct {
+	// DeliveryService is the XMLID of the Federation's Delivery Service.
+	DeliveryService string  `json:"deliveryService"`
+	CName           string  `json:"cname"`
+	TTL             int     `json:"ttl"`
+	Description     *string `json:"description"`
+	// Resolvers are the IP addresses of the Federation's Federation
+	// Resolvers, which must exist.
+	Resolvers []string `json:"resolvers"`
+}
+
+// CDNConfigurationAction is the action of a CDNConfigurationChange.
+type CDNConfigurationAction string
+
+const (
+	CDNConfigurationActionCreate = CDNConfigurationAction("create")
+	CDNConfigurationActionUpdate = CDNConfigurationAction("update")
+	CDNConfigurationActionDelete = CDNConfigurationAction("delete")
+)
+
+// CDNConfigurationObjectType is the type of the object changed by a
+// CDNConfigurationChange.
+type CDNConfigurationObjectType string
+
+const (
+	CDNConfigurationObjectCDN              = CDNConfigurationObjectType("cdn")
+	CDNConfigurationObjectServerCapability = CDNConfigurationObjectType("serverCapability")
+	CDNConfigurationObjectCacheGroup       = CDNConfigurationObjectType("cacheGroup")
+	CDNConfigurationObjectTopology         = CDNConfigurationObjectType("topology")
+	CDNConfigurationObjectProfile          = CDNConfigurationObjectType("profile")
+	CDNConfigurationObjectDeliveryService  = CDNConfigurationObjectType("deliveryService")
+	CDNConfigurationObjectFederation       = CDNConfigurationObjectType("federation")
+)
+
+// CDNConfigurationChange is a change to one object, of a
+// CDNConfigurationPlan.
+type CDNConfigurationChange struct {
+	Action CDNConfigurationAction     `json:"action"`
+	Type   CDNConfigurationObjectType `json:"type"`
+	// Name identifies the object. For Federations, it's the Delivery
+	// Service XMLID and the CNAME, separated by a slash.
+	Name string `json:"name"`
+	// Fields are the names of the changed fields of an update.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// CDNConfigurationPlan is the changes needed to make a CDN's configuration
+// match a CDNConfiguration, in the order they're applied.
+type CDNConfigurationPlan struct {
+	Changes []CDNConfigurationChange `json:"changes"`
+}
+
+// CDNConfigurationPlanResponse is the type of a response from the
+// cdns/{name}/configuration/plan and cdns/{name}/configuration Traffic Ops
+// API endpoints.
+type CDNConfigurationPlanResponse struct {
+	Response CDNConfigurationPlan `json:"response"`
+	Alerts
+}
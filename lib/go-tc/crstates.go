@@ -33,6 +33,13 @@ type CRStates struct {
 type CRStatesDeliveryService struct {
 	DisabledLocations []CacheGroupName `json:"disabledLocations"`
 	IsAvailable       bool             `json:"isAvailable"`
+	// HealthScore is the fraction, from 0 to 1, of the Delivery Service's
+	// Cache Groups which meet its health policy. It is nil when it hasn't been
+	// computed, e.g. by Traffic Monitors which don't compute it.
+	HealthScore *float64 `json:"healthScore,omitempty"`
+	// DegradedLocations are the Cache Groups of the Delivery Service which
+	// don't meet its health policy.
+	DegradedLocations []CacheGroupName `json:"degradedLocations,omitempty"`
 }
 
 // IsAvailable contains whether the given cache or delivery service is available. It is designed for JSON serialization, namely in the Traffic Monitor 1.0 API.
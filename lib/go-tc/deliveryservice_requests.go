@@ -684,6 +684,17 @@ func (dsrct *DSRChangeType) UnmarshalJSON(b []byte) error {
 // DeliveryServiceRequestV40 is the type of a Delivery Service Request in
 // Traffic Ops API version 4.0.
 type DeliveryServiceRequestV40 struct {
+	// AppliedAt is the date/time at which Traffic Ops applied the requested
+	// changes upon the Delivery Service Request's approval, if it did.
+	AppliedAt *time.Time `json:"appliedAt,omitempty" db:"applied_at"`
+	// AppliedLastUpdated is the lastUpdated date/time of the Delivery Service as
+	// it was left by the application of the requested changes, if Traffic Ops
+	// applied them and the Delivery Service wasn't deleted.
+	AppliedLastUpdated *time.Time `json:"appliedLastUpdated,omitempty" db:"applied_last_updated"`
+	// AppliedVersion is the number of the version of the Delivery Service
+	// recorded when Traffic Ops applied the requested changes, if it did and
+	// the Delivery Service wasn't deleted.
+	AppliedVersion *int `json:"appliedVersion,omitempty" db:"applied_version"`
 	// Assignee is the username of the user assigned to the Delivery Service
 	// Request, if any.
 	Assignee *string `json:"assignee"`
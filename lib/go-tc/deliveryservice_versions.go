@@ -0,0 +1,104 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DeliveryServiceVersionData is the state of a Delivery Service captured by
+// a version: its properties, and the regular expressions, required
+// capabilities, and servers assigned to it.
+type DeliveryServiceVersionData struct {
+	// DeliveryService holds the Delivery Service's properties, including its
+	// SSL key version and URL signing algorithm. Its MatchList, ExampleURLs,
+	// LastUpdated, LongDesc1, and LongDesc2 are omitted; the regular
+	// expressions are in Regexes.
+	DeliveryService DeliveryServiceV4 `json:"deliveryService"`
+	// Regexes are the regular expressions used to match requests to the
+	// Delivery Service.
+	Regexes []DeliveryServiceMatch `json:"regexes"`
+	// RequiredCapabilities are the names of the Server Capabilities the
+	// Delivery Service requires.
+	RequiredCapabilities []string `json:"requiredCapabilities"`
+	// Servers are the host names of the servers assigned to the Delivery
+	// Service.
+	Servers []string `json:"servers"`
+}
+
+// DeliveryServiceVersion is an immutable record of the state of a Delivery
+// Service after a change was made to it.
+type DeliveryServiceVersion struct {
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// Version counts the versions of the Delivery Service, starting from 1.
+	Version int `json:"version"`
+	// Username is the name of the user who made the change.
+	Username string    `json:"username"`
+	Created  time.Time `json:"created"`
+	// Data is omitted when versions are listed.
+	Data *DeliveryServiceVersionData `json:"data,omitempty"`
+}
+
+// DeliveryServiceVersionChange is a difference between two versions of a
+// Delivery Service, in one property of their data.
+type DeliveryServiceVersionChange struct {
+	// Field is the path to the property, e.g. "deliveryService.active" or
+	// "servers". Arrays are compared as a whole.
+	Field string `json:"field"`
+	// From is the property's value in the older version, or null if it
+	// didn't have one.
+	From json.RawMessage `json:"from"`
+	// To is the property's value in the newer version, or null if it doesn't
+	// have one.
+	To json.RawMessage `json:"to"`
+}
+
+// DeliveryServiceVersionDiff is the difference between two versions of a
+// Delivery Service.
+type DeliveryServiceVersionDiff struct {
+	DeliveryServiceID int                            `json:"deliveryServiceId"`
+	From              int                            `json:"from"`
+	To                int                            `json:"to"`
+	Changes           []DeliveryServiceVersionChange `json:"changes"`
+}
+
+// DeliveryServiceVersionsResponse is the type of a response from Traffic Ops
+// to a GET request made to its deliveryservices/{{ID}}/versions endpoint.
+type DeliveryServiceVersionsResponse struct {
+	Response []DeliveryServiceVersion `json:"response"`
+	Alerts
+}
+
+// DeliveryServiceVersionResponse is the type of a response from Traffic Ops
+// to a GET request made to its deliveryservices/{{ID}}/versions/{{version}}
+// endpoint.
+type DeliveryServiceVersionResponse struct {
+	Response DeliveryServiceVersion `json:"response"`
+	Alerts
+}
+
+// DeliveryServiceVersionDiffResponse is the type of a response from Traffic
+// Ops to a GET request made to its deliveryservices/{{ID}}/versions/diff
+// endpoint.
+type DeliveryServiceVersionDiffResponse struct {
+	Response DeliveryServiceVersionDiff `json:"response"`
+	Alerts
+}
@@ -205,6 +205,28 @@ type DeliveryServiceV40 struct {
 	// TLSVersions is the list of explicitly supported TLS versions for cache
 	// servers serving the Delivery Service's content.
 	TLSVersions []string `json:"tlsVersions" db:"tls_versions"`
+
+	// TLSCiphers is the list of OpenSSL cipher names allowed for TLS 1.2 and
+	// older connections to cache servers serving the Delivery Service's
+	// content. If empty, the cache servers' default ciphers are allowed.
+	TLSCiphers []string `json:"tlsCiphers" db:"tls_ciphers"`
+	// TLSCipherSuites is the list of OpenSSL cipher suite names allowed for
+	// TLS 1.3 connections to cache servers serving the Delivery Service's
+	// content. If empty, the cache servers' default suites are allowed.
+	TLSCipherSuites []string `json:"tlsCipherSuites" db:"tls_cipher_suites"`
+	// TLSVerifyClient is whether cache servers request and verify client
+	// certificates, one of the TLSVerifyClient constants. If nil, client
+	// certificates aren't requested.
+	TLSVerifyClient *string `json:"tlsVerifyClient" db:"tls_verify_client"`
+	// TLSHostSNIPolicy is how cache servers treat requests whose Host header
+	// doesn't match the TLS SNI, one of the TLSHostSNIPolicy constants. If
+	// nil, the cache servers' default is used.
+	TLSHostSNIPolicy *string `json:"tlsHostSNIPolicy" db:"tls_host_sni_policy"`
+	// TLSOCSPStapling is whether cache servers staple OCSP responses for the
+	// Delivery Service's certificate. Because ATS only supports stapling
+	// globally, it's enabled for all certificates on cache servers assigned
+	// any Delivery Service with it enabled.
+	TLSOCSPStapling *bool `json:"tlsOCSPStapling" db:"tls_ocsp_stapling"`
 }
 
 // DeliveryServiceV4 is a Delivery Service as it appears in version 4 of the
@@ -221,6 +243,62 @@ const (
 	TLSVersion13 = "1.3"
 )
 
+// These are the client certificate verification levels of a Delivery Service's
+// TLSVerifyClient, as in the ATS sni.yaml verify_client setting.
+const (
+	// TLSVerifyClientNone is to not request client certificates.
+	TLSVerifyClientNone = "NONE"
+	// TLSVerifyClientModerate is to request client certificates, and verify
+	// them if given, but allow clients without one.
+	TLSVerifyClientModerate = "MODERATE"
+	// TLSVerifyClientStrict is to require clients to give a valid
+	// certificate.
+	TLSVerifyClientStrict = "STRICT"
+)
+
+// These are the policies of a Delivery Service's TLSHostSNIPolicy, as in the
+// ATS sni.yaml host_sni_policy setting.
+const (
+	// TLSHostSNIPolicyDisabled is to not check the Host against the SNI.
+	TLSHostSNIPolicyDisabled = "DISABLED"
+	// TLSHostSNIPolicyPermissive is to log Host and SNI mismatches, but allow
+	// the request.
+	TLSHostSNIPolicyPermissive = "PERMISSIVE"
+	// TLSHostSNIPolicyEnforced is to reject requests whose Host doesn't match
+	// the SNI, if the SNI's policy is stricter than the Host's.
+	TLSHostSNIPolicyEnforced = "ENFORCED"
+)
+
+// HasTLSPolicy returns whether any of the Delivery Service's TLS policy
+// fields, other than TLSVersions, are set.
+func (ds DeliveryServiceV4) HasTLSPolicy() bool {
+	return len(ds.TLSCiphers) > 0 ||
+		len(ds.TLSCipherSuites) > 0 ||
+		ds.TLSVerifyClient != nil ||
+		ds.TLSHostSNIPolicy != nil ||
+		ds.TLSOCSPStapling != nil
+}
+
+// TLSPolicyAlerts generates warning-level alerts for the Delivery Service's
+// TLS policy fields, if they're set on a Delivery Service whose Protocol
+// doesn't use TLS, or if they allow client connections cache servers can't
+// verify.
+//
+// Like TLSVersionsAlerts, this does NOT verify the fields are valid.
+func (ds DeliveryServiceV4) TLSPolicyAlerts() Alerts {
+	messages := []string{}
+	if ds.HasTLSPolicy() && ds.Protocol != nil && *ds.Protocol == DSProtocolHTTP {
+		messages = append(messages, "TLS policy fields have no effect on Delivery Services with Protocol '0' (HTTP_ONLY)")
+	}
+	if ds.TLSVerifyClient != nil && *ds.TLSVerifyClient != TLSVerifyClientNone {
+		messages = append(messages, "tlsVerifyClient requires the cache servers to be configured with the CA certificates to verify clients with, e.g. by the records.config Parameter 'proxy.config.ssl.CA.cert.filename'")
+	}
+	if len(messages) == 0 {
+		return Alerts{Alerts: []Alert{}}
+	}
+	return CreateAlerts(WarnLevel, messages...)
+}
+
 func newerTLSVersionsDisallowedMessage(old string, newer []string) string {
 	l := len(newer)
 	if l < 1 {
@@ -0,0 +1,226 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DSRApprovalPolicy is a policy which determines how Delivery Service
+// Requests for Delivery Services of a Tenant, or Type, or both, are approved.
+//
+// The policy for a Delivery Service Request is the most specific one which
+// matches its Delivery Service: a policy for both its Type and its Tenant (or
+// that Tenant's nearest ancestor with a policy), then one for only its
+// Tenant, then one for only its Type, then one for neither.
+type DSRApprovalPolicy struct {
+	ID   *int   `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+	// TenantID is the ID of the Tenant to which the policy applies - along
+	// with its descendants - or nil if it applies to all Tenants.
+	TenantID *int    `json:"tenantId" db:"tenant_id"`
+	Tenant   *string `json:"tenant" db:"tenant"`
+	// TypeID is the ID of the Delivery Service Type to which the policy
+	// applies, or nil if it applies to all Types.
+	TypeID *int    `json:"typeId" db:"type_id"`
+	Type   *string `json:"type" db:"type"`
+	// RequiredApprovals is the number of users who must approve a Delivery
+	// Service Request before it's approved.
+	RequiredApprovals int `json:"requiredApprovals" db:"required_approvals"`
+	// ApproverRoles are the names of the Roles whose users may approve
+	// Delivery Service Requests. If empty, users of any Role with the
+	// permission to approve Delivery Service Requests may approve them.
+	ApproverRoles []string `json:"approverRoles" db:"approver_roles"`
+	// RequiredFields are the names of the properties of the requested
+	// Delivery Service which must be set - i.e. not null or empty - for a
+	// Delivery Service Request to be submitted.
+	RequiredFields []string `json:"requiredFields" db:"required_fields"`
+	// RejectOnValidationFailure is whether Delivery Service Requests which
+	// fail validation upon submission or application are rejected, rather
+	// than the submission or approval failing.
+	RejectOnValidationFailure bool `json:"rejectOnValidationFailure" db:"reject_on_validation_failure"`
+	// ApplyOnApproval is whether the requested changes are made to the
+	// Delivery Service when a Delivery Service Request is approved, in the
+	// same transaction, completing it.
+	ApplyOnApproval bool      `json:"applyOnApproval" db:"apply_on_approval"`
+	LastUpdated     time.Time `json:"lastUpdated" db:"last_updated"`
+}
+
+// DefaultDSRApprovalPolicy is the policy for Delivery Service Requests which
+// match no DSRApprovalPolicy: a single approval, by a user of any Role, marks
+// them "pending".
+var DefaultDSRApprovalPolicy = DSRApprovalPolicy{
+	Name:              "default",
+	RequiredApprovals: 1,
+	ApproverRoles:     []string{},
+	RequiredFields:    []string{},
+}
+
+// deliveryServiceV4FieldNames returns the names of the properties of a
+// DeliveryServiceV4, as it's encoded in JSON.
+func deliveryServiceV4FieldNames() map[string]struct{} {
+	names := map[string]struct{}{}
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := strings.Split(field.Tag.Get("json"), ",")[0]
+			if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+				walk(field.Type)
+				continue
+			}
+			if tag != "" && tag != "-" {
+				names[tag] = struct{}{}
+			}
+		}
+	}
+	walk(reflect.TypeOf(DeliveryServiceV4{}))
+	return names
+}
+
+// Validate returns an error describing the problems with the policy, if it
+// isn't valid.
+func (p DSRApprovalPolicy) Validate() error {
+	errs := []string{}
+	if strings.TrimSpace(p.Name) == "" {
+		errs = append(errs, "name: required")
+	}
+	if p.RequiredApprovals < 1 {
+		errs = append(errs, "requiredApprovals: must be at least 1")
+	}
+	for _, role := range p.ApproverRoles {
+		if strings.TrimSpace(role) == "" {
+			errs = append(errs, "approverRoles: must not contain empty names")
+			break
+		}
+	}
+	fields := deliveryServiceV4FieldNames()
+	for _, field := range p.RequiredFields {
+		if _, ok := fields[field]; !ok {
+			errs = append(errs, fmt.Sprintf("requiredFields: '%s' is not a property of a Delivery Service", field))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// MissingFields returns those of the policy's RequiredFields which aren't set
+// on the given Delivery Service - that is, which are null, or empty strings or
+// arrays - in sorted order.
+func (p DSRApprovalPolicy) MissingFields(ds DeliveryServiceV4) ([]string, error) {
+	if len(p.RequiredFields) == 0 {
+		return nil, nil
+	}
+	bts, err := json.Marshal(ds)
+	if err != nil {
+		return nil, fmt.Errorf("encoding Delivery Service: %w", err)
+	}
+	props := map[string]interface{}{}
+	if err := json.Unmarshal(bts, &props); err != nil {
+		return nil, fmt.Errorf("decoding Delivery Service properties: %w", err)
+	}
+	missing := []string{}
+	for _, field := range p.RequiredFields {
+		switch val := props[field].(type) {
+		case nil:
+			missing = append(missing, field)
+		case string:
+			if strings.TrimSpace(val) == "" {
+				missing = append(missing, field)
+			}
+		case []interface{}:
+			if len(val) == 0 {
+				missing = append(missing, field)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// AllowsRole returns whether users of the Role with the given name may
+// approve Delivery Service Requests under the policy.
+func (p DSRApprovalPolicy) AllowsRole(role string) bool {
+	if len(p.ApproverRoles) == 0 {
+		return true
+	}
+	for _, r := range p.ApproverRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// DSRApprovalPoliciesResponse is the type of a response from Traffic Ops to a
+// request for DSRApprovalPolicies.
+type DSRApprovalPoliciesResponse struct {
+	Response []DSRApprovalPolicy `json:"response"`
+	Alerts
+}
+
+// DSRApprovalPolicyResponse is the type of a response from Traffic Ops to a
+// request to create, update, or delete a DSRApprovalPolicy.
+type DSRApprovalPolicyResponse struct {
+	Response DSRApprovalPolicy `json:"response"`
+	Alerts
+}
+
+// DSRApproval is the approval of a Delivery Service Request by a user.
+type DSRApproval struct {
+	ID                       int    `json:"id" db:"id"`
+	DeliveryServiceRequestID int    `json:"deliveryServiceRequestId" db:"deliveryservice_request_id"`
+	Approver                 string `json:"approver" db:"approver"`
+	// Role is the name of the approver's Role when they approved the
+	// Delivery Service Request.
+	Role      string    `json:"role" db:"role"`
+	Comment   *string   `json:"comment" db:"comment"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// DSRApprovalRequest is a request to approve a Delivery Service Request.
+type DSRApprovalRequest struct {
+	Comment *string `json:"comment"`
+}
+
+// DSRApprovals are the approvals of a Delivery Service Request, along with
+// the policy which determines how many it needs to be approved.
+type DSRApprovals struct {
+	// Policy is the name of the DSRApprovalPolicy of the Delivery Service
+	// Request.
+	Policy            string        `json:"policy"`
+	RequiredApprovals int           `json:"requiredApprovals"`
+	Approvals         []DSRApproval `json:"approvals"`
+}
+
+// DSRApprovalsResponse is the type of a response from Traffic Ops to a
+// request for the approvals of a Delivery Service Request.
+type DSRApprovalsResponse struct {
+	Response DSRApprovals `json:"response"`
+	Alerts
+}
@@ -0,0 +1,83 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDSRApprovalPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  DSRApprovalPolicy
+		invalid bool
+	}{
+		{"default", DefaultDSRApprovalPolicy, false},
+		{"required fields", DSRApprovalPolicy{Name: "p", RequiredApprovals: 2, RequiredFields: []string{"longDesc", "orgServerFqdn", "tlsVersions"}}, false},
+		{"no name", DSRApprovalPolicy{RequiredApprovals: 1}, true},
+		{"no approvals", DSRApprovalPolicy{Name: "p"}, true},
+		{"empty role", DSRApprovalPolicy{Name: "p", RequiredApprovals: 1, ApproverRoles: []string{""}}, true},
+		{"unknown field", DSRApprovalPolicy{Name: "p", RequiredApprovals: 1, RequiredFields: []string{"notAField"}}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.policy.Validate()
+			if test.invalid && err == nil {
+				t.Error("expected an error, got none")
+			} else if !test.invalid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDSRApprovalPolicyMissingFields(t *testing.T) {
+	longDesc := "described"
+	empty := " "
+	policy := DSRApprovalPolicy{RequiredFields: []string{"tlsVersions", "longDesc", "orgServerFqdn", "displayName"}}
+
+	ds := DeliveryServiceV4{}
+	ds.LongDesc = &longDesc
+	ds.DisplayName = &empty
+	ds.TLSVersions = []string{}
+
+	missing, err := policy.MissingFields(ds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"displayName", "orgServerFqdn", "tlsVersions"}
+	if !reflect.DeepEqual(missing, expected) {
+		t.Errorf("expected missing fields %v, got: %v", expected, missing)
+	}
+}
+
+func TestDSRApprovalPolicyAllowsRole(t *testing.T) {
+	if !DefaultDSRApprovalPolicy.AllowsRole("operations") {
+		t.Error("expected a policy without approver Roles to allow any Role")
+	}
+	policy := DSRApprovalPolicy{ApproverRoles: []string{"operations"}}
+	if !policy.AllowsRole("operations") {
+		t.Error("expected policy to allow one of its approver Roles")
+	}
+	if policy.AllowsRole("read-only") {
+		t.Error("expected policy not to allow a Role that isn't one of its approver Roles")
+	}
+}
@@ -0,0 +1,60 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+)
+
+// GraphQLRequest is the request body of the /graphql API endpoint.
+type GraphQLRequest struct {
+	// Query is the GraphQL document, which may only have query operations.
+	Query string `json:"query"`
+	// OperationName is the name of the operation in Query to execute. It's
+	// only required if Query has more than one operation.
+	OperationName string `json:"operationName,omitempty"`
+	// Variables are the values of the variables of the operation.
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLLocation is a location in a GraphQL document.
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLError is an error in a GraphQL document, or with executing it.
+type GraphQLError struct {
+	Message   string            `json:"message"`
+	Locations []GraphQLLocation `json:"locations,omitempty"`
+	// Path is the path of response keys and array indices to the field with
+	// the error, if any.
+	Path []interface{} `json:"path,omitempty"`
+}
+
+// GraphQLResponse is the response of the /graphql API endpoint. Unlike other
+// API endpoints, it's a GraphQL response, rather than having a "response"
+// and "alerts".
+type GraphQLResponse struct {
+	// Data is the result of the operation, which is omitted if it couldn't
+	// be executed at all.
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
@@ -0,0 +1,133 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// These are the types of resources which may be locked.
+const (
+	ResourceLockTypeCDN             = "cdn"
+	ResourceLockTypeDeliveryService = "deliveryservice"
+	ResourceLockTypeTopology        = "topology"
+	ResourceLockTypeCacheGroup      = "cachegroup"
+	ResourceLockTypeProfile         = "profile"
+)
+
+// ResourceLockTypes are all of the types of resources which may be locked.
+var ResourceLockTypes = []string{
+	ResourceLockTypeCDN,
+	ResourceLockTypeDeliveryService,
+	ResourceLockTypeTopology,
+	ResourceLockTypeCacheGroup,
+	ResourceLockTypeProfile,
+}
+
+// DefaultResourceLockTTL is the time for which a resource lock is held, if
+// none is given.
+const DefaultResourceLockTTL = time.Hour
+
+// MaxResourceLockTTL is the longest time for which a resource lock may be
+// held without being renewed.
+const MaxResourceLockTTL = 24 * time.Hour
+
+// ResourceLock is a lock held by a user on a single resource - a CDN,
+// Delivery Service, Topology, Cache Group, or Profile - which prevents other
+// users from modifying it until it's released or it expires.
+//
+// An exclusive lock is the only lock on its resource. A shared lock may be
+// held by any number of users at once, who may all modify the resource.
+type ResourceLock struct {
+	ID           int       `json:"id" db:"id"`
+	ResourceType string    `json:"resourceType" db:"resource_type"`
+	Resource     string    `json:"resource" db:"resource"`
+	UserName     string    `json:"userName" db:"username"`
+	Shared       bool      `json:"shared" db:"shared"`
+	Message      *string   `json:"message" db:"message"`
+	Expires      time.Time `json:"expires" db:"expires"`
+	LastUpdated  time.Time `json:"lastUpdated" db:"last_updated"`
+}
+
+// ResourceLockRequest is a request to acquire, or renew, a ResourceLock.
+type ResourceLockRequest struct {
+	// ResourceType is the type of the resource to be locked, one of
+	// ResourceLockTypes.
+	ResourceType string `json:"resourceType"`
+	// Resource is the name of the resource - or the XMLID, of a Delivery
+	// Service.
+	Resource string  `json:"resource"`
+	Shared   bool    `json:"shared"`
+	Message  *string `json:"message"`
+	// TTL is the number of seconds for which the lock is held. If nil,
+	// DefaultResourceLockTTL is used.
+	TTL *uint64 `json:"ttl"`
+	// Takeover is whether any conflicting locks of other users are to be
+	// released, so that the lock may be acquired.
+	Takeover bool `json:"takeover"`
+}
+
+// Validate returns an error if the request isn't valid, or else the TTL of the
+// requested lock.
+func (r ResourceLockRequest) Validate() (time.Duration, error) {
+	errs := []string{}
+	validType := false
+	for _, typ := range ResourceLockTypes {
+		if r.ResourceType == typ {
+			validType = true
+			break
+		}
+	}
+	if !validType {
+		errs = append(errs, "resourceType: must be one of "+strings.Join(ResourceLockTypes, ", "))
+	}
+	if r.Resource == "" {
+		errs = append(errs, "resource: required")
+	}
+	ttl := DefaultResourceLockTTL
+	if r.TTL != nil {
+		if *r.TTL == 0 || *r.TTL > uint64(MaxResourceLockTTL/time.Second) {
+			errs = append(errs, fmt.Sprintf("ttl: must be between 1 and %d seconds", uint64(MaxResourceLockTTL/time.Second)))
+		} else {
+			ttl = time.Duration(*r.TTL) * time.Second
+		}
+	}
+	if len(errs) > 0 {
+		return 0, errors.New(strings.Join(errs, "; "))
+	}
+	return ttl, nil
+}
+
+// ResourceLockResponse is the type of a response from Traffic Ops to a request
+// to acquire or release a ResourceLock.
+type ResourceLockResponse struct {
+	Response ResourceLock `json:"response"`
+	Alerts
+}
+
+// ResourceLocksResponse is the type of a response from Traffic Ops to a
+// request to get ResourceLocks.
+type ResourceLocksResponse struct {
+	Response []ResourceLock `json:"response"`
+	Alerts
+}
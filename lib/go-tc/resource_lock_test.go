@@ -0,0 +1,61 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResourceLockRequestValidate(t *testing.T) {
+	ttl := func(seconds uint64) *uint64 { return &seconds }
+
+	tests := []struct {
+		name    string
+		req     ResourceLockRequest
+		ttl     time.Duration
+		invalid bool
+	}{
+		{"default TTL", ResourceLockRequest{ResourceType: ResourceLockTypeDeliveryService, Resource: "demo1"}, DefaultResourceLockTTL, false},
+		{"explicit TTL", ResourceLockRequest{ResourceType: ResourceLockTypeCDN, Resource: "cdn1", TTL: ttl(90)}, 90 * time.Second, false},
+		{"zero TTL", ResourceLockRequest{ResourceType: ResourceLockTypeCDN, Resource: "cdn1", TTL: ttl(0)}, 0, true},
+		{"TTL too long", ResourceLockRequest{ResourceType: ResourceLockTypeCDN, Resource: "cdn1", TTL: ttl(uint64(MaxResourceLockTTL/time.Second) + 1)}, 0, true},
+		{"unknown type", ResourceLockRequest{ResourceType: "server", Resource: "edge"}, 0, true},
+		{"missing resource", ResourceLockRequest{ResourceType: ResourceLockTypeTopology}, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.req.Validate()
+			if test.invalid {
+				if err == nil {
+					t.Error("Expected a validation error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected validation error: %v", err)
+			}
+			if actual != test.ttl {
+				t.Errorf("Expected TTL %s, got: %s", test.ttl, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"time"
+)
+
+// RolloutStatus is the status of a Rollout.
+type RolloutStatus string
+
+const (
+	// RolloutStatusRunning is the status of a Rollout which is queueing updates
+	// on its waves.
+	RolloutStatusRunning = RolloutStatus("running")
+	// RolloutStatusPaused is the status of a Rollout which was paused, and won't
+	// queue updates on more waves until it's resumed.
+	RolloutStatusPaused = RolloutStatus("paused")
+	// RolloutStatusAborted is the status of a Rollout which was aborted, and
+	// will never queue updates on more waves.
+	RolloutStatusAborted = RolloutStatus("aborted")
+	// RolloutStatusFailed is the status of a Rollout whose wave timed out or
+	// was unhealthy. It may be resumed.
+	RolloutStatusFailed = RolloutStatus("failed")
+	// RolloutStatusCompleted is the status of a Rollout which queued updates on
+	// all of its waves, and all of them applied them.
+	RolloutStatusCompleted = RolloutStatus("completed")
+)
+
+// DefaultRolloutWaveTimeoutSeconds is the wave timeout of a Rollout which
+// doesn't give one.
+const DefaultRolloutWaveTimeoutSeconds = 3600
+
+// RolloutRequest is a request to queue updates on the cache servers of a CDN
+// in waves, rather than all at once.
+type RolloutRequest struct {
+	// CDN is the name of the CDN to queue updates on.
+	CDN string `json:"cdn"`
+	// Topology, if not nil, limits the Rollout to the servers in the
+	// Cache Groups of the named Topology.
+	Topology *string `json:"topology"`
+	// CanaryPercent is the percent of servers, not in the canary Cache Groups,
+	// to queue first, as the canary wave.
+	CanaryPercent int `json:"canaryPercent"`
+	// CanaryCacheGroups are the names of the Cache Groups whose servers are
+	// queued first, as the canary wave.
+	CanaryCacheGroups []string `json:"canaryCacheGroups"`
+	// WavePercent is the percent of all servers to queue in each wave after
+	// the canary wave.
+	WavePercent int `json:"wavePercent"`
+	// WaveWaitSeconds is how long to wait after a wave applied its updates,
+	// before checking its health and queueing the next wave.
+	WaveWaitSeconds int `json:"waveWaitSeconds"`
+	// WaveTimeoutSeconds is how long a wave may take to apply its updates,
+	// before the Rollout fails.
+	WaveTimeoutSeconds int `json:"waveTimeoutSeconds"`
+	// CheckHealth is whether to check the availability of each wave's
+	// servers in Traffic Monitor, before queueing the next wave.
+	CheckHealth bool `json:"checkHealth"`
+	// MaxUnavailablePercent is the percent of a wave's monitored servers which
+	// may be unavailable in Traffic Monitor, without the Rollout failing.
+	MaxUnavailablePercent int `json:"maxUnavailablePercent"`
+}
+
+// Rollout is a staged queueing of updates on the cache servers of a CDN,
+// which queues updates on a wave of servers at a time, and waits for them to
+// apply them before queueing the next.
+type Rollout struct {
+	RolloutRequest
+	ID       int           `json:"id"`
+	Username string        `json:"username"`
+	Status   RolloutStatus `json:"status"`
+	// Wave is the index of the current wave in Waves.
+	Wave int `json:"wave"`
+	// Waves are the waves of servers, in the order they're queued.
+	Waves []RolloutWave `json:"waves"`
+	// WaveStarted is when the current wave was queued, or nil if it hasn't
+	// been yet.
+	WaveStarted *time.Time `json:"waveStarted"`
+	// WaveCompleted is when the servers of the current wave applied their
+	// updates, or nil if they haven't yet.
+	WaveCompleted *time.Time `json:"waveCompleted"`
+	// Message is why the Rollout was paused, failed, or was aborted, if it was.
+	Message     *string   `json:"message"`
+	Created     time.Time `json:"created"`
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// RolloutWave is a wave of servers of a Rollout, which are queued together.
+type RolloutWave struct {
+	Wave int `json:"wave"`
+	// Servers are the host names of the wave's servers.
+	Servers []string `json:"servers"`
+	// Pending is the number of the wave's servers which haven't applied their
+	// updates, if the wave has been queued.
+	Pending int `json:"pending"`
+}
+
+// RolloutsResponse is the type of a response from Traffic Ops to a GET
+// request to its /rollouts endpoint.
+type RolloutsResponse struct {
+	Response []Rollout `json:"response"`
+	Alerts
+}
+
+// RolloutResponse is the type of a response from Traffic Ops to a request
+// which creates or changes a single Rollout.
+type RolloutResponse struct {
+	Response Rollout `json:"response"`
+	Alerts
+}
@@ -0,0 +1,212 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScheduledChangeAction is the action a Scheduled Change takes.
+type ScheduledChangeAction string
+
+const (
+	// ScheduledChangeActionServerStatus changes the status of a server, as
+	// by PUT /servers/{{ID}}/status. Its Target is the server's host name.
+	ScheduledChangeActionServerStatus = ScheduledChangeAction("server-status")
+	// ScheduledChangeActionQueueUpdates queues or dequeues updates on the
+	// servers of a CDN, as by POST /cdns/{{ID}}/queue_update. Its Target is
+	// the CDN's name.
+	ScheduledChangeActionQueueUpdates = ScheduledChangeAction("queue-updates")
+	// ScheduledChangeActionSnapshot takes a snapshot of a CDN, as by PUT
+	// /snapshot. Its Target is the CDN's name.
+	ScheduledChangeActionSnapshot = ScheduledChangeAction("snapshot")
+	// ScheduledChangeActionDeliveryServiceUpdate changes fields of a Delivery
+	// Service, as by PUT /deliveryservices/{{ID}}. Its Target is the Delivery
+	// Service's XMLID.
+	ScheduledChangeActionDeliveryServiceUpdate = ScheduledChangeAction("deliveryservice-update")
+)
+
+// ScheduledChangeStatus is the status of a Scheduled Change.
+type ScheduledChangeStatus string
+
+const (
+	// ScheduledChangeStatusScheduled is the status of a Scheduled Change
+	// which hasn't been made yet.
+	ScheduledChangeStatusScheduled = ScheduledChangeStatus("scheduled")
+	// ScheduledChangeStatusCompleted is the status of a Scheduled Change
+	// which was made.
+	ScheduledChangeStatusCompleted = ScheduledChangeStatus("completed")
+	// ScheduledChangeStatusFailed is the status of a Scheduled Change which
+	// couldn't be made, because it was invalid when its window opened.
+	ScheduledChangeStatusFailed = ScheduledChangeStatus("failed")
+	// ScheduledChangeStatusMissed is the status of a Scheduled Change whose
+	// window closed before it could be made - because its target was locked
+	// by another user throughout, or Traffic Ops wasn't running.
+	ScheduledChangeStatusMissed = ScheduledChangeStatus("missed")
+	// ScheduledChangeStatusCancelled is the status of a Scheduled Change
+	// which was cancelled before it was made.
+	ScheduledChangeStatusCancelled = ScheduledChangeStatus("cancelled")
+)
+
+// DefaultScheduledChangeWindow is the length of the window of a Scheduled
+// Change which doesn't give when its window ends.
+const DefaultScheduledChangeWindow = time.Hour
+
+// ScheduledChangeParameters are the parameters of the action of a Scheduled
+// Change. Which are used depends on the action.
+type ScheduledChangeParameters struct {
+	// Status is the name of the status to give a server, for
+	// ScheduledChangeActionServerStatus.
+	Status *string `json:"status,omitempty"`
+	// OfflineReason is why a server is being given the ADMIN_DOWN or OFFLINE
+	// Status, which requires one.
+	OfflineReason *string `json:"offlineReason,omitempty"`
+	// Queue is whether ScheduledChangeActionQueueUpdates queues updates,
+	// rather than dequeueing them. Default: true.
+	Queue *bool `json:"queue,omitempty"`
+	// Fields are the properties of a Delivery Service to change, and their
+	// new values, for ScheduledChangeActionDeliveryServiceUpdate.
+	Fields map[string]json.RawMessage `json:"fields,omitempty"`
+}
+
+// ScheduledChangeRequest is a request to make a change in Traffic Ops at a
+// later time.
+type ScheduledChangeRequest struct {
+	Action ScheduledChangeAction `json:"action"`
+	// Target is the name of the object the action changes: a server's host
+	// name, a CDN's name, or a Delivery Service's XMLID.
+	Target     string                    `json:"target"`
+	Parameters ScheduledChangeParameters `json:"parameters"`
+	// WindowStart is the earliest time at which the change is made.
+	WindowStart time.Time `json:"windowStart"`
+	// WindowEnd is the latest time at which the change is made; if it can't
+	// be made by then, it's missed. Default: DefaultScheduledChangeWindow
+	// after WindowStart.
+	WindowEnd *time.Time `json:"windowEnd"`
+	Comment   *string    `json:"comment"`
+}
+
+// Validate returns an error describing the problems with the request, if it
+// isn't valid at the given time. It doesn't check that the things the request
+// refers to exist.
+func (r ScheduledChangeRequest) Validate(now time.Time) error {
+	errs := []string{}
+	if strings.TrimSpace(r.Target) == "" {
+		errs = append(errs, "target: required")
+	}
+
+	p := r.Parameters
+	if r.Action != ScheduledChangeActionServerStatus && (p.Status != nil || p.OfflineReason != nil) {
+		errs = append(errs, "parameters: status and offlineReason are only allowed for the "+string(ScheduledChangeActionServerStatus)+" action")
+	}
+	if r.Action != ScheduledChangeActionQueueUpdates && p.Queue != nil {
+		errs = append(errs, "parameters: queue is only allowed for the "+string(ScheduledChangeActionQueueUpdates)+" action")
+	}
+	if r.Action != ScheduledChangeActionDeliveryServiceUpdate && len(p.Fields) > 0 {
+		errs = append(errs, "parameters: fields are only allowed for the "+string(ScheduledChangeActionDeliveryServiceUpdate)+" action")
+	}
+
+	switch r.Action {
+	case ScheduledChangeActionServerStatus:
+		if p.Status == nil || strings.TrimSpace(*p.Status) == "" {
+			errs = append(errs, "parameters.status: required")
+		} else if (*p.Status == CacheStatusAdminDown.String() || *p.Status == CacheStatusOffline.String()) && (p.OfflineReason == nil || strings.TrimSpace(*p.OfflineReason) == "") {
+			errs = append(errs, "parameters.offlineReason: required for "+CacheStatusAdminDown.String()+" or "+CacheStatusOffline.String()+" status")
+		}
+	case ScheduledChangeActionQueueUpdates, ScheduledChangeActionSnapshot:
+	case ScheduledChangeActionDeliveryServiceUpdate:
+		if len(p.Fields) == 0 {
+			errs = append(errs, "parameters.fields: required")
+		}
+		names := deliveryServiceV4FieldNames()
+		fields := make([]string, 0, len(p.Fields))
+		for field := range p.Fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			if field == "id" || field == "xmlId" {
+				errs = append(errs, fmt.Sprintf("parameters.fields: '%s' cannot be changed", field))
+			} else if _, ok := names[field]; !ok {
+				errs = append(errs, fmt.Sprintf("parameters.fields: '%s' is not a property of a Delivery Service", field))
+			}
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("action: must be one of '%s', '%s', '%s', or '%s'", ScheduledChangeActionServerStatus, ScheduledChangeActionQueueUpdates, ScheduledChangeActionSnapshot, ScheduledChangeActionDeliveryServiceUpdate))
+	}
+
+	if r.WindowStart.IsZero() {
+		errs = append(errs, "windowStart: required")
+	} else if r.WindowEnd != nil && !r.WindowEnd.After(r.WindowStart) {
+		errs = append(errs, "windowEnd: must be after windowStart")
+	} else if !r.End().After(now) {
+		errs = append(errs, "windowEnd: must be in the future")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// End returns when the window of the requested change ends.
+func (r ScheduledChangeRequest) End() time.Time {
+	if r.WindowEnd != nil {
+		return *r.WindowEnd
+	}
+	return r.WindowStart.Add(DefaultScheduledChangeWindow)
+}
+
+// ScheduledChange is a change which Traffic Ops makes during a window of
+// time, as the user who scheduled it.
+type ScheduledChange struct {
+	ScheduledChangeRequest
+	ID int `json:"id"`
+	// CDN is the name of the CDN of the Target.
+	CDN      string                `json:"cdn"`
+	Username string                `json:"username"`
+	Status   ScheduledChangeStatus `json:"status"`
+	// Message describes the result of the change, or why it's waiting.
+	Message *string `json:"message"`
+	// ExecutedAt is when the change was made, or found to be invalid, or
+	// missed, if it has been.
+	ExecutedAt  *time.Time `json:"executedAt"`
+	Created     time.Time  `json:"created"`
+	LastUpdated time.Time  `json:"lastUpdated"`
+}
+
+// ScheduledChangesResponse is the type of a response from Traffic Ops to a
+// GET request to its /scheduled_changes endpoint.
+type ScheduledChangesResponse struct {
+	Response []ScheduledChange `json:"response"`
+	Alerts
+}
+
+// ScheduledChangeResponse is the type of a response from Traffic Ops to a
+// request which creates or cancels a single Scheduled Change.
+type ScheduledChangeResponse struct {
+	Response ScheduledChange `json:"response"`
+	Alerts
+}
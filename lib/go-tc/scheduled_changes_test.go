@@ -0,0 +1,84 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestScheduledChangeRequestValidate(t *testing.T) {
+	now := time.Now()
+	start := now.Add(time.Hour)
+	past := now.Add(-2 * time.Hour)
+	beforeStart := start.Add(-time.Minute)
+	status := CacheStatusOnline.String()
+	offline := CacheStatusOffline.String()
+	reason := "maintenance"
+	queue := false
+	fields := map[string]json.RawMessage{"active": json.RawMessage(`false`)}
+
+	tests := []struct {
+		name    string
+		req     ScheduledChangeRequest
+		invalid bool
+	}{
+		{"server status", ScheduledChangeRequest{Action: ScheduledChangeActionServerStatus, Target: "edge", Parameters: ScheduledChangeParameters{Status: &status}, WindowStart: start}, false},
+		{"offline with reason", ScheduledChangeRequest{Action: ScheduledChangeActionServerStatus, Target: "edge", Parameters: ScheduledChangeParameters{Status: &offline, OfflineReason: &reason}, WindowStart: start}, false},
+		{"dequeue", ScheduledChangeRequest{Action: ScheduledChangeActionQueueUpdates, Target: "cdn1", Parameters: ScheduledChangeParameters{Queue: &queue}, WindowStart: start}, false},
+		{"snapshot", ScheduledChangeRequest{Action: ScheduledChangeActionSnapshot, Target: "cdn1", WindowStart: past, WindowEnd: &start}, false},
+		{"ds update", ScheduledChangeRequest{Action: ScheduledChangeActionDeliveryServiceUpdate, Target: "ds1", Parameters: ScheduledChangeParameters{Fields: fields}, WindowStart: start}, false},
+		{"unknown action", ScheduledChangeRequest{Action: "reboot", Target: "edge", WindowStart: start}, true},
+		{"no target", ScheduledChangeRequest{Action: ScheduledChangeActionSnapshot, WindowStart: start}, true},
+		{"no status", ScheduledChangeRequest{Action: ScheduledChangeActionServerStatus, Target: "edge", WindowStart: start}, true},
+		{"offline without reason", ScheduledChangeRequest{Action: ScheduledChangeActionServerStatus, Target: "edge", Parameters: ScheduledChangeParameters{Status: &offline}, WindowStart: start}, true},
+		{"wrong parameters", ScheduledChangeRequest{Action: ScheduledChangeActionSnapshot, Target: "cdn1", Parameters: ScheduledChangeParameters{Queue: &queue}, WindowStart: start}, true},
+		{"no fields", ScheduledChangeRequest{Action: ScheduledChangeActionDeliveryServiceUpdate, Target: "ds1", WindowStart: start}, true},
+		{"unknown field", ScheduledChangeRequest{Action: ScheduledChangeActionDeliveryServiceUpdate, Target: "ds1", Parameters: ScheduledChangeParameters{Fields: map[string]json.RawMessage{"notAField": json.RawMessage(`1`)}}, WindowStart: start}, true},
+		{"xmlId field", ScheduledChangeRequest{Action: ScheduledChangeActionDeliveryServiceUpdate, Target: "ds1", Parameters: ScheduledChangeParameters{Fields: map[string]json.RawMessage{"xmlId": json.RawMessage(`"ds2"`)}}, WindowStart: start}, true},
+		{"no window start", ScheduledChangeRequest{Action: ScheduledChangeActionSnapshot, Target: "cdn1"}, true},
+		{"window end before start", ScheduledChangeRequest{Action: ScheduledChangeActionSnapshot, Target: "cdn1", WindowStart: start, WindowEnd: &beforeStart}, true},
+		{"window ended", ScheduledChangeRequest{Action: ScheduledChangeActionSnapshot, Target: "cdn1", WindowStart: past}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.req.Validate(now)
+			if test.invalid && err == nil {
+				t.Error("expected an error, got none")
+			} else if !test.invalid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestScheduledChangeRequestEnd(t *testing.T) {
+	start := time.Now()
+	req := ScheduledChangeRequest{WindowStart: start}
+	if expected := start.Add(DefaultScheduledChangeWindow); !req.End().Equal(expected) {
+		t.Errorf("expected default window end %v, actual %v", expected, req.End())
+	}
+	end := start.Add(time.Minute)
+	req.WindowEnd = &end
+	if !req.End().Equal(end) {
+		t.Errorf("expected window end %v, actual %v", end, req.End())
+	}
+}
@@ -32,12 +32,21 @@ import (
  * under the License.
  */
 
+// ResponseSummary is the "summary" of a response to a GET request which reads
+// a page of objects.
+type ResponseSummary struct {
+	// Count is the number of objects matching the request's filters, on every
+	// page.
+	Count uint64 `json:"count"`
+	// NextCursor is the cursor of the next page, if the request used cursor
+	// pagination and there may be more objects.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
 // ServersV4Response is the format of a response to a GET request for API v4.x /servers.
 type ServersV4Response struct {
-	Response []ServerV40 `json:"response"`
-	Summary  struct {
-		Count uint64 `json:"count"`
-	} `json:"summary"`
+	Response []ServerV40     `json:"response"`
+	Summary  ResponseSummary `json:"summary"`
 	Alerts
 }
 
@@ -423,6 +423,34 @@ type TMDeliveryService struct {
 	TotalTPSThreshold  int64  `json:"TotalTpsThreshold"`
 	ServerStatus       string `json:"status"`
 	TotalKbpsThreshold int64  `json:"TotalKbpsThreshold"`
+	// ProbeURLs are the URLs of objects which Traffic Monitor should request
+	// through each cache server assigned to the Delivery Service, when those
+	// cache servers are polled with the "probe" polling type. These come from
+	// the 'health.probe.url' Parameters of the Delivery Service's Profile.
+	ProbeURLs []string `json:"probeUrls,omitempty"`
+	// HealthPolicy is the policy by which Traffic Monitor scores the health
+	// of the Delivery Service in each of its Cache Groups. It comes from the
+	// 'health.ds.*' Parameters of the Delivery Service's Profile, and is nil
+	// if it has none.
+	HealthPolicy *TMDeliveryServiceHealthPolicy `json:"healthPolicy,omitempty"`
+}
+
+// TMDeliveryServiceHealthPolicy is the set of conditions a Delivery Service
+// must meet in a Cache Group to be considered healthy there. Conditions that
+// are nil are not checked.
+type TMDeliveryServiceHealthPolicy struct {
+	// MinAvailableCachesPerCacheGroup is the least number of available cache
+	// servers assigned to the Delivery Service each Cache Group must have.
+	// If nil, one available cache server is required.
+	MinAvailableCachesPerCacheGroup *int64 `json:"minAvailableCachesPerCacheGroup,omitempty"`
+	// MaxErrorRatio is the greatest allowed ratio, from 0 to 1, of the
+	// Delivery Service's 5xx responses to its total responses in a Cache
+	// Group.
+	MaxErrorRatio *float64 `json:"maxErrorRatio,omitempty"`
+	// MinAvailableCapacityKbps is the least unused bandwidth, in kilobits per
+	// second, the available cache servers assigned to the Delivery Service
+	// in a Cache Group must have between them.
+	MinAvailableCapacityKbps *float64 `json:"minAvailableCapacityKbps,omitempty"`
 }
 
 // TMProfile is primarily a collection of the Parameters with special meaning
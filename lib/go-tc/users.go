@@ -331,6 +331,25 @@ type UserCurrentResponse struct {
 	Alerts
 }
 
+// UserPermissions is the set of permissions of a user, which are those of
+// their Role.
+type UserPermissions struct {
+	// Role is the name of the user's Role.
+	Role string `json:"role"`
+	// Superuser is whether the user has every permission by virtue of their
+	// Role, regardless of the Permissions it's been given.
+	Superuser bool `json:"superuser"`
+	// Permissions are the permissions of the user's Role, in lexical order.
+	Permissions []string `json:"permissions"`
+}
+
+// UserPermissionsResponse is the type of a response from Traffic Ops to a
+// request to get the permissions of the current user.
+type UserPermissionsResponse struct {
+	Response UserPermissions `json:"response"`
+	Alerts
+}
+
 // UserDeliveryServiceDeleteResponse can hold a Traffic Ops API response to
 // a request to remove a delivery service from a user.
 type UserDeliveryServiceDeleteResponse struct {
@@ -0,0 +1,106 @@
+package bandwidth
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"time"
+)
+
+// Forecast is the bandwidth trend of a single series, extrapolated over a
+// horizon, and compared against the capacity available to it.
+type Forecast struct {
+	// CurrentKbps is the most recent bandwidth measurement.
+	CurrentKbps float64 `json:"currentKbps"`
+	// TrendKbpsPerSecond is the rate of change of the bandwidth over the
+	// history window, fitted by least squares.
+	TrendKbpsPerSecond float64 `json:"trendKbpsPerSecond"`
+	// ProjectedKbps is the bandwidth extrapolated from the current value at
+	// the trend, at the end of the forecast horizon. It is never negative.
+	ProjectedKbps float64 `json:"projectedKbps"`
+	// CapacityKbps is the total bandwidth capacity of the available cache
+	// servers the series is served from.
+	CapacityKbps float64 `json:"capacityKbps"`
+	// HeadroomKbps is the capacity not currently used, by any traffic.
+	HeadroomKbps float64 `json:"headroomKbps"`
+	// SecondsToSaturation is how long until the trend uses all the headroom.
+	// It is 0 if there is already no headroom, and nil if the trend is not
+	// increasing or the capacity is unknown.
+	SecondsToSaturation *float64 `json:"secondsToSaturation"`
+	// Samples is the number of measurements the trend was fitted to.
+	Samples int `json:"samples"`
+}
+
+// Trend returns the rate of change of the series, in kilobits per second per
+// second, fitted by least squares. It returns 0 if there are too few samples
+// to have a trend.
+func (s Series) Trend() float64 {
+	if len(s) < 2 {
+		return 0
+	}
+	start := s[0].Time
+	n := float64(len(s))
+	sumX, sumY, sumXY, sumXX := float64(0), float64(0), float64(0), float64(0)
+	for _, sample := range s {
+		x := sample.Time.Sub(start).Seconds()
+		sumX += x
+		sumY += sample.Kbps
+		sumXY += x * sample.Kbps
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0 // all samples at the same time
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
+
+// Forecast forecasts the series over the given horizon, against the given
+// capacity. The usedKbps is the bandwidth currently used of that capacity,
+// which may include traffic not in the series, e.g. for a delivery service,
+// the traffic of other delivery services on the same cache servers.
+func (s Series) Forecast(horizon time.Duration, capacityKbps float64, usedKbps float64) Forecast {
+	f := Forecast{
+		TrendKbpsPerSecond: s.Trend(),
+		CapacityKbps:       capacityKbps,
+		HeadroomKbps:       capacityKbps - usedKbps,
+		Samples:            len(s),
+	}
+	if len(s) > 0 {
+		f.CurrentKbps = s[len(s)-1].Kbps
+	}
+	if f.ProjectedKbps = f.CurrentKbps + f.TrendKbpsPerSecond*horizon.Seconds(); f.ProjectedKbps < 0 {
+		f.ProjectedKbps = 0
+	}
+	if f.HeadroomKbps < 0 {
+		f.HeadroomKbps = 0
+	}
+
+	if capacityKbps <= 0 {
+		return f
+	}
+	if f.HeadroomKbps == 0 {
+		zero := float64(0)
+		f.SecondsToSaturation = &zero
+	} else if f.TrendKbpsPerSecond > 0 {
+		secs := f.HeadroomKbps / f.TrendKbpsPerSecond
+		f.SecondsToSaturation = &secs
+	}
+	return f
+}
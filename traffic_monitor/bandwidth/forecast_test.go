@@ -0,0 +1,68 @@
+package bandwidth
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestForecast(t *testing.T) {
+	start := time.Now()
+	// 100kbps, increasing by 2kbps every second
+	s := Series{}
+	for i := 0; i < 10; i++ {
+		s = append(s, Sample{Time: start.Add(time.Duration(i) * time.Second), Kbps: 100 + 2*float64(i)})
+	}
+
+	f := s.Forecast(time.Minute, 1000, 318)
+	if math.Abs(f.TrendKbpsPerSecond-2) > 0.0001 {
+		t.Errorf("expected: trend 2, actual: %v", f.TrendKbpsPerSecond)
+	}
+	if f.CurrentKbps != 118 {
+		t.Errorf("expected: current 118, actual: %v", f.CurrentKbps)
+	}
+	if math.Abs(f.ProjectedKbps-238) > 0.0001 {
+		t.Errorf("expected: projected 238, actual: %v", f.ProjectedKbps)
+	}
+	if f.HeadroomKbps != 682 {
+		t.Errorf("expected: headroom 682, actual: %v", f.HeadroomKbps)
+	}
+	if f.SecondsToSaturation == nil || math.Abs(*f.SecondsToSaturation-341) > 0.0001 {
+		t.Errorf("expected: 341 seconds to saturation, actual: %v", f.SecondsToSaturation)
+	}
+
+	if f := s.Forecast(time.Minute, 0, 118); f.SecondsToSaturation != nil {
+		t.Errorf("expected: no time to saturation with unknown capacity, actual: %v", *f.SecondsToSaturation)
+	}
+	if f := s.Forecast(time.Minute, 100, 118); f.SecondsToSaturation == nil || *f.SecondsToSaturation != 0 || f.HeadroomKbps != 0 {
+		t.Errorf("expected: saturated with no headroom, actual: %+v", f)
+	}
+
+	decreasing := Series{{Time: start, Kbps: 100}, {Time: start.Add(time.Second), Kbps: 50}}
+	if f := decreasing.Forecast(time.Minute, 1000, 50); f.SecondsToSaturation != nil || f.ProjectedKbps != 0 {
+		t.Errorf("expected: decreasing trend never to saturate, and to project to no less than 0, actual: %+v", f)
+	}
+
+	if trend := (Series{{Time: start, Kbps: 100}}).Trend(); trend != 0 {
+		t.Errorf("expected: a single sample to have no trend, actual: %v", trend)
+	}
+}
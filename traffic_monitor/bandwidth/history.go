@@ -0,0 +1,188 @@
+// Package bandwidth maintains rolling bandwidth histories of cachegroups and
+// delivery services, and forecasts their bandwidth and time to saturation.
+package bandwidth
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/ds"
+	"github.com/apache/trafficcontrol/traffic_monitor/dsdata"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+)
+
+// Sample is a single bandwidth measurement.
+type Sample struct {
+	Time time.Time `json:"time"`
+	Kbps float64   `json:"kbps"`
+}
+
+// Series is a time series of bandwidth measurements, oldest first.
+type Series []Sample
+
+// History is the rolling bandwidth history of the CDN, each of its
+// cachegroups, and each of its delivery services.
+type History struct {
+	// Window is how long samples are kept.
+	Window           time.Duration
+	Total            Series
+	CacheGroups      map[tc.CacheGroupName]Series
+	DeliveryServices map[tc.DeliveryServiceName]Series
+}
+
+// NewHistory returns a new, empty History which keeps samples for the given
+// window.
+func NewHistory(window time.Duration) *History {
+	return &History{
+		Window:           window,
+		Total:            Series{},
+		CacheGroups:      map[tc.CacheGroupName]Series{},
+		DeliveryServices: map[tc.DeliveryServiceName]Series{},
+	}
+}
+
+// Add returns a new History with a sample taken at the given time added to
+// every series, and samples older than the History's Window removed.
+// Cachegroups and delivery services without a sample are left out of the new
+// History, because they no longer exist.
+//
+// The History isn't modified, and the new History shares the storage of its
+// samples, so Add is cheap and safe to call while the History is read by other
+// goroutines. Because a series' samples are only ever appended after the end
+// of its newest version, they must only be added to the newest History.
+func (h History) Add(now time.Time, totalKbps float64, cacheGroupKbps map[tc.CacheGroupName]float64, dsKbps map[tc.DeliveryServiceName]float64) History {
+	oldest := now.Add(-h.Window)
+	b := History{
+		Window:           h.Window,
+		Total:            h.Total.add(Sample{Time: now, Kbps: totalKbps}, oldest),
+		CacheGroups:      make(map[tc.CacheGroupName]Series, len(cacheGroupKbps)),
+		DeliveryServices: make(map[tc.DeliveryServiceName]Series, len(dsKbps)),
+	}
+	for cg, kbps := range cacheGroupKbps {
+		b.CacheGroups[cg] = h.CacheGroups[cg].add(Sample{Time: now, Kbps: kbps}, oldest)
+	}
+	for dsName, kbps := range dsKbps {
+		b.DeliveryServices[dsName] = h.DeliveryServices[dsName].add(Sample{Time: now, Kbps: kbps}, oldest)
+	}
+	return b
+}
+
+// add returns the series with the given sample appended, and all samples
+// before oldest removed.
+func (s Series) add(sample Sample, oldest time.Time) Series {
+	s = append(s, sample)
+	i := 0
+	for i < len(s) && s[i].Time.Before(oldest) {
+		i++
+	}
+	return s[i:]
+}
+
+// CacheKbps returns the current bandwidth of each cache server, in kilobits
+// per second, from the out bytes of its interfaces in the stat history.
+//
+// The bandwidth is the rate between the two most recent distinct values of
+// each interface's out bytes. If the most recent value has been polled more
+// than once, the interface isn't sending anything, and its bandwidth is 0.
+func CacheKbps(statResultHistory threadsafe.ResultStatHistory) map[tc.CacheName]float64 {
+	kbps := map[tc.CacheName]float64{}
+	statResultHistory.Range(func(cacheName string, history threadsafe.CacheStatHistory) bool {
+		total := float64(0)
+		for _, interfaceHistory := range history.Interfaces {
+			total += interfaceKbps(interfaceHistory.Load(threadsafe.InterfaceStatNameBytesOut))
+		}
+		kbps[tc.CacheName(cacheName)] = total
+		return true
+	})
+	return kbps
+}
+
+// interfaceKbps returns the current bandwidth, in kilobits per second, of the
+// given history of an interface's out bytes, newest first.
+func interfaceKbps(outBytes []tc.ResultStatVal) float64 {
+	if len(outBytes) < 2 || outBytes[0].Span > 1 {
+		return 0
+	}
+	newest, ok := outBytes[0].Val.(uint64)
+	if !ok {
+		return 0
+	}
+	previous, ok := outBytes[1].Val.(uint64)
+	if !ok || newest < previous {
+		return 0 // the counter was reset
+	}
+	secs := outBytes[0].Time.Sub(outBytes[1].Time).Seconds()
+	if secs <= 0 {
+		return 0
+	}
+	return float64(newest-previous) / ds.BytesPerKilobit / secs
+}
+
+// AddStats returns a new History with a sample of the current bandwidth of
+// the CDN, each cachegroup, and each delivery service added, exactly like Add.
+// The cache server and cachegroup bandwidths come from the stat history, and
+// the delivery service bandwidths from the delivery service stats.
+func (h History) AddStats(now time.Time, statResultHistory threadsafe.ResultStatHistory, dsStats dsdata.Stats, serverCachegroups map[tc.CacheName]tc.CacheGroupName) History {
+	total := float64(0)
+	cacheGroupKbps := map[tc.CacheGroupName]float64{}
+	for cacheName, kbps := range CacheKbps(statResultHistory) {
+		total += kbps
+		if cg, ok := serverCachegroups[cacheName]; ok {
+			cacheGroupKbps[cg] += kbps
+		}
+	}
+	dsKbps := make(map[tc.DeliveryServiceName]float64, len(dsStats.DeliveryService))
+	for dsName, stat := range dsStats.DeliveryService {
+		dsKbps[dsName] = stat.TotalStats.Kbps.Value
+	}
+	return h.Add(now, total, cacheGroupKbps, dsKbps)
+}
+
+// ThreadsafeHistory wraps a History to be safe for multiple reader goroutines
+// and one writer.
+type ThreadsafeHistory struct {
+	h *History
+	m *sync.RWMutex
+}
+
+// NewThreadsafeHistory returns a new, empty ThreadsafeHistory which keeps
+// samples for the given window.
+func NewThreadsafeHistory(window time.Duration) ThreadsafeHistory {
+	return ThreadsafeHistory{h: NewHistory(window), m: &sync.RWMutex{}}
+}
+
+// Get returns the History. Callers MUST NOT modify it. To add samples, call
+// History.Add, which doesn't modify it.
+func (o ThreadsafeHistory) Get() History {
+	o.m.RLock()
+	defer o.m.RUnlock()
+	return *o.h
+}
+
+// Set sets the internal History. This MUST NOT be called by multiple
+// goroutines.
+func (o ThreadsafeHistory) Set(h History) {
+	o.m.Lock()
+	*o.h = h
+	o.m.Unlock()
+}
@@ -0,0 +1,98 @@
+package bandwidth
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/cache"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+)
+
+func TestHistoryAdd(t *testing.T) {
+	start := time.Now()
+	h := *NewHistory(time.Minute)
+	for i := 0; i < 10; i++ {
+		now := start.Add(time.Duration(i) * 10 * time.Second)
+		h = h.Add(now, float64(i), map[tc.CacheGroupName]float64{"cg": float64(i)}, map[tc.DeliveryServiceName]float64{"ds": float64(i)})
+	}
+	// samples at 30s through 90s are within a minute of the last
+	if len(h.Total) != 7 {
+		t.Errorf("expected: 7 samples in window, actual: %d", len(h.Total))
+	}
+	if len(h.CacheGroups["cg"]) != 7 || len(h.DeliveryServices["ds"]) != 7 {
+		t.Errorf("expected: 7 cachegroup and delivery service samples in window, actual: %d %d", len(h.CacheGroups["cg"]), len(h.DeliveryServices["ds"]))
+	}
+
+	next := h.Add(start.Add(100*time.Second), 10, map[tc.CacheGroupName]float64{"cg2": 1}, map[tc.DeliveryServiceName]float64{})
+	if _, ok := next.CacheGroups["cg"]; ok {
+		t.Error("expected: removed cachegroup to be removed from history, actual: still exists")
+	}
+	if _, ok := next.DeliveryServices["ds"]; ok {
+		t.Error("expected: removed delivery service to be removed from history, actual: still exists")
+	}
+	if len(next.CacheGroups["cg2"]) != 1 {
+		t.Errorf("expected: new cachegroup to have 1 sample, actual: %d", len(next.CacheGroups["cg2"]))
+	}
+	if len(next.Total) != 7 || next.Total[6].Kbps != 10 {
+		t.Errorf("expected: 7 samples in window ending with the new sample, actual: %+v", next.Total)
+	}
+	if len(h.CacheGroups["cg"]) != 7 || len(h.Total) != 7 || h.Total[6].Kbps != 9 {
+		t.Error("expected: adding to history not to modify it, actual: modified")
+	}
+}
+
+func TestCacheKbps(t *testing.T) {
+	start := time.Now()
+	statResultHistory := threadsafe.NewResultStatHistory()
+	for i, bytesOut := range []uint64{1000, 26000} {
+		result := cache.Result{
+			ID:   "cache0",
+			Time: start.Add(time.Duration(i) * time.Second),
+			Statistics: cache.Statistics{
+				Interfaces: map[string]cache.Interface{"eth0": {BytesOut: bytesOut}},
+			},
+		}
+		if err := statResultHistory.Add(result, 5); err != nil {
+			t.Fatalf("adding result: %v", err)
+		}
+	}
+
+	// 25000 bytes in a second is 200 kilobits per second
+	if kbps := CacheKbps(statResultHistory); kbps["cache0"] != 200 {
+		t.Errorf("expected: cache0 200 kbps, actual: %v", kbps["cache0"])
+	}
+
+	result := cache.Result{
+		ID:   "cache0",
+		Time: start.Add(2 * time.Second),
+		Statistics: cache.Statistics{
+			Interfaces: map[string]cache.Interface{"eth0": {BytesOut: 26000}},
+		},
+	}
+	if err := statResultHistory.Add(result, 5); err != nil {
+		t.Fatalf("adding result: %v", err)
+	}
+	if kbps := CacheKbps(statResultHistory); kbps["cache0"] != 0 {
+		t.Errorf("expected: cache0 0 kbps when its out bytes haven't changed, actual: %v", kbps["cache0"])
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/poller"
 	"github.com/apache/trafficcontrol/traffic_monitor/todata"
 )
 
@@ -294,6 +295,16 @@ func (handler Handler) Handle(id string, rdr io.Reader, format string, reqTime t
 		return
 	}
 
+	// The probe poller polls stats with an embedded HTTP poller, and that is
+	// what stats parsers understand. Its probe stats are added to the parsed
+	// stats, so they're kept in the stat history and checked against
+	// thresholds like any other stat.
+	probeStats := map[string]interface{}(nil)
+	if probeCtx, ok := pollCtx.(*poller.ProbePollCtx); ok {
+		pollCtx = probeCtx.HTTPPollCtx
+		probeStats = probeCtx.ProbeStats
+	}
+
 	stats, miscStats, err := decoder.Parse(result.ID, rdr, pollCtx)
 	if err != nil {
 		log.Warnf("%s decode error '%v'", id, err)
@@ -302,6 +313,13 @@ func (handler Handler) Handle(id string, rdr io.Reader, format string, reqTime t
 		return
 	}
 
+	if len(probeStats) > 0 && miscStats == nil {
+		miscStats = make(map[string]interface{}, len(probeStats))
+	}
+	for name, val := range probeStats {
+		miscStats[name] = val
+	}
+
 	result.Statistics = stats
 	result.Miscellaneous = miscStats
 
@@ -128,6 +128,11 @@ type Config struct {
 	CachePollingProtocol         PollingProtocol `json:"cache_polling_protocol"`
 	PeerPollingProtocol          PollingProtocol `json:"peer_polling_protocol"`
 	HTTPPollingFormat            string          `json:"http_polling_format"`
+	BandwidthHistoryWindow       time.Duration   `json:"-"`
+	RecordFile                   string          `json:"record_file"`
+	ReplayFile                   string          `json:"replay_file"`
+	ReplaySpeed                  float64         `json:"replay_speed"`
+	ReplayMonitoringFile         string          `json:"replay_monitoring_file"`
 }
 
 func (c Config) ErrorLog() log.LogLocation   { return log.LogLocation(c.LogLocationError) }
@@ -169,6 +174,11 @@ var DefaultConfig = Config{
 	CachePollingProtocol:         Both,
 	PeerPollingProtocol:          Both,
 	HTTPPollingFormat:            HTTPPollingFormat,
+	BandwidthHistoryWindow:       30 * time.Minute,
+	RecordFile:                   "",
+	ReplayFile:                   "",
+	ReplaySpeed:                  1,
+	ReplayMonitoringFile:         "",
 }
 
 // MarshalJSON marshals custom millisecond durations. Aliasing inspired by http://choly.ca/post/go-json-marshalling/
@@ -188,6 +198,7 @@ func (c *Config) MarshalJSON() ([]byte, error) {
 		StatBufferIntervalMs           uint64 `json:"stat_buffer_interval_ms"`
 		ServeReadTimeoutMs             uint64 `json:"serve_read_timeout_ms"`
 		ServeWriteTimeoutMs            uint64 `json:"serve_write_timeout_ms"`
+		BandwidthHistoryWindowMs       uint64 `json:"bandwidth_history_window_ms"`
 		*Alias
 	}{
 		CacheHealthPollingIntervalMs:   uint64(c.CacheHealthPollingInterval / time.Millisecond),
@@ -200,6 +211,7 @@ func (c *Config) MarshalJSON() ([]byte, error) {
 		HealthFlushIntervalMs:          uint64(c.HealthFlushInterval / time.Millisecond),
 		StatFlushIntervalMs:            uint64(c.StatFlushInterval / time.Millisecond),
 		StatBufferIntervalMs:           uint64(c.StatBufferInterval / time.Millisecond),
+		BandwidthHistoryWindowMs:       uint64(c.BandwidthHistoryWindow / time.Millisecond),
 		Alias:                          (*Alias)(c),
 	})
 }
@@ -226,6 +238,7 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 		CRConfigBackupFile             *string `json:"crconfig_backup_file"`
 		TMConfigBackupFile             *string `json:"tmconfig_backup_file"`
 		HTTPPollingFormat              *string `json:"http_polling_format"`
+		BandwidthHistoryWindowMs       *uint64 `json:"bandwidth_history_window_ms"`
 		*Alias
 	}{
 		Alias: (*Alias)(c),
@@ -289,6 +302,9 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	if aux.HTTPPollingFormat != nil {
 		c.HTTPPollingFormat = *aux.HTTPPollingFormat
 	}
+	if aux.BandwidthHistoryWindowMs != nil {
+		c.BandwidthHistoryWindow = time.Duration(*aux.BandwidthHistoryWindowMs) * time.Millisecond
+	}
 	return nil
 }
 
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package datareq
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/bandwidth"
+	"github.com/apache/trafficcontrol/traffic_monitor/peer"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+	"github.com/apache/trafficcontrol/traffic_monitor/todata"
+
+	"github.com/json-iterator/go"
+)
+
+// DefaultBandwidthForecastHorizon is the time over which bandwidth is
+// forecast, if the request doesn't give a horizon.
+const DefaultBandwidthForecastHorizon = 15 * time.Minute
+
+// BandwidthForecast is the response of the bandwidth forecast endpoint.
+type BandwidthForecast struct {
+	HorizonSeconds   float64                                       `json:"horizonSeconds"`
+	WindowSeconds    float64                                       `json:"windowSeconds"`
+	Total            bandwidth.Forecast                            `json:"total"`
+	CacheGroups      map[tc.CacheGroupName]bandwidth.Forecast      `json:"cacheGroups"`
+	DeliveryServices map[tc.DeliveryServiceName]bandwidth.Forecast `json:"deliveryServices"`
+}
+
+func srvAPIBandwidthForecast(params url.Values, errorCount threadsafe.Uint, path string, toData todata.TODataThreadsafe, bandwidthHistory bandwidth.ThreadsafeHistory, statResultHistory threadsafe.ResultStatHistory, statMaxKbpses threadsafe.CacheKbpses, localStates peer.CRStatesThreadsafe) ([]byte, int) {
+	horizon := DefaultBandwidthForecastHorizon
+	if horizonStr := params.Get("horizon"); horizonStr != "" {
+		horizonSecs, err := strconv.ParseUint(horizonStr, 10, 64)
+		if err != nil {
+			err = errors.New("invalid horizon '" + horizonStr + "': must be a non-negative integer number of seconds")
+			HandleErr(errorCount, path, err)
+			return []byte(err.Error()), http.StatusBadRequest
+		}
+		horizon = time.Duration(horizonSecs) * time.Second
+	}
+
+	forecast := createBandwidthForecast(toData.Get(), bandwidthHistory.Get(), bandwidth.CacheKbps(statResultHistory), statMaxKbpses.Get(), localStates.GetCaches(), horizon)
+	json := jsoniter.ConfigFastest
+	bytes, err := json.Marshal(forecast)
+	return WrapErrCode(errorCount, path, bytes, err)
+}
+
+// createBandwidthForecast forecasts the total, cachegroup, and delivery
+// service bandwidth histories over the given horizon.
+//
+// The capacity of each is the maximum bandwidth of the available cache servers
+// serving it, and the used bandwidth the current bandwidth of those caches.
+func createBandwidthForecast(toData todata.TOData, history bandwidth.History, cacheKbps map[tc.CacheName]float64, maxKbpses map[string]uint64, cacheStates map[tc.CacheName]tc.IsAvailable, horizon time.Duration) BandwidthForecast {
+	capacity := func(caches []tc.CacheName) (float64, float64) {
+		capacityKbps, usedKbps := float64(0), float64(0)
+		for _, cacheName := range caches {
+			if !cacheStates[cacheName].IsAvailable {
+				continue
+			}
+			capacityKbps += float64(maxKbpses[string(cacheName)])
+			usedKbps += cacheKbps[cacheName]
+		}
+		return capacityKbps, usedKbps
+	}
+
+	allCaches := make([]tc.CacheName, 0, len(cacheStates))
+	cacheGroupCaches := map[tc.CacheGroupName][]tc.CacheName{}
+	for cacheName := range cacheStates {
+		allCaches = append(allCaches, cacheName)
+		if cg, ok := toData.ServerCachegroups[cacheName]; ok {
+			cacheGroupCaches[cg] = append(cacheGroupCaches[cg], cacheName)
+		}
+	}
+
+	forecast := BandwidthForecast{
+		HorizonSeconds:   horizon.Seconds(),
+		WindowSeconds:    history.Window.Seconds(),
+		CacheGroups:      make(map[tc.CacheGroupName]bandwidth.Forecast, len(history.CacheGroups)),
+		DeliveryServices: make(map[tc.DeliveryServiceName]bandwidth.Forecast, len(history.DeliveryServices)),
+	}
+	capacityKbps, usedKbps := capacity(allCaches)
+	forecast.Total = history.Total.Forecast(horizon, capacityKbps, usedKbps)
+	for cg, series := range history.CacheGroups {
+		capacityKbps, usedKbps := capacity(cacheGroupCaches[cg])
+		forecast.CacheGroups[cg] = series.Forecast(horizon, capacityKbps, usedKbps)
+	}
+	for dsName, series := range history.DeliveryServices {
+		capacityKbps, usedKbps := capacity(toData.DeliveryServiceServers[dsName])
+		forecast.DeliveryServices[dsName] = series.Forecast(horizon, capacityKbps, usedKbps)
+	}
+	return forecast
+}
@@ -0,0 +1,84 @@
+package datareq
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/bandwidth"
+	"github.com/apache/trafficcontrol/traffic_monitor/todata"
+)
+
+func TestCreateBandwidthForecast(t *testing.T) {
+	toData := todata.New()
+	toData.ServerCachegroups["a0"] = "cgA"
+	toData.ServerCachegroups["a1"] = "cgA"
+	toData.ServerCachegroups["b0"] = "cgB"
+	toData.DeliveryServiceServers["ds0"] = []tc.CacheName{"a0", "b0"}
+
+	cacheStates := map[tc.CacheName]tc.IsAvailable{
+		"a0": {IsAvailable: true},
+		"a1": {IsAvailable: false},
+		"b0": {IsAvailable: true},
+	}
+	maxKbpses := map[string]uint64{"a0": 1000, "a1": 1000, "b0": 2000}
+	cacheKbps := map[tc.CacheName]float64{"a0": 400, "a1": 0, "b0": 500}
+
+	start := time.Now()
+	history := bandwidth.NewHistory(time.Hour).
+		Add(start, 800, map[tc.CacheGroupName]float64{"cgA": 300, "cgB": 500}, map[tc.DeliveryServiceName]float64{"ds0": 100}).
+		Add(start.Add(time.Second), 900, map[tc.CacheGroupName]float64{"cgA": 400, "cgB": 500}, map[tc.DeliveryServiceName]float64{"ds0": 110})
+
+	forecast := createBandwidthForecast(*toData, history, cacheKbps, maxKbpses, cacheStates, time.Minute)
+
+	if forecast.HorizonSeconds != 60 || forecast.WindowSeconds != 3600 {
+		t.Errorf("expected: horizon 60 and window 3600 seconds, actual: %v %v", forecast.HorizonSeconds, forecast.WindowSeconds)
+	}
+	if forecast.Total.CapacityKbps != 3000 || forecast.Total.HeadroomKbps != 2100 {
+		t.Errorf("expected: total capacity 3000 and headroom 2100 of available caches, actual: %+v", forecast.Total)
+	}
+
+	cgA, ok := forecast.CacheGroups["cgA"]
+	if !ok {
+		t.Fatal("expected: cachegroup cgA forecast, actual: missing")
+	}
+	if cgA.CapacityKbps != 1000 || cgA.HeadroomKbps != 600 {
+		t.Errorf("expected: cgA capacity 1000 of its only available cache, and headroom 600, actual: %+v", cgA)
+	}
+	if cgA.SecondsToSaturation == nil || *cgA.SecondsToSaturation != 6 {
+		t.Errorf("expected: cgA 6 seconds to saturation, actual: %v", cgA.SecondsToSaturation)
+	}
+	if cgB := forecast.CacheGroups["cgB"]; cgB.SecondsToSaturation != nil {
+		t.Errorf("expected: cgB with flat trend never to saturate, actual: %v", *cgB.SecondsToSaturation)
+	}
+
+	ds0, ok := forecast.DeliveryServices["ds0"]
+	if !ok {
+		t.Fatal("expected: delivery service ds0 forecast, actual: missing")
+	}
+	if ds0.CapacityKbps != 3000 || ds0.HeadroomKbps != 2100 || ds0.CurrentKbps != 110 {
+		t.Errorf("expected: ds0 capacity 3000, headroom 2100, current 110, actual: %+v", ds0)
+	}
+	if ds0.SecondsToSaturation == nil || *ds0.SecondsToSaturation != 210 {
+		t.Errorf("expected: ds0 210 seconds to saturation, actual: %v", ds0.SecondsToSaturation)
+	}
+}
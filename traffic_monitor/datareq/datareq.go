@@ -31,6 +31,7 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/traffic_monitor/bandwidth"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
 	"github.com/apache/trafficcontrol/traffic_monitor/health"
 	"github.com/apache/trafficcontrol/traffic_monitor/peer"
@@ -63,6 +64,7 @@ func MakeDispatchMap(
 	lastStats threadsafe.LastStats,
 	unpolledCaches threadsafe.UnpolledCaches,
 	monitorConfig threadsafe.TrafficMonitorConfigMap,
+	bandwidthHistory bandwidth.ThreadsafeHistory,
 ) map[string]http.HandlerFunc {
 
 	// wrap composes all universal wrapper functions. Right now, it's only the UnpolledCheck, but there may be others later. For example, security headers.
@@ -126,6 +128,9 @@ func MakeDispatchMap(
 		"/api/bandwidth-capacity-kbps": wrap(WrapBytes(func() []byte {
 			return srvAPIBandwidthCapacityKbps(statMaxKbpses)
 		}, rfc.ApplicationJSON)),
+		"/api/bandwidth-forecast": wrap(WrapParams(func(params url.Values, path string) ([]byte, int) {
+			return srvAPIBandwidthForecast(params, errorCount, path, toData, bandwidthHistory, statResultHistory, statMaxKbpses, localStates)
+		}, rfc.ApplicationJSON)),
 		"/api/monitor-config": wrap(WrapErr(errorCount, func() ([]byte, error) {
 			return srvMonitorConfig(monitorConfig)
 		}, rfc.ApplicationJSON)),
@@ -0,0 +1,181 @@
+package ds
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/cache"
+	"github.com/apache/trafficcontrol/traffic_monitor/dsdata"
+	"github.com/apache/trafficcontrol/traffic_monitor/health"
+	"github.com/apache/trafficcontrol/traffic_monitor/peer"
+	"github.com/apache/trafficcontrol/traffic_monitor/todata"
+)
+
+// DefaultMinAvailableCachesPerCacheGroup is the number of available caches a
+// delivery service needs in a cachegroup to be healthy there, if its health
+// policy doesn't say otherwise.
+const DefaultMinAvailableCachesPerCacheGroup = 1
+
+// cacheGroupHealth is the data of a delivery service in a single cachegroup
+// which its health policy is evaluated against.
+type cacheGroupHealth struct {
+	AvailableCaches       int64
+	AvailableCapacityKbps float64
+}
+
+// addHealthData evaluates the health policy of each delivery service in each
+// of its cachegroups, and sets the resulting health score and degraded
+// cachegroups in both the dsStats and the local CRStates.
+//
+// Note this mutates dsStats, lastStats, and states.
+func addHealthData(dsStats *dsdata.Stats, toData todata.TOData, crStates tc.CRStates, lastStats *dsdata.LastStats, precomputed map[tc.CacheName]cache.PrecomputedData, mc tc.TrafficMonitorConfigMap, events health.ThreadsafeEvents, states peer.CRStatesThreadsafe) {
+	for dsName, stat := range dsStats.DeliveryService {
+		policy := mc.DeliveryService[dsName.String()].HealthPolicy
+		cgHealths := getCacheGroupHealths(toData.DeliveryServiceServers[dsName], toData.ServerCachegroups, crStates, lastStats, precomputed)
+
+		degraded := map[tc.CacheGroupName]string{}
+		for cg, cgHealth := range cgHealths {
+			if why := evalCacheGroupHealth(cgHealth, stat.CacheGroups[cg], policy); why != "" {
+				degraded[cg] = why
+			}
+		}
+
+		score := float64(0)
+		if len(cgHealths) > 0 {
+			score = float64(len(cgHealths)-len(degraded)) / float64(len(cgHealths))
+		}
+
+		degradedNames := make([]string, 0, len(degraded))
+		for cg := range degraded {
+			degradedNames = append(degradedNames, string(cg))
+		}
+		sort.Strings(degradedNames)
+		whys := make([]string, 0, len(degradedNames))
+		degradedLocations := make([]tc.CacheGroupName, 0, len(degradedNames))
+		for _, cg := range degradedNames {
+			whys = append(whys, cg+": "+degraded[tc.CacheGroupName(cg)])
+			degradedLocations = append(degradedLocations, tc.CacheGroupName(cg))
+		}
+
+		stat.CommonStats.HealthScore.Value = score
+		stat.CommonStats.CachesDegraded = degradedNames
+		stat.CommonStats.HealthStr.Value = strings.Join(whys, "; ")
+
+		dsState, _ := states.GetDeliveryService(dsName)
+		dsState.HealthScore = &score
+		dsState.DegradedLocations = degradedLocations
+		states.SetDeliveryService(dsName, dsState)
+
+		lastStat, ok := lastStats.DeliveryServices[dsName]
+		if !ok {
+			continue
+		}
+		addHealthEvents(dsName, lastStat.Degraded, degraded, events)
+		lastStat.Degraded = degraded
+	}
+}
+
+// getCacheGroupHealths returns the health data of each cachegroup with caches
+// assigned to a delivery service.
+func getCacheGroupHealths(dsCaches []tc.CacheName, serverCachegroups map[tc.CacheName]tc.CacheGroupName, crStates tc.CRStates, lastStats *dsdata.LastStats, precomputed map[tc.CacheName]cache.PrecomputedData) map[tc.CacheGroupName]*cacheGroupHealth {
+	cgHealths := map[tc.CacheGroupName]*cacheGroupHealth{}
+	for _, cacheName := range dsCaches {
+		cg, ok := serverCachegroups[cacheName]
+		if !ok {
+			continue
+		}
+		cgHealth, ok := cgHealths[cg]
+		if !ok {
+			cgHealth = &cacheGroupHealth{}
+			cgHealths[cg] = cgHealth
+		}
+		if !crStates.Caches[cacheName].IsAvailable {
+			continue
+		}
+		cgHealth.AvailableCaches++
+
+		usedKbps := float64(0)
+		if lastCacheStat, ok := lastStats.Caches[cacheName]; ok {
+			usedKbps = lastCacheStat.Bytes.PerSec / BytesPerKilobit
+		}
+		if availableKbps := float64(precomputed[cacheName].MaxKbps) - usedKbps; availableKbps > 0 {
+			cgHealth.AvailableCapacityKbps += availableKbps
+		}
+	}
+	return cgHealths
+}
+
+// evalCacheGroupHealth returns why the delivery service doesn't meet its
+// health policy in a cachegroup, or the empty string if it does. The cgStat
+// may be nil, if the cachegroup has no stats for the delivery service, and
+// policy may be nil, if the delivery service has no health policy.
+func evalCacheGroupHealth(cgHealth *cacheGroupHealth, cgStat *dsdata.StatCacheStats, policy *tc.TMDeliveryServiceHealthPolicy) string {
+	minCaches := int64(DefaultMinAvailableCachesPerCacheGroup)
+	if policy != nil && policy.MinAvailableCachesPerCacheGroup != nil {
+		minCaches = *policy.MinAvailableCachesPerCacheGroup
+	}
+	if cgHealth.AvailableCaches < minCaches {
+		return fmt.Sprintf("available caches too low (%d < %d)", cgHealth.AvailableCaches, minCaches)
+	}
+	if policy == nil {
+		return ""
+	}
+
+	if policy.MaxErrorRatio != nil && cgStat != nil && cgStat.TpsTotal.Value > 0 {
+		if errorRatio := cgStat.Tps5xx.Value / cgStat.TpsTotal.Value; errorRatio > *policy.MaxErrorRatio {
+			return fmt.Sprintf("error ratio too high (%.4f > %.4f)", errorRatio, *policy.MaxErrorRatio)
+		}
+	}
+
+	if policy.MinAvailableCapacityKbps != nil && cgHealth.AvailableCapacityKbps < *policy.MinAvailableCapacityKbps {
+		return fmt.Sprintf("available capacity too low (%.2f < %.2f kbps)", cgHealth.AvailableCapacityKbps, *policy.MinAvailableCapacityKbps)
+	}
+	return ""
+}
+
+// addHealthEvents adds an event for each cachegroup in which the delivery
+// service became degraded or recovered.
+func addHealthEvents(dsName tc.DeliveryServiceName, lastDegraded map[tc.CacheGroupName]string, degraded map[tc.CacheGroupName]string, events health.ThreadsafeEvents) {
+	getEvent := func(desc string, available bool) health.Event {
+		return health.Event{
+			Time:        health.Time(time.Now()),
+			Description: desc,
+			Name:        dsName.String(),
+			Hostname:    dsName.String(),
+			Type:        "DELIVERYSERVICE",
+			Available:   available,
+		}
+	}
+	for cg, why := range degraded {
+		if _, ok := lastDegraded[cg]; !ok {
+			events.Add(getEvent("degraded in "+string(cg)+" - "+why, false))
+		}
+	}
+	for cg := range lastDegraded {
+		if _, ok := degraded[cg]; !ok {
+			events.Add(getEvent("healthy in "+string(cg), true))
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package ds
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/cache"
+	"github.com/apache/trafficcontrol/traffic_monitor/dsdata"
+	"github.com/apache/trafficcontrol/traffic_monitor/health"
+	"github.com/apache/trafficcontrol/traffic_monitor/peer"
+	"github.com/apache/trafficcontrol/traffic_monitor/todata"
+)
+
+func TestAddHealthData(t *testing.T) {
+	const dsName = tc.DeliveryServiceName("ds0")
+	minCaches := int64(2)
+	maxErrorRatio := 0.1
+	minCapacity := float64(500)
+
+	toData := todata.New()
+	toData.DeliveryServiceServers[dsName] = []tc.CacheName{"a0", "a1", "b0", "b1", "c0", "c1", "d0", "d1"}
+	for _, cacheName := range toData.DeliveryServiceServers[dsName] {
+		toData.ServerCachegroups[cacheName] = tc.CacheGroupName(cacheName[:1])
+	}
+
+	crStates := tc.NewCRStates()
+	precomputed := map[tc.CacheName]cache.PrecomputedData{}
+	lastStats := dsdata.NewLastStats(1, 8)
+	for _, cacheName := range toData.DeliveryServiceServers[dsName] {
+		crStates.Caches[cacheName] = tc.IsAvailable{IsAvailable: true}
+		precomputed[cacheName] = cache.PrecomputedData{MaxKbps: 1000}
+	}
+	// cachegroup b is missing a cache
+	crStates.Caches["b1"] = tc.IsAvailable{IsAvailable: false}
+	// cachegroup d is nearly at capacity
+	lastStats.Caches["d0"] = &dsdata.LastStatsData{Bytes: dsdata.LastStatData{PerSec: 900 * BytesPerKilobit}}
+	lastStats.Caches["d1"] = &dsdata.LastStatsData{Bytes: dsdata.LastStatData{PerSec: 900 * BytesPerKilobit}}
+	lastStats.DeliveryServices[dsName] = newLastDSStat()
+	lastStats.DeliveryServices[dsName].Degraded["a"] = "previously degraded"
+
+	dsStats := dsdata.NewStats(1)
+	stat := dsdata.NewStat()
+	// cachegroup c is serving too many errors
+	stat.CacheGroups["c"] = &dsdata.StatCacheStats{Tps5xx: dsdata.StatFloat{Value: 5}, TpsTotal: dsdata.StatFloat{Value: 10}}
+	stat.CacheGroups["a"] = &dsdata.StatCacheStats{Tps5xx: dsdata.StatFloat{Value: 0.5}, TpsTotal: dsdata.StatFloat{Value: 10}}
+	dsStats.DeliveryService[dsName] = stat
+
+	mc := tc.TrafficMonitorConfigMap{DeliveryService: map[string]tc.TMDeliveryService{
+		string(dsName): {
+			XMLID: string(dsName),
+			HealthPolicy: &tc.TMDeliveryServiceHealthPolicy{
+				MinAvailableCachesPerCacheGroup: &minCaches,
+				MaxErrorRatio:                   &maxErrorRatio,
+				MinAvailableCapacityKbps:        &minCapacity,
+			},
+		},
+	}}
+	events := health.NewThreadsafeEvents(10)
+	states := peer.NewCRStatesThreadsafe()
+
+	addHealthData(dsStats, *toData, crStates, lastStats, precomputed, mc, events, states)
+
+	if stat.CommonStats.HealthScore.Value != 0.25 {
+		t.Errorf("expected: health score 0.25, actual: %v", stat.CommonStats.HealthScore.Value)
+	}
+	expectedDegraded := []string{"b", "c", "d"}
+	if !reflect.DeepEqual(stat.CommonStats.CachesDegraded, expectedDegraded) {
+		t.Errorf("expected: degraded locations %v, actual: %v", expectedDegraded, stat.CommonStats.CachesDegraded)
+	}
+	if stat.CommonStats.HealthStr.Value == "" {
+		t.Error("expected: health string explaining degraded locations, actual: empty")
+	}
+
+	dsState, ok := states.GetDeliveryService(dsName)
+	if !ok {
+		t.Fatal("expected: delivery service in local states, actual: missing")
+	}
+	if dsState.HealthScore == nil || *dsState.HealthScore != 0.25 {
+		t.Errorf("expected: states health score 0.25, actual: %v", dsState.HealthScore)
+	}
+	if !reflect.DeepEqual(dsState.DegradedLocations, []tc.CacheGroupName{"b", "c", "d"}) {
+		t.Errorf("expected: states degraded locations [b c d], actual: %v", dsState.DegradedLocations)
+	}
+
+	// 3 newly degraded, and 1 recovered
+	if evts := events.Get(); len(evts) != 4 {
+		t.Errorf("expected: 4 events, actual: %d %+v", len(evts), evts)
+	}
+	if len(lastStats.DeliveryServices[dsName].Degraded) != 3 {
+		t.Errorf("expected: 3 last degraded locations, actual: %+v", lastStats.DeliveryServices[dsName].Degraded)
+	}
+}
+
+func TestEvalCacheGroupHealthDefaultPolicy(t *testing.T) {
+	if why := evalCacheGroupHealth(&cacheGroupHealth{AvailableCaches: 1}, nil, nil); why != "" {
+		t.Errorf("expected: cachegroup with an available cache and no policy to be healthy, actual: '%s'", why)
+	}
+	if why := evalCacheGroupHealth(&cacheGroupHealth{AvailableCaches: 0}, nil, nil); why == "" {
+		t.Error("expected: cachegroup with no available caches and no policy to be degraded, actual: healthy")
+	}
+}
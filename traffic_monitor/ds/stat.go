@@ -150,6 +150,7 @@ func newLastDSStat() *dsdata.LastDSStat {
 		CacheGroups: map[tc.CacheGroupName]*dsdata.LastStatsData{},
 		Type:        map[tc.CacheType]*dsdata.LastStatsData{},
 		Caches:      map[tc.CacheName]*dsdata.LastStatsData{},
+		Degraded:    map[tc.CacheGroupName]string{},
 	}
 }
 
@@ -459,6 +460,7 @@ func CreateStats(precomputed map[tc.CacheName]cache.PrecomputedData, toData toda
 	}
 
 	addPerSecStats(precomputed, dsStats, lastStats, toData.ServerCachegroups, toData.ServerTypes, mc, events, states)
+	addHealthData(dsStats, toData, crStates, lastStats, precomputed, mc, events, states)
 	log.Infof("CreateStats took %v\n", time.Since(start))
 	dsStats.Time = time.Now()
 	return dsStats, nil
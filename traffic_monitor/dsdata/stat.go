@@ -80,6 +80,7 @@ type StatCommonReadonly interface {
 	Healthy() StatBool
 	Available() StatBool
 	CachesAvailable() StatInt
+	Health() StatFloat
 }
 
 // StatMeta includes metadata about a particular stat.
@@ -121,6 +122,12 @@ type StatCommon struct {
 	IsAvailable         StatBool              `json:"is_available"`
 	CachesAvailableNum  StatInt               `json:"caches_available"`
 	CachesDisabled      []string              `json:"disabled_locations"`
+	// HealthScore is the fraction of the delivery service's cachegroups which meet its health policy.
+	HealthScore StatFloat `json:"health_score"`
+	// HealthStr describes why each degraded cachegroup doesn't meet the health policy.
+	HealthStr StatString `json:"health_string"`
+	// CachesDegraded are the cachegroups which don't meet the health policy.
+	CachesDegraded []string `json:"degraded_locations"`
 }
 
 // Copy returns a deep copy of this StatCommon object.
@@ -133,6 +140,10 @@ func (a StatCommon) Copy() StatCommon {
 	for i, v := range a.CachesDisabled {
 		b.CachesDisabled[i] = v
 	}
+	b.CachesDegraded = make([]string, len(a.CachesDegraded), len(a.CachesDegraded))
+	for i, v := range a.CachesDegraded {
+		b.CachesDegraded[i] = v
+	}
 	return b
 }
 
@@ -181,6 +192,11 @@ func (a StatCommon) CachesAvailable() StatInt {
 	return a.CachesAvailableNum
 }
 
+// Health returns the health score of the delivery service in this stat. It is part of the StatCommonReadonly interface.
+func (a StatCommon) Health() StatFloat {
+	return a.HealthScore
+}
+
 // StatCacheStats is all the stats generated by a cache.
 // This may also be used for aggregate stats, for example, the summary of all cache stats for a cache group, or delivery service.
 // Each stat is an array, in case there are multiple data points at different times. However, a single data point i.e. a single array member is common.
@@ -373,6 +389,8 @@ type LastDSStat struct {
 	Type        map[tc.CacheType]*LastStatsData
 	Total       LastStatsData
 	Available   bool
+	// Degraded maps the cachegroups which didn't meet the health policy to why.
+	Degraded map[tc.CacheGroupName]string
 }
 
 // Copy performs a deep copy of this LastDSStat object.
@@ -383,6 +401,10 @@ func (a LastDSStat) Copy() *LastDSStat {
 		Caches:      map[tc.CacheName]*LastStatsData{},
 		Total:       a.Total,
 		Available:   a.Available,
+		Degraded:    map[tc.CacheGroupName]string{},
+	}
+	for k, v := range a.Degraded {
+		b.Degraded[k] = v
 	}
 	for k, v := range a.CacheGroups {
 		b.CacheGroups[k] = v
@@ -441,6 +463,9 @@ func addCommonData(s *StatsOld, c *StatCommon, deliveryService tc.DeliveryServic
 	add("isAvailable", fmt.Sprintf("%t", c.IsAvailable.Value))
 	add("caches-available", fmt.Sprintf("%d", c.CachesAvailableNum.Value))
 	add("disabledLocations", c.CachesDisabled)
+	add("healthScore", fmt.Sprintf("%f", c.HealthScore.Value))
+	add("health-string", c.HealthStr.Value)
+	add("degradedLocations", c.CachesDegraded)
 	return s
 }
 
@@ -35,6 +35,7 @@ import (
 	"github.com/apache/trafficcontrol/traffic_monitor/health"
 	"github.com/apache/trafficcontrol/traffic_monitor/peer"
 	"github.com/apache/trafficcontrol/traffic_monitor/poller"
+	"github.com/apache/trafficcontrol/traffic_monitor/record"
 	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
 	"github.com/apache/trafficcontrol/traffic_monitor/todata"
 	"github.com/apache/trafficcontrol/traffic_monitor/towrap"
@@ -44,7 +45,28 @@ import (
 // Start starts the poller and handler goroutines
 //
 func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData, trafficMonitorConfigFileName string) error {
+	if cfg.RecordFile != "" && cfg.ReplayFile != "" {
+		return fmt.Errorf("record_file and replay_file can't both be set")
+	}
+	recorder, err := record.NewRecorder(cfg.RecordFile)
+	if err != nil {
+		return fmt.Errorf("starting recording: %v", err)
+	}
+	if recorder != nil {
+		defer closeRecorder(recorder)
+		go closeRecorderOnSignal(recorder)
+	}
+	player, err := record.NewPlayer(cfg.ReplayFile, cfg.ReplaySpeed)
+	if err != nil {
+		return fmt.Errorf("loading replay: %v", err)
+	}
+	if player != nil {
+		log.Infof("replaying %s at %vx speed\n", cfg.ReplayFile, cfg.ReplaySpeed)
+	}
+
 	toSession := towrap.NewTrafficOpsSessionThreadsafe(nil, nil, cfg.CRConfigHistoryCount, cfg)
+	toSession.Recorder = recorder
+	toSession.Replay = player
 
 	localStates := peer.NewCRStatesThreadsafe() // this is the local state as discoverer by this traffic_monitor
 	fetchCount := threadsafe.NewUint()          // note this is the number of individual caches fetched from, not the number of times all the caches were polled.
@@ -61,6 +83,10 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 	peerHandler := peer.NewHandler()
 	peerPoller := poller.NewCache(cfg.PeerPollingInterval, false, peerHandler, cfg, appData, cfg.PeerPollingProtocol)
 
+	cacheHealthPoller.Kind, cacheHealthPoller.Recorder, cacheHealthPoller.Replay = record.KindHealth, recorder, player
+	cacheStatPoller.Kind, cacheStatPoller.Recorder, cacheStatPoller.Replay = record.KindStat, recorder, player
+	peerPoller.Kind, peerPoller.Recorder, peerPoller.Replay = record.KindPeer, recorder, player
+
 	go monitorConfigPoller.Poll()
 	go cacheHealthPoller.Poll()
 	go cacheStatPoller.Poll()
@@ -95,7 +121,7 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 		combineStateFunc,
 	)
 
-	statInfoHistory, statResultHistory, statMaxKbpses, _, lastKbpsStats, dsStats, unpolledCaches, localCacheStatus := StartStatHistoryManager(
+	statInfoHistory, statResultHistory, statMaxKbpses, _, lastKbpsStats, dsStats, unpolledCaches, localCacheStatus, bandwidthHistory := StartStatHistoryManager(
 		cacheStatHandler.ResultChan(),
 		localStates,
 		combinedStates,
@@ -146,6 +172,7 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 		localCacheStatus,
 		unpolledCaches,
 		monitorConfig,
+		bandwidthHistory,
 		cfg,
 	)
 
@@ -211,3 +238,24 @@ func ipv6CIDRStrToAddr(cidr string) string {
 	}
 	return cidr[:i]
 }
+
+// closeRecorder finishes the recording, logging any error.
+func closeRecorder(recorder *record.Recorder) {
+	if err := recorder.Close(); err != nil {
+		log.Errorln("finishing recording: " + err.Error())
+	}
+}
+
+// closeRecorderOnSignal finishes the recording when Traffic Monitor is
+// interrupted or terminated, then lets the signal terminate it.
+func closeRecorderOnSignal(recorder *record.Recorder) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, unix.SIGINT, unix.SIGTERM)
+	sig := <-sigs
+	log.Infoln("received " + sig.String() + ", finishing recording")
+	closeRecorder(recorder)
+	signal.Stop(sigs)
+	if p, err := os.FindProcess(os.Getpid()); err == nil {
+		p.Signal(sig)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -281,7 +282,12 @@ func monitorConfigListen(
 
 			statURL4 := createServerStatPollURL(pollURL4Str)
 			statURL6 := createServerStatPollURL(pollURL6Str)
-			statURLs[srv.HostName] = poller.PollConfig{URL: statURL4, URLv6: statURL6, Host: srv.FQDN, Timeout: connTimeout, Format: format, PollType: pollType}
+			statPollCfg := poller.PollConfig{URL: statURL4, URLv6: statURL6, Host: srv.FQDN, Timeout: connTimeout, Format: format, PollType: pollType}
+			if pollType == poller.PollerTypeProbe {
+				// only stat polls probe, because only stat results are checked against thresholds.
+				statPollCfg.Probes = getServerProbes(cacheName, toData.Get(), monitorConfig)
+			}
+			statURLs[srv.HostName] = statPollCfg
 		}
 
 		peerSet := map[tc.TrafficMonitorName]struct{}{}
@@ -334,6 +340,29 @@ func monitorConfigListen(
 	}
 }
 
+// getServerProbes returns the probes of all Delivery Services assigned to the
+// given cache server, sorted so unchanged probes compare equal across monitor
+// config updates.
+func getServerProbes(cacheName tc.CacheName, toData todata.TOData, monitorConfig tc.TrafficMonitorConfigMap) []poller.ProbeConfig {
+	probes := []poller.ProbeConfig{}
+	for _, dsName := range toData.ServerDeliveryServices[cacheName] {
+		ds, ok := monitorConfig.DeliveryService[string(dsName)]
+		if !ok {
+			continue
+		}
+		for _, probeURL := range ds.ProbeURLs {
+			probes = append(probes, poller.ProbeConfig{DeliveryService: ds.XMLID, URL: probeURL})
+		}
+	}
+	sort.Slice(probes, func(i, j int) bool {
+		if probes[i].DeliveryService != probes[j].DeliveryService {
+			return probes[i].DeliveryService < probes[j].DeliveryService
+		}
+		return probes[i].URL < probes[j].URL
+	})
+	return probes
+}
+
 // createServerHealthPollURLs takes the template pollingURLStr, and replaces
 // variables with data from srv, and returns the polling URL for srv.
 //
@@ -29,6 +29,7 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_monitor/bandwidth"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
 	"github.com/apache/trafficcontrol/traffic_monitor/datareq"
 	"github.com/apache/trafficcontrol/traffic_monitor/handler"
@@ -69,6 +70,7 @@ func StartOpsConfigManager(
 	localCacheStatus threadsafe.CacheAvailableStatus,
 	unpolledCaches threadsafe.UnpolledCaches,
 	monitorConfig threadsafe.TrafficMonitorConfigMap,
+	bandwidthHistory bandwidth.ThreadsafeHistory,
 	cfg config.Config,
 ) (threadsafe.OpsConfig, error) {
 
@@ -126,6 +128,7 @@ func StartOpsConfigManager(
 			lastStats,
 			unpolledCaches,
 			monitorConfig,
+			bandwidthHistory,
 		)
 
 		// If the HTTPS Listener is defined in the traffic_ops.cfg file then it creates the HTTPS endpoint and the corresponding HTTP endpoint as a redirect
@@ -149,6 +149,8 @@ func combineDSState(
 		deliveryService.IsAvailable = true
 	}
 	deliveryService.DisabledLocations = localDeliveryService.DisabledLocations
+	deliveryService.HealthScore = localDeliveryService.HealthScore
+	deliveryService.DegradedLocations = localDeliveryService.DegradedLocations
 
 	for peerName, iPeerStates := range peerStates.GetCrstates() {
 		peerDeliveryService, ok := iPeerStates.DeliveryService[deliveryServiceName]
@@ -160,6 +162,20 @@ func combineDSState(
 			deliveryService.IsAvailable = true
 		}
 		deliveryService.DisabledLocations = intersection(deliveryService.DisabledLocations, peerDeliveryService.DisabledLocations)
+		if peerDeliveryService.HealthScore == nil {
+			continue
+		}
+		// Degraded locations are only intersected with monitors which have health data; a monitor without any
+		// (e.g. one with no policy for the DS yet) would otherwise clear them.
+		if deliveryService.HealthScore == nil {
+			deliveryService.DegradedLocations = peerDeliveryService.DegradedLocations
+		} else {
+			deliveryService.DegradedLocations = intersection(deliveryService.DegradedLocations, peerDeliveryService.DegradedLocations)
+		}
+		// like availability, health is optimistic: the DS is as healthy as the healthiest monitor sees it
+		if deliveryService.HealthScore == nil || *peerDeliveryService.HealthScore > *deliveryService.HealthScore {
+			deliveryService.HealthScore = peerDeliveryService.HealthScore
+		}
 	}
 	combinedStates.SetDeliveryService(deliveryServiceName, deliveryService)
 }
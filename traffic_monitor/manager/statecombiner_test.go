@@ -154,3 +154,88 @@ func TestCombineCacheStateCacheDown(t *testing.T) {
 		t.Fatalf("cache IPv6 is unavailable and should be available")
 	}
 }
+
+func TestCombineDSStateHealth(t *testing.T) {
+	dsName := tc.DeliveryServiceName("testDS")
+	localScore := 0.5
+	peerScore := 0.75
+	localDS := tc.CRStatesDeliveryService{
+		IsAvailable:       true,
+		DisabledLocations: []tc.CacheGroupName{},
+		HealthScore:       &localScore,
+		DegradedLocations: []tc.CacheGroupName{"cgA", "cgB"},
+	}
+
+	peerStates := peer.NewCRStatesPeersThreadsafe(1)
+	peerStates.Set(peer.Result{
+		ID:        tc.TrafficMonitorName("TestTM-01"),
+		Available: true,
+		PeerStates: tc.CRStates{
+			DeliveryService: map[tc.DeliveryServiceName]tc.CRStatesDeliveryService{
+				dsName: tc.CRStatesDeliveryService{
+					IsAvailable:       true,
+					DisabledLocations: []tc.CacheGroupName{},
+					HealthScore:       &peerScore,
+					DegradedLocations: []tc.CacheGroupName{"cgB"},
+				},
+			},
+		},
+		Time: time.Now(),
+	})
+	peerStates.SetPeers(map[tc.TrafficMonitorName]struct{}{tc.TrafficMonitorName("TestTM-01"): struct{}{}})
+
+	combinedStates := peer.NewCRStatesThreadsafe()
+	combineDSState(dsName, localDS, peerStates, combinedStates)
+
+	combinedDS, ok := combinedStates.GetDeliveryService(dsName)
+	if !ok {
+		t.Fatalf("expected: combined delivery service, actual: missing")
+	}
+	if combinedDS.HealthScore == nil || *combinedDS.HealthScore != peerScore {
+		t.Errorf("expected: combined health score to be the highest score %v, actual: %v", peerScore, combinedDS.HealthScore)
+	}
+	if len(combinedDS.DegradedLocations) != 1 || combinedDS.DegradedLocations[0] != "cgB" {
+		t.Errorf("expected: combined degraded locations to be those degraded for every monitor [cgB], actual: %v", combinedDS.DegradedLocations)
+	}
+}
+
+func TestCombineDSStateHealthNoLocalData(t *testing.T) {
+	dsName := tc.DeliveryServiceName("testDS")
+	peerScore := 0.75
+	localDS := tc.CRStatesDeliveryService{
+		IsAvailable:       true,
+		DisabledLocations: []tc.CacheGroupName{},
+	}
+
+	peerStates := peer.NewCRStatesPeersThreadsafe(1)
+	peerStates.Set(peer.Result{
+		ID:        tc.TrafficMonitorName("TestTM-01"),
+		Available: true,
+		PeerStates: tc.CRStates{
+			DeliveryService: map[tc.DeliveryServiceName]tc.CRStatesDeliveryService{
+				dsName: tc.CRStatesDeliveryService{
+					IsAvailable:       true,
+					DisabledLocations: []tc.CacheGroupName{},
+					HealthScore:       &peerScore,
+					DegradedLocations: []tc.CacheGroupName{"cgB"},
+				},
+			},
+		},
+		Time: time.Now(),
+	})
+	peerStates.SetPeers(map[tc.TrafficMonitorName]struct{}{tc.TrafficMonitorName("TestTM-01"): struct{}{}})
+
+	combinedStates := peer.NewCRStatesThreadsafe()
+	combineDSState(dsName, localDS, peerStates, combinedStates)
+
+	combinedDS, ok := combinedStates.GetDeliveryService(dsName)
+	if !ok {
+		t.Fatalf("expected: combined delivery service, actual: missing")
+	}
+	if combinedDS.HealthScore == nil || *combinedDS.HealthScore != peerScore {
+		t.Errorf("expected: combined health score to be the peer's score %v, actual: %v", peerScore, combinedDS.HealthScore)
+	}
+	if len(combinedDS.DegradedLocations) != 1 || combinedDS.DegradedLocations[0] != "cgB" {
+		t.Errorf("expected: combined degraded locations to be the peer's [cgB] when there's no local health data, actual: %v", combinedDS.DegradedLocations)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"bytes"
 	"io"
 	"math/rand"
+	"reflect"
 	"runtime"
 	"sync/atomic"
 	"time"
@@ -30,6 +31,7 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
 	"github.com/apache/trafficcontrol/traffic_monitor/handler"
+	"github.com/apache/trafficcontrol/traffic_monitor/record"
 )
 
 type CachePoller struct {
@@ -38,6 +40,13 @@ type CachePoller struct {
 	TickChan       chan uint64
 	GlobalContexts map[string]interface{}
 	Handler        handler.Handler
+	// Kind is the kind of data polled, under which polls are recorded and
+	// replayed.
+	Kind record.Kind
+	// Recorder, if not nil, records every poll response.
+	Recorder *record.Recorder
+	// Replay, if not nil, replaces polling with replaying recorded responses.
+	Replay *record.Player
 }
 
 type PollConfig struct {
@@ -47,6 +56,7 @@ type PollConfig struct {
 	Timeout  time.Duration
 	Format   string
 	PollType string
+	Probes   []ProbeConfig
 }
 
 type CachePollerConfig struct {
@@ -121,12 +131,21 @@ func (p CachePoller) Poll() {
 				Timeout:     info.Timeout,
 				NoKeepAlive: info.NoKeepAlive,
 				PollerID:    info.ID,
+				Probes:      info.Probes,
 			}
 			pollerCtx := interface{}(nil)
 			if pollerObj.Init != nil {
 				pollerCtx = pollerObj.Init(pollerCfg, p.GlobalContexts[info.PollType])
 			}
-			go poller(info.Interval, info.ID, info.PollingProtocol, info.URL, info.URLv6, info.Host, info.Format, p.Handler, pollerObj.Poll, pollerCtx, kill)
+			pollFunc := pollerObj.Poll
+			interval := info.Interval
+			if p.Replay != nil {
+				pollFunc = replayPollFunc(p.Replay, p.Kind, info.ID)
+				interval = p.Replay.Interval(interval)
+			} else if p.Recorder != nil {
+				pollFunc = recordPollFunc(p.Recorder, p.Kind, info.ID, pollFunc)
+			}
+			go poller(interval, info.ID, info.PollingProtocol, info.URL, info.URLv6, info.Host, info.Format, p.Handler, pollFunc, pollerCtx, kill)
 		}
 		p.Config = newConfig
 	}
@@ -187,6 +206,12 @@ func poller(
 			}
 
 			bts, reqEnd, reqTime, err := pollFunc(pollCtx, pollUrl, host, pollID)
+			if err == record.ErrReplayFinished {
+				log.Infoln("poll " + id + ": replay finished")
+				tick.Stop()
+				<-die
+				return
+			}
 			rdr := io.Reader(nil)
 			if bts != nil {
 				rdr = bytes.NewReader(bts) // TODO change handler to take bytes? Benchmark?
@@ -232,7 +257,7 @@ func diffConfigs(old CachePollerConfig, new CachePollerConfig) ([]string, []Cach
 		newPollCfg, newIdExists := new.Urls[id]
 		if !newIdExists {
 			deletions = append(deletions, id)
-		} else if newPollCfg != oldPollCfg {
+		} else if !reflect.DeepEqual(newPollCfg, oldPollCfg) {
 			deletions = append(deletions, id)
 			additions = append(additions, CachePollInfo{
 				Interval:        new.Interval,
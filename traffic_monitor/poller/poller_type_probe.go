@@ -0,0 +1,311 @@
+package poller
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+)
+
+// PollerTypeProbe is a poller which polls cache stats exactly like the HTTP
+// poller, and additionally makes real requests for each of the cache's
+// configured Delivery Service probe URLs through the cache, adding the
+// results to the polled stats.
+const PollerTypeProbe = "probe"
+
+// ProbeStatPrefix is the prefix of the names of all stats produced by the
+// probe poller.
+const ProbeStatPrefix = "probe."
+
+// These are the names of the probe stats aggregated over all of a cache
+// server's probes, which may be given thresholds with the
+// "health.threshold.probe.*" Parameters.
+const (
+	ProbeStatCount      = ProbeStatPrefix + "count"
+	ProbeStatFailures   = ProbeStatPrefix + "failures"
+	ProbeStatMaxTTFBMS  = ProbeStatPrefix + "maxTtfbMs"
+	ProbeStatMinKbps    = ProbeStatPrefix + "minKbps"
+	probeStatDSFailures = "failures"
+	probeStatDSStatus   = "status"
+	probeStatDSTTFBMS   = "ttfbMs"
+	probeStatDSKbps     = "kbps"
+	probeStatDSError    = "error"
+)
+
+// probeMaxBodyBytes is the most bytes of a probe response body which will be
+// read. Larger objects are truncated, and the throughput is calculated from
+// the bytes read.
+const probeMaxBodyBytes = 10 * 1024 * 1024
+
+// probeMaxConcurrency is the maximum number of probe requests made through a
+// single cache server at once.
+const probeMaxConcurrency = 8
+
+type probeDialAddrKey struct{}
+
+func init() {
+	AddPollerType(PollerTypeProbe, probeGlobalInit, probeInit, probePoll)
+}
+
+// ProbeConfig is a single synthetic request for a Delivery Service's content,
+// made through a cache server by the probe poller.
+type ProbeConfig struct {
+	DeliveryService string
+	URL             string
+}
+
+type ProbePollGlobalCtx struct {
+	HTTP        *HTTPPollGlobalCtx
+	ProbeClient *http.Client
+}
+
+// ProbePollCtx is the context of a single probe poller. It embeds the context
+// of the HTTP poller used to poll the cache's stats, which is what the stats
+// parsers receive.
+type ProbePollCtx struct {
+	*HTTPPollCtx
+	ProbeClient *http.Client
+	Probes      []ProbeConfig
+	// ProbeStats holds the stats produced by the last poll's probes. It is
+	// replaced, never modified, by each poll.
+	ProbeStats map[string]interface{}
+}
+
+func probeGlobalInit(cfg config.Config, appData config.StaticAppData) interface{} {
+	dialer := &net.Dialer{Timeout: cfg.HTTPTimeout}
+	transport := &http.Transport{
+		// Probe requests are made to the Delivery Service's URL, so the Host
+		// header and TLS SNI are the Delivery Service's, but the connection
+		// is made to the cache server being polled.
+		DialContext: func(ctx context.Context, network string, addr string) (net.Conn, error) {
+			if dialAddr, ok := ctx.Value(probeDialAddrKey{}).(string); ok {
+				addr = dialAddr
+			}
+			return dialer.DialContext(ctx, network, addr)
+		},
+		// Connections are never reused, so every probe verifies a full
+		// connection and handshake with the cache.
+		DisableKeepAlives: true,
+	}
+	return &ProbePollGlobalCtx{
+		HTTP: httpGlobalInit(cfg, appData).(*HTTPPollGlobalCtx),
+		ProbeClient: &http.Client{
+			Transport: transport,
+			Timeout:   cfg.HTTPTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse // the cache's redirect is the probe result, not wherever it points.
+			},
+		},
+	}
+}
+
+func probeInit(cfg PollerConfig, globalCtxI interface{}) interface{} {
+	gctx := (globalCtxI).(*ProbePollGlobalCtx)
+	probeClient := gctx.ProbeClient
+	if cfg.Timeout != 0 {
+		clientCopy := *probeClient
+		clientCopy.Timeout = cfg.Timeout
+		probeClient = &clientCopy
+	}
+	return &ProbePollCtx{
+		HTTPPollCtx: httpInit(cfg, gctx.HTTP).(*HTTPPollCtx),
+		ProbeClient: probeClient,
+		Probes:      cfg.Probes,
+		ProbeStats:  map[string]interface{}{},
+	}
+}
+
+func probePoll(ctxI interface{}, pollURL string, host string, pollID uint64) ([]byte, time.Time, time.Duration, error) {
+	ctx := (ctxI).(*ProbePollCtx)
+	bts, reqEnd, reqTime, err := httpPoll(ctx.HTTPPollCtx, pollURL, host, pollID)
+	if err != nil || len(ctx.Probes) == 0 {
+		ctx.ProbeStats = map[string]interface{}{}
+		return bts, reqEnd, reqTime, err
+	}
+
+	cacheAddr, err := url.Parse(pollURL)
+	if err != nil {
+		return nil, reqEnd, reqTime, fmt.Errorf("id %v url %v probe error: parsing poll URL: %v", ctx.PollerID, pollURL, err)
+	}
+	ctx.ProbeStats = probeStats(runProbes(ctx.ProbeClient, cacheAddr.Hostname(), ctx.Probes, ctx.UserAgent))
+	return bts, reqEnd, reqTime, nil
+}
+
+// ProbeResult is the result of a single probe request.
+type ProbeResult struct {
+	ProbeConfig
+	Status int
+	TTFB   time.Duration
+	Bytes  int64
+	Time   time.Duration
+	Error  error
+}
+
+// Failed returns whether the probe failed to successfully get a response from
+// the cache. Redirects are considered successful, since the cache served the
+// response the Delivery Service is configured to serve.
+func (r ProbeResult) Failed() bool {
+	return r.Error != nil || r.Status < http.StatusOK || r.Status >= http.StatusBadRequest
+}
+
+// Kbps returns the throughput of the probe's response body, in kilobits per
+// second.
+func (r ProbeResult) Kbps() float64 {
+	if r.Time <= 0 {
+		return 0
+	}
+	return float64(r.Bytes*8) / 1000 / r.Time.Seconds()
+}
+
+// runProbes makes each probe request through the cache at cacheHost, and
+// returns the results in the same order as probes.
+func runProbes(client *http.Client, cacheHost string, probes []ProbeConfig, userAgent string) []ProbeResult {
+	results := make([]ProbeResult, len(probes))
+	sem := make(chan struct{}, probeMaxConcurrency)
+	wg := sync.WaitGroup{}
+	for i, probe := range probes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, probe ProbeConfig) {
+			defer func() { <-sem; wg.Done() }()
+			results[i] = runProbe(client, cacheHost, probe, userAgent)
+		}(i, probe)
+	}
+	wg.Wait()
+	return results
+}
+
+func runProbe(client *http.Client, cacheHost string, probe ProbeConfig, userAgent string) ProbeResult {
+	result := ProbeResult{ProbeConfig: probe}
+
+	probeURL, err := url.Parse(probe.URL)
+	if err != nil {
+		result.Error = errors.New("parsing probe URL: " + err.Error())
+		return result
+	}
+	port := probeURL.Port()
+	if port == "" {
+		port = "80"
+		if probeURL.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	start := time.Now()
+	trace := &httptrace.ClientTrace{
+		GotFirstResponseByte: func() { result.TTFB = time.Since(start) },
+	}
+	reqCtx := context.WithValue(httptrace.WithClientTrace(context.Background(), trace), probeDialAddrKey{}, net.JoinHostPort(cacheHost, port))
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, probe.URL, nil)
+	if err != nil {
+		result.Error = errors.New("creating probe request: " + err.Error())
+		return result
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Time = time.Since(start)
+		result.Error = err
+		return result
+	}
+	defer resp.Body.Close()
+	result.Status = resp.StatusCode
+
+	result.Bytes, err = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, probeMaxBodyBytes))
+	result.Time = time.Since(start)
+	if err != nil {
+		result.Error = errors.New("reading probe response body: " + err.Error())
+	}
+	if result.Failed() {
+		log.Infof("probe of delivery service '%v' url '%v' through cache '%v' failed: status %v error %v", probe.DeliveryService, probe.URL, cacheHost, result.Status, result.Error)
+	}
+	return result
+}
+
+// probeStats turns probe results into stats, both per-Delivery Service and
+// aggregated over all of the cache server's probes.
+//
+// Per-Delivery Service stats are aggregated over all of that Delivery Service's
+// probe URLs. The status is that of the first failed probe, or the first probe
+// if none failed.
+func probeStats(results []ProbeResult) map[string]interface{} {
+	stats := map[string]interface{}{}
+	failures := int64(0)
+	maxTTFBMS := int64(0)
+	minKbps := float64(0)
+	dsFailed := map[string]bool{}
+	for i, result := range results {
+		dsPrefix := ProbeStatPrefix + result.DeliveryService + "."
+		ttfbMS := int64(result.TTFB / time.Millisecond)
+		kbps := result.Kbps()
+
+		if _, ok := stats[dsPrefix+probeStatDSFailures]; !ok {
+			stats[dsPrefix+probeStatDSFailures] = int64(0)
+			stats[dsPrefix+probeStatDSStatus] = int64(result.Status)
+			stats[dsPrefix+probeStatDSTTFBMS] = ttfbMS
+			stats[dsPrefix+probeStatDSKbps] = kbps
+			stats[dsPrefix+probeStatDSError] = ""
+		}
+		if dsTTFB := stats[dsPrefix+probeStatDSTTFBMS].(int64); ttfbMS > dsTTFB {
+			stats[dsPrefix+probeStatDSTTFBMS] = ttfbMS
+		}
+		if dsKbps := stats[dsPrefix+probeStatDSKbps].(float64); kbps < dsKbps {
+			stats[dsPrefix+probeStatDSKbps] = kbps
+		}
+		if result.Failed() {
+			failures++
+			stats[dsPrefix+probeStatDSFailures] = stats[dsPrefix+probeStatDSFailures].(int64) + 1
+			if !dsFailed[result.DeliveryService] {
+				dsFailed[result.DeliveryService] = true
+				stats[dsPrefix+probeStatDSStatus] = int64(result.Status)
+				if result.Error != nil {
+					stats[dsPrefix+probeStatDSError] = result.Error.Error()
+				} else {
+					stats[dsPrefix+probeStatDSError] = "bad HTTP status: " + fmt.Sprint(result.Status)
+				}
+			}
+		}
+
+		if ttfbMS > maxTTFBMS {
+			maxTTFBMS = ttfbMS
+		}
+		if i == 0 || kbps < minKbps {
+			minKbps = kbps
+		}
+	}
+	stats[ProbeStatCount] = int64(len(results))
+	stats[ProbeStatFailures] = failures
+	stats[ProbeStatMaxTTFBMS] = maxTTFBMS
+	stats[ProbeStatMinKbps] = minKbps
+	return stats
+}
@@ -0,0 +1,131 @@
+package poller
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+)
+
+func TestProbePoll(t *testing.T) {
+	const statsBody = `{"ats":{}}`
+	const objBody = "probe object"
+	cache := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/_astats":
+			w.Write([]byte(statsBody))
+		case strings.HasPrefix(r.Host, "good.example.net:"):
+			w.Write([]byte(objBody))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer cache.Close()
+
+	cacheURL, err := url.Parse(cache.URL)
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	port := cacheURL.Port()
+
+	gctx := probeGlobalInit(config.Config{HTTPTimeout: time.Second}, config.StaticAppData{UserAgent: "test"})
+	ctx := probeInit(PollerConfig{
+		URL:      cache.URL + "/_astats",
+		PollerID: "cache0",
+		Probes: []ProbeConfig{
+			{DeliveryService: "bad", URL: "http://bad.example.net:" + port + "/obj"},
+			{DeliveryService: "good", URL: "http://good.example.net:" + port + "/obj"},
+		},
+	}, gctx).(*ProbePollCtx)
+
+	bts, _, _, err := probePoll(ctx, cache.URL+"/_astats", "cache0.example.net", 1)
+	if err != nil {
+		t.Fatalf("expected: no poll error, actual: %v", err)
+	}
+	if string(bts) != statsBody {
+		t.Errorf("expected: poll to return stats body '%s', actual: '%s'", statsBody, string(bts))
+	}
+
+	expected := map[string]interface{}{
+		ProbeStatCount:        int64(2),
+		ProbeStatFailures:     int64(1),
+		"probe.bad.failures":  int64(1),
+		"probe.bad.status":    int64(http.StatusNotFound),
+		"probe.bad.error":     "bad HTTP status: 404",
+		"probe.good.failures": int64(0),
+		"probe.good.status":   int64(http.StatusOK),
+		"probe.good.error":    "",
+		"probe.good.ttfbMs":   nil,
+		"probe.good.kbps":     nil,
+		ProbeStatMaxTTFBMS:    nil,
+		ProbeStatMinKbps:      nil,
+		"probe.bad.ttfbMs":    nil,
+		"probe.bad.kbps":      nil,
+	}
+	for stat, expectedVal := range expected {
+		actualVal, ok := ctx.ProbeStats[stat]
+		if !ok {
+			t.Errorf("expected: stat '%s', actual: missing", stat)
+			continue
+		}
+		if expectedVal != nil && actualVal != expectedVal {
+			t.Errorf("expected: stat '%s' value %v, actual: %v", stat, expectedVal, actualVal)
+		}
+	}
+	if len(ctx.ProbeStats) != len(expected) {
+		t.Errorf("expected: %d stats, actual: %d %+v", len(expected), len(ctx.ProbeStats), ctx.ProbeStats)
+	}
+}
+
+func TestProbeStats(t *testing.T) {
+	results := []ProbeResult{
+		{ProbeConfig: ProbeConfig{DeliveryService: "a"}, Status: 200, TTFB: 5 * time.Millisecond, Bytes: 1000, Time: time.Second},
+		{ProbeConfig: ProbeConfig{DeliveryService: "a"}, Status: 302, TTFB: 20 * time.Millisecond, Bytes: 4000, Time: time.Second},
+		{ProbeConfig: ProbeConfig{DeliveryService: "b"}, Error: errors.New("connection refused")},
+	}
+	stats := probeStats(results)
+
+	expected := map[string]interface{}{
+		ProbeStatCount:     int64(3),
+		ProbeStatFailures:  int64(1),
+		ProbeStatMaxTTFBMS: int64(20),
+		ProbeStatMinKbps:   float64(0),
+		"probe.a.failures": int64(0),
+		"probe.a.status":   int64(200),
+		"probe.a.ttfbMs":   int64(20),
+		"probe.a.kbps":     float64(8),
+		"probe.a.error":    "",
+		"probe.b.failures": int64(1),
+		"probe.b.status":   int64(0),
+		"probe.b.error":    "connection refused",
+	}
+	for stat, expectedVal := range expected {
+		if actualVal := stats[stat]; actualVal != expectedVal {
+			t.Errorf("expected: stat '%s' value %v (%T), actual: %v (%T)", stat, expectedVal, expectedVal, actualVal, actualVal)
+		}
+	}
+}
@@ -42,6 +42,9 @@ type PollerConfig struct {
 	Timeout     time.Duration
 	NoKeepAlive bool
 	PollerID    string
+	// Probes are the Delivery Service probes made through the cache by
+	// pollers which support them. Other pollers ignore them.
+	Probes []ProbeConfig
 }
 
 // PollerGlobalInit performs global initialization, and returns a global context object.
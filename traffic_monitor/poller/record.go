@@ -0,0 +1,57 @@
+package poller
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/traffic_monitor/record"
+)
+
+// recordPollFunc returns a PollerFunc which polls with pollFunc, and records
+// each response to the recorder.
+func recordPollFunc(recorder *record.Recorder, kind record.Kind, id string, pollFunc PollerFunc) PollerFunc {
+	return func(ctx interface{}, url string, host string, pollID uint64) ([]byte, time.Time, time.Duration, error) {
+		bts, reqEnd, reqTime, err := pollFunc(ctx, url, host, pollID)
+		e := record.Entry{Kind: kind, ID: id, URL: url, Time: reqEnd, ReqTime: reqTime, Body: bts}
+		if err != nil {
+			e.Err = err.Error()
+		}
+		if recErr := recorder.Record(e); recErr != nil {
+			log.Errorln("recording " + string(kind) + " poll of '" + id + "': " + recErr.Error())
+		}
+		return bts, reqEnd, reqTime, err
+	}
+}
+
+// replayPollFunc returns a PollerFunc which, rather than polling, returns the
+// next recorded response of the given poller, with its time shifted onto the
+// replay clock. When all of them have been returned, it returns
+// record.ErrReplayFinished.
+func replayPollFunc(player *record.Player, kind record.Kind, id string) PollerFunc {
+	return func(ctx interface{}, url string, host string, pollID uint64) ([]byte, time.Time, time.Duration, error) {
+		e, err := player.Next(kind, id)
+		if err != nil {
+			return nil, time.Now(), 0, err
+		}
+		return e.Body, player.Shift(e.Time), e.ReqTime, e.Error()
+	}
+}
@@ -0,0 +1,160 @@
+package record
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrReplayFinished is returned by Player.Next when every recorded response of
+// a poller has been replayed, or none were recorded.
+var ErrReplayFinished = errors.New("replay finished")
+
+type streamKey struct {
+	Kind Kind
+	ID   string
+}
+
+// Player replays the entries of an archive. Poll responses are replayed in
+// the order they were recorded, one per poll, so a poller replays the same
+// responses however fast it polls. Traffic Ops data is replayed by time, on a
+// clock starting at the first recorded entry and running Speed times faster
+// than real time. This is safe for multiple goroutines.
+type Player struct {
+	Speed   float64
+	start   time.Time
+	began   time.Time
+	streams map[streamKey][]Entry
+	next    map[streamKey]int
+	m       *sync.Mutex
+}
+
+// NewPlayer creates a Player replaying the archive at the given path at the
+// given speed. If path is empty, the returned Player is nil.
+func NewPlayer(path string, speed float64) (*Player, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if speed <= 0 {
+		return nil, errors.New("replay speed must be positive")
+	}
+	entries, err := ReadArchive(path)
+	if err != nil {
+		return nil, err
+	}
+	return newPlayer(entries, speed, time.Now()), nil
+}
+
+func newPlayer(entries []Entry, speed float64, began time.Time) *Player {
+	p := &Player{
+		Speed:   speed,
+		began:   began,
+		streams: map[streamKey][]Entry{},
+		next:    map[streamKey]int{},
+		m:       &sync.Mutex{},
+	}
+	for _, e := range entries {
+		if p.start.IsZero() || e.Time.Before(p.start) {
+			p.start = e.Time
+		}
+		key := streamKey{Kind: e.Kind, ID: e.ID}
+		p.streams[key] = append(p.streams[key], e)
+	}
+	// Traffic Ops data is looked up by time, so it must be sorted by it. Polls are
+	// replayed in recorded order, which concurrent handlers may not have written
+	// in time order.
+	for key, stream := range p.streams {
+		if key.Kind == KindCRConfig || key.Kind == KindMonitoring {
+			sort.SliceStable(stream, func(i, j int) bool { return stream[i].Time.Before(stream[j].Time) })
+		}
+	}
+	return p
+}
+
+// Interval returns the given real poll interval, shortened by the replay speed.
+func (p *Player) Interval(interval time.Duration) time.Duration {
+	return time.Duration(float64(interval) / p.Speed)
+}
+
+// Shift returns the given recorded time moved onto the replay clock: by the
+// time between the start of the recording and the start of the replay. The
+// intervals between recorded times are kept, so values calculated from them,
+// like bandwidth, are the same as when recorded, regardless of the speed.
+func (p *Player) Shift(t time.Time) time.Time {
+	return t.Add(p.began.Sub(p.start))
+}
+
+// Now returns the current time of the replay, in the time of the recording.
+func (p *Player) Now() time.Time {
+	return p.now(time.Now())
+}
+
+func (p *Player) now(realNow time.Time) time.Time {
+	return p.start.Add(time.Duration(float64(realNow.Sub(p.began)) * p.Speed))
+}
+
+// Next returns the next recorded response of the given kind and ID, or
+// ErrReplayFinished if there are no more.
+func (p *Player) Next(kind Kind, id string) (Entry, error) {
+	key := streamKey{Kind: kind, ID: id}
+	p.m.Lock()
+	defer p.m.Unlock()
+	i := p.next[key]
+	stream := p.streams[key]
+	if i >= len(stream) {
+		return Entry{}, ErrReplayFinished
+	}
+	p.next[key] = i + 1
+	return stream[i], nil
+}
+
+// Latest returns the last recorded response of the given kind and ID at the
+// current replay time. If none were recorded yet at that time, the first is
+// returned. If none were recorded at all, an error is returned.
+func (p *Player) Latest(kind Kind, id string) (Entry, error) {
+	return p.latest(kind, id, p.Now())
+}
+
+func (p *Player) latest(kind Kind, id string, now time.Time) (Entry, error) {
+	stream := p.streams[streamKey{Kind: kind, ID: id}]
+	if len(stream) == 0 {
+		return Entry{}, errors.New("no " + string(kind) + " recorded for '" + id + "'")
+	}
+	i := sort.Search(len(stream), func(i int) bool { return stream[i].Time.After(now) })
+	if i == 0 {
+		return stream[0], nil
+	}
+	return stream[i-1], nil
+}
+
+// IDs returns the IDs of all recorded responses of the given kind, sorted.
+func (p *Player) IDs(kind Kind) []string {
+	ids := []string{}
+	for key := range p.streams {
+		if key.Kind == kind {
+			ids = append(ids, key.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
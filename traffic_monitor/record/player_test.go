@@ -0,0 +1,98 @@
+package record
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPlayerNext(t *testing.T) {
+	entries := testEntries()
+	p := newPlayer(entries, 10, time.Now())
+
+	for _, expected := range []Entry{entries[1], entries[2]} {
+		e, err := p.Next(KindHealth, "cache0")
+		if err != nil {
+			t.Fatalf("expected next health entry, actual error %v", err)
+		}
+		if !reflect.DeepEqual(expected, e) {
+			t.Errorf("expected entry %+v actual %+v", expected, e)
+		}
+	}
+	if _, err := p.Next(KindHealth, "cache0"); err != ErrReplayFinished {
+		t.Errorf("expected ErrReplayFinished after last entry, actual %v", err)
+	}
+	if e, err := p.Next(KindStat, "cache0"); err != nil || !reflect.DeepEqual(entries[3], e) {
+		t.Errorf("expected stat entry %+v independent of health, actual %+v error %v", entries[3], e, err)
+	}
+	if _, err := p.Next(KindHealth, "cache1"); err != ErrReplayFinished {
+		t.Errorf("expected ErrReplayFinished for unrecorded poller, actual %v", err)
+	}
+}
+
+func TestPlayerLatest(t *testing.T) {
+	entries := testEntries()
+	began := time.Now()
+	p := newPlayer(entries, 10, began)
+
+	tests := []struct {
+		RealElapsed time.Duration
+		Expected    Entry
+	}{
+		{0, entries[0]},
+		{5 * time.Second, entries[0]}, // 50s recorded
+		{6 * time.Second, entries[4]}, // 60s recorded
+		{time.Hour, entries[4]},
+	}
+	for _, test := range tests {
+		e, err := p.latest(KindCRConfig, "cdn0", p.now(began.Add(test.RealElapsed)))
+		if err != nil {
+			t.Fatalf("%v: unexpected error %v", test.RealElapsed, err)
+		}
+		if !reflect.DeepEqual(test.Expected, e) {
+			t.Errorf("%v: expected entry %+v actual %+v", test.RealElapsed, test.Expected, e)
+		}
+	}
+
+	if _, err := p.Latest(KindMonitoring, "cdn0"); err == nil {
+		t.Errorf("expected error for unrecorded monitoring config, actual nil")
+	}
+	if ids := p.IDs(KindCRConfig); !reflect.DeepEqual([]string{"cdn0"}, ids) {
+		t.Errorf("expected CRConfig IDs [cdn0] actual %v", ids)
+	}
+	if interval := p.Interval(time.Second); interval != 100*time.Millisecond {
+		t.Errorf("expected 1s interval at 10x to be 100ms, actual %v", interval)
+	}
+}
+
+func TestPlayerShift(t *testing.T) {
+	entries := testEntries()
+	began := time.Now()
+	p := newPlayer(entries, 10, began)
+
+	if shifted := p.Shift(entries[0].Time); !shifted.Equal(began) {
+		t.Errorf("expected first recorded time to be shifted to the start of the replay %v, actual %v", began, shifted)
+	}
+	if shifted := p.Shift(entries[4].Time); !shifted.Equal(began.Add(time.Minute)) {
+		t.Errorf("expected recorded time a minute in to be shifted a minute after the start of the replay %v, actual %v", began.Add(time.Minute), shifted)
+	}
+}
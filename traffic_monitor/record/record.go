@@ -0,0 +1,271 @@
+// Package record records the raw data Traffic Monitor receives - cache health
+// and stat poll responses, peer responses, and its Traffic Ops configuration -
+// to an archive, and replays such an archive back through Traffic Monitor.
+//
+// This allows health threshold and policy changes to be evaluated against the
+// data of real incidents, before they're rolled out.
+package record
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+
+	"github.com/json-iterator/go"
+)
+
+// Kind is the kind of data an archive Entry holds.
+type Kind string
+
+const (
+	// KindHealth is a cache health poll response.
+	KindHealth = Kind("health")
+	// KindStat is a cache stat poll response.
+	KindStat = Kind("stat")
+	// KindPeer is a Traffic Monitor peer poll response.
+	KindPeer = Kind("peer")
+	// KindCRConfig is a CRConfig snapshot from Traffic Ops.
+	KindCRConfig = Kind("crconfig")
+	// KindMonitoring is a monitoring config snapshot from Traffic Ops.
+	KindMonitoring = Kind("monitoring")
+)
+
+// Entry is a single recorded response.
+type Entry struct {
+	Kind Kind `json:"kind"`
+	// ID is the poller ID of polls, and the CDN name of Traffic Ops data.
+	ID  string `json:"id"`
+	URL string `json:"url,omitempty"`
+	// Time is the time the request finished.
+	Time time.Time `json:"time"`
+	// ReqTime is the length of time the request took.
+	ReqTime time.Duration `json:"reqTime"`
+	Err     string        `json:"err,omitempty"`
+	Body    []byte        `json:"body,omitempty"`
+}
+
+// Error returns the recorded error of the entry, or nil if the request
+// succeeded.
+func (e Entry) Error() error {
+	if e.Err == "" {
+		return nil
+	}
+	return errors.New(e.Err)
+}
+
+// isGzip returns whether the archive at the given path is gzipped, which is
+// determined by its extension.
+func isGzip(path string) bool {
+	return strings.HasSuffix(path, ".gz")
+}
+
+// RecordBufferSize is the number of entries which may be waiting to be
+// written to the archive. Entries recorded while it's full are dropped.
+const RecordBufferSize = 4096
+
+// Recorder writes entries to an archive. A nil Recorder is valid, and records
+// nothing. This is safe for multiple goroutines.
+//
+// Entries are written by a single goroutine, so recording never makes a poller
+// wait for another poller's entry to be written.
+type Recorder struct {
+	entries chan Entry
+	done    chan error
+	closed  bool
+	m       *sync.RWMutex
+	file    *os.File
+	buf     *bufio.Writer
+	gz      *gzip.Writer
+	enc     *jsoniter.Encoder
+}
+
+// NewRecorder creates a Recorder writing to the archive at the given path,
+// truncating any existing file. If the path ends in '.gz', the archive is
+// gzipped. If path is empty, the returned Recorder is nil.
+//
+// The Recorder MUST be closed, to finish the archive.
+func NewRecorder(path string) (*Recorder, error) {
+	if path == "" {
+		return nil, nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.New("creating record file: " + err.Error())
+	}
+	r := &Recorder{
+		entries: make(chan Entry, RecordBufferSize),
+		done:    make(chan error, 1),
+		m:       &sync.RWMutex{},
+		file:    file,
+		buf:     bufio.NewWriter(file),
+	}
+	w := io.Writer(r.buf)
+	if isGzip(path) {
+		r.gz = gzip.NewWriter(r.buf)
+		w = r.gz
+	}
+	r.enc = jsoniter.ConfigFastest.NewEncoder(w)
+	go r.write()
+	return r, nil
+}
+
+// Record queues the given entry to be written to the archive. It returns an
+// error if the entry can't be queued, because the Recorder is closed or too
+// far behind.
+func (r *Recorder) Record(e Entry) error {
+	if r == nil {
+		return nil
+	}
+	r.m.RLock()
+	defer r.m.RUnlock()
+	if r.closed {
+		return errors.New("recorder is closed")
+	}
+	select {
+	case r.entries <- e:
+		return nil
+	default:
+		return errors.New("record buffer is full, dropping entry")
+	}
+}
+
+// write writes queued entries to the archive until the Recorder is closed,
+// then finishes the archive.
+//
+// The archive is flushed whenever there are no more entries queued, so the
+// archive of a Traffic Monitor which is killed rather than shut down is still
+// readable up to the last few entries.
+func (r *Recorder) write() {
+	var err error
+	for e := range r.entries {
+		if err != nil {
+			continue // keep draining, so Record never blocks
+		}
+		if err = r.enc.Encode(e); err != nil {
+			err = errors.New("encoding record entry: " + err.Error())
+			log.Errorln("recording: " + err.Error())
+			continue
+		}
+		if len(r.entries) > 0 {
+			continue
+		}
+		if err = r.flush(); err != nil {
+			log.Errorln("recording: " + err.Error())
+		}
+	}
+	if err == nil && r.gz != nil {
+		if err = r.gz.Close(); err != nil {
+			err = errors.New("closing record gzip: " + err.Error())
+		}
+	}
+	if err == nil {
+		if err = r.buf.Flush(); err != nil {
+			err = errors.New("flushing record file: " + err.Error())
+		}
+	}
+	if closeErr := r.file.Close(); err == nil && closeErr != nil {
+		err = errors.New("closing record file: " + closeErr.Error())
+	}
+	r.done <- err
+}
+
+func (r *Recorder) flush() error {
+	if r.gz != nil {
+		if err := r.gz.Flush(); err != nil {
+			return errors.New("flushing record gzip: " + err.Error())
+		}
+	}
+	if err := r.buf.Flush(); err != nil {
+		return errors.New("flushing record file: " + err.Error())
+	}
+	return nil
+}
+
+// Close writes all queued entries, then finishes and closes the archive. It
+// returns the first error writing the archive, if any. Entries recorded after
+// Close are dropped.
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.m.Lock()
+	if r.closed {
+		r.m.Unlock()
+		return errors.New("recorder is already closed")
+	}
+	r.closed = true
+	close(r.entries)
+	r.m.Unlock()
+	return <-r.done
+}
+
+// ReadArchive reads all entries from the archive at the given path.
+//
+// An archive whose last entry was cut off, because its Traffic Monitor was
+// killed while writing it, is read up to that entry.
+func ReadArchive(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.New("opening archive: " + err.Error())
+	}
+	defer file.Close()
+
+	rdr := io.Reader(bufio.NewReader(file))
+	if isGzip(path) {
+		gz, err := gzip.NewReader(rdr)
+		if err != nil {
+			return nil, errors.New("reading archive gzip: " + err.Error())
+		}
+		defer gz.Close()
+		rdr = gz
+	}
+	return readEntries(rdr)
+}
+
+func readEntries(rdr io.Reader) ([]Entry, error) {
+	entries := []Entry{}
+	scanner := bufio.NewScanner(rdr)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024*1024)
+	json := jsoniter.ConfigFastest
+	for scanner.Scan() {
+		e := Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			if !scanner.Scan() {
+				break // the last entry was cut off
+			}
+			return nil, errors.New("decoding archive entry " + strconv.Itoa(len(entries)) + ": " + err.Error())
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil && err != io.ErrUnexpectedEOF {
+		return nil, errors.New("reading archive: " + err.Error())
+	}
+	return entries, nil
+}
@@ -0,0 +1,152 @@
+package record
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testEntries() []Entry {
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []Entry{
+		{Kind: KindCRConfig, ID: "cdn0", Time: start, Body: []byte(`{"stats":{}}`)},
+		{Kind: KindHealth, ID: "cache0", URL: "http://cache0/_astats", Time: start.Add(time.Second), ReqTime: 10 * time.Millisecond, Body: []byte("health 0")},
+		{Kind: KindHealth, ID: "cache0", URL: "http://cache0/_astats", Time: start.Add(2 * time.Second), ReqTime: 20 * time.Millisecond, Err: "connection refused"},
+		{Kind: KindStat, ID: "cache0", URL: "http://cache0/_astats", Time: start.Add(2 * time.Second), ReqTime: 30 * time.Millisecond, Body: []byte("stat 0")},
+		{Kind: KindCRConfig, ID: "cdn0", Time: start.Add(time.Minute), Body: []byte(`{"stats":{"date":1}}`)},
+	}
+}
+
+func TestRecordReadArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tm-record")
+	if err != nil {
+		t.Fatalf("creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"archive.json", "archive.json.gz"} {
+		path := filepath.Join(dir, name)
+		recorder, err := NewRecorder(path)
+		if err != nil {
+			t.Fatalf("%s: creating recorder: %v", name, err)
+		}
+		for _, e := range testEntries() {
+			if err := recorder.Record(e); err != nil {
+				t.Fatalf("%s: recording: %v", name, err)
+			}
+		}
+		if err := recorder.Close(); err != nil {
+			t.Fatalf("%s: closing recorder: %v", name, err)
+		}
+
+		entries, err := ReadArchive(path)
+		if err != nil {
+			t.Fatalf("%s: reading archive: %v", name, err)
+		}
+		if expected := testEntries(); !reflect.DeepEqual(expected, entries) {
+			t.Errorf("%s: expected entries %+v actual %+v", name, expected, entries)
+		}
+	}
+}
+
+func TestReadArchiveCutOff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tm-record")
+	if err != nil {
+		t.Fatalf("creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "archive.json")
+	recorder, err := NewRecorder(path)
+	if err != nil {
+		t.Fatalf("creating recorder: %v", err)
+	}
+	for _, e := range testEntries() {
+		if err := recorder.Record(e); err != nil {
+			t.Fatalf("recording: %v", err)
+		}
+	}
+	// no Close, as if Traffic Monitor was killed; wait for the entries to be
+	// written, then cut off the last entry
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if entries, err := ReadArchive(path); err == nil && len(entries) == len(testEntries()) {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for entries to be written: %d entries, error %v", len(entries), err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat archive: %v", err)
+	}
+	if err := os.Truncate(path, info.Size()-10); err != nil {
+		t.Fatalf("truncating archive: %v", err)
+	}
+
+	entries, err := ReadArchive(path)
+	if err != nil {
+		t.Fatalf("reading cut off archive: %v", err)
+	}
+	if expected := testEntries()[:4]; !reflect.DeepEqual(expected, entries) {
+		t.Errorf("expected entries %+v actual %+v", expected, entries)
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	recorder, err := NewRecorder("")
+	if err != nil {
+		t.Fatalf("expected no error for empty path, actual %v", err)
+	}
+	if recorder != nil {
+		t.Fatalf("expected nil recorder for empty path")
+	}
+	if err := recorder.Record(Entry{}); err != nil {
+		t.Errorf("expected nil recorder to record nothing, actual error %v", err)
+	}
+}
+
+func TestRecorderClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tm-record")
+	if err != nil {
+		t.Fatalf("creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	recorder, err := NewRecorder(filepath.Join(dir, "archive.json.gz"))
+	if err != nil {
+		t.Fatalf("creating recorder: %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("closing recorder: %v", err)
+	}
+	if err := recorder.Record(testEntries()[0]); err == nil {
+		t.Error("expected error recording to a closed recorder, actual nil")
+	}
+	if err := recorder.Close(); err == nil {
+		t.Error("expected error closing a closed recorder, actual nil")
+	}
+}
@@ -0,0 +1,399 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tmcheck
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	to "github.com/apache/trafficcontrol/traffic_ops/v2-client"
+
+	"github.com/json-iterator/go"
+)
+
+const TrafficMonitorCRConfigPath = "/publish/CrConfig"
+
+// CRConfigSkewMax is the default maximum difference between the dates of the
+// CRConfigs of the Traffic Monitors of a CDN, and of the CDN's Traffic Ops
+// Snapshot, before the Monitors are considered inconsistent.
+const CRConfigSkewMax = time.Duration(5) * time.Minute
+
+// Divergence is a cache or Delivery Service whose availability the Traffic
+// Monitors of a CDN disagree on.
+type Divergence struct {
+	Name        string                  `json:"name"`
+	Available   []tc.TrafficMonitorName `json:"available"`
+	Unavailable []tc.TrafficMonitorName `json:"unavailable"`
+	// Missing is the Monitors whose CRStates don't contain it at all, though
+	// it is in the Snapshot.
+	Missing []tc.TrafficMonitorName `json:"missing"`
+}
+
+// ConsistencyReport is the result of cross-checking the CRStates of all the
+// Traffic Monitors of a CDN against each other, and against the CDN's Traffic
+// Ops Snapshot.
+type ConsistencyReport struct {
+	CDN  tc.CDNName `json:"cdn"`
+	Time time.Time  `json:"time"`
+	// Monitors is the Monitors whose CRStates were audited.
+	Monitors []tc.TrafficMonitorName `json:"monitors"`
+	// MonitorErrors is the errors getting data from Monitors, which are
+	// therefore not audited.
+	MonitorErrors map[tc.TrafficMonitorName]string `json:"monitorErrors"`
+	// SnapshotDate is the date of the Traffic Ops Snapshot, in seconds since
+	// the epoch.
+	SnapshotDate int64 `json:"snapshotDate"`
+	// CRConfigDates is the date of each Monitor's CRConfig, in seconds since
+	// the epoch.
+	CRConfigDates map[tc.TrafficMonitorName]int64 `json:"crConfigDates"`
+	// CRConfigSkewSeconds is the largest difference between any two of the
+	// Monitors' CRConfig dates and the Snapshot date.
+	CRConfigSkewSeconds int64 `json:"crConfigSkewSeconds"`
+	// StaleMonitors is the Monitors whose CRConfig date differs from the
+	// Snapshot date by more than the maximum skew.
+	StaleMonitors []tc.TrafficMonitorName `json:"staleMonitors"`
+	// OfflineAvailable is the OFFLINE and ADMIN_DOWN caches in the Snapshot
+	// which each Monitor marks available.
+	OfflineAvailable map[tc.TrafficMonitorName][]tc.CacheName `json:"offlineAvailable"`
+	DivergentCaches  []Divergence                             `json:"divergentCaches"`
+	DivergentDSes    []Divergence                             `json:"divergentDeliveryServices"`
+}
+
+// Consistent returns whether the report found no inconsistencies. Errors
+// getting data from Monitors are not inconsistencies.
+func (r ConsistencyReport) Consistent() bool {
+	return len(r.StaleMonitors) == 0 && len(r.OfflineAvailable) == 0 && len(r.DivergentCaches) == 0 && len(r.DivergentDSes) == 0
+}
+
+// MonitorErrs returns the inconsistencies of each Monitor in the report as an
+// error, or a nil error if the Monitor is consistent. Divergent caches and
+// Delivery Services are errors of every Monitor in the minority.
+func (r ConsistencyReport) MonitorErrs() map[tc.TrafficMonitorName]error {
+	msgs := map[tc.TrafficMonitorName][]string{}
+	for name, err := range r.MonitorErrors {
+		msgs[name] = append(msgs[name], err)
+	}
+	for _, name := range r.StaleMonitors {
+		msgs[name] = append(msgs[name], fmt.Sprintf("CRConfig date %v differs from Snapshot date %v", r.CRConfigDates[name], r.SnapshotDate))
+	}
+	for name, caches := range r.OfflineAvailable {
+		msgs[name] = append(msgs[name], fmt.Sprintf("OFFLINE or ADMIN_DOWN caches available: %v", caches))
+	}
+	addDivergences := func(kind string, divergences []Divergence) {
+		for _, d := range divergences {
+			for _, name := range d.minority() {
+				msgs[name] = append(msgs[name], fmt.Sprintf("%s %s diverges: available on %v, unavailable on %v, missing on %v", kind, d.Name, d.Available, d.Unavailable, d.Missing))
+			}
+		}
+	}
+	addDivergences("cache", r.DivergentCaches)
+	addDivergences("delivery service", r.DivergentDSes)
+
+	errs := map[tc.TrafficMonitorName]error{}
+	for _, name := range r.Monitors {
+		errs[name] = nil
+	}
+	for name, msg := range msgs {
+		errs[name] = fmt.Errorf("%s", strings.Join(msg, "; "))
+	}
+	return errs
+}
+
+// minority returns the Monitors which disagree with the most Monitors. If
+// there is a tie, all Monitors are returned, because none can be said to be
+// correct.
+func (d Divergence) minority() []tc.TrafficMonitorName {
+	groups := [][]tc.TrafficMonitorName{d.Available, d.Unavailable, d.Missing}
+	largest := 0
+	for _, group := range groups {
+		if len(group) > largest {
+			largest = len(group)
+		}
+	}
+	majorities := 0
+	for _, group := range groups {
+		if len(group) == largest {
+			majorities++
+		}
+	}
+	minority := []tc.TrafficMonitorName{}
+	for _, group := range groups {
+		if len(group) < largest || majorities > 1 {
+			minority = append(minority, group...)
+		}
+	}
+	return minority
+}
+
+// AuditCDN cross-checks the CRStates of every Traffic Monitor of a CDN against
+// each other, and against the CDN's Snapshot. The crConfigDates are the dates
+// of the Monitors' CRConfigs, in seconds since the epoch.
+func AuditCDN(cdn tc.CDNName, snapshot *tc.CRConfig, crStates map[tc.TrafficMonitorName]*tc.CRStates, crConfigDates map[tc.TrafficMonitorName]int64, maxSkew time.Duration) ConsistencyReport {
+	report := ConsistencyReport{
+		CDN:              cdn,
+		Time:             time.Now(),
+		Monitors:         []tc.TrafficMonitorName{},
+		MonitorErrors:    map[tc.TrafficMonitorName]string{},
+		CRConfigDates:    crConfigDates,
+		StaleMonitors:    []tc.TrafficMonitorName{},
+		OfflineAvailable: map[tc.TrafficMonitorName][]tc.CacheName{},
+		DivergentCaches:  []Divergence{},
+		DivergentDSes:    []Divergence{},
+	}
+	for name := range crStates {
+		report.Monitors = append(report.Monitors, name)
+	}
+	sort.Sort(tmNames(report.Monitors))
+
+	if snapshot.Stats.DateUnixSeconds != nil {
+		report.SnapshotDate = *snapshot.Stats.DateUnixSeconds
+	}
+	minDate, maxDate := report.SnapshotDate, report.SnapshotDate
+	for _, name := range report.Monitors {
+		date, ok := crConfigDates[name]
+		if !ok {
+			continue
+		}
+		if date < minDate {
+			minDate = date
+		}
+		if date > maxDate {
+			maxDate = date
+		}
+		skew := date - report.SnapshotDate
+		if skew < 0 {
+			skew = -skew
+		}
+		if time.Duration(skew)*time.Second > maxSkew {
+			report.StaleMonitors = append(report.StaleMonitors, name)
+		}
+	}
+	report.CRConfigSkewSeconds = maxDate - minDate
+
+	for _, name := range report.Monitors {
+		if offline := offlineAvailableCaches(crStates[name], snapshot); len(offline) > 0 {
+			report.OfflineAvailable[name] = offline
+		}
+	}
+
+	cacheNames := map[string]struct{}{}
+	for cacheName := range snapshot.ContentServers {
+		cacheNames[cacheName] = struct{}{}
+	}
+	dsNames := map[string]struct{}{}
+	for dsName := range snapshot.DeliveryServices {
+		dsNames[dsName] = struct{}{}
+	}
+	for _, name := range report.Monitors {
+		for cacheName := range crStates[name].Caches {
+			cacheNames[string(cacheName)] = struct{}{}
+		}
+		for dsName := range crStates[name].DeliveryService {
+			dsNames[string(dsName)] = struct{}{}
+		}
+	}
+
+	for cacheName := range cacheNames {
+		d := Divergence{Name: cacheName}
+		for _, name := range report.Monitors {
+			if state, ok := crStates[name].Caches[tc.CacheName(cacheName)]; !ok {
+				d.Missing = append(d.Missing, name)
+			} else if state.IsAvailable {
+				d.Available = append(d.Available, name)
+			} else {
+				d.Unavailable = append(d.Unavailable, name)
+			}
+		}
+		if d.diverges() {
+			report.DivergentCaches = append(report.DivergentCaches, d)
+		}
+	}
+	for dsName := range dsNames {
+		d := Divergence{Name: dsName}
+		for _, name := range report.Monitors {
+			if state, ok := crStates[name].DeliveryService[tc.DeliveryServiceName(dsName)]; !ok {
+				d.Missing = append(d.Missing, name)
+			} else if state.IsAvailable {
+				d.Available = append(d.Available, name)
+			} else {
+				d.Unavailable = append(d.Unavailable, name)
+			}
+		}
+		if d.diverges() {
+			report.DivergentDSes = append(report.DivergentDSes, d)
+		}
+	}
+	sort.Slice(report.DivergentCaches, func(i, j int) bool { return report.DivergentCaches[i].Name < report.DivergentCaches[j].Name })
+	sort.Slice(report.DivergentDSes, func(i, j int) bool { return report.DivergentDSes[i].Name < report.DivergentDSes[j].Name })
+	return report
+}
+
+// diverges returns whether the Monitors don't all agree.
+func (d Divergence) diverges() bool {
+	groups := 0
+	for _, group := range [][]tc.TrafficMonitorName{d.Available, d.Unavailable, d.Missing} {
+		if len(group) > 0 {
+			groups++
+		}
+	}
+	return groups > 1
+}
+
+// offlineAvailableCaches returns the OFFLINE and ADMIN_DOWN caches in the
+// given CRConfig which are marked available in the given CRStates.
+func offlineAvailableCaches(crStates *tc.CRStates, crConfig *tc.CRConfig) []tc.CacheName {
+	caches := []tc.CacheName{}
+	for cacheName, cacheInfo := range crConfig.ContentServers {
+		if cacheInfo.ServerStatus == nil {
+			continue
+		}
+		status := tc.CacheStatusFromString(string(*cacheInfo.ServerStatus))
+		if status != tc.CacheStatusAdminDown && status != tc.CacheStatusOffline {
+			continue
+		}
+		if crStates.Caches[tc.CacheName(cacheName)].IsAvailable {
+			caches = append(caches, tc.CacheName(cacheName))
+		}
+	}
+	sort.Slice(caches, func(i, j int) bool { return caches[i] < caches[j] })
+	return caches
+}
+
+type tmNames []tc.TrafficMonitorName
+
+func (n tmNames) Len() int           { return len(n) }
+func (n tmNames) Less(i, j int) bool { return n[i] < n[j] }
+func (n tmNames) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+
+// CRConfigStatsOnly is the CRConfig returned by Traffic Monitor's CrConfig
+// endpoint, with only its stats decoded.
+type CRConfigStatsOnly struct {
+	Stats tc.CRConfigStats `json:"stats"`
+}
+
+// GetCRConfigDate gets the date of the CRConfig the given Traffic Monitor is
+// using, in seconds since the epoch.
+func GetCRConfigDate(uri string) (int64, error) {
+	resp, err := getClient().Get(uri)
+	if err != nil {
+		return 0, fmt.Errorf("reading reply from %v: %v\n", uri, err)
+	}
+	defer resp.Body.Close()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("reading reply from %v: %v\n", uri, err)
+	}
+
+	crConfig := CRConfigStatsOnly{}
+	json := jsoniter.ConfigFastest
+	if err := json.Unmarshal(respBytes, &crConfig); err != nil {
+		return 0, fmt.Errorf("unmarshalling: %v", err)
+	}
+	if crConfig.Stats.DateUnixSeconds == nil {
+		return 0, fmt.Errorf("CRConfig from %v has no date", uri)
+	}
+	return *crConfig.Stats.DateUnixSeconds, nil
+}
+
+// GetConsistencyReports gets the CRStates and CRConfig dates of all the given
+// Traffic Monitors, and audits each CDN with AuditCDN.
+func GetConsistencyReports(toClient *to.Session, monitors []tc.Server, maxSkew time.Duration) map[tc.CDNName]ConsistencyReport {
+	crConfigs := GetCRConfigs(GetCDNs(monitors), toClient)
+
+	monitorErrs := map[tc.CDNName]map[tc.TrafficMonitorName]string{}
+	crStates := map[tc.CDNName]map[tc.TrafficMonitorName]*tc.CRStates{}
+	crConfigDates := map[tc.CDNName]map[tc.TrafficMonitorName]int64{}
+	for cdn := range crConfigs {
+		monitorErrs[cdn] = map[tc.TrafficMonitorName]string{}
+		crStates[cdn] = map[tc.TrafficMonitorName]*tc.CRStates{}
+		crConfigDates[cdn] = map[tc.TrafficMonitorName]int64{}
+	}
+
+	for _, server := range monitors {
+		cdn := tc.CDNName(server.CDNName)
+		name := tc.TrafficMonitorName(server.HostName)
+		uri := fmt.Sprintf("http://%s.%s", server.HostName, server.DomainName)
+		states, err := GetCRStates(uri + TrafficMonitorCRStatesPath)
+		if err != nil {
+			monitorErrs[cdn][name] = "getting CRStates: " + err.Error()
+			continue
+		}
+		date, err := GetCRConfigDate(uri + TrafficMonitorCRConfigPath)
+		if err != nil {
+			monitorErrs[cdn][name] = "getting CRConfig date: " + err.Error()
+			continue
+		}
+		crStates[cdn][name] = states
+		crConfigDates[cdn][name] = date
+	}
+
+	reports := map[tc.CDNName]ConsistencyReport{}
+	for cdn, crConfig := range crConfigs {
+		if crConfig.Err != nil {
+			errs := map[tc.TrafficMonitorName]string{}
+			for _, server := range monitors {
+				if tc.CDNName(server.CDNName) == cdn {
+					errs[tc.TrafficMonitorName(server.HostName)] = "getting Snapshot: " + crConfig.Err.Error()
+				}
+			}
+			reports[cdn] = ConsistencyReport{CDN: cdn, Time: time.Now(), MonitorErrors: errs}
+			continue
+		}
+		report := AuditCDN(cdn, crConfig.CRConfig, crStates[cdn], crConfigDates[cdn], maxSkew)
+		report.MonitorErrors = monitorErrs[cdn]
+		reports[cdn] = report
+	}
+	return reports
+}
+
+// ValidateAllMonitorsConsistency validates that all Traffic Monitors of each
+// CDN agree on the availability of every cache and Delivery Service, and are
+// using the CDN's current Snapshot. Divergences are errors of the Monitors in
+// the minority.
+func ValidateAllMonitorsConsistency(toClient *to.Session, includeOffline bool) (map[tc.TrafficMonitorName]error, error) {
+	servers, err := GetMonitors(toClient, includeOffline)
+	if err != nil {
+		return nil, err
+	}
+	errs := map[tc.TrafficMonitorName]error{}
+	for _, report := range GetConsistencyReports(toClient, servers, CRConfigSkewMax) {
+		for name, err := range report.MonitorErrs() {
+			errs[name] = err
+		}
+	}
+	return errs, nil
+}
+
+// AllMonitorsConsistencyValidator is designed to be run as a goroutine, and does not return. It continously validates every `interval`, and calls `onErr` on failure, `onResumeSuccess` when a failure ceases, and `onCheck` on every poll. Note the error passed to `onErr` may be a general validation error not associated with any monitor, in which case the passed `tc.TrafficMonitorName` will be empty.
+func AllMonitorsConsistencyValidator(
+	toClient *to.Session,
+	interval time.Duration,
+	includeOffline bool,
+	grace time.Duration,
+	onErr func(tc.TrafficMonitorName, error),
+	onResumeSuccess func(tc.TrafficMonitorName),
+	onCheck func(tc.TrafficMonitorName, error),
+) {
+	AllValidator(toClient, interval, includeOffline, grace, onErr, onResumeSuccess, onCheck, ValidateAllMonitorsConsistency)
+}
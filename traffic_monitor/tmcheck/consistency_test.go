@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tmcheck
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestAuditCDN(t *testing.T) {
+	snapshotDate := int64(1600000000)
+	offline := tc.CRConfigServerStatus(tc.CacheStatusOffline)
+	online := tc.CRConfigServerStatus(tc.CacheStatusOnline)
+	snapshot := &tc.CRConfig{
+		ContentServers: map[string]tc.CRConfigTrafficOpsServer{
+			"edge0": {ServerStatus: &online},
+			"edge1": {ServerStatus: &online},
+			"edge2": {ServerStatus: &offline},
+		},
+		DeliveryServices: map[string]tc.CRConfigDeliveryService{"ds0": {}},
+		Stats:            tc.CRConfigStats{DateUnixSeconds: &snapshotDate},
+	}
+
+	states := func(edge1Available bool, edge2Available bool) *tc.CRStates {
+		return &tc.CRStates{
+			Caches: map[tc.CacheName]tc.IsAvailable{
+				"edge0": {IsAvailable: true},
+				"edge1": {IsAvailable: edge1Available},
+				"edge2": {IsAvailable: edge2Available},
+			},
+			DeliveryService: map[tc.DeliveryServiceName]tc.CRStatesDeliveryService{"ds0": {IsAvailable: true}},
+		}
+	}
+	crStates := map[tc.TrafficMonitorName]*tc.CRStates{
+		"tm0": states(true, false),
+		"tm1": states(true, false),
+		"tm2": states(false, true),
+	}
+	delete(crStates["tm2"].DeliveryService, "ds0")
+	crConfigDates := map[tc.TrafficMonitorName]int64{
+		"tm0": snapshotDate,
+		"tm1": snapshotDate - 60,
+		"tm2": snapshotDate - 3600,
+	}
+
+	report := AuditCDN("cdn", snapshot, crStates, crConfigDates, CRConfigSkewMax)
+
+	if report.Consistent() {
+		t.Fatal("expected: inconsistent report, actual: consistent")
+	}
+	if !reflect.DeepEqual(report.Monitors, []tc.TrafficMonitorName{"tm0", "tm1", "tm2"}) {
+		t.Errorf("expected: monitors [tm0 tm1 tm2], actual: %v", report.Monitors)
+	}
+	if report.CRConfigSkewSeconds != 3600 {
+		t.Errorf("expected: CRConfig skew 3600, actual: %v", report.CRConfigSkewSeconds)
+	}
+	if !reflect.DeepEqual(report.StaleMonitors, []tc.TrafficMonitorName{"tm2"}) {
+		t.Errorf("expected: stale monitors [tm2], actual: %v", report.StaleMonitors)
+	}
+	if !reflect.DeepEqual(report.OfflineAvailable, map[tc.TrafficMonitorName][]tc.CacheName{"tm2": {"edge2"}}) {
+		t.Errorf("expected: tm2 marks offline edge2 available, actual: %v", report.OfflineAvailable)
+	}
+
+	expectedCaches := []Divergence{
+		{Name: "edge1", Available: []tc.TrafficMonitorName{"tm0", "tm1"}, Unavailable: []tc.TrafficMonitorName{"tm2"}},
+		{Name: "edge2", Available: []tc.TrafficMonitorName{"tm2"}, Unavailable: []tc.TrafficMonitorName{"tm0", "tm1"}},
+	}
+	if !reflect.DeepEqual(report.DivergentCaches, expectedCaches) {
+		t.Errorf("expected: divergent caches %+v, actual: %+v", expectedCaches, report.DivergentCaches)
+	}
+	expectedDSes := []Divergence{{Name: "ds0", Available: []tc.TrafficMonitorName{"tm0", "tm1"}, Missing: []tc.TrafficMonitorName{"tm2"}}}
+	if !reflect.DeepEqual(report.DivergentDSes, expectedDSes) {
+		t.Errorf("expected: divergent delivery services %+v, actual: %+v", expectedDSes, report.DivergentDSes)
+	}
+
+	errs := report.MonitorErrs()
+	if errs["tm0"] != nil || errs["tm1"] != nil {
+		t.Errorf("expected: no errors for the majority tm0 and tm1, actual: %v %v", errs["tm0"], errs["tm1"])
+	}
+	if errs["tm2"] == nil {
+		t.Error("expected: error for the minority tm2, actual: nil")
+	}
+}
+
+func TestAuditCDNConsistent(t *testing.T) {
+	snapshotDate := int64(1600000000)
+	snapshot := &tc.CRConfig{
+		ContentServers: map[string]tc.CRConfigTrafficOpsServer{"edge0": {}},
+		Stats:          tc.CRConfigStats{DateUnixSeconds: &snapshotDate},
+	}
+	crStates := map[tc.TrafficMonitorName]*tc.CRStates{
+		"tm0": {Caches: map[tc.CacheName]tc.IsAvailable{"edge0": {IsAvailable: true}}},
+		"tm1": {Caches: map[tc.CacheName]tc.IsAvailable{"edge0": {IsAvailable: true}}},
+	}
+	crConfigDates := map[tc.TrafficMonitorName]int64{"tm0": snapshotDate, "tm1": snapshotDate}
+
+	report := AuditCDN("cdn", snapshot, crStates, crConfigDates, time.Minute)
+	if !report.Consistent() {
+		t.Errorf("expected: consistent report, actual: %+v", report)
+	}
+	for name, err := range report.MonitorErrs() {
+		if err != nil {
+			t.Errorf("expected: no error for %v, actual: %v", name, err)
+		}
+	}
+}
@@ -62,6 +62,15 @@ func (c *TMClient) BandwidthCapacityKBPS() (float64, error) {
 	return c.getFloat("/api/bandwidth-capacity-kbps")
 }
 
+func (c *TMClient) BandwidthForecast() (datareq.BandwidthForecast, error) {
+	path := "/api/bandwidth-forecast"
+	obj := datareq.BandwidthForecast{}
+	if err := c.GetJSON(path, &obj); err != nil {
+		return datareq.BandwidthForecast{}, err // GetJSON adds context
+	}
+	return obj, nil
+}
+
 func (c *TMClient) CacheStatuses() (map[tc.CacheName]datareq.CacheStatus, error) {
 	path := "/api/cache-statuses"
 	obj := map[tc.CacheName]datareq.CacheStatus{}
@@ -0,0 +1,279 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// tm-auditor is a long-running HTTP service which continuously cross-checks
+// the CRStates of all Traffic Monitors of each CDN against each other and
+// against the CDN's Traffic Ops Snapshot, and serves the results as a
+// dashboard, a JSON report, and metrics in the Prometheus text format.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/tmcheck"
+	to "github.com/apache/trafficcontrol/traffic_ops/v2-client"
+)
+
+const UserAgent = "tm-auditor/0.1"
+
+// CDNAudit is the latest audit of a single CDN.
+type CDNAudit struct {
+	tmcheck.ConsistencyReport
+	// InconsistentSince is when the CDN's Monitors became inconsistent, or
+	// nil if they are consistent.
+	InconsistentSince *time.Time `json:"inconsistentSince"`
+}
+
+// Audits is the latest audit of every CDN, safe for multiple readers and one
+// writer.
+type Audits struct {
+	cdns        map[tc.CDNName]CDNAudit
+	audits      uint64
+	auditErrors uint64
+	lastErr     string
+	lastAudit   time.Time
+	m           *sync.RWMutex
+}
+
+func NewAudits() *Audits {
+	return &Audits{cdns: map[tc.CDNName]CDNAudit{}, m: &sync.RWMutex{}}
+}
+
+// Set sets the latest reports, keeping when each CDN became inconsistent.
+// CDNs without a report, e.g. because they no longer have any Monitors, are
+// removed.
+func (a *Audits) Set(reports map[tc.CDNName]tmcheck.ConsistencyReport) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	cdns := make(map[tc.CDNName]CDNAudit, len(reports))
+	for cdn, report := range reports {
+		audit := CDNAudit{ConsistencyReport: report}
+		if !report.Consistent() {
+			inconsistentSince := report.Time
+			audit.InconsistentSince = &inconsistentSince
+			if last, ok := a.cdns[cdn]; ok && last.InconsistentSince != nil {
+				audit.InconsistentSince = last.InconsistentSince
+			} else {
+				fmt.Printf("%v ERROR CDN %v Monitors inconsistent: %v\n", time.Now(), cdn, report.MonitorErrs())
+			}
+		} else if last, ok := a.cdns[cdn]; ok && last.InconsistentSince != nil {
+			fmt.Printf("%v INFO CDN %v Monitors consistent after %v\n", time.Now(), cdn, report.Time.Sub(*last.InconsistentSince))
+		}
+		cdns[cdn] = audit
+	}
+	a.cdns = cdns
+	a.audits++
+	a.lastErr = ""
+	a.lastAudit = time.Now()
+}
+
+// SetErr records an audit which failed entirely, keeping the last reports.
+func (a *Audits) SetErr(err error) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.audits++
+	a.auditErrors++
+	a.lastErr = err.Error()
+	a.lastAudit = time.Now()
+	fmt.Printf("%v ERROR auditing: %v\n", time.Now(), err)
+}
+
+// AuditsReport is the JSON report of all audits.
+type AuditsReport struct {
+	LastAudit   time.Time               `json:"lastAudit"`
+	LastError   string                  `json:"lastError,omitempty"`
+	Audits      uint64                  `json:"audits"`
+	AuditErrors uint64                  `json:"auditErrors"`
+	CDNs        map[tc.CDNName]CDNAudit `json:"cdns"`
+}
+
+func (a *Audits) Get() AuditsReport {
+	a.m.RLock()
+	defer a.m.RUnlock()
+	return AuditsReport{LastAudit: a.lastAudit, LastError: a.lastErr, Audits: a.audits, AuditErrors: a.auditErrors, CDNs: a.cdns}
+}
+
+func startAuditor(toClient *to.Session, interval time.Duration, includeOffline bool, maxSkew time.Duration) *Audits {
+	audits := NewAudits()
+	go func() {
+		for {
+			monitors, err := tmcheck.GetMonitors(toClient, includeOffline)
+			if err != nil {
+				audits.SetErr(err)
+			} else {
+				audits.Set(tmcheck.GetConsistencyReports(toClient, monitors, maxSkew))
+			}
+			time.Sleep(interval)
+		}
+	}()
+	return audits
+}
+
+func main() {
+	toURI := flag.String("to", "", "The Traffic Ops URI, whose Snapshots and Monitors to audit")
+	toUser := flag.String("touser", "", "The Traffic Ops user")
+	toPass := flag.String("topass", "", "The Traffic Ops password")
+	interval := flag.Duration("interval", time.Second*time.Duration(30), "The interval to audit")
+	maxSkew := flag.Duration("maxSkew", tmcheck.CRConfigSkewMax, "The maximum difference between a Monitor's CRConfig date and the Snapshot date")
+	includeOffline := flag.Bool("includeOffline", false, "Whether to include Offline Monitors")
+	listen := flag.String("listen", ":80", "The address to serve the dashboard, report, and metrics on")
+	help := flag.Bool("help", false, "Usage info")
+	helpBrief := flag.Bool("h", false, "Usage info")
+	flag.Parse()
+	if *help || *helpBrief {
+		fmt.Printf("Usage: tm-auditor -to https://traffic-ops.example.net -touser bill -topass thelizard -interval 30s -maxSkew 5m -includeOffline false -listen :80\n")
+		return
+	}
+
+	toClient, _, err := to.LoginWithAgent(*toURI, *toUser, *toPass, true, UserAgent, false, tmcheck.RequestTimeout)
+	if err != nil {
+		fmt.Printf("Error logging in to Traffic Ops: %v\n", err)
+		return
+	}
+
+	audits := startAuditor(toClient, *interval, *includeOffline, *maxSkew)
+	if err := serve(*listen, *toURI, audits); err != nil {
+		fmt.Printf("Serve error: %v\n", err)
+	}
+}
+
+func sortedCDNs(report AuditsReport) []tc.CDNName {
+	cdns := make([]tc.CDNName, 0, len(report.CDNs))
+	for cdn := range report.CDNs {
+		cdns = append(cdns, cdn)
+	}
+	sort.Slice(cdns, func(i, j int) bool { return cdns[i] < cdns[j] })
+	return cdns
+}
+
+func printDivergences(w io.Writer, kind string, divergences []tmcheck.Divergence) {
+	for _, d := range divergences {
+		fmt.Fprintf(w, `<tr><td><span>%s</span></td><td><span style="font-family:monospace">%s</span></td><td><span>available on %v, unavailable on %v, missing on %v</span></td></tr>`, kind, html.EscapeString(d.Name), d.Available, d.Unavailable, d.Missing)
+	}
+}
+
+func printAudit(w io.Writer, audit CDNAudit) {
+	fmt.Fprintf(w, `<h2>%s</h2>`, html.EscapeString(string(audit.CDN)))
+	if audit.Consistent() {
+		fmt.Fprintf(w, `<p><span style="color:limegreen">Consistent</span> as of %v`, audit.Time)
+	} else {
+		fmt.Fprintf(w, `<p><span style="color:red">Inconsistent</span> since %v, as of %v`, audit.InconsistentSince, audit.Time)
+	}
+	fmt.Fprintf(w, `<p>Monitors audited: %v. CRConfig skew: %v.`, audit.Monitors, time.Duration(audit.CRConfigSkewSeconds)*time.Second)
+
+	fmt.Fprintf(w, `<table style="width:100%%">`)
+	names := make([]string, 0, len(audit.MonitorErrors))
+	for name := range audit.MonitorErrors {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, `<tr><td><span>%s</span></td><td><span style="color:orange">Unreachable</span></td><td><span style="font-family:monospace">%s</span></td></tr>`, html.EscapeString(name), html.EscapeString(audit.MonitorErrors[tc.TrafficMonitorName(name)]))
+	}
+	for _, name := range audit.StaleMonitors {
+		fmt.Fprintf(w, `<tr><td><span>%s</span></td><td><span style="color:red">Stale CRConfig</span></td><td><span>CRConfig date %v, Snapshot date %v</span></td></tr>`, name, time.Unix(audit.CRConfigDates[name], 0), time.Unix(audit.SnapshotDate, 0))
+	}
+	for name, caches := range audit.OfflineAvailable {
+		fmt.Fprintf(w, `<tr><td><span>%s</span></td><td><span style="color:red">Offline Available</span></td><td><span>%v</span></td></tr>`, name, caches)
+	}
+	printDivergences(w, "cache", audit.DivergentCaches)
+	printDivergences(w, "delivery service", audit.DivergentDSes)
+	fmt.Fprintf(w, `</table>`)
+}
+
+func boolGauge(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// writeMetrics writes the audits as metrics in the Prometheus text format.
+func writeMetrics(w io.Writer, report AuditsReport) {
+	fmt.Fprintf(w, "# HELP tm_auditor_audits_total The number of audits run.\n# TYPE tm_auditor_audits_total counter\ntm_auditor_audits_total %d\n", report.Audits)
+	fmt.Fprintf(w, "# HELP tm_auditor_audit_errors_total The number of audits which failed to get the Monitors from Traffic Ops.\n# TYPE tm_auditor_audit_errors_total counter\ntm_auditor_audit_errors_total %d\n", report.AuditErrors)
+	fmt.Fprintf(w, "# HELP tm_auditor_last_audit_timestamp_seconds When the last audit was run.\n# TYPE tm_auditor_last_audit_timestamp_seconds gauge\ntm_auditor_last_audit_timestamp_seconds %d\n", report.LastAudit.Unix())
+
+	cdns := sortedCDNs(report)
+	gauge := func(name string, help string, val func(CDNAudit) float64) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		for _, cdn := range cdns {
+			fmt.Fprintf(w, "%s{cdn=%q} %v\n", name, string(cdn), val(report.CDNs[cdn]))
+		}
+	}
+	gauge("tm_auditor_consistent", "Whether the CDN's Monitors are consistent.", func(a CDNAudit) float64 { return float64(boolGauge(a.Consistent())) })
+	gauge("tm_auditor_inconsistent_seconds", "How long the CDN's Monitors have been inconsistent.", func(a CDNAudit) float64 {
+		if a.InconsistentSince == nil {
+			return 0
+		}
+		return a.Time.Sub(*a.InconsistentSince).Seconds()
+	})
+	gauge("tm_auditor_monitors", "The number of the CDN's Monitors audited.", func(a CDNAudit) float64 { return float64(len(a.Monitors)) })
+	gauge("tm_auditor_monitor_errors", "The number of the CDN's Monitors which could not be audited.", func(a CDNAudit) float64 { return float64(len(a.MonitorErrors)) })
+	gauge("tm_auditor_divergent_caches", "The number of caches the CDN's Monitors disagree on.", func(a CDNAudit) float64 { return float64(len(a.DivergentCaches)) })
+	gauge("tm_auditor_divergent_delivery_services", "The number of Delivery Services the CDN's Monitors disagree on.", func(a CDNAudit) float64 { return float64(len(a.DivergentDSes)) })
+	gauge("tm_auditor_offline_available_monitors", "The number of the CDN's Monitors marking OFFLINE or ADMIN_DOWN caches available.", func(a CDNAudit) float64 { return float64(len(a.OfflineAvailable)) })
+	gauge("tm_auditor_stale_monitors", "The number of the CDN's Monitors whose CRConfig is too far from the Snapshot.", func(a CDNAudit) float64 { return float64(len(a.StaleMonitors)) })
+	gauge("tm_auditor_crconfig_skew_seconds", "The largest difference between the CDN's Monitors' CRConfig dates and the Snapshot date.", func(a CDNAudit) float64 { return float64(a.CRConfigSkewSeconds) })
+}
+
+func serve(listen string, toURI string, audits *Audits) error {
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		report := audits.Get()
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintf(w, `<!DOCTYPE html>
+<meta http-equiv="refresh" content="5">
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Traffic Monitor Auditor</title>
+<style type="text/css">body{margin:40px auto;line-height:1.6;font-size:18px;color:#444;padding:0 8px 0 8px}h1,h2,h3{line-height:1.2}span{padding:0px 4px 0px 4px;}</style>`)
+
+		fmt.Fprintf(w, `<h1>Traffic Monitor Auditor</h1>`)
+		fmt.Fprintf(w, `<p>%s`, html.EscapeString(toURI))
+		fmt.Fprintf(w, `<p>last audit %v`, report.LastAudit)
+		if report.LastError != "" {
+			fmt.Fprintf(w, `<p><span style="color:red">%s</span>`, html.EscapeString(report.LastError))
+		}
+		for _, cdn := range sortedCDNs(report) {
+			printAudit(w, report.CDNs[cdn])
+		}
+	})
+	http.HandleFunc("/api/report", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(audits.Get()); err != nil {
+			fmt.Printf("%v ERROR writing report: %v\n", time.Now(), err)
+		}
+	})
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeMetrics(w, audits.Get())
+	})
+	return http.ListenAndServe(listen, nil)
+}
@@ -159,8 +159,9 @@ func main() {
 	peerPollerLogs := startValidator(tmcheck.PeerPollersAllValidator, toClient, *interval, *includeOffline, *grace)
 	dsStatsLogs := startValidator(tmcheck.AllMonitorsDSStatsValidator, toClient, *interval, *includeOffline, *grace)
 	queryIntervalLogs := startValidator(tmcheck.AllMonitorsQueryIntervalValidator, toClient, *interval, *includeOffline, *grace)
+	consistencyLogs := startValidator(tmcheck.AllMonitorsConsistencyValidator, toClient, *interval, *includeOffline, *grace)
 
-	if err := serve(*toURI, crStatesOfflineLogs, peerPollerLogs, dsStatsLogs, queryIntervalLogs); err != nil {
+	if err := serve(*toURI, crStatesOfflineLogs, peerPollerLogs, dsStatsLogs, queryIntervalLogs, consistencyLogs); err != nil {
 		fmt.Printf("Serve error: %v\n", err)
 	}
 }
@@ -200,7 +201,7 @@ func printLogs(logs Logs, w io.Writer) {
 	fmt.Fprintf(w, `</table>`)
 }
 
-func serve(toURI string, crStatesOfflineLogs Logs, peerPollerLogs Logs, dsStatsLogs Logs, queryIntervalLogs Logs) error {
+func serve(toURI string, crStatesOfflineLogs Logs, peerPollerLogs Logs, dsStatsLogs Logs, queryIntervalLogs Logs, consistencyLogs Logs) error {
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Content-Type", "text/html")
@@ -231,6 +232,10 @@ func serve(toURI string, crStatesOfflineLogs Logs, peerPollerLogs Logs, dsStatsL
 		fmt.Fprintf(w, `<h2>Query Interval</h2>`)
 		fmt.Fprintf(w, `<h3>validates all Monitors' Query Interval (95th percentile) is less than %v</h3>`, tmcheck.QueryIntervalMax)
 		printLogs(queryIntervalLogs, w)
+
+		fmt.Fprintf(w, `<h2>Consistency</h2>`)
+		fmt.Fprintf(w, `<h3>validates all Monitors of each CDN agree on cache and Delivery Service availability, and their CRConfigs are within %v of the Snapshot</h3>`, tmcheck.CRConfigSkewMax)
+		printLogs(consistencyLogs, w)
 	})
 	return http.ListenAndServe(":80", nil)
 }
@@ -34,6 +34,7 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
+	"github.com/apache/trafficcontrol/traffic_monitor/record"
 	legacyClient "github.com/apache/trafficcontrol/traffic_ops/v2-client"
 	client "github.com/apache/trafficcontrol/traffic_ops/v3-client"
 
@@ -196,26 +197,39 @@ type TrafficOpsSessionThreadsafe struct {
 	useLegacy          bool
 	CRConfigBackupFile string
 	TMConfigBackupFile string
+	// Recorder, if not nil, records every CRConfig and monitoring config
+	// fetched from Traffic Ops.
+	Recorder *record.Recorder
+	// Replay, if not nil, replaces Traffic Ops with the recorded CRConfig and
+	// monitoring config at the current replay time.
+	Replay *record.Player
+	// ReplayMonitoringFile, if not empty, replaces the recorded monitoring
+	// config when replaying, so changed thresholds can be evaluated.
+	ReplayMonitoringFile string
 }
 
 // NewTrafficOpsSessionThreadsafe returns a new threadsafe
 // TrafficOpsSessionThreadsafe wrapping the given `Session`.
 func NewTrafficOpsSessionThreadsafe(s *client.Session, ls *legacyClient.Session, histLimit uint64, cfg config.Config) TrafficOpsSessionThreadsafe {
 	return TrafficOpsSessionThreadsafe{
-		CRConfigBackupFile: cfg.CRConfigBackupFile,
-		crConfigHist:       NewCRConfigHistoryThreadsafe(histLimit),
-		lastCRConfig:       NewByteMapCache(),
-		m:                  &sync.Mutex{},
-		session:            &s,
-		legacySession:      &ls,
-		TMConfigBackupFile: cfg.TMConfigBackupFile,
-		useLegacy:          false,
+		CRConfigBackupFile:   cfg.CRConfigBackupFile,
+		crConfigHist:         NewCRConfigHistoryThreadsafe(histLimit),
+		lastCRConfig:         NewByteMapCache(),
+		m:                    &sync.Mutex{},
+		session:              &s,
+		legacySession:        &ls,
+		TMConfigBackupFile:   cfg.TMConfigBackupFile,
+		useLegacy:            false,
+		ReplayMonitoringFile: cfg.ReplayMonitoringFile,
 	}
 }
 
 // Initialized tells whether or not the TrafficOpsSessionThreadsafe has been
 // properly initialized (by calling 'Update').
 func (s TrafficOpsSessionThreadsafe) Initialized() bool {
+	if s.Replay != nil {
+		return true
+	}
 	if s.useLegacy {
 		return s.legacySession != nil && *s.legacySession != nil
 	}
@@ -237,6 +251,10 @@ func (s *TrafficOpsSessionThreadsafe) Update(
 	if s == nil {
 		return errors.New("cannot update nil session")
 	}
+	if s.Replay != nil {
+		log.Infoln("replaying Traffic Ops data, not logging in to " + url)
+		return nil
+	}
 	s.m.Lock()
 	defer s.m.Unlock()
 
@@ -335,7 +353,14 @@ func (s TrafficOpsSessionThreadsafe) CRConfigRaw(cdn string) ([]byte, error) {
 	var err error
 	var data []byte
 
-	if s.useLegacy {
+	if s.Replay != nil {
+		e, replayErr := s.Replay.Latest(record.KindCRConfig, cdn)
+		if replayErr != nil {
+			return nil, fmt.Errorf("replaying CRConfig: %v", replayErr)
+		}
+		data = e.Body
+		remoteAddr = localHostIP
+	} else if s.useLegacy {
 		ss := s.getLegacy()
 		if ss == nil {
 			return nil, ErrNilSession
@@ -360,7 +385,11 @@ func (s TrafficOpsSessionThreadsafe) CRConfigRaw(cdn string) ([]byte, error) {
 	}
 
 	if err == nil {
-		ioutil.WriteFile(s.CRConfigBackupFile, data, 0644)
+		// replayed snapshots are neither backed up nor recorded again
+		if s.Replay == nil {
+			ioutil.WriteFile(s.CRConfigBackupFile, data, 0644)
+			s.record(record.KindCRConfig, cdn, data)
+		}
 	} else {
 		if s.BackupFileExists() {
 			log.Errorln("using backup file for CRConfig snapshot due to error fetching CRConfig snapshot from Traffic Ops: " + err.Error())
@@ -441,6 +470,40 @@ func (s TrafficOpsSessionThreadsafe) fetchLegacyTMConfig(cdn string) (*tc.Traffi
 	return m.Upgrade(), e
 }
 
+// replayTMConfig returns the recorded monitoring config of the given CDN at
+// the current replay time, or the ReplayMonitoringFile if there is one.
+func (s TrafficOpsSessionThreadsafe) replayTMConfig(cdn string) (*tc.TrafficMonitorConfig, error) {
+	var data []byte
+	if s.ReplayMonitoringFile != "" {
+		b, err := ioutil.ReadFile(s.ReplayMonitoringFile)
+		if err != nil {
+			return nil, errors.New("reading replay monitoring file: " + err.Error())
+		}
+		data = b
+	} else {
+		e, err := s.Replay.Latest(record.KindMonitoring, cdn)
+		if err != nil {
+			return nil, err
+		}
+		data = e.Body
+	}
+	config := tc.TrafficMonitorConfig{}
+	json := jsoniter.ConfigFastest
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.New("unmarshalling recorded monitoring config: " + err.Error())
+	}
+	return &config, nil
+}
+
+// record records the given data fetched from Traffic Ops for the given CDN, if
+// recording.
+func (s TrafficOpsSessionThreadsafe) record(kind record.Kind, cdn string, data []byte) {
+	e := record.Entry{Kind: kind, ID: cdn, Time: time.Now(), Body: data}
+	if err := s.Recorder.Record(e); err != nil {
+		log.Errorln("recording " + string(kind) + " of CDN '" + cdn + "': " + err.Error())
+	}
+}
+
 // trafficMonitorConfigMapRaw returns the Traffic Monitor config map from the
 // Traffic Ops, directly from the monitoring endpoint. This is not usually
 // what is needed, rather monitoring needs the snapshotted CRConfig data, which
@@ -451,7 +514,9 @@ func (s TrafficOpsSessionThreadsafe) trafficMonitorConfigMapRaw(cdn string) (*tc
 	var configMap *tc.TrafficMonitorConfigMap
 	var err error
 
-	if s.useLegacy {
+	if s.Replay != nil {
+		config, err = s.replayTMConfig(cdn)
+	} else if s.useLegacy {
 		config, err = s.fetchLegacyTMConfig(cdn)
 	} else {
 		config, err = s.fetchTMConfig(cdn)
@@ -470,7 +535,7 @@ func (s TrafficOpsSessionThreadsafe) trafficMonitorConfigMapRaw(cdn string) (*tc
 
 	if err != nil {
 		// Default error case, no backup file exists
-		if !s.BackupFileExists() {
+		if s.Replay != nil || !s.BackupFileExists() {
 			return nil, err
 		}
 		log.Errorln("using backup file for monitoring config snapshot due to invalid monitoring config snapshot from Traffic Ops: " + err.Error())
@@ -490,8 +555,9 @@ func (s TrafficOpsSessionThreadsafe) trafficMonitorConfigMapRaw(cdn string) (*tc
 
 	json := jsoniter.ConfigFastest
 	data, err := json.Marshal(*config)
-	if err == nil {
+	if err == nil && s.Replay == nil {
 		ioutil.WriteFile(s.TMConfigBackupFile, data, 0644)
+		s.record(record.KindMonitoring, cdn, data)
 	}
 
 	return configMap, err
@@ -613,6 +679,14 @@ func (s TrafficOpsSessionThreadsafe) MonitorCDN(hostName string) (string, error)
 	var server tc.ServerV30
 	var err error
 
+	if s.Replay != nil {
+		cdns := s.Replay.IDs(record.KindCRConfig)
+		if len(cdns) != 1 {
+			return "", fmt.Errorf("getting monitor CDN: replay archive has %d CDNs, not 1", len(cdns))
+		}
+		return cdns[0], nil
+	}
+
 	if s.useLegacy {
 		server, err = s.fetchLegacyServerByHostname(hostName)
 	} else {
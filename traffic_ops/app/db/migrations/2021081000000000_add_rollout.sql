@@ -0,0 +1,63 @@
+/*
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS public.rollout (
+    id bigserial NOT NULL,
+    cdn text NOT NULL,
+    topology text,
+    username text NOT NULL,
+    status text NOT NULL DEFAULT 'running',
+    canary_percent integer NOT NULL DEFAULT 0,
+    canary_cachegroups text[] NOT NULL DEFAULT '{}',
+    wave_percent integer NOT NULL,
+    wave_wait_seconds integer NOT NULL DEFAULT 0,
+    wave_timeout_seconds integer NOT NULL,
+    check_health boolean NOT NULL DEFAULT FALSE,
+    max_unavailable_percent integer NOT NULL DEFAULT 0,
+    wave integer NOT NULL DEFAULT 0,
+    wave_started timestamp with time zone,
+    wave_completed timestamp with time zone,
+    message text,
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    last_updated timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT pk_rollout PRIMARY KEY (id),
+    CONSTRAINT fk_rollout_cdn FOREIGN KEY (cdn) REFERENCES cdn(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_rollout_topology FOREIGN KEY (topology) REFERENCES topology(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_rollout_username FOREIGN KEY (username) REFERENCES tm_user(username) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT rollout_status_check CHECK (status IN ('running', 'paused', 'aborted', 'failed', 'completed')),
+    CONSTRAINT rollout_canary_percent_check CHECK (canary_percent BETWEEN 0 AND 100),
+    CONSTRAINT rollout_wave_percent_check CHECK (wave_percent BETWEEN 1 AND 100),
+    CONSTRAINT rollout_max_unavailable_percent_check CHECK (max_unavailable_percent BETWEEN 0 AND 100)
+);
+
+-- a CDN may only have one rollout which may still queue updates.
+CREATE UNIQUE INDEX IF NOT EXISTS rollout_active_cdn_idx ON public.rollout (cdn) WHERE status IN ('running', 'paused', 'failed');
+
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON public.rollout;
+CREATE TRIGGER on_update_current_timestamp BEFORE UPDATE ON public.rollout FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TABLE IF NOT EXISTS public.rollout_server (
+    rollout bigint NOT NULL,
+    server bigint NOT NULL,
+    wave integer NOT NULL,
+    CONSTRAINT pk_rollout_server PRIMARY KEY (rollout, server),
+    CONSTRAINT fk_rollout_server_rollout FOREIGN KEY (rollout) REFERENCES rollout(id) ON DELETE CASCADE,
+    CONSTRAINT fk_rollout_server_server FOREIGN KEY (server) REFERENCES server(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS rollout_server_rollout_wave_idx ON public.rollout_server (rollout, wave);
+
+-- +goose Down
+DROP TABLE IF EXISTS public.rollout_server;
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON public.rollout;
+DROP TABLE IF EXISTS public.rollout;
@@ -0,0 +1,37 @@
+-- syntax:postgresql
+/*
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS public.deliveryservice_tls_policy (
+	deliveryservice bigint PRIMARY KEY REFERENCES public.deliveryservice(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	ciphers text[],
+	cipher_suites text[],
+	verify_client text CHECK (verify_client IN ('NONE', 'MODERATE', 'STRICT')),
+	host_sni_policy text CHECK (host_sni_policy IN ('DISABLED', 'PERMISSIVE', 'ENFORCED')),
+	ocsp_stapling boolean
+);
+
+CREATE TRIGGER update_ds_timestamp_on_tls_policy_insertion
+	AFTER INSERT ON public.deliveryservice_tls_policy
+	REFERENCING NEW TABLE AS new_table
+	FOR EACH STATEMENT EXECUTE PROCEDURE update_ds_timestamp_on_insert();
+
+CREATE TRIGGER update_ds_timestamp_on_tls_policy_delete
+	AFTER DELETE ON public.deliveryservice_tls_policy
+	REFERENCING OLD TABLE AS old_table
+	FOR EACH STATEMENT EXECUTE PROCEDURE update_ds_timestamp_on_delete();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS update_ds_timestamp_on_tls_policy_insertion ON public.deliveryservice_tls_policy;
+DROP TRIGGER IF EXISTS update_ds_timestamp_on_tls_policy_delete ON public.deliveryservice_tls_policy;
+DROP TABLE IF EXISTS public.deliveryservice_tls_policy;
@@ -0,0 +1,384 @@
+-- syntax:postgresql
+/*
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+-- +goose Up
+INSERT INTO public.capability (name, description) VALUES
+	('ACME-ACCOUNT:CREATE', 'Permission to create acme account objects'),
+	('ACME-ACCOUNT:DELETE', 'Permission to delete acme account objects'),
+	('ACME-ACCOUNT:READ', 'Permission to read acme account objects'),
+	('ACME-ACCOUNT:UPDATE', 'Permission to update acme account objects'),
+	('ACME:READ', 'Permission to read acme objects'),
+	('API-CAPABILITY:READ', 'Permission to read api capability objects'),
+	('ASN:CREATE', 'Permission to create asn objects'),
+	('ASN:DELETE', 'Permission to delete asn objects'),
+	('ASN:READ', 'Permission to read asn objects'),
+	('ASN:UPDATE', 'Permission to update asn objects'),
+	('ASYNC-STATUS:READ', 'Permission to read async status objects'),
+	('CACHE-GROUP:CREATE', 'Permission to create cache group objects'),
+	('CACHE-GROUP:DELETE', 'Permission to delete cache group objects'),
+	('CACHE-GROUP:READ', 'Permission to read cache group objects'),
+	('CACHE-GROUP:UPDATE', 'Permission to update cache group objects'),
+	('CAPABILITY:READ', 'Permission to read capability objects'),
+	('CDN-LOCK:CREATE', 'Permission to create cdn lock objects'),
+	('CDN-LOCK:DELETE', 'Permission to delete cdn lock objects'),
+	('CDN-LOCK:READ', 'Permission to read cdn lock objects'),
+	('CDN-NOTIFICATION:CREATE', 'Permission to create cdn notification objects'),
+	('CDN-NOTIFICATION:DELETE', 'Permission to delete cdn notification objects'),
+	('CDN-NOTIFICATION:READ', 'Permission to read cdn notification objects'),
+	('CDN:APPLY', 'Permission to apply cdn objects'),
+	('CDN:CREATE', 'Permission to create cdn objects'),
+	('CDN:DELETE', 'Permission to delete cdn objects'),
+	('CDN:PREVIEW', 'Permission to preview cdn objects'),
+	('CDN:READ', 'Permission to read cdn objects'),
+	('CDN:SNAPSHOT', 'Permission to snapshot cdn objects'),
+	('CDN:UPDATE', 'Permission to update cdn objects'),
+	('COORDINATE:CREATE', 'Permission to create coordinate objects'),
+	('COORDINATE:DELETE', 'Permission to delete coordinate objects'),
+	('COORDINATE:READ', 'Permission to read coordinate objects'),
+	('COORDINATE:UPDATE', 'Permission to update coordinate objects'),
+	('DBDUMP:READ', 'Permission to read dbdump objects'),
+	('DELIVERY-SERVICE:CREATE', 'Permission to create delivery service objects'),
+	('DELIVERY-SERVICE:DELETE', 'Permission to delete delivery service objects'),
+	('DELIVERY-SERVICE:READ', 'Permission to read delivery service objects'),
+	('DELIVERY-SERVICE:UPDATE', 'Permission to update delivery service objects'),
+	('DIVISION:CREATE', 'Permission to create division objects'),
+	('DIVISION:DELETE', 'Permission to delete division objects'),
+	('DIVISION:READ', 'Permission to read division objects'),
+	('DIVISION:UPDATE', 'Permission to update division objects'),
+	('DNSSEC-KEY:CREATE', 'Permission to create dnssec key objects'),
+	('DNSSEC-KEY:DELETE', 'Permission to delete dnssec key objects'),
+	('DNSSEC-KEY:READ', 'Permission to read dnssec key objects'),
+	('DNSSEC-KEY:UPDATE', 'Permission to update dnssec key objects'),
+	('DS-REQUEST-COMMENT:CREATE', 'Permission to create ds request comment objects'),
+	('DS-REQUEST-COMMENT:DELETE', 'Permission to delete ds request comment objects'),
+	('DS-REQUEST-COMMENT:UPDATE', 'Permission to update ds request comment objects'),
+	('DS-REQUEST:ASSIGN', 'Permission to assign ds request objects'),
+	('DS-REQUEST:CREATE', 'Permission to create ds request objects'),
+	('DS-REQUEST:DELETE', 'Permission to delete ds request objects'),
+	('DS-REQUEST:READ', 'Permission to read ds request objects'),
+	('DS-REQUEST:UPDATE', 'Permission to update ds request objects'),
+	('FEDERATION-MAPPING:CREATE', 'Permission to create federation mapping objects'),
+	('FEDERATION-MAPPING:DELETE', 'Permission to delete federation mapping objects'),
+	('FEDERATION-MAPPING:READ', 'Permission to read federation mapping objects'),
+	('FEDERATION-MAPPING:READ-ALL', 'Permission to read all federation mapping objects'),
+	('FEDERATION-MAPPING:UPDATE', 'Permission to update federation mapping objects'),
+	('FEDERATION-RESOLVER:CREATE', 'Permission to create federation resolver objects'),
+	('FEDERATION-RESOLVER:DELETE', 'Permission to delete federation resolver objects'),
+	('FEDERATION-RESOLVER:READ', 'Permission to read federation resolver objects'),
+	('FEDERATION:CREATE', 'Permission to create federation objects'),
+	('FEDERATION:DELETE', 'Permission to delete federation objects'),
+	('FEDERATION:READ', 'Permission to read federation objects'),
+	('FEDERATION:UPDATE', 'Permission to update federation objects'),
+	('ISO:GENERATE', 'Permission to generate iso objects'),
+	('ISO:READ', 'Permission to read iso objects'),
+	('JOB:CREATE', 'Permission to create job objects'),
+	('JOB:DELETE', 'Permission to delete job objects'),
+	('JOB:READ', 'Permission to read job objects'),
+	('JOB:UPDATE', 'Permission to update job objects'),
+	('LOG:READ', 'Permission to read log objects'),
+	('ORIGIN:CREATE', 'Permission to create origin objects'),
+	('ORIGIN:DELETE', 'Permission to delete origin objects'),
+	('ORIGIN:READ', 'Permission to read origin objects'),
+	('ORIGIN:UPDATE', 'Permission to update origin objects'),
+	('PARAMETER:CREATE', 'Permission to create parameter objects'),
+	('PARAMETER:DELETE', 'Permission to delete parameter objects'),
+	('PARAMETER:READ', 'Permission to read parameter objects'),
+	('PARAMETER:SECURE-READ', 'Permission to read the values of secure parameter objects'),
+	('PARAMETER:UPDATE', 'Permission to update parameter objects'),
+	('PHYSICAL-LOCATION:CREATE', 'Permission to create physical location objects'),
+	('PHYSICAL-LOCATION:DELETE', 'Permission to delete physical location objects'),
+	('PHYSICAL-LOCATION:READ', 'Permission to read physical location objects'),
+	('PHYSICAL-LOCATION:UPDATE', 'Permission to update physical location objects'),
+	('PLUGIN:READ', 'Permission to read plugin objects'),
+	('PROFILE-PARAMETER:CREATE', 'Permission to create profile parameter objects'),
+	('PROFILE-PARAMETER:DELETE', 'Permission to delete profile parameter objects'),
+	('PROFILE-PARAMETER:READ', 'Permission to read profile parameter objects'),
+	('PROFILE:CREATE', 'Permission to create profile objects'),
+	('PROFILE:DELETE', 'Permission to delete profile objects'),
+	('PROFILE:READ', 'Permission to read profile objects'),
+	('PROFILE:UPDATE', 'Permission to update profile objects'),
+	('REGION:CREATE', 'Permission to create region objects'),
+	('REGION:DELETE', 'Permission to delete region objects'),
+	('REGION:READ', 'Permission to read region objects'),
+	('REGION:UPDATE', 'Permission to update region objects'),
+	('ROLE:CREATE', 'Permission to create role objects'),
+	('ROLE:DELETE', 'Permission to delete role objects'),
+	('ROLE:READ', 'Permission to read role objects'),
+	('ROLE:UPDATE', 'Permission to update role objects'),
+	('ROLLOUT:CREATE', 'Permission to create rollout objects'),
+	('ROLLOUT:READ', 'Permission to read rollout objects'),
+	('ROLLOUT:UPDATE', 'Permission to update rollout objects'),
+	('SERVER-CAPABILITY:CREATE', 'Permission to create server capability objects'),
+	('SERVER-CAPABILITY:DELETE', 'Permission to delete server capability objects'),
+	('SERVER-CAPABILITY:READ', 'Permission to read server capability objects'),
+	('SERVER-CAPABILITY:UPDATE', 'Permission to update server capability objects'),
+	('SERVER-CHECK-EXTENSION:CREATE', 'Permission to create server check extension objects'),
+	('SERVER-CHECK-EXTENSION:DELETE', 'Permission to delete server check extension objects'),
+	('SERVER-CHECK-EXTENSION:READ', 'Permission to read server check extension objects'),
+	('SERVER-CHECK:CREATE', 'Permission to create server check objects'),
+	('SERVER-CHECK:READ', 'Permission to read server check objects'),
+	('SERVER:CREATE', 'Permission to create server objects'),
+	('SERVER:DELETE', 'Permission to delete server objects'),
+	('SERVER:QUEUE', 'Permission to queue server objects'),
+	('SERVER:READ', 'Permission to read server objects'),
+	('SERVER:STATUS', 'Permission to status server objects'),
+	('SERVER:UPDATE', 'Permission to update server objects'),
+	('SERVICE-CATEGORY:CREATE', 'Permission to create service category objects'),
+	('SERVICE-CATEGORY:DELETE', 'Permission to delete service category objects'),
+	('SERVICE-CATEGORY:READ', 'Permission to read service category objects'),
+	('SERVICE-CATEGORY:UPDATE', 'Permission to update service category objects'),
+	('SSL-KEY:CREATE', 'Permission to create ssl key objects'),
+	('SSL-KEY:DELETE', 'Permission to delete ssl key objects'),
+	('SSL-KEY:IMPORT', 'Permission to import ssl key objects'),
+	('SSL-KEY:READ', 'Permission to read ssl key objects'),
+	('SSL-KEY:UPDATE', 'Permission to update ssl key objects'),
+	('STAT:CREATE', 'Permission to create stat objects'),
+	('STAT:READ', 'Permission to read stat objects'),
+	('STATIC-DN:CREATE', 'Permission to create static dn objects'),
+	('STATIC-DN:DELETE', 'Permission to delete static dn objects'),
+	('STATIC-DN:READ', 'Permission to read static dn objects'),
+	('STATIC-DN:UPDATE', 'Permission to update static dn objects'),
+	('STATUS:CREATE', 'Permission to create status objects'),
+	('STATUS:DELETE', 'Permission to delete status objects'),
+	('STATUS:READ', 'Permission to read status objects'),
+	('STATUS:UPDATE', 'Permission to update status objects'),
+	('STEERING-CONFIG:READ', 'Permission to read steering config objects'),
+	('STEERING:CREATE', 'Permission to create steering objects'),
+	('STEERING:DELETE', 'Permission to delete steering objects'),
+	('STEERING:READ', 'Permission to read steering objects'),
+	('STEERING:UPDATE', 'Permission to update steering objects'),
+	('TENANT:CREATE', 'Permission to create tenant objects'),
+	('TENANT:DELETE', 'Permission to delete tenant objects'),
+	('TENANT:READ', 'Permission to read tenant objects'),
+	('TENANT:UPDATE', 'Permission to update tenant objects'),
+	('TOPOLOGY:CREATE', 'Permission to create topology objects'),
+	('TOPOLOGY:DELETE', 'Permission to delete topology objects'),
+	('TOPOLOGY:READ', 'Permission to read topology objects'),
+	('TOPOLOGY:UPDATE', 'Permission to update topology objects'),
+	('TRAFFIC-VAULT:PING', 'Permission to ping traffic vault objects'),
+	('TRAFFIC-VAULT:READ', 'Permission to read traffic vault objects'),
+	('TYPE:CREATE', 'Permission to create type objects'),
+	('TYPE:DELETE', 'Permission to delete type objects'),
+	('TYPE:READ', 'Permission to read type objects'),
+	('TYPE:UPDATE', 'Permission to update type objects'),
+	('URI-SIGNING-KEY:CREATE', 'Permission to create uri signing key objects'),
+	('URI-SIGNING-KEY:DELETE', 'Permission to delete uri signing key objects'),
+	('URI-SIGNING-KEY:READ', 'Permission to read uri signing key objects'),
+	('URI-SIGNING-KEY:UPDATE', 'Permission to update uri signing key objects'),
+	('URL-SIG-KEY:CREATE', 'Permission to create url sig key objects'),
+	('URL-SIG-KEY:DELETE', 'Permission to delete url sig key objects'),
+	('URL-SIG-KEY:READ', 'Permission to read url sig key objects'),
+	('USER:CREATE', 'Permission to create user objects'),
+	('USER:READ', 'Permission to read user objects'),
+	('USER:UPDATE', 'Permission to update user objects')
+ON CONFLICT (name) DO NOTHING;
+
+-- The default roles are normally created by seeding, which happens after
+-- migration; they're created here so that their permissions can be granted.
+INSERT INTO public.role (name, description, priv_level) VALUES
+	('admin', 'Has access to everything.', 30),
+	('operations', 'Has all reads and most write capabilities', 20),
+	('read-only', 'Has access to all read capabilities', 10),
+	('disallowed', 'Block all access', 0),
+	('cache-operator', 'Can read everything, queue updates, and change the statuses of servers', 10)
+ON CONFLICT (name) DO NOTHING;
+
+-- Every existing Role is given the permissions of the routes it could use by
+-- its privilege level.
+INSERT INTO public.role_capability (role_id, cap_name)
+SELECT r.id, p.name
+FROM public.role AS r
+JOIN (VALUES
+		('ACME-ACCOUNT:CREATE', 30),
+		('ACME-ACCOUNT:DELETE', 30),
+		('ACME-ACCOUNT:READ', 30),
+		('ACME-ACCOUNT:UPDATE', 30),
+		('ACME:READ', 20),
+		('API-CAPABILITY:READ', 10),
+		('ASN:CREATE', 20),
+		('ASN:DELETE', 20),
+		('ASN:READ', 10),
+		('ASN:UPDATE', 20),
+		('ASYNC-STATUS:READ', 20),
+		('CACHE-GROUP:CREATE', 20),
+		('CACHE-GROUP:DELETE', 20),
+		('CACHE-GROUP:READ', 10),
+		('CACHE-GROUP:UPDATE', 20),
+		('CAPABILITY:READ', 10),
+		('CDN-LOCK:CREATE', 20),
+		('CDN-LOCK:DELETE', 20),
+		('CDN-LOCK:READ', 10),
+		('CDN-NOTIFICATION:CREATE', 20),
+		('CDN-NOTIFICATION:DELETE', 20),
+		('CDN-NOTIFICATION:READ', 10),
+		('CDN:APPLY', 20),
+		('CDN:CREATE', 20),
+		('CDN:DELETE', 20),
+		('CDN:PREVIEW', 20),
+		('CDN:READ', 10),
+		('CDN:SNAPSHOT', 20),
+		('CDN:UPDATE', 20),
+		('COORDINATE:CREATE', 20),
+		('COORDINATE:DELETE', 20),
+		('COORDINATE:READ', 10),
+		('COORDINATE:UPDATE', 20),
+		('DBDUMP:READ', 30),
+		('DELIVERY-SERVICE:CREATE', 20),
+		('DELIVERY-SERVICE:DELETE', 20),
+		('DELIVERY-SERVICE:READ', 10),
+		('DELIVERY-SERVICE:UPDATE', 20),
+		('DIVISION:CREATE', 20),
+		('DIVISION:DELETE', 20),
+		('DIVISION:READ', 10),
+		('DIVISION:UPDATE', 20),
+		('DNSSEC-KEY:CREATE', 30),
+		('DNSSEC-KEY:DELETE', 30),
+		('DNSSEC-KEY:READ', 30),
+		('DNSSEC-KEY:UPDATE', 20),
+		('DS-REQUEST-COMMENT:CREATE', 15),
+		('DS-REQUEST-COMMENT:DELETE', 15),
+		('DS-REQUEST-COMMENT:UPDATE', 15),
+		('DS-REQUEST:ASSIGN', 20),
+		('DS-REQUEST:CREATE', 15),
+		('DS-REQUEST:DELETE', 15),
+		('DS-REQUEST:READ', 10),
+		('DS-REQUEST:UPDATE', 15),
+		('FEDERATION-MAPPING:CREATE', 15),
+		('FEDERATION-MAPPING:DELETE', 15),
+		('FEDERATION-MAPPING:READ', 15),
+		('FEDERATION-MAPPING:READ-ALL', 30),
+		('FEDERATION-MAPPING:UPDATE', 15),
+		('FEDERATION-RESOLVER:CREATE', 30),
+		('FEDERATION-RESOLVER:DELETE', 30),
+		('FEDERATION-RESOLVER:READ', 10),
+		('FEDERATION:CREATE', 30),
+		('FEDERATION:DELETE', 30),
+		('FEDERATION:READ', 10),
+		('FEDERATION:UPDATE', 30),
+		('ISO:GENERATE', 20),
+		('ISO:READ', 10),
+		('JOB:CREATE', 15),
+		('JOB:DELETE', 15),
+		('JOB:READ', 10),
+		('JOB:UPDATE', 15),
+		('LOG:READ', 10),
+		('ORIGIN:CREATE', 20),
+		('ORIGIN:DELETE', 20),
+		('ORIGIN:READ', 10),
+		('ORIGIN:UPDATE', 20),
+		('PARAMETER:CREATE', 20),
+		('PARAMETER:DELETE', 20),
+		('PARAMETER:READ', 10),
+		('PARAMETER:SECURE-READ', 30),
+		('PARAMETER:UPDATE', 20),
+		('PHYSICAL-LOCATION:CREATE', 20),
+		('PHYSICAL-LOCATION:DELETE', 20),
+		('PHYSICAL-LOCATION:READ', 10),
+		('PHYSICAL-LOCATION:UPDATE', 20),
+		('PLUGIN:READ', 10),
+		('PROFILE-PARAMETER:CREATE', 20),
+		('PROFILE-PARAMETER:DELETE', 20),
+		('PROFILE-PARAMETER:READ', 10),
+		('PROFILE:CREATE', 20),
+		('PROFILE:DELETE', 20),
+		('PROFILE:READ', 10),
+		('PROFILE:UPDATE', 20),
+		('REGION:CREATE', 20),
+		('REGION:DELETE', 20),
+		('REGION:READ', 10),
+		('REGION:UPDATE', 20),
+		('ROLE:CREATE', 30),
+		('ROLE:DELETE', 30),
+		('ROLE:READ', 10),
+		('ROLE:UPDATE', 30),
+		('ROLLOUT:CREATE', 20),
+		('ROLLOUT:READ', 10),
+		('ROLLOUT:UPDATE', 20),
+		('SERVER-CAPABILITY:CREATE', 20),
+		('SERVER-CAPABILITY:DELETE', 20),
+		('SERVER-CAPABILITY:READ', 10),
+		('SERVER-CAPABILITY:UPDATE', 20),
+		('SERVER-CHECK-EXTENSION:CREATE', 10),
+		('SERVER-CHECK-EXTENSION:DELETE', 10),
+		('SERVER-CHECK-EXTENSION:READ', 10),
+		('SERVER-CHECK:CREATE', -1),
+		('SERVER-CHECK:READ', 10),
+		('SERVER:CREATE', 20),
+		('SERVER:DELETE', 20),
+		('SERVER:QUEUE', 20),
+		('SERVER:READ', 10),
+		('SERVER:STATUS', 20),
+		('SERVER:UPDATE', 20),
+		('SERVICE-CATEGORY:CREATE', 20),
+		('SERVICE-CATEGORY:DELETE', 20),
+		('SERVICE-CATEGORY:READ', 10),
+		('SERVICE-CATEGORY:UPDATE', 20),
+		('SSL-KEY:CREATE', 20),
+		('SSL-KEY:DELETE', 20),
+		('SSL-KEY:IMPORT', 30),
+		('SSL-KEY:READ', 30),
+		('SSL-KEY:UPDATE', 20),
+		('STAT:CREATE', 10),
+		('STAT:READ', 10),
+		('STATIC-DN:CREATE', 20),
+		('STATIC-DN:DELETE', 20),
+		('STATIC-DN:READ', 10),
+		('STATIC-DN:UPDATE', 20),
+		('STATUS:CREATE', 20),
+		('STATUS:DELETE', 20),
+		('STATUS:READ', 10),
+		('STATUS:UPDATE', 20),
+		('STEERING-CONFIG:READ', 15),
+		('STEERING:CREATE', 15),
+		('STEERING:DELETE', 15),
+		('STEERING:READ', 10),
+		('STEERING:UPDATE', 15),
+		('TENANT:CREATE', 20),
+		('TENANT:DELETE', 20),
+		('TENANT:READ', 10),
+		('TENANT:UPDATE', 20),
+		('TOPOLOGY:CREATE', 20),
+		('TOPOLOGY:DELETE', 20),
+		('TOPOLOGY:READ', 10),
+		('TOPOLOGY:UPDATE', 20),
+		('TRAFFIC-VAULT:PING', 10),
+		('TRAFFIC-VAULT:READ', 30),
+		('TYPE:CREATE', 20),
+		('TYPE:DELETE', 20),
+		('TYPE:READ', 10),
+		('TYPE:UPDATE', 20),
+		('URI-SIGNING-KEY:CREATE', 30),
+		('URI-SIGNING-KEY:DELETE', 30),
+		('URI-SIGNING-KEY:READ', 30),
+		('URI-SIGNING-KEY:UPDATE', 30),
+		('URL-SIG-KEY:CREATE', 20),
+		('URL-SIG-KEY:DELETE', 20),
+		('URL-SIG-KEY:READ', 10),
+		('USER:CREATE', 20),
+		('USER:READ', 10),
+		('USER:UPDATE', 20)
+	) AS p(name, priv_level) ON r.priv_level >= p.priv_level
+WHERE r.name <> 'disallowed'
+ON CONFLICT (role_id, cap_name) DO NOTHING;
+
+INSERT INTO public.role_capability (role_id, cap_name)
+SELECT r.id, p.name
+FROM public.role AS r
+CROSS JOIN (VALUES ('SERVER:QUEUE'), ('SERVER:STATUS')) AS p(name)
+WHERE r.name = 'cache-operator'
+ON CONFLICT (role_id, cap_name) DO NOTHING;
+
+-- +goose Down
+DELETE FROM public.role WHERE name = 'cache-operator' AND NOT EXISTS (SELECT 1 FROM public.tm_user WHERE tm_user.role = role.id);
+DELETE FROM public.role_capability WHERE cap_name IN ('ACME-ACCOUNT:CREATE', 'ACME-ACCOUNT:DELETE', 'ACME-ACCOUNT:READ', 'ACME-ACCOUNT:UPDATE', 'ACME:READ', 'API-CAPABILITY:READ', 'ASN:CREATE', 'ASN:DELETE', 'ASN:READ', 'ASN:UPDATE', 'ASYNC-STATUS:READ', 'CACHE-GROUP:CREATE', 'CACHE-GROUP:DELETE', 'CACHE-GROUP:READ', 'CACHE-GROUP:UPDATE', 'CAPABILITY:READ', 'CDN-LOCK:CREATE', 'CDN-LOCK:DELETE', 'CDN-LOCK:READ', 'CDN-NOTIFICATION:CREATE', 'CDN-NOTIFICATION:DELETE', 'CDN-NOTIFICATION:READ', 'CDN:APPLY', 'CDN:CREATE', 'CDN:DELETE', 'CDN:PREVIEW', 'CDN:READ', 'CDN:SNAPSHOT', 'CDN:UPDATE', 'COORDINATE:CREATE', 'COORDINATE:DELETE', 'COORDINATE:READ', 'COORDINATE:UPDATE', 'DBDUMP:READ', 'DELIVERY-SERVICE:CREATE', 'DELIVERY-SERVICE:DELETE', 'DELIVERY-SERVICE:READ', 'DELIVERY-SERVICE:UPDATE', 'DIVISION:CREATE', 'DIVISION:DELETE', 'DIVISION:READ', 'DIVISION:UPDATE', 'DNSSEC-KEY:CREATE', 'DNSSEC-KEY:DELETE', 'DNSSEC-KEY:READ', 'DNSSEC-KEY:UPDATE', 'DS-REQUEST-COMMENT:CREATE', 'DS-REQUEST-COMMENT:DELETE', 'DS-REQUEST-COMMENT:UPDATE', 'DS-REQUEST:ASSIGN', 'DS-REQUEST:CREATE', 'DS-REQUEST:DELETE', 'DS-REQUEST:READ', 'DS-REQUEST:UPDATE', 'FEDERATION-MAPPING:CREATE', 'FEDERATION-MAPPING:DELETE', 'FEDERATION-MAPPING:READ', 'FEDERATION-MAPPING:READ-ALL', 'FEDERATION-MAPPING:UPDATE', 'FEDERATION-RESOLVER:CREATE', 'FEDERATION-RESOLVER:DELETE', 'FEDERATION-RESOLVER:READ', 'FEDERATION:CREATE', 'FEDERATION:DELETE', 'FEDERATION:READ', 'FEDERATION:UPDATE', 'ISO:GENERATE', 'ISO:READ', 'JOB:CREATE', 'JOB:DELETE', 'JOB:READ', 'JOB:UPDATE', 'LOG:READ', 'ORIGIN:CREATE', 'ORIGIN:DELETE', 'ORIGIN:READ', 'ORIGIN:UPDATE', 'PARAMETER:CREATE', 'PARAMETER:DELETE', 'PARAMETER:READ', 'PARAMETER:SECURE-READ', 'PARAMETER:UPDATE', 'PHYSICAL-LOCATION:CREATE', 'PHYSICAL-LOCATION:DELETE', 'PHYSICAL-LOCATION:READ', 'PHYSICAL-LOCATION:UPDATE', 'PLUGIN:READ', 'PROFILE-PARAMETER:CREATE', 'PROFILE-PARAMETER:DELETE', 'PROFILE-PARAMETER:READ', 'PROFILE:CREATE', 'PROFILE:DELETE', 'PROFILE:READ', 'PROFILE:UPDATE', 'REGION:CREATE', 'REGION:DELETE', 'REGION:READ', 'REGION:UPDATE', 'ROLE:CREATE', 'ROLE:DELETE', 'ROLE:READ', 'ROLE:UPDATE', 'ROLLOUT:CREATE', 'ROLLOUT:READ', 'ROLLOUT:UPDATE', 'SERVER-CAPABILITY:CREATE', 'SERVER-CAPABILITY:DELETE', 'SERVER-CAPABILITY:READ', 'SERVER-CAPABILITY:UPDATE', 'SERVER-CHECK-EXTENSION:CREATE', 'SERVER-CHECK-EXTENSION:DELETE', 'SERVER-CHECK-EXTENSION:READ', 'SERVER-CHECK:CREATE', 'SERVER-CHECK:READ', 'SERVER:CREATE', 'SERVER:DELETE', 'SERVER:QUEUE', 'SERVER:READ', 'SERVER:STATUS', 'SERVER:UPDATE', 'SERVICE-CATEGORY:CREATE', 'SERVICE-CATEGORY:DELETE', 'SERVICE-CATEGORY:READ', 'SERVICE-CATEGORY:UPDATE', 'SSL-KEY:CREATE', 'SSL-KEY:DELETE', 'SSL-KEY:IMPORT', 'SSL-KEY:READ', 'SSL-KEY:UPDATE', 'STAT:CREATE', 'STAT:READ', 'STATIC-DN:CREATE', 'STATIC-DN:DELETE', 'STATIC-DN:READ', 'STATIC-DN:UPDATE', 'STATUS:CREATE', 'STATUS:DELETE', 'STATUS:READ', 'STATUS:UPDATE', 'STEERING-CONFIG:READ', 'STEERING:CREATE', 'STEERING:DELETE', 'STEERING:READ', 'STEERING:UPDATE', 'TENANT:CREATE', 'TENANT:DELETE', 'TENANT:READ', 'TENANT:UPDATE', 'TOPOLOGY:CREATE', 'TOPOLOGY:DELETE', 'TOPOLOGY:READ', 'TOPOLOGY:UPDATE', 'TRAFFIC-VAULT:PING', 'TRAFFIC-VAULT:READ', 'TYPE:CREATE', 'TYPE:DELETE', 'TYPE:READ', 'TYPE:UPDATE', 'URI-SIGNING-KEY:CREATE', 'URI-SIGNING-KEY:DELETE', 'URI-SIGNING-KEY:READ', 'URI-SIGNING-KEY:UPDATE', 'URL-SIG-KEY:CREATE', 'URL-SIG-KEY:DELETE', 'URL-SIG-KEY:READ', 'USER:CREATE', 'USER:READ', 'USER:UPDATE');
+DELETE FROM public.capability WHERE name IN ('ACME-ACCOUNT:CREATE', 'ACME-ACCOUNT:DELETE', 'ACME-ACCOUNT:READ', 'ACME-ACCOUNT:UPDATE', 'ACME:READ', 'API-CAPABILITY:READ', 'ASN:CREATE', 'ASN:DELETE', 'ASN:READ', 'ASN:UPDATE', 'ASYNC-STATUS:READ', 'CACHE-GROUP:CREATE', 'CACHE-GROUP:DELETE', 'CACHE-GROUP:READ', 'CACHE-GROUP:UPDATE', 'CAPABILITY:READ', 'CDN-LOCK:CREATE', 'CDN-LOCK:DELETE', 'CDN-LOCK:READ', 'CDN-NOTIFICATION:CREATE', 'CDN-NOTIFICATION:DELETE', 'CDN-NOTIFICATION:READ', 'CDN:APPLY', 'CDN:CREATE', 'CDN:DELETE', 'CDN:PREVIEW', 'CDN:READ', 'CDN:SNAPSHOT', 'CDN:UPDATE', 'COORDINATE:CREATE', 'COORDINATE:DELETE', 'COORDINATE:READ', 'COORDINATE:UPDATE', 'DBDUMP:READ', 'DELIVERY-SERVICE:CREATE', 'DELIVERY-SERVICE:DELETE', 'DELIVERY-SERVICE:READ', 'DELIVERY-SERVICE:UPDATE', 'DIVISION:CREATE', 'DIVISION:DELETE', 'DIVISION:READ', 'DIVISION:UPDATE', 'DNSSEC-KEY:CREATE', 'DNSSEC-KEY:DELETE', 'DNSSEC-KEY:READ', 'DNSSEC-KEY:UPDATE', 'DS-REQUEST-COMMENT:CREATE', 'DS-REQUEST-COMMENT:DELETE', 'DS-REQUEST-COMMENT:UPDATE', 'DS-REQUEST:ASSIGN', 'DS-REQUEST:CREATE', 'DS-REQUEST:DELETE', 'DS-REQUEST:READ', 'DS-REQUEST:UPDATE', 'FEDERATION-MAPPING:CREATE', 'FEDERATION-MAPPING:DELETE', 'FEDERATION-MAPPING:READ', 'FEDERATION-MAPPING:READ-ALL', 'FEDERATION-MAPPING:UPDATE', 'FEDERATION-RESOLVER:CREATE', 'FEDERATION-RESOLVER:DELETE', 'FEDERATION-RESOLVER:READ', 'FEDERATION:CREATE', 'FEDERATION:DELETE', 'FEDERATION:READ', 'FEDERATION:UPDATE', 'ISO:GENERATE', 'ISO:READ', 'JOB:CREATE', 'JOB:DELETE', 'JOB:READ', 'JOB:UPDATE', 'LOG:READ', 'ORIGIN:CREATE', 'ORIGIN:DELETE', 'ORIGIN:READ', 'ORIGIN:UPDATE', 'PARAMETER:CREATE', 'PARAMETER:DELETE', 'PARAMETER:READ', 'PARAMETER:SECURE-READ', 'PARAMETER:UPDATE', 'PHYSICAL-LOCATION:CREATE', 'PHYSICAL-LOCATION:DELETE', 'PHYSICAL-LOCATION:READ', 'PHYSICAL-LOCATION:UPDATE', 'PLUGIN:READ', 'PROFILE-PARAMETER:CREATE', 'PROFILE-PARAMETER:DELETE', 'PROFILE-PARAMETER:READ', 'PROFILE:CREATE', 'PROFILE:DELETE', 'PROFILE:READ', 'PROFILE:UPDATE', 'REGION:CREATE', 'REGION:DELETE', 'REGION:READ', 'REGION:UPDATE', 'ROLE:CREATE', 'ROLE:DELETE', 'ROLE:READ', 'ROLE:UPDATE', 'ROLLOUT:CREATE', 'ROLLOUT:READ', 'ROLLOUT:UPDATE', 'SERVER-CAPABILITY:CREATE', 'SERVER-CAPABILITY:DELETE', 'SERVER-CAPABILITY:READ', 'SERVER-CAPABILITY:UPDATE', 'SERVER-CHECK-EXTENSION:CREATE', 'SERVER-CHECK-EXTENSION:DELETE', 'SERVER-CHECK-EXTENSION:READ', 'SERVER-CHECK:CREATE', 'SERVER-CHECK:READ', 'SERVER:CREATE', 'SERVER:DELETE', 'SERVER:QUEUE', 'SERVER:READ', 'SERVER:STATUS', 'SERVER:UPDATE', 'SERVICE-CATEGORY:CREATE', 'SERVICE-CATEGORY:DELETE', 'SERVICE-CATEGORY:READ', 'SERVICE-CATEGORY:UPDATE', 'SSL-KEY:CREATE', 'SSL-KEY:DELETE', 'SSL-KEY:IMPORT', 'SSL-KEY:READ', 'SSL-KEY:UPDATE', 'STAT:CREATE', 'STAT:READ', 'STATIC-DN:CREATE', 'STATIC-DN:DELETE', 'STATIC-DN:READ', 'STATIC-DN:UPDATE', 'STATUS:CREATE', 'STATUS:DELETE', 'STATUS:READ', 'STATUS:UPDATE', 'STEERING-CONFIG:READ', 'STEERING:CREATE', 'STEERING:DELETE', 'STEERING:READ', 'STEERING:UPDATE', 'TENANT:CREATE', 'TENANT:DELETE', 'TENANT:READ', 'TENANT:UPDATE', 'TOPOLOGY:CREATE', 'TOPOLOGY:DELETE', 'TOPOLOGY:READ', 'TOPOLOGY:UPDATE', 'TRAFFIC-VAULT:PING', 'TRAFFIC-VAULT:READ', 'TYPE:CREATE', 'TYPE:DELETE', 'TYPE:READ', 'TYPE:UPDATE', 'URI-SIGNING-KEY:CREATE', 'URI-SIGNING-KEY:DELETE', 'URI-SIGNING-KEY:READ', 'URI-SIGNING-KEY:UPDATE', 'URL-SIG-KEY:CREATE', 'URL-SIG-KEY:DELETE', 'URL-SIG-KEY:READ', 'USER:CREATE', 'USER:READ', 'USER:UPDATE');
@@ -0,0 +1,105 @@
+/*
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS public.resource_lock (
+    id bigserial NOT NULL,
+    resource_type text NOT NULL,
+    resource text NOT NULL,
+    username text NOT NULL,
+    shared boolean NOT NULL DEFAULT FALSE,
+    message text,
+    expires timestamp with time zone NOT NULL,
+    last_updated timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT pk_resource_lock PRIMARY KEY (id),
+    CONSTRAINT fk_resource_lock_username FOREIGN KEY (username) REFERENCES tm_user(username) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT resource_lock_resource_type_check CHECK (resource_type IN ('cdn', 'deliveryservice', 'topology', 'cachegroup', 'profile')),
+    CONSTRAINT resource_lock_resource_username_unique UNIQUE (resource_type, resource, username)
+);
+
+CREATE INDEX IF NOT EXISTS resource_lock_resource_idx ON public.resource_lock (resource_type, resource);
+
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON public.resource_lock;
+CREATE TRIGGER on_update_current_timestamp BEFORE UPDATE ON public.resource_lock FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+-- Locks refer to their resources by name, so they're removed with their
+-- resources, and resources can't be renamed while they're locked.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION on_change_locked_resource()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM public.resource_lock
+        WHERE resource_type = TG_ARGV[0]
+          AND resource = to_jsonb(OLD) ->> TG_ARGV[1];
+        RETURN OLD;
+    END IF;
+    IF (to_jsonb(OLD) ->> TG_ARGV[1]) IS DISTINCT FROM (to_jsonb(NEW) ->> TG_ARGV[1]) THEN
+        IF EXISTS (
+            SELECT 1 FROM public.resource_lock
+            WHERE resource_type = TG_ARGV[0]
+              AND resource = to_jsonb(OLD) ->> TG_ARGV[1]
+              AND expires > now()
+        ) THEN
+            RAISE EXCEPTION '% % has resource locks, which must be released before it can be renamed', TG_ARGV[0], to_jsonb(OLD) ->> TG_ARGV[1];
+        END IF;
+        DELETE FROM public.resource_lock
+        WHERE resource_type = TG_ARGV[0]
+          AND resource = to_jsonb(OLD) ->> TG_ARGV[1];
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS on_change_locked_resource ON public.cdn;
+CREATE TRIGGER on_change_locked_resource AFTER UPDATE OR DELETE ON public.cdn FOR EACH ROW EXECUTE PROCEDURE on_change_locked_resource('cdn', 'name');
+DROP TRIGGER IF EXISTS on_change_locked_resource ON public.deliveryservice;
+CREATE TRIGGER on_change_locked_resource AFTER UPDATE OR DELETE ON public.deliveryservice FOR EACH ROW EXECUTE PROCEDURE on_change_locked_resource('deliveryservice', 'xml_id');
+DROP TRIGGER IF EXISTS on_change_locked_resource ON public.topology;
+CREATE TRIGGER on_change_locked_resource AFTER UPDATE OR DELETE ON public.topology FOR EACH ROW EXECUTE PROCEDURE on_change_locked_resource('topology', 'name');
+DROP TRIGGER IF EXISTS on_change_locked_resource ON public.cachegroup;
+CREATE TRIGGER on_change_locked_resource AFTER UPDATE OR DELETE ON public.cachegroup FOR EACH ROW EXECUTE PROCEDURE on_change_locked_resource('cachegroup', 'name');
+DROP TRIGGER IF EXISTS on_change_locked_resource ON public.profile;
+CREATE TRIGGER on_change_locked_resource AFTER UPDATE OR DELETE ON public.profile FOR EACH ROW EXECUTE PROCEDURE on_change_locked_resource('profile', 'name');
+
+INSERT INTO public.capability (name, description) VALUES
+	('RESOURCE-LOCK:CREATE', 'Permission to create resource lock objects'),
+	('RESOURCE-LOCK:DELETE', 'Permission to delete resource lock objects'),
+	('RESOURCE-LOCK:READ', 'Permission to read resource lock objects'),
+	('RESOURCE-LOCK:TAKEOVER', 'Permission to take over or release the resource locks of other users')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO public.role_capability (role_id, cap_name)
+SELECT r.id, p.name
+FROM public.role AS r
+JOIN (VALUES
+		('RESOURCE-LOCK:CREATE', 20),
+		('RESOURCE-LOCK:DELETE', 20),
+		('RESOURCE-LOCK:READ', 10),
+		('RESOURCE-LOCK:TAKEOVER', 30)
+	) AS p(name, priv_level) ON r.priv_level >= p.priv_level
+WHERE r.name <> 'disallowed'
+ON CONFLICT (role_id, cap_name) DO NOTHING;
+
+-- +goose Down
+DELETE FROM public.role_capability WHERE cap_name IN ('RESOURCE-LOCK:CREATE', 'RESOURCE-LOCK:DELETE', 'RESOURCE-LOCK:READ', 'RESOURCE-LOCK:TAKEOVER');
+DELETE FROM public.capability WHERE name IN ('RESOURCE-LOCK:CREATE', 'RESOURCE-LOCK:DELETE', 'RESOURCE-LOCK:READ', 'RESOURCE-LOCK:TAKEOVER');
+DROP TRIGGER IF EXISTS on_change_locked_resource ON public.profile;
+DROP TRIGGER IF EXISTS on_change_locked_resource ON public.cachegroup;
+DROP TRIGGER IF EXISTS on_change_locked_resource ON public.topology;
+DROP TRIGGER IF EXISTS on_change_locked_resource ON public.deliveryservice;
+DROP TRIGGER IF EXISTS on_change_locked_resource ON public.cdn;
+DROP FUNCTION IF EXISTS on_change_locked_resource();
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON public.resource_lock;
+DROP TABLE IF EXISTS public.resource_lock;
@@ -0,0 +1,85 @@
+/*
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS public.dsr_approval_policy (
+    id bigserial NOT NULL,
+    name text NOT NULL,
+    tenant_id bigint,
+    type_id bigint,
+    required_approvals integer NOT NULL DEFAULT 1,
+    approver_roles text[] NOT NULL DEFAULT '{}',
+    required_fields text[] NOT NULL DEFAULT '{}',
+    reject_on_validation_failure boolean NOT NULL DEFAULT FALSE,
+    apply_on_approval boolean NOT NULL DEFAULT FALSE,
+    last_updated timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT pk_dsr_approval_policy PRIMARY KEY (id),
+    CONSTRAINT dsr_approval_policy_name_unique UNIQUE (name),
+    CONSTRAINT fk_dsr_approval_policy_tenant FOREIGN KEY (tenant_id) REFERENCES tenant(id) ON DELETE CASCADE,
+    CONSTRAINT fk_dsr_approval_policy_type FOREIGN KEY (type_id) REFERENCES type(id) ON DELETE CASCADE,
+    CONSTRAINT dsr_approval_policy_required_approvals_check CHECK (required_approvals > 0)
+);
+
+-- There may be only one policy for each combination of Tenant and Delivery
+-- Service Type, where either may be unspecified (NULL).
+CREATE UNIQUE INDEX IF NOT EXISTS dsr_approval_policy_scope_idx ON public.dsr_approval_policy (COALESCE(tenant_id, 0), COALESCE(type_id, 0));
+
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON public.dsr_approval_policy;
+CREATE TRIGGER on_update_current_timestamp BEFORE UPDATE ON public.dsr_approval_policy FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TABLE IF NOT EXISTS public.deliveryservice_request_approval (
+    id bigserial NOT NULL,
+    deliveryservice_request_id bigint NOT NULL,
+    approver_id bigint NOT NULL,
+    role text NOT NULL,
+    comment text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT pk_deliveryservice_request_approval PRIMARY KEY (id),
+    CONSTRAINT fk_deliveryservice_request_approval_dsr FOREIGN KEY (deliveryservice_request_id) REFERENCES deliveryservice_request(id) ON DELETE CASCADE,
+    CONSTRAINT fk_deliveryservice_request_approval_approver FOREIGN KEY (approver_id) REFERENCES tm_user(id) ON DELETE CASCADE,
+    CONSTRAINT deliveryservice_request_approval_approver_unique UNIQUE (deliveryservice_request_id, approver_id)
+);
+
+ALTER TABLE public.deliveryservice_request
+    ADD COLUMN IF NOT EXISTS applied_at timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS applied_last_updated timestamp with time zone;
+
+INSERT INTO public.capability (name, description) VALUES
+	('DS-REQUEST:APPROVE', 'Permission to approve ds request objects'),
+	('DS-REQUEST-POLICY:CREATE', 'Permission to create ds request approval policy objects'),
+	('DS-REQUEST-POLICY:DELETE', 'Permission to delete ds request approval policy objects'),
+	('DS-REQUEST-POLICY:READ', 'Permission to read ds request approval policy objects'),
+	('DS-REQUEST-POLICY:UPDATE', 'Permission to update ds request approval policy objects')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO public.role_capability (role_id, cap_name)
+SELECT r.id, p.name
+FROM public.role AS r
+JOIN (VALUES
+		('DS-REQUEST:APPROVE', 20),
+		('DS-REQUEST-POLICY:CREATE', 30),
+		('DS-REQUEST-POLICY:DELETE', 30),
+		('DS-REQUEST-POLICY:READ', 10),
+		('DS-REQUEST-POLICY:UPDATE', 30)
+	) AS p(name, priv_level) ON r.priv_level >= p.priv_level
+WHERE r.name <> 'disallowed'
+ON CONFLICT (role_id, cap_name) DO NOTHING;
+
+-- +goose Down
+DELETE FROM public.role_capability WHERE cap_name IN ('DS-REQUEST:APPROVE', 'DS-REQUEST-POLICY:CREATE', 'DS-REQUEST-POLICY:DELETE', 'DS-REQUEST-POLICY:READ', 'DS-REQUEST-POLICY:UPDATE');
+DELETE FROM public.capability WHERE name IN ('DS-REQUEST:APPROVE', 'DS-REQUEST-POLICY:CREATE', 'DS-REQUEST-POLICY:DELETE', 'DS-REQUEST-POLICY:READ', 'DS-REQUEST-POLICY:UPDATE');
+ALTER TABLE public.deliveryservice_request
+    DROP COLUMN IF EXISTS applied_last_updated,
+    DROP COLUMN IF EXISTS applied_at;
+DROP TABLE IF EXISTS public.deliveryservice_request_approval;
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON public.dsr_approval_policy;
+DROP TABLE IF EXISTS public.dsr_approval_policy;
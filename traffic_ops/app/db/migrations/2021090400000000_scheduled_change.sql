@@ -0,0 +1,63 @@
+/*
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS public.scheduled_change (
+    id bigserial NOT NULL,
+    action text NOT NULL,
+    cdn text NOT NULL,
+    target text NOT NULL,
+    parameters jsonb NOT NULL DEFAULT '{}',
+    window_start timestamp with time zone NOT NULL,
+    window_end timestamp with time zone NOT NULL,
+    username text NOT NULL,
+    status text NOT NULL DEFAULT 'scheduled',
+    comment text,
+    message text,
+    executed_at timestamp with time zone,
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    last_updated timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT pk_scheduled_change PRIMARY KEY (id),
+    CONSTRAINT fk_scheduled_change_cdn FOREIGN KEY (cdn) REFERENCES cdn(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_scheduled_change_username FOREIGN KEY (username) REFERENCES tm_user(username) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT scheduled_change_action_check CHECK (action IN ('server-status', 'queue-updates', 'snapshot', 'deliveryservice-update')),
+    CONSTRAINT scheduled_change_status_check CHECK (status IN ('scheduled', 'completed', 'failed', 'missed', 'cancelled')),
+    CONSTRAINT scheduled_change_window_check CHECK (window_end > window_start)
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_change_due_idx ON public.scheduled_change (window_start) WHERE status = 'scheduled';
+
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON public.scheduled_change;
+CREATE TRIGGER on_update_current_timestamp BEFORE UPDATE ON public.scheduled_change FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+INSERT INTO public.capability (name, description) VALUES
+	('SCHEDULED-CHANGE:CREATE', 'Permission to schedule changes'),
+	('SCHEDULED-CHANGE:READ', 'Permission to read scheduled changes'),
+	('SCHEDULED-CHANGE:UPDATE', 'Permission to cancel scheduled changes')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO public.role_capability (role_id, cap_name)
+SELECT r.id, p.name
+FROM public.role AS r
+JOIN (VALUES
+		('SCHEDULED-CHANGE:CREATE', 20),
+		('SCHEDULED-CHANGE:READ', 10),
+		('SCHEDULED-CHANGE:UPDATE', 20)
+	) AS p(name, priv_level) ON r.priv_level >= p.priv_level
+WHERE r.name <> 'disallowed'
+ON CONFLICT (role_id, cap_name) DO NOTHING;
+
+-- +goose Down
+DELETE FROM public.role_capability WHERE cap_name IN ('SCHEDULED-CHANGE:CREATE', 'SCHEDULED-CHANGE:READ', 'SCHEDULED-CHANGE:UPDATE');
+DELETE FROM public.capability WHERE name IN ('SCHEDULED-CHANGE:CREATE', 'SCHEDULED-CHANGE:READ', 'SCHEDULED-CHANGE:UPDATE');
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON public.scheduled_change;
+DROP TABLE IF EXISTS public.scheduled_change;
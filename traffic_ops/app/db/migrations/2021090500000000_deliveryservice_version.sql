@@ -0,0 +1,45 @@
+/*
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+-- +goose Up
+CREATE TABLE IF NOT EXISTS public.deliveryservice_version (
+    id bigserial NOT NULL,
+    deliveryservice bigint NOT NULL,
+    username text NOT NULL,
+    data jsonb NOT NULL,
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT pk_deliveryservice_version PRIMARY KEY (id),
+    CONSTRAINT fk_deliveryservice_version_deliveryservice FOREIGN KEY (deliveryservice) REFERENCES deliveryservice(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS deliveryservice_version_deliveryservice_idx ON public.deliveryservice_version (deliveryservice, id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION deliveryservice_version_immutable()
+RETURNS TRIGGER AS $$
+BEGIN
+	RAISE EXCEPTION 'delivery service versions cannot be changed';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS deliveryservice_version_immutable ON public.deliveryservice_version;
+CREATE TRIGGER deliveryservice_version_immutable BEFORE UPDATE ON public.deliveryservice_version FOR EACH ROW EXECUTE PROCEDURE deliveryservice_version_immutable();
+
+ALTER TABLE public.deliveryservice_request
+    ADD COLUMN IF NOT EXISTS applied_version_id bigint CONSTRAINT fk_deliveryservice_request_applied_version REFERENCES deliveryservice_version(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE public.deliveryservice_request DROP COLUMN IF EXISTS applied_version_id;
+DROP TRIGGER IF EXISTS deliveryservice_version_immutable ON public.deliveryservice_version;
+DROP FUNCTION IF EXISTS deliveryservice_version_immutable();
+DROP TABLE IF EXISTS public.deliveryservice_version;
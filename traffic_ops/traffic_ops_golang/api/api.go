@@ -79,6 +79,11 @@ const (
 	APIRespWrittenKey      = "respwritten"
 	PathParamsKey          = "pathParams"
 	TrafficVaultContextKey = "tv"
+	// TransactionContextKey is the key of a transaction shared by the
+	// requests of a batch, or by a conditional request and the request for
+	// the current representation of the resource it changes, which NewInfo
+	// uses instead of beginning its own.
+	TransactionContextKey = "tx"
 )
 
 const influxServersQuery = `
@@ -98,10 +103,8 @@ type APIResponse struct {
 }
 
 type APIResponseWithSummary struct {
-	Response interface{} `json:"response"`
-	Summary  struct {
-		Count uint64 `json:"count"`
-	} `json:"summary"`
+	Response interface{}        `json:"response"`
+	Summary  tc.ResponseSummary `json:"summary"`
 }
 
 // GoneHandler is an http.Handler function that just writes a 410 Gone response
@@ -151,11 +154,13 @@ func WriteRespRaw(w http.ResponseWriter, r *http.Request, v interface{}) {
 // object. It also provides a "summary" section to the response object that
 // contains the given "count".
 func WriteRespWithSummary(w http.ResponseWriter, r *http.Request, v interface{}, count uint64) {
-	var resp APIResponseWithSummary
-	resp.Response = v
-	resp.Summary.Count = count
+	WriteRespSummary(w, r, v, tc.ResponseSummary{Count: count})
+}
 
-	WriteRespRaw(w, r, resp)
+// WriteRespSummary is like WriteRespWithSummary, but takes the whole summary,
+// e.g. to include the cursor of the next page.
+func WriteRespSummary(w http.ResponseWriter, r *http.Request, v interface{}, summary tc.ResponseSummary) {
+	WriteRespRaw(w, r, APIResponseWithSummary{Response: v, Summary: summary})
 }
 
 // WriteRespVals is like WriteResp, but also takes a map of root-level values to write. The API most commonly needs these for meta-parameters, like size, limit, and orderby.
@@ -191,7 +196,7 @@ func WriteIMSHitResp(w http.ResponseWriter, r *http.Request, t time.Time) {
 	w.WriteHeader(http.StatusNotModified)
 }
 
-// HandleErr handles an API error, rolling back the transaction, writing the given statusCode and userErr to the user, and logging the sysErr. If userErr is nil, the text of the HTTP statusCode is written.
+// HandleErr handles an API error, rolling back the transaction (unless it's shared by a batch of requests, which rolls it back itself), writing the given statusCode and userErr to the user, and logging the sysErr. If userErr is nil, the text of the HTTP statusCode is written.
 //
 // The tx may be nil, if there is no transaction. Passing a nil tx is strongly discouraged if a transaction exists, because it will result in copy-paste errors for the common APIInfo use case.
 //
@@ -203,14 +208,25 @@ func HandleErr(w http.ResponseWriter, r *http.Request, tx *sql.Tx, statusCode in
 	}
 	setRespWritten(r)
 
-	if tx != nil {
-		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
-			log.Errorln("rolling back transaction: " + err.Error())
-		}
-	}
+	rollbackUnlessShared(r, tx)
 	handleSimpleErr(w, r, statusCode, userErr, sysErr)
 }
 
+// rollbackUnlessShared rolls back the given transaction, if any, unless it's
+// the transaction shared through the request's context, which is ended by
+// whatever shared it.
+func rollbackUnlessShared(r *http.Request, tx *sql.Tx) {
+	if tx == nil {
+		return
+	}
+	if shared, ok := r.Context().Value(TransactionContextKey).(*sqlx.Tx); ok && shared != nil && shared.Tx == tx {
+		return
+	}
+	if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+		log.Errorln("rolling back transaction: " + err.Error())
+	}
+}
+
 func HandleErrOptionalDeprecation(w http.ResponseWriter, r *http.Request, tx *sql.Tx, statusCode int, userErr error, sysErr error, deprecated bool, alternative *string) {
 	if deprecated {
 		HandleDeprecatedErr(w, r, tx, statusCode, userErr, sysErr, alternative)
@@ -232,11 +248,7 @@ func HandleDeprecatedErr(w http.ResponseWriter, r *http.Request, tx *sql.Tx, sta
 		// Don't return, attempt to rollback and write the error anyway
 	}
 
-	if tx != nil {
-		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
-			log.Errorln("rolling back transaction: " + err.Error())
-		}
-	}
+	rollbackUnlessShared(r, tx)
 
 	alerts := CreateDeprecationAlerts(alternative)
 
@@ -505,6 +517,12 @@ type APIInfo struct {
 	Vault     trafficvault.TrafficVault
 	Config    *config.Config
 	request   *http.Request
+	// sharedTx is whether Tx belongs to a batch of requests, in which case
+	// Close doesn't commit it.
+	sharedTx bool
+	// summary is the summary of the objects read by GenericRead, if it read
+	// a page of them.
+	summary *tc.ResponseSummary
 }
 
 // NewInfo get and returns the context info needed by handlers. It also returns any user error, any system error, and the status code which should be returned to the client if an error occurred.
@@ -563,6 +581,21 @@ func NewInfo(r *http.Request, requiredParams []string, intParamNames []string) (
 	if userErr != nil || sysErr != nil {
 		return &APIInfo{Tx: &sqlx.Tx{}}, userErr, sysErr, errCode
 	}
+	if tx, ok := r.Context().Value(TransactionContextKey).(*sqlx.Tx); ok && tx != nil {
+		return &APIInfo{
+			Config:    cfg,
+			ReqID:     reqID,
+			Version:   version,
+			Params:    params,
+			IntParams: intParams,
+			User:      user,
+			Tx:        tx,
+			CancelTx:  func() {},
+			Vault:     tv,
+			request:   r,
+			sharedTx:  true,
+		}, nil, nil, http.StatusOK
+	}
 	dbCtx, cancelTx := context.WithTimeout(r.Context(), time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second) //only place we could call cancel here is in APIInfo.Close(), which already will rollback the transaction (which is all cancel will do.)
 	tx, err := db.BeginTxx(dbCtx, nil)                                                                        // must be last, MUST not return an error if this succeeds, without closing the tx
 	if err != nil {
@@ -597,7 +630,7 @@ INSERT INTO log (
 // CreateChangeLog creates a new changelog message at the APICHANGE level for
 // the current user.
 func (inf APIInfo) CreateChangeLog(msg string) {
-	_, err := inf.Tx.Tx.Exec(createChangeLogQuery, ApiChange, msg, inf.User.ID)
+	_, err := inf.Tx.Tx.Exec(createChangeLogQuery, ApiChange, changeLogGroupMsg(inf.Tx.Tx, msg), inf.User.ID)
 	if err != nil {
 		log.Errorf("Inserting chage log level '%s' message '%s' for user '%s': %v", ApiChange, msg, inf.User.UserName, err)
 	}
@@ -663,9 +696,13 @@ func (inf APIInfo) CheckPrecondition(query string, args ...interface{}) (int, er
 
 // Close implements the io.Closer interface. It should be called in a defer immediately after NewInfo().
 //
-// Close will commit the transaction, if it hasn't been rolled back.
+// Close will commit the transaction, if it hasn't been rolled back, unless
+// it's shared by a batch of requests, which commits it itself.
 func (inf *APIInfo) Close() {
 	defer inf.CancelTx()
+	if inf.sharedTx {
+		return
+	}
 	if err := inf.Tx.Tx.Commit(); err != nil && err != sql.ErrTxDone {
 		log.Errorln("committing transaction: " + err.Error())
 	}
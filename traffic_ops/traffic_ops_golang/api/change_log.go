@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
@@ -80,14 +81,39 @@ func CreateChangeLogBuildMsg(level string, action string, user *auth.CurrentUser
 }
 
 func CreateChangeLogRawErr(level string, msg string, user *auth.CurrentUser, tx *sql.Tx) error {
-	if _, err := tx.Exec(`INSERT INTO log (level, message, tm_user) VALUES ($1, $2, $3)`, level, msg, user.ID); err != nil {
+	if _, err := tx.Exec(`INSERT INTO log (level, message, tm_user) VALUES ($1, $2, $3)`, level, changeLogGroupMsg(tx, msg), user.ID); err != nil {
 		return errors.New("Inserting change log level '" + level + "' message '" + msg + "' user '" + user.UserName + "': " + err.Error())
 	}
 	return nil
 }
 
 func CreateChangeLogRawTx(level string, msg string, user *auth.CurrentUser, tx *sql.Tx) {
-	if _, err := tx.Exec(`INSERT INTO log (level, message, tm_user) VALUES ($1, $2, $3)`, level, msg, user.ID); err != nil {
+	if _, err := tx.Exec(`INSERT INTO log (level, message, tm_user) VALUES ($1, $2, $3)`, level, changeLogGroupMsg(tx, msg), user.ID); err != nil {
 		log.Errorln("Inserting change log level '" + level + "' message '" + msg + "' user '" + user.UserName + "': " + err.Error())
 	}
 }
+
+// changeLogGroups maps transactions to the group, such as a batch of
+// requests, to which the change log messages made in them belong.
+var changeLogGroups = sync.Map{}
+
+// SetChangeLogGroup groups the change log messages made in the given
+// transaction, by prefixing them with the given group, until
+// ClearChangeLogGroup is called.
+func SetChangeLogGroup(tx *sql.Tx, group string) {
+	changeLogGroups.Store(tx, group)
+}
+
+// ClearChangeLogGroup stops grouping the change log messages made in the given
+// transaction.
+func ClearChangeLogGroup(tx *sql.Tx) {
+	changeLogGroups.Delete(tx)
+}
+
+func changeLogGroupMsg(tx *sql.Tx, msg string) string {
+	group, ok := changeLogGroups.Load(tx)
+	if !ok {
+		return msg
+	}
+	return group.(string) + ": " + msg
+}
@@ -21,6 +21,7 @@ package api
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -191,10 +192,11 @@ func GenericRead(h http.Header, val GenericReader, useIMS bool) ([]interface{},
 	code := http.StatusOK
 	var maxTime time.Time
 	var runSecond bool
-	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(val.APIInfo().Params, val.ParamColumns())
+	clauses, errs := dbhelpers.BuildQueryClauses(val.APIInfo().Params, val.ParamColumns())
 	if len(errs) > 0 {
 		return nil, util.JoinErrs(errs), nil, http.StatusBadRequest, nil
 	}
+	where, orderBy, pagination, queryValues := clauses.Where, clauses.OrderBy, clauses.Pagination, clauses.Values
 	if useIMS {
 		runSecond, maxTime = TryIfModifiedSinceQuery(val, h, where, orderBy, pagination, queryValues)
 		if !runSecond {
@@ -221,9 +223,46 @@ func GenericRead(h http.Header, val GenericReader, useIMS bool) ([]interface{},
 		}
 		vals = append(vals, v)
 	}
+
+	inf := val.APIInfo()
+	if clauses.Limit >= 0 && inf.Version != nil && inf.Version.Major >= 4 {
+		summary := tc.ResponseSummary{}
+		countRows, err := inf.Tx.NamedQuery(`SELECT COUNT(*) FROM (`+val.SelectQuery()+clauses.FilterWhere+`) AS q`, queryValues)
+		if err != nil {
+			return nil, nil, errors.New("counting " + val.GetType() + ": " + err.Error()), http.StatusInternalServerError, &maxTime
+		}
+		defer countRows.Close()
+		if countRows.Next() {
+			if err := countRows.Scan(&summary.Count); err != nil {
+				return nil, nil, errors.New("scanning " + val.GetType() + " count: " + err.Error()), http.StatusInternalServerError, &maxTime
+			}
+		}
+		if len(vals) > 0 {
+			if id, ok := objectID(vals[len(vals)-1]); ok {
+				summary.NextCursor = dbhelpers.NextCursor(inf.Params, len(vals), id)
+			}
+		}
+		inf.summary = &summary
+	}
 	return vals, nil, nil, code, &maxTime
 }
 
+// objectID returns the "id" property of the JSON representation of the given
+// object, if it has one.
+func objectID(v interface{}) (int, bool) {
+	bts, err := json.Marshal(v)
+	if err != nil {
+		return 0, false
+	}
+	obj := struct {
+		ID *int `json:"id"`
+	}{}
+	if err := json.Unmarshal(bts, &obj); err != nil || obj.ID == nil {
+		return 0, false
+	}
+	return *obj.ID, true
+}
+
 // GenericUpdate handles the common update case, where the update returns the new last_modified time.
 func GenericUpdate(h http.Header, val GenericUpdater) (error, error, int) {
 	existingLastUpdated, found, err := val.GetLastUpdated()
@@ -31,10 +31,12 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 )
 
 type KeyFieldInfo struct {
@@ -140,7 +142,7 @@ func SetLastModifiedHeader(r *http.Request, useIMS bool) bool {
 }
 
 type errWriterFunc func(w http.ResponseWriter, r *http.Request, tx *sql.Tx, statusCode int, userErr error, sysErr error)
-type readSuccessWriterFunc func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{})
+type readSuccessWriterFunc func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{}, summary interface{})
 type deleteSuccessWriterFunc func(w http.ResponseWriter, r *http.Request, message string)
 
 // ReadHandler creates a handler function from the pointer to a struct implementing the Reader interface
@@ -152,8 +154,12 @@ func ReadHandler(reader Reader) http.HandlerFunc {
 	return readHandlerHelper(
 		reader,
 		HandleErr,
-		func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{}) {
+		func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{}, summary interface{}) {
 			w.WriteHeader(statusCode)
+			if summary != nil {
+				WriteRespVals(w, r, results, map[string]interface{}{"summary": summary})
+				return
+			}
 			WriteResp(w, r, results)
 		},
 	)
@@ -167,7 +173,7 @@ func DeprecatedReadHandler(reader Reader, alternative *string) http.HandlerFunc
 		func(w http.ResponseWriter, r *http.Request, tx *sql.Tx, statusCode int, userErr error, sysErr error) {
 			HandleDeprecatedErr(w, r, tx, statusCode, userErr, sysErr, alternative)
 		},
-		func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{}) {
+		func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{}, summary interface{}) {
 			alerts := CreateDeprecationAlerts(alternative)
 			WriteAlertsObj(w, r, statusCode, alerts, results)
 		},
@@ -213,8 +219,82 @@ func readHandlerHelper(reader Reader, errHandler errWriterFunc, successHandler r
 			date := maxTime.Format(rfc.LastModifiedFormat)
 			w.Header().Add(rfc.LastModified, date)
 		}
-		successHandler(w, r, errCode, results)
+
+		summary := interface{}(nil)
+		if inf.summary != nil {
+			summary = inf.summary
+		} else if len(results) > 0 && inf.Version != nil && inf.Version.Major >= 4 {
+			// Readers which don't count their objects can still be paged with
+			// cursors.
+			if id, ok := objectID(results[len(results)-1]); ok {
+				if next := dbhelpers.NextCursor(inf.Params, len(results), id); next != "" {
+					summary = map[string]string{"nextCursor": next}
+				}
+			}
+		}
+
+		if fields, ok := inf.Params[FieldsQueryParam]; ok {
+			selected, err := SelectFields(results, fields)
+			if err != nil {
+				errHandler(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+				return
+			}
+			successHandler(w, r, errCode, selected, summary)
+			return
+		}
+		successHandler(w, r, errCode, results, summary)
+	}
+}
+
+// FieldsQueryParam is the query parameter of a comma-separated list of the
+// properties of the objects to read, so that a response includes only those.
+const FieldsQueryParam = "fields"
+
+// SelectFields returns the JSON representations of the given array of objects,
+// with only the given comma-separated list of their properties. It returns an
+// error if no object has one of the properties.
+func SelectFields(v interface{}, fields string) ([]map[string]json.RawMessage, error) {
+	bts, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.New("fields: objects can't be selected from")
+	}
+	objs := []map[string]json.RawMessage{}
+	if err := json.Unmarshal(bts, &objs); err != nil {
+		return nil, errors.New("fields: objects can't be selected from")
+	}
+
+	names := strings.Split(fields, ",")
+	found := make(map[string]bool, len(names))
+	selected := make([]map[string]json.RawMessage, 0, len(objs))
+	for _, obj := range objs {
+		selectedObj := make(map[string]json.RawMessage, len(names))
+		for _, name := range names {
+			if val, ok := obj[name]; ok {
+				selectedObj[name] = val
+				found[name] = true
+			}
+		}
+		selected = append(selected, selectedObj)
+	}
+	if len(objs) > 0 {
+		for _, name := range names {
+			if !found[name] {
+				return nil, errors.New("fields: unknown field '" + name + "'")
+			}
+		}
+	}
+	return selected, nil
+}
+
+// IsTime returns an error if the given string isn't a time, in RFC3339 format,
+// the format of lastUpdated properties, or a date.
+func IsTime(s string) error {
+	for _, layout := range []string{time.RFC3339, tc.TimeLayout, "2006-01-02"} {
+		if _, err := time.Parse(layout, s); err == nil {
+			return nil
+		}
 	}
+	return errors.New("cannot parse to time")
 }
 
 // UpdateHandler creates a handler function from the pointer to a struct implementing the Updater interface
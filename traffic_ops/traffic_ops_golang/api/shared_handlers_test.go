@@ -21,6 +21,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -347,3 +348,43 @@ func TestDeleteHandler(t *testing.T) {
 		t.Error("Expected body", body, "got", w.Body.String())
 	}
 }
+
+func TestSelectFields(t *testing.T) {
+	type obj struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type,omitempty"`
+	}
+	objs := []obj{{ID: 1, Name: "one", Type: "a"}, {ID: 2, Name: "two"}}
+
+	selected, err := SelectFields(objs, "id,type")
+	if err != nil {
+		t.Fatalf("unexpected error selecting fields: %v", err)
+	}
+	bts, err := json.Marshal(selected)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling selected fields: %v", err)
+	}
+	expected := `[{"id":1,"type":"a"},{"id":2}]`
+	if string(bts) != expected {
+		t.Errorf("expected selected fields %s, actual: %s", expected, bts)
+	}
+
+	if _, err := SelectFields(objs, "id,unknown"); err == nil {
+		t.Error("expected an error selecting an unknown field, actual: nil")
+	}
+	if _, err := SelectFields([]obj{}, "unknown"); err != nil {
+		t.Errorf("expected no error selecting fields of no objects, actual: %v", err)
+	}
+}
+
+func TestIsTime(t *testing.T) {
+	for _, s := range []string{"2021-01-02T03:04:05Z", "2021-01-02 03:04:05+00", "2021-01-02"} {
+		if err := IsTime(s); err != nil {
+			t.Errorf("expected %q to be a time, actual error: %v", s, err)
+		}
+	}
+	if err := IsTime("yesterday"); err == nil {
+		t.Error("expected 'yesterday' not to be a time, actual: nil error")
+	}
+}
@@ -53,6 +53,7 @@ func (v *TOASNV11) NewReadObj() interface{}       { return &tc.ASNNullable{} }
 func (v *TOASNV11) SelectQuery() string           { return selectQuery() }
 func (v *TOASNV11) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
+		"lastUpdated":    dbhelpers.WhereColumnInfo{Column: "a.last_updated", Checker: api.IsTime},
 		"asn":            dbhelpers.WhereColumnInfo{Column: "a.asn", Checker: api.IsInt},
 		"cachegroup":     dbhelpers.WhereColumnInfo{Column: "c.id", Checker: api.IsInt},
 		"id":             dbhelpers.WhereColumnInfo{Column: "a.id", Checker: api.IsInt},
@@ -41,9 +41,38 @@ type CurrentUser struct {
 	PrivLevel    int            `json:"privLevel" db:"priv_level"`
 	TenantID     int            `json:"tenantId" db:"tenant_id"`
 	Role         int            `json:"role" db:"role"`
+	RoleName     string         `json:"roleName" db:"role_name"`
 	Capabilities pq.StringArray `json:"capabilities" db:"capabilities"`
 }
 
+// AdminRoleName is the name of the Role whose users have every permission,
+// regardless of the permissions actually assigned to it.
+const AdminRoleName = "admin"
+
+// Can returns whether the user has all of the given permissions.
+func (u CurrentUser) Can(permissions ...string) bool {
+	return len(u.MissingPermissions(permissions...)) == 0
+}
+
+// MissingPermissions returns those of the given permissions which the user
+// doesn't have, in the order given.
+func (u CurrentUser) MissingPermissions(permissions ...string) []string {
+	if u.RoleName == AdminRoleName {
+		return nil
+	}
+	has := make(map[string]struct{}, len(u.Capabilities))
+	for _, perm := range u.Capabilities {
+		has[perm] = struct{}{}
+	}
+	missing := []string{}
+	for _, perm := range permissions {
+		if _, ok := has[perm]; !ok {
+			missing = append(missing, perm)
+		}
+	}
+	return missing
+}
+
 type PasswordForm struct {
 	Username string `json:"u"`
 	Password string `json:"p"`
@@ -81,6 +110,7 @@ func GetCurrentUserFromDB(DB *sqlx.DB, user string, timeout time.Duration) (Curr
 SELECT
   r.priv_level,
   r.id as role,
+  r.name AS role_name,
   u.id,
   u.username,
   COALESCE(u.tenant_id, -1) AS tenant_id,
@@ -95,7 +125,7 @@ WHERE
 
 	var currentUserInfo CurrentUser
 	if DB == nil {
-		return CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, []string{}}, nil, errors.New("no db provided to GetCurrentUserFromDB"), http.StatusInternalServerError
+		return CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}}, nil, errors.New("no db provided to GetCurrentUserFromDB"), http.StatusInternalServerError
 	}
 	dbCtx, dbClose := context.WithTimeout(context.Background(), timeout)
 	defer dbClose()
@@ -103,11 +133,11 @@ WHERE
 	err := DB.GetContext(dbCtx, &currentUserInfo, qry, user)
 	switch {
 	case err == sql.ErrNoRows:
-		return CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, []string{}}, errors.New("user not found"), fmt.Errorf("checking user %v info: user not in database", user), http.StatusUnauthorized
+		return CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}}, errors.New("user not found"), fmt.Errorf("checking user %v info: user not in database", user), http.StatusUnauthorized
 	case err == context.DeadlineExceeded || err == context.Canceled:
-		return CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, []string{}}, nil, fmt.Errorf("db access timed out: %s number of open connections: %d\n", err, DB.Stats().OpenConnections), http.StatusServiceUnavailable
+		return CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}}, nil, fmt.Errorf("db access timed out: %s number of open connections: %d\n", err, DB.Stats().OpenConnections), http.StatusServiceUnavailable
 	case err != nil:
-		return CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, []string{}}, nil, fmt.Errorf("Error checking user %v info: %v", user, err.Error()), http.StatusInternalServerError
+		return CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}}, nil, fmt.Errorf("Error checking user %v info: %v", user, err.Error()), http.StatusInternalServerError
 	default:
 		return currentUserInfo, nil, nil, http.StatusOK
 	}
@@ -123,7 +153,7 @@ func GetCurrentUser(ctx context.Context) (*CurrentUser, error) {
 			return nil, fmt.Errorf("CurrentUser found with bad type: %T", v)
 		}
 	}
-	return &CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, []string{}}, errors.New("No user found in Context")
+	return &CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}}, errors.New("No user found in Context")
 }
 
 func CheckLocalUserIsAllowed(form PasswordForm, db *sqlx.DB, timeout time.Duration) (bool, error, error) {
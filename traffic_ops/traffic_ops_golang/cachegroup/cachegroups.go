@@ -616,6 +616,18 @@ func (cg *TOCacheGroup) Update(h http.Header) (error, error, int) {
 	if userErr != nil || sysErr != nil {
 		return userErr, sysErr, errCode
 	}
+	if cg.Name != nil {
+		name, ok, err := dbhelpers.GetCacheGroupNameFromID(cg.ReqInfo.Tx.Tx, *cg.ID)
+		if err != nil {
+			return nil, err, http.StatusInternalServerError
+		}
+		if ok {
+			userErr, sysErr, errCode = dbhelpers.CheckIfResourceCanBeRenamed(cg.ReqInfo.Tx.Tx, tc.ResourceLockTypeCacheGroup, string(name), *cg.Name)
+			if userErr != nil || sysErr != nil {
+				return userErr, sysErr, errCode
+			}
+		}
+	}
 	coordinateID, userErr, sysErr, errCode := cg.handleCoordinateUpdate()
 	if userErr != nil || sysErr != nil {
 		return userErr, sysErr, errCode
@@ -32,6 +32,7 @@ import (
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 
 	"github.com/lib/pq"
@@ -62,6 +63,13 @@ func DSPostHandlerV31(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	for _, dsID := range req.DeliveryServices {
+		if _, err := deliveryservice.CreateVersion(inf.Tx, dsID, inf.User); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("assigning delivery services to cachegroup: "+err.Error()))
+			return
+		}
+	}
+
 	if err, errCode := writeChangeLog(inf.Tx.Tx, inf.User, inf.IntParams["id"]); err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, errCode, nil, err)
 		return
@@ -94,12 +102,29 @@ func DSPostHandlerV40(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
 		return
 	}
+	userErr, sysErr, statusCode = dbhelpers.CheckIfCurrentUserCanModifyCachegroupResources(inf.Tx.Tx, []int{inf.IntParams["id"]}, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
+	userErr, sysErr, statusCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServicesWithIDs(inf.Tx.Tx, req.DeliveryServices, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
 	resp, vals, userErr, sysErr, errCode := postDSes(inf.Tx.Tx, inf.User, inf.IntParams["id"], req.DeliveryServices)
 	if userErr != nil || sysErr != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
 		return
 	}
 
+	for _, dsID := range req.DeliveryServices {
+		if _, err := deliveryservice.CreateVersion(inf.Tx, dsID, inf.User); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("assigning delivery services to cachegroup: "+err.Error()))
+			return
+		}
+	}
+
 	if err, errCode := writeChangeLog(inf.Tx.Tx, inf.User, inf.IntParams["id"]); err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, errCode, nil, err)
 	}
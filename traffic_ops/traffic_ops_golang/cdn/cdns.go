@@ -58,6 +58,7 @@ func (v *TOCDN) NewReadObj() interface{}       { return &tc.CDNNullable{} }
 func (v *TOCDN) SelectQuery() string           { return selectQuery() }
 func (v *TOCDN) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
+		"lastUpdated":   dbhelpers.WhereColumnInfo{Column: "last_updated", Checker: api.IsTime},
 		"domainName":    dbhelpers.WhereColumnInfo{Column: "domain_name"},
 		"dnssecEnabled": dbhelpers.WhereColumnInfo{Column: "dnssec_enabled"},
 		"id":            dbhelpers.WhereColumnInfo{Column: "id", Checker: api.IsInt},
@@ -147,6 +148,18 @@ func (cdn *TOCDN) Update(h http.Header) (error, error, int) {
 		if userErr != nil || sysErr != nil {
 			return userErr, sysErr, errCode
 		}
+		if cdn.Name != nil {
+			name, ok, err := dbhelpers.GetCDNNameFromID(cdn.APIInfo().Tx.Tx, int64(*cdn.ID))
+			if err != nil {
+				return nil, err, http.StatusInternalServerError
+			}
+			if ok {
+				userErr, sysErr, errCode = dbhelpers.CheckIfResourceCanBeRenamed(cdn.APIInfo().Tx.Tx, tc.ResourceLockTypeCDN, string(name), *cdn.Name)
+				if userErr != nil || sysErr != nil {
+					return userErr, sysErr, errCode
+				}
+			}
+		}
 	}
 	*cdn.DomainName = strings.ToLower(*cdn.DomainName)
 	return api.GenericUpdate(h, cdn)
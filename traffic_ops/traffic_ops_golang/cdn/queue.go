@@ -30,6 +30,7 @@ import (
 
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/rollout"
 )
 
 func Queue(w http.ResponseWriter, r *http.Request) {
@@ -61,7 +62,11 @@ func Queue(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
 		return
 	}
-	if err := queueUpdates(inf.Tx.Tx, int64(inf.IntParams["id"]), reqObj.Action == "queue"); err != nil {
+	if userErr, sysErr, statusCode := rollout.CheckQueueAllowed(inf.Tx.Tx, string(cdnName)); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
+	if err := QueueUpdates(inf.Tx.Tx, int64(inf.IntParams["id"]), reqObj.Action == "queue"); err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("CDN queueing updates: "+err.Error()))
 		return
 	}
@@ -69,7 +74,9 @@ func Queue(w http.ResponseWriter, r *http.Request) {
 	api.WriteResp(w, r, tc.CDNQueueUpdateResponse{Action: reqObj.Action, CDNID: int64(inf.IntParams["id"])})
 }
 
-func queueUpdates(tx *sql.Tx, cdnID int64, queue bool) error {
+// QueueUpdates queues updates on all of the servers of the CDN with the given
+// ID, or dequeues them if queue is false.
+func QueueUpdates(tx *sql.Tx, cdnID int64, queue bool) error {
 	if _, err := tx.Exec(`UPDATE server SET upd_pending = $1 WHERE server.cdn_id = $2`, queue, cdnID); err != nil {
 		return errors.New("querying queue updates: " + err.Error())
 	}
@@ -0,0 +1,696 @@
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/lib/pq"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/cachegroup"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/parameter"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/topology"
+)
+
+const insertServerCapabilityQuery = `INSERT INTO server_capability (name) VALUES ($1) ON CONFLICT DO NOTHING`
+
+const selectCacheGroupIDQuery = `SELECT id FROM cachegroup WHERE name = $1`
+
+const updateCDNQuery = `UPDATE cdn SET domain_name = $2, dnssec_enabled = $3 WHERE name = $1`
+
+const insertProfileQuery = `
+INSERT INTO profile (name, description, type, cdn, routing_disabled)
+VALUES ($1, $2, $3, (SELECT id FROM cdn WHERE name = $4), $5)
+RETURNING id
+`
+
+const updateProfileQuery = `
+UPDATE profile SET description = $2, type = $3, routing_disabled = $4
+WHERE name = $1
+RETURNING id
+`
+
+// selectHiddenParametersQuery selects the secure Parameters of a Profile with
+// the given config files and names, whose values are hidden from the user.
+const selectHiddenParametersQuery = `
+SELECT pa.id
+FROM profile_parameter pp
+JOIN parameter pa ON pa.id = pp.parameter
+WHERE pp.profile = $1
+AND pa.secure
+AND pa.config_file = $2
+AND pa.name = $3
+`
+
+const selectParameterQuery = `SELECT id, secure FROM parameter WHERE config_file = $1 AND name = $2 AND value = $3`
+
+const insertParameterQuery = `INSERT INTO parameter (config_file, name, value, secure) VALUES ($1, $2, $3, $4) RETURNING id`
+
+const deleteProfileParametersQuery = `DELETE FROM profile_parameter WHERE profile = $1`
+
+const insertProfileParametersQuery = `
+INSERT INTO profile_parameter (profile, parameter)
+SELECT $1, p FROM UNNEST($2::bigint[]) AS p
+`
+
+const deleteProfileQuery = `DELETE FROM profile WHERE name = $1`
+
+const selectDeliveryServiceQuery = `SELECT id, ssl_key_version FROM deliveryservice WHERE xml_id = $1`
+
+const selectDeliveryServiceIDQuery = `SELECT id FROM deliveryservice WHERE xml_id = $1`
+
+const selectTenantIDQuery = `SELECT id FROM tenant WHERE name = $1`
+
+// deleteRegexesQuery deletes the regexes of a Delivery Service. They MUST be
+// deleted before deliveryservice_regex, which references them.
+const deleteRegexesQuery = `DELETE FROM regex WHERE id IN (SELECT regex FROM deliveryservice_regex WHERE deliveryservice = $1)`
+
+const deleteDeliveryServiceRegexesQuery = `DELETE FROM deliveryservice_regex WHERE deliveryservice = $1`
+
+const insertRegexQuery = `INSERT INTO regex (type, pattern) VALUES ($1, $2) RETURNING id`
+
+const insertDeliveryServiceRegexQuery = `INSERT INTO deliveryservice_regex (deliveryservice, regex, set_number) VALUES ($1, $2, $3)`
+
+const deleteRequiredCapabilitiesQuery = `DELETE FROM deliveryservices_required_capability WHERE deliveryservice_id = $1`
+
+const insertRequiredCapabilitiesQuery = `
+INSERT INTO deliveryservices_required_capability (deliveryservice_id, required_capability)
+SELECT $1, c FROM UNNEST($2::text[]) AS c
+`
+
+const deleteSteeringTargetsQuery = `DELETE FROM steering_target WHERE deliveryservice = $1`
+
+const insertSteeringTargetQuery = `
+INSERT INTO steering_target (deliveryservice, target, value, type)
+VALUES ($1, (SELECT id FROM deliveryservice WHERE xml_id = $2), $3, $4)
+`
+
+const selectFederationIDQuery = `
+SELECT f.id
+FROM federation f
+JOIN federation_deliveryservice fd ON fd.federation = f.id
+JOIN deliveryservice ds ON ds.id = fd.deliveryservice
+WHERE ds.xml_id = $1
+AND f.cname = $2
+`
+
+const insertFederationQuery = `INSERT INTO federation (cname, ttl, description) VALUES ($1, $2, $3) RETURNING id`
+
+const insertFederationDeliveryServiceQuery = `
+INSERT INTO federation_deliveryservice (federation, deliveryservice)
+VALUES ($1, (SELECT id FROM deliveryservice WHERE xml_id = $2))
+`
+
+const updateFederationQuery = `UPDATE federation SET ttl = $2, description = $3 WHERE id = $1`
+
+const deleteFederationResolversQuery = `DELETE FROM federation_federation_resolver WHERE federation = $1`
+
+const insertFederationResolversQuery = `
+INSERT INTO federation_federation_resolver (federation, federation_resolver)
+SELECT $1, id FROM federation_resolver WHERE ip_address = ANY($2)
+`
+
+const deleteFederationQuery = `DELETE FROM federation WHERE id = $1`
+
+// apply makes the changes of the given plan, from the current to the desired
+// configuration, in the transaction of inf.
+//
+// Cache Group fallbacks and Delivery Service steering targets may refer to
+// objects created later in the plan, so they're set in a second pass, after
+// all objects are created.
+func apply(inf *api.APIInfo, r *http.Request, current tc.CDNConfiguration, desired tc.CDNConfiguration, plan tc.CDNConfigurationPlan) (error, error, int) {
+	tx := inf.Tx.Tx
+	cacheGroups := map[string]tc.CDNConfigurationCacheGroup{}
+	for _, cg := range desired.CacheGroups {
+		cacheGroups[cg.Name] = cg
+	}
+	topologies := map[string]tc.CDNConfigurationTopology{}
+	for _, topology := range desired.Topologies {
+		topologies[topology.Name] = topology
+	}
+	profiles := map[string]tc.CDNConfigurationProfile{}
+	for _, profile := range desired.Profiles {
+		profiles[profile.Name] = profile
+	}
+	dses := map[string]tc.CDNConfigurationDeliveryService{}
+	for _, ds := range desired.DeliveryServices {
+		dses[dsXMLID(ds)] = ds
+	}
+	feds := map[string]tc.CDNConfigurationFederation{}
+	for _, fed := range desired.Federations {
+		feds[federationName(fed)] = fed
+	}
+	currentFeds := map[string]tc.CDNConfigurationFederation{}
+	for _, fed := range current.Federations {
+		currentFeds[federationName(fed)] = fed
+	}
+	if userErr, sysErr, errCode := checkResourceLocks(tx, inf.User.UserName, plan, currentFeds, feds); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+
+	// The Delivery Service handlers check If-Unmodified-Since, which is
+	// meaningless for the objects of a configuration.
+	dsReq := r.Clone(r.Context())
+	dsReq.Header = http.Header{}
+	hideSecure := !inf.User.Can(parameter.SecureReadPermission)
+
+	fallbackCacheGroups := []tc.CDNConfigurationCacheGroup{}
+	steeringDSes := []tc.CDNConfigurationDeliveryService{}
+	for _, change := range plan.Changes {
+		create := change.Action == tc.CDNConfigurationActionCreate
+		userErr, sysErr, errCode := error(nil), error(nil), http.StatusOK
+		switch change.Type {
+		case tc.CDNConfigurationObjectServerCapability:
+			if _, err := tx.Exec(insertServerCapabilityQuery, change.Name); err != nil {
+				userErr, sysErr, errCode = api.ParseDBError(err)
+			}
+		case tc.CDNConfigurationObjectCacheGroup:
+			cg := cacheGroups[change.Name]
+			userErr, sysErr, errCode = applyCacheGroup(inf, cg, create, false)
+			if len(cg.Fallbacks) > 0 {
+				fallbackCacheGroups = append(fallbackCacheGroups, cg)
+			}
+		case tc.CDNConfigurationObjectTopology:
+			userErr, sysErr, errCode = applyTopology(inf, topologies[change.Name], create)
+		case tc.CDNConfigurationObjectCDN:
+			if _, err := tx.Exec(updateCDNQuery, desired.CDN.Name, desired.CDN.DomainName, desired.CDN.DNSSECEnabled); err != nil {
+				userErr, sysErr, errCode = api.ParseDBError(err)
+			}
+		case tc.CDNConfigurationObjectProfile:
+			if change.Action == tc.CDNConfigurationActionDelete {
+				if _, err := tx.Exec(deleteProfileQuery, change.Name); err != nil {
+					userErr, sysErr, errCode = api.ParseDBError(err)
+				}
+			} else {
+				userErr, sysErr, errCode = applyProfile(tx, desired.CDN.Name, profiles[change.Name], create, hideSecure)
+			}
+		case tc.CDNConfigurationObjectDeliveryService:
+			if change.Action == tc.CDNConfigurationActionDelete {
+				userErr, sysErr, errCode = deleteDeliveryService(inf, change.Name)
+			} else {
+				ds := dses[change.Name]
+				userErr, sysErr, errCode = applyDeliveryService(inf, dsReq, desired.CDN.Name, ds, create)
+				steeringDSes = append(steeringDSes, ds)
+			}
+		case tc.CDNConfigurationObjectFederation:
+			if change.Action == tc.CDNConfigurationActionDelete {
+				userErr, sysErr, errCode = deleteFederation(tx, currentFeds[change.Name])
+			} else {
+				userErr, sysErr, errCode = applyFederation(tx, feds[change.Name], create)
+			}
+		}
+		if userErr != nil || sysErr != nil {
+			return changeErr(change, userErr), changeErr(change, sysErr), errCode
+		}
+		// Delivery Service changes are logged by the Delivery Service
+		// handlers.
+		if change.Type != tc.CDNConfigurationObjectDeliveryService {
+			api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("CDN: %s, ACTION: Applied CDN configuration: %s %s %s", desired.CDN.Name, change.Action, change.Type, change.Name), inf.User, tx)
+		}
+	}
+
+	for _, cg := range fallbackCacheGroups {
+		if userErr, sysErr, errCode := applyCacheGroup(inf, cg, false, true); userErr != nil || sysErr != nil {
+			change := tc.CDNConfigurationChange{Action: tc.CDNConfigurationActionUpdate, Type: tc.CDNConfigurationObjectCacheGroup, Name: cg.Name}
+			return changeErr(change, userErr), changeErr(change, sysErr), errCode
+		}
+	}
+	for _, ds := range steeringDSes {
+		if userErr, sysErr, errCode := applySteeringTargets(tx, ds); userErr != nil || sysErr != nil {
+			change := tc.CDNConfigurationChange{Action: tc.CDNConfigurationActionUpdate, Type: tc.CDNConfigurationObjectDeliveryService, Name: dsXMLID(ds)}
+			return changeErr(change, userErr), changeErr(change, sysErr), errCode
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+// lockedObjectTypes are the resource lock types (see tc.ResourceLockTypes) of
+// the types of objects in a CDN configuration which may be locked.
+var lockedObjectTypes = map[tc.CDNConfigurationObjectType]string{
+	tc.CDNConfigurationObjectCacheGroup:      tc.ResourceLockTypeCacheGroup,
+	tc.CDNConfigurationObjectTopology:        tc.ResourceLockTypeTopology,
+	tc.CDNConfigurationObjectProfile:         tc.ResourceLockTypeProfile,
+	tc.CDNConfigurationObjectDeliveryService: tc.ResourceLockTypeDeliveryService,
+}
+
+// checkResourceLocks checks that the given user may modify every locked
+// resource the given plan changes, before any of them are changed. Not all
+// changes are made by the handlers of their objects, which check locks
+// themselves, e.g. Profiles and their Parameters are changed directly.
+// Federations belong to their Delivery Services.
+func checkResourceLocks(tx *sql.Tx, user string, plan tc.CDNConfigurationPlan, currentFeds map[string]tc.CDNConfigurationFederation, feds map[string]tc.CDNConfigurationFederation) (error, error, int) {
+	resources := map[string][]string{}
+	for _, change := range plan.Changes {
+		if change.Type == tc.CDNConfigurationObjectFederation {
+			fed, ok := feds[change.Name]
+			if change.Action == tc.CDNConfigurationActionDelete {
+				fed, ok = currentFeds[change.Name]
+			}
+			if ok {
+				resources[tc.ResourceLockTypeDeliveryService] = append(resources[tc.ResourceLockTypeDeliveryService], fed.DeliveryService)
+			}
+			continue
+		}
+		resourceType, ok := lockedObjectTypes[change.Type]
+		// Objects which don't exist yet can't be locked.
+		if !ok || change.Action == tc.CDNConfigurationActionCreate {
+			continue
+		}
+		resources[resourceType] = append(resources[resourceType], change.Name)
+	}
+	for _, resourceType := range tc.ResourceLockTypes {
+		if len(resources[resourceType]) == 0 {
+			continue
+		}
+		if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyResources(tx, resourceType, resources[resourceType], user); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+// changeErr returns the given error of applying the given change, with the
+// change it was for. It returns nil if the error is nil.
+func changeErr(change tc.CDNConfigurationChange, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s %s '%s': %s", change.Action, change.Type, change.Name, err.Error())
+}
+
+// getID returns the ID selected by the given query, or false if it selects
+// nothing.
+func getID(tx *sql.Tx, query string, args ...interface{}) (int, bool, error) {
+	id := 0
+	if err := tx.QueryRow(query, args...).Scan(&id); err == sql.ErrNoRows {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+// getTypeID returns the ID of the Type with the given name, or a user error
+// if it doesn't exist.
+func getTypeID(tx *sql.Tx, name string) (int, error, error, int) {
+	id, ok, err := dbhelpers.GetTypeIDByName(name, tx)
+	if err != nil {
+		return 0, nil, errors.New("getting type ID: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return 0, errors.New("type '" + name + "' not found"), nil, http.StatusBadRequest
+	}
+	return id, nil, nil, http.StatusOK
+}
+
+// applyCacheGroup creates or updates the given Cache Group. Its fallbacks are
+// only set if withFallbacks is true, because they may not exist yet.
+func applyCacheGroup(inf *api.APIInfo, cg tc.CDNConfigurationCacheGroup, create bool, withFallbacks bool) (error, error, int) {
+	tx := inf.Tx.Tx
+	typeID, userErr, sysErr, errCode := getTypeID(tx, cg.Type)
+	if userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+
+	toCG := &cachegroup.TOCacheGroup{}
+	toCG.ReqInfo = inf
+	toCG.Name = &cg.Name
+	toCG.ShortName = &cg.ShortName
+	toCG.TypeID = &typeID
+	toCG.Latitude = &cg.Latitude
+	toCG.Longitude = &cg.Longitude
+	toCG.FallbackToClosest = &cg.FallbackToClosest
+	localizationMethods := cg.LocalizationMethods
+	toCG.LocalizationMethods = &localizationMethods
+	fallbacks := []string{}
+	if withFallbacks {
+		fallbacks = cg.Fallbacks
+	}
+	toCG.Fallbacks = &fallbacks
+
+	for _, parent := range []struct {
+		name *string
+		id   **int
+	}{
+		{cg.ParentCacheGroup, &toCG.ParentCachegroupID},
+		{cg.SecondaryParentCacheGroup, &toCG.SecondaryParentCachegroupID},
+	} {
+		if parent.name == nil {
+			continue
+		}
+		id, ok, err := getID(tx, selectCacheGroupIDQuery, *parent.name)
+		if err != nil {
+			return nil, errors.New("getting parent cache group ID: " + err.Error()), http.StatusInternalServerError
+		} else if !ok {
+			return errors.New("parent cache group '" + *parent.name + "' not found"), nil, http.StatusBadRequest
+		}
+		*parent.id = &id
+	}
+
+	if !create {
+		id, ok, err := getID(tx, selectCacheGroupIDQuery, cg.Name)
+		if err != nil {
+			return nil, errors.New("getting cache group ID: " + err.Error()), http.StatusInternalServerError
+		} else if !ok {
+			return errors.New("cache group not found"), nil, http.StatusNotFound
+		}
+		toCG.ID = &id
+	}
+
+	if err := toCG.Validate(); err != nil {
+		return err, nil, http.StatusBadRequest
+	}
+	if create {
+		return toCG.Create()
+	}
+	return toCG.Update(http.Header{})
+}
+
+// applyTopology creates or updates the given Topology.
+func applyTopology(inf *api.APIInfo, t tc.CDNConfigurationTopology, create bool) (error, error, int) {
+	// The Topology CRUDer identifies the Topology being updated by its
+	// request parameters.
+	topologyInf := *inf
+	topologyInf.Params = map[string]string{}
+	if !create {
+		topologyInf.Params["name"] = t.Name
+	}
+
+	toTopology := &topology.TOTopology{}
+	toTopology.ReqInfo = &topologyInf
+	toTopology.Name = t.Name
+	toTopology.RequestedName = t.Name
+	toTopology.Description = t.Description
+	nodeIndexes := make(map[string]int, len(t.Nodes))
+	for i, node := range t.Nodes {
+		nodeIndexes[node.CacheGroup] = i
+	}
+	for _, node := range t.Nodes {
+		parents := []int{}
+		for _, parent := range node.Parents {
+			parents = append(parents, nodeIndexes[parent])
+		}
+		toTopology.Nodes = append(toTopology.Nodes, tc.TopologyNode{Cachegroup: node.CacheGroup, Parents: parents})
+	}
+
+	if err := toTopology.Validate(); err != nil {
+		return err, nil, http.StatusBadRequest
+	}
+	if create {
+		return toTopology.Create()
+	}
+	return toTopology.Update(http.Header{})
+}
+
+// applyProfile creates or updates the given Profile of the given CDN, and
+// replaces its Parameters. If hideSecure is true, secure Parameters with
+// hidden values keep the values of the Profile's current Parameters with the
+// same config file and name.
+func applyProfile(tx *sql.Tx, cdnName string, profile tc.CDNConfigurationProfile, create bool, hideSecure bool) (error, error, int) {
+	profileID := 0
+	var err error
+	if create {
+		err = tx.QueryRow(insertProfileQuery, profile.Name, profile.Description, profile.Type, cdnName, profile.RoutingDisabled).Scan(&profileID)
+	} else {
+		err = tx.QueryRow(updateProfileQuery, profile.Name, profile.Description, profile.Type, profile.RoutingDisabled).Scan(&profileID)
+	}
+	if err != nil {
+		return api.ParseDBError(err)
+	}
+
+	paramIDs := []int64{}
+	for _, param := range profile.Parameters {
+		if hideSecure && param.Secure && param.Value == parameter.HiddenField {
+			rows, err := tx.Query(selectHiddenParametersQuery, profileID, param.ConfigFile, param.Name)
+			if err != nil {
+				return nil, errors.New("querying hidden parameters: " + err.Error()), http.StatusInternalServerError
+			}
+			found := false
+			for rows.Next() {
+				id := int64(0)
+				if err := rows.Scan(&id); err != nil {
+					rows.Close()
+					return nil, errors.New("scanning hidden parameters: " + err.Error()), http.StatusInternalServerError
+				}
+				paramIDs = append(paramIDs, id)
+				found = true
+			}
+			rows.Close()
+			if !found {
+				return fmt.Errorf("secure parameter '%s' in '%s' has a hidden value, but the profile has no such parameter", param.Name, param.ConfigFile), nil, http.StatusBadRequest
+			}
+			continue
+		}
+
+		id := int64(0)
+		secure := false
+		if err := tx.QueryRow(selectParameterQuery, param.ConfigFile, param.Name, param.Value).Scan(&id, &secure); err == sql.ErrNoRows {
+			if err := tx.QueryRow(insertParameterQuery, param.ConfigFile, param.Name, param.Value, param.Secure).Scan(&id); err != nil {
+				return api.ParseDBError(err)
+			}
+		} else if err != nil {
+			return nil, errors.New("querying parameter: " + err.Error()), http.StatusInternalServerError
+		} else if secure != param.Secure {
+			return fmt.Errorf("parameter '%s' in '%s' already exists with secure %t", param.Name, param.ConfigFile, secure), nil, http.StatusBadRequest
+		}
+		paramIDs = append(paramIDs, id)
+	}
+
+	if _, err := tx.Exec(deleteProfileParametersQuery, profileID); err != nil {
+		return nil, errors.New("deleting profile parameters: " + err.Error()), http.StatusInternalServerError
+	}
+	if _, err := tx.Exec(insertProfileParametersQuery, profileID, pq.Array(paramIDs)); err != nil {
+		return api.ParseDBError(err)
+	}
+	return nil, nil, http.StatusOK
+}
+
+// applyDeliveryService creates or updates the given Delivery Service of the
+// given CDN, as the Delivery Service handlers do, and replaces its regexes
+// and required capabilities.
+//
+// An updated Delivery Service's regexes and required capabilities are
+// replaced before it's updated, as when restoring a version, so that the
+// version recorded by the update has them. A created Delivery Service's are
+// replaced after it's created, so its version is recorded again.
+func applyDeliveryService(inf *api.APIInfo, r *http.Request, cdnName string, cfgDS tc.CDNConfigurationDeliveryService, create bool) (error, error, int) {
+	tx := inf.Tx.Tx
+	ds := cfgDS.DeliveryServiceV4
+
+	cdnID, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(cdnName))
+	if err != nil {
+		return nil, errors.New("getting CDN ID: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return errors.New("cdn '" + cdnName + "' not found"), nil, http.StatusNotFound
+	}
+	ds.CDNID = &cdnID
+	ds.CDNName = &cdnName
+
+	typeID, userErr, sysErr, errCode := getTypeID(tx, ds.Type.String())
+	if userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	ds.TypeID = &typeID
+
+	tenantID, ok, err := getID(tx, selectTenantIDQuery, *ds.Tenant)
+	if err != nil {
+		return nil, errors.New("getting tenant ID: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return errors.New("tenant '" + *ds.Tenant + "' not found"), nil, http.StatusBadRequest
+	}
+	ds.TenantID = &tenantID
+
+	if ds.ProfileName != nil && *ds.ProfileName != "" {
+		profileID, ok, err := dbhelpers.GetProfileIDFromName(*ds.ProfileName, tx)
+		if err != nil {
+			return nil, errors.New("getting profile ID: " + err.Error()), http.StatusInternalServerError
+		} else if !ok {
+			return errors.New("profile '" + *ds.ProfileName + "' not found"), nil, http.StatusBadRequest
+		}
+		ds.ProfileID = &profileID
+	}
+
+	if create {
+		res, _, errCode, userErr, sysErr := deliveryservice.CreateV4(r, inf, ds)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		if userErr, sysErr, errCode := replaceRegexesAndCapabilities(tx, *res.ID, cfgDS); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		if _, err := deliveryservice.CreateVersion(inf.Tx, *res.ID, inf.User); err != nil {
+			return nil, err, http.StatusInternalServerError
+		}
+		return nil, nil, http.StatusOK
+	}
+
+	// The SSL key version is managed by the SSL key handlers, so the current
+	// one is kept.
+	id := 0
+	sslKeyVersion := sql.NullInt64{}
+	if err := tx.QueryRow(selectDeliveryServiceQuery, *ds.XMLID).Scan(&id, &sslKeyVersion); err != nil {
+		return nil, errors.New("getting delivery service ID: " + err.Error()), http.StatusInternalServerError
+	}
+	ds.ID = &id
+	if sslKeyVersion.Valid {
+		version := int(sslKeyVersion.Int64)
+		ds.SSLKeyVersion = &version
+	}
+	if userErr, sysErr, errCode := replaceRegexesAndCapabilities(tx, id, cfgDS); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	_, _, errCode, userErr, sysErr = deliveryservice.UpdateV4(r, inf, &ds)
+	return userErr, sysErr, errCode
+}
+
+// replaceRegexesAndCapabilities replaces the regexes and required
+// capabilities of the Delivery Service with the given ID with those of the
+// given one.
+func replaceRegexesAndCapabilities(tx *sql.Tx, dsID int, cfgDS tc.CDNConfigurationDeliveryService) (error, error, int) {
+	if _, err := tx.Exec(deleteRegexesQuery, dsID); err != nil {
+		return nil, errors.New("deleting regexes: " + err.Error()), http.StatusInternalServerError
+	}
+	if _, err := tx.Exec(deleteDeliveryServiceRegexesQuery, dsID); err != nil {
+		return nil, errors.New("deleting delivery service regexes: " + err.Error()), http.StatusInternalServerError
+	}
+	for _, regex := range cfgDS.Regexes {
+		regexTypeID, userErr, sysErr, errCode := getTypeID(tx, regex.Type)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		regexID := 0
+		if err := tx.QueryRow(insertRegexQuery, regexTypeID, regex.Pattern).Scan(&regexID); err != nil {
+			return api.ParseDBError(err)
+		}
+		if _, err := tx.Exec(insertDeliveryServiceRegexQuery, dsID, regexID, regex.SetNumber); err != nil {
+			return api.ParseDBError(err)
+		}
+	}
+
+	if _, err := tx.Exec(deleteRequiredCapabilitiesQuery, dsID); err != nil {
+		return nil, errors.New("deleting required capabilities: " + err.Error()), http.StatusInternalServerError
+	}
+	if _, err := tx.Exec(insertRequiredCapabilitiesQuery, dsID, pq.Array(cfgDS.RequiredCapabilities)); err != nil {
+		return api.ParseDBError(err)
+	}
+	return nil, nil, http.StatusOK
+}
+
+// applySteeringTargets replaces the steering targets of the given Delivery
+// Service.
+func applySteeringTargets(tx *sql.Tx, ds tc.CDNConfigurationDeliveryService) (error, error, int) {
+	dsID, ok, err := getID(tx, selectDeliveryServiceIDQuery, dsXMLID(ds))
+	if err != nil {
+		return nil, errors.New("getting delivery service ID: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return errors.New("delivery service not found"), nil, http.StatusNotFound
+	}
+	if _, err := tx.Exec(deleteSteeringTargetsQuery, dsID); err != nil {
+		return nil, errors.New("deleting steering targets: " + err.Error()), http.StatusInternalServerError
+	}
+	for _, target := range ds.SteeringTargets {
+		typeID, userErr, sysErr, errCode := getTypeID(tx, target.Type)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		if _, err := tx.Exec(insertSteeringTargetQuery, dsID, target.Target, target.Value, typeID); err != nil {
+			return api.ParseDBError(err)
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+// deleteDeliveryService deletes the Delivery Service with the given XMLID, as
+// the Delivery Service handlers do.
+func deleteDeliveryService(inf *api.APIInfo, xmlID string) (error, error, int) {
+	id, _, err := getID(inf.Tx.Tx, selectDeliveryServiceIDQuery, xmlID)
+	if err != nil {
+		return nil, errors.New("getting delivery service ID: " + err.Error()), http.StatusInternalServerError
+	}
+	ds := &deliveryservice.TODeliveryService{}
+	ds.ReqInfo = inf
+	ds.ID = &id
+	if userErr, sysErr, errCode := ds.Delete(); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+xmlID+", ID: "+strconv.Itoa(id)+", ACTION: Deleted delivery service", inf.User, inf.Tx.Tx)
+	return nil, nil, http.StatusOK
+}
+
+// applyFederation creates or updates the given Federation, and replaces its
+// Federation Resolvers, which must exist.
+func applyFederation(tx *sql.Tx, fed tc.CDNConfigurationFederation, create bool) (error, error, int) {
+	fedID := 0
+	if create {
+		if err := tx.QueryRow(insertFederationQuery, fed.CName, fed.TTL, fed.Description).Scan(&fedID); err != nil {
+			return api.ParseDBError(err)
+		}
+		if _, err := tx.Exec(insertFederationDeliveryServiceQuery, fedID, fed.DeliveryService); err != nil {
+			return api.ParseDBError(err)
+		}
+	} else {
+		id, ok, err := getID(tx, selectFederationIDQuery, fed.DeliveryService, fed.CName)
+		if err != nil {
+			return nil, errors.New("getting federation ID: " + err.Error()), http.StatusInternalServerError
+		} else if !ok {
+			return errors.New("federation not found"), nil, http.StatusNotFound
+		}
+		fedID = id
+		if _, err := tx.Exec(updateFederationQuery, fedID, fed.TTL, fed.Description); err != nil {
+			return api.ParseDBError(err)
+		}
+	}
+
+	if _, err := tx.Exec(deleteFederationResolversQuery, fedID); err != nil {
+		return nil, errors.New("deleting federation resolvers: " + err.Error()), http.StatusInternalServerError
+	}
+	result, err := tx.Exec(insertFederationResolversQuery, fedID, pq.Array(fed.Resolvers))
+	if err != nil {
+		return api.ParseDBError(err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return nil, errors.New("getting federation resolvers rows affected: " + err.Error()), http.StatusInternalServerError
+	} else if rows < int64(len(fed.Resolvers)) {
+		return errors.New("federation resolvers must exist"), nil, http.StatusBadRequest
+	}
+	return nil, nil, http.StatusOK
+}
+
+// deleteFederation deletes the given Federation. Its assignments are deleted
+// with it.
+func deleteFederation(tx *sql.Tx, fed tc.CDNConfigurationFederation) (error, error, int) {
+	id, ok, err := getID(tx, selectFederationIDQuery, fed.DeliveryService, fed.CName)
+	if err != nil {
+		return nil, errors.New("getting federation ID: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return errors.New("federation not found"), nil, http.StatusNotFound
+	}
+	if _, err := tx.Exec(deleteFederationQuery, id); err != nil {
+		return api.ParseDBError(err)
+	}
+	return nil, nil, http.StatusOK
+}
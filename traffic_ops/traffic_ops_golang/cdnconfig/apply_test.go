@@ -0,0 +1,66 @@
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestCheckResourceLocks(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	lockCols := []string{"resource", "username", "shared", "expires"}
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM resource_lock").WithArgs(tc.ResourceLockTypeDeliveryService, `{"ds1","ds2"}`).WillReturnRows(sqlmock.NewRows(lockCols))
+	mock.ExpectQuery("FROM resource_lock").WithArgs(tc.ResourceLockTypeProfile, `{"EDGE1"}`).WillReturnRows(sqlmock.NewRows(lockCols).AddRow("EDGE1", "other", false, time.Now().Add(time.Hour)))
+	tx, err := mockDB.Begin()
+	if err != nil {
+		t.Fatalf("creating transaction: %v", err)
+	}
+
+	fed := tc.CDNConfigurationFederation{DeliveryService: "ds1", CName: "fed.example."}
+	plan := tc.CDNConfigurationPlan{Changes: []tc.CDNConfigurationChange{
+		{Action: tc.CDNConfigurationActionCreate, Type: tc.CDNConfigurationObjectCacheGroup, Name: "new"},
+		{Action: tc.CDNConfigurationActionUpdate, Type: tc.CDNConfigurationObjectProfile, Name: "EDGE1"},
+		{Action: tc.CDNConfigurationActionDelete, Type: tc.CDNConfigurationObjectFederation, Name: federationName(fed)},
+		{Action: tc.CDNConfigurationActionUpdate, Type: tc.CDNConfigurationObjectDeliveryService, Name: "ds2"},
+	}}
+	currentFeds := map[string]tc.CDNConfigurationFederation{federationName(fed): fed}
+	userErr, sysErr, errCode := checkResourceLocks(tx, "user", plan, currentFeds, map[string]tc.CDNConfigurationFederation{})
+	if sysErr != nil {
+		t.Errorf("expected no system error, actual: %v", sysErr)
+	}
+	if userErr == nil || errCode != http.StatusForbidden {
+		t.Errorf("expected status %d with a user error for the locked profile, actual: status %d user error %v", http.StatusForbidden, errCode, userErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,280 @@
+// Package cdnconfig exports the configuration of a CDN as a declarative
+// document, and plans and applies the changes needed to make a CDN match
+// such a document.
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+)
+
+// FormatQueryParam is the query parameter of the document format, either
+// FormatJSON or FormatYAML. It defaults to FormatJSON, unless a request body
+// has a YAML Content-Type.
+const FormatQueryParam = "format"
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// ContentTypeYAML is the Content-Type of YAML documents.
+const ContentTypeYAML = "application/yaml"
+
+// Get handles exporting the configuration of the CDN in the path.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	format, userErr := getFormat(inf.Params, "")
+	if userErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+
+	cfg, userErr, sysErr, errCode := readConfiguration(inf.Tx, inf.User, inf.Params["name"], nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.%s\"", cfg.CDN.Name, format))
+	if format == FormatJSON {
+		api.WriteRespRaw(w, r, cfg)
+		return
+	}
+	bts, err := marshalYAML(cfg)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("marshalling CDN configuration YAML: "+err.Error()))
+		return
+	}
+	w.Header().Set(rfc.ContentType, ContentTypeYAML)
+	w.Write(bts)
+}
+
+// Plan handles planning the changes needed to make the CDN in the path match
+// the configuration in the request body. Nothing is changed.
+func Plan(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	_, _, plan, userErr, sysErr, errCode := planRequest(inf, r)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, plan)
+}
+
+// Put handles applying the configuration in the request body to the CDN in
+// the path. The changes are planned as by Plan, and applied in the request's
+// transaction, so either all of them are made or none are.
+func Put(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	current, desired, plan, userErr, sysErr, errCode := planRequest(inf, r)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	if len(plan.Changes) == 0 {
+		api.WriteRespAlertObj(w, r, tc.SuccessLevel, "CDN configuration is already up to date", plan)
+		return
+	}
+
+	for _, change := range plan.Changes {
+		if change.Type != tc.CDNConfigurationObjectFederation {
+			continue
+		}
+		if perm := federationPermission(change.Action); !inf.User.Can(perm) {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusForbidden, fmt.Errorf("the %s permission is required to %s federations", perm, change.Action), nil)
+			return
+		}
+	}
+
+	if userErr, sysErr, errCode := apply(inf, r, current, desired, plan); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("CDN: %s, ACTION: Applied CDN configuration with %d changes", desired.CDN.Name, len(plan.Changes)), inf.User, inf.Tx.Tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, fmt.Sprintf("CDN configuration was applied with %d changes", len(plan.Changes)), plan)
+}
+
+// planRequest reads the configuration in the request body and the current
+// configuration of the CDN in the path, and returns them normalized with the
+// plan to make the CDN match the request.
+func planRequest(inf *api.APIInfo, r *http.Request) (tc.CDNConfiguration, tc.CDNConfiguration, tc.CDNConfigurationPlan, error, error, int) {
+	current := tc.CDNConfiguration{}
+	desired := tc.CDNConfiguration{}
+	cdnName := inf.Params["name"]
+
+	userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, cdnName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		return current, desired, tc.CDNConfigurationPlan{}, userErr, sysErr, errCode
+	}
+
+	format, userErr := getFormat(inf.Params, r.Header.Get(rfc.ContentType))
+	if userErr != nil {
+		return current, desired, tc.CDNConfigurationPlan{}, userErr, nil, http.StatusBadRequest
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return current, desired, tc.CDNConfigurationPlan{}, errors.New("reading request body: " + err.Error()), nil, http.StatusBadRequest
+	}
+	if format == FormatYAML {
+		err = unmarshalYAML(body, &desired)
+	} else {
+		err = json.Unmarshal(body, &desired)
+	}
+	if err != nil {
+		return current, desired, tc.CDNConfigurationPlan{}, errors.New("parsing request: " + err.Error()), nil, http.StatusBadRequest
+	}
+
+	normalizeConfiguration(&desired)
+	if err := validateConfiguration(desired, cdnName); err != nil {
+		return current, desired, tc.CDNConfigurationPlan{}, errors.New("invalid CDN configuration: " + err.Error()), nil, http.StatusBadRequest
+	}
+
+	current, userErr, sysErr, errCode = readConfiguration(inf.Tx, inf.User, cdnName, &desired)
+	if userErr != nil || sysErr != nil {
+		return current, desired, tc.CDNConfigurationPlan{}, userErr, sysErr, errCode
+	}
+	return current, desired, makePlan(current, desired), nil, nil, http.StatusOK
+}
+
+// federationPermission returns the permission a user needs to make a change
+// with the given action to a Federation.
+func federationPermission(action tc.CDNConfigurationAction) string {
+	switch action {
+	case tc.CDNConfigurationActionCreate:
+		return "FEDERATION:CREATE"
+	case tc.CDNConfigurationActionDelete:
+		return "FEDERATION:DELETE"
+	default:
+		return "FEDERATION:UPDATE"
+	}
+}
+
+// getFormat returns the document format of the request, from its format
+// query parameter or else the given Content-Type.
+func getFormat(params map[string]string, contentType string) (string, error) {
+	format, ok := params[FormatQueryParam]
+	if !ok {
+		if strings.Contains(contentType, FormatYAML) {
+			return FormatYAML, nil
+		}
+		return FormatJSON, nil
+	}
+	if format != FormatJSON && format != FormatYAML {
+		return "", fmt.Errorf("%s must be '%s' or '%s'", FormatQueryParam, FormatJSON, FormatYAML)
+	}
+	return format, nil
+}
+
+// marshalYAML marshals the given object as YAML, with the same field names
+// as its JSON, and map keys sorted.
+func marshalYAML(obj interface{}) ([]byte, error) {
+	bts, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	generic := interface{}(nil)
+	if err := json.Unmarshal(bts, &generic); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(generic)
+}
+
+// unmarshalYAML unmarshals YAML into the given object, using its JSON field
+// names.
+func unmarshalYAML(bts []byte, obj interface{}) error {
+	generic := interface{}(nil)
+	if err := yaml.Unmarshal(bts, &generic); err != nil {
+		return err
+	}
+	generic, err := yamlToJSONValue(generic)
+	if err != nil {
+		return err
+	}
+	if bts, err = json.Marshal(generic); err != nil {
+		return err
+	}
+	return json.Unmarshal(bts, obj)
+}
+
+// yamlToJSONValue converts the maps of a generic YAML value, which may have
+// keys of any type, to maps with string keys, which can be marshalled as
+// JSON.
+func yamlToJSONValue(val interface{}) (interface{}, error) {
+	switch val := val.(type) {
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(val))
+		for key, fieldVal := range val {
+			keyStr, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("object key %v must be a string", key)
+			}
+			fieldVal, err := yamlToJSONValue(fieldVal)
+			if err != nil {
+				return nil, err
+			}
+			obj[keyStr] = fieldVal
+		}
+		return obj, nil
+	case []interface{}:
+		arr := make([]interface{}, len(val))
+		for i, elem := range val {
+			elem, err := yamlToJSONValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = elem
+		}
+		return arr, nil
+	default:
+		return val, nil
+	}
+}
@@ -0,0 +1,476 @@
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/parameter"
+)
+
+const selectCDNQuery = `SELECT id, name, domain_name, dnssec_enabled FROM cdn WHERE name = $1`
+
+const selectServerCapabilitiesQuery = `
+SELECT name FROM server_capability
+WHERE name IN (SELECT required_capability FROM deliveryservices_required_capability WHERE deliveryservice_id = ANY($1))
+OR name = ANY($2)
+ORDER BY name
+`
+
+// selectServerCacheGroupsQuery selects the Cache Groups of the servers of a
+// CDN.
+const selectServerCacheGroupsQuery = `
+SELECT DISTINCT cg.name
+FROM server s
+JOIN cachegroup cg ON cg.id = s.cachegroup
+WHERE s.cdn_id = $1
+`
+
+const selectCacheGroupsQuery = `
+SELECT
+	cg.name,
+	cg.short_name,
+	t.name,
+	COALESCE(co.latitude, 0),
+	COALESCE(co.longitude, 0),
+	p.name,
+	sp.name,
+	COALESCE(cg.fallback_to_closest, TRUE),
+	ARRAY(
+		SELECT b.name FROM cachegroup_fallbacks f
+		JOIN cachegroup b ON b.id = f.backup_cg
+		WHERE f.primary_cg = cg.id
+		ORDER BY f.set_order
+	),
+	ARRAY(
+		SELECT m.method::text FROM cachegroup_localization_method m
+		WHERE m.cachegroup = cg.id
+		ORDER BY m.method::text
+	)
+FROM cachegroup cg
+JOIN type t ON t.id = cg.type
+LEFT JOIN coordinate co ON co.id = cg.coordinate
+LEFT JOIN cachegroup p ON p.id = cg.parent_cachegroup_id
+LEFT JOIN cachegroup sp ON sp.id = cg.secondary_parent_cachegroup_id
+`
+
+// selectTopologiesQuery selects the nodes of Topologies, with the names of
+// their parents in rank order.
+const selectTopologiesQuery = `
+SELECT
+	t.name,
+	t.description,
+	tc.cachegroup,
+	ARRAY(
+		SELECT p.cachegroup FROM topology_cachegroup_parents tcp
+		JOIN topology_cachegroup p ON p.id = tcp.parent
+		WHERE tcp.child = tc.id
+		ORDER BY tcp.rank
+	)
+FROM topology t
+JOIN topology_cachegroup tc ON tc.topology = t.name
+WHERE t.name = ANY($1)
+ORDER BY t.name, tc.cachegroup
+`
+
+const selectProfilesQuery = `
+SELECT
+	p.name,
+	COALESCE(p.description, ''),
+	p.type::text,
+	p.routing_disabled,
+	pa.config_file,
+	pa.name,
+	pa.value,
+	pa.secure
+FROM profile p
+LEFT JOIN profile_parameter pp ON pp.profile = p.id
+LEFT JOIN parameter pa ON pa.id = pp.parameter
+WHERE p.cdn = $1
+ORDER BY p.name, pa.config_file, pa.name, pa.value
+`
+
+const selectRegexesQuery = `
+SELECT ds.xml_id, t.name, r.pattern, COALESCE(dr.set_number, 0)
+FROM deliveryservice_regex dr
+JOIN regex r ON r.id = dr.regex
+JOIN type t ON t.id = r.type
+JOIN deliveryservice ds ON ds.id = dr.deliveryservice
+WHERE ds.id = ANY($1)
+ORDER BY ds.xml_id, COALESCE(dr.set_number, 0), t.name, r.pattern
+`
+
+const selectRequiredCapabilitiesQuery = `
+SELECT ds.xml_id, rc.required_capability
+FROM deliveryservices_required_capability rc
+JOIN deliveryservice ds ON ds.id = rc.deliveryservice_id
+WHERE ds.id = ANY($1)
+ORDER BY ds.xml_id, rc.required_capability
+`
+
+const selectSteeringTargetsQuery = `
+SELECT ds.xml_id, target.xml_id, t.name, st.value
+FROM steering_target st
+JOIN deliveryservice ds ON ds.id = st.deliveryservice
+JOIN deliveryservice target ON target.id = st.target
+JOIN type t ON t.id = st.type
+WHERE ds.id = ANY($1)
+ORDER BY ds.xml_id, target.xml_id
+`
+
+const selectFederationsQuery = `
+SELECT
+	ds.xml_id,
+	f.cname,
+	f.ttl,
+	f.description,
+	ARRAY(
+		SELECT fr.ip_address FROM federation_federation_resolver ffr
+		JOIN federation_resolver fr ON fr.id = ffr.federation_resolver
+		WHERE ffr.federation = f.id
+		ORDER BY fr.ip_address
+	)
+FROM federation f
+JOIN federation_deliveryservice fd ON fd.federation = f.id
+JOIN deliveryservice ds ON ds.id = fd.deliveryservice
+WHERE ds.id = ANY($1)
+ORDER BY ds.xml_id, f.cname
+`
+
+// readConfiguration reads the current configuration of the given CDN, as
+// visible to the given user. Secure Parameter values are hidden from users
+// without admin privileges.
+//
+// Cache Groups, Topologies, and Server Capabilities are shared by CDNs, so
+// only those used by the CDN are read, along with any in the given desired
+// configuration, if it isn't nil, so a plan can tell whether they exist.
+func readConfiguration(tx *sqlx.Tx, user *auth.CurrentUser, cdnName string, desired *tc.CDNConfiguration) (tc.CDNConfiguration, error, error, int) {
+	cfg := tc.CDNConfiguration{Version: tc.CDNConfigurationVersion}
+	cdnID := 0
+	if err := tx.QueryRow(selectCDNQuery, cdnName).Scan(&cdnID, &cfg.CDN.Name, &cfg.CDN.DomainName, &cfg.CDN.DNSSECEnabled); err == sql.ErrNoRows {
+		return cfg, errors.New("cdn '" + cdnName + "' not found"), nil, http.StatusNotFound
+	} else if err != nil {
+		return cfg, nil, errors.New("querying cdn: " + err.Error()), http.StatusInternalServerError
+	}
+
+	dses, userErr, sysErr, errCode := deliveryservice.ReadV4(tx, user, map[string]string{"cdn": strconv.Itoa(cdnID)})
+	if userErr != nil || sysErr != nil {
+		return cfg, userErr, sysErr, errCode
+	}
+	dsIDs := make([]int64, 0, len(dses))
+	dsTopologies := []string{}
+	for _, ds := range dses {
+		dsIDs = append(dsIDs, int64(*ds.ID))
+		if ds.Topology != nil {
+			dsTopologies = append(dsTopologies, *ds.Topology)
+		}
+	}
+
+	var err error
+	include := tc.CDNConfiguration{}
+	if desired != nil {
+		include = *desired
+	}
+	if cfg.ServerCapabilities, err = readServerCapabilities(tx, dsIDs, include.ServerCapabilities); err != nil {
+		return cfg, nil, errors.New("reading server capabilities: " + err.Error()), http.StatusInternalServerError
+	}
+	topologyNames := dsTopologies
+	for _, topology := range include.Topologies {
+		topologyNames = append(topologyNames, topology.Name)
+	}
+	if cfg.Topologies, err = readTopologies(tx, topologyNames); err != nil {
+		return cfg, nil, errors.New("reading topologies: " + err.Error()), http.StatusInternalServerError
+	}
+	cacheGroupNames := []string{}
+	for _, topology := range cfg.Topologies {
+		for _, node := range topology.Nodes {
+			cacheGroupNames = append(cacheGroupNames, node.CacheGroup)
+		}
+	}
+	for _, cg := range include.CacheGroups {
+		cacheGroupNames = append(cacheGroupNames, cg.Name)
+	}
+	if cfg.CacheGroups, err = readCacheGroups(tx, cdnID, cacheGroupNames); err != nil {
+		return cfg, nil, errors.New("reading cache groups: " + err.Error()), http.StatusInternalServerError
+	}
+	if cfg.Profiles, err = readProfiles(tx, cdnID, user.Can(parameter.SecureReadPermission)); err != nil {
+		return cfg, nil, errors.New("reading profiles: " + err.Error()), http.StatusInternalServerError
+	}
+	if cfg.DeliveryServices, err = readDeliveryServices(tx, dses, dsIDs); err != nil {
+		return cfg, nil, errors.New("reading delivery services: " + err.Error()), http.StatusInternalServerError
+	}
+	if cfg.Federations, err = readFederations(tx, dsIDs); err != nil {
+		return cfg, nil, errors.New("reading federations: " + err.Error()), http.StatusInternalServerError
+	}
+	normalizeConfiguration(&cfg)
+	return cfg, nil, nil, http.StatusOK
+}
+
+func readServerCapabilities(tx *sqlx.Tx, dsIDs []int64, include []string) ([]string, error) {
+	rows, err := tx.Query(selectServerCapabilitiesQuery, pq.Array(dsIDs), pq.Array(include))
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+	capabilities := []string{}
+	for rows.Next() {
+		capability := ""
+		if err := rows.Scan(&capability); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		capabilities = append(capabilities, capability)
+	}
+	return capabilities, rows.Err()
+}
+
+func readTopologies(tx *sqlx.Tx, names []string) ([]tc.CDNConfigurationTopology, error) {
+	rows, err := tx.Query(selectTopologiesQuery, pq.Array(names))
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+	topologies := []tc.CDNConfigurationTopology{}
+	for rows.Next() {
+		name := ""
+		description := ""
+		node := tc.CDNConfigurationTopologyNode{}
+		if err := rows.Scan(&name, &description, &node.CacheGroup, pq.Array(&node.Parents)); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		if len(topologies) == 0 || topologies[len(topologies)-1].Name != name {
+			topologies = append(topologies, tc.CDNConfigurationTopology{Name: name, Description: description})
+		}
+		topology := &topologies[len(topologies)-1]
+		topology.Nodes = append(topology.Nodes, node)
+	}
+	return topologies, rows.Err()
+}
+
+// readCacheGroups reads the Cache Groups of the servers of the given CDN and
+// the given Cache Groups, along with the parents and fallbacks of each, so a
+// configuration can be applied to a Traffic Ops without them.
+func readCacheGroups(tx *sqlx.Tx, cdnID int, names []string) ([]tc.CDNConfigurationCacheGroup, error) {
+	rows, err := tx.Query(selectServerCacheGroupsQuery, cdnID)
+	if err != nil {
+		return nil, errors.New("querying server cache groups: " + err.Error())
+	}
+	defer rows.Close()
+	for rows.Next() {
+		name := ""
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.New("scanning server cache groups: " + err.Error())
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("reading server cache groups: " + err.Error())
+	}
+
+	// Cache Groups are few enough to read them all, rather than query each
+	// level of parents.
+	allRows, err := tx.Query(selectCacheGroupsQuery)
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer allRows.Close()
+	all := map[string]tc.CDNConfigurationCacheGroup{}
+	for allRows.Next() {
+		cg := tc.CDNConfigurationCacheGroup{}
+		methods := []string{}
+		if err := allRows.Scan(&cg.Name, &cg.ShortName, &cg.Type, &cg.Latitude, &cg.Longitude, &cg.ParentCacheGroup, &cg.SecondaryParentCacheGroup, &cg.FallbackToClosest, pq.Array(&cg.Fallbacks), pq.Array(&methods)); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		for _, method := range methods {
+			cg.LocalizationMethods = append(cg.LocalizationMethods, tc.LocalizationMethodFromString(method))
+		}
+		all[cg.Name] = cg
+	}
+	if err := allRows.Err(); err != nil {
+		return nil, errors.New("reading: " + err.Error())
+	}
+
+	cacheGroups := []tc.CDNConfigurationCacheGroup{}
+	added := map[string]struct{}{}
+	for len(names) > 0 {
+		name := names[len(names)-1]
+		names = names[:len(names)-1]
+		if _, ok := added[name]; ok {
+			continue
+		}
+		cg, ok := all[name]
+		if !ok {
+			continue
+		}
+		added[name] = struct{}{}
+		cacheGroups = append(cacheGroups, cg)
+		if cg.ParentCacheGroup != nil {
+			names = append(names, *cg.ParentCacheGroup)
+		}
+		if cg.SecondaryParentCacheGroup != nil {
+			names = append(names, *cg.SecondaryParentCacheGroup)
+		}
+		names = append(names, cg.Fallbacks...)
+	}
+	return cacheGroups, nil
+}
+
+func readProfiles(tx *sqlx.Tx, cdnID int, showSecure bool) ([]tc.CDNConfigurationProfile, error) {
+	rows, err := tx.Query(selectProfilesQuery, cdnID)
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+	profiles := []tc.CDNConfigurationProfile{}
+	for rows.Next() {
+		profile := tc.CDNConfigurationProfile{}
+		configFile := sql.NullString{}
+		name := sql.NullString{}
+		value := sql.NullString{}
+		secure := sql.NullBool{}
+		if err := rows.Scan(&profile.Name, &profile.Description, &profile.Type, &profile.RoutingDisabled, &configFile, &name, &value, &secure); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		if len(profiles) == 0 || profiles[len(profiles)-1].Name != profile.Name {
+			profiles = append(profiles, profile)
+		}
+		if !name.Valid {
+			continue
+		}
+		param := tc.CDNConfigurationParameter{ConfigFile: configFile.String, Name: name.String, Value: value.String, Secure: secure.Bool}
+		if param.Secure && !showSecure {
+			param.Value = parameter.HiddenField
+		}
+		last := &profiles[len(profiles)-1]
+		last.Parameters = append(last.Parameters, param)
+	}
+	return profiles, rows.Err()
+}
+
+// readDeliveryServices converts the given Delivery Services to their
+// configuration, without IDs or fields Traffic Ops generates, and reads their
+// regexes, required capabilities, and steering targets.
+func readDeliveryServices(tx *sqlx.Tx, dses []tc.DeliveryServiceV4, dsIDs []int64) ([]tc.CDNConfigurationDeliveryService, error) {
+	byXMLID := map[string]*tc.CDNConfigurationDeliveryService{}
+	cfgDSes := make([]tc.CDNConfigurationDeliveryService, len(dses))
+	for i, ds := range dses {
+		cfgDSes[i] = tc.CDNConfigurationDeliveryService{DeliveryServiceV4: exportDeliveryService(ds)}
+		byXMLID[*ds.XMLID] = &cfgDSes[i]
+	}
+
+	rows, err := tx.Query(selectRegexesQuery, pq.Array(dsIDs))
+	if err != nil {
+		return nil, errors.New("querying regexes: " + err.Error())
+	}
+	defer rows.Close()
+	for rows.Next() {
+		xmlID := ""
+		regex := tc.CDNConfigurationRegex{}
+		if err := rows.Scan(&xmlID, &regex.Type, &regex.Pattern, &regex.SetNumber); err != nil {
+			return nil, errors.New("scanning regexes: " + err.Error())
+		}
+		if ds, ok := byXMLID[xmlID]; ok {
+			ds.Regexes = append(ds.Regexes, regex)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("reading regexes: " + err.Error())
+	}
+
+	capRows, err := tx.Query(selectRequiredCapabilitiesQuery, pq.Array(dsIDs))
+	if err != nil {
+		return nil, errors.New("querying required capabilities: " + err.Error())
+	}
+	defer capRows.Close()
+	for capRows.Next() {
+		xmlID := ""
+		capability := ""
+		if err := capRows.Scan(&xmlID, &capability); err != nil {
+			return nil, errors.New("scanning required capabilities: " + err.Error())
+		}
+		if ds, ok := byXMLID[xmlID]; ok {
+			ds.RequiredCapabilities = append(ds.RequiredCapabilities, capability)
+		}
+	}
+	if err := capRows.Err(); err != nil {
+		return nil, errors.New("reading required capabilities: " + err.Error())
+	}
+
+	targetRows, err := tx.Query(selectSteeringTargetsQuery, pq.Array(dsIDs))
+	if err != nil {
+		return nil, errors.New("querying steering targets: " + err.Error())
+	}
+	defer targetRows.Close()
+	for targetRows.Next() {
+		xmlID := ""
+		target := tc.CDNConfigurationSteeringTarget{}
+		if err := targetRows.Scan(&xmlID, &target.Target, &target.Type, &target.Value); err != nil {
+			return nil, errors.New("scanning steering targets: " + err.Error())
+		}
+		if ds, ok := byXMLID[xmlID]; ok {
+			ds.SteeringTargets = append(ds.SteeringTargets, target)
+		}
+	}
+	return cfgDSes, targetRows.Err()
+}
+
+// exportDeliveryService returns the given Delivery Service without its IDs,
+// CDN, or fields Traffic Ops generates or manages itself.
+func exportDeliveryService(ds tc.DeliveryServiceV4) tc.DeliveryServiceV4 {
+	ds.ID = nil
+	ds.CDNID = nil
+	ds.CDNName = nil
+	ds.TypeID = nil
+	ds.TenantID = nil
+	ds.ProfileID = nil
+	ds.ProfileDesc = nil
+	ds.LastUpdated = nil
+	ds.ExampleURLs = nil
+	ds.MatchList = nil
+	ds.SSLKeyVersion = nil
+	ds.LongDesc1 = nil
+	ds.LongDesc2 = nil
+	return ds
+}
+
+func readFederations(tx *sqlx.Tx, dsIDs []int64) ([]tc.CDNConfigurationFederation, error) {
+	rows, err := tx.Query(selectFederationsQuery, pq.Array(dsIDs))
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+	federations := []tc.CDNConfigurationFederation{}
+	for rows.Next() {
+		fed := tc.CDNConfigurationFederation{}
+		if err := rows.Scan(&fed.DeliveryService, &fed.CName, &fed.TTL, &fed.Description, pq.Array(&fed.Resolvers)); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		federations = append(federations, fed)
+	}
+	return federations, rows.Err()
+}
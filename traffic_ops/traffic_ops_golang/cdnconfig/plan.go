@@ -0,0 +1,469 @@
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// namedObject is an object of a CDNConfiguration, with the name which
+// identifies it in a CDNConfigurationChange.
+type namedObject struct {
+	Name   string
+	Object interface{}
+}
+
+// normalizeConfiguration sorts the lists of the given configuration, replaces
+// nil lists with empty ones, and removes Delivery Service fields Traffic Ops
+// manages, so equal configurations are identical.
+func normalizeConfiguration(cfg *tc.CDNConfiguration) {
+	if cfg.ServerCapabilities == nil {
+		cfg.ServerCapabilities = []string{}
+	}
+	sort.Strings(cfg.ServerCapabilities)
+
+	if cfg.CacheGroups == nil {
+		cfg.CacheGroups = []tc.CDNConfigurationCacheGroup{}
+	}
+	for i := range cfg.CacheGroups {
+		cg := &cfg.CacheGroups[i]
+		if cg.Fallbacks == nil {
+			cg.Fallbacks = []string{}
+		}
+		if cg.LocalizationMethods == nil {
+			cg.LocalizationMethods = []tc.LocalizationMethod{}
+		}
+		sort.Slice(cg.LocalizationMethods, func(i, j int) bool {
+			return cg.LocalizationMethods[i].String() < cg.LocalizationMethods[j].String()
+		})
+	}
+	sort.Slice(cfg.CacheGroups, func(i, j int) bool { return cfg.CacheGroups[i].Name < cfg.CacheGroups[j].Name })
+
+	if cfg.Topologies == nil {
+		cfg.Topologies = []tc.CDNConfigurationTopology{}
+	}
+	for i := range cfg.Topologies {
+		topology := &cfg.Topologies[i]
+		if topology.Nodes == nil {
+			topology.Nodes = []tc.CDNConfigurationTopologyNode{}
+		}
+		for j := range topology.Nodes {
+			if topology.Nodes[j].Parents == nil {
+				topology.Nodes[j].Parents = []string{}
+			}
+		}
+		sort.Slice(topology.Nodes, func(i, j int) bool { return topology.Nodes[i].CacheGroup < topology.Nodes[j].CacheGroup })
+	}
+	sort.Slice(cfg.Topologies, func(i, j int) bool { return cfg.Topologies[i].Name < cfg.Topologies[j].Name })
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = []tc.CDNConfigurationProfile{}
+	}
+	for i := range cfg.Profiles {
+		params := cfg.Profiles[i].Parameters
+		if params == nil {
+			params = []tc.CDNConfigurationParameter{}
+		}
+		sort.Slice(params, func(i, j int) bool {
+			if params[i].ConfigFile != params[j].ConfigFile {
+				return params[i].ConfigFile < params[j].ConfigFile
+			}
+			if params[i].Name != params[j].Name {
+				return params[i].Name < params[j].Name
+			}
+			return params[i].Value < params[j].Value
+		})
+		cfg.Profiles[i].Parameters = params
+	}
+	sort.Slice(cfg.Profiles, func(i, j int) bool { return cfg.Profiles[i].Name < cfg.Profiles[j].Name })
+
+	if cfg.DeliveryServices == nil {
+		cfg.DeliveryServices = []tc.CDNConfigurationDeliveryService{}
+	}
+	for i := range cfg.DeliveryServices {
+		normalizeDeliveryService(&cfg.DeliveryServices[i])
+	}
+	sort.Slice(cfg.DeliveryServices, func(i, j int) bool {
+		return dsXMLID(cfg.DeliveryServices[i]) < dsXMLID(cfg.DeliveryServices[j])
+	})
+
+	if cfg.Federations == nil {
+		cfg.Federations = []tc.CDNConfigurationFederation{}
+	}
+	for i := range cfg.Federations {
+		if cfg.Federations[i].Resolvers == nil {
+			cfg.Federations[i].Resolvers = []string{}
+		}
+		sort.Strings(cfg.Federations[i].Resolvers)
+	}
+	sort.Slice(cfg.Federations, func(i, j int) bool { return federationName(cfg.Federations[i]) < federationName(cfg.Federations[j]) })
+}
+
+func normalizeDeliveryService(ds *tc.CDNConfigurationDeliveryService) {
+	ds.DeliveryServiceV4 = exportDeliveryService(ds.DeliveryServiceV4)
+	if ds.ConsistentHashQueryParams == nil {
+		ds.ConsistentHashQueryParams = []string{}
+	}
+	if ds.TLSVersions == nil {
+		ds.TLSVersions = []string{}
+	}
+	if ds.TLSCiphers == nil {
+		ds.TLSCiphers = []string{}
+	}
+	if ds.TLSCipherSuites == nil {
+		ds.TLSCipherSuites = []string{}
+	}
+
+	if ds.Regexes == nil {
+		ds.Regexes = []tc.CDNConfigurationRegex{}
+	}
+	sort.Slice(ds.Regexes, func(i, j int) bool {
+		a, b := ds.Regexes[i], ds.Regexes[j]
+		if a.SetNumber != b.SetNumber {
+			return a.SetNumber < b.SetNumber
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Pattern < b.Pattern
+	})
+	if ds.RequiredCapabilities == nil {
+		ds.RequiredCapabilities = []string{}
+	}
+	sort.Strings(ds.RequiredCapabilities)
+	if ds.SteeringTargets == nil {
+		ds.SteeringTargets = []tc.CDNConfigurationSteeringTarget{}
+	}
+	sort.Slice(ds.SteeringTargets, func(i, j int) bool { return ds.SteeringTargets[i].Target < ds.SteeringTargets[j].Target })
+}
+
+// dsXMLID returns the XMLID of the given Delivery Service, or the empty
+// string if it has none.
+func dsXMLID(ds tc.CDNConfigurationDeliveryService) string {
+	if ds.XMLID == nil {
+		return ""
+	}
+	return *ds.XMLID
+}
+
+// federationName returns the name of the given Federation in a
+// CDNConfigurationChange.
+func federationName(fed tc.CDNConfigurationFederation) string {
+	return fed.DeliveryService + "/" + fed.CName
+}
+
+// validateConfiguration validates a normalized configuration to be applied
+// to the given CDN. Objects must be uniquely named, and refer to objects in
+// the configuration, except Cache Groups, Server Capabilities, Types, and
+// Tenants, which may already exist.
+func validateConfiguration(cfg tc.CDNConfiguration, cdnName string) error {
+	errs := []error{}
+	if cfg.Version != tc.CDNConfigurationVersion {
+		errs = append(errs, fmt.Errorf("version must be %d", tc.CDNConfigurationVersion))
+	}
+	if cfg.CDN.Name != cdnName {
+		errs = append(errs, fmt.Errorf("cdn.name must be '%s', the CDN being configured", cdnName))
+	}
+
+	uniqueNames := func(objType string, names []string) map[string]struct{} {
+		set := make(map[string]struct{}, len(names))
+		for _, name := range names {
+			if name == "" {
+				errs = append(errs, fmt.Errorf("%s names must not be empty", objType))
+			} else if _, ok := set[name]; ok {
+				errs = append(errs, fmt.Errorf("%s '%s' is duplicated", objType, name))
+			}
+			set[name] = struct{}{}
+		}
+		return set
+	}
+
+	uniqueNames("server capability", cfg.ServerCapabilities)
+
+	cgNames := []string{}
+	for _, cg := range cfg.CacheGroups {
+		cgNames = append(cgNames, cg.Name)
+	}
+	uniqueNames("cache group", cgNames)
+	if _, err := sortCacheGroups(cfg.CacheGroups); err != nil {
+		errs = append(errs, err)
+	}
+
+	topologyNames := []string{}
+	for _, topology := range cfg.Topologies {
+		topologyNames = append(topologyNames, topology.Name)
+		nodes := map[string]struct{}{}
+		for _, node := range topology.Nodes {
+			nodes[node.CacheGroup] = struct{}{}
+		}
+		for _, node := range topology.Nodes {
+			for _, parent := range node.Parents {
+				if _, ok := nodes[parent]; !ok {
+					errs = append(errs, fmt.Errorf("topology '%s' node '%s' parent '%s' is not a node of the topology", topology.Name, node.CacheGroup, parent))
+				}
+			}
+		}
+	}
+	uniqueNames("topology", topologyNames)
+
+	profileNames := []string{}
+	for _, profile := range cfg.Profiles {
+		profileNames = append(profileNames, profile.Name)
+		for i := 1; i < len(profile.Parameters); i++ {
+			if profile.Parameters[i] == profile.Parameters[i-1] {
+				errs = append(errs, fmt.Errorf("profile '%s' parameter '%s' in '%s' is duplicated", profile.Name, profile.Parameters[i].Name, profile.Parameters[i].ConfigFile))
+			}
+		}
+	}
+	profiles := uniqueNames("profile", profileNames)
+
+	xmlIDs := []string{}
+	for _, ds := range cfg.DeliveryServices {
+		xmlID := dsXMLID(ds)
+		xmlIDs = append(xmlIDs, xmlID)
+		if ds.Type == nil {
+			errs = append(errs, fmt.Errorf("delivery service '%s' must have a type", xmlID))
+		}
+		if ds.Tenant == nil {
+			errs = append(errs, fmt.Errorf("delivery service '%s' must have a tenant", xmlID))
+		}
+		if ds.ProfileName != nil && *ds.ProfileName != "" {
+			if _, ok := profiles[*ds.ProfileName]; !ok {
+				errs = append(errs, fmt.Errorf("delivery service '%s' profile '%s' is not a profile of the configuration", xmlID, *ds.ProfileName))
+			}
+		}
+	}
+	dses := uniqueNames("delivery service", xmlIDs)
+	for _, ds := range cfg.DeliveryServices {
+		for _, target := range ds.SteeringTargets {
+			if _, ok := dses[target.Target]; !ok {
+				errs = append(errs, fmt.Errorf("delivery service '%s' steering target '%s' is not a delivery service of the configuration", dsXMLID(ds), target.Target))
+			}
+		}
+	}
+
+	fedNames := []string{}
+	for _, fed := range cfg.Federations {
+		fedNames = append(fedNames, federationName(fed))
+		if _, ok := dses[fed.DeliveryService]; !ok {
+			errs = append(errs, fmt.Errorf("federation '%s' delivery service '%s' is not a delivery service of the configuration", fed.CName, fed.DeliveryService))
+		}
+		if fed.CName == "" {
+			errs = append(errs, fmt.Errorf("federation of delivery service '%s' must have a cname", fed.DeliveryService))
+		}
+	}
+	uniqueNames("federation", fedNames)
+
+	return util.JoinErrs(errs)
+}
+
+// sortCacheGroups returns the given Cache Groups sorted so each comes after
+// its parent and secondary parent, if they're in the list. It returns an
+// error if Cache Groups are their own ancestors.
+func sortCacheGroups(cgs []tc.CDNConfigurationCacheGroup) ([]tc.CDNConfigurationCacheGroup, error) {
+	byName := make(map[string]tc.CDNConfigurationCacheGroup, len(cgs))
+	for _, cg := range cgs {
+		byName[cg.Name] = cg
+	}
+	sorted := make([]tc.CDNConfigurationCacheGroup, 0, len(cgs))
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var visit func(name string) error
+	visit = func(name string) error {
+		cg, ok := byName[name]
+		if !ok || state[name] == visited {
+			return nil
+		}
+		if state[name] == visiting {
+			return errors.New("cache group '" + name + "' is its own parent")
+		}
+		state[name] = visiting
+		for _, parent := range []*string{cg.ParentCacheGroup, cg.SecondaryParentCacheGroup} {
+			if parent != nil {
+				if err := visit(*parent); err != nil {
+					return err
+				}
+			}
+		}
+		state[name] = visited
+		sorted = append(sorted, cg)
+		return nil
+	}
+	for _, cg := range cgs {
+		if err := visit(cg.Name); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// makePlan returns the changes needed to make the current normalized
+// configuration match the desired one, in the order they must be applied.
+//
+// Objects are created and updated in dependency order, then Federations,
+// Delivery Services, and Profiles which aren't in the desired configuration
+// are deleted, in that order. Server Capabilities, Cache Groups, and
+// Topologies may be shared with other CDNs, so they're never deleted.
+func makePlan(current, desired tc.CDNConfiguration) tc.CDNConfigurationPlan {
+	changes := []tc.CDNConfigurationChange{}
+
+	currentCapabilities := map[string]struct{}{}
+	for _, capability := range current.ServerCapabilities {
+		currentCapabilities[capability] = struct{}{}
+	}
+	for _, capability := range desired.ServerCapabilities {
+		if _, ok := currentCapabilities[capability]; !ok {
+			changes = append(changes, tc.CDNConfigurationChange{Action: tc.CDNConfigurationActionCreate, Type: tc.CDNConfigurationObjectServerCapability, Name: capability})
+		}
+	}
+
+	desiredCGs, _ := sortCacheGroups(desired.CacheGroups)
+	changes = planUpserts(changes, tc.CDNConfigurationObjectCacheGroup, cacheGroupObjects(current.CacheGroups), cacheGroupObjects(desiredCGs))
+	changes = planUpserts(changes, tc.CDNConfigurationObjectTopology, topologyObjects(current.Topologies), topologyObjects(desired.Topologies))
+	if fields := diffFields(current.CDN, desired.CDN); len(fields) > 0 {
+		changes = append(changes, tc.CDNConfigurationChange{Action: tc.CDNConfigurationActionUpdate, Type: tc.CDNConfigurationObjectCDN, Name: desired.CDN.Name, Fields: fields})
+	}
+	changes = planUpserts(changes, tc.CDNConfigurationObjectProfile, profileObjects(current.Profiles), profileObjects(desired.Profiles))
+	changes = planUpserts(changes, tc.CDNConfigurationObjectDeliveryService, deliveryServiceObjects(current.DeliveryServices), deliveryServiceObjects(desired.DeliveryServices))
+	changes = planUpserts(changes, tc.CDNConfigurationObjectFederation, federationObjects(current.Federations), federationObjects(desired.Federations))
+
+	changes = planDeletes(changes, tc.CDNConfigurationObjectFederation, federationObjects(current.Federations), federationObjects(desired.Federations))
+	changes = planDeletes(changes, tc.CDNConfigurationObjectDeliveryService, deliveryServiceObjects(current.DeliveryServices), deliveryServiceObjects(desired.DeliveryServices))
+	changes = planDeletes(changes, tc.CDNConfigurationObjectProfile, profileObjects(current.Profiles), profileObjects(desired.Profiles))
+
+	return tc.CDNConfigurationPlan{Changes: changes}
+}
+
+// planUpserts appends the creates and updates of the desired objects to
+// changes, in the order of desired.
+func planUpserts(changes []tc.CDNConfigurationChange, objType tc.CDNConfigurationObjectType, current []namedObject, desired []namedObject) []tc.CDNConfigurationChange {
+	currentByName := make(map[string]interface{}, len(current))
+	for _, obj := range current {
+		currentByName[obj.Name] = obj.Object
+	}
+	for _, obj := range desired {
+		currentObj, ok := currentByName[obj.Name]
+		if !ok {
+			changes = append(changes, tc.CDNConfigurationChange{Action: tc.CDNConfigurationActionCreate, Type: objType, Name: obj.Name})
+			continue
+		}
+		if fields := diffFields(currentObj, obj.Object); len(fields) > 0 {
+			changes = append(changes, tc.CDNConfigurationChange{Action: tc.CDNConfigurationActionUpdate, Type: objType, Name: obj.Name, Fields: fields})
+		}
+	}
+	return changes
+}
+
+// planDeletes appends the deletes of the current objects which aren't
+// desired to changes, in the order of current.
+func planDeletes(changes []tc.CDNConfigurationChange, objType tc.CDNConfigurationObjectType, current []namedObject, desired []namedObject) []tc.CDNConfigurationChange {
+	desiredNames := make(map[string]struct{}, len(desired))
+	for _, obj := range desired {
+		desiredNames[obj.Name] = struct{}{}
+	}
+	for _, obj := range current {
+		if _, ok := desiredNames[obj.Name]; !ok {
+			changes = append(changes, tc.CDNConfigurationChange{Action: tc.CDNConfigurationActionDelete, Type: objType, Name: obj.Name})
+		}
+	}
+	return changes
+}
+
+// diffFields returns the sorted names of the top-level JSON fields which
+// differ between the given objects.
+func diffFields(current interface{}, desired interface{}) []string {
+	currentFields := jsonFields(current)
+	desiredFields := jsonFields(desired)
+	fields := []string{}
+	for name, val := range desiredFields {
+		if !reflect.DeepEqual(val, currentFields[name]) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range currentFields {
+		if _, ok := desiredFields[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// jsonFields returns the top-level fields of the given object's JSON. The
+// objects of a CDNConfiguration always marshal to JSON objects.
+func jsonFields(obj interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	bts, err := json.Marshal(obj)
+	if err != nil {
+		return fields
+	}
+	json.Unmarshal(bts, &fields)
+	return fields
+}
+
+func cacheGroupObjects(cgs []tc.CDNConfigurationCacheGroup) []namedObject {
+	objs := make([]namedObject, len(cgs))
+	for i, cg := range cgs {
+		objs[i] = namedObject{Name: cg.Name, Object: cg}
+	}
+	return objs
+}
+
+func topologyObjects(topologies []tc.CDNConfigurationTopology) []namedObject {
+	objs := make([]namedObject, len(topologies))
+	for i, topology := range topologies {
+		objs[i] = namedObject{Name: topology.Name, Object: topology}
+	}
+	return objs
+}
+
+func profileObjects(profiles []tc.CDNConfigurationProfile) []namedObject {
+	objs := make([]namedObject, len(profiles))
+	for i, profile := range profiles {
+		objs[i] = namedObject{Name: profile.Name, Object: profile}
+	}
+	return objs
+}
+
+func deliveryServiceObjects(dses []tc.CDNConfigurationDeliveryService) []namedObject {
+	objs := make([]namedObject, len(dses))
+	for i, ds := range dses {
+		objs[i] = namedObject{Name: dsXMLID(ds), Object: ds}
+	}
+	return objs
+}
+
+func federationObjects(feds []tc.CDNConfigurationFederation) []namedObject {
+	objs := make([]namedObject, len(feds))
+	for i, fed := range feds {
+		objs[i] = namedObject{Name: federationName(fed), Object: fed}
+	}
+	return objs
+}
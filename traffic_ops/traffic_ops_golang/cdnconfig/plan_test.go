@@ -0,0 +1,226 @@
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func testConfiguration() tc.CDNConfiguration {
+	ds := func(xmlID string, active bool) tc.CDNConfigurationDeliveryService {
+		ds := tc.CDNConfigurationDeliveryService{}
+		ds.XMLID = util.StrPtr(xmlID)
+		ds.Active = util.BoolPtr(active)
+		ds.Type = (*tc.DSType)(util.StrPtr(string(tc.DSTypeHTTP)))
+		ds.Tenant = util.StrPtr("root")
+		ds.ProfileName = util.StrPtr("EDGE1")
+		ds.Regexes = []tc.CDNConfigurationRegex{{Type: "HOST_REGEXP", Pattern: `.*\.` + xmlID + `\..*`}}
+		return ds
+	}
+	return tc.CDNConfiguration{
+		Version:            tc.CDNConfigurationVersion,
+		CDN:                tc.CDNConfigurationCDN{Name: "cdn1", DomainName: "example.net"},
+		ServerCapabilities: []string{"ram"},
+		CacheGroups: []tc.CDNConfigurationCacheGroup{
+			{Name: "edge", ShortName: "edge", Type: "EDGE_LOC", ParentCacheGroup: util.StrPtr("mid")},
+			{Name: "mid", ShortName: "mid", Type: "MID_LOC"},
+		},
+		Topologies: []tc.CDNConfigurationTopology{
+			{Name: "topo", Nodes: []tc.CDNConfigurationTopologyNode{{CacheGroup: "mid"}, {CacheGroup: "edge", Parents: []string{"mid"}}}},
+		},
+		Profiles: []tc.CDNConfigurationProfile{
+			{Name: "EDGE1", Type: "ATS_PROFILE", Parameters: []tc.CDNConfigurationParameter{
+				{ConfigFile: "records.config", Name: "CONFIG proxy.config.http.cache.http", Value: "INT 1"},
+			}},
+		},
+		DeliveryServices: []tc.CDNConfigurationDeliveryService{ds("ds2", true), ds("ds1", true)},
+		Federations: []tc.CDNConfigurationFederation{
+			{DeliveryService: "ds1", CName: "fed.example.net.", TTL: 60, Resolvers: []string{"192.0.2.1"}},
+		},
+	}
+}
+
+func TestNormalizeConfiguration(t *testing.T) {
+	cfg := testConfiguration()
+	normalizeConfiguration(&cfg)
+
+	if cfg.CacheGroups[0].Name != "edge" || cfg.CacheGroups[1].Name != "mid" {
+		t.Errorf("expected cache groups sorted by name, actual %s, %s", cfg.CacheGroups[0].Name, cfg.CacheGroups[1].Name)
+	}
+	if nodes := cfg.Topologies[0].Nodes; nodes[0].CacheGroup != "edge" || nodes[1].Parents == nil {
+		t.Errorf("expected topology nodes sorted with non-nil parents, actual %+v", nodes)
+	}
+	if *cfg.DeliveryServices[0].XMLID != "ds1" {
+		t.Errorf("expected delivery services sorted by XMLID, actual first %s", *cfg.DeliveryServices[0].XMLID)
+	}
+	if cfg.DeliveryServices[0].SteeringTargets == nil || cfg.DeliveryServices[0].RequiredCapabilities == nil {
+		t.Errorf("expected nil delivery service lists to be empty")
+	}
+
+	ds := cfg.DeliveryServices[0]
+	ds.ID = util.IntPtr(42)
+	ds.CDNName = util.StrPtr("cdn1")
+	ds.ExampleURLs = []string{"http://ds1.cdn1.example.net"}
+	normalizeDeliveryService(&ds)
+	if ds.ID != nil || ds.CDNName != nil || ds.ExampleURLs != nil {
+		t.Errorf("expected normalized delivery service to have no ID, CDN, or example URLs, actual %v %v %v", ds.ID, ds.CDNName, ds.ExampleURLs)
+	}
+}
+
+func TestValidateConfiguration(t *testing.T) {
+	cfg := testConfiguration()
+	normalizeConfiguration(&cfg)
+	if err := validateConfiguration(cfg, "cdn1"); err != nil {
+		t.Fatalf("expected valid configuration, actual error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(cfg *tc.CDNConfiguration)
+		expected string
+	}{
+		{"wrong cdn", func(cfg *tc.CDNConfiguration) { cfg.CDN.Name = "cdn2" }, "cdn.name must be 'cdn1'"},
+		{"wrong version", func(cfg *tc.CDNConfiguration) { cfg.Version = 0 }, "version must be"},
+		{"duplicate profile", func(cfg *tc.CDNConfiguration) { cfg.Profiles = append(cfg.Profiles, cfg.Profiles[0]) }, "profile 'EDGE1' is duplicated"},
+		{"parent cycle", func(cfg *tc.CDNConfiguration) { cfg.CacheGroups[1].ParentCacheGroup = util.StrPtr("edge") }, "is its own parent"},
+		{"topology parent not a node", func(cfg *tc.CDNConfiguration) { cfg.Topologies[0].Nodes[0].Parents = []string{"other"} }, "parent 'other' is not a node"},
+		{"unknown ds profile", func(cfg *tc.CDNConfiguration) { cfg.DeliveryServices[0].ProfileName = util.StrPtr("nope") }, "profile 'nope' is not a profile"},
+		{"unknown steering target", func(cfg *tc.CDNConfiguration) {
+			cfg.DeliveryServices[0].SteeringTargets = []tc.CDNConfigurationSteeringTarget{{Target: "nope", Type: "STEERING_WEIGHT", Value: 1}}
+		}, "steering target 'nope'"},
+		{"federation of unknown ds", func(cfg *tc.CDNConfiguration) { cfg.Federations[0].DeliveryService = "nope" }, "delivery service 'nope' is not"},
+		{"ds without tenant", func(cfg *tc.CDNConfiguration) { cfg.DeliveryServices[0].Tenant = nil }, "must have a tenant"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := testConfiguration()
+			normalizeConfiguration(&cfg)
+			test.modify(&cfg)
+			err := validateConfiguration(cfg, "cdn1")
+			if err == nil {
+				t.Fatalf("expected error containing '%s', actual nil", test.expected)
+			}
+			if !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("expected error containing '%s', actual: %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestSortCacheGroups(t *testing.T) {
+	cgs := []tc.CDNConfigurationCacheGroup{
+		{Name: "a", ParentCacheGroup: util.StrPtr("c"), SecondaryParentCacheGroup: util.StrPtr("b")},
+		{Name: "b", ParentCacheGroup: util.StrPtr("c")},
+		{Name: "c", ParentCacheGroup: util.StrPtr("elsewhere")},
+	}
+	sorted, err := sortCacheGroups(cgs)
+	if err != nil {
+		t.Fatalf("expected no error, actual %v", err)
+	}
+	names := []string{}
+	for _, cg := range sorted {
+		names = append(names, cg.Name)
+	}
+	if expected := []string{"c", "b", "a"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, actual %v", expected, names)
+	}
+}
+
+func TestMakePlanUnchanged(t *testing.T) {
+	current := testConfiguration()
+	normalizeConfiguration(&current)
+	desired := testConfiguration()
+	normalizeConfiguration(&desired)
+	if plan := makePlan(current, desired); len(plan.Changes) != 0 {
+		t.Errorf("expected no changes, actual %+v", plan.Changes)
+	}
+}
+
+func TestMakePlan(t *testing.T) {
+	current := testConfiguration()
+	current.ServerCapabilities = nil
+	current.CacheGroups = current.CacheGroups[1:]
+	current.Topologies = nil
+	current.DeliveryServices = append(current.DeliveryServices, testConfiguration().DeliveryServices[0])
+	*current.DeliveryServices[2].XMLID = "old"
+	current.Profiles = append(current.Profiles, tc.CDNConfigurationProfile{Name: "OLD", Type: "ATS_PROFILE"})
+	normalizeConfiguration(&current)
+
+	desired := testConfiguration()
+	desired.CDN.DNSSECEnabled = true
+	desired.CacheGroups[1].Latitude = 1
+	desired.DeliveryServices[1].Active = util.BoolPtr(false)
+	desired.DeliveryServices[1].RequiredCapabilities = []string{"ram"}
+	desired.Federations = nil
+	normalizeConfiguration(&desired)
+
+	expected := []tc.CDNConfigurationChange{
+		{Action: tc.CDNConfigurationActionCreate, Type: tc.CDNConfigurationObjectServerCapability, Name: "ram"},
+		{Action: tc.CDNConfigurationActionUpdate, Type: tc.CDNConfigurationObjectCacheGroup, Name: "mid", Fields: []string{"latitude"}},
+		{Action: tc.CDNConfigurationActionCreate, Type: tc.CDNConfigurationObjectCacheGroup, Name: "edge"},
+		{Action: tc.CDNConfigurationActionCreate, Type: tc.CDNConfigurationObjectTopology, Name: "topo"},
+		{Action: tc.CDNConfigurationActionUpdate, Type: tc.CDNConfigurationObjectCDN, Name: "cdn1", Fields: []string{"dnssecEnabled"}},
+		{Action: tc.CDNConfigurationActionUpdate, Type: tc.CDNConfigurationObjectDeliveryService, Name: "ds1", Fields: []string{"active", "requiredCapabilities"}},
+		{Action: tc.CDNConfigurationActionDelete, Type: tc.CDNConfigurationObjectFederation, Name: "ds1/fed.example.net."},
+		{Action: tc.CDNConfigurationActionDelete, Type: tc.CDNConfigurationObjectDeliveryService, Name: "old"},
+		{Action: tc.CDNConfigurationActionDelete, Type: tc.CDNConfigurationObjectProfile, Name: "OLD"},
+	}
+	if actual := makePlan(current, desired).Changes; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected changes:\n%+v\nactual:\n%+v", expected, actual)
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	cfg := testConfiguration()
+	normalizeConfiguration(&cfg)
+	bts, err := marshalYAML(cfg)
+	if err != nil {
+		t.Fatalf("marshalling YAML: %v", err)
+	}
+	if !strings.Contains(string(bts), "deliveryServices:") || !strings.Contains(string(bts), "xmlId: ds1") {
+		t.Errorf("expected YAML with JSON field names, actual:\n%s", bts)
+	}
+
+	actual := tc.CDNConfiguration{}
+	if err := unmarshalYAML(bts, &actual); err != nil {
+		t.Fatalf("unmarshalling YAML: %v", err)
+	}
+	normalizeConfiguration(&actual)
+	if plan := makePlan(cfg, actual); len(plan.Changes) != 0 {
+		t.Errorf("expected YAML round trip to be unchanged, actual changes %+v", plan.Changes)
+	}
+}
+
+func TestGetFormat(t *testing.T) {
+	if format, err := getFormat(map[string]string{}, "application/yaml"); err != nil || format != FormatYAML {
+		t.Errorf("expected YAML Content-Type to be yaml, actual %s %v", format, err)
+	}
+	if format, err := getFormat(map[string]string{FormatQueryParam: FormatJSON}, "application/yaml"); err != nil || format != FormatJSON {
+		t.Errorf("expected format parameter to override Content-Type, actual %s %v", format, err)
+	}
+	if _, err := getFormat(map[string]string{FormatQueryParam: "xml"}, ""); err == nil {
+		t.Errorf("expected error for unknown format, actual nil")
+	}
+}
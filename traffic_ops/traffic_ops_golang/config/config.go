@@ -72,30 +72,32 @@ type ConfigTrafficOpsGolang struct {
 	// Deprecated in 5.0
 	Insecure bool `json:"insecure"`
 	// end deprecated
-	Port                     string                     `json:"port"`
-	ProxyTimeout             int                        `json:"proxy_timeout"`
-	ProxyKeepAlive           int                        `json:"proxy_keep_alive"`
-	ProxyTLSTimeout          int                        `json:"proxy_tls_timeout"`
-	ProxyReadHeaderTimeout   int                        `json:"proxy_read_header_timeout"`
-	ReadTimeout              int                        `json:"read_timeout"`
-	RequestTimeout           int                        `json:"request_timeout"`
-	ReadHeaderTimeout        int                        `json:"read_header_timeout"`
-	WriteTimeout             int                        `json:"write_timeout"`
-	IdleTimeout              int                        `json:"idle_timeout"`
-	LogLocationError         string                     `json:"log_location_error"`
-	LogLocationWarning       string                     `json:"log_location_warning"`
-	LogLocationInfo          string                     `json:"log_location_info"`
-	LogLocationDebug         string                     `json:"log_location_debug"`
-	LogLocationEvent         string                     `json:"log_location_event"`
-	MaxDBConnections         int                        `json:"max_db_connections"`
-	DBMaxIdleConnections     int                        `json:"db_max_idle_connections"`
-	DBConnMaxLifetimeSeconds int                        `json:"db_conn_max_lifetime_seconds"`
-	DBQueryTimeoutSeconds    int                        `json:"db_query_timeout_seconds"`
-	Plugins                  []string                   `json:"plugins"`
-	PluginConfig             map[string]json.RawMessage `json:"plugin_config"`
-	PluginSharedConfig       map[string]interface{}     `json:"plugin_shared_config"`
-	ProfilingEnabled         bool                       `json:"profiling_enabled"`
-	ProfilingLocation        string                     `json:"profiling_location"`
+	Port                           string                     `json:"port"`
+	ProxyTimeout                   int                        `json:"proxy_timeout"`
+	ProxyKeepAlive                 int                        `json:"proxy_keep_alive"`
+	ProxyTLSTimeout                int                        `json:"proxy_tls_timeout"`
+	ProxyReadHeaderTimeout         int                        `json:"proxy_read_header_timeout"`
+	ReadTimeout                    int                        `json:"read_timeout"`
+	RequestTimeout                 int                        `json:"request_timeout"`
+	ReadHeaderTimeout              int                        `json:"read_header_timeout"`
+	WriteTimeout                   int                        `json:"write_timeout"`
+	IdleTimeout                    int                        `json:"idle_timeout"`
+	LogLocationError               string                     `json:"log_location_error"`
+	LogLocationWarning             string                     `json:"log_location_warning"`
+	LogLocationInfo                string                     `json:"log_location_info"`
+	LogLocationDebug               string                     `json:"log_location_debug"`
+	LogLocationEvent               string                     `json:"log_location_event"`
+	MaxDBConnections               int                        `json:"max_db_connections"`
+	DBMaxIdleConnections           int                        `json:"db_max_idle_connections"`
+	DBConnMaxLifetimeSeconds       int                        `json:"db_conn_max_lifetime_seconds"`
+	DBQueryTimeoutSeconds          int                        `json:"db_query_timeout_seconds"`
+	Plugins                        []string                   `json:"plugins"`
+	PluginConfig                   map[string]json.RawMessage `json:"plugin_config"`
+	PluginSharedConfig             map[string]interface{}     `json:"plugin_shared_config"`
+	ProfilingEnabled               bool                       `json:"profiling_enabled"`
+	ProfilingLocation              string                     `json:"profiling_location"`
+	RolloutIntervalSeconds         int                        `json:"rollout_interval_seconds"`
+	ScheduledChangeIntervalSeconds int                        `json:"scheduled_change_interval_seconds"`
 	// Deprecated: use 'port' in traffic_vault_config instead.
 	RiakPort             *uint    `json:"riak_port"`
 	WhitelistedOAuthUrls []string `json:"whitelisted_oauth_urls"`
@@ -212,6 +214,8 @@ func NewFakeConfig() Config {
 
 const DefaultLDAPTimeoutSecs = 60
 const DefaultDBQueryTimeoutSecs = 20
+const DefaultRolloutIntervalSecs = 10
+const DefaultScheduledChangeIntervalSecs = 10
 
 // ErrorLog - critical messages
 func (c Config) ErrorLog() log.LogLocation {
@@ -382,6 +386,12 @@ func ParseConfig(cfg Config) (Config, error) {
 	if cfg.DBQueryTimeoutSeconds == 0 {
 		cfg.DBQueryTimeoutSeconds = DefaultDBQueryTimeoutSecs
 	}
+	if cfg.RolloutIntervalSeconds == 0 {
+		cfg.RolloutIntervalSeconds = DefaultRolloutIntervalSecs
+	}
+	if cfg.ScheduledChangeIntervalSeconds <= 0 {
+		cfg.ScheduledChangeIntervalSeconds = DefaultScheduledChangeIntervalSecs
+	}
 
 	invalidTOURLStr := ""
 	var err error
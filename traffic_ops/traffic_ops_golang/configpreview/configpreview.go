@@ -0,0 +1,79 @@
+// Package configpreview previews the changes a set of Parameter and Delivery
+// Service changes would make to the config of every cache on a CDN.
+package configpreview
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg/preview"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+// DirQueryParam is the query parameter of the ATS config directory, used for
+// config files without location Parameters.
+const DirQueryParam = "dir"
+
+// Post handles previewing the config changes of the change set in the request
+// body, on every cache of the CDN in the path.
+//
+// Nothing is changed in Traffic Ops. The change set is applied to the data
+// read in this request's transaction, and config generated for each cache with
+// and without it, as t3c-preview does.
+func Post(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	changes := preview.ChangeSet{}
+	if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("parsing request: "+err.Error()), nil)
+		return
+	}
+
+	cdnName := tc.CDNName(inf.Params["name"])
+	before, ok, userErr, sysErr, errCode := getCDNConfigData(inf, cdnName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("cdn '"+string(cdnName)+"' not found"), nil)
+		return
+	}
+
+	after, err := changes.Apply(before)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("applying changes: "+err.Error()), nil)
+		return
+	}
+
+	dir := preview.DefaultDir
+	if paramDir, ok := inf.Params[DirQueryParam]; ok {
+		dir = paramDir
+	}
+	api.WriteResp(w, r, preview.Preview(before, after, preview.DefaultOpts(dir)))
+}
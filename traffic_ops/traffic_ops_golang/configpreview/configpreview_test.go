@@ -0,0 +1,139 @@
+package configpreview
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestSplitParams(t *testing.T) {
+	params := []tc.Parameter{
+		{ID: 1, ConfigFile: "global", Name: "tm.url", Profiles: json.RawMessage(`["GLOBAL"]`)},
+		{ID: 2, ConfigFile: atscfg.RecordsFileName, Name: "CONFIG a", Profiles: json.RawMessage(`["EDGE","MID","OTHER"]`)},
+		{ID: 3, ConfigFile: atscfg.ParentConfigFileName, Name: "b", Profiles: json.RawMessage(`["MID"]`)},
+		{ID: 4, ConfigFile: atscfg.CacheKeyParameterConfigFile, Name: "c", Profiles: json.RawMessage(`["OTHER"]`)},
+		{ID: 5, ConfigFile: atscfg.LoggingYAMLFileName, Name: "DSLogObject.Filename", Profiles: json.RawMessage(`["DS_PROFILE"]`)},
+	}
+	data := &atscfg.CDNConfigData{}
+	if err := splitParams(data, params, []string{"EDGE", "MID", "UNUSED"}); err != nil {
+		t.Fatal(err)
+	}
+	globalParams, profileParams, cacheKeyParams, parentConfigParams := data.GlobalParams, data.ProfileParams, data.CacheKeyParams, data.ParentConfigParams
+
+	paramIDs := func(params []tc.Parameter) []int {
+		ids := []int{}
+		for _, param := range params {
+			ids = append(ids, param.ID)
+		}
+		return ids
+	}
+	if ids := paramIDs(globalParams); !reflect.DeepEqual(ids, []int{1}) {
+		t.Errorf("expected global params [1], actual %v", ids)
+	}
+	if ids := paramIDs(profileParams["EDGE"]); !reflect.DeepEqual(ids, []int{2}) {
+		t.Errorf("expected EDGE params [2], actual %v", ids)
+	}
+	if ids := paramIDs(profileParams["MID"]); !reflect.DeepEqual(ids, []int{2, 3}) {
+		t.Errorf("expected MID params [2 3], actual %v", ids)
+	}
+	if params, ok := profileParams["UNUSED"]; !ok || len(params) != 0 {
+		t.Errorf("expected a profile without params to have an empty list, actual %v", params)
+	}
+	if _, ok := profileParams["OTHER"]; ok {
+		t.Error("expected params of profiles not requested to not be included")
+	}
+	if ids := paramIDs(cacheKeyParams); !reflect.DeepEqual(ids, []int{4}) {
+		t.Errorf("expected cachekey.config params [4], actual %v", ids)
+	}
+	if ids := paramIDs(parentConfigParams); !reflect.DeepEqual(ids, []int{3}) {
+		t.Errorf("expected parent.config params [3], actual %v", ids)
+	}
+
+	if ids := paramIDs(data.LoggingYAMLParams); !reflect.DeepEqual(ids, []int{5}) {
+		t.Errorf("expected logging.yaml params of any profile [5], actual %v", ids)
+	}
+
+	if err := splitParams(&atscfg.CDNConfigData{}, []tc.Parameter{{Profiles: json.RawMessage(`{`)}}, nil); err == nil {
+		t.Error("expected malformed profiles to return an error")
+	}
+}
+
+func TestGetDeliveryServiceRegexes(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	rows := sqlmock.NewRows([]string{"xml_id", "name", "set_number", "pattern"})
+	rows.AddRow("ds0", "HOST_REGEXP", 0, `.*\.ds0\..*`)
+	rows.AddRow("ds0", "PATH_REGEXP", 1, `/foo/.*`)
+	rows.AddRow("ds1", "HOST_REGEXP", 0, `.*\.ds1\..*`)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT").WithArgs(42).WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx, err := mockDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dsRegexes, err := getDeliveryServiceRegexes(tx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Commit()
+
+	expected := []tc.DeliveryServiceRegexes{
+		{DSName: "ds0", Regexes: []tc.DeliveryServiceRegex{
+			{Type: "HOST_REGEXP", SetNumber: 0, Pattern: `.*\.ds0\..*`},
+			{Type: "PATH_REGEXP", SetNumber: 1, Pattern: `/foo/.*`},
+		}},
+		{DSName: "ds1", Regexes: []tc.DeliveryServiceRegex{
+			{Type: "HOST_REGEXP", SetNumber: 0, Pattern: `.*\.ds1\..*`},
+		}},
+	}
+	if !reflect.DeepEqual(dsRegexes, expected) {
+		t.Errorf("expected regexes grouped by delivery service %+v, actual %+v", expected, dsRegexes)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCheckTenancy(t *testing.T) {
+	dses := []tc.DeliveryServiceV4{{}, {}, {}}
+	dses[0].TenantID = util.IntPtr(1)
+	dses[1].TenantID = util.IntPtr(2)
+
+	if err := checkTenancy(dses, []int{1, 2, 3}); err != nil {
+		t.Errorf("expected no error when the user can see every delivery service, actual %v", err)
+	}
+	if err := checkTenancy(dses, []int{1}); err == nil {
+		t.Error("expected an error when the user can't see a delivery service")
+	}
+}
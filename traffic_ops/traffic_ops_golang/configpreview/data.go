@@ -0,0 +1,380 @@
+package configpreview
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/cachegroup"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/parameter"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/server"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/topology"
+
+	"github.com/lib/pq"
+)
+
+// HiddenField is the value of secure Parameters, for users who may not see them.
+const HiddenField = "********"
+
+// getCDNConfigData returns the data to generate the config of every cache on
+// the given CDN, as t3c-request --get-data=cdn-config does.
+//
+// Traffic Vault keys are not included, so config files with keys are generated
+// without them, and are never returned in a preview.
+//
+// Returns the data, whether the CDN exists, any user error, any system error,
+// and the status code to return if there was an error.
+func getCDNConfigData(inf *api.APIInfo, cdnName tc.CDNName) (*atscfg.CDNConfigData, bool, error, error, int) {
+	tx := inf.Tx.Tx
+
+	cdn, ok, err := getCDN(tx, cdnName)
+	if err != nil {
+		return nil, false, nil, errors.New("getting cdn: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return nil, false, nil, nil, http.StatusOK
+	}
+	data := &atscfg.CDNConfigData{}
+	data.CDN = &cdn
+
+	// the cachegroup and topology readers take their query parameters from the
+	// API info, which has the CDN name as a path parameter
+	readInf := *inf
+	readInf.Params = map[string]string{}
+
+	servers, userErr, sysErr, errCode := server.GetServers(map[string]string{"cdn": strconv.Itoa(cdn.ID)}, inf.Tx, inf.User, api.Version{Major: 4, Minor: 0})
+	if userErr != nil || sysErr != nil {
+		return nil, true, userErr, sysErr, errCode
+	}
+	for _, sv := range servers {
+		svV3, err := sv.ToServerV3FromV4()
+		if err != nil {
+			return nil, true, nil, errors.New("converting server '" + strconv.Itoa(*sv.ID) + "': " + err.Error()), http.StatusInternalServerError
+		}
+		data.Servers = append(data.Servers, atscfg.Server(svV3))
+	}
+
+	cacheGroups, userErr, sysErr, errCode, _ := (&cachegroup.TOCacheGroup{APIInfoImpl: api.APIInfoImpl{ReqInfo: &readInf}}).Read(nil, false)
+	if userErr != nil || sysErr != nil {
+		return nil, true, userErr, sysErr, errCode
+	}
+	for _, cg := range cacheGroups {
+		data.CacheGroups = append(data.CacheGroups, cg.(cachegroup.TOCacheGroup).CacheGroupNullable)
+	}
+
+	topologies, userErr, sysErr, errCode, _ := (&topology.TOTopology{APIInfoImpl: api.APIInfoImpl{ReqInfo: &readInf}}).Read(nil, false)
+	if userErr != nil || sysErr != nil {
+		return nil, true, userErr, sysErr, errCode
+	}
+	for _, tp := range topologies {
+		data.Topologies = append(data.Topologies, tp.(tc.Topology))
+	}
+
+	profileNames := []string{}
+	for _, sv := range data.CacheServers() {
+		profileNames = append(profileNames, *sv.Profile)
+	}
+	if data.Profiles, err = getProfiles(tx, profileNames); err != nil {
+		return nil, true, nil, errors.New("getting profiles: " + err.Error()), http.StatusInternalServerError
+	}
+
+	params, err := getParams(tx, inf.User)
+	if err != nil {
+		return nil, true, nil, errors.New("getting parameters: " + err.Error()), http.StatusInternalServerError
+	}
+	if err := splitParams(data, params, profileNames); err != nil {
+		return nil, true, nil, errors.New("getting parameters: " + err.Error()), http.StatusInternalServerError
+	}
+
+	dses, userErr, sysErr, errCode := deliveryservice.GetDeliveryServices(deliveryservice.SelectDeliveryServicesQuery+` WHERE ds.cdn_id = :cdn_id`, map[string]interface{}{"cdn_id": cdn.ID}, inf.Tx)
+	if userErr != nil || sysErr != nil {
+		return nil, true, userErr, sysErr, errCode
+	}
+	// The config of every cache on the CDN is generated from all of its
+	// Delivery Services, so previewing it would expose those the user can't
+	// see.
+	tenantIDs, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		return nil, true, nil, errors.New("getting user tenants: " + err.Error()), http.StatusInternalServerError
+	}
+	if userErr := checkTenancy(dses, tenantIDs); userErr != nil {
+		return nil, true, userErr, nil, http.StatusForbidden
+	}
+	for _, ds := range dses {
+		data.DeliveryServices = append(data.DeliveryServices, atscfg.DeliveryService(ds.DowngradeToV3()))
+	}
+
+	if data.DeliveryServiceServers, err = getDeliveryServiceServers(tx, cdn.ID); err != nil {
+		return nil, true, nil, errors.New("getting delivery service servers: " + err.Error()), http.StatusInternalServerError
+	}
+	if data.DeliveryServiceRegexes, err = getDeliveryServiceRegexes(tx, cdn.ID); err != nil {
+		return nil, true, nil, errors.New("getting delivery service regexes: " + err.Error()), http.StatusInternalServerError
+	}
+	if data.Jobs, err = getJobs(tx, cdn.ID); err != nil {
+		return nil, true, nil, errors.New("getting jobs: " + err.Error()), http.StatusInternalServerError
+	}
+	if data.ServerCapabilities, err = getCapabilities(tx, serverCapabilitiesQuery); err != nil {
+		return nil, true, nil, errors.New("getting server capabilities: " + err.Error()), http.StatusInternalServerError
+	}
+	if data.DSRequiredCapabilities, err = getCapabilities(tx, dsRequiredCapabilitiesQuery); err != nil {
+		return nil, true, nil, errors.New("getting delivery service required capabilities: " + err.Error()), http.StatusInternalServerError
+	}
+	return data, true, nil, nil, http.StatusOK
+}
+
+// checkTenancy returns an error if any of the given Delivery Services isn't in
+// one of the given tenants.
+func checkTenancy(dses []tc.DeliveryServiceV4, tenantIDs []int) error {
+	tenants := make(map[int]struct{}, len(tenantIDs))
+	for _, id := range tenantIDs {
+		tenants[id] = struct{}{}
+	}
+	for _, ds := range dses {
+		if ds.TenantID == nil {
+			continue
+		}
+		if _, ok := tenants[*ds.TenantID]; !ok {
+			return errors.New("not authorized on all delivery services of the cdn")
+		}
+	}
+	return nil
+}
+
+func getCDN(tx *sql.Tx, cdnName tc.CDNName) (tc.CDN, bool, error) {
+	cdn := tc.CDN{}
+	qry := `SELECT id, name, domain_name, dnssec_enabled, last_updated FROM cdn WHERE name = $1`
+	if err := tx.QueryRow(qry, cdnName).Scan(&cdn.ID, &cdn.Name, &cdn.DomainName, &cdn.DNSSECEnabled, &cdn.LastUpdated); err != nil {
+		if err == sql.ErrNoRows {
+			return tc.CDN{}, false, nil
+		}
+		return tc.CDN{}, false, errors.New("querying: " + err.Error())
+	}
+	return cdn, true, nil
+}
+
+// getProfiles returns the Profiles with the given names, by name.
+func getProfiles(tx *sql.Tx, names []string) (map[string]tc.Profile, error) {
+	qry := `
+SELECT p.id, p.name, p.description, c.name, p.cdn, p.routing_disabled, p.type, p.last_updated
+FROM profile p
+JOIN cdn c ON p.cdn = c.id
+WHERE p.name = ANY($1)
+`
+	rows, err := tx.Query(qry, pq.Array(names))
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+	profiles := map[string]tc.Profile{}
+	for rows.Next() {
+		p := tc.Profile{}
+		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.CDNName, &p.CDNID, &p.RoutingDisabled, &p.Type, &p.LastUpdated); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		profiles[p.Name] = p
+	}
+	return profiles, nil
+}
+
+// getParams returns all Parameters assigned to any Profile. The values of
+// secure Parameters are hidden from users without the
+// parameter.SecureReadPermission, as they are by the parameters endpoint.
+func getParams(tx *sql.Tx, user *auth.CurrentUser) ([]tc.Parameter, error) {
+	qry := `
+SELECT p.id, p.name, p.config_file, p.value, p.secure, p.last_updated, array_to_json(array_agg(pr.name ORDER BY pr.name))
+FROM parameter p
+JOIN profile_parameter pp ON p.id = pp.parameter
+JOIN profile pr ON pp.profile = pr.id
+GROUP BY p.id
+ORDER BY p.id
+`
+	rows, err := tx.Query(qry)
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+	params := []tc.Parameter{}
+	for rows.Next() {
+		p := tc.Parameter{}
+		profiles := []byte{}
+		if err := rows.Scan(&p.ID, &p.Name, &p.ConfigFile, &p.Value, &p.Secure, &p.LastUpdated, &profiles); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		p.Profiles = json.RawMessage(profiles)
+		if p.Secure && !user.Can(parameter.SecureReadPermission) {
+			p.Value = HiddenField
+		}
+		params = append(params, p)
+	}
+	return params, nil
+}
+
+// splitParams splits the given Parameters into those of the GLOBAL Profile,
+// those of each of the given Profiles, and the cachekey.config, parent.config,
+// and logging.yaml Parameters of any Profile, as CDNConfigData holds them.
+// The logging.yaml Parameters include those of Delivery Service Profiles, for
+// Delivery Service logs.
+func splitParams(data *atscfg.CDNConfigData, params []tc.Parameter, profileNames []string) error {
+	data.GlobalParams = []tc.Parameter{}
+	data.CacheKeyParams = []tc.Parameter{}
+	data.ParentConfigParams = []tc.Parameter{}
+	data.LoggingYAMLParams = []tc.Parameter{}
+	data.ProfileParams = map[string][]tc.Parameter{}
+	for _, name := range profileNames {
+		data.ProfileParams[name] = []tc.Parameter{}
+	}
+
+	for _, param := range params {
+		paramProfiles := []string{}
+		if err := json.Unmarshal(param.Profiles, &paramProfiles); err != nil {
+			return errors.New("parameter " + strconv.Itoa(param.ID) + " malformed profiles: " + err.Error())
+		}
+		for _, profile := range paramProfiles {
+			if profile == tc.GlobalProfileName {
+				data.GlobalParams = append(data.GlobalParams, param)
+			}
+			if _, ok := data.ProfileParams[profile]; ok {
+				data.ProfileParams[profile] = append(data.ProfileParams[profile], param)
+			}
+		}
+		switch param.ConfigFile {
+		case atscfg.CacheKeyParameterConfigFile:
+			data.CacheKeyParams = append(data.CacheKeyParams, param)
+		case atscfg.ParentConfigFileName:
+			data.ParentConfigParams = append(data.ParentConfigParams, param)
+		case atscfg.LoggingYAMLFileName:
+			data.LoggingYAMLParams = append(data.LoggingYAMLParams, param)
+		}
+	}
+	return nil
+}
+
+func getDeliveryServiceServers(tx *sql.Tx, cdnID int) ([]atscfg.DeliveryServiceServer, error) {
+	qry := `
+SELECT dss.server, dss.deliveryservice
+FROM deliveryservice_server dss
+JOIN deliveryservice ds ON dss.deliveryservice = ds.id
+WHERE ds.cdn_id = $1
+`
+	rows, err := tx.Query(qry, cdnID)
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+	dsses := []atscfg.DeliveryServiceServer{}
+	for rows.Next() {
+		dss := atscfg.DeliveryServiceServer{}
+		if err := rows.Scan(&dss.Server, &dss.DeliveryService); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		dsses = append(dsses, dss)
+	}
+	return dsses, nil
+}
+
+func getDeliveryServiceRegexes(tx *sql.Tx, cdnID int) ([]tc.DeliveryServiceRegexes, error) {
+	qry := `
+SELECT ds.xml_id, t.name, dsr.set_number, r.pattern
+FROM deliveryservice_regex dsr
+JOIN deliveryservice ds ON dsr.deliveryservice = ds.id
+JOIN regex r ON dsr.regex = r.id
+JOIN type t ON r.type = t.id
+WHERE ds.cdn_id = $1
+ORDER BY ds.xml_id, dsr.set_number
+`
+	rows, err := tx.Query(qry, cdnID)
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+	dsRegexes := []tc.DeliveryServiceRegexes{}
+	for rows.Next() {
+		dsName := ""
+		re := tc.DeliveryServiceRegex{}
+		if err := rows.Scan(&dsName, &re.Type, &re.SetNumber, &re.Pattern); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		if len(dsRegexes) == 0 || dsRegexes[len(dsRegexes)-1].DSName != dsName {
+			dsRegexes = append(dsRegexes, tc.DeliveryServiceRegexes{DSName: dsName})
+		}
+		dsRegexes[len(dsRegexes)-1].Regexes = append(dsRegexes[len(dsRegexes)-1].Regexes, re)
+	}
+	return dsRegexes, nil
+}
+
+func getJobs(tx *sql.Tx, cdnID int) ([]tc.InvalidationJob, error) {
+	qry := `
+SELECT job.id, job.keyword, job.parameters, job.asset_url, job.start_time, u.username, ds.xml_id
+FROM job
+JOIN tm_user u ON job.job_user = u.id
+JOIN deliveryservice ds ON job.job_deliveryservice = ds.id
+WHERE ds.cdn_id = $1
+`
+	rows, err := tx.Query(qry, cdnID)
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+	jobs := []tc.InvalidationJob{}
+	for rows.Next() {
+		j := tc.InvalidationJob{}
+		if err := rows.Scan(&j.ID, &j.Keyword, &j.Parameters, &j.AssetURL, &j.StartTime, &j.CreatedBy, &j.DeliveryService); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+const serverCapabilitiesQuery = `SELECT server, server_capability FROM server_server_capability`
+
+const dsRequiredCapabilitiesQuery = `SELECT deliveryservice_id, required_capability FROM deliveryservices_required_capability`
+
+// getCapabilities returns the capabilities of each ID of the given query, which
+// must select an ID and a capability.
+func getCapabilities(tx *sql.Tx, qry string) (map[int]map[atscfg.ServerCapability]struct{}, error) {
+	rows, err := tx.Query(qry)
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+	caps := map[int]map[atscfg.ServerCapability]struct{}{}
+	for rows.Next() {
+		id := 0
+		capability := ""
+		if err := rows.Scan(&id, &capability); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		if _, ok := caps[id]; !ok {
+			caps[id] = map[atscfg.ServerCapability]struct{}{}
+		}
+		caps[id][atscfg.ServerCapability(capability)] = struct{}{}
+	}
+	return caps, nil
+}
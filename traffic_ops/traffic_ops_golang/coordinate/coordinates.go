@@ -46,8 +46,9 @@ func (v *TOCoordinate) NewReadObj() interface{}       { return &tc.CoordinateNul
 func (v *TOCoordinate) SelectQuery() string           { return selectQuery() }
 func (v *TOCoordinate) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
-		"id":   dbhelpers.WhereColumnInfo{Column: "id", Checker: api.IsInt},
-		"name": dbhelpers.WhereColumnInfo{Column: "name"},
+		"lastUpdated": dbhelpers.WhereColumnInfo{Column: "last_updated", Checker: api.IsTime},
+		"id":          dbhelpers.WhereColumnInfo{Column: "id", Checker: api.IsInt},
+		"name":        dbhelpers.WhereColumnInfo{Column: "name"},
 	}
 }
 
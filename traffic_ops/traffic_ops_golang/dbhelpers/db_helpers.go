@@ -21,11 +21,15 @@ package dbhelpers
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
@@ -126,22 +130,134 @@ func CheckIfCurrentUserHasCdnLock(tx *sql.Tx, cdn, user string) (error, error, i
 	return nil, nil, http.StatusOK
 }
 
+// QueryClauses are the clauses of a query built from the query parameters of
+// a request, by BuildQueryClauses.
+type QueryClauses struct {
+	// Where filters by the query parameters, and the cursor, if any.
+	Where string
+	// FilterWhere filters by the query parameters only, e.g. to count every
+	// matching row on every page.
+	FilterWhere string
+	OrderBy     string
+	Pagination  string
+	Values      map[string]interface{}
+	// Cursor is whether cursor pagination was requested.
+	Cursor bool
+	// Limit is the maximum number of rows requested, or -1 if there's no
+	// limit.
+	Limit int
+}
+
+// NextCursor returns the cursor of the page after one requested with the given
+// parameters, which has the given number of rows, the last of which has the
+// given ID, or an empty string if cursor pagination wasn't requested or there
+// are no more rows.
+func NextCursor(parameters map[string]string, rows int, lastID int) string {
+	cursor, ok := parameters["cursor"]
+	if !ok {
+		return ""
+	}
+	if limit, err := strconv.Atoi(parameters["limit"]); err != nil || rows < limit {
+		return ""
+	}
+	c := queryCursor{After: lastID, Desc: parameters["sortOrder"] == "desc"}
+	if cursor != "" {
+		prev, err := decodeQueryCursor(cursor)
+		if err != nil {
+			return ""
+		}
+		c.Desc = prev.Desc
+	}
+	bts, err := json.Marshal(c)
+	if err != nil {
+		log.Errorf("marshalling cursor: %v", err)
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(bts)
+}
+
+// queryCursor is the decoded value of a cursor, which is the ID of the last
+// row of a page, and the order of the rows.
+type queryCursor struct {
+	After int  `json:"after"`
+	Desc  bool `json:"desc,omitempty"`
+}
+
+func decodeQueryCursor(s string) (queryCursor, error) {
+	c := queryCursor{}
+	bts, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(bts, &c)
+	return c, err
+}
+
+// filterOperator is a comparison of a filter query parameter other than
+// equality. The query parameter's name ends with its Suffix, so that the
+// query string of a request reads naturally, e.g. "lastUpdated>=2021-01-01" is
+// the parameter "lastUpdated>" with the value "2021-01-01".
+type filterOperator struct {
+	Suffix string
+	Name   string
+	SQL    string
+}
+
+var filterOperators = []filterOperator{
+	{Suffix: "!", Name: "ne", SQL: "<>"},
+	{Suffix: ">", Name: "gte", SQL: ">="},
+	{Suffix: "<", Name: "lte", SQL: "<="},
+	{Suffix: "~", Name: "like", SQL: "ILIKE"},
+	{Suffix: "|", Name: "in", SQL: "IN"},
+}
+
+// likeEscaper escapes the special characters of an SQL LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// BuildWhereAndOrderByAndPagination builds the WHERE, ORDER BY, and LIMIT and
+// OFFSET clauses of a query from the given request parameters, as
+// BuildQueryClauses does, and returns them and the values of their named
+// parameters.
 func BuildWhereAndOrderByAndPagination(parameters map[string]string, queryParamsToSQLCols map[string]WhereColumnInfo) (string, string, string, map[string]interface{}, []error) {
-	whereClause := BaseWhere
-	orderBy := BaseOrderBy
-	paginationClause := BaseLimit
+	q, errs := BuildQueryClauses(parameters, queryParamsToSQLCols)
+	if len(errs) > 0 {
+		return "", "", "", q.Values, errs
+	}
+	return q.Where, q.OrderBy, q.Pagination, q.Values, nil
+}
+
+// BuildQueryClauses builds the clauses of a query from the given request
+// parameters, which may be any of the keys of queryParamsToSQLCols, to filter
+// by, and:
+//
+//   - "orderby" and "sortOrder", to sort by one of the keys of
+//     queryParamsToSQLCols, in "asc" or "desc" order.
+//   - "limit", and "offset" or "page", for offset pagination.
+//   - "limit" and "cursor", for cursor pagination, which is always in order of
+//     the "id" key of queryParamsToSQLCols. An empty "cursor" requests the
+//     first page, and the cursor of each next page is given by
+//     NextCursor. Unlike offset pagination, rows aren't skipped or
+//     repeated if rows are created or deleted between pages.
+//
+// Besides equality, a filter may be a comparison, with a parameter name
+// suffixed by one of "!" (not equal), ">" (greater than or equal), "<" (less
+// than or equal), "~" (contains, case-insensitively), or "|" (equal to one of
+// a comma-separated list of values). Filters with a suffix whose key isn't in
+// queryParamsToSQLCols are an error.
+func BuildQueryClauses(parameters map[string]string, queryParamsToSQLCols map[string]WhereColumnInfo) (QueryClauses, []error) {
+	q := QueryClauses{Limit: -1}
 	var criteria string
-	var queryValues map[string]interface{}
 	var errs []error
-	criteria, queryValues, errs = parseCriteriaAndQueryValues(queryParamsToSQLCols, parameters)
-
-	if len(queryValues) > 0 {
-		whereClause += " " + criteria
-	}
+	criteria, q.Values, errs = parseCriteriaAndQueryValues(queryParamsToSQLCols, parameters)
 	if len(errs) > 0 {
-		return "", "", "", queryValues, errs
+		return q, errs
 	}
 
+	if criteria != "" {
+		q.FilterWhere = BaseWhere + " " + criteria
+	}
+
+	orderBy := BaseOrderBy
 	if orderby, ok := parameters["orderby"]; ok {
 		log.Debugln("orderby: ", orderby)
 		if colInfo, ok := queryParamsToSQLCols[orderby]; ok {
@@ -162,14 +278,16 @@ func BuildWhereAndOrderByAndPagination(parameters map[string]string, queryParams
 		}
 	}
 
+	paginationClause := BaseLimit
 	if limit, exists := parameters["limit"]; exists {
 		// try to convert to int, if it fails the limit parameter is invalid, so return an error
 		limitInt, err := strconv.Atoi(limit)
 		if err != nil || limitInt < -1 {
 			errs = append(errs, errors.New("limit parameter must be bigger than -1"))
-			return "", "", "", queryValues, errs
+			return q, errs
 		}
 		log.Debugln("limit: ", limit)
+		q.Limit = limitInt
 		if limitInt == -1 {
 			paginationClause = ""
 		} else {
@@ -180,7 +298,7 @@ func BuildWhereAndOrderByAndPagination(parameters map[string]string, queryParams
 			offsetInt, err := strconv.Atoi(offset)
 			if err != nil || offsetInt < 1 {
 				errs = append(errs, errors.New("offset parameter must be a positive integer"))
-				return "", "", "", queryValues, errs
+				return q, errs
 			}
 			paginationClause += BaseOffset + " " + offset
 		} else if page, exists := parameters["page"]; exists {
@@ -188,27 +306,68 @@ func BuildWhereAndOrderByAndPagination(parameters map[string]string, queryParams
 			page, err := strconv.Atoi(page)
 			if err != nil || page < 1 {
 				errs = append(errs, errors.New("page parameter must be a positive integer"))
-				return "", "", "", queryValues, errs
+				return q, errs
 			}
 			paginationClause += BaseOffset + " " + strconv.Itoa((page-1)*limitInt)
 		}
 	}
 
-	if whereClause == BaseWhere {
-		whereClause = ""
+	q.Where = q.FilterWhere
+	if cursor, exists := parameters["cursor"]; exists {
+		idCol, ok := queryParamsToSQLCols["id"]
+		if !ok {
+			return q, append(errs, errors.New("cursor pagination is not supported by this endpoint"))
+		}
+		if q.Limit < 1 {
+			return q, append(errs, errors.New("cursor parameter requires a positive limit parameter"))
+		}
+		if _, ok := parameters["offset"]; ok {
+			return q, append(errs, errors.New("cursor parameter can't be used with the offset parameter"))
+		}
+		if _, ok := parameters["page"]; ok {
+			return q, append(errs, errors.New("cursor parameter can't be used with the page parameter"))
+		}
+		if orderby, ok := parameters["orderby"]; ok && orderby != "id" {
+			return q, append(errs, errors.New("cursor pagination is always ordered by id"))
+		}
+		q.Cursor = true
+		desc := parameters["sortOrder"] == "desc"
+		if cursor != "" {
+			c, err := decodeQueryCursor(cursor)
+			if err != nil {
+				return q, append(errs, errors.New("cursor parameter is not a valid cursor"))
+			}
+			desc = c.Desc
+			comparison := " > "
+			if desc {
+				comparison = " < "
+			}
+			q.Values["cursor"] = c.After
+			if q.Where == "" {
+				q.Where = BaseWhere + " " + idCol.Column + comparison + ":cursor"
+			} else {
+				q.Where += " AND " + idCol.Column + comparison + ":cursor"
+			}
+		}
+		orderBy = BaseOrderBy + " " + idCol.Column
+		if desc {
+			orderBy += " DESC"
+		}
 	}
-	if orderBy == BaseOrderBy {
-		orderBy = ""
+
+	if orderBy != BaseOrderBy {
+		q.OrderBy = orderBy
 	}
-	if paginationClause == BaseLimit {
-		paginationClause = ""
+	if paginationClause != BaseLimit {
+		q.Pagination = paginationClause
 	}
-	log.Debugf("\n--\n Where: %s \n Order By: %s \n Limit+Offset: %s", whereClause, orderBy, paginationClause)
-	return whereClause, orderBy, paginationClause, queryValues, errs
+	log.Debugf("\n--\n Where: %s \n Order By: %s \n Limit+Offset: %s", q.Where, q.OrderBy, q.Pagination)
+	return q, nil
 }
 
 // CheckIfCurrentUserCanModifyCDNs checks if the current user has the lock on the list of cdns that the requested operation is to be performed on.
 // This will succeed if the either there is no lock by any user on any of the CDNs, or if the current user has the lock on any of the CDNs.
+// Resource locks on the CDNs are checked as well, by CheckIfCurrentUserCanModifyResources.
 func CheckIfCurrentUserCanModifyCDNs(tx *sql.Tx, cdns []string, user string) (error, error, int) {
 	query := `SELECT username, soft, cdn FROM cdn_lock WHERE cdn=ANY($1)`
 	var userName, cdn string
@@ -230,7 +389,7 @@ func CheckIfCurrentUserCanModifyCDNs(tx *sql.Tx, cdns []string, user string) (er
 			return errors.New("user " + userName + " currently has a hard lock on cdn " + cdn), nil, http.StatusForbidden
 		}
 	}
-	return nil, nil, http.StatusOK
+	return CheckIfCurrentUserCanModifyResources(tx, tc.ResourceLockTypeCDN, cdns, user)
 }
 
 // CheckIfCurrentUserCanModifyCDNs checks if the current user has the lock on the list of cdns(identified by ID) that the requested operation is to be performed on.
@@ -256,6 +415,7 @@ func CheckIfCurrentUserCanModifyCDNsByID(tx *sql.Tx, cdns []int, user string) (e
 
 // CheckIfCurrentUserCanModifyCDN checks if the current user has the lock on the cdn that the requested operation is to be performed on.
 // This will succeed if the either there is no lock by any user on the CDN, or if the current user has the lock on the CDN.
+// Resource locks on the CDN are checked as well, by CheckIfCurrentUserCanModifyResources.
 func CheckIfCurrentUserCanModifyCDN(tx *sql.Tx, cdn, user string) (error, error, int) {
 	query := `SELECT username, soft FROM cdn_lock WHERE cdn=$1`
 	var userName string
@@ -277,7 +437,7 @@ func CheckIfCurrentUserCanModifyCDN(tx *sql.Tx, cdn, user string) (error, error,
 			return errors.New("user " + userName + " currently has a hard lock on cdn " + cdn), nil, http.StatusForbidden
 		}
 	}
-	return nil, nil, http.StatusOK
+	return CheckIfCurrentUserCanModifyResources(tx, tc.ResourceLockTypeCDN, []string{cdn}, user)
 }
 
 // CheckIfCurrentUserCanModifyCDNWithID checks if the current user has the lock on the cdn (identified by ID) that the requested operation is to be performed on.
@@ -293,7 +453,8 @@ func CheckIfCurrentUserCanModifyCDNWithID(tx *sql.Tx, cdnID int64, user string)
 }
 
 // CheckIfCurrentUserCanModifyCachegroup checks if the current user has the lock on the cdns that are associated with the provided cachegroup ID.
-// This will succeed if no other user has a hard lock on any of the CDNs that relate to the cachegroup in question.
+// This will succeed if no other user has a hard lock on any of the CDNs that relate to the cachegroup in question,
+// and no other user has a resource lock on the cachegroup itself.
 func CheckIfCurrentUserCanModifyCachegroup(tx *sql.Tx, cachegroupID int, user string) (error, error, int) {
 	query := `SELECT username, cdn, soft FROM cdn_lock WHERE cdn IN (SELECT name FROM cdn WHERE id IN (SELECT cdn_id FROM server WHERE cachegroup = ($1)))`
 	var userName string
@@ -316,11 +477,12 @@ func CheckIfCurrentUserCanModifyCachegroup(tx *sql.Tx, cachegroupID int, user st
 			return errors.New("user " + userName + " currently has a hard lock on cdn " + cdn), nil, http.StatusForbidden
 		}
 	}
-	return nil, nil, http.StatusOK
+	return CheckIfCurrentUserCanModifyCachegroupResources(tx, []int{cachegroupID}, user)
 }
 
 // CheckIfCurrentUserCanModifyCachegroups checks if the current user has the lock on the cdns that are associated with the provided cachegroup IDs.
-// This will succeed if no other user has a hard lock on any of the CDNs that relate to the cachegroups in question.
+// This will succeed if no other user has a hard lock on any of the CDNs that relate to the cachegroups in question,
+// and no other user has a resource lock on any of the cachegroups themselves.
 func CheckIfCurrentUserCanModifyCachegroups(tx *sql.Tx, cachegroupIDs []int, user string) (error, error, int) {
 	query := `SELECT username, cdn, soft FROM cdn_lock WHERE cdn IN (SELECT name FROM cdn WHERE id IN (SELECT cdn_id FROM server WHERE cachegroup = ANY($1)))`
 	var userName string
@@ -343,6 +505,131 @@ func CheckIfCurrentUserCanModifyCachegroups(tx *sql.Tx, cachegroupIDs []int, use
 			return errors.New("user " + userName + " currently has a hard lock on cdn " + cdn), nil, http.StatusForbidden
 		}
 	}
+	return CheckIfCurrentUserCanModifyCachegroupResources(tx, cachegroupIDs, user)
+}
+
+// CheckIfCurrentUserCanModifyResources checks if the current user may modify the given resources of the given type (one of tc.ResourceLockTypes), given the resource locks on them.
+// This will succeed if no other user has an unexpired resource lock on any of the resources, or if the current user also has a lock on each of those which are locked.
+func CheckIfCurrentUserCanModifyResources(tx *sql.Tx, resourceType string, resources []string, user string) (error, error, int) {
+	query := `
+SELECT resource, username, shared, expires
+FROM resource_lock
+WHERE resource_type = $1
+AND resource = ANY($2)
+AND expires > now()
+ORDER BY resource, username
+`
+	rows, err := tx.Query(query, resourceType, pq.Array(resources))
+	if err != nil {
+		return nil, errors.New("querying " + resourceType + " resource locks for user " + user + ": " + err.Error()), http.StatusInternalServerError
+	}
+	defer rows.Close()
+
+	held := map[string]struct{}{}
+	lockedBy := map[string]error{}
+	for rows.Next() {
+		lock := tc.ResourceLock{ResourceType: resourceType}
+		if err := rows.Scan(&lock.Resource, &lock.UserName, &lock.Shared, &lock.Expires); err != nil {
+			return nil, errors.New("scanning " + resourceType + " resource locks for user " + user + ": " + err.Error()), http.StatusInternalServerError
+		}
+		if lock.UserName == user {
+			held[lock.Resource] = struct{}{}
+		} else if _, ok := lockedBy[lock.Resource]; !ok {
+			lockedBy[lock.Resource] = ResourceLockError(lock)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over " + resourceType + " resource locks for user " + user + ": " + err.Error()), http.StatusInternalServerError
+	}
+	for _, resource := range resources {
+		if _, ok := held[resource]; ok {
+			continue
+		}
+		if err, ok := lockedBy[resource]; ok {
+			return err, nil, http.StatusForbidden
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+// ResourceLockError returns the error given to a user prevented from modifying a resource by the given lock.
+func ResourceLockError(lock tc.ResourceLock) error {
+	mode := "an exclusive"
+	if lock.Shared {
+		mode = "a shared"
+	}
+	return fmt.Errorf("user %s currently has %s lock on %s %s, which expires at %s", lock.UserName, mode, lock.ResourceType, lock.Resource, lock.Expires.Format(time.RFC3339))
+}
+
+// CheckIfCurrentUserCanModifyDeliveryService checks if the current user may modify the delivery service with the given XMLID, given the resource locks on it.
+// Locks on the delivery service's CDN aren't checked.
+func CheckIfCurrentUserCanModifyDeliveryService(tx *sql.Tx, xmlID string, user string) (error, error, int) {
+	return CheckIfCurrentUserCanModifyResources(tx, tc.ResourceLockTypeDeliveryService, []string{xmlID}, user)
+}
+
+// CheckIfCurrentUserCanModifyDeliveryServiceWithID checks if the current user may modify the delivery service with the given ID, given the resource locks on it.
+// Locks on the delivery service's CDN aren't checked.
+func CheckIfCurrentUserCanModifyDeliveryServiceWithID(tx *sql.Tx, dsID int, user string) (error, error, int) {
+	return CheckIfCurrentUserCanModifyDeliveryServicesWithIDs(tx, []int{dsID}, user)
+}
+
+// CheckIfCurrentUserCanModifyDeliveryServicesWithIDs checks if the current user may modify the delivery services with the given IDs, given the resource locks on them.
+// Locks on the delivery services' CDNs aren't checked.
+func CheckIfCurrentUserCanModifyDeliveryServicesWithIDs(tx *sql.Tx, dsIDs []int, user string) (error, error, int) {
+	xmlIDs := []string{}
+	if err := tx.QueryRow(`SELECT ARRAY(SELECT xml_id FROM deliveryservice WHERE id = ANY($1))`, pq.Array(dsIDs)).Scan(pq.Array(&xmlIDs)); err != nil {
+		return nil, errors.New("getting delivery service XMLIDs for resource lock check: " + err.Error()), http.StatusInternalServerError
+	}
+	return CheckIfCurrentUserCanModifyResources(tx, tc.ResourceLockTypeDeliveryService, xmlIDs, user)
+}
+
+// CheckIfCurrentUserCanModifyTopology checks if the current user may modify the topology with the given name, given the resource locks on it.
+func CheckIfCurrentUserCanModifyTopology(tx *sql.Tx, topology string, user string) (error, error, int) {
+	return CheckIfCurrentUserCanModifyResources(tx, tc.ResourceLockTypeTopology, []string{topology}, user)
+}
+
+// CheckIfCurrentUserCanModifyProfile checks if the current user may modify the profile with the given name, given the resource locks on it.
+func CheckIfCurrentUserCanModifyProfile(tx *sql.Tx, profile string, user string) (error, error, int) {
+	return CheckIfCurrentUserCanModifyResources(tx, tc.ResourceLockTypeProfile, []string{profile}, user)
+}
+
+// CheckIfCurrentUserCanModifyProfileWithID checks if the current user may modify the profile with the given ID, given the resource locks on it.
+func CheckIfCurrentUserCanModifyProfileWithID(tx *sql.Tx, profileID int, user string) (error, error, int) {
+	return CheckIfCurrentUserCanModifyProfilesWithIDs(tx, []int64{int64(profileID)}, user)
+}
+
+// CheckIfCurrentUserCanModifyProfilesWithIDs checks if the current user may modify the profiles with the given IDs, given the resource locks on them.
+func CheckIfCurrentUserCanModifyProfilesWithIDs(tx *sql.Tx, profileIDs []int64, user string) (error, error, int) {
+	names := []string{}
+	if err := tx.QueryRow(`SELECT ARRAY(SELECT name FROM profile WHERE id = ANY($1))`, pq.Array(profileIDs)).Scan(pq.Array(&names)); err != nil {
+		return nil, errors.New("getting profile names for resource lock check: " + err.Error()), http.StatusInternalServerError
+	}
+	return CheckIfCurrentUserCanModifyResources(tx, tc.ResourceLockTypeProfile, names, user)
+}
+
+// CheckIfCurrentUserCanModifyCachegroupResources checks if the current user may modify the cachegroups with the given IDs, given the resource locks on them.
+// Locks on the cachegroups' CDNs aren't checked.
+func CheckIfCurrentUserCanModifyCachegroupResources(tx *sql.Tx, cachegroupIDs []int, user string) (error, error, int) {
+	names := []string{}
+	if err := tx.QueryRow(`SELECT ARRAY(SELECT name FROM cachegroup WHERE id = ANY($1))`, pq.Array(cachegroupIDs)).Scan(pq.Array(&names)); err != nil {
+		return nil, errors.New("getting cachegroup names for resource lock check: " + err.Error()), http.StatusInternalServerError
+	}
+	return CheckIfCurrentUserCanModifyResources(tx, tc.ResourceLockTypeCacheGroup, names, user)
+}
+
+// CheckIfResourceCanBeRenamed checks that the resource of the given type (one of tc.ResourceLockTypes) may be renamed from oldName to newName.
+// Resource locks refer to their resources by name, so a resource with unexpired resource locks can't be renamed until they're released.
+func CheckIfResourceCanBeRenamed(tx *sql.Tx, resourceType string, oldName string, newName string) (error, error, int) {
+	if oldName == newName {
+		return nil, nil, http.StatusOK
+	}
+	locked := false
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM resource_lock WHERE resource_type = $1 AND resource = $2 AND expires > now())`, resourceType, oldName).Scan(&locked); err != nil {
+		return nil, errors.New("checking " + resourceType + " resource locks for rename: " + err.Error()), http.StatusInternalServerError
+	}
+	if locked {
+		return errors.New(resourceType + " " + oldName + " has resource locks, which must be released before it can be renamed"), nil, http.StatusConflict
+	}
 	return nil, nil, http.StatusOK
 }
 
@@ -367,6 +654,51 @@ func parseCriteriaAndQueryValues(queryParamsToSQLCols map[string]WhereColumnInfo
 			}
 		}
 	}
+
+	for param, urlValue := range parameters {
+		for _, op := range filterOperators {
+			if len(param) <= len(op.Suffix) || !strings.HasSuffix(param, op.Suffix) {
+				continue
+			}
+			key := strings.TrimSuffix(param, op.Suffix)
+			colInfo, ok := queryParamsToSQLCols[key]
+			if !ok {
+				errs = append(errs, errors.New("cannot filter by unknown parameter "+key))
+				break
+			}
+			name := key + "_" + op.Name
+			switch op.Suffix {
+			case "~":
+				criteriaArgs = append(criteriaArgs, colInfo.Column+"::text "+op.SQL+" :"+name)
+				queryValues[name] = "%" + likeEscaper.Replace(urlValue) + "%"
+			case "|":
+				names := []string{}
+				for i, value := range strings.Split(urlValue, ",") {
+					if colInfo.Checker != nil {
+						if err := colInfo.Checker(value); err != nil {
+							errs = append(errs, errors.New(key+" "+err.Error()))
+							continue
+						}
+					}
+					valueName := name + "_" + strconv.Itoa(i)
+					names = append(names, ":"+valueName)
+					queryValues[valueName] = value
+				}
+				criteriaArgs = append(criteriaArgs, colInfo.Column+" "+op.SQL+" ("+strings.Join(names, ", ")+")")
+			default:
+				if colInfo.Checker != nil {
+					if err := colInfo.Checker(urlValue); err != nil {
+						errs = append(errs, errors.New(key+" "+err.Error()))
+						break
+					}
+				}
+				criteriaArgs = append(criteriaArgs, colInfo.Column+" "+op.SQL+" :"+name)
+				queryValues[name] = urlValue
+			}
+			break
+		}
+	}
+	sort.Strings(criteriaArgs)
 	criteria = strings.Join(criteriaArgs, " AND ")
 
 	return criteria, queryValues, errs
@@ -1496,3 +1828,17 @@ func GetCDNNamesFromProfileIDs(tx *sql.Tx, profileIDs []int64) ([]string, error)
 	}
 	return cdns, nil
 }
+
+// GetActiveRolloutID returns the ID of the Rollout of the given CDN which may
+// still queue updates - that is, one which is running, paused, or failed -
+// and whether one exists.
+func GetActiveRolloutID(tx *sql.Tx, cdn string) (int, bool, error) {
+	id := 0
+	if err := tx.QueryRow(`SELECT id FROM rollout WHERE cdn = $1 AND status IN ('running', 'paused', 'failed')`, cdn).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, errors.New("querying active rollout of cdn '" + cdn + "': " + err.Error())
+	}
+	return id, true, nil
+}
@@ -22,6 +22,7 @@ package dbhelpers
 import (
 	"context"
 	"errors"
+	"net/http"
 	"reflect"
 	"strconv"
 	"strings"
@@ -89,6 +90,119 @@ FROM table t
 
 }
 
+func TestBuildQueryClausesFilterOperators(t *testing.T) {
+	queryParamsToSQLCols := map[string]WhereColumnInfo{
+		"id":   {Column: "t.id", Checker: isInt},
+		"name": {Column: "t.name"},
+	}
+	v := map[string]string{"id>": "2", "id!": "5", "name~": "50%_off", "id|": "2,3,5"}
+	q, errs := BuildQueryClauses(v, queryParamsToSQLCols)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors building query clauses: %v", errs)
+	}
+	expectedWhere := "\nWHERE t.id <> :id_ne AND t.id >= :id_gte AND t.id IN (:id_in_0, :id_in_1, :id_in_2) AND t.name::text ILIKE :name_like"
+	if q.Where != expectedWhere {
+		t.Errorf("expected where clause %q, actual: %q", expectedWhere, q.Where)
+	}
+	if q.FilterWhere != q.Where {
+		t.Errorf("expected filter where clause to be the where clause without a cursor, actual: %q", q.FilterWhere)
+	}
+	expectedValues := map[string]interface{}{
+		"id_ne":     "5",
+		"id_gte":    "2",
+		"id_in_0":   "2",
+		"id_in_1":   "3",
+		"id_in_2":   "5",
+		"name_like": `%50\%\_off%`,
+	}
+	if !reflect.DeepEqual(q.Values, expectedValues) {
+		t.Errorf("expected values %v, actual: %v", expectedValues, q.Values)
+	}
+
+	for _, params := range []map[string]string{{"id>": "two"}, {"id|": "1,two"}, {"unknown~": "x"}} {
+		if _, errs := BuildQueryClauses(params, queryParamsToSQLCols); len(errs) == 0 {
+			t.Errorf("expected an error building query clauses from %v, actual: nil", params)
+		}
+	}
+}
+
+func TestBuildQueryClausesCursor(t *testing.T) {
+	queryParamsToSQLCols := map[string]WhereColumnInfo{
+		"id":   {Column: "t.id", Checker: isInt},
+		"name": {Column: "t.name"},
+	}
+
+	first := map[string]string{"name": "foo", "limit": "2", "cursor": ""}
+	q, errs := BuildQueryClauses(first, queryParamsToSQLCols)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors building query clauses: %v", errs)
+	}
+	if !q.Cursor || q.Limit != 2 {
+		t.Errorf("expected cursor pagination with a limit of 2, actual: cursor %t, limit %d", q.Cursor, q.Limit)
+	}
+	if q.Where != "\nWHERE t.name=:name" {
+		t.Errorf("expected the first page not to be filtered by a cursor, actual where clause: %q", q.Where)
+	}
+	if q.OrderBy != "\nORDER BY t.id" {
+		t.Errorf("expected cursor pagination to be ordered by ID, actual order by clause: %q", q.OrderBy)
+	}
+
+	if next := NextCursor(first, 1, 7); next != "" {
+		t.Errorf("expected no cursor after a partial page, actual: %q", next)
+	}
+	next := NextCursor(first, 2, 7)
+	if next == "" {
+		t.Fatal("expected a cursor after a full page, actual: none")
+	}
+
+	second := map[string]string{"name": "foo", "limit": "2", "cursor": next}
+	q, errs = BuildQueryClauses(second, queryParamsToSQLCols)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors building query clauses: %v", errs)
+	}
+	if q.Where != "\nWHERE t.name=:name AND t.id > :cursor" {
+		t.Errorf("expected the second page to be filtered by the cursor, actual where clause: %q", q.Where)
+	}
+	if q.FilterWhere != "\nWHERE t.name=:name" {
+		t.Errorf("expected the filter where clause not to include the cursor, actual: %q", q.FilterWhere)
+	}
+	if q.Values["cursor"] != 7 {
+		t.Errorf("expected the cursor to be after ID 7, actual: %v", q.Values["cursor"])
+	}
+
+	desc := map[string]string{"limit": "2", "cursor": "", "sortOrder": "desc"}
+	q, _ = BuildQueryClauses(desc, queryParamsToSQLCols)
+	if q.OrderBy != "\nORDER BY t.id DESC" {
+		t.Errorf("expected descending cursor pagination, actual order by clause: %q", q.OrderBy)
+	}
+	desc["cursor"] = NextCursor(desc, 2, 7)
+	q, _ = BuildQueryClauses(desc, queryParamsToSQLCols)
+	if q.Where != "\nWHERE t.id < :cursor" || q.OrderBy != "\nORDER BY t.id DESC" {
+		t.Errorf("expected the next descending page to be before the cursor, actual where clause: %q, order by clause: %q", q.Where, q.OrderBy)
+	}
+
+	invalid := []map[string]string{
+		{"cursor": ""},
+		{"cursor": "", "limit": "2", "offset": "2"},
+		{"cursor": "", "limit": "2", "page": "2"},
+		{"cursor": "", "limit": "2", "orderby": "name"},
+		{"cursor": "not a cursor", "limit": "2"},
+	}
+	for _, params := range invalid {
+		if _, errs := BuildQueryClauses(params, queryParamsToSQLCols); len(errs) == 0 {
+			t.Errorf("expected an error building query clauses from %v, actual: nil", params)
+		}
+	}
+	if _, errs := BuildQueryClauses(first, map[string]WhereColumnInfo{"name": {Column: "t.name"}}); len(errs) == 0 {
+		t.Error("expected an error using a cursor without an id column, actual: nil")
+	}
+}
+
+func isInt(s string) error {
+	_, err := strconv.Atoi(s)
+	return err
+}
+
 func TestGetCacheGroupByName(t *testing.T) {
 	var testCases = []struct {
 		description  string
@@ -408,3 +522,64 @@ func TestGetCDNIDFromName(t *testing.T) {
 	}
 
 }
+
+func TestCheckIfResourceCanBeRenamed(t *testing.T) {
+	var testCases = []struct {
+		description string
+		newName     string
+		locked      bool
+		statusCode  int
+	}{
+		{
+			description: "Success: name unchanged",
+			newName:     "testProfile",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "Success: renamed without locks",
+			newName:     "renamedProfile",
+			locked:      false,
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "Failure: renamed with locks",
+			newName:     "renamedProfile",
+			locked:      true,
+			statusCode:  http.StatusConflict,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db := sqlx.NewDb(mockDB, "sqlmock")
+			defer db.Close()
+			mock.ExpectBegin()
+			if testCase.newName != "testProfile" {
+				rows := sqlmock.NewRows([]string{"exists"}).AddRow(testCase.locked)
+				mock.ExpectQuery("SELECT EXISTS").WithArgs(tc.ResourceLockTypeProfile, "testProfile").WillReturnRows(rows)
+			}
+			mock.ExpectCommit()
+			tx := db.MustBegin().Tx
+			userErr, sysErr, statusCode := CheckIfResourceCanBeRenamed(tx, tc.ResourceLockTypeProfile, "testProfile", testCase.newName)
+			if sysErr != nil {
+				t.Fatalf("unexpected system error: %v", sysErr)
+			}
+			if statusCode != testCase.statusCode {
+				t.Errorf("Expected status code %d, actual %d", testCase.statusCode, statusCode)
+			}
+			if (userErr != nil) != testCase.locked {
+				t.Errorf("Expected user error: %t, actual: %v", testCase.locked, userErr)
+			}
+			if err := tx.Commit(); err != nil {
+				t.Fatalf("committing: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -211,6 +211,12 @@ func GenerateAcmeCertificates(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
 		return
 	}
+	userErr, sysErr, statusCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryService(inf.Tx.Tx, *req.DeliveryService, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		defer cancelTx()
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
 
 	asyncStatusId, errCode, userErr, sysErr := api.InsertAsyncStatus(inf.Tx.Tx, "ACME async job has started.")
 	if userErr != nil || sysErr != nil {
@@ -279,6 +285,12 @@ func GenerateLetsEncryptCertificates(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
 		return
 	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(inf.Tx.Tx, dsID, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		defer cancelTx()
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
 
 	userErr, sysErr, errCode = tenant.CheckID(inf.Tx.Tx, inf.User, dsID)
 	if userErr != nil || sysErr != nil {
@@ -512,7 +524,7 @@ func GetAcmeCertificates(cfg *config.Config, req tc.DeliveryServiceAcmeSSLKeysRe
 		return fmt.Errorf(deliveryService+": putting keys in Traffic Vault: %v", err)
 	}
 
-	tx2, err := db.Begin()
+	tx2, err := db.Beginx()
 	if err != nil {
 		log.Errorf("starting sql transaction for delivery service " + *req.DeliveryService + ": " + err.Error())
 		if asycErr := api.UpdateAsyncStatus(db, api.AsyncFailed, "ACME renewal failed.", asyncStatusId, true); asycErr != nil {
@@ -521,13 +533,20 @@ func GetAcmeCertificates(cfg *config.Config, req tc.DeliveryServiceAcmeSSLKeysRe
 		return fmt.Errorf("starting sql transaction for delivery service "+*req.DeliveryService+": %v", err)
 	}
 
-	if err := updateSSLKeyVersion(*req.DeliveryService, req.Version.ToInt64(), tx2); err != nil {
+	if err := updateSSLKeyVersion(*req.DeliveryService, req.Version.ToInt64(), tx2.Tx); err != nil {
 		log.Errorf("updating SSL key version for delivery service '" + *req.DeliveryService + "': " + err.Error())
 		if asycErr := api.UpdateAsyncStatus(db, api.AsyncFailed, "ACME renewal failed.", asyncStatusId, true); asycErr != nil {
 			log.Errorf("updating async status for id %v: %v", asyncStatusId, asycErr)
 		}
 		return fmt.Errorf("updating SSL key version for delivery service '"+*req.DeliveryService+"': %v", err)
 	}
+	if _, err := CreateVersion(tx2, dsID, currentUser); err != nil {
+		log.Errorf("recording version of delivery service '" + *req.DeliveryService + "': " + err.Error())
+		if asycErr := api.UpdateAsyncStatus(db, api.AsyncFailed, "ACME renewal failed.", asyncStatusId, true); asycErr != nil {
+			log.Errorf("updating async status for id %v: %v", asyncStatusId, asycErr)
+		}
+		return fmt.Errorf("recording version of delivery service '"+*req.DeliveryService+"': %v", err)
+	}
 	tx2.Commit()
 
 	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+*req.DeliveryService+", ID: "+strconv.Itoa(dsID)+", ACTION: Added SSL keys with "+provider, currentUser, logTx)
@@ -71,6 +71,11 @@ func RenewAcmeCertificate(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
 		return
 	}
+	userErr, sysErr, statusCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryService(inf.Tx.Tx, xmlID, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
 
 	ctx, cancelTx := context.WithTimeout(r.Context(), AcmeTimeout)
 	defer cancelTx()
@@ -185,16 +190,20 @@ func renewAcmeCerts(cfg *config.Config, dsName string, ctx context.Context, http
 		return nil, errors.New(dsName + ": putting keys in Traffic Vault: " + err.Error()), http.StatusInternalServerError
 	}
 
-	tx2, err := db.Begin()
+	tx2, err := db.Beginx()
 	if err != nil {
 		log.Errorf("starting sql transaction for delivery service " + dsName + ": " + err.Error())
 		return nil, errors.New("starting sql transaction for delivery service " + dsName + ": " + err.Error()), http.StatusInternalServerError
 	}
 
-	if err := updateSSLKeyVersion(dsName, *certVersion+1, tx2); err != nil {
+	if err := updateSSLKeyVersion(dsName, *certVersion+1, tx2.Tx); err != nil {
 		log.Errorf("updating SSL key version for delivery service '" + dsName + "': " + err.Error())
 		return nil, errors.New("updating SSL key version for delivery service '" + dsName + "': " + err.Error()), http.StatusInternalServerError
 	}
+	if _, err := CreateVersion(tx2, *dsID, currentUser); err != nil {
+		log.Errorf("recording version of delivery service '" + dsName + "': " + err.Error())
+		return nil, errors.New("recording version of delivery service '" + dsName + "': " + err.Error()), http.StatusInternalServerError
+	}
 	tx2.Commit()
 
 	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+dsName+", ID: "+strconv.Itoa(*dsID)+", ACTION: Added SSL keys with "+acmeAccount.AcmeProvider, currentUser, logTx)
@@ -217,12 +217,78 @@ func CreateV40(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, status, userErr, sysErr)
 		return
 	}
+	if _, err := CreateVersion(inf.Tx, *res.ID, inf.User); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("creating delivery service: "+err.Error()))
+		return
+	}
 	alerts := res.TLSVersionsAlerts()
+	alerts.AddAlerts(res.TLSPolicyAlerts())
 	alerts.AddNewAlert(tc.SuccessLevel, "Delivery Service creation was successful")
 	w.Header().Set("Location", fmt.Sprintf("/api/4.0/deliveryservices?id=%d", *res.ID))
 	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, []tc.DeliveryServiceV40{*res})
 }
 
+// ReadV4 returns the Delivery Services matching the given query parameters
+// which are visible to the given user, as a GET request to deliveryservices
+// would.
+func ReadV4(tx *sqlx.Tx, user *auth.CurrentUser, params map[string]string) ([]tc.DeliveryServiceV4, error, error, int) {
+	dses, userErr, sysErr, errCode, _ := readGetDeliveryServices(nil, params, tx, user, false)
+	return dses, userErr, sysErr, errCode
+}
+
+// CreateV4 creates the given Delivery Service in the transaction of inf, as a
+// POST request to deliveryservices would, and returns it as created along with
+// the ID of the version of it that was recorded. The request is only used for
+// its context.
+func CreateV4(r *http.Request, inf *api.APIInfo, ds tc.DeliveryServiceV4) (*tc.DeliveryServiceV4, int64, int, error, error) {
+	res, status, userErr, sysErr := createV40(nil, r, inf, ds, true)
+	if userErr != nil || sysErr != nil {
+		return nil, 0, status, userErr, sysErr
+	}
+	versionID, err := CreateVersion(inf.Tx, *res.ID, inf.User)
+	if err != nil {
+		return nil, 0, http.StatusInternalServerError, nil, errors.New("creating delivery service: " + err.Error())
+	}
+	return res, versionID, http.StatusOK, nil, nil
+}
+
+// UpdateV4 updates the given Delivery Service, which must have its ID set, in
+// the transaction of inf, as a PUT request to deliveryservices/{id} would,
+// and returns it as updated. The request's If-Unmodified-Since header is
+// checked against the Delivery Service. Like CreateV4, it also returns the ID
+// of the version of the Delivery Service that was recorded.
+func UpdateV4(r *http.Request, inf *api.APIInfo, ds *tc.DeliveryServiceV4) (*tc.DeliveryServiceV4, int64, int, error, error) {
+	res, status, userErr, sysErr := updateV40(nil, r, inf, ds, true)
+	if userErr != nil || sysErr != nil {
+		return nil, 0, status, userErr, sysErr
+	}
+	versionID, err := CreateVersion(inf.Tx, *res.ID, inf.User)
+	if err != nil {
+		return nil, 0, http.StatusInternalServerError, nil, errors.New("updating delivery service: " + err.Error())
+	}
+	return res, versionID, http.StatusOK, nil, nil
+}
+
+// DeleteV4 deletes the Delivery Service with the given ID in the transaction
+// of inf, as a DELETE request to deliveryservices/{id} would. It returns the
+// HTTP status code, user error, and system error, like CreateV4.
+func DeleteV4(inf *api.APIInfo, id int) (int, error, error) {
+	ds := &TODeliveryService{APIInfoImpl: api.APIInfoImpl{ReqInfo: inf}}
+	ds.ID = &id
+	if authorized, err := ds.IsTenantAuthorized(inf.User); err != nil {
+		return http.StatusInternalServerError, nil, errors.New("checking tenant: " + err.Error())
+	} else if !authorized {
+		return http.StatusForbidden, errors.New("not authorized on this tenant"), nil
+	}
+	if userErr, sysErr, errCode := ds.Delete(); userErr != nil || sysErr != nil {
+		return errCode, userErr, sysErr
+	}
+	if err := api.CreateChangeLog(api.ApiChange, api.Deleted, ds, inf.User, inf.Tx.Tx); err != nil {
+		return http.StatusInternalServerError, nil, errors.New("writing change log entry: " + err.Error())
+	}
+	return http.StatusOK, nil, nil
+}
+
 func createV15(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, reqDS tc.DeliveryServiceNullableV15) (*tc.DeliveryServiceNullableV15, int, error, error) {
 	dsV30 := tc.DeliveryServiceV30{DeliveryServiceNullableV15: reqDS}
 	res, status, userErr, sysErr := createV30(w, r, inf, dsV30)
@@ -262,6 +328,9 @@ func createV31(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV31 t
 	if err := EnsureCacheURLParams(tx, *ds.ID, *ds.XMLID, dsV31.CacheURL); err != nil {
 		return nil, http.StatusInternalServerError, nil, err
 	}
+	if _, err := CreateVersion(inf.Tx, *ds.ID, inf.User); err != nil {
+		return nil, http.StatusInternalServerError, nil, errors.New("creating delivery service: " + err.Error())
+	}
 
 	oldRes := tc.DeliveryServiceV31(ds.DowngradeToV3())
 	return &oldRes, status, userErr, sysErr
@@ -498,6 +567,10 @@ func createV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 t
 		return nil, http.StatusInternalServerError, nil, fmt.Errorf("creating TLS versions for new Delivery Service: %w", err)
 	}
 
+	if err = recreateTLSPolicy(&ds, tx); err != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("creating TLS policy for new Delivery Service: %w", err)
+	}
+
 	if err := createDefaultRegex(tx, *ds.ID, *ds.XMLID); err != nil {
 		return nil, http.StatusInternalServerError, nil, errors.New("creating default regex: " + err.Error())
 	}
@@ -713,7 +786,12 @@ func UpdateV40(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, status, userErr, sysErr)
 		return
 	}
+	if _, err := CreateVersion(inf.Tx, *res.ID, inf.User); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("updating delivery service: "+err.Error()))
+		return
+	}
 	alerts := res.TLSVersionsAlerts()
+	alerts.AddAlerts(res.TLSPolicyAlerts())
 	alerts.AddNewAlert(tc.SuccessLevel, "Delivery Service update was successful")
 	api.WriteAlertsObj(w, r, http.StatusOK, alerts, []tc.DeliveryServiceV40{*res})
 }
@@ -787,6 +865,9 @@ func updateV31(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV31 *
 	if dsV40.TLSVersions, sysErr = GetDSTLSVersions(*dsV40.ID, tx); sysErr != nil {
 		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting TLS versions for DS #%d in API version < 4.0: %w", *dsV40.ID, sysErr)
 	}
+	if sysErr = GetDSTLSPolicy(&dsV40, tx); sysErr != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting TLS policy for DS #%d in API version < 4.0: %w", *dsV40.ID, sysErr)
+	}
 
 	res, status, usrErr, sysErr := updateV40(w, r, inf, &dsV40, false)
 	if res == nil || usrErr != nil || sysErr != nil {
@@ -806,6 +887,9 @@ func updateV31(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV31 *
 	if err := EnsureCacheURLParams(tx, *ds.ID, *ds.XMLID, dsV31.CacheURL); err != nil {
 		return nil, http.StatusInternalServerError, nil, err
 	}
+	if _, err := CreateVersion(inf.Tx, *ds.ID, inf.User); err != nil {
+		return nil, http.StatusInternalServerError, nil, errors.New("updating delivery service: " + err.Error())
+	}
 
 	oldRes := tc.DeliveryServiceV31(ds.DowngradeToV3())
 	return &oldRes, http.StatusOK, nil, nil
@@ -830,6 +914,9 @@ func updateV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 *
 	if ds.ID == nil {
 		return nil, http.StatusBadRequest, errors.New("missing id"), nil
 	}
+	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(tx, *ds.ID, user.UserName); userErr != nil || sysErr != nil {
+		return nil, errCode, userErr, sysErr
+	}
 
 	dsType, ok, err := getDSType(tx, *ds.XMLID)
 	if !ok {
@@ -1072,6 +1159,9 @@ func updateV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 *
 	if err != nil {
 		return nil, http.StatusInternalServerError, nil, fmt.Errorf("updating TLS versions for DS #%d: %w", *ds.ID, err)
 	}
+	if err = recreateTLSPolicy(&ds, tx); err != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("updating TLS policy for DS #%d: %w", *ds.ID, err)
+	}
 
 	newDSType, err := getTypeFromID(*ds.TypeID, tx)
 	if err != nil {
@@ -1161,6 +1251,9 @@ func (ds *TODeliveryService) Delete() (error, error, int) {
 			return userErr, sysErr, errCode
 		}
 	}
+	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyDeliveryService(ds.APIInfo().Tx.Tx, xmlID, ds.APIInfo().User.UserName); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
 	// Note ds regexes MUST be deleted before the ds, because there's a ON DELETE CASCADE on deliveryservice_regex (but not on regex).
 	// Likewise, it MUST happen in a transaction with the later DS delete, so they aren't deleted if the DS delete fails.
 	if _, err := ds.ReqInfo.Tx.Tx.Exec(`DELETE FROM regex WHERE id IN (SELECT regex FROM deliveryservice_regex WHERE deliveryservice=$1)`, *ds.ID); err != nil {
@@ -1353,6 +1446,10 @@ func Validate(tx *sql.Tx, ds *tc.DeliveryServiceV4) error {
 				return nil
 			},
 		)),
+		"tlsCiphers":       validation.Validate(ds.TLSCiphers, validation.By(validateTLSCiphers)),
+		"tlsCipherSuites":  validation.Validate(ds.TLSCipherSuites, validation.By(validateTLSCiphers)),
+		"tlsVerifyClient":  validation.Validate(ds.TLSVerifyClient, validation.In(tc.TLSVerifyClientNone, tc.TLSVerifyClientModerate, tc.TLSVerifyClientStrict)),
+		"tlsHostSNIPolicy": validation.Validate(ds.TLSHostSNIPolicy, validation.In(tc.TLSHostSNIPolicyDisabled, tc.TLSHostSNIPolicyPermissive, tc.TLSHostSNIPolicyEnforced)),
 	})
 	if err := validateTopologyFields(ds); err != nil {
 		errs = append(errs, err)
@@ -1700,6 +1797,11 @@ func GetDeliveryServices(query string, queryValues map[string]interface{}, tx *s
 			&ds.TenantID,
 			&ds.Tenant,
 			pq.Array(&ds.TLSVersions),
+			pq.Array(&ds.TLSCiphers),
+			pq.Array(&ds.TLSCipherSuites),
+			&ds.TLSVerifyClient,
+			&ds.TLSHostSNIPolicy,
+			&ds.TLSOCSPStapling,
 			&ds.Topology,
 			&ds.TRRequestHeaders,
 			&ds.TRResponseHeaders,
@@ -1735,6 +1837,7 @@ func GetDeliveryServices(query string, queryValues map[string]interface{}, tx *s
 		if len(ds.TLSVersions) < 1 {
 			ds.TLSVersions = nil
 		}
+		normalizeTLSPolicy(&ds)
 
 		dses = append(dses, ds)
 	}
@@ -2229,7 +2332,7 @@ ds.active,
 	ds.ssl_key_version,
 	ds.tenant_id,
 	tenant.name,
-	(` + baseTLSVersionsQuery + ` WHERE deliveryservice = ds.id) AS tls_versions,
+	(` + baseTLSVersionsQuery + ` WHERE deliveryservice = ds.id) AS tls_versions,` + selectTLSPolicyColumns + `
 	ds.topology,
 	ds.tr_request_headers,
 	ds.tr_response_headers,
@@ -260,7 +260,17 @@ func (rc *RequiredCapability) Delete() (error, error, int) {
 	if userErr != nil || sysErr != nil {
 		return userErr, sysErr, errCode
 	}
-	return api.GenericDelete(rc)
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(rc.ReqInfo.Tx.Tx, *rc.DeliveryServiceID, rc.ReqInfo.User.UserName)
+	if userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	if userErr, sysErr, errCode = api.GenericDelete(rc); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	if _, err := CreateVersion(rc.APIInfo().Tx, *rc.DeliveryServiceID, rc.APIInfo().User); err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	return nil, nil, http.StatusOK
 }
 
 // Create implements the api.CRUDer interface.
@@ -280,6 +290,10 @@ func (rc *RequiredCapability) Create() (error, error, int) {
 	if userErr != nil || sysErr != nil {
 		return userErr, sysErr, errCode
 	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(rc.ReqInfo.Tx.Tx, *rc.DeliveryServiceID, rc.ReqInfo.User.UserName)
+	if userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
 
 	// Ensure DS type is only of HTTP*, DNS* types
 	dsType, reqCaps, topology, dsExists, err := dbhelpers.GetDeliveryServiceTypeRequiredCapabilitiesAndTopology(*rc.DeliveryServiceID, rc.APIInfo().Tx.Tx)
@@ -328,12 +342,16 @@ func (rc *RequiredCapability) Create() (error, error, int) {
 			return nil, fmt.Errorf("%s create scanning: %s", rc.GetType(), err.Error()), http.StatusInternalServerError
 		}
 	}
+	rows.Close()
 	if rowsAffected == 0 {
 		return nil, fmt.Errorf("%s create: no %s was inserted, no rows was returned", rc.GetType(), rc.GetType()), http.StatusInternalServerError
 	} else if rowsAffected > 1 {
 		return nil, fmt.Errorf("too many rows returned from %s insert", rc.GetType()), http.StatusInternalServerError
 	}
 
+	if _, err := CreateVersion(rc.APIInfo().Tx, *rc.DeliveryServiceID, rc.APIInfo().User); err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
 	return nil, nil, http.StatusOK
 }
 
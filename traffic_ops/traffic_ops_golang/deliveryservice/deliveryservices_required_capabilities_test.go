@@ -82,6 +82,9 @@ func TestCreateDeliveryServicesRequiredCapability(t *testing.T) {
 	)
 	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"xml_id", "name"}).AddRow("name", "cdnName"))
 	mock.ExpectQuery("SELECT username").WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectQuery("SELECT resource").WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectQuery("SELECT ARRAY").WillReturnRows(sqlmock.NewRows([]string{"array"}).AddRow("{name}"))
+	mock.ExpectQuery("SELECT resource").WillReturnRows(sqlmock.NewRows(nil))
 	mock.ExpectQuery("SELECT t.name.*").WillReturnRows(typeRows)
 
 	scRows := sqlmock.NewRows([]string{"name"}).AddRow(
@@ -98,6 +101,7 @@ func TestCreateDeliveryServicesRequiredCapability(t *testing.T) {
 		time.Now(),
 	)
 	mock.ExpectQuery("INSERT INTO deliveryservices_required_capability").WillReturnRows(rows)
+	expectCreateVersion(mock)
 
 	userErr, sysErr, errCode := rc.Create()
 	if userErr != nil {
@@ -248,7 +252,11 @@ func TestDeleteDeliveryServicesRequiredCapability(t *testing.T) {
 
 	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"xml_id", "name"}).AddRow("name", "cdnName"))
 	mock.ExpectQuery("SELECT username").WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectQuery("SELECT resource").WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectQuery("SELECT ARRAY").WillReturnRows(sqlmock.NewRows([]string{"array"}).AddRow("{name}"))
+	mock.ExpectQuery("SELECT resource").WillReturnRows(sqlmock.NewRows(nil))
 	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(1, 1))
+	expectCreateVersion(mock)
 
 	rc := RequiredCapability{
 		api.APIInfoImpl{
@@ -372,6 +380,9 @@ func TestCreateDeliveryServicesRequiredCapabilityInvalidDSType(t *testing.T) {
 	)
 	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"xml_id", "name"}).AddRow("ds1", "cdnName"))
 	mock.ExpectQuery("SELECT username").WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectQuery("SELECT resource").WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectQuery("SELECT ARRAY").WillReturnRows(sqlmock.NewRows([]string{"array"}).AddRow("{name}"))
+	mock.ExpectQuery("SELECT resource").WillReturnRows(sqlmock.NewRows(nil))
 	mock.ExpectQuery("SELECT t.name.*").WillReturnRows(typeRows)
 
 	userErr, sysErr, errCode := rc.Create()
@@ -263,7 +263,26 @@ func TestReadGetDeliveryServices(t *testing.T) {
 	tenantRows := sqlmock.NewRows([]string{"id"})
 	tenantRows.AddRow(u.TenantID)
 	mock.ExpectQuery("WITH RECURSIVE").WillReturnRows(tenantRows)
-	dsRows := sqlmock.NewRows([]string{
+	dsRows := mockDeliveryServiceRows()
+	mock.ExpectQuery("^SELECT.*ORDER BY ds.xml_id$").WillReturnRows(dsRows)
+	regexRows := sqlmock.NewRows([]string{"ds_name", "type", "pattern", "set_number"})
+	regexRows.AddRow("demo1", "hostregexp", "", 0)
+	mock.ExpectQuery("SELECT ds\\.xml_id as ds_name, t\\.name as type, r\\.pattern, COALESCE\\(dsr\\.set_number, 0\\) FROM regex").WillReturnRows(regexRows)
+
+	_, userErr, sysErr, _, _ := readGetDeliveryServices(nil, nil, db.MustBegin(), &u, false)
+	if userErr != nil {
+		t.Errorf("Unexpected user error reading Delivery Services: %v", userErr)
+	}
+	if sysErr != nil {
+		t.Errorf("Unexpected system error reading Delivery Services: %v", sysErr)
+	}
+}
+
+// mockDeliveryServiceRows returns rows of the columns selected by
+// SelectDeliveryServicesQuery, with the Delivery Service "demo1", whose ID
+// is 1.
+func mockDeliveryServiceRows() *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
 		"active",
 		"anonymous_blocking_enabled",
 		"ccr_dns_ttl",
@@ -327,6 +346,11 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		"tenant_id",
 		"tenant.name",
 		"tls_versions",
+		"tls_ciphers",
+		"tls_cipher_suites",
+		"tls_verify_client",
+		"tls_host_sni_policy",
+		"tls_ocsp_stapling",
 		"topology",
 		"tr_request_headers",
 		"tr_response_headers",
@@ -335,7 +359,7 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		"xml_id",
 		"cdn_domain",
 	})
-	dsRows.AddRow(
+	rows.AddRow(
 		true,
 		false,
 		nil,
@@ -402,21 +426,15 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 		"test",
 		1,
 		"demo1",
 		"mycdn.ciab.test",
 	)
-	mock.ExpectQuery("^SELECT.*ORDER BY ds.xml_id$").WillReturnRows(dsRows)
-	regexRows := sqlmock.NewRows([]string{"ds_name", "type", "pattern", "set_number"})
-	regexRows.AddRow("demo1", "hostregexp", "", 0)
-	mock.ExpectQuery("SELECT ds\\.xml_id as ds_name, t\\.name as type, r\\.pattern, COALESCE\\(dsr\\.set_number, 0\\) FROM regex").WillReturnRows(regexRows)
-
-	_, userErr, sysErr, _, _ := readGetDeliveryServices(nil, nil, db.MustBegin(), &u, false)
-	if userErr != nil {
-		t.Errorf("Unexpected user error reading Delivery Services: %v", userErr)
-	}
-	if sysErr != nil {
-		t.Errorf("Unexpected system error reading Delivery Services: %v", sysErr)
-	}
+	return rows
 }
@@ -0,0 +1,407 @@
+// Package approvalpolicy contains the handlers for the policies which govern
+// the approval of Delivery Service Requests, and the means of finding the
+// policy for a Delivery Service.
+package approvalpolicy
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const selectColumns = `
+	p.id,
+	p.name,
+	p.tenant_id,
+	tn.name AS tenant,
+	p.type_id,
+	t.name AS type,
+	p.required_approvals,
+	p.approver_roles,
+	p.required_fields,
+	p.reject_on_validation_failure,
+	p.apply_on_approval,
+	p.last_updated`
+
+const readQuery = `
+SELECT` + selectColumns + `
+FROM dsr_approval_policy p
+LEFT OUTER JOIN tenant tn ON p.tenant_id = tn.id
+LEFT OUTER JOIN type t ON p.type_id = t.id
+`
+
+// forDeliveryServiceQuery selects the most specific policy which applies to a
+// Delivery Service of the Tenant with the ID $1 and the Type with the ID $2.
+// Policies for Tenants apply to their descendants, unless a descendant nearer
+// to the Delivery Service's Tenant has its own policy.
+const forDeliveryServiceQuery = `
+WITH RECURSIVE ancestor AS (
+	SELECT id, parent_id, 0 AS depth FROM tenant WHERE id = $1
+	UNION ALL
+	SELECT tn.id, tn.parent_id, a.depth + 1
+	FROM tenant tn
+	JOIN ancestor a ON tn.id = a.parent_id
+)
+SELECT` + selectColumns + `
+FROM dsr_approval_policy p
+LEFT OUTER JOIN tenant tn ON p.tenant_id = tn.id
+LEFT OUTER JOIN type t ON p.type_id = t.id
+LEFT OUTER JOIN ancestor a ON p.tenant_id = a.id
+WHERE (p.tenant_id IS NULL OR a.id IS NOT NULL)
+AND (p.type_id IS NULL OR p.type_id = $2)
+ORDER BY p.tenant_id IS NULL, p.type_id IS NULL, a.depth
+LIMIT 1
+`
+
+const insertQuery = `
+INSERT INTO dsr_approval_policy (
+	name,
+	tenant_id,
+	type_id,
+	required_approvals,
+	approver_roles,
+	required_fields,
+	reject_on_validation_failure,
+	apply_on_approval
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id
+`
+
+const updateQuery = `
+UPDATE dsr_approval_policy SET
+	name = $1,
+	tenant_id = $2,
+	type_id = $3,
+	required_approvals = $4,
+	approver_roles = $5,
+	required_fields = $6,
+	reject_on_validation_failure = $7,
+	apply_on_approval = $8
+WHERE id = $9
+RETURNING id
+`
+
+const deleteQuery = `DELETE FROM dsr_approval_policy WHERE id = $1`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPolicy(s scanner, p *tc.DSRApprovalPolicy) error {
+	return s.Scan(
+		&p.ID,
+		&p.Name,
+		&p.TenantID,
+		&p.Tenant,
+		&p.TypeID,
+		&p.Type,
+		&p.RequiredApprovals,
+		pq.Array(&p.ApproverRoles),
+		pq.Array(&p.RequiredFields),
+		&p.RejectOnValidationFailure,
+		&p.ApplyOnApproval,
+		&p.LastUpdated,
+	)
+}
+
+// ForDeliveryService returns the policy which applies to Delivery Service
+// Requests for Delivery Services of the given Tenant and Type, or nil if none
+// does. Either ID may be nil, if it's not known, in which case only policies
+// for all Tenants or all Types (respectively) can apply.
+func ForDeliveryService(tx *sql.Tx, tenantID *int, typeID *int) (*tc.DSRApprovalPolicy, error) {
+	var p tc.DSRApprovalPolicy
+	if err := scanPolicy(tx.QueryRow(forDeliveryServiceQuery, tenantID, typeID), &p); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("querying Delivery Service Request approval policy: %w", err)
+	}
+	return &p, nil
+}
+
+// Read is the handler for GET requests to
+// /deliveryservice_request_approval_policies.
+func Read(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cols := map[string]dbhelpers.WhereColumnInfo{
+		"id":       {Column: "p.id", Checker: api.IsInt},
+		"name":     {Column: "p.name", Checker: nil},
+		"tenantId": {Column: "p.tenant_id", Checker: api.IsInt},
+		"typeId":   {Column: "p.type_id", Checker: api.IsInt},
+	}
+	if _, ok := inf.Params["orderby"]; !ok {
+		inf.Params["orderby"] = "name"
+	}
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, cols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+
+	rows, err := inf.Tx.NamedQuery(readQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("querying Delivery Service Request approval policies: "+err.Error()))
+		return
+	}
+	defer log.Close(rows, "closing Delivery Service Request approval policy rows")
+
+	policies := []tc.DSRApprovalPolicy{}
+	for rows.Next() {
+		var p tc.DSRApprovalPolicy
+		if err := scanPolicy(rows, &p); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("scanning Delivery Service Request approval policies: "+err.Error()))
+			return
+		}
+		policies = append(policies, p)
+	}
+
+	api.WriteResp(w, r, policies)
+}
+
+// parse decodes and validates a policy from the body of the request, checking
+// that its Tenant and Type exist and that the user has access to its Tenant -
+// or is of the root Tenant, for a policy of all Tenants.
+func parse(r *http.Request, inf *api.APIInfo) (tc.DSRApprovalPolicy, int, error, error) {
+	var p tc.DSRApprovalPolicy
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		return p, http.StatusBadRequest, errors.New("decoding: " + err.Error()), nil
+	}
+	if p.ApproverRoles == nil {
+		p.ApproverRoles = []string{}
+	}
+	if p.RequiredFields == nil {
+		p.RequiredFields = []string{}
+	}
+	p.ApproverRoles, _ = util.RemoveStrDuplicates(p.ApproverRoles, map[string]struct{}{})
+	p.RequiredFields, _ = util.RemoveStrDuplicates(p.RequiredFields, map[string]struct{}{})
+	if err := p.Validate(); err != nil {
+		return p, http.StatusBadRequest, err, nil
+	}
+	tx := inf.Tx.Tx
+
+	if errCode, userErr, sysErr := checkTenant(tx, inf.User, p.TenantID); userErr != nil || sysErr != nil {
+		return p, errCode, userErr, sysErr
+	}
+	if p.TypeID != nil {
+		exists := false
+		if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM type WHERE id = $1 AND use_in_table = 'deliveryservice')`, *p.TypeID).Scan(&exists); err != nil {
+			return p, http.StatusInternalServerError, nil, fmt.Errorf("checking existence of Type #%d: %w", *p.TypeID, err)
+		}
+		if !exists {
+			return p, http.StatusBadRequest, fmt.Errorf("typeId: no Delivery Service Type exists with ID %d", *p.TypeID), nil
+		}
+	}
+	roles := []string{}
+	if err := tx.QueryRow(`SELECT ARRAY(SELECT name FROM role WHERE name = ANY($1))`, pq.Array(p.ApproverRoles)).Scan(pq.Array(&roles)); err != nil {
+		return p, http.StatusInternalServerError, nil, fmt.Errorf("checking existence of approver Roles: %w", err)
+	}
+	if len(roles) != len(p.ApproverRoles) {
+		return p, http.StatusBadRequest, errors.New("approverRoles: must all be the names of existing Roles"), nil
+	}
+	return p, http.StatusOK, nil, nil
+}
+
+// checkTenant checks that the user has access to the Tenant with the given
+// ID, or - for policies of all Tenants, when it's nil - that the user is of
+// the root Tenant.
+func checkTenant(tx *sql.Tx, user *auth.CurrentUser, tenantID *int) (int, error, error) {
+	if tenantID != nil {
+		ok, err := tenant.IsResourceAuthorizedToUserTx(*tenantID, user, tx)
+		if err != nil {
+			return http.StatusInternalServerError, nil, fmt.Errorf("checking access to Tenant #%d: %w", *tenantID, err)
+		}
+		if !ok {
+			return http.StatusForbidden, errors.New("not authorized on this tenant"), nil
+		}
+		return http.StatusOK, nil, nil
+	}
+	root := false
+	if err := tx.QueryRow(`SELECT parent_id IS NULL FROM tenant WHERE id = $1`, user.TenantID).Scan(&root); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return http.StatusInternalServerError, nil, fmt.Errorf("checking whether Tenant #%d is the root Tenant: %w", user.TenantID, err)
+	}
+	if !root {
+		return http.StatusForbidden, errors.New("only users of the root tenant are authorized on policies for all tenants"), nil
+	}
+	return http.StatusOK, nil, nil
+}
+
+// get returns the policy with the given ID, as it's presented in responses.
+func get(tx *sql.Tx, id int) (tc.DSRApprovalPolicy, error) {
+	var p tc.DSRApprovalPolicy
+	err := scanPolicy(tx.QueryRow(readQuery+`WHERE p.id = $1`, id), &p)
+	return p, err
+}
+
+// Create is the handler for POST requests to
+// /deliveryservice_request_approval_policies.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	p, errCode, userErr, sysErr := parse(r, inf)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var id int
+	if err := tx.QueryRow(insertQuery, p.Name, p.TenantID, p.TypeID, p.RequiredApprovals, pq.Array(p.ApproverRoles), pq.Array(p.RequiredFields), p.RejectOnValidationFailure, p.ApplyOnApproval).Scan(&id); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	created, err := get(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting created Delivery Service Request approval policy #%d: %w", id, err))
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/%d.%d/deliveryservice_request_approval_policies?id=%d", inf.Version.Major, inf.Version.Minor, id))
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "Delivery Service Request approval policy was created.")
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, created)
+
+	changeLogMsg := fmt.Sprintf("DSR APPROVAL POLICY: %s, ID: %d, ACTION: Created Delivery Service Request approval policy", created.Name, id)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+}
+
+// Update is the handler for PUT requests to
+// /deliveryservice_request_approval_policies.
+func Update(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	existing, err := get(tx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no Delivery Service Request approval policy exists with id %d", id), nil)
+			return
+		}
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting Delivery Service Request approval policy #%d: %w", id, err))
+		return
+	}
+	if errCode, userErr, sysErr := checkTenant(tx, inf.User, existing.TenantID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	p, errCode, userErr, sysErr := parse(r, inf)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	userErr, sysErr, errCode = api.CheckIfUnModified(r.Header, inf.Tx, id, "dsr_approval_policy")
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	if err := tx.QueryRow(updateQuery, p.Name, p.TenantID, p.TypeID, p.RequiredApprovals, pq.Array(p.ApproverRoles), pq.Array(p.RequiredFields), p.RejectOnValidationFailure, p.ApplyOnApproval, id).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no Delivery Service Request approval policy exists with id %d", id), nil)
+			return
+		}
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	updated, err := get(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting updated Delivery Service Request approval policy #%d: %w", id, err))
+		return
+	}
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "Delivery Service Request approval policy was updated.")
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, updated)
+
+	changeLogMsg := fmt.Sprintf("DSR APPROVAL POLICY: %s, ID: %d, ACTION: Updated Delivery Service Request approval policy", updated.Name, id)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+}
+
+// Delete is the handler for DELETE requests to
+// /deliveryservice_request_approval_policies.
+func Delete(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	deleted, err := get(tx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no Delivery Service Request approval policy exists with id %d", id), nil)
+			return
+		}
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting Delivery Service Request approval policy #%d: %w", id, err))
+		return
+	}
+	if errCode, userErr, sysErr := checkTenant(tx, inf.User, deleted.TenantID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	if _, err := tx.Exec(deleteQuery, id); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "Delivery Service Request approval policy was deleted.")
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, deleted)
+
+	changeLogMsg := fmt.Sprintf("DSR APPROVAL POLICY: %s, ID: %d, ACTION: Deleted Delivery Service Request approval policy", deleted.Name, id)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+}
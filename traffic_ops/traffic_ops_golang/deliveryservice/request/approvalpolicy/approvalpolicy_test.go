@@ -0,0 +1,76 @@
+package approvalpolicy
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestCheckTenantAllTenants(t *testing.T) {
+	tests := []struct {
+		name     string
+		root     bool
+		expected int
+	}{
+		{"root tenant user", true, http.StatusOK},
+		{"child tenant user", false, http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT parent_id IS NULL FROM tenant").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"root"}).AddRow(test.root))
+			mock.ExpectRollback()
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatalf("beginning transaction: %v", err)
+			}
+			user := auth.CurrentUser{UserName: "user", ID: 1, TenantID: 2}
+			code, userErr, sysErr := checkTenant(tx, &user, nil)
+			if sysErr != nil {
+				t.Fatalf("unexpected system error: %v", sysErr)
+			}
+			if code != test.expected {
+				t.Errorf("expected response code %d, got: %d (%v)", test.expected, code, userErr)
+			}
+			if (userErr == nil) != test.root {
+				t.Errorf("expected user error: %t, got: %v", !test.root, userErr)
+			}
+			if err := tx.Rollback(); err != nil {
+				t.Fatalf("rolling back: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations were not met: %v", err)
+			}
+		})
+	}
+}
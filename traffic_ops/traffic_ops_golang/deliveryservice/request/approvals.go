@@ -0,0 +1,524 @@
+package request
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice/request/approvalpolicy"
+)
+
+const selectApprovalsQuery = `
+SELECT
+	a.id,
+	a.deliveryservice_request_id,
+	u.username AS approver,
+	a.role,
+	a.comment,
+	a.created_at
+FROM deliveryservice_request_approval a
+JOIN tm_user u ON a.approver_id = u.id
+WHERE a.deliveryservice_request_id = $1
+ORDER BY a.created_at, a.id
+`
+
+const insertApprovalQuery = `
+INSERT INTO deliveryservice_request_approval (
+	deliveryservice_request_id,
+	approver_id,
+	role,
+	comment
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (deliveryservice_request_id, approver_id) DO NOTHING
+RETURNING id, created_at
+`
+
+const clearApprovalsQuery = `
+DELETE FROM deliveryservice_request_approval
+WHERE deliveryservice_request_id = $1
+`
+
+// updateApprovedQuery returns the number of the applied version of the
+// Delivery Service as selectQuery does.
+const updateApprovedQuery = `
+UPDATE deliveryservice_request
+SET original = $1, status = $2, last_edited_by_id = $3, applied_at = $4, applied_last_updated = $5, applied_version_id = $6
+WHERE id = $7
+RETURNING
+	last_updated,
+	NULLIF((
+		SELECT count(*)
+		FROM deliveryservice_version v
+		JOIN deliveryservice_version av ON v.deliveryservice = av.deliveryservice AND v.id <= av.id
+		WHERE av.id = $6
+	), 0)
+`
+
+// applySavepoint is the name of the savepoint to which the transaction is
+// rolled back if the changes of a Delivery Service Request fail validation
+// when they're applied, and the Delivery Service Request is rejected instead.
+const applySavepoint = "apply_deliveryservice_request"
+
+// getDSR returns the Delivery Service Request with the given ID, after
+// checking that the user has access to its Tenant. If forUpdate is true, the
+// Delivery Service Request is locked until the transaction ends, so that
+// concurrent approvals of it are made one after another.
+func getDSR(inf *api.APIInfo, id int, forUpdate bool) (tc.DeliveryServiceRequestV40, int, error, error) {
+	query := selectQuery + "WHERE r.id=$1"
+	if forUpdate {
+		query += " FOR UPDATE OF r"
+	}
+	var dsr tc.DeliveryServiceRequestV40
+	if err := inf.Tx.QueryRowx(query, id).StructScan(&dsr); err != nil {
+		if err == sql.ErrNoRows {
+			return dsr, http.StatusNotFound, fmt.Errorf("no such Delivery Service Request: %d", id), nil
+		}
+		return dsr, http.StatusInternalServerError, nil, fmt.Errorf("looking for DSR: %v", err)
+	}
+	dsr.SetXMLID()
+
+	authorized, err := isTenantAuthorized(dsr, inf)
+	if err != nil {
+		return dsr, http.StatusInternalServerError, nil, err
+	}
+	if !authorized {
+		return dsr, http.StatusForbidden, errors.New("not authorized on this tenant"), nil
+	}
+	return dsr, http.StatusOK, nil, nil
+}
+
+// getPolicy returns the approval policy for the Delivery Service Request, and
+// whether it was explicitly defined - if not, it's the default policy, which
+// doesn't restrict the status changes of the Delivery Service Request.
+func getPolicy(tx *sql.Tx, dsr tc.DeliveryServiceRequestV40) (tc.DSRApprovalPolicy, bool, error) {
+	ds := dsr.Requested
+	if dsr.ChangeType == tc.DSRChangeTypeDelete {
+		ds = dsr.Original
+	}
+	var tenantID, typeID *int
+	if ds != nil {
+		tenantID = ds.TenantID
+		typeID = ds.TypeID
+		// Requests to delete Delivery Services needn't describe more than
+		// their IDs.
+		if ds.ID != nil && (tenantID == nil || typeID == nil) {
+			err := tx.QueryRow(`SELECT tenant_id, type FROM deliveryservice WHERE id = $1`, *ds.ID).Scan(&tenantID, &typeID)
+			if err != nil && err != sql.ErrNoRows {
+				return tc.DefaultDSRApprovalPolicy, false, fmt.Errorf("getting Tenant and Type of Delivery Service #%d: %v", *ds.ID, err)
+			}
+		}
+	}
+
+	policy, err := approvalpolicy.ForDeliveryService(tx, tenantID, typeID)
+	if err != nil {
+		return tc.DefaultDSRApprovalPolicy, false, err
+	}
+	if policy == nil {
+		return tc.DefaultDSRApprovalPolicy, false, nil
+	}
+	return *policy, true, nil
+}
+
+// checkRequiredFields returns a user-facing error listing the fields
+// required by the policy which the Delivery Service Request doesn't set, if
+// there are any.
+func checkRequiredFields(dsr tc.DeliveryServiceRequestV40, policy tc.DSRApprovalPolicy) (error, error) {
+	if dsr.ChangeType == tc.DSRChangeTypeDelete || dsr.Requested == nil {
+		return nil, nil
+	}
+	missing, err := policy.MissingFields(*dsr.Requested)
+	if err != nil {
+		return nil, fmt.Errorf("checking fields required by approval policy '%s': %v", policy.Name, err)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing fields required by approval policy '%s': %s", policy.Name, strings.Join(missing, ", ")), nil
+	}
+	return nil, nil
+}
+
+// checkSubmittedRequiredFields checks that a Delivery Service Request which is
+// created or edited as "submitted" sets the fields its approval policy
+// requires.
+func checkSubmittedRequiredFields(tx *sql.Tx, dsr tc.DeliveryServiceRequestV40) (int, error, error) {
+	if dsr.Status != tc.RequestStatusSubmitted {
+		return http.StatusOK, nil, nil
+	}
+	policy, explicit, err := getPolicy(tx, dsr)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	if !explicit {
+		return http.StatusOK, nil, nil
+	}
+	if userErr, sysErr := checkRequiredFields(dsr, policy); userErr != nil || sysErr != nil {
+		return http.StatusBadRequest, userErr, sysErr
+	}
+	return http.StatusOK, nil, nil
+}
+
+// checkSubmission returns a user-facing error describing why the Delivery
+// Service Request may not be submitted or approved under the policy, if it may
+// not: because it lacks required fields, or its requested Delivery Service
+// isn't valid (anymore).
+func checkSubmission(tx *sql.Tx, dsr tc.DeliveryServiceRequestV40, policy tc.DSRApprovalPolicy) (error, error) {
+	if userErr, sysErr := checkRequiredFields(dsr, policy); userErr != nil || sysErr != nil {
+		return userErr, sysErr
+	}
+	if dsr.ChangeType == tc.DSRChangeTypeDelete || dsr.Requested == nil {
+		return nil, nil
+	}
+	ds := *dsr.Requested
+	if err := deliveryservice.Validate(tx, &ds); err != nil {
+		return fmt.Errorf("invalid requested Delivery Service: %v", err), nil
+	}
+	return nil, nil
+}
+
+// getApprovals returns the approvals of the Delivery Service Request with the
+// given ID, in the order they were given.
+func getApprovals(tx *sql.Tx, dsrID int) ([]tc.DSRApproval, error) {
+	rows, err := tx.Query(selectApprovalsQuery, dsrID)
+	if err != nil {
+		return nil, fmt.Errorf("querying approvals of DSR #%d: %v", dsrID, err)
+	}
+	defer log.Close(rows, "closing DSR approval rows")
+
+	approvals := []tc.DSRApproval{}
+	for rows.Next() {
+		var a tc.DSRApproval
+		if err := rows.Scan(&a.ID, &a.DeliveryServiceRequestID, &a.Approver, &a.Role, &a.Comment, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning approvals of DSR #%d: %v", dsrID, err)
+		}
+		approvals = append(approvals, a)
+	}
+	return approvals, rows.Err()
+}
+
+// clearApprovals removes the approvals of the Delivery Service Request with
+// the given ID, which no longer apply once it's changed or returned to draft.
+func clearApprovals(tx *sql.Tx, dsrID int) error {
+	if _, err := tx.Exec(clearApprovalsQuery, dsrID); err != nil {
+		return fmt.Errorf("clearing approvals of DSR #%d: %v", dsrID, err)
+	}
+	return nil
+}
+
+// setCurrentOriginal sets the Original of a Delivery Service Request which
+// updates or deletes a Delivery Service to the current state of that Delivery
+// Service, as is stored when the request is closed.
+func setCurrentOriginal(inf *api.APIInfo, dsr *tc.DeliveryServiceRequestV40, omitExtraLongDescFields bool) (int, error, error) {
+	var id int
+	var idDesc string
+	if dsr.ChangeType == tc.DSRChangeTypeUpdate && dsr.Requested != nil && dsr.Requested.ID != nil {
+		id = *dsr.Requested.ID
+		idDesc = "requested ID"
+	} else if dsr.ChangeType == tc.DSRChangeTypeDelete && dsr.Original != nil && dsr.Original.ID != nil {
+		id = *dsr.Original.ID
+		idDesc = "original ID"
+	} else {
+		return http.StatusOK, nil, nil
+	}
+
+	errCode, userErr, sysErr := getOriginals([]int{id}, inf.Tx, map[int][]*tc.DeliveryServiceRequestV4{id: {dsr}}, omitExtraLongDescFields)
+	if userErr != nil || sysErr != nil {
+		return errCode, userErr, sysErr
+	}
+	if dsr.Original == nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to build original from dsr #%d that was to be closed; %s: %d", *dsr.ID, idDesc, id)
+	}
+	return http.StatusOK, nil, nil
+}
+
+// applyRequest makes the changes requested by the Delivery Service Request to
+// its Delivery Service, in the transaction of inf, returning the lastUpdated
+// time of the resulting Delivery Service and the ID of the version of it that
+// was recorded - or nils if it was deleted.
+func applyRequest(r *http.Request, inf *api.APIInfo, dsr tc.DeliveryServiceRequestV40) (*time.Time, *int64, int, error, error) {
+	// The conditional headers of the request to approve the Delivery Service
+	// Request are about the Delivery Service Request, not its Delivery
+	// Service.
+	r = r.Clone(r.Context())
+	r.Header.Del(rfc.IfMatch)
+	r.Header.Del(rfc.IfUnmodifiedSince)
+
+	var res *tc.DeliveryServiceV4
+	var versionID int64
+	var errCode int
+	var userErr, sysErr error
+	switch dsr.ChangeType {
+	case tc.DSRChangeTypeCreate:
+		if dsr.Requested == nil {
+			return nil, nil, http.StatusBadRequest, errors.New("no requested Delivery Service to create"), nil
+		}
+		res, versionID, errCode, userErr, sysErr = deliveryservice.CreateV4(r, inf, *dsr.Requested)
+	case tc.DSRChangeTypeUpdate:
+		if dsr.Requested == nil {
+			return nil, nil, http.StatusBadRequest, errors.New("no requested Delivery Service to update"), nil
+		}
+		ds := *dsr.Requested
+		if ds.ID == nil && dsr.Original != nil {
+			ds.ID = dsr.Original.ID
+		}
+		if ds.ID == nil {
+			return nil, nil, http.StatusBadRequest, fmt.Errorf("cannot update non-existent Delivery Service '%s'", dsr.XMLID), nil
+		}
+		_, cdn, _, err := dbhelpers.GetDSNameAndCDNFromID(inf.Tx.Tx, *ds.ID)
+		if err != nil {
+			return nil, nil, http.StatusInternalServerError, nil, fmt.Errorf("getting CDN of Delivery Service #%d: %v", *ds.ID, err)
+		}
+		if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, string(cdn), inf.User.UserName); userErr != nil || sysErr != nil {
+			return nil, nil, errCode, userErr, sysErr
+		}
+		if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(inf.Tx.Tx, *ds.ID, inf.User.UserName); userErr != nil || sysErr != nil {
+			return nil, nil, errCode, userErr, sysErr
+		}
+		res, versionID, errCode, userErr, sysErr = deliveryservice.UpdateV4(r, inf, &ds)
+	case tc.DSRChangeTypeDelete:
+		if dsr.Original == nil || dsr.Original.ID == nil {
+			return nil, nil, http.StatusBadRequest, errors.New("no original Delivery Service to delete"), nil
+		}
+		errCode, userErr, sysErr = deliveryservice.DeleteV4(inf, *dsr.Original.ID)
+		return nil, nil, errCode, userErr, sysErr
+	default:
+		return nil, nil, http.StatusBadRequest, fmt.Errorf("cannot apply changes of unknown type '%s'", dsr.ChangeType), nil
+	}
+	if userErr != nil || sysErr != nil {
+		return nil, nil, errCode, userErr, sysErr
+	}
+	if res == nil || res.LastUpdated == nil {
+		return nil, nil, http.StatusInternalServerError, nil, fmt.Errorf("applying DSR #%d: no resulting Delivery Service", *dsr.ID)
+	}
+	lastUpdated := res.LastUpdated.Time
+	return &lastUpdated, &versionID, http.StatusOK, nil, nil
+}
+
+// approve closes a Delivery Service Request which has all of the approvals its
+// policy requires, and - if the policy says to - applies its changes. If its
+// changes are invalid, and the policy says to reject invalid requests, it's
+// rejected instead. The returned alert describes the outcome.
+func approve(r *http.Request, inf *api.APIInfo, dsr *tc.DeliveryServiceRequestV40, policy tc.DSRApprovalPolicy) (tc.Alert, int, error, error) {
+	tx := inf.Tx.Tx
+	status := tc.RequestStatusPending
+	var appliedAt, appliedLastUpdated *time.Time
+	var appliedVersionID *int64
+	var rejection error
+
+	userErr, sysErr := checkSubmission(tx, *dsr, policy)
+	if sysErr != nil {
+		return tc.Alert{}, http.StatusInternalServerError, nil, sysErr
+	}
+	if userErr != nil {
+		if !policy.RejectOnValidationFailure {
+			return tc.Alert{}, http.StatusBadRequest, fmt.Errorf("Delivery Service Request #%d cannot be approved: %v", *dsr.ID, userErr), nil
+		}
+		rejection = userErr
+		status = tc.RequestStatusRejected
+	}
+
+	if dsr.ChangeType != tc.DSRChangeTypeCreate {
+		if errCode, userErr, sysErr := setCurrentOriginal(inf, dsr, true); userErr != nil || sysErr != nil {
+			return tc.Alert{}, errCode, userErr, sysErr
+		}
+	}
+
+	if rejection == nil && policy.ApplyOnApproval {
+		if _, err := tx.Exec("SAVEPOINT " + applySavepoint); err != nil {
+			return tc.Alert{}, http.StatusInternalServerError, nil, fmt.Errorf("creating savepoint to apply DSR #%d: %v", *dsr.ID, err)
+		}
+		lastUpdated, versionID, errCode, userErr, sysErr := applyRequest(r, inf, *dsr)
+		if sysErr != nil || (userErr != nil && (errCode != http.StatusBadRequest || !policy.RejectOnValidationFailure)) {
+			return tc.Alert{}, errCode, userErr, sysErr
+		}
+		if userErr != nil {
+			if _, err := tx.Exec("ROLLBACK TO SAVEPOINT " + applySavepoint); err != nil {
+				return tc.Alert{}, http.StatusInternalServerError, nil, fmt.Errorf("rolling back application of DSR #%d: %v", *dsr.ID, err)
+			}
+			rejection = userErr
+			status = tc.RequestStatusRejected
+		} else {
+			now := time.Now()
+			appliedAt = &now
+			appliedLastUpdated = lastUpdated
+			appliedVersionID = versionID
+			status = tc.RequestStatusComplete
+		}
+	}
+
+	var appliedVersion *int
+	if err := tx.QueryRow(updateApprovedQuery, dsr.Original, status, inf.User.ID, appliedAt, appliedLastUpdated, appliedVersionID, *dsr.ID).Scan(&dsr.LastUpdated, &appliedVersion); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		return tc.Alert{}, errCode, userErr, sysErr
+	}
+	dsr.Status = status
+	dsr.AppliedAt = appliedAt
+	dsr.AppliedLastUpdated = appliedLastUpdated
+	dsr.AppliedVersion = appliedVersion
+	dsr.LastEditedBy = inf.User.UserName
+	dsr.LastEditedByID = new(int)
+	*dsr.LastEditedByID = inf.User.ID
+
+	switch status {
+	case tc.RequestStatusRejected:
+		return tc.Alert{Level: tc.WarnLevel.String(), Text: fmt.Sprintf("Delivery Service Request rejected by approval policy '%s': %v", policy.Name, rejection)}, http.StatusOK, nil, nil
+	case tc.RequestStatusComplete:
+		return tc.Alert{Level: tc.SuccessLevel.String(), Text: fmt.Sprintf("Delivery Service Request approved, and its changes to Delivery Service '%s' applied", dsr.XMLID)}, http.StatusOK, nil, nil
+	}
+	return tc.Alert{Level: tc.SuccessLevel.String(), Text: "Delivery Service Request approved, and ready to be implemented"}, http.StatusOK, nil, nil
+}
+
+// GetApprovals is the handler for GET requests to
+// /deliveryservice_requests/{{ID}}/approvals.
+func GetApprovals(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	dsr, errCode, userErr, sysErr := getDSR(inf, inf.IntParams["id"], false)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	policy, _, err := getPolicy(tx, dsr)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	approvals, err := getApprovals(tx, *dsr.ID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	api.WriteResp(w, r, tc.DSRApprovals{
+		Policy:            policy.Name,
+		RequiredApprovals: policy.RequiredApprovals,
+		Approvals:         approvals,
+	})
+}
+
+// PostApproval is the handler for POST requests to
+// /deliveryservice_requests/{{ID}}/approvals, which approve the Delivery
+// Service Request as the current user. Once it has all of the approvals its
+// policy requires, it's closed.
+func PostApproval(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var req tc.DSRApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("decoding: %v", err), nil)
+		return
+	}
+
+	dsr, errCode, userErr, sysErr := getDSR(inf, inf.IntParams["id"], true)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if dsr.Status != tc.RequestStatusSubmitted {
+		api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("only submitted Delivery Service Requests can be approved; #%d is %s", *dsr.ID, dsr.Status), nil)
+		return
+	}
+	if dsr.AuthorID != nil && *dsr.AuthorID == inf.User.ID {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("users cannot approve their own Delivery Service Requests"), nil)
+		return
+	}
+
+	policy, _, err := getPolicy(tx, dsr)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if inf.User.RoleName != auth.AdminRoleName && !policy.AllowsRole(inf.User.RoleName) {
+		userErr = fmt.Errorf("approval policy '%s' only allows users with the Roles %s to approve Delivery Service Requests", policy.Name, strings.Join(policy.ApproverRoles, ", "))
+		api.HandleErr(w, r, tx, http.StatusForbidden, userErr, nil)
+		return
+	}
+
+	approval := tc.DSRApproval{
+		DeliveryServiceRequestID: *dsr.ID,
+		Approver:                 inf.User.UserName,
+		Role:                     inf.User.RoleName,
+		Comment:                  req.Comment,
+	}
+	if err := tx.QueryRow(insertApprovalQuery, *dsr.ID, inf.User.ID, inf.User.RoleName, req.Comment).Scan(&approval.ID, &approval.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("you have already approved Delivery Service Request #%d", *dsr.ID), nil)
+			return
+		}
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	approvals, err := getApprovals(tx, *dsr.ID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	message := fmt.Sprintf("Approved '%s' Delivery Service Request (%d of %d required approvals)", dsr.XMLID, len(approvals), policy.RequiredApprovals)
+	alerts := tc.CreateAlerts(tc.SuccessLevel, message)
+	if len(approvals) >= policy.RequiredApprovals {
+		alert, errCode, userErr, sysErr := approve(r, inf, &dsr, policy)
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+		alerts.Alerts = append(alerts.Alerts, alert)
+		message += fmt.Sprintf(", changing its status to '%s'", dsr.Status)
+	}
+
+	if dsr.Original != nil {
+		*dsr.Original = dsr.Original.RemoveLD1AndLD2()
+	}
+	if dsr.Requested != nil {
+		*dsr.Requested = dsr.Requested.RemoveLD1AndLD2()
+	}
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, dsr)
+
+	message = fmt.Sprintf("Delivery Service Request: %d, ID: %d, ACTION: %s deliveryservice_request, keys: {id:%d }", *dsr.ID, *dsr.ID, message, *dsr.ID)
+	inf.CreateChangeLog(message)
+}
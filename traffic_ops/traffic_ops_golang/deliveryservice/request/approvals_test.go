@@ -0,0 +1,101 @@
+package request
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault/backends/disabled"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestPostApprovalGuards(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   tc.RequestStatus
+		authorID int
+		expected int
+	}{
+		{"own request", tc.RequestStatusSubmitted, 1, http.StatusForbidden},
+		{"draft request", tc.RequestStatusDraft, 2, http.StatusConflict},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db := sqlx.NewDb(mockDB, "sqlmock")
+			defer db.Close()
+
+			columns := []string{"author", "lasteditedby", "assignee", "assignee_id", "author_id", "change_type", "created_at", "id", "last_edited_by_id", "last_updated", "deliveryservice", "original", "status", "applied_at", "applied_last_updated", "applied_version"}
+			requested := []byte(`{"xmlId": "demo1", "tenantId": 1}`)
+			rows := sqlmock.NewRows(columns).AddRow("author", "author", nil, nil, test.authorID, tc.DSRChangeTypeCreate.String(), time.Now(), 1, test.authorID, time.Now(), requested, nil, []byte(test.status), nil, nil, nil)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT .* FOR UPDATE OF r").WithArgs(1).WillReturnRows(rows)
+			mock.ExpectQuery("WITH RECURSIVE").WillReturnRows(sqlmock.NewRows([]string{"id", "active"}).AddRow(1, true))
+			mock.ExpectRollback()
+
+			req, err := http.NewRequest(http.MethodPost, "deliveryservice_requests/1/approvals", http.NoBody)
+			if err != nil {
+				t.Fatalf("creating request: %v", err)
+			}
+			user := auth.CurrentUser{UserName: "approver", ID: 1, TenantID: 1, RoleName: "operations"}
+			cfg := config.Config{ConfigTrafficOpsGolang: config.ConfigTrafficOpsGolang{DBQueryTimeoutSeconds: 20}}
+			var tv trafficvault.TrafficVault = &disabled.Disabled{}
+			ctx := req.Context()
+			ctx = context.WithValue(ctx, auth.CurrentUserKey, user)
+			ctx = context.WithValue(ctx, "db", db)
+			ctx = context.WithValue(ctx, "context", &cfg)
+			ctx = context.WithValue(ctx, "reqid", uint64(0))
+			ctx = context.WithValue(ctx, api.TrafficVaultContextKey, tv)
+			ctx = context.WithValue(ctx, "pathParams", map[string]string{"id": "1"})
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			PostApproval(w, req)
+
+			code := w.Code
+			if c, ok := req.Context().Value(tc.StatusKey).(int); ok {
+				code = c
+			}
+			if code != test.expected {
+				t.Errorf("expected response code %d, got: %d", test.expected, code)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations were not met: %v", err)
+			}
+		})
+	}
+}
@@ -44,6 +44,9 @@ import (
 	"github.com/lib/pq"
 )
 
+// selectQuery numbers the Delivery Service version recorded by applying a
+// Delivery Service Request the way the versions of a Delivery Service are
+// numbered, by their order.
 const selectQuery = `
 SELECT
 	a.username AS author,
@@ -58,11 +61,20 @@ SELECT
 	r.last_updated,
 	r.deliveryservice,
 	r.original,
-	r.status
+	r.status,
+	r.applied_at,
+	r.applied_last_updated,
+	NULLIF((
+		SELECT count(*)
+		FROM deliveryservice_version v
+		WHERE v.deliveryservice = av.deliveryservice
+		AND v.id <= av.id
+	), 0) AS applied_version
 FROM deliveryservice_request r
 JOIN tm_user a ON r.author_id = a.id
 LEFT OUTER JOIN tm_user s ON r.assignee_id = s.id
 LEFT OUTER JOIN tm_user e ON r.last_edited_by_id = e.id
+LEFT OUTER JOIN deliveryservice_version av ON r.applied_version_id = av.id
 `
 
 const insertQuery = `
@@ -89,7 +101,13 @@ RETURNING
 	created_at
 `
 
+// updateQuery also removes any approvals of the Delivery Service Request,
+// which don't carry over to its changed contents.
 const updateQuery = `
+WITH cleared_approvals AS (
+	DELETE FROM deliveryservice_request_approval
+	WHERE deliveryservice_request_id = $7
+)
 UPDATE deliveryservice_request
 SET
 	assignee_id = $1,
@@ -437,6 +455,10 @@ func createV4(w http.ResponseWriter, r *http.Request, inf *api.APIInfo) (result
 		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
 		return
 	}
+	if errCode, userErr, sysErr := checkSubmittedRequiredFields(tx, dsr); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
 
 	ok, err := isTenantAuthorized(dsr, inf)
 	if err != nil {
@@ -722,6 +744,10 @@ func putV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo) (result ds
 	} else {
 		dsr.Requested = nil
 	}
+	if errCode, userErr, sysErr := checkSubmittedRequiredFields(tx, dsr); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
 
 	authorized, err := isTenantAuthorized(dsr, inf)
 	if err != nil {
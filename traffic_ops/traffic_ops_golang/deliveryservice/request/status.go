@@ -135,8 +135,10 @@ func PutStatus(w http.ResponseWriter, r *http.Request) {
 
 	dsrID := inf.IntParams["id"]
 
+	// The Delivery Service Request is locked, so that its status isn't changed
+	// while it's being approved.
 	var dsr tc.DeliveryServiceRequestV40
-	if err := inf.Tx.QueryRowx(selectQuery+"WHERE r.id=$1", dsrID).StructScan(&dsr); err != nil {
+	if err := inf.Tx.QueryRowx(selectQuery+"WHERE r.id=$1 FOR UPDATE OF r", dsrID).StructScan(&dsr); err != nil {
 		if err == sql.ErrNoRows {
 			errCode = http.StatusNotFound
 			userErr = fmt.Errorf("no such Delivery Service Request: %d", dsrID)
@@ -166,6 +168,15 @@ func PutStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var rejection *tc.Alert
+	if req.Status != dsr.Status {
+		rejection, errCode, userErr, sysErr = checkStatusChange(inf, dsr, &req.Status)
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+	}
+
 	dsr.LastEditedBy = inf.User.UserName
 	dsr.LastEditedByID = new(int)
 	*dsr.LastEditedByID = inf.User.ID
@@ -173,28 +184,9 @@ func PutStatus(w http.ResponseWriter, r *http.Request) {
 	// store the current original DS if the DSR is being closed
 	// (and isn't a "create" request)
 	if dsr.IsOpen() && req.Status != tc.RequestStatusDraft && req.Status != tc.RequestStatusSubmitted && dsr.ChangeType != tc.DSRChangeTypeCreate {
-		if dsr.ChangeType == tc.DSRChangeTypeUpdate && dsr.Requested != nil && dsr.Requested.ID != nil {
-			errCode, userErr, sysErr = getOriginals([]int{*dsr.Requested.ID}, inf.Tx, map[int][]*tc.DeliveryServiceRequestV4{*dsr.Requested.ID: {&dsr}}, omitExtraLongDescFields)
-			if userErr != nil || sysErr != nil {
-				api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-				return
-			}
-			if dsr.Original == nil {
-				sysErr = fmt.Errorf("failed to build original from dsr #%d that was to be closed; requested ID: %d", dsrID, *dsr.Requested.ID)
-			}
-		} else if dsr.ChangeType == tc.DSRChangeTypeDelete && dsr.Original != nil && dsr.Original.ID != nil {
-			errCode, userErr, sysErr = getOriginals([]int{*dsr.Original.ID}, inf.Tx, map[int][]*tc.DeliveryServiceRequestV4{*dsr.Original.ID: {&dsr}}, omitExtraLongDescFields)
-			if userErr != nil || sysErr != nil {
-				api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-				return
-			}
-			if dsr.Original == nil {
-				sysErr = fmt.Errorf("failed to build original from dsr #%d that was to be closed; original ID: %d", dsrID, *dsr.Original.ID)
-			}
-		}
-
-		if sysErr != nil {
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
+		errCode, userErr, sysErr = setCurrentOriginal(inf, &dsr, omitExtraLongDescFields)
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
 			return
 		}
 
@@ -241,7 +233,64 @@ func PutStatus(w http.ResponseWriter, r *http.Request) {
 		resp = dsr.Downgrade()
 	}
 
-	api.WriteRespAlertObj(w, r, tc.SuccessLevel, message, resp)
+	alerts := tc.CreateAlerts(tc.SuccessLevel, message)
+	if rejection != nil {
+		alerts.Alerts = append(alerts.Alerts, *rejection)
+	}
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, resp)
 	message = fmt.Sprintf("Delivery Service Request: %d, ID: %d, ACTION: %s deliveryservice_request, keys: {id:%d }", *dsr.ID, *dsr.ID, message, *dsr.ID)
 	inf.CreateChangeLog(message)
 }
+
+// checkStatusChange enforces the approval policy of a Delivery Service Request
+// on a change of its status to the given status. If the policy rejects the
+// Delivery Service Request on submission, the status is changed to "rejected"
+// and the returned alert says why.
+func checkStatusChange(inf *api.APIInfo, dsr tc.DeliveryServiceRequestV40, status *tc.RequestStatus) (*tc.Alert, int, error, error) {
+	tx := inf.Tx.Tx
+	if *status == tc.RequestStatusDraft {
+		if err := clearApprovals(tx, *dsr.ID); err != nil {
+			return nil, http.StatusInternalServerError, nil, err
+		}
+		return nil, http.StatusOK, nil, nil
+	}
+
+	policy, explicit, err := getPolicy(tx, dsr)
+	if err != nil {
+		return nil, http.StatusInternalServerError, nil, err
+	}
+	// Without an approval policy, status changes aren't restricted, as they
+	// weren't before approval policies existed.
+	if !explicit {
+		return nil, http.StatusOK, nil, nil
+	}
+
+	switch *status {
+	case tc.RequestStatusSubmitted:
+		userErr, sysErr := checkSubmission(tx, dsr, policy)
+		if sysErr != nil {
+			return nil, http.StatusInternalServerError, nil, sysErr
+		}
+		if userErr == nil {
+			break
+		}
+		if !policy.RejectOnValidationFailure {
+			return nil, http.StatusBadRequest, userErr, nil
+		}
+		*status = tc.RequestStatusRejected
+		return &tc.Alert{
+			Level: tc.WarnLevel.String(),
+			Text:  fmt.Sprintf("Delivery Service Request rejected by approval policy '%s': %v", policy.Name, userErr),
+		}, http.StatusOK, nil, nil
+	case tc.RequestStatusPending, tc.RequestStatusComplete:
+		approvals, err := getApprovals(tx, *dsr.ID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, nil, err
+		}
+		if len(approvals) < policy.RequiredApprovals {
+			userErr := fmt.Errorf("Delivery Service Request #%d has %d of the %d approvals required by approval policy '%s'", *dsr.ID, len(approvals), policy.RequiredApprovals, policy.Name)
+			return nil, http.StatusConflict, userErr, nil
+		}
+	}
+	return nil, http.StatusOK, nil, nil
+}
@@ -97,6 +97,11 @@ func UpdateSafe(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
 		return
 	}
+	userErr, sysErr, statusCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(inf.Tx.Tx, dsID, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
 	if version.Major > 3 && version.Minor >= 0 {
 		if dsr.LongDesc1 != nil {
 			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("the longDesc1 field is no longer supported in API 4.0 onwards"), nil)
@@ -114,6 +119,10 @@ func UpdateSafe(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, tx, http.StatusNotFound, userErr, nil)
 		return
 	}
+	if _, err := CreateVersion(inf.Tx, dsID, inf.User); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("Updating Delivery Service (safe): %s", err))
+		return
+	}
 	useIMS := false
 	config, e := api.GetConfig(r.Context())
 	if e == nil && config != nil {
@@ -429,13 +429,17 @@ func GetReplaceHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyDeliveryService(inf.Tx.Tx, ds.Name, inf.User.UserName); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
 	serverInfos, err := dbhelpers.GetServerInfosFromIDs(inf.Tx.Tx, servers)
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
 		return
 	}
 
-	userErr, sysErr, status := validateDSSAssignments(inf.Tx.Tx, ds, serverInfos, *payload.Replace)
+	userErr, sysErr, status := ValidateDSSAssignments(inf.Tx.Tx, ds, serverInfos, *payload.Replace)
 	if userErr != nil || sysErr != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, status, userErr, sysErr)
 		return
@@ -469,6 +473,10 @@ func GetReplaceHandler(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deliveryservice_server replace ensuring ds parameters: "+err.Error()))
 		return
 	}
+	if _, err := deliveryservice.CreateVersion(inf.Tx, *dsId, inf.User); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deliveryservice_server replace: "+err.Error()))
+		return
+	}
 	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+ds.Name+", ID: "+strconv.Itoa(*dsId)+", ACTION: Replace existing servers assigned to delivery service", inf.User, inf.Tx.Tx)
 	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "server assignments complete", tc.DSSMapResponse{DsId: *dsId, Replace: *payload.Replace, Servers: respServers})
 }
@@ -515,6 +523,10 @@ func GetCreateHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyDeliveryService(inf.Tx.Tx, ds.Name, inf.User.UserName); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
 
 	// get list of server Ids to insert
 	payload := tc.DeliveryServiceServers{}
@@ -531,7 +543,7 @@ func GetCreateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userErr, sysErr, status := validateDSSAssignments(inf.Tx.Tx, ds, serverInfos, false)
+	userErr, sysErr, status := ValidateDSSAssignments(inf.Tx.Tx, ds, serverInfos, false)
 	if userErr != nil || sysErr != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, status, userErr, sysErr)
 		return
@@ -562,12 +574,17 @@ func GetCreateHandler(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deliveryservice_server replace ensuring ds parameters: "+err.Error()))
 		return
 	}
+	if _, err := deliveryservice.CreateVersion(inf.Tx, ds.ID, inf.User); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("ds servers create: "+err.Error()))
+		return
+	}
 	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+dsName+", ID: "+strconv.Itoa(ds.ID)+", ACTION: Assigned servers "+strings.Join(serverNames, ", ")+" to delivery service", inf.User, inf.Tx.Tx)
 	api.WriteResp(w, r, tc.DeliveryServiceServers{ServerNames: payload.ServerNames, XmlId: payload.XmlId})
 }
 
-// validateDSSAssignments returns an error if the given servers cannot be assigned to the given delivery service.
-func validateDSSAssignments(tx *sql.Tx, ds DSInfo, serverInfos []tc.ServerInfo, replace bool) (error, error, int) {
+// ValidateDSSAssignments returns an error if the given servers cannot be assigned to the given delivery service.
+// If replace is true, the servers replace those currently assigned to it.
+func ValidateDSSAssignments(tx *sql.Tx, ds DSInfo, serverInfos []tc.ServerInfo, replace bool) (error, error, int) {
 	valid := false
 	userErr, sysErr, status := validateDSS(tx, ds, serverInfos)
 	if userErr != nil || sysErr != nil {
@@ -0,0 +1,129 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/lib/pq"
+)
+
+// validTLSCipherPattern matches OpenSSL cipher and cipher suite names. It's
+// deliberately strict, because the names are written into cache config
+// files, joined with colons.
+var validTLSCipherPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.+=-]*$`)
+
+// validateTLSCiphers validates a list of TLS ciphers or cipher suites, for
+// validation.By.
+func validateTLSCiphers(value interface{}) error {
+	ciphers, ok := value.([]string)
+	if !ok {
+		return fmt.Errorf("must be an array of string, got: %T", value)
+	}
+	seen := make(map[string]struct{}, len(ciphers))
+	for _, cipher := range ciphers {
+		if _, ok := seen[cipher]; ok {
+			return fmt.Errorf("duplicate cipher '%s'", cipher)
+		}
+		seen[cipher] = struct{}{}
+		if !validTLSCipherPattern.MatchString(cipher) {
+			return fmt.Errorf("invalid cipher '%s'", cipher)
+		}
+	}
+	return nil
+}
+
+// selectTLSPolicyColumns are the columns of the Delivery Service TLS policy,
+// for the Delivery Service 'ds', in the order GetDeliveryServices scans them.
+const selectTLSPolicyColumns = `
+	(SELECT ciphers FROM deliveryservice_tls_policy WHERE deliveryservice = ds.id) AS tls_ciphers,
+	(SELECT cipher_suites FROM deliveryservice_tls_policy WHERE deliveryservice = ds.id) AS tls_cipher_suites,
+	(SELECT verify_client FROM deliveryservice_tls_policy WHERE deliveryservice = ds.id) AS tls_verify_client,
+	(SELECT host_sni_policy FROM deliveryservice_tls_policy WHERE deliveryservice = ds.id) AS tls_host_sni_policy,
+	(SELECT ocsp_stapling FROM deliveryservice_tls_policy WHERE deliveryservice = ds.id) AS tls_ocsp_stapling,`
+
+const getTLSPolicyQuery = `
+SELECT ciphers, cipher_suites, verify_client, host_sni_policy, ocsp_stapling
+FROM public.deliveryservice_tls_policy
+WHERE deliveryservice = $1
+`
+
+// GetDSTLSPolicy sets the TLS policy fields of the given Delivery Service,
+// which must have an ID, from the database. This will panic if handed a nil
+// transaction.
+func GetDSTLSPolicy(ds *tc.DeliveryServiceV4, tx *sql.Tx) error {
+	err := tx.QueryRow(getTLSPolicyQuery, *ds.ID).Scan(
+		pq.Array(&ds.TLSCiphers),
+		pq.Array(&ds.TLSCipherSuites),
+		&ds.TLSVerifyClient,
+		&ds.TLSHostSNIPolicy,
+		&ds.TLSOCSPStapling,
+	)
+	if err == sql.ErrNoRows {
+		ds.TLSCiphers = nil
+		ds.TLSCipherSuites = nil
+		ds.TLSVerifyClient = nil
+		ds.TLSHostSNIPolicy = nil
+		ds.TLSOCSPStapling = nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("querying: %w", err)
+	}
+	normalizeTLSPolicy(ds)
+	return nil
+}
+
+const insertTLSPolicyQuery = `
+INSERT INTO public.deliveryservice_tls_policy (deliveryservice, ciphers, cipher_suites, verify_client, host_sni_policy, ocsp_stapling)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+// recreateTLSPolicy replaces the TLS policy of the given Delivery Service,
+// which must have an ID, with its TLS policy fields.
+func recreateTLSPolicy(ds *tc.DeliveryServiceV4, tx *sql.Tx) error {
+	if _, err := tx.Exec(`DELETE FROM public.deliveryservice_tls_policy WHERE deliveryservice = $1`, *ds.ID); err != nil {
+		return fmt.Errorf("cleaning up existing TLS policy for DS #%d: %w", *ds.ID, err)
+	}
+	normalizeTLSPolicy(ds)
+	if !ds.HasTLSPolicy() {
+		return nil
+	}
+	if _, err := tx.Exec(insertTLSPolicyQuery, *ds.ID, pq.Array(ds.TLSCiphers), pq.Array(ds.TLSCipherSuites), ds.TLSVerifyClient, ds.TLSHostSNIPolicy, ds.TLSOCSPStapling); err != nil {
+		return fmt.Errorf("inserting new TLS policy: %w", err)
+	}
+	return nil
+}
+
+// normalizeTLSPolicy sets empty cipher lists to nil, as TLSVersions are, so
+// Delivery Services without them look the same whether or not they were
+// ever set.
+func normalizeTLSPolicy(ds *tc.DeliveryServiceV4) {
+	if len(ds.TLSCiphers) < 1 {
+		ds.TLSCiphers = nil
+	}
+	if len(ds.TLSCipherSuites) < 1 {
+		ds.TLSCipherSuites = nil
+	}
+}
@@ -0,0 +1,237 @@
+package dsversion
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	dsserver "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice/servers"
+
+	"github.com/lib/pq"
+)
+
+// Restore is the handler for POST requests to
+// deliveryservices/{{ID}}/versions/{{version}}/restore.
+//
+// The Delivery Service's regular expressions, required capabilities, and
+// server assignments are replaced by the version's, then its properties are
+// updated as by PUT deliveryservices/{{ID}}, which validates the result and
+// records it as a new version. Required capabilities and servers which no
+// longer exist are skipped, with a warning. The SSL keys and URL signing keys
+// in Traffic Vault aren't changed.
+func Restore(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id", "version"}, []string{"id", "version"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	dsID := inf.IntParams["id"]
+	xmlID, cdn, userErr, sysErr, errCode := checkDeliveryService(inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(tx, string(cdn), inf.User.UserName); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(tx, dsID, inf.User.UserName); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	number := inf.IntParams["version"]
+	version, ok, err := getVersion(tx, dsID, number)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("delivery service #%d has no version %d", dsID, number), nil)
+		return
+	}
+
+	data := *version.Data
+	ds := data.DeliveryService
+	ds.ID = &dsID
+	ds.XMLID = (*string)(&xmlID)
+	ds = ds.RemoveLD1AndLD2()
+	if ds.CDNID == nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("version %d of delivery service #%d has no CDN", number, dsID))
+		return
+	}
+
+	alerts := tc.Alerts{}
+	if err := restoreRegexes(tx, dsID, data.Regexes); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	missingCaps, err := restoreRequiredCapabilities(tx, dsID, data.RequiredCapabilities)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if len(missingCaps) > 0 {
+		alerts.AddNewAlert(tc.WarnLevel, "required capabilities which no longer exist were not restored: "+strings.Join(missingCaps, ", "))
+	}
+	missingServers, userErr, sysErr, errCode := restoreServers(tx, ds, data.Servers)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if len(missingServers) > 0 {
+		alerts.AddNewAlert(tc.WarnLevel, "servers which no longer exist in the Delivery Service's CDN were not assigned: "+strings.Join(missingServers, ", "))
+	}
+
+	restored, _, errCode, userErr, sysErr := deliveryservice.UpdateV4(r, inf, &ds)
+	if userErr != nil || sysErr != nil {
+		if userErr != nil {
+			userErr = fmt.Errorf("restoring version %d: %v", number, userErr)
+		}
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+string(xmlID)+", ID: "+strconv.Itoa(dsID)+", ACTION: Restored version "+strconv.Itoa(number), inf.User, tx)
+	alerts.AddNewAlert(tc.SuccessLevel, fmt.Sprintf("Delivery Service '%s' was restored to version %d", xmlID, number))
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, []tc.DeliveryServiceV4{*restored})
+}
+
+// restoreRegexes replaces the regular expressions of the Delivery Service
+// with the given ID.
+func restoreRegexes(tx *sql.Tx, dsID int, regexes []tc.DeliveryServiceMatch) error {
+	if _, err := tx.Exec(`DELETE FROM regex WHERE id IN (SELECT regex FROM deliveryservice_regex WHERE deliveryservice = $1)`, dsID); err != nil {
+		return fmt.Errorf("deleting regexes of delivery service #%d: %v", dsID, err)
+	}
+	for _, re := range regexes {
+		regexID := 0
+		if err := tx.QueryRow(`INSERT INTO regex (type, pattern) VALUES ((SELECT id FROM type WHERE name = $1), $2) RETURNING id`, re.Type.String(), re.Pattern).Scan(&regexID); err != nil {
+			return fmt.Errorf("inserting regex '%s' of delivery service #%d: %v", re.Pattern, dsID, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO deliveryservice_regex (deliveryservice, regex, set_number) VALUES ($1, $2, $3)`, dsID, regexID, re.SetNumber); err != nil {
+			return fmt.Errorf("assigning regex '%s' to delivery service #%d: %v", re.Pattern, dsID, err)
+		}
+	}
+	return nil
+}
+
+// restoreRequiredCapabilities replaces the required capabilities of the
+// Delivery Service with the given ID, and returns the names of those which no
+// longer exist.
+func restoreRequiredCapabilities(tx *sql.Tx, dsID int, capabilities []string) ([]string, error) {
+	if _, err := tx.Exec(`DELETE FROM deliveryservices_required_capability WHERE deliveryservice_id = $1`, dsID); err != nil {
+		return nil, fmt.Errorf("deleting required capabilities of delivery service #%d: %v", dsID, err)
+	}
+	rows, err := tx.Query(`
+INSERT INTO deliveryservices_required_capability (deliveryservice_id, required_capability)
+SELECT $1, name FROM server_capability WHERE name = ANY($2::text[])
+RETURNING required_capability`, dsID, pq.Array(capabilities))
+	if err != nil {
+		return nil, fmt.Errorf("inserting required capabilities of delivery service #%d: %v", dsID, err)
+	}
+	defer log.Close(rows, "closing restored required capability rows")
+
+	restored := map[string]struct{}{}
+	for rows.Next() {
+		name := ""
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scanning required capabilities of delivery service #%d: %v", dsID, err)
+		}
+		restored[name] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("inserting required capabilities of delivery service #%d: %v", dsID, err)
+	}
+
+	missing := []string{}
+	for _, name := range capabilities {
+		if _, ok := restored[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// restoreServers replaces the servers assigned to the given Delivery Service
+// with those of the given host names in its CDN, after checking that they can
+// be assigned to it as it will be once restored. It returns the host names of
+// the servers which no longer exist in its CDN.
+func restoreServers(tx *sql.Tx, ds tc.DeliveryServiceV4, hostNames []string) ([]string, error, error, int) {
+	infos, err := dbhelpers.GetServerInfosFromHostNames(tx, hostNames)
+	if err != nil {
+		return nil, nil, err, http.StatusInternalServerError
+	}
+	servers := make([]tc.ServerInfo, 0, len(infos))
+	ids := make([]int, 0, len(infos))
+	found := map[string]struct{}{}
+	for _, s := range infos {
+		if s.CDNID != *ds.CDNID {
+			continue
+		}
+		servers = append(servers, s)
+		ids = append(ids, s.ID)
+		found[s.HostName] = struct{}{}
+	}
+	missing := []string{}
+	for _, name := range hostNames {
+		if _, ok := found[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	info := dsserver.DSInfo{
+		Active:   ds.Active != nil && *ds.Active,
+		ID:       *ds.ID,
+		Name:     *ds.XMLID,
+		Topology: ds.Topology,
+		CDNID:    ds.CDNID,
+	}
+	if ds.Type != nil {
+		info.Type = *ds.Type
+	}
+	// Topology-based Delivery Services are served by the caches in their
+	// Topology, so they may have no servers assigned at all.
+	if userErr, sysErr, errCode := dsserver.ValidateDSSAssignments(tx, info, servers, ds.Topology == nil); userErr != nil || sysErr != nil {
+		if userErr != nil {
+			userErr = errors.New("restoring server assignments: " + userErr.Error())
+		}
+		return nil, userErr, sysErr, errCode
+	}
+
+	if _, err := tx.Exec(`DELETE FROM deliveryservice_server WHERE deliveryservice = $1`, *ds.ID); err != nil {
+		return nil, nil, fmt.Errorf("deleting servers of delivery service #%d: %v", *ds.ID, err), http.StatusInternalServerError
+	}
+	if _, err := tx.Exec(`INSERT INTO deliveryservice_server (deliveryservice, server) SELECT $1, UNNEST($2::bigint[])`, *ds.ID, pq.Array(ids)); err != nil {
+		return nil, nil, fmt.Errorf("assigning servers to delivery service #%d: %v", *ds.ID, err), http.StatusInternalServerError
+	}
+	return missing, nil, nil, http.StatusOK
+}
@@ -0,0 +1,295 @@
+// Package dsversion provides handlers for the version history of Delivery
+// Services: listing, reading, and comparing the versions recorded when a
+// Delivery Service changes, and restoring a Delivery Service to one of them.
+package dsversion
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+// Versions are numbered per Delivery Service at read time, so concurrent
+// changes to different Delivery Services never contend for a number.
+const selectVersionsQuery = `
+SELECT v.deliveryservice, v.version, v.username, v.created, %s
+FROM (
+	SELECT deliveryservice,
+		row_number() OVER (ORDER BY id) AS version,
+		username,
+		created,
+		data
+	FROM deliveryservice_version
+	WHERE deliveryservice = $1
+) AS v
+`
+
+// Read is the handler for GET requests to deliveryservices/{{ID}}/versions.
+func Read(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	dsID := inf.IntParams["id"]
+	if _, _, userErr, sysErr, errCode := checkDeliveryService(inf, dsID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	versions, err := getVersions(inf.Tx.Tx, dsID, nil, false)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, versions)
+}
+
+// ReadVersion is the handler for GET requests to
+// deliveryservices/{{ID}}/versions/{{version}}.
+func ReadVersion(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id", "version"}, []string{"id", "version"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	dsID := inf.IntParams["id"]
+	if _, _, userErr, sysErr, errCode := checkDeliveryService(inf, dsID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	version, ok, err := getVersion(inf.Tx.Tx, dsID, inf.IntParams["version"])
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, fmt.Errorf("delivery service #%d has no version %d", dsID, inf.IntParams["version"]), nil)
+		return
+	}
+	api.WriteResp(w, r, version)
+}
+
+// Diff is the handler for GET requests to deliveryservices/{{ID}}/versions/diff.
+// The 'to' query parameter defaults to the latest version.
+func Diff(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id", "from"}, []string{"id", "from", "to"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	dsID := inf.IntParams["id"]
+	if _, _, userErr, sysErr, errCode := checkDeliveryService(inf, dsID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	from := inf.IntParams["from"]
+	to, ok := inf.IntParams["to"]
+	if !ok {
+		if err := inf.Tx.Tx.QueryRow(`SELECT count(*) FROM deliveryservice_version WHERE deliveryservice = $1`, dsID).Scan(&to); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("counting versions of delivery service #%d: %v", dsID, err))
+			return
+		}
+	}
+	versions := make([]tc.DeliveryServiceVersion, 0, 2)
+	for _, v := range []int{from, to} {
+		version, ok, err := getVersion(inf.Tx.Tx, dsID, v)
+		if err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+			return
+		} else if !ok {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, fmt.Errorf("delivery service #%d has no version %d", dsID, v), nil)
+			return
+		}
+		versions = append(versions, version)
+	}
+
+	changes, err := diffVersionData(*versions[0].Data, *versions[1].Data)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("comparing versions %d and %d of delivery service #%d: %v", from, to, dsID, err))
+		return
+	}
+	api.WriteResp(w, r, tc.DeliveryServiceVersionDiff{
+		DeliveryServiceID: dsID,
+		From:              from,
+		To:                to,
+		Changes:           changes,
+	})
+}
+
+// checkDeliveryService returns the XMLID and CDN of the Delivery Service with
+// the given ID, or an error if it doesn't exist or the user can't see its
+// tenant.
+func checkDeliveryService(inf *api.APIInfo, dsID int) (tc.DeliveryServiceName, tc.CDNName, error, error, int) {
+	xmlID, cdn, ok, err := dbhelpers.GetDSNameAndCDNFromID(inf.Tx.Tx, dsID)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("getting delivery service #%d: %v", dsID, err), http.StatusInternalServerError
+	} else if !ok {
+		return "", "", fmt.Errorf("no delivery service exists by ID %d", dsID), nil, http.StatusNotFound
+	}
+	if userErr, sysErr, errCode := tenant.Check(inf.User, string(xmlID), inf.Tx.Tx); userErr != nil || sysErr != nil {
+		return "", "", userErr, sysErr, errCode
+	}
+	return xmlID, cdn, nil, nil, http.StatusOK
+}
+
+// getVersions returns the versions of the Delivery Service with the given ID,
+// oldest first, with their data if withData is true. If version isn't nil,
+// only that version is returned.
+func getVersions(tx *sql.Tx, dsID int, version *int, withData bool) ([]tc.DeliveryServiceVersion, error) {
+	dataColumn := "NULL::jsonb"
+	if withData {
+		dataColumn = "v.data"
+	}
+	qry := fmt.Sprintf(selectVersionsQuery, dataColumn)
+	args := []interface{}{dsID}
+	if version != nil {
+		qry += "WHERE v.version = $2\n"
+		args = append(args, *version)
+	}
+	qry += "ORDER BY v.version"
+
+	rows, err := tx.Query(qry, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying versions of delivery service #%d: %v", dsID, err)
+	}
+	defer log.Close(rows, "closing delivery service version rows")
+
+	versions := []tc.DeliveryServiceVersion{}
+	for rows.Next() {
+		v := tc.DeliveryServiceVersion{}
+		data := []byte{}
+		if err := rows.Scan(&v.DeliveryServiceID, &v.Version, &v.Username, &v.Created, &data); err != nil {
+			return nil, fmt.Errorf("scanning versions of delivery service #%d: %v", dsID, err)
+		}
+		if withData {
+			v.Data = &tc.DeliveryServiceVersionData{}
+			if err := json.Unmarshal(data, v.Data); err != nil {
+				return nil, fmt.Errorf("decoding version %d of delivery service #%d: %v", v.Version, dsID, err)
+			}
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// getVersion returns the given version of the Delivery Service with the given
+// ID, and whether it exists.
+func getVersion(tx *sql.Tx, dsID int, version int) (tc.DeliveryServiceVersion, bool, error) {
+	versions, err := getVersions(tx, dsID, &version, true)
+	if err != nil || len(versions) == 0 {
+		return tc.DeliveryServiceVersion{}, false, err
+	}
+	return versions[0], true, nil
+}
+
+// diffVersionData returns the properties which differ between two versions'
+// data, ordered by their paths. Objects are compared property by property;
+// anything else, including arrays, is compared as a whole.
+func diffVersionData(from, to tc.DeliveryServiceVersionData) ([]tc.DeliveryServiceVersionChange, error) {
+	fromObj, err := toJSONObject(from)
+	if err != nil {
+		return nil, err
+	}
+	toObj, err := toJSONObject(to)
+	if err != nil {
+		return nil, err
+	}
+	changes := []tc.DeliveryServiceVersionChange{}
+	if err := diffJSON("", fromObj, toObj, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func toJSONObject(v interface{}) (interface{}, error) {
+	bts, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.New("encoding version data: " + err.Error())
+	}
+	dec := json.NewDecoder(bytes.NewReader(bts))
+	dec.UseNumber()
+	var obj interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, errors.New("decoding version data: " + err.Error())
+	}
+	return obj, nil
+}
+
+func diffJSON(path string, from, to interface{}, changes *[]tc.DeliveryServiceVersionChange) error {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if fromIsMap && toIsMap {
+		keys := make([]string, 0, len(fromMap)+len(toMap))
+		for k := range fromMap {
+			keys = append(keys, k)
+		}
+		for k := range toMap {
+			if _, ok := fromMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			field := k
+			if path != "" {
+				field = path + "." + k
+			}
+			if err := diffJSON(field, fromMap[k], toMap[k], changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+	fromBts, err := json.Marshal(from)
+	if err != nil {
+		return fmt.Errorf("encoding '%s': %v", path, err)
+	}
+	toBts, err := json.Marshal(to)
+	if err != nil {
+		return fmt.Errorf("encoding '%s': %v", path, err)
+	}
+	*changes = append(*changes, tc.DeliveryServiceVersionChange{Field: path, From: fromBts, To: toBts})
+	return nil
+}
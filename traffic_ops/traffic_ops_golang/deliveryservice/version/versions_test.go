@@ -0,0 +1,109 @@
+package dsversion
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestDiffVersionData(t *testing.T) {
+	from := tc.DeliveryServiceVersionData{
+		Regexes:              []tc.DeliveryServiceMatch{{Type: tc.DSMatchTypeHostRegex, Pattern: `.*\.demo1\..*`}},
+		RequiredCapabilities: []string{"disk"},
+		Servers:              []string{"edge1", "edge2"},
+	}
+	from.DeliveryService.XMLID = util.StrPtr("demo1")
+	from.DeliveryService.Active = util.BoolPtr(true)
+	from.DeliveryService.DisplayName = util.StrPtr("Demo 1")
+
+	to := from
+	to.DeliveryService.Active = util.BoolPtr(false)
+	to.DeliveryService.InfoURL = util.StrPtr("https://example.com")
+	to.Servers = []string{"edge1"}
+
+	changes, err := diffVersionData(from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []tc.DeliveryServiceVersionChange{
+		{Field: "deliveryService.active", From: []byte(`true`), To: []byte(`false`)},
+		{Field: "deliveryService.infoUrl", From: []byte(`null`), To: []byte(`"https://example.com"`)},
+		{Field: "servers", From: []byte(`["edge1","edge2"]`), To: []byte(`["edge1"]`)},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+	for i, c := range changes {
+		e := expected[i]
+		if c.Field != e.Field || string(c.From) != string(e.From) || string(c.To) != string(e.To) {
+			t.Errorf("change %d: expected %s: %s -> %s, got %s: %s -> %s", i, e.Field, e.From, e.To, c.Field, c.From, c.To)
+		}
+	}
+
+	if changes, err := diffVersionData(from, from); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if len(changes) != 0 {
+		t.Errorf("expected no changes between identical versions, got %+v", changes)
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	created := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT v.deliveryservice, v.version, v.username, v.created, v.data").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"deliveryservice", "version", "username", "created", "data"}).
+			AddRow(1, 2, "admin", created, []byte(`{"deliveryService":{"xmlId":"demo1"},"regexes":[],"requiredCapabilities":[],"servers":["edge1"]}`)))
+
+	tx, err := mockDB.Begin()
+	if err != nil {
+		t.Fatalf("beginning transaction: %v", err)
+	}
+	version, ok, err := getVersion(tx, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected version to exist")
+	}
+	if version.Version != 2 || version.Username != "admin" {
+		t.Errorf("expected version 2 by admin, got version %d by %s", version.Version, version.Username)
+	}
+	if version.Data == nil || version.Data.DeliveryService.XMLID == nil || *version.Data.DeliveryService.XMLID != "demo1" {
+		t.Errorf("expected data of Delivery Service demo1, got %+v", version.Data)
+	} else if len(version.Data.Servers) != 1 || version.Data.Servers[0] != "edge1" {
+		t.Errorf("expected servers [edge1], got %v", version.Data.Servers)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
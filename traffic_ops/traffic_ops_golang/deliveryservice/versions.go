@@ -0,0 +1,130 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const insertVersionQuery = `
+INSERT INTO deliveryservice_version (deliveryservice, username, data)
+VALUES ($1, $2, $3)
+RETURNING id
+`
+
+const selectVersionServersQuery = `
+SELECT s.host_name
+FROM server AS s
+JOIN deliveryservice_server AS dss ON dss.server = s.id
+WHERE dss.deliveryservice = $1
+ORDER BY s.host_name
+`
+
+// CreateVersion records the current state of the Delivery Service with the
+// given ID - its properties, regular expressions, required capabilities, and
+// server assignments - as a new version made by the given user. It must be
+// called in the transaction that changed the Delivery Service, after the
+// change. It returns the ID of the new version, or an error if the Delivery
+// Service doesn't exist.
+func CreateVersion(tx *sqlx.Tx, dsID int, user *auth.CurrentUser) (int64, error) {
+	data, ok, err := GetVersionData(tx, dsID)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("creating version of delivery service #%d: delivery service not found", dsID)
+	}
+	bts, err := json.Marshal(data)
+	if err != nil {
+		return 0, errors.New("encoding delivery service version: " + err.Error())
+	}
+	var id int64
+	if err := tx.QueryRow(insertVersionQuery, dsID, user.UserName, bts).Scan(&id); err != nil {
+		return 0, fmt.Errorf("inserting version of delivery service #%d: %w", dsID, err)
+	}
+	return id, nil
+}
+
+// GetVersionData returns the current state of the Delivery Service with the
+// given ID, as it is recorded by CreateVersion, and whether it exists.
+func GetVersionData(tx *sqlx.Tx, dsID int) (tc.DeliveryServiceVersionData, bool, error) {
+	data := tc.DeliveryServiceVersionData{
+		Regexes:              []tc.DeliveryServiceMatch{},
+		RequiredCapabilities: []string{},
+		Servers:              []string{},
+	}
+	dses, userErr, sysErr, _ := GetDeliveryServices(SelectDeliveryServicesQuery+" WHERE ds.id = :id", map[string]interface{}{"id": dsID}, tx)
+	if userErr != nil || sysErr != nil {
+		return data, false, fmt.Errorf("getting delivery service #%d: %v", dsID, util.JoinErrs([]error{userErr, sysErr}))
+	}
+	if len(dses) == 0 {
+		return data, false, nil
+	}
+
+	ds := dses[0].RemoveLD1AndLD2()
+	if ds.MatchList != nil {
+		data.Regexes = append(data.Regexes, *ds.MatchList...)
+	}
+	sort.SliceStable(data.Regexes, func(i, j int) bool {
+		a, b := data.Regexes[i], data.Regexes[j]
+		if a.SetNumber != b.SetNumber {
+			return a.SetNumber < b.SetNumber
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Pattern < b.Pattern
+	})
+	ds.MatchList = nil
+	ds.ExampleURLs = nil
+	ds.LastUpdated = nil
+	data.DeliveryService = ds
+
+	caps, err := dbhelpers.GetDSRequiredCapabilitiesFromID(dsID, tx.Tx)
+	if err != nil {
+		return data, false, err
+	}
+	data.RequiredCapabilities = append(data.RequiredCapabilities, caps...)
+
+	rows, err := tx.Query(selectVersionServersQuery, dsID)
+	if err != nil {
+		return data, false, fmt.Errorf("querying servers of delivery service #%d: %w", dsID, err)
+	}
+	defer log.Close(rows, "closing delivery service version server rows")
+	for rows.Next() {
+		hostName := ""
+		if err := rows.Scan(&hostName); err != nil {
+			return data, false, fmt.Errorf("scanning servers of delivery service #%d: %w", dsID, err)
+		}
+		data.Servers = append(data.Servers, hostName)
+	}
+	return data, true, rows.Err()
+}
@@ -0,0 +1,157 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// versionDataArg matches the data of a Delivery Service version, as
+// inserted by CreateVersion.
+type versionDataArg struct {
+	t        *testing.T
+	expected tc.DeliveryServiceVersionData
+}
+
+func (a versionDataArg) Match(v driver.Value) bool {
+	bts, ok := v.([]byte)
+	if !ok {
+		a.t.Errorf("expected version data to be inserted as []byte, actual: %T", v)
+		return false
+	}
+	data := tc.DeliveryServiceVersionData{}
+	if err := json.Unmarshal(bts, &data); err != nil {
+		a.t.Errorf("unmarshalling inserted version data: %v", err)
+		return false
+	}
+	if data.DeliveryService.XMLID == nil || *data.DeliveryService.XMLID != *a.expected.DeliveryService.XMLID {
+		a.t.Errorf("expected version of delivery service '%s', actual: %v", *a.expected.DeliveryService.XMLID, data.DeliveryService.XMLID)
+		return false
+	}
+	if data.DeliveryService.LastUpdated != nil || data.DeliveryService.MatchList != nil {
+		a.t.Errorf("expected version data to omit lastUpdated and matchList from the delivery service")
+		return false
+	}
+	if !reflect.DeepEqual(data.Regexes, a.expected.Regexes) {
+		a.t.Errorf("expected version regexes %+v, actual: %+v", a.expected.Regexes, data.Regexes)
+		return false
+	}
+	if !reflect.DeepEqual(data.RequiredCapabilities, a.expected.RequiredCapabilities) {
+		a.t.Errorf("expected version required capabilities %v, actual: %v", a.expected.RequiredCapabilities, data.RequiredCapabilities)
+		return false
+	}
+	if !reflect.DeepEqual(data.Servers, a.expected.Servers) {
+		a.t.Errorf("expected version servers %v, actual: %v", a.expected.Servers, data.Servers)
+		return false
+	}
+	return true
+}
+
+// expectCreateVersion sets the expectations of CreateVersion recording a
+// version of the Delivery Service of mockDeliveryServiceRows.
+func expectCreateVersion(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("SELECT ds.active").WillReturnRows(mockDeliveryServiceRows())
+	mock.ExpectQuery("SELECT ds.xml_id as ds_name").WillReturnRows(sqlmock.NewRows([]string{"ds_name", "type", "pattern", "set_number"}))
+	mock.ExpectQuery("SELECT required_capability").WillReturnRows(sqlmock.NewRows([]string{"required_capability"}))
+	mock.ExpectQuery("SELECT s.host_name").WillReturnRows(sqlmock.NewRows([]string{"host_name"}))
+	mock.ExpectQuery("INSERT INTO deliveryservice_version").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+func TestCreateVersion(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	xmlID := "demo1"
+	expected := tc.DeliveryServiceVersionData{
+		Regexes: []tc.DeliveryServiceMatch{
+			{Type: "HOST_REGEXP", SetNumber: 0, Pattern: `.*\.demo1\..*`},
+			{Type: "HOST_REGEXP", SetNumber: 1, Pattern: "demo1.example.com"},
+		},
+		RequiredCapabilities: []string{"disk", "mem"},
+		Servers:              []string{"edge1", "edge2"},
+	}
+	expected.DeliveryService.XMLID = &xmlID
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT ds.active").WillReturnRows(mockDeliveryServiceRows())
+	regexRows := sqlmock.NewRows([]string{"ds_name", "type", "pattern", "set_number"})
+	regexRows.AddRow("demo1", "HOST_REGEXP", "demo1.example.com", 1)
+	regexRows.AddRow("demo1", "HOST_REGEXP", `.*\.demo1\..*`, 0)
+	mock.ExpectQuery("SELECT ds.xml_id as ds_name").WillReturnRows(regexRows)
+	mock.ExpectQuery("SELECT required_capability").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"required_capability"}).AddRow("disk").AddRow("mem"))
+	mock.ExpectQuery("SELECT s.host_name").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"host_name"}).AddRow("edge1").AddRow("edge2"))
+	mock.ExpectQuery("INSERT INTO deliveryservice_version").WithArgs(1, "admin", versionDataArg{t: t, expected: expected}).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	tx := db.MustBegin()
+	id, err := CreateVersion(tx, 1, &auth.CurrentUser{UserName: "admin"})
+	if err != nil {
+		t.Fatalf("unexpected error creating version: %v", err)
+	}
+	if id != 1 {
+		t.Errorf("expected version ID 1, actual: %d", id)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("committing: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCreateVersionNotFound(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT ds.active").WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectQuery("SELECT ds.xml_id as ds_name").WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectRollback()
+
+	tx := db.MustBegin()
+	if _, err := CreateVersion(tx, 1, &auth.CurrentUser{UserName: "admin"}); err == nil {
+		t.Error("expected an error creating a version of a nonexistent delivery service, actual: nil")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("rolling back: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
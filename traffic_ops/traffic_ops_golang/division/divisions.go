@@ -57,8 +57,9 @@ func (v *TODivision) NewReadObj() interface{} { return &tc.Division{} }
 func (v *TODivision) SelectQuery() string     { return selectQuery() }
 func (v *TODivision) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
-		"id":   dbhelpers.WhereColumnInfo{Column: "id", Checker: api.IsInt},
-		"name": dbhelpers.WhereColumnInfo{Column: "name"},
+		"lastUpdated": dbhelpers.WhereColumnInfo{Column: "last_updated", Checker: api.IsTime},
+		"id":          dbhelpers.WhereColumnInfo{Column: "id", Checker: api.IsInt},
+		"name":        dbhelpers.WhereColumnInfo{Column: "name"},
 	}
 }
 func (v *TODivision) UpdateQuery() string { return updateQuery() }
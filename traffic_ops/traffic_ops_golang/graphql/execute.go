@@ -0,0 +1,754 @@
+package graphql
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+const (
+	// MaxDepth is the maximum number of levels of nested fields of a query.
+	MaxDepth = 8
+	// MaxCost is the maximum estimated cost of a query, which is the number
+	// of requests of the Traffic Ops API it's estimated to make.
+	MaxCost = 200
+	// MaxRequests is the maximum number of requests of the Traffic Ops API
+	// which a query may actually make. Fields which would need more are null.
+	MaxRequests = 200
+	// DefaultListSize is the number of objects a list field is estimated to
+	// have, when estimating the cost of a query, unless it has a "limit"
+	// argument.
+	DefaultListSize = 10
+
+	// batchSize is the maximum number of keys of related objects fetched by
+	// a single request.
+	batchSize = 100
+)
+
+// LimitArgument is the argument which limits the number of objects of a list
+// field.
+const LimitArgument = "limit"
+
+// relationArgumentsIgnored are the arguments of API endpoints which can't be
+// arguments of relations, because the objects of a relation may be fetched
+// for many objects at once.
+var relationArgumentsIgnored = []string{"offset", "page", "cursor"}
+
+// Fetcher makes a GET request of the given path of the Traffic Ops API,
+// relative to its version 4 root, with the given query parameters, and
+// returns the status code and body of the response. An error is returned only
+// if the request couldn't be made at all.
+type Fetcher func(path string, query url.Values) (int, []byte, error)
+
+// object is an object in a response of the Traffic Ops API.
+type object map[string]json.RawMessage
+
+// orderedObject is an object in a GraphQL response, whose keys are in the
+// order of the fields of the query.
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedObject() *orderedObject {
+	return &orderedObject{values: map[string]interface{}{}}
+}
+
+func (o *orderedObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface.
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// collectedField is a field of a response object, which may be selected by
+// more than one field of a query.
+type collectedField struct {
+	key    string
+	fields []*field
+}
+
+func (c collectedField) name() string {
+	return c.fields[0].name
+}
+
+// selections returns the selections of every field which selects c.
+func (c collectedField) selections() []selection {
+	selections := []selection{}
+	for _, f := range c.fields {
+		selections = append(selections, f.selections...)
+	}
+	return selections
+}
+
+// target is an object of the API and the object of the GraphQL response
+// which represents it.
+type target struct {
+	obj  object
+	out  *orderedObject
+	path []interface{}
+}
+
+type fetchResult struct {
+	objs []object
+	ok   bool
+}
+
+type executor struct {
+	doc       *document
+	vars      map[string]interface{}
+	fetch     Fetcher
+	requests  int
+	exhausted bool
+	fetched   map[string]fetchResult
+	errors    []tc.GraphQLError
+}
+
+// Execute executes the given GraphQL request, fetching every object from the
+// Traffic Ops API with fetch. If the request can't be executed at all,
+// because it's invalid or its estimated cost is too high, the Data of the
+// response is nil.
+func Execute(req tc.GraphQLRequest, fetch Fetcher) tc.GraphQLResponse {
+	doc, err := parse(req.Query)
+	if err != nil {
+		resp := tc.GraphQLResponse{Errors: []tc.GraphQLError{{Message: err.Error()}}}
+		if serr, ok := err.(syntaxError); ok {
+			resp.Errors[0].Locations = []tc.GraphQLLocation{serr.loc}
+		}
+		return resp
+	}
+	e := &executor{doc: doc, fetch: fetch, fetched: map[string]fetchResult{}}
+
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return tc.GraphQLResponse{Errors: []tc.GraphQLError{{Message: err.Error()}}}
+	}
+	e.vars = e.coerceVariables(op, req.Variables)
+	if len(e.errors) > 0 {
+		return tc.GraphQLResponse{Errors: e.errors}
+	}
+	cost := e.validateQuery(op.selections)
+	if len(e.errors) > 0 {
+		return tc.GraphQLResponse{Errors: e.errors}
+	}
+	if cost > MaxCost {
+		return tc.GraphQLResponse{Errors: []tc.GraphQLError{{
+			Message:   fmt.Sprintf("the estimated cost of the query is more than the maximum of %d", MaxCost),
+			Locations: []tc.GraphQLLocation{op.loc},
+		}}}
+	}
+
+	data, err := json.Marshal(e.executeQuery(op.selections))
+	if err != nil {
+		log.Errorf("marshalling GraphQL response data: %v", err)
+		return tc.GraphQLResponse{Errors: []tc.GraphQLError{{Message: http.StatusText(http.StatusInternalServerError)}}}
+	}
+	return tc.GraphQLResponse{Data: data, Errors: e.errors}
+}
+
+// operation returns the operation of the document with the given name, or
+// its only operation if name is empty.
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, fmt.Errorf("an operationName is required, because the query has more than one operation")
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("the query has no operation named '%s'", name)
+}
+
+func (e *executor) addError(msg string, loc tc.GraphQLLocation, path []interface{}) {
+	e.errors = append(e.errors, tc.GraphQLError{Message: msg, Locations: []tc.GraphQLLocation{loc}, Path: path})
+}
+
+// coerceVariables returns the values of the variables of the given operation,
+// from the given values and their defaults.
+func (e *executor) coerceVariables(op *operation, values map[string]interface{}) map[string]interface{} {
+	vars := map[string]interface{}{}
+	for _, def := range op.variables {
+		if _, ok := vars[def.name]; ok {
+			e.addError("there is more than one variable named '$"+def.name+"'", def.loc, nil)
+			continue
+		}
+		v, ok := values[def.name]
+		if !ok && def.defaultValue != nil {
+			v = def.defaultValue.resolve(nil)
+		}
+		if def.nonNull && v == nil {
+			e.addError("variable '$"+def.name+"' is required", def.loc, nil)
+			continue
+		}
+		vars[def.name] = v
+	}
+	return vars
+}
+
+// collectFields returns the fields of a response object selected by the
+// given selections of an object of the named type, with fragments expanded
+// and the @skip and @include directives applied, in order.
+func (e *executor) collectFields(typeName string, selections []selection) ([]collectedField, error) {
+	collected := []collectedField{}
+	indices := map[string]int{}
+	var collect func([]selection, map[string]bool) error
+	collect = func(selections []selection, visited map[string]bool) error {
+		for _, sel := range selections {
+			switch sel := sel.(type) {
+			case *field:
+				if include, err := e.included(sel.directives); err != nil || !include {
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				i, ok := indices[sel.key()]
+				if !ok {
+					indices[sel.key()] = len(collected)
+					collected = append(collected, collectedField{key: sel.key(), fields: []*field{sel}})
+					continue
+				}
+				if collected[i].name() != sel.name {
+					return syntaxError{fmt.Sprintf("'%s' is the key of both '%s' and '%s'; use an alias for one of them", sel.key(), collected[i].name(), sel.name), sel.loc}
+				}
+				collected[i].fields = append(collected[i].fields, sel)
+			case *fragmentSpread:
+				if include, err := e.included(sel.directives); err != nil || !include {
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				if visited[sel.name] {
+					continue
+				}
+				visited[sel.name] = true
+				frag, ok := e.doc.fragments[sel.name]
+				if !ok {
+					return syntaxError{"the query has no fragment named '" + sel.name + "'", sel.loc}
+				}
+				if frag.typeCondition != typeName {
+					return syntaxError{fmt.Sprintf("fragment '%s' on type '%s' can't be spread in type '%s'", frag.name, frag.typeCondition, typeName), sel.loc}
+				}
+				if err := collect(frag.selections, visited); err != nil {
+					return err
+				}
+			case *inlineFragment:
+				if include, err := e.included(sel.directives); err != nil || !include {
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				if sel.typeCondition != "" && sel.typeCondition != typeName {
+					return syntaxError{fmt.Sprintf("a fragment on type '%s' can't be spread in type '%s'", sel.typeCondition, typeName), sel.loc}
+				}
+				if err := collect(sel.selections, visited); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	err := collect(selections, map[string]bool{})
+	return collected, err
+}
+
+// included returns whether a selection with the given directives is included
+// in the response.
+func (e *executor) included(directives []directive) (bool, error) {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			return false, syntaxError{"unknown directive '@" + d.name + "'", d.loc}
+		}
+		if len(d.arguments) != 1 || d.arguments[0].name != "if" {
+			return false, syntaxError{"directive '@" + d.name + "' requires exactly one argument, 'if'", d.loc}
+		}
+		cond, ok := d.arguments[0].value.resolve(e.vars).(bool)
+		if !ok {
+			return false, syntaxError{"the 'if' argument of directive '@" + d.name + "' must be a Boolean", d.loc}
+		}
+		if cond == (d.name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// arguments returns the arguments of a field as query parameters of a request
+// of the Traffic Ops API. A list argument is a filter by any of its values.
+func (e *executor) arguments(f *field) (url.Values, error) {
+	query := url.Values{}
+	for _, arg := range f.arguments {
+		for _, name := range arg.value.variables() {
+			if _, ok := e.vars[name]; !ok {
+				return nil, syntaxError{"variable '$" + name + "' isn't defined", arg.value.loc}
+			}
+		}
+		v := arg.value.resolve(e.vars)
+		if v == nil {
+			continue
+		}
+		if list, ok := v.([]interface{}); ok {
+			values := make([]string, 0, len(list))
+			for _, elem := range list {
+				s, ok := scalarString(elem)
+				if !ok {
+					return nil, syntaxError{"argument '" + arg.name + "' must be a scalar, or a list of scalars", arg.value.loc}
+				}
+				values = append(values, s)
+			}
+			query.Set(arg.name+"|", strings.Join(values, ","))
+			continue
+		}
+		s, ok := scalarString(v)
+		if !ok {
+			return nil, syntaxError{"argument '" + arg.name + "' must be a scalar, or a list of scalars", arg.value.loc}
+		}
+		query.Set(arg.name, s)
+	}
+	return query, nil
+}
+
+// scalarString returns the given scalar value as a query parameter value, and
+// whether it's a scalar.
+func scalarString(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// relationArguments returns the query parameters of the requests of a
+// relation field, and the maximum number of objects of the field for each
+// object, or -1 if there's no maximum.
+func (e *executor) relationArguments(f *field) (url.Values, int, error) {
+	query, err := e.arguments(f)
+	if err != nil {
+		return nil, -1, err
+	}
+	for _, name := range relationArgumentsIgnored {
+		if _, ok := query[name]; ok {
+			return nil, -1, syntaxError{"argument '" + name + "' isn't supported by relations", f.loc}
+		}
+	}
+	limit := -1
+	if l, ok := query[LimitArgument]; ok {
+		if limit, err = strconv.Atoi(l[0]); err != nil || limit < 0 {
+			return nil, -1, syntaxError{"argument '" + LimitArgument + "' must be a non-negative integer", f.loc}
+		}
+		query.Del(LimitArgument)
+	}
+	return query, limit, nil
+}
+
+// listSize returns the number of objects a list field is estimated to have.
+func listSize(query url.Values, limit int) int {
+	if limit >= 0 {
+		return limit
+	}
+	if l, err := strconv.Atoi(query.Get(LimitArgument)); err == nil && l >= 0 {
+		return l
+	}
+	return DefaultListSize
+}
+
+// validateQuery checks that the given selections of the query type are
+// valid, and returns the estimated cost of executing them.
+func (e *executor) validateQuery(selections []selection) int {
+	fields, err := e.collectFields("Query", selections)
+	if err != nil {
+		e.addError(err.(syntaxError).msg, err.(syntaxError).loc, nil)
+		return 0
+	}
+	cost := 0
+	for _, cf := range fields {
+		f := cf.fields[0]
+		if cf.name() == TypeNameField {
+			continue
+		}
+		root, ok := rootFields[cf.name()]
+		if !ok {
+			e.addError("the query type has no field named '"+cf.name()+"'", f.loc, nil)
+			continue
+		}
+		query, err := e.arguments(f)
+		if err != nil {
+			e.addError(err.(syntaxError).msg, err.(syntaxError).loc, nil)
+			continue
+		}
+		cost += 1 + e.validateObject(types[root.typ], cf, 2, listSize(query, -1))
+	}
+	return cost
+}
+
+// validateObject checks that the selections of the given field, which is an
+// object of type t at the given depth, are valid, and returns the estimated
+// cost of executing them for the estimated number of objects.
+func (e *executor) validateObject(t *objectType, parent collectedField, depth int, objects int) int {
+	selections := parent.selections()
+	if len(selections) == 0 {
+		e.addError("field '"+parent.key+"' of type '"+t.name+"' must have a selection of subfields", parent.fields[0].loc, nil)
+		return 0
+	}
+	if depth > MaxDepth {
+		e.addError(fmt.Sprintf("the query is more than %d levels deep", MaxDepth), parent.fields[0].loc, nil)
+		return 0
+	}
+	fields, err := e.collectFields(t.name, selections)
+	if err != nil {
+		e.addError(err.(syntaxError).msg, err.(syntaxError).loc, nil)
+		return 0
+	}
+	cost := 0
+	for _, cf := range fields {
+		f := cf.fields[0]
+		if _, ok := t.scalars[cf.name()]; ok || cf.name() == TypeNameField {
+			if len(cf.selections()) > 0 {
+				e.addError("field '"+cf.name()+"' of type '"+t.name+"' has no subfields", f.loc, nil)
+			}
+			if len(f.arguments) > 0 {
+				e.addError("field '"+cf.name()+"' of type '"+t.name+"' has no arguments", f.loc, nil)
+			}
+			continue
+		}
+		rel, ok := t.relations[cf.name()]
+		if !ok {
+			e.addError("type '"+t.name+"' has no field named '"+cf.name()+"'", f.loc, nil)
+			continue
+		}
+		query, limit, err := e.relationArguments(f)
+		if err != nil {
+			e.addError(err.(syntaxError).msg, err.(syntaxError).loc, nil)
+			continue
+		}
+		related := objects
+		if rel.list {
+			related = capCost(objects * listSize(query, limit))
+		}
+		if rel.batched() {
+			cost += (objects + batchSize - 1) / batchSize
+		} else {
+			cost += objects
+		}
+		cost = capCost(cost + e.validateObject(types[rel.typ], cf, depth+1, related))
+	}
+	return cost
+}
+
+// capCost limits an estimated cost to just more than the maximum, so that
+// the estimates of deep queries can't overflow.
+func capCost(cost int) int {
+	if cost > MaxCost || cost < 0 {
+		return MaxCost + 1
+	}
+	return cost
+}
+
+// executeQuery executes the given selections of the query type, which have
+// been validated.
+func (e *executor) executeQuery(selections []selection) *orderedObject {
+	data := newOrderedObject()
+	fields, _ := e.collectFields("Query", selections)
+	for _, cf := range fields {
+		if cf.name() == TypeNameField {
+			data.set(cf.key, "Query")
+			continue
+		}
+		root := rootFields[cf.name()]
+		query, _ := e.arguments(cf.fields[0])
+		path := []interface{}{cf.key}
+		objs, ok := e.get(root.path, query, cf.fields[0], path)
+		if !ok {
+			data.set(cf.key, nil)
+			continue
+		}
+		list := make([]interface{}, 0, len(objs))
+		targets := make([]target, 0, len(objs))
+		for i, obj := range objs {
+			out := newOrderedObject()
+			list = append(list, out)
+			targets = append(targets, target{obj: obj, out: out, path: appendPath(path, i)})
+		}
+		data.set(cf.key, list)
+		e.resolve(types[root.typ], cf.selections(), targets)
+	}
+	return data
+}
+
+// resolve sets the fields selected by the given selections of every target,
+// which are objects of type t.
+func (e *executor) resolve(t *objectType, selections []selection, targets []target) {
+	if len(targets) == 0 {
+		return
+	}
+	fields, _ := e.collectFields(t.name, selections)
+	for _, cf := range fields {
+		if cf.name() == TypeNameField {
+			for _, tgt := range targets {
+				tgt.out.set(cf.key, t.name)
+			}
+			continue
+		}
+		if _, ok := t.scalars[cf.name()]; ok {
+			for _, tgt := range targets {
+				if v, ok := tgt.obj[cf.name()]; ok {
+					tgt.out.set(cf.key, v)
+				} else {
+					tgt.out.set(cf.key, nil)
+				}
+			}
+			continue
+		}
+		e.resolveRelation(t.relations[cf.name()], cf, targets)
+	}
+}
+
+// resolveRelation sets the given relation field of every target.
+func (e *executor) resolveRelation(rel relation, cf collectedField, targets []target) {
+	f := cf.fields[0]
+	query, limit, _ := e.relationArguments(f)
+	related := make([][]object, len(targets))
+	ok := make([]bool, len(targets))
+
+	if rel.batched() {
+		keys := []string{}
+		first := map[string]int{}
+		for i, tgt := range targets {
+			key := keyString(tgt.obj[rel.key])
+			if key == "" {
+				ok[i] = true
+				continue
+			}
+			if _, seen := first[key]; !seen {
+				first[key] = i
+				keys = append(keys, key)
+			}
+		}
+		matches := map[string][]object{}
+		fetched := map[string]bool{}
+		for start := 0; start < len(keys); start += batchSize {
+			end := start + batchSize
+			if end > len(keys) {
+				end = len(keys)
+			}
+			batchQuery := url.Values{}
+			for k, v := range query {
+				batchQuery[k] = v
+			}
+			batchQuery.Set(rel.param+"|", strings.Join(keys[start:end], ","))
+			objs, batchOK := e.get(rel.path, batchQuery, f, appendPath(targets[first[keys[start]]].path, cf.key))
+			if !batchOK {
+				continue
+			}
+			for _, key := range keys[start:end] {
+				fetched[key] = true
+			}
+			for _, obj := range objs {
+				match := keyString(obj[rel.match])
+				matches[match] = append(matches[match], obj)
+			}
+		}
+		for i, tgt := range targets {
+			if key := keyString(tgt.obj[rel.key]); key != "" && fetched[key] {
+				related[i], ok[i] = matches[key], true
+			}
+		}
+	} else {
+		for i, tgt := range targets {
+			key := keyString(tgt.obj[rel.key])
+			if key == "" {
+				ok[i] = true
+				continue
+			}
+			path := strings.Replace(rel.path, "{}", url.PathEscape(key), 1)
+			parentQuery := url.Values{}
+			for k, v := range query {
+				parentQuery[k] = v
+			}
+			if rel.pathParam != "" {
+				parentQuery.Set(rel.pathParam, key)
+			}
+			related[i], ok[i] = e.get(path, parentQuery, f, appendPath(tgt.path, cf.key))
+		}
+	}
+
+	children := []target{}
+	for i, tgt := range targets {
+		objs := related[i]
+		if !ok[i] || (!rel.list && len(objs) == 0) {
+			tgt.out.set(cf.key, nil)
+			continue
+		}
+		if limit >= 0 && len(objs) > limit {
+			objs = objs[:limit]
+		}
+		if !rel.list {
+			out := newOrderedObject()
+			tgt.out.set(cf.key, out)
+			children = append(children, target{obj: objs[0], out: out, path: appendPath(tgt.path, cf.key)})
+			continue
+		}
+		list := make([]interface{}, 0, len(objs))
+		for j, obj := range objs {
+			out := newOrderedObject()
+			list = append(list, out)
+			children = append(children, target{obj: obj, out: out, path: appendPath(tgt.path, cf.key, j)})
+		}
+		tgt.out.set(cf.key, list)
+	}
+	e.resolve(types[rel.typ], cf.selections(), children)
+}
+
+// get returns the objects in the response to a GET request of the given path
+// with the given query parameters, and whether they could be fetched. If they
+// couldn't, an error for the given field at the given path is added.
+func (e *executor) get(path string, query url.Values, f *field, responsePath []interface{}) ([]object, bool) {
+	id := path + "?" + query.Encode()
+	if result, ok := e.fetched[id]; ok {
+		return result.objs, result.ok
+	}
+	objs, err := e.getUncached(path, query)
+	if err != nil && err != errRequestLimitReported {
+		e.addError(err.Error(), f.loc, responsePath)
+	}
+	if err != errRequestLimit && err != errRequestLimitReported {
+		e.fetched[id] = fetchResult{objs: objs, ok: err == nil}
+	}
+	return objs, err == nil
+}
+
+var errRequestLimit = fmt.Errorf("the query needs more than the maximum of %d requests of the Traffic Ops API", MaxRequests)
+
+// errRequestLimitReported is returned instead of errRequestLimit once it's
+// been reported, so that it's only reported once.
+var errRequestLimitReported = errors.New("request limit reported")
+
+func (e *executor) getUncached(path string, query url.Values) ([]object, error) {
+	if e.requests >= MaxRequests {
+		if e.exhausted {
+			return nil, errRequestLimitReported
+		}
+		e.exhausted = true
+		return nil, errRequestLimit
+	}
+	e.requests++
+
+	status, body, err := e.fetch(path, query)
+	if err != nil {
+		log.Errorf("GraphQL request of %s: %v", path, err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+	resp := struct {
+		Response json.RawMessage `json:"response"`
+		tc.Alerts
+	}{}
+	if err := json.Unmarshal(body, &resp); err != nil && status == http.StatusOK {
+		log.Errorf("GraphQL request of %s: decoding response: %v", path, err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+	if status != http.StatusOK {
+		msgs := []string{}
+		for _, alert := range resp.Alerts.Alerts {
+			if alert.Level == tc.ErrorLevel.String() {
+				msgs = append(msgs, alert.Text)
+			}
+		}
+		if len(msgs) == 0 {
+			msgs = append(msgs, http.StatusText(status))
+		}
+		return nil, fmt.Errorf("%s: %s", path, strings.Join(msgs, "; "))
+	}
+	objs := []object{}
+	if len(resp.Response) == 0 || bytes.Equal(bytes.TrimSpace(resp.Response), []byte("null")) {
+		return objs, nil
+	}
+	if err := json.Unmarshal(resp.Response, &objs); err != nil {
+		log.Errorf("GraphQL request of %s: decoding response objects: %v", path, err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+	return objs, nil
+}
+
+// keyString returns the given JSON string or number as a string, or an
+// empty string if it's null or missing.
+func keyString(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return ""
+	}
+	s := ""
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// appendPath returns a copy of the given response path, with the given keys
+// and indices appended.
+func appendPath(path []interface{}, elems ...interface{}) []interface{} {
+	p := make([]interface{}, 0, len(path)+len(elems))
+	return append(append(p, path...), elems...)
+}
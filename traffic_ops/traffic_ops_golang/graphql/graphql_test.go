@@ -0,0 +1,206 @@
+package graphql
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// testFetcher is a Fetcher of fixed responses, which records the requests
+// made of it.
+type testFetcher struct {
+	responses map[string]string
+	requests  []string
+}
+
+func (f *testFetcher) fetch(path string, query url.Values) (int, []byte, error) {
+	request := path
+	if len(query) > 0 {
+		request += "?" + query.Encode()
+	}
+	f.requests = append(f.requests, request)
+	if resp, ok := f.responses[request]; ok {
+		return http.StatusOK, []byte(resp), nil
+	}
+	return http.StatusForbidden, []byte(`{"alerts":[{"text":"forbidden","level":"error"}]}`), nil
+}
+
+func newTestFetcher() *testFetcher {
+	return &testFetcher{responses: map[string]string{
+		"servers":                `{"response":[{"id":1,"hostName":"edge1","cachegroupId":10,"profileId":100},{"id":2,"hostName":"edge2","cachegroupId":10,"profileId":null},{"id":3,"hostName":"mid1","cachegroupId":11,"profileId":100}]}`,
+		"servers?hostName=edge1": `{"response":[{"id":1,"hostName":"edge1","cachegroupId":10,"profileId":100}]}`,
+		"cachegroups?" + url.Values{"id|": {"10,11"}}.Encode(): `{"response":[{"id":10,"name":"edge-cg","parentCachegroupId":11},{"id":11,"name":"mid-cg","parentCachegroupId":null}]}`,
+		"cachegroups?" + url.Values{"id|": {"11"}}.Encode():    `{"response":[{"id":11,"name":"mid-cg","parentCachegroupId":null}]}`,
+		"profiles?" + url.Values{"id|": {"100"}}.Encode():      `{"response":[{"id":100,"name":"EDGE"}]}`,
+		"profiles/100/parameters":                              `{"response":[{"id":1000,"name":"foo","value":"bar"},{"id":1001,"name":"baz","value":"qux"}]}`,
+		"servers/1/deliveryservices":                           `{"response":[{"id":7,"xmlId":"ds1"}]}`,
+	}}
+}
+
+func TestExecute(t *testing.T) {
+	f := newTestFetcher()
+	query := `
+		query Servers($withProfile: Boolean = true) {
+			servers {
+				hostName
+				cg: cachegroup { ...cg }
+				profile @include(if: $withProfile) {
+					name
+					parameters(limit: 1) { name value }
+				}
+				__typename
+			}
+		}
+		fragment cg on CacheGroup {
+			name
+			parent { name }
+		}`
+	resp := Execute(tc.GraphQLRequest{Query: query}, f.fetch)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("expected no errors, actual: %+v", resp.Errors)
+	}
+	expected := `{"servers":[` +
+		`{"hostName":"edge1","cg":{"name":"edge-cg","parent":{"name":"mid-cg"}},"profile":{"name":"EDGE","parameters":[{"name":"foo","value":"bar"}]},"__typename":"Server"},` +
+		`{"hostName":"edge2","cg":{"name":"edge-cg","parent":{"name":"mid-cg"}},"profile":null,"__typename":"Server"},` +
+		`{"hostName":"mid1","cg":{"name":"mid-cg","parent":null},"profile":{"name":"EDGE","parameters":[{"name":"foo","value":"bar"}]},"__typename":"Server"}]}`
+	if string(resp.Data) != expected {
+		t.Errorf("expected data %s, actual: %s", expected, resp.Data)
+	}
+	// servers, their cachegroups in one request, those cachegroups' parents,
+	// their profiles in one request, and each profile's parameters once
+	if len(f.requests) != 5 {
+		t.Errorf("expected 5 requests, actual: %v", f.requests)
+	}
+
+	f = newTestFetcher()
+	resp = Execute(tc.GraphQLRequest{
+		Query:     `query($withProfile: Boolean!) { servers { hostName profile @include(if: $withProfile) { name } } }`,
+		Variables: map[string]interface{}{"withProfile": false},
+	}, f.fetch)
+	if expected := `{"servers":[{"hostName":"edge1"},{"hostName":"edge2"},{"hostName":"mid1"}]}`; string(resp.Data) != expected {
+		t.Errorf("expected data %s, actual: %s (%+v)", expected, resp.Data, resp.Errors)
+	}
+}
+
+func TestExecuteArguments(t *testing.T) {
+	f := newTestFetcher()
+	resp := Execute(tc.GraphQLRequest{
+		Query:     `query($host: String) { servers(hostName: $host) { id deliveryServices { xmlId } } }`,
+		Variables: map[string]interface{}{"host": "edge1"},
+	}, f.fetch)
+	if expected := `{"servers":[{"id":1,"deliveryServices":[{"xmlId":"ds1"}]}]}`; string(resp.Data) != expected {
+		t.Errorf("expected data %s, actual: %s (%+v)", expected, resp.Data, resp.Errors)
+	}
+
+	f = newTestFetcher()
+	Execute(tc.GraphQLRequest{Query: `{ cacheGroups(id: [10, 11], limit: 2) { name } }`}, f.fetch)
+	if expected := []string{"cachegroups?" + url.Values{"id|": {"10,11"}, "limit": {"2"}}.Encode()}; !reflect.DeepEqual(f.requests, expected) {
+		t.Errorf("expected requests %v, actual: %v", expected, f.requests)
+	}
+}
+
+func TestExecuteFieldErrors(t *testing.T) {
+	f := newTestFetcher()
+	resp := Execute(tc.GraphQLRequest{Query: `{ servers { id deliveryServices { xmlId } } }`}, f.fetch)
+	if resp.Data == nil {
+		t.Fatalf("expected data despite field errors, actual errors: %+v", resp.Errors)
+	}
+	expected := `{"servers":[{"id":1,"deliveryServices":[{"xmlId":"ds1"}]},{"id":2,"deliveryServices":null},{"id":3,"deliveryServices":null}]}`
+	if string(resp.Data) != expected {
+		t.Errorf("expected data %s, actual: %s", expected, resp.Data)
+	}
+	if len(resp.Errors) != 2 {
+		t.Fatalf("expected 2 errors, actual: %+v", resp.Errors)
+	}
+	if expected := []interface{}{"servers", 1, "deliveryServices"}; !reflect.DeepEqual(resp.Errors[0].Path, expected) {
+		t.Errorf("expected error path %v, actual: %v", expected, resp.Errors[0].Path)
+	}
+	if !strings.Contains(resp.Errors[0].Message, "forbidden") {
+		t.Errorf("expected the error to have the alert text 'forbidden', actual: %s", resp.Errors[0].Message)
+	}
+}
+
+func TestExecuteInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"syntax error", `{ servers { id }`, "syntax error at 1:17"},
+		{"mutation", `mutation { servers { id } }`, "only queries are supported"},
+		{"unknown root field", `{ users { id } }`, "no field named 'users'"},
+		{"unknown field", `{ servers { nope } }`, "no field named 'nope'"},
+		{"missing subfields", `{ servers { cachegroup } }`, "must have a selection of subfields"},
+		{"subfields of a scalar", `{ servers { hostName { id } } }`, "has no subfields"},
+		{"unknown fragment", `{ servers { ...nope } }`, "no fragment named 'nope'"},
+		{"fragment on the wrong type", `{ servers { ...cg } } fragment cg on CacheGroup { name }`, "can't be spread"},
+		{"conflicting keys", `{ servers { id: hostName id } }`, "use an alias"},
+		{"missing variable", `query($id: Int!) { servers(id: $id) { id } }`, "'$id' is required"},
+		{"undefined variable", `{ servers(id: $id) { id } }`, "'$id' isn't defined"},
+		{"relation offset", `{ servers { deliveryServices(offset: 1) { id } } }`, "isn't supported by relations"},
+		{"too deep", `{ cacheGroups(limit: 1) { parent { parent { parent { parent { parent { parent { parent { name } } } } } } } } }`, "levels deep"},
+		{"too costly", `{ servers(limit: 500) { deliveryServices { id } } }`, "estimated cost"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newTestFetcher()
+			resp := Execute(tc.GraphQLRequest{Query: test.query}, f.fetch)
+			if resp.Data != nil {
+				t.Errorf("expected no data, actual: %s", resp.Data)
+			}
+			if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, test.expected) {
+				t.Errorf("expected an error containing '%s', actual: %+v", test.expected, resp.Errors)
+			}
+			if len(f.requests) > 0 {
+				t.Errorf("expected no requests of an invalid query, actual: %v", f.requests)
+			}
+		})
+	}
+}
+
+func TestExecuteRequestLimit(t *testing.T) {
+	servers := []string{}
+	for i := 0; i < MaxRequests+10; i++ {
+		servers = append(servers, `{"id":`+strconv.Itoa(i)+`}`)
+	}
+	f := &testFetcher{responses: map[string]string{
+		"servers?limit=10": `{"response":[` + strings.Join(servers, ",") + `]}`,
+	}}
+	resp := Execute(tc.GraphQLRequest{Query: `{ servers(limit: 10) { deliveryServices { id } } }`}, f.fetch)
+	if len(f.requests) != MaxRequests {
+		t.Errorf("expected %d requests, actual: %d", MaxRequests, len(f.requests))
+	}
+	limitErrors := 0
+	for _, err := range resp.Errors {
+		if strings.Contains(err.Message, "maximum of") {
+			limitErrors++
+		}
+	}
+	if limitErrors != 1 {
+		t.Errorf("expected the request limit to be reported once, actual: %d", limitErrors)
+	}
+}
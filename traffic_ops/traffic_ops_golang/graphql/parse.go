@@ -0,0 +1,739 @@
+package graphql
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// This file parses the subset of GraphQL executable documents which Traffic
+// Ops supports: queries, with fields, aliases, arguments, variables,
+// fragments, and the @include and @skip directives.
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	loc   tc.GraphQLLocation
+}
+
+// syntaxError is an error in a GraphQL document, at a location.
+type syntaxError struct {
+	msg string
+	loc tc.GraphQLLocation
+}
+
+func (e syntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %s", e.loc.Line, e.loc.Column, e.msg)
+}
+
+// lex splits a GraphQL document into tokens, ignoring whitespace, commas, and
+// comments.
+func lex(src string) ([]token, error) {
+	tokens := []token{}
+	line, lineStart := 1, 0
+	for i := 0; i < len(src); {
+		loc := tc.GraphQLLocation{Line: line, Column: i - lineStart + 1}
+		c := src[i]
+		switch {
+		case c == '\n':
+			i++
+			line, lineStart = line+1, i
+		case c == '\r':
+			i++
+			if i < len(src) && src[i] == '\n' {
+				i++
+			}
+			line, lineStart = line+1, i
+		case c == ' ' || c == '\t' || c == ',':
+			i++
+		case strings.HasPrefix(src[i:], "\uFEFF"):
+			i += len("\uFEFF")
+		case c == '#':
+			for i < len(src) && src[i] != '\n' && src[i] != '\r' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "..."):
+			tokens = append(tokens, token{kind: tokenPunctuator, value: "...", loc: loc})
+			i += 3
+		case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+			tokens = append(tokens, token{kind: tokenPunctuator, value: string(c), loc: loc})
+			i++
+		case c == '_' || isLetter(c):
+			start := i
+			for i < len(src) && (src[i] == '_' || isLetter(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenName, value: src[start:i], loc: loc})
+		case c == '-' || isDigit(c):
+			start := i
+			kind := tokenInt
+			if c == '-' {
+				i++
+			}
+			digits := i
+			for i < len(src) && isDigit(src[i]) {
+				i++
+			}
+			if i == digits {
+				return nil, syntaxError{"invalid number", loc}
+			}
+			if i < len(src) && src[i] == '.' {
+				kind = tokenFloat
+				i++
+				fraction := i
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+				if i == fraction {
+					return nil, syntaxError{"invalid number", loc}
+				}
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				kind = tokenFloat
+				i++
+				if i < len(src) && (src[i] == '+' || src[i] == '-') {
+					i++
+				}
+				exponent := i
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+				if i == exponent {
+					return nil, syntaxError{"invalid number", loc}
+				}
+			}
+			tokens = append(tokens, token{kind: kind, value: src[start:i], loc: loc})
+		case strings.HasPrefix(src[i:], `"""`):
+			end := strings.Index(strings.ReplaceAll(src[i+3:], `\"""`, "    "), `"""`)
+			if end < 0 {
+				return nil, syntaxError{"unterminated string", loc}
+			}
+			raw := src[i+3 : i+3+end]
+			tokens = append(tokens, token{kind: tokenString, value: strings.ReplaceAll(raw, `\"""`, `"""`), loc: loc})
+			line += strings.Count(raw, "\n")
+			if nl := strings.LastIndex(raw, "\n"); nl >= 0 {
+				lineStart = i + 3 + nl + 1
+			}
+			i += 3 + end + 3
+		case c == '"':
+			value, n, err := lexString(src[i:])
+			if err != nil {
+				return nil, syntaxError{err.Error(), loc}
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, loc: loc})
+			i += n
+		default:
+			r, _ := utf8.DecodeRuneInString(src[i:])
+			return nil, syntaxError{fmt.Sprintf("unexpected character %q", r), loc}
+		}
+	}
+	loc := tc.GraphQLLocation{Line: line, Column: len(src) - lineStart + 1}
+	return append(tokens, token{kind: tokenEOF, loc: loc}), nil
+}
+
+// lexString returns the value of the quoted string at the start of src, and
+// its length in src.
+func lexString(src string) (string, int, error) {
+	value := strings.Builder{}
+	for i := 1; i < len(src); i++ {
+		switch c := src[i]; c {
+		case '"':
+			return value.String(), i + 1, nil
+		case '\n', '\r':
+			return "", 0, fmt.Errorf("unterminated string")
+		case '\\':
+			i++
+			if i >= len(src) {
+				return "", 0, fmt.Errorf("unterminated string")
+			}
+			switch src[i] {
+			case '"', '\\', '/':
+				value.WriteByte(src[i])
+			case 'b':
+				value.WriteByte('\b')
+			case 'f':
+				value.WriteByte('\f')
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case 't':
+				value.WriteByte('\t')
+			case 'u':
+				if i+4 >= len(src) {
+					return "", 0, fmt.Errorf("invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(src[i+1:i+5], 16, 32)
+				if err != nil {
+					return "", 0, fmt.Errorf("invalid unicode escape")
+				}
+				value.WriteRune(rune(r))
+				i += 4
+			default:
+				return "", 0, fmt.Errorf("invalid escape '\\%c'", src[i])
+			}
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// document is a parsed GraphQL executable document.
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	name       string
+	variables  []variableDefinition
+	selections []selection
+	loc        tc.GraphQLLocation
+}
+
+type variableDefinition struct {
+	name         string
+	nonNull      bool
+	defaultValue *value
+	loc          tc.GraphQLLocation
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	selections    []selection
+	loc           tc.GraphQLLocation
+}
+
+// selection is a *field, *fragmentSpread, or *inlineFragment.
+type selection interface{}
+
+type field struct {
+	alias      string
+	name       string
+	arguments  []argument
+	directives []directive
+	selections []selection
+	loc        tc.GraphQLLocation
+}
+
+// key returns the key of the field in the response.
+func (f *field) key() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []directive
+	loc        tc.GraphQLLocation
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []directive
+	selections    []selection
+	loc           tc.GraphQLLocation
+}
+
+type argument struct {
+	name  string
+	value value
+}
+
+type directive struct {
+	name      string
+	arguments []argument
+	loc       tc.GraphQLLocation
+}
+
+type valueKind int
+
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+type value struct {
+	kind   valueKind
+	raw    string
+	list   []value
+	fields []argument
+	loc    tc.GraphQLLocation
+}
+
+// resolve returns the JSON value of v, with variables replaced by their
+// values.
+func (v value) resolve(vars map[string]interface{}) interface{} {
+	switch v.kind {
+	case valueVariable:
+		return vars[v.raw]
+	case valueInt, valueFloat:
+		return json.Number(v.raw)
+	case valueString, valueEnum:
+		return v.raw
+	case valueBoolean:
+		return v.raw == "true"
+	case valueList:
+		list := make([]interface{}, 0, len(v.list))
+		for _, elem := range v.list {
+			list = append(list, elem.resolve(vars))
+		}
+		return list
+	case valueObject:
+		obj := make(map[string]interface{}, len(v.fields))
+		for _, f := range v.fields {
+			obj[f.name] = f.value.resolve(vars)
+		}
+		return obj
+	}
+	return nil
+}
+
+// variables returns the names of the variables used by v.
+func (v value) variables() []string {
+	switch v.kind {
+	case valueVariable:
+		return []string{v.raw}
+	case valueList:
+		names := []string{}
+		for _, elem := range v.list {
+			names = append(names, elem.variables()...)
+		}
+		return names
+	case valueObject:
+		names := []string{}
+		for _, f := range v.fields {
+			names = append(names, f.value.variables()...)
+		}
+		return names
+	}
+	return nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parse parses a GraphQL executable document.
+func parse(src string) (*document, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	doc := &document{fragments: map[string]*fragment{}}
+	for p.peek().kind != tokenEOF {
+		t := p.peek()
+		switch {
+		case t.kind == tokenPunctuator && t.value == "{":
+			op := &operation{loc: t.loc}
+			if op.selections, err = p.parseSelectionSet(); err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case t.kind == tokenName && t.value == "query":
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case t.kind == tokenName && (t.value == "mutation" || t.value == "subscription"):
+			return nil, syntaxError{"only queries are supported, not " + t.value + "s", t.loc}
+		case t.kind == tokenName && t.value == "fragment":
+			frag, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[frag.name]; ok {
+				return nil, syntaxError{"there is more than one fragment named '" + frag.name + "'", frag.loc}
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, syntaxError{"the document has no operations", p.peek().loc}
+	}
+	return doc, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// skip consumes the next token if it's the given punctuator, and returns
+// whether it was.
+func (p *parser) skip(punctuator string) bool {
+	if t := p.peek(); t.kind == tokenPunctuator && t.value == punctuator {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(punctuator string) error {
+	if !p.skip(punctuator) {
+		return p.unexpectedWant("'" + punctuator + "'")
+	}
+	return nil
+}
+
+func (p *parser) expectName() (token, error) {
+	t := p.peek()
+	if t.kind != tokenName {
+		return t, p.unexpectedWant("a name")
+	}
+	return p.next(), nil
+}
+
+func (p *parser) unexpected() error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return syntaxError{"unexpected end of document", t.loc}
+	}
+	return syntaxError{"unexpected '" + t.value + "'", t.loc}
+}
+
+func (p *parser) unexpectedWant(want string) error {
+	err := p.unexpected().(syntaxError)
+	err.msg += ", expected " + want
+	return err
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{loc: p.next().loc}
+	if p.peek().kind == tokenName {
+		op.name = p.next().value
+	}
+	if p.skip("(") {
+		for !p.skip(")") {
+			def := variableDefinition{loc: p.peek().loc}
+			if err := p.expect("$"); err != nil {
+				return nil, err
+			}
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			def.name = name.value
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if def.nonNull, err = p.parseType(); err != nil {
+				return nil, err
+			}
+			if p.skip("=") {
+				v, err := p.parseValue(true)
+				if err != nil {
+					return nil, err
+				}
+				def.defaultValue = &v
+			}
+			op.variables = append(op.variables, def)
+		}
+	}
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	var err error
+	op.selections, err = p.parseSelectionSet()
+	return op, err
+}
+
+// parseType parses a variable's type, and returns whether it's non-null.
+// Otherwise, types aren't checked; arguments are passed on to the Traffic Ops
+// API as query parameters, which checks them.
+func (p *parser) parseType() (bool, error) {
+	if p.skip("[") {
+		if _, err := p.parseType(); err != nil {
+			return false, err
+		}
+		if err := p.expect("]"); err != nil {
+			return false, err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return false, err
+	}
+	return p.skip("!"), nil
+}
+
+func (p *parser) parseFragment() (*fragment, error) {
+	frag := &fragment{loc: p.next().loc}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if name.value == "on" {
+		return nil, syntaxError{"a fragment can't be named 'on'", name.loc}
+	}
+	frag.name = name.value
+	if on, err := p.expectName(); err != nil {
+		return nil, err
+	} else if on.value != "on" {
+		return nil, syntaxError{"unexpected '" + on.value + "', expected 'on'", on.loc}
+	}
+	typeCondition, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	frag.typeCondition = typeCondition.value
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	frag.selections, err = p.parseSelectionSet()
+	return frag, err
+}
+
+func (p *parser) parseSelectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	selections := []selection{}
+	for !p.skip("}") {
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	if len(selections) == 0 {
+		return nil, syntaxError{"a selection set can't be empty", p.tokens[p.pos-1].loc}
+	}
+	return selections, nil
+}
+
+func (p *parser) parseSelection() (selection, error) {
+	loc := p.peek().loc
+	if p.skip("...") {
+		if t := p.peek(); t.kind == tokenName && t.value != "on" {
+			spread := &fragmentSpread{name: p.next().value, loc: loc}
+			var err error
+			spread.directives, err = p.parseDirectives()
+			return spread, err
+		}
+		inline := &inlineFragment{loc: loc}
+		if t := p.peek(); t.kind == tokenName && t.value == "on" {
+			p.next()
+			typeCondition, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			inline.typeCondition = typeCondition.value
+		}
+		var err error
+		if inline.directives, err = p.parseDirectives(); err != nil {
+			return nil, err
+		}
+		inline.selections, err = p.parseSelectionSet()
+		return inline, err
+	}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	f := &field{name: name.value, loc: loc}
+	if p.skip(":") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		f.alias, f.name = f.name, name.value
+	}
+	if f.arguments, err = p.parseArguments(false); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind == tokenPunctuator && t.value == "{" {
+		if f.selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) parseArguments(constant bool) ([]argument, error) {
+	if !p.skip("(") {
+		return nil, nil
+	}
+	args := []argument{}
+	for !p.skip(")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		v, err := p.parseValue(constant)
+		if err != nil {
+			return nil, err
+		}
+		for _, arg := range args {
+			if arg.name == name.value {
+				return nil, syntaxError{"there is more than one argument named '" + name.value + "'", name.loc}
+			}
+		}
+		args = append(args, argument{name: name.value, value: v})
+	}
+	if len(args) == 0 {
+		return nil, syntaxError{"arguments can't be empty", p.tokens[p.pos-1].loc}
+	}
+	return args, nil
+}
+
+func (p *parser) parseDirectives() ([]directive, error) {
+	directives := []directive{}
+	for {
+		loc := p.peek().loc
+		if !p.skip("@") {
+			return directives, nil
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.parseArguments(false)
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, directive{name: name.value, arguments: args, loc: loc})
+	}
+}
+
+// parseValue parses a value. A constant value, such as the default value of a
+// variable, can't have variables.
+func (p *parser) parseValue(constant bool) (value, error) {
+	t := p.peek()
+	v := value{loc: t.loc, raw: t.value}
+	switch t.kind {
+	case tokenInt:
+		v.kind = valueInt
+	case tokenFloat:
+		v.kind = valueFloat
+	case tokenString:
+		v.kind = valueString
+	case tokenName:
+		switch t.value {
+		case "true", "false":
+			v.kind = valueBoolean
+		case "null":
+			v.kind = valueNull
+		default:
+			v.kind = valueEnum
+		}
+	case tokenPunctuator:
+		switch t.value {
+		case "$":
+			if constant {
+				return v, syntaxError{"variables aren't allowed here", t.loc}
+			}
+			p.next()
+			name, err := p.expectName()
+			if err != nil {
+				return v, err
+			}
+			v.kind, v.raw = valueVariable, name.value
+			return v, nil
+		case "[":
+			p.next()
+			v.kind = valueList
+			for !p.skip("]") {
+				elem, err := p.parseValue(constant)
+				if err != nil {
+					return v, err
+				}
+				v.list = append(v.list, elem)
+			}
+			return v, nil
+		case "{":
+			p.next()
+			v.kind = valueObject
+			for !p.skip("}") {
+				name, err := p.expectName()
+				if err != nil {
+					return v, err
+				}
+				if err := p.expect(":"); err != nil {
+					return v, err
+				}
+				fieldValue, err := p.parseValue(constant)
+				if err != nil {
+					return v, err
+				}
+				v.fields = append(v.fields, argument{name: name.value, value: fieldValue})
+			}
+			return v, nil
+		}
+		return v, p.unexpectedWant("a value")
+	default:
+		return v, p.unexpectedWant("a value")
+	}
+	p.next()
+	return v, nil
+}
@@ -0,0 +1,165 @@
+// Package graphql executes read-only GraphQL queries of the Traffic Ops data
+// model.
+//
+// Every field of a query is resolved by a GET request of the version 4 Traffic
+// Ops API, made by a Fetcher, so that queries are subject to exactly the same
+// authorization, tenancy, and filtering as the API endpoints themselves.
+package graphql
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Type names.
+const (
+	ServerType          = "Server"
+	DeliveryServiceType = "DeliveryService"
+	CacheGroupType      = "CacheGroup"
+	TopologyType        = "Topology"
+	ProfileType         = "Profile"
+	ParameterType       = "Parameter"
+)
+
+// TypeNameField is the field of every type which is its name.
+const TypeNameField = "__typename"
+
+// objectType is a type of object in the schema. Its scalar fields are the
+// properties of its objects in the API; its relations are objects fetched by
+// other requests.
+type objectType struct {
+	name      string
+	scalars   map[string]struct{}
+	relations map[string]relation
+}
+
+// relation is a field whose value is one or more objects of another type,
+// related to the object which has the field.
+//
+// Related objects are either fetched for every object at once, by filtering
+// an endpoint by Param to the Key property of every object, and matching
+// them to objects by their Match property; or, if Param is empty, for each
+// object separately, from Path with "{}" replaced by its Key property, and
+// the query parameter PathParam, if any, set to it.
+type relation struct {
+	typ  string
+	list bool
+	key  string
+
+	path  string
+	param string
+	match string
+
+	pathParam string
+}
+
+// batched returns whether the related objects of every object are fetched
+// at once.
+func (r relation) batched() bool {
+	return r.param != ""
+}
+
+// rootField is a field of the query type, whose value is a list of objects
+// from an endpoint.
+type rootField struct {
+	typ  string
+	path string
+}
+
+var rootFields = map[string]rootField{
+	"servers":          {typ: ServerType, path: "servers"},
+	"deliveryServices": {typ: DeliveryServiceType, path: "deliveryservices"},
+	"cacheGroups":      {typ: CacheGroupType, path: "cachegroups"},
+	"topologies":       {typ: TopologyType, path: "topologies"},
+	"profiles":         {typ: ProfileType, path: "profiles"},
+	"parameters":       {typ: ParameterType, path: "parameters"},
+}
+
+var types = map[string]*objectType{
+	ServerType: newObjectType(ServerType, tc.ServerV40{}, map[string]relation{
+		"cachegroup":       {typ: CacheGroupType, key: "cachegroupId", path: "cachegroups", param: "id", match: "id"},
+		"profile":          {typ: ProfileType, key: "profileId", path: "profiles", param: "id", match: "id"},
+		"deliveryServices": {typ: DeliveryServiceType, list: true, key: "id", path: "servers/{}/deliveryservices"},
+	}),
+	DeliveryServiceType: newObjectType(DeliveryServiceType, tc.DeliveryServiceV4{}, map[string]relation{
+		"topology": {typ: TopologyType, key: "topology", path: "topologies", param: "name", match: "name"},
+		"profile":  {typ: ProfileType, key: "profileId", path: "profiles", param: "id", match: "id"},
+		"servers":  {typ: ServerType, list: true, key: "id", path: "servers", pathParam: "dsId"},
+	}),
+	CacheGroupType: newObjectType(CacheGroupType, tc.CacheGroupNullable{}, map[string]relation{
+		"parent":          {typ: CacheGroupType, key: "parentCachegroupId", path: "cachegroups", param: "id", match: "id"},
+		"secondaryParent": {typ: CacheGroupType, key: "secondaryParentCachegroupId", path: "cachegroups", param: "id", match: "id"},
+		"servers":         {typ: ServerType, list: true, key: "id", path: "servers", param: "cachegroup", match: "cachegroupId"},
+	}),
+	TopologyType: newObjectType(TopologyType, tc.Topology{}, map[string]relation{
+		"cacheGroups":      {typ: CacheGroupType, list: true, key: "name", path: "cachegroups", pathParam: "topology"},
+		"deliveryServices": {typ: DeliveryServiceType, list: true, key: "name", path: "deliveryservices", param: "topology", match: "topology"},
+	}),
+	ProfileType: newObjectType(ProfileType, tc.ProfileNullable{}, map[string]relation{
+		"parameters": {typ: ParameterType, list: true, key: "id", path: "profiles/{}/parameters"},
+		"servers":    {typ: ServerType, list: true, key: "id", path: "servers", param: "profileId", match: "profileId"},
+	}),
+	ParameterType: newObjectType(ParameterType, tc.ParameterNullable{}, nil),
+}
+
+// newObjectType returns the type of objects represented in the API by the
+// given struct. A relation of the same name as a property replaces it.
+func newObjectType(name string, v interface{}, relations map[string]relation) *objectType {
+	t := &objectType{name: name, scalars: map[string]struct{}{}, relations: relations}
+	if t.relations == nil {
+		t.relations = map[string]relation{}
+	}
+	for _, property := range jsonProperties(reflect.TypeOf(v)) {
+		if _, ok := t.relations[property]; !ok {
+			t.scalars[property] = struct{}{}
+		}
+	}
+	return t
+}
+
+// jsonProperties returns the names of the properties of the JSON encoding of
+// the given struct type, including those of its embedded structs.
+func jsonProperties(t reflect.Type) []string {
+	properties := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			properties = append(properties, jsonProperties(f.Type)...)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties = append(properties, name)
+	}
+	return properties
+}
@@ -23,6 +23,7 @@ package monitoring
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -45,6 +46,23 @@ const MonitorConfigFile = "rascal-config.txt"
 const KilobitsPerMegabit = 1000
 const DeliveryServiceStatus = "REPORTED"
 
+// ProbeURLParameterName is the Name of the Parameters on a Delivery Service's
+// Profile which hold the URLs Traffic Monitor uses to probe content delivery
+// through the Delivery Service's cache servers.
+const ProbeURLParameterName = "health.probe.url"
+
+// DSHealthPolicyParameterPrefix is the prefix of the Names of the Parameters on
+// a Delivery Service's Profile which define its health policy.
+const DSHealthPolicyParameterPrefix = "health.ds."
+
+// These are the Names of the Parameters on a Delivery Service's Profile which
+// define its health policy.
+const (
+	DSHealthPolicyMinAvailableCachesParameterName   = DSHealthPolicyParameterPrefix + "minAvailableCachesPerCacheGroup"
+	DSHealthPolicyMaxErrorRatioParameterName        = DSHealthPolicyParameterPrefix + "maxErrorRatio"
+	DSHealthPolicyMinAvailableCapacityParameterName = DSHealthPolicyParameterPrefix + "minAvailableCapacityKbps"
+)
+
 type BasicServer struct {
 	CommonServerProperties
 	IP  string `json:"ip"`
@@ -129,10 +147,13 @@ type Router struct {
 }
 
 type DeliveryService struct {
-	XMLID              string  `json:"xmlId"`
-	TotalTPSThreshold  float64 `json:"totalTpsThreshold"`
-	Status             string  `json:"status"`
-	TotalKBPSThreshold float64 `json:"totalKbpsThreshold"`
+	XMLID              string   `json:"xmlId"`
+	TotalTPSThreshold  float64  `json:"totalTpsThreshold"`
+	Status             string   `json:"status"`
+	TotalKBPSThreshold float64  `json:"totalKbpsThreshold"`
+	ProbeURLs          []string `json:"probeUrls,omitempty"`
+
+	HealthPolicy *tc.TMDeliveryServiceHealthPolicy `json:"healthPolicy,omitempty"`
 }
 
 func Get(w http.ResponseWriter, r *http.Request) {
@@ -456,11 +477,28 @@ WHERE pr.config_file = $2;
 
 func getDeliveryServices(tx *sql.Tx) ([]DeliveryService, error) {
 	query := `
-	SELECT ds.xml_id, ds.global_max_tps, ds.global_max_mbps
+	SELECT ds.xml_id, ds.global_max_tps, ds.global_max_mbps,
+	ARRAY(
+		SELECT pr.value
+		FROM parameter pr
+		JOIN profile_parameter pp ON pp.parameter = pr.id
+		WHERE pp.profile = ds.profile
+		AND pr.name = $1
+		AND pr.config_file = $2
+		ORDER BY pr.value
+	) AS probe_urls,
+	(
+		SELECT json_object_agg(pr.name, pr.value)
+		FROM parameter pr
+		JOIN profile_parameter pp ON pp.parameter = pr.id
+		WHERE pp.profile = ds.profile
+		AND pr.name LIKE $3
+		AND pr.config_file = $2
+	) AS health_policy
 	FROM deliveryservice ds
 	WHERE ds.active = true
 	`
-	rows, err := tx.Query(query)
+	rows, err := tx.Query(query, ProbeURLParameterName, CacheMonitorConfigFile, DSHealthPolicyParameterPrefix+"%")
 	if err != nil {
 		return nil, err
 	}
@@ -472,19 +510,74 @@ func getDeliveryServices(tx *sql.Tx) ([]DeliveryService, error) {
 		var xmlid sql.NullString
 		var tps sql.NullFloat64
 		var mbps sql.NullFloat64
-		if err := rows.Scan(&xmlid, &tps, &mbps); err != nil {
+		probeURLs := []string{}
+		healthPolicyParams := []byte(nil)
+		if err := rows.Scan(&xmlid, &tps, &mbps, pq.Array(&probeURLs), &healthPolicyParams); err != nil {
 			return nil, err
 		}
-		dses = append(dses, DeliveryService{
+		ds := DeliveryService{
 			XMLID:              xmlid.String,
 			TotalTPSThreshold:  tps.Float64,
 			Status:             DeliveryServiceStatus,
 			TotalKBPSThreshold: mbps.Float64 * KilobitsPerMegabit,
-		})
+		}
+		if len(probeURLs) > 0 {
+			ds.ProbeURLs = probeURLs
+		}
+		if len(healthPolicyParams) > 0 {
+			params := map[string]string{}
+			if err := json.Unmarshal(healthPolicyParams, &params); err != nil {
+				return nil, fmt.Errorf("parsing delivery service '%s' health policy parameters: %v", xmlid.String, err)
+			}
+			ds.HealthPolicy = getDSHealthPolicy(xmlid.String, params)
+		}
+		dses = append(dses, ds)
 	}
 	return dses, nil
 }
 
+// getDSHealthPolicy creates a Delivery Service health policy from the given
+// Parameter Names and Values. Invalid Values are logged and ignored, rather
+// than failing the monitoring config of the whole CDN. It returns nil if none
+// of the Parameters are valid.
+func getDSHealthPolicy(xmlID string, params map[string]string) *tc.TMDeliveryServiceHealthPolicy {
+	policy := tc.TMDeliveryServiceHealthPolicy{}
+	hasPolicy := false
+	for name, value := range params {
+		switch name {
+		case DSHealthPolicyMinAvailableCachesParameterName:
+			minCaches, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || minCaches < 0 {
+				log.Warnf("delivery service '%s' parameter '%s' value '%s' is not a non-negative integer, ignoring", xmlID, name, value)
+				continue
+			}
+			policy.MinAvailableCachesPerCacheGroup = &minCaches
+		case DSHealthPolicyMaxErrorRatioParameterName:
+			maxErrorRatio, err := strconv.ParseFloat(value, 64)
+			if err != nil || maxErrorRatio < 0 || maxErrorRatio > 1 {
+				log.Warnf("delivery service '%s' parameter '%s' value '%s' is not a number between 0 and 1, ignoring", xmlID, name, value)
+				continue
+			}
+			policy.MaxErrorRatio = &maxErrorRatio
+		case DSHealthPolicyMinAvailableCapacityParameterName:
+			minCapacity, err := strconv.ParseFloat(value, 64)
+			if err != nil || minCapacity < 0 {
+				log.Warnf("delivery service '%s' parameter '%s' value '%s' is not a non-negative number, ignoring", xmlID, name, value)
+				continue
+			}
+			policy.MinAvailableCapacityKbps = &minCapacity
+		default:
+			log.Warnf("delivery service '%s' has unknown health policy parameter '%s', ignoring", xmlID, name)
+			continue
+		}
+		hasPolicy = true
+	}
+	if !hasPolicy {
+		return nil
+	}
+	return &policy
+}
+
 func getConfig(tx *sql.Tx, cdnName string) (map[string]interface{}, error) {
 	// TODO remove 'like' in query? Slow?
 	query := `
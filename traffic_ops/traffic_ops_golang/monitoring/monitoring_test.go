@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -311,13 +312,37 @@ func TestGetDeliveryServices(t *testing.T) {
 		Status:             DeliveryServiceStatus,
 		TotalKBPSThreshold: 24.24,
 	}
+	probedDeliveryService := DeliveryService{
+		XMLID:              "myProbedDsid",
+		TotalTPSThreshold:  42.42,
+		Status:             DeliveryServiceStatus,
+		TotalKBPSThreshold: 24.24,
+		ProbeURLs:          []string{"http://probed.example.net/probe.txt", "https://probed.example.net/probe.txt"},
+	}
+
+	minCaches := int64(2)
+	maxErrorRatio := 0.05
+	policyDeliveryService := DeliveryService{
+		XMLID:              "myPolicyDsid",
+		TotalTPSThreshold:  42.42,
+		Status:             DeliveryServiceStatus,
+		TotalKBPSThreshold: 24.24,
+		HealthPolicy: &tc.TMDeliveryServiceHealthPolicy{
+			MinAvailableCachesPerCacheGroup: &minCaches,
+			MaxErrorRatio:                   &maxErrorRatio,
+		},
+	}
+	healthPolicyParams := map[string]interface{}{
+		// the invalid capacity is ignored, rather than failing the whole CDN's config
+		policyDeliveryService.XMLID: []byte(`{"health.ds.minAvailableCachesPerCacheGroup": "2", "health.ds.maxErrorRatio": "0.05", "health.ds.minAvailableCapacityKbps": "lots"}`),
+	}
 
-	deliveryservices := []DeliveryService{deliveryservice}
+	deliveryservices := []DeliveryService{deliveryservice, probedDeliveryService, policyDeliveryService}
 
 	mock.ExpectBegin()
-	rows := sqlmock.NewRows([]string{"xml_id", "global_max_tps", "global_max_mbps"})
+	rows := sqlmock.NewRows([]string{"xml_id", "global_max_tps", "global_max_mbps", "probe_urls", "health_policy"})
 	for _, deliveryservice := range deliveryservices {
-		rows = rows.AddRow(deliveryservice.XMLID, deliveryservice.TotalTPSThreshold, deliveryservice.TotalKBPSThreshold/KilobitsPerMegabit)
+		rows = rows.AddRow(deliveryservice.XMLID, deliveryservice.TotalTPSThreshold, deliveryservice.TotalKBPSThreshold/KilobitsPerMegabit, "{"+strings.Join(deliveryservice.ProbeURLs, ",")+"}", healthPolicyParams[deliveryservice.XMLID])
 	}
 
 	mock.ExpectQuery("SELECT").WillReturnRows(rows)
@@ -340,7 +365,7 @@ func TestGetDeliveryServices(t *testing.T) {
 
 	for i, sqlDeliveryservice := range sqlDeliveryservices {
 		deliveryservice := deliveryservices[i]
-		if deliveryservice != sqlDeliveryservice {
+		if !reflect.DeepEqual(deliveryservice, sqlDeliveryservice) {
 			t.Errorf("getDeliveryServices expected: %v, actual: %v", deliveryservice, sqlDeliveryservice)
 		}
 	}
@@ -491,9 +516,9 @@ func TestGetMonitoringJSON(t *testing.T) {
 		deliveryservices := []DeliveryService{deliveryservice}
 		// routers := []Router{router}
 
-		rows := sqlmock.NewRows([]string{"xml_id", "global_max_tps", "global_max_mbps"})
+		rows := sqlmock.NewRows([]string{"xml_id", "global_max_tps", "global_max_mbps", "probe_urls", "health_policy"})
 		for _, deliveryservice := range deliveryservices {
-			rows = rows.AddRow(deliveryservice.XMLID, deliveryservice.TotalTPSThreshold, deliveryservice.TotalKBPSThreshold/KilobitsPerMegabit)
+			rows = rows.AddRow(deliveryservice.XMLID, deliveryservice.TotalTPSThreshold, deliveryservice.TotalKBPSThreshold/KilobitsPerMegabit, "{}", nil)
 		}
 
 		mock.ExpectQuery("SELECT").WillReturnRows(rows)
@@ -324,6 +324,10 @@ func (origin *TOOrigin) Update(h http.Header) (error, error, int) {
 	if userErr != nil || sysErr != nil {
 		return userErr, sysErr, errCode
 	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(origin.ReqInfo.Tx.Tx, *origin.DeliveryServiceID, origin.ReqInfo.User.UserName)
+	if userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
 
 	log.Debugf("about to run exec query: %s with origin: %++v", updateQuery(), origin)
 	resultRows, err := origin.ReqInfo.Tx.NamedQuery(updateQuery(), origin)
@@ -390,6 +394,10 @@ func (origin *TOOrigin) Create() (error, error, int) {
 	if userErr != nil || sysErr != nil {
 		return userErr, sysErr, errCode
 	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(origin.ReqInfo.Tx.Tx, *origin.DeliveryServiceID, origin.ReqInfo.User.UserName)
+	if userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
 
 	resultRows, err := origin.ReqInfo.Tx.NamedQuery(insertQuery(), origin)
 	if err != nil {
@@ -468,6 +476,10 @@ func (origin *TOOrigin) Delete() (error, error, int) {
 		if userErr != nil || sysErr != nil {
 			return userErr, sysErr, errCode
 		}
+		userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(origin.ReqInfo.Tx.Tx, *origin.DeliveryServiceID, origin.ReqInfo.User.UserName)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
 	}
 	result, err := origin.ReqInfo.Tx.NamedExec(deleteQuery(), origin)
 	if err != nil {
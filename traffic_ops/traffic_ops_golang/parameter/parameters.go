@@ -31,11 +31,11 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
 	"github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/util/ims"
 
 	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/lib/pq"
 )
 
 const (
@@ -50,6 +50,10 @@ var (
 	HiddenField = "********"
 )
 
+// SecureReadPermission is the permission a user needs to see the values of
+// secure Parameters, which are otherwise hidden as HiddenField.
+const SecureReadPermission = "PARAMETER:SECURE-READ"
+
 //we need a type alias to define functions on
 type TOParameter struct {
 	api.APIInfoImpl `json:"-"`
@@ -77,6 +81,7 @@ func (v *TOParameter) NewReadObj() interface{} { return &tc.ParameterNullable{}
 func (v *TOParameter) SelectQuery() string     { return selectQuery() }
 func (v *TOParameter) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
+		"lastUpdated":        {Column: "p.last_updated", Checker: api.IsTime},
 		ConfigFileQueryParam: {Column: "p.config_file"},
 		IDQueryParam:         {Column: "p.id", Checker: api.IsInt},
 		NameQueryParam:       {Column: "p.name"},
@@ -175,7 +180,7 @@ func (param *TOParameter) Read(h http.Header, useIMS bool) ([]interface{}, error
 		if err = rows.StructScan(&p); err != nil {
 			return nil, nil, errors.New("scanning " + param.GetType() + ": " + err.Error()), http.StatusInternalServerError, nil
 		}
-		if p.Secure != nil && *p.Secure && param.ReqInfo.User.PrivLevel < auth.PrivLevelAdmin {
+		if p.Secure != nil && *p.Secure && !param.ReqInfo.User.Can(SecureReadPermission) {
 			p.Value = &HiddenField
 		}
 		params = append(params, p)
@@ -185,13 +190,44 @@ func (param *TOParameter) Read(h http.Header, useIMS bool) ([]interface{}, error
 }
 
 func (pa *TOParameter) Update(h http.Header) (error, error, int) {
+	if userErr, sysErr, errCode := pa.checkProfileLocks(); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
 	if pa.Value == nil {
 		pa.Value = util.StrPtr("")
 	}
 	return api.GenericUpdate(h, pa)
 }
 
-func (pa *TOParameter) Delete() (error, error, int) { return api.GenericDelete(pa) }
+func (pa *TOParameter) Delete() (error, error, int) {
+	if userErr, sysErr, errCode := pa.checkProfileLocks(); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	return api.GenericDelete(pa)
+}
+
+// checkProfileLocks checks that the current user may modify the Profiles
+// which have the Parameter, and their CDNs, given the locks on them.
+func (pa *TOParameter) checkProfileLocks() (error, error, int) {
+	tx := pa.ReqInfo.Tx.Tx
+	profileIDs := []int64{}
+	if err := tx.QueryRow(selectProfileIDsQuery, *pa.ID).Scan(pq.Array(&profileIDs)); err != nil {
+		return nil, errors.New("getting profiles of parameter: " + err.Error()), http.StatusInternalServerError
+	}
+	if len(profileIDs) == 0 {
+		return nil, nil, http.StatusOK
+	}
+	cdnNames, err := dbhelpers.GetCDNNamesFromProfileIDs(tx, profileIDs)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDNs(tx, cdnNames, pa.ReqInfo.User.UserName); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	return dbhelpers.CheckIfCurrentUserCanModifyProfilesWithIDs(tx, profileIDs, pa.ReqInfo.User.UserName)
+}
+
+const selectProfileIDsQuery = `SELECT ARRAY(SELECT profile FROM profile_parameter WHERE parameter = $1)`
 
 func insertQuery() string {
 	query := `INSERT INTO parameter (
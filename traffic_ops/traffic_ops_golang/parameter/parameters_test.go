@@ -20,6 +20,7 @@ package parameter
  */
 
 import (
+	"net/http"
 	"testing"
 	"time"
 
@@ -114,6 +115,45 @@ func TestGetParameters(t *testing.T) {
 
 }
 
+func TestDeleteParameterOfLockedProfile(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT ARRAY\(SELECT profile FROM profile_parameter`).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"array"}).AddRow("{5}"))
+	mock.ExpectQuery(`SELECT DISTINCT\(cdn.name\)`).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("cdn1"))
+	mock.ExpectQuery("FROM cdn_lock").WillReturnRows(sqlmock.NewRows([]string{"username", "soft", "cdn"}))
+	mock.ExpectQuery("FROM resource_lock").WithArgs(tc.ResourceLockTypeCDN, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"resource", "username", "shared", "expires"}))
+	mock.ExpectQuery(`SELECT ARRAY\(SELECT name FROM profile`).WillReturnRows(sqlmock.NewRows([]string{"array"}).AddRow("{profile1}"))
+	mock.ExpectQuery("FROM resource_lock").WithArgs(tc.ResourceLockTypeProfile, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"resource", "username", "shared", "expires"}).AddRow("profile1", "other", false, time.Now().Add(time.Hour)))
+
+	id := 1
+	reqInfo := api.APIInfo{
+		Tx:   db.MustBegin(),
+		User: &auth.CurrentUser{UserName: "user", PrivLevel: 30},
+	}
+	obj := TOParameter{
+		api.APIInfoImpl{ReqInfo: &reqInfo},
+		tc.ParameterNullable{ID: &id},
+	}
+	userErr, sysErr, errCode := obj.Delete()
+	if sysErr != nil {
+		t.Errorf("Delete expected: no system error, actual: %v", sysErr)
+	}
+	if userErr == nil || errCode != http.StatusForbidden {
+		t.Errorf("Delete expected: status %d with a user error, actual: status %d user error %v", http.StatusForbidden, errCode, userErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestInterfaces(t *testing.T) {
 	var i interface{}
 	i = &TOParameter{}
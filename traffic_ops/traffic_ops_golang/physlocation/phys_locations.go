@@ -49,9 +49,10 @@ func (v *TOPhysLocation) NewReadObj() interface{}       { return &tc.PhysLocatio
 func (v *TOPhysLocation) SelectQuery() string           { return selectQuery() }
 func (v *TOPhysLocation) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
-		"name":   dbhelpers.WhereColumnInfo{Column: "pl.name"},
-		"id":     dbhelpers.WhereColumnInfo{Column: "pl.id", Checker: api.IsInt},
-		"region": dbhelpers.WhereColumnInfo{Column: "pl.region", Checker: api.IsInt},
+		"lastUpdated": dbhelpers.WhereColumnInfo{Column: "pl.last_updated", Checker: api.IsTime},
+		"name":        dbhelpers.WhereColumnInfo{Column: "pl.name"},
+		"id":          dbhelpers.WhereColumnInfo{Column: "pl.id", Checker: api.IsInt},
+		"region":      dbhelpers.WhereColumnInfo{Column: "pl.region", Checker: api.IsInt},
 	}
 }
 func (v *TOPhysLocation) UpdateQuery() string { return updateQuery() }
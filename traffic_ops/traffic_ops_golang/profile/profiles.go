@@ -205,7 +205,6 @@ LEFT JOIN cdn c ON prof.cdn = c.id`
 }
 
 func ReadParameters(tx *sqlx.Tx, parameters map[string]string, user *auth.CurrentUser, profile tc.ProfileNullable) ([]tc.ParameterNullable, error) {
-	privLevel := user.PrivLevel
 	queryValues := make(map[string]interface{})
 	queryValues["profile_id"] = *profile.ID
 
@@ -227,7 +226,7 @@ func ReadParameters(tx *sqlx.Tx, parameters map[string]string, user *auth.Curren
 		if param.Secure != nil {
 			isSecure = *param.Secure
 		}
-		if isSecure && (privLevel < auth.PrivLevelAdmin) {
+		if isSecure && !user.Can(parameter.SecureReadPermission) {
 			param.Value = &parameter.HiddenField
 		}
 		params = append(params, param)
@@ -278,6 +277,20 @@ func (pr *TOProfile) Update(h http.Header) (error, error, int) {
 			return userErr, sysErr, statusCode
 		}
 	}
+	if userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyProfileWithID(pr.ReqInfo.Tx.Tx, *pr.ID, pr.ReqInfo.User.UserName); userErr != nil || sysErr != nil {
+		return userErr, sysErr, statusCode
+	}
+	if pr.Name != nil {
+		name, ok, err := dbhelpers.GetProfileNameFromID(*pr.ID, pr.ReqInfo.Tx.Tx)
+		if err != nil {
+			return nil, err, http.StatusInternalServerError
+		}
+		if ok {
+			if userErr, sysErr, statusCode := dbhelpers.CheckIfResourceCanBeRenamed(pr.ReqInfo.Tx.Tx, tc.ResourceLockTypeProfile, name, *pr.Name); userErr != nil || sysErr != nil {
+				return userErr, sysErr, statusCode
+			}
+		}
+	}
 	return api.GenericUpdate(h, pr)
 }
 
@@ -305,6 +318,9 @@ func (pr *TOProfile) Delete() (error, error, int) {
 			return userErr, sysErr, statusCode
 		}
 	}
+	if userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyProfileWithID(pr.ReqInfo.Tx.Tx, *pr.ID, pr.ReqInfo.User.UserName); userErr != nil || sysErr != nil {
+		return userErr, sysErr, statusCode
+	}
 	return api.GenericDelete(pr)
 }
 
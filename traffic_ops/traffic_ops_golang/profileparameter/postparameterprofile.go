@@ -57,6 +57,11 @@ func PostParamProfile(w http.ResponseWriter, r *http.Request) {
 			api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
 			return
 		}
+		userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyProfilesWithIDs(inf.Tx.Tx, *paramProfile.ProfileIDs, inf.User.UserName)
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
 	}
 	if err := insertParameterProfile(paramProfile, inf.Tx.Tx); err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("posting parameter profile: "+err.Error()))
@@ -64,6 +64,11 @@ func PostProfileParamsByID(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
 		return
 	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyProfile(inf.Tx.Tx, profileName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
 	insertedObjs, err := insertParametersForProfile(profileName, profParams, inf.Tx.Tx)
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("posting profile parameters by name: "+err.Error()))
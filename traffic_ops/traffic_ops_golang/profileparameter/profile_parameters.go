@@ -53,7 +53,7 @@ func (v *TOProfileParameter) ParamColumns() map[string]dbhelpers.WhereColumnInfo
 	return map[string]dbhelpers.WhereColumnInfo{
 		"profileId":   dbhelpers.WhereColumnInfo{Column: "pp.profile"},
 		"parameterId": dbhelpers.WhereColumnInfo{Column: "pp.parameter"},
-		"lastUpdated": dbhelpers.WhereColumnInfo{Column: "pp.last_updated"},
+		"lastUpdated": dbhelpers.WhereColumnInfo{Column: "pp.last_updated", Checker: api.IsTime},
 	}
 }
 func (v *TOProfileParameter) DeleteQuery() string { return deleteQuery() }
@@ -126,6 +126,10 @@ func (pp *TOProfileParameter) Create() (error, error, int) {
 		if userErr != nil || sysErr != nil {
 			return userErr, sysErr, errCode
 		}
+		userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyProfileWithID(pp.ReqInfo.Tx.Tx, *pp.ProfileID, pp.ReqInfo.User.UserName)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
 	} else {
 		return errors.New("no profile ID in request"), nil, http.StatusBadRequest
 	}
@@ -181,6 +185,10 @@ func (pp *TOProfileParameter) Delete() (error, error, int) {
 		if userErr != nil || sysErr != nil {
 			return userErr, sysErr, errCode
 		}
+		userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyProfileWithID(pp.ReqInfo.Tx.Tx, *pp.ProfileID, pp.ReqInfo.User.UserName)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
 	} else {
 		return errors.New("no profile ID in request"), nil, http.StatusBadRequest
 	}
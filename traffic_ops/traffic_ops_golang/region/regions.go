@@ -45,9 +45,10 @@ func (v *TORegion) NewReadObj() interface{}       { return &tc.Region{} }
 func (v *TORegion) SelectQuery() string           { return selectQuery() }
 func (v *TORegion) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
-		"name":     dbhelpers.WhereColumnInfo{Column: "r.name"},
-		"division": dbhelpers.WhereColumnInfo{Column: "r.division"},
-		"id":       dbhelpers.WhereColumnInfo{Column: "r.id", Checker: api.IsInt},
+		"lastUpdated": dbhelpers.WhereColumnInfo{Column: "r.last_updated", Checker: api.IsTime},
+		"name":        dbhelpers.WhereColumnInfo{Column: "r.name"},
+		"division":    dbhelpers.WhereColumnInfo{Column: "r.division"},
+		"id":          dbhelpers.WhereColumnInfo{Column: "r.id", Checker: api.IsInt},
 	}
 }
 func (v *TORegion) UpdateQuery() string { return updateQuery() }
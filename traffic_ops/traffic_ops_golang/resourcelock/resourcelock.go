@@ -0,0 +1,293 @@
+// Package resourcelock contains the handlers which acquire, renew, list, and
+// release the expiring locks users may hold on individual CDNs, Delivery
+// Services, Topologies, Cache Groups, and Profiles.
+package resourcelock
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+)
+
+// TakeoverPermission is the permission a user needs to release, or take over,
+// the resource locks of other users.
+const TakeoverPermission = "RESOURCE-LOCK:TAKEOVER"
+
+const selectColumns = `id, resource_type, resource, username, shared, message, expires, last_updated`
+
+const readQuery = `SELECT ` + selectColumns + ` FROM resource_lock`
+
+// lockResourceQuery serializes all lock acquisitions for the same resource
+// until the end of the transaction, so that concurrent requests can't both
+// see no conflicts and acquire conflicting locks.
+const lockResourceQuery = `SELECT pg_advisory_xact_lock(hashtext($1 || '/' || $2))`
+
+const deleteExpiredQuery = `DELETE FROM resource_lock WHERE resource_type = $1 AND resource = $2 AND expires <= now()`
+
+const conflictsQuery = `
+SELECT ` + selectColumns + `
+FROM resource_lock
+WHERE resource_type = $1
+AND resource = $2
+AND username <> $3
+AND (shared = false OR $4 = false)
+ORDER BY username`
+
+const deleteByIDQuery = `DELETE FROM resource_lock WHERE id = $1`
+
+const upsertQuery = `
+INSERT INTO resource_lock (resource_type, resource, username, shared, message, expires)
+VALUES ($1, $2, $3, $4, $5, now() + $6 * interval '1 second')
+ON CONFLICT (resource_type, resource, username) DO UPDATE SET
+	shared = EXCLUDED.shared,
+	message = EXCLUDED.message,
+	expires = EXCLUDED.expires
+RETURNING ` + selectColumns
+
+const readByIDQuery = readQuery + ` WHERE id = $1 FOR UPDATE`
+
+// resourceExistsQueries are the queries which check whether a resource of
+// each lockable type exists, by its name.
+var resourceExistsQueries = map[string]string{
+	tc.ResourceLockTypeCDN:             `SELECT EXISTS(SELECT 1 FROM cdn WHERE name = $1)`,
+	tc.ResourceLockTypeDeliveryService: `SELECT EXISTS(SELECT 1 FROM deliveryservice WHERE xml_id = $1)`,
+	tc.ResourceLockTypeTopology:        `SELECT EXISTS(SELECT 1 FROM topology WHERE name = $1)`,
+	tc.ResourceLockTypeCacheGroup:      `SELECT EXISTS(SELECT 1 FROM cachegroup WHERE name = $1)`,
+	tc.ResourceLockTypeProfile:         `SELECT EXISTS(SELECT 1 FROM profile WHERE name = $1)`,
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLock(s scanner, lock *tc.ResourceLock) error {
+	return s.Scan(&lock.ID, &lock.ResourceType, &lock.Resource, &lock.UserName, &lock.Shared, &lock.Message, &lock.Expires, &lock.LastUpdated)
+}
+
+func lockMode(shared bool) string {
+	if shared {
+		return "shared"
+	}
+	return "exclusive"
+}
+
+// Read is the handler for GET requests to /resource_locks.
+func Read(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cols := map[string]dbhelpers.WhereColumnInfo{
+		"id":           {Column: "resource_lock.id", Checker: api.IsInt},
+		"resourceType": {Column: "resource_lock.resource_type", Checker: nil},
+		"resource":     {Column: "resource_lock.resource", Checker: nil},
+		"username":     {Column: "resource_lock.username", Checker: nil},
+	}
+
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, cols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+	// Expired locks are kept until the next acquisition of a lock on their
+	// resource, but they aren't held by anyone.
+	if where == "" {
+		where = dbhelpers.BaseWhere + " resource_lock.expires > now()"
+	} else {
+		where += " AND resource_lock.expires > now()"
+	}
+
+	locks := []tc.ResourceLock{}
+	rows, err := inf.Tx.NamedQuery(readQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("querying resource locks: "+err.Error()))
+		return
+	}
+	defer log.Close(rows, "closing resource lock rows")
+
+	for rows.Next() {
+		var lock tc.ResourceLock
+		if err := scanLock(rows, &lock); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("scanning resource locks: "+err.Error()))
+			return
+		}
+		locks = append(locks, lock)
+	}
+
+	api.WriteResp(w, r, locks)
+}
+
+// Create is the handler for POST requests to /resource_locks. It acquires a
+// lock for the current user, or renews the lock they already hold on the same
+// resource.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	var req tc.ResourceLockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	ttl, err := req.Validate()
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if req.Takeover && !inf.User.Can(TakeoverPermission) {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("taking over resource locks requires the "+TakeoverPermission+" permission"), nil)
+		return
+	}
+
+	exists := false
+	if err := tx.QueryRow(resourceExistsQueries[req.ResourceType], req.Resource).Scan(&exists); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking existence of %s '%s': %w", req.ResourceType, req.Resource, err))
+		return
+	}
+	if !exists {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no %s named '%s' exists", req.ResourceType, req.Resource), nil)
+		return
+	}
+
+	if _, err := tx.Exec(lockResourceQuery, req.ResourceType, req.Resource); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("serializing resource lock acquisition: "+err.Error()))
+		return
+	}
+	if _, err := tx.Exec(deleteExpiredQuery, req.ResourceType, req.Resource); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting expired resource locks: "+err.Error()))
+		return
+	}
+
+	conflicts, err := getConflicts(tx, req, inf.User.UserName)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if len(conflicts) > 0 && !req.Takeover {
+		api.HandleErr(w, r, tx, http.StatusConflict, dbhelpers.ResourceLockError(conflicts[0]), nil)
+		return
+	}
+	for _, conflict := range conflicts {
+		if _, err := tx.Exec(deleteByIDQuery, conflict.ID); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("releasing resource lock #%d for takeover: %w", conflict.ID, err))
+			return
+		}
+		changeLogMsg := fmt.Sprintf("USER: %s, %s: %s, ACTION: %s lock held by %s taken over", inf.User.UserName, req.ResourceType, req.Resource, lockMode(conflict.Shared), conflict.UserName)
+		api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+	}
+
+	var lock tc.ResourceLock
+	row := tx.QueryRow(upsertQuery, req.ResourceType, req.Resource, inf.User.UserName, req.Shared, req.Message, int64(ttl/time.Second))
+	if err := scanLock(row, &lock); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, fmt.Sprintf("%s lock acquired on %s '%s' until %s", lockMode(lock.Shared), lock.ResourceType, lock.Resource, lock.Expires.Format(time.RFC3339)))
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, lock)
+
+	changeLogMsg := fmt.Sprintf("USER: %s, %s: %s, ACTION: %s lock acquired until %s", inf.User.UserName, lock.ResourceType, lock.Resource, lockMode(lock.Shared), lock.Expires.Format(time.RFC3339))
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+}
+
+// getConflicts returns the unexpired locks of users other than the given user
+// which prevent the requested lock from being acquired.
+func getConflicts(tx *sql.Tx, req tc.ResourceLockRequest, user string) ([]tc.ResourceLock, error) {
+	rows, err := tx.Query(conflictsQuery, req.ResourceType, req.Resource, user, req.Shared)
+	if err != nil {
+		return nil, errors.New("querying conflicting resource locks: " + err.Error())
+	}
+	defer log.Close(rows, "closing conflicting resource lock rows")
+
+	conflicts := []tc.ResourceLock{}
+	for rows.Next() {
+		var lock tc.ResourceLock
+		if err := scanLock(rows, &lock); err != nil {
+			return nil, errors.New("scanning conflicting resource locks: " + err.Error())
+		}
+		conflicts = append(conflicts, lock)
+	}
+	return conflicts, rows.Err()
+}
+
+// Delete is the handler for DELETE requests to /resource_locks. Users may
+// release their own locks, and users with the TakeoverPermission may release
+// anyone's.
+func Delete(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	var lock tc.ResourceLock
+	if err := scanLock(tx.QueryRow(readByIDQuery, id), &lock); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no resource lock exists with id %d", id), nil)
+			return
+		}
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting resource lock #%d: %w", id, err))
+		return
+	}
+
+	forced := lock.UserName != inf.User.UserName
+	if forced && !inf.User.Can(TakeoverPermission) {
+		api.HandleErr(w, r, tx, http.StatusForbidden, fmt.Errorf("resource lock #%d is held by another user; releasing it requires the %s permission", id, TakeoverPermission), nil)
+		return
+	}
+
+	if _, err := tx.Exec(deleteByIDQuery, id); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("deleting resource lock #%d: %w", id, err))
+		return
+	}
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "resource lock released")
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, lock)
+
+	changeLogMsg := fmt.Sprintf("USER: %s, %s: %s, ACTION: %s lock released", lock.UserName, lock.ResourceType, lock.Resource, lockMode(lock.Shared))
+	if forced {
+		changeLogMsg = fmt.Sprintf("USER: %s, %s: %s, ACTION: %s lock held by %s forcibly released", inf.User.UserName, lock.ResourceType, lock.Resource, lockMode(lock.Shared), lock.UserName)
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+}
@@ -0,0 +1,157 @@
+package resourcelock
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault/backends/disabled"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var lockColumns = []string{"id", "resource_type", "resource", "username", "shared", "message", "expires", "last_updated"}
+
+func mockRequest(t *testing.T, method string, body io.Reader, user auth.CurrentUser, db *sqlx.DB) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(method, "resource_locks", body)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	cfg := config.Config{ConfigTrafficOpsGolang: config.ConfigTrafficOpsGolang{DBQueryTimeoutSeconds: 20}}
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, auth.CurrentUserKey, user)
+	ctx = context.WithValue(ctx, "db", db)
+	ctx = context.WithValue(ctx, "context", &cfg)
+	ctx = context.WithValue(ctx, "reqid", uint64(0))
+	var tv trafficvault.TrafficVault = &disabled.Disabled{}
+	ctx = context.WithValue(ctx, api.TrafficVaultContextKey, tv)
+	ctx = context.WithValue(ctx, "pathParams", map[string]string{})
+	return req.WithContext(ctx)
+}
+
+// statusCode returns the status code with which the handler responded to r.
+func statusCode(w *httptest.ResponseRecorder, r *http.Request) int {
+	if code, ok := r.Context().Value(tc.StatusKey).(int); ok {
+		return code
+	}
+	return w.Code
+}
+
+func TestCreateConflict(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	expires := time.Now().Add(time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT EXISTS").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM resource_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT .* FROM resource_lock").WithArgs("topology", "top", "alice", false).WillReturnRows(
+		sqlmock.NewRows(lockColumns).AddRow(1, "topology", "top", "bob", true, nil, expires, time.Now()))
+	mock.ExpectRollback()
+
+	user := auth.CurrentUser{UserName: "alice", ID: 1, PrivLevel: auth.PrivLevelOperations, RoleName: "operations", Capabilities: []string{"RESOURCE-LOCK:CREATE"}}
+	body := strings.NewReader(`{"resourceType": "topology", "resource": "top"}`)
+	req := mockRequest(t, http.MethodPost, body, user, db)
+	w := httptest.NewRecorder()
+	Create(w, req)
+
+	if code := statusCode(w, req); code != http.StatusConflict {
+		t.Errorf("Expected a conflicting lock to result in a %d response, got: %d", http.StatusConflict, code)
+	}
+	if !strings.Contains(w.Body.String(), "user bob currently has a shared lock on topology top") {
+		t.Errorf("Expected the response to name the holder of the conflicting lock, got: %s", w.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateTakeoverWithoutPermission(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	user := auth.CurrentUser{UserName: "alice", ID: 1, PrivLevel: auth.PrivLevelOperations, RoleName: "operations", Capabilities: []string{"RESOURCE-LOCK:CREATE"}}
+	body := strings.NewReader(`{"resourceType": "cdn", "resource": "cdn1", "takeover": true}`)
+	req := mockRequest(t, http.MethodPost, body, user, db)
+	w := httptest.NewRecorder()
+	Create(w, req)
+
+	if code := statusCode(w, req); code != http.StatusForbidden {
+		t.Errorf("Expected a takeover without the %s permission to result in a %d response, got: %d", TakeoverPermission, http.StatusForbidden, code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeleteOtherUsersLock(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .* FROM resource_lock").WithArgs(1).WillReturnRows(
+		sqlmock.NewRows(lockColumns).AddRow(1, "profile", "prof", "bob", false, nil, time.Now().Add(time.Hour), time.Now()))
+	mock.ExpectRollback()
+
+	user := auth.CurrentUser{UserName: "alice", ID: 1, PrivLevel: auth.PrivLevelOperations, RoleName: "operations", Capabilities: []string{"RESOURCE-LOCK:DELETE"}}
+	req := mockRequest(t, http.MethodDelete, nil, user, db)
+	req.URL.RawQuery = "id=1"
+	w := httptest.NewRecorder()
+	Delete(w, req)
+
+	if code := statusCode(w, req); code != http.StatusForbidden {
+		t.Errorf("Expected releasing another user's lock without the %s permission to result in a %d response, got: %d", TakeoverPermission, http.StatusForbidden, code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
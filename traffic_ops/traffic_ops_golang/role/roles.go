@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
@@ -127,6 +128,9 @@ func (role *TORole) Create() (error, error, int) {
 	if *role.PrivLevel > role.ReqInfo.User.PrivLevel {
 		return errors.New("can not create a role with a higher priv level than your own"), nil, http.StatusBadRequest
 	}
+	if userErr := role.checkGrantable(); userErr != nil {
+		return userErr, nil, http.StatusForbidden
+	}
 
 	userErr, sysErr, errCode := api.GenericCreate(role)
 	if userErr != nil || sysErr != nil {
@@ -143,6 +147,19 @@ func (role *TORole) Create() (error, error, int) {
 	return nil, nil, http.StatusOK
 }
 
+// checkGrantable returns an error if the Role has permissions which the
+// current user doesn't, so that users can't give themselves (or anyone else)
+// permissions they don't already have.
+func (role *TORole) checkGrantable() error {
+	if role.Capabilities == nil {
+		return nil
+	}
+	if missing := role.ReqInfo.User.MissingPermissions(*role.Capabilities...); len(missing) > 0 {
+		return errors.New("can not grant permissions you don't have: " + strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 func (role *TORole) createRoleCapabilityAssociations(tx *sqlx.Tx) (error, error, int) {
 	result, err := tx.Exec(associateCapabilities(), role.ID, pq.Array(role.Capabilities))
 	if err != nil {
@@ -194,6 +211,9 @@ func (role *TORole) Update(h http.Header) (error, error, int) {
 	if *role.PrivLevel > role.ReqInfo.User.PrivLevel {
 		return errors.New("can not create a role with a higher priv level than your own"), nil, http.StatusForbidden
 	}
+	if userErr := role.checkGrantable(); userErr != nil {
+		return userErr, nil, http.StatusForbidden
+	}
 	userErr, sysErr, errCode := api.GenericUpdate(h, role)
 	if userErr != nil || sysErr != nil {
 		return userErr, sysErr, errCode
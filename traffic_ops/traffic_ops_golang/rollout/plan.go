@@ -0,0 +1,77 @@
+package rollout
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// rolloutServer is a cache server which a Rollout queues updates on.
+type rolloutServer struct {
+	ID         int
+	HostName   string
+	CacheGroup string
+}
+
+// planWaves splits the given servers, which should be sorted by host name,
+// into the waves of a Rollout.
+//
+// The first wave is the canary wave: all servers in the canary Cache Groups,
+// and canaryPercent percent of the rest, rounded up. If that's no servers,
+// there's no canary wave. The rest are split into waves of wavePercent
+// percent of all servers, rounded up, in the order given.
+func planWaves(servers []rolloutServer, canaryCacheGroups []string, canaryPercent int, wavePercent int) [][]rolloutServer {
+	canaryCGs := make(map[string]struct{}, len(canaryCacheGroups))
+	for _, cg := range canaryCacheGroups {
+		canaryCGs[cg] = struct{}{}
+	}
+
+	canary := []rolloutServer{}
+	rest := []rolloutServer{}
+	for _, server := range servers {
+		if _, ok := canaryCGs[server.CacheGroup]; ok {
+			canary = append(canary, server)
+		} else {
+			rest = append(rest, server)
+		}
+	}
+	numCanary := percentOf(len(rest), canaryPercent)
+	canary = append(canary, rest[:numCanary]...)
+	rest = rest[numCanary:]
+
+	waves := [][]rolloutServer{}
+	if len(canary) > 0 {
+		waves = append(waves, canary)
+	}
+	waveSize := percentOf(len(servers), wavePercent)
+	if waveSize < 1 {
+		waveSize = 1
+	}
+	for len(rest) > 0 {
+		n := waveSize
+		if n > len(rest) {
+			n = len(rest)
+		}
+		waves = append(waves, rest[:n])
+		rest = rest[n:]
+	}
+	return waves
+}
+
+// percentOf returns the given percent of n, rounded up.
+func percentOf(n int, percent int) int {
+	return (n*percent + 99) / 100
+}
@@ -0,0 +1,424 @@
+package rollout
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/util/monitorhlp"
+)
+
+// selectStateQuery selects the state of a running Rollout.
+const selectStateQuery = `
+SELECT
+	r.cdn,
+	r.username,
+	r.wave,
+	r.wave_started,
+	r.wave_completed,
+	r.wave_wait_seconds,
+	r.wave_timeout_seconds,
+	r.check_health,
+	r.max_unavailable_percent,
+	now()
+FROM rollout r
+WHERE r.id = $1 AND r.status = 'running'
+`
+
+// selectStateForUpdateQuery selects and locks a running Rollout to progress
+// it. Other Traffic Ops instances progressing it at the same time skip it.
+const selectStateForUpdateQuery = selectStateQuery + `FOR UPDATE SKIP LOCKED
+`
+
+const selectPendingQuery = `
+SELECT COUNT(*)
+FROM rollout_server rs
+JOIN server s ON s.id = rs.server
+JOIN status st ON st.id = s.status
+WHERE rs.rollout = $1 AND rs.wave = $2 AND s.upd_pending AND st.name <> '` + string(tc.CacheStatusOffline) + `'
+`
+
+// selectMonitoredQuery selects the host names of the servers of a wave which
+// Traffic Monitor reports the health of.
+const selectMonitoredQuery = `
+SELECT s.host_name
+FROM rollout_server rs
+JOIN server s ON s.id = rs.server
+JOIN status st ON st.id = s.status
+WHERE rs.rollout = $1 AND rs.wave = $2 AND st.name = '` + string(tc.CacheStatusReported) + `'
+`
+
+// step is what progressing a Rollout does next.
+type step int
+
+const (
+	// stepNone is waiting for the current wave to apply its updates, or for
+	// its wait to elapse.
+	stepNone step = iota
+	// stepQueueWave is queueing updates on the current wave.
+	stepQueueWave
+	// stepCompleteWave is marking the current wave completed, because all its
+	// servers applied their updates.
+	stepCompleteWave
+	// stepTimeOut is failing the Rollout, because the current wave didn't
+	// apply its updates within its timeout.
+	stepTimeOut
+	// stepNextWave is checking the health of the completed current wave, if
+	// the Rollout checks health, and moving on to the next wave, or
+	// completing the Rollout if there are no more.
+	stepNextWave
+)
+
+// rolloutState is the state of a running Rollout, as needed to progress it.
+type rolloutState struct {
+	ID                    int
+	CDN                   string
+	Username              string
+	Wave                  int
+	NumWaves              int
+	Pending               int
+	WaveStarted           *time.Time
+	WaveCompleted         *time.Time
+	WaveWaitSeconds       int
+	WaveTimeoutSeconds    int
+	CheckHealth           bool
+	MaxUnavailablePercent int
+}
+
+// nextStep returns what to do next to progress a Rollout in the given state,
+// at the given time.
+func nextStep(s rolloutState, now time.Time) step {
+	if s.WaveStarted == nil {
+		return stepQueueWave
+	}
+	if s.WaveCompleted == nil {
+		if s.Pending == 0 {
+			return stepCompleteWave
+		}
+		if now.Sub(*s.WaveStarted) >= time.Duration(s.WaveTimeoutSeconds)*time.Second {
+			return stepTimeOut
+		}
+		return stepNone
+	}
+	if now.Sub(*s.WaveCompleted) >= time.Duration(s.WaveWaitSeconds)*time.Second {
+		return stepNextWave
+	}
+	return stepNone
+}
+
+// StartProgressor starts a goroutine which progresses all running Rollouts,
+// every rollout interval of the given config, for as long as Traffic Ops
+// runs.
+func StartProgressor(db *sql.DB, cfg config.Config) {
+	interval := time.Duration(cfg.RolloutIntervalSeconds) * time.Second
+	dbTimeout := time.Duration(cfg.DBQueryTimeoutSeconds) * time.Second
+	go func() {
+		for range time.Tick(interval) {
+			progressRollouts(db, dbTimeout)
+		}
+	}()
+}
+
+// progressRollouts progresses all running Rollouts, each in its own
+// transaction. Errors are logged.
+func progressRollouts(db *sql.DB, dbTimeout time.Duration) {
+	ids, err := getRunningIDs(db, dbTimeout)
+	if err != nil {
+		log.Errorln("rollout progressor: " + err.Error())
+		return
+	}
+	for _, id := range ids {
+		if err := progressRolloutDB(db, dbTimeout, id); err != nil {
+			log.Errorf("rollout progressor: rollout %d: %s\n", id, err.Error())
+		}
+	}
+}
+
+func getRunningIDs(db *sql.DB, dbTimeout time.Duration) ([]int, error) {
+	dbCtx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	rows, err := db.QueryContext(dbCtx, `SELECT id FROM rollout WHERE status = 'running' ORDER BY id`)
+	if err != nil {
+		return nil, errors.New("querying running rollouts: " + err.Error())
+	}
+	defer log.Close(rows, "closing running rollout rows")
+	ids := []int{}
+	for rows.Next() {
+		id := 0
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.New("scanning running rollouts: " + err.Error())
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating running rollouts: " + err.Error())
+	}
+	return ids, nil
+}
+
+// progressRolloutDB creates a transaction to pass to progressRollout, and
+// commits it if progressRollout succeeds. If the Rollout is due to check the
+// health of its current wave, Traffic Monitor is asked for it first, so that
+// the Rollout isn't locked while waiting on Traffic Monitor.
+func progressRolloutDB(db *sql.DB, dbTimeout time.Duration, id int) error {
+	health, err := getWaveHealth(db, dbTimeout, id)
+	if err != nil {
+		return err
+	}
+	dbCtx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	tx, err := db.BeginTx(dbCtx, nil)
+	if err != nil {
+		return errors.New("beginning tx: " + err.Error())
+	}
+	txCommit := false
+	defer dbhelpers.CommitIf(tx, &txCommit)
+	if err := progressRollout(tx, id, health); err != nil {
+		return err
+	}
+	txCommit = true
+	return nil
+}
+
+// waveHealth is the health of the servers of a wave of a Rollout, as
+// reported by Traffic Monitor, or the error getting it.
+type waveHealth struct {
+	Wave     int
+	CRStates tc.CRStates
+	Err      error
+}
+
+// getWaveHealth gets the health of the current wave of the Rollout with the
+// given ID from Traffic Monitor, if the Rollout is due to check it. It
+// returns nil if it isn't. The transaction used to find the Rollout's monitor
+// is closed before Traffic Monitor is requested.
+func getWaveHealth(db *sql.DB, dbTimeout time.Duration, id int) (*waveHealth, error) {
+	dbCtx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	tx, err := db.BeginTx(dbCtx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.New("beginning health tx: " + err.Error())
+	}
+	s, now, ok, err := getState(tx, selectStateQuery, id)
+	if err != nil || !ok || !s.CheckHealth || nextStep(s, now) != stepNextWave {
+		tx.Rollback()
+		return nil, err
+	}
+	monitorFQDN, client, err := getMonitor(tx, s.CDN)
+	tx.Rollback()
+	if err != nil {
+		return &waveHealth{Wave: s.Wave, Err: err}, nil
+	}
+	crStates, err := monitorhlp.GetCRStates(monitorFQDN, client)
+	if err != nil {
+		return &waveHealth{Wave: s.Wave, Err: errors.New("getting CRStates from monitor '" + monitorFQDN + "': " + err.Error())}, nil
+	}
+	return &waveHealth{Wave: s.Wave, CRStates: crStates}, nil
+}
+
+// getMonitor returns the FQDN of an online Traffic Monitor of the given CDN,
+// and a client to request it with.
+func getMonitor(tx *sql.Tx, cdn string) (string, *http.Client, error) {
+	monitors, err := monitorhlp.GetURLs(tx)
+	if err != nil {
+		return "", nil, errors.New("getting monitor URLs: " + err.Error())
+	}
+	monitorFQDN, ok := monitors[tc.CDNName(cdn)]
+	if !ok {
+		return "", nil, errors.New("cdn has no online monitor")
+	}
+	client, err := monitorhlp.GetClient(tx)
+	if err != nil {
+		return "", nil, errors.New("getting monitor client: " + err.Error())
+	}
+	return monitorFQDN, client, nil
+}
+
+// getState returns the state of the running Rollout with the given ID,
+// selected with the given query, along with the current database time, and
+// whether it was found.
+func getState(tx *sql.Tx, query string, id int) (rolloutState, time.Time, bool, error) {
+	s := rolloutState{ID: id}
+	now := time.Time{}
+	if err := tx.QueryRow(query, id).Scan(
+		&s.CDN,
+		&s.Username,
+		&s.Wave,
+		&s.WaveStarted,
+		&s.WaveCompleted,
+		&s.WaveWaitSeconds,
+		&s.WaveTimeoutSeconds,
+		&s.CheckHealth,
+		&s.MaxUnavailablePercent,
+		&now,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return s, now, false, nil
+		}
+		return s, now, false, errors.New("querying state: " + err.Error())
+	}
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(wave) + 1, 0) FROM rollout_server WHERE rollout = $1`, id).Scan(&s.NumWaves); err != nil {
+		return s, now, false, errors.New("querying number of waves: " + err.Error())
+	}
+	if err := tx.QueryRow(selectPendingQuery, id, s.Wave).Scan(&s.Pending); err != nil {
+		return s, now, false, errors.New("querying pending servers: " + err.Error())
+	}
+	return s, now, true, nil
+}
+
+// progressRollout takes the next step of the Rollout with the given ID, if
+// it's still running and no other Traffic Ops is progressing it. The health
+// of its current wave is taken from the given waveHealth, if it's needed.
+func progressRollout(tx *sql.Tx, id int, health *waveHealth) error {
+	s, now, ok, err := getState(tx, selectStateForUpdateQuery, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil // no longer running, or locked by another Traffic Ops
+	}
+
+	switch nextStep(s, now) {
+	case stepQueueWave:
+		return queueWave(tx, s, s.Wave)
+	case stepCompleteWave:
+		if _, err := tx.Exec(`UPDATE rollout SET wave_completed = now() WHERE id = $1`, id); err != nil {
+			return errors.New("completing wave: " + err.Error())
+		}
+		return changeLog(tx, s, fmt.Sprintf("Rollout wave %d of %d applied updates", s.Wave, s.NumWaves))
+	case stepTimeOut:
+		return fail(tx, s, fmt.Sprintf("wave %d timed out with %d servers still pending after %d seconds", s.Wave, s.Pending, s.WaveTimeoutSeconds))
+	case stepNextWave:
+		if s.CheckHealth {
+			if health == nil || health.Wave != s.Wave {
+				return nil // the Rollout changed since its health was checked; check it next time
+			}
+			if health.Err != nil {
+				// Traffic Monitor may be briefly unreachable, so keep trying until the wave would have timed out.
+				if now.Sub(*s.WaveCompleted) >= time.Duration(s.WaveWaitSeconds+s.WaveTimeoutSeconds)*time.Second {
+					return fail(tx, s, "checking health of wave "+fmt.Sprint(s.Wave)+": "+health.Err.Error())
+				}
+				return errors.New("checking health of wave: " + health.Err.Error())
+			}
+			if healthy, msg, err := checkHealth(tx, s, health.CRStates); err != nil {
+				return errors.New("checking health of wave: " + err.Error())
+			} else if !healthy {
+				return fail(tx, s, msg)
+			}
+		}
+		if s.Wave+1 >= s.NumWaves {
+			if _, err := tx.Exec(`UPDATE rollout SET status = 'completed' WHERE id = $1`, id); err != nil {
+				return errors.New("completing rollout: " + err.Error())
+			}
+			return changeLog(tx, s, "Rollout completed")
+		}
+		return queueWave(tx, s, s.Wave+1)
+	}
+	return nil
+}
+
+// queueWave makes the given wave the current wave of a Rollout, and queues
+// updates on its servers.
+func queueWave(tx *sql.Tx, s rolloutState, wave int) error {
+	if _, err := tx.Exec(`UPDATE rollout SET wave = $2, wave_started = now(), wave_completed = NULL WHERE id = $1`, s.ID, wave); err != nil {
+		return errors.New("starting wave: " + err.Error())
+	}
+	res, err := tx.Exec(queueWaveQuery, s.ID, wave)
+	if err != nil {
+		return errors.New("queueing updates on wave: " + err.Error())
+	}
+	queued, err := res.RowsAffected()
+	if err != nil {
+		return errors.New("getting number of servers queued: " + err.Error())
+	}
+	return changeLog(tx, s, fmt.Sprintf("Rollout queued updates on %d servers of wave %d of %d", queued, wave, s.NumWaves))
+}
+
+// fail fails a Rollout with the given message.
+func fail(tx *sql.Tx, s rolloutState, msg string) error {
+	if _, err := tx.Exec(`UPDATE rollout SET status = 'failed', message = $2 WHERE id = $1`, s.ID, msg); err != nil {
+		return errors.New("failing rollout: " + err.Error())
+	}
+	log.Warnf("rollout %d of cdn %s failed: %s\n", s.ID, s.CDN, msg)
+	return changeLog(tx, s, "Rollout failed: "+msg)
+}
+
+// changeLog writes a change log message for a Rollout, as the user who
+// created it, since the progressor has no user of its own.
+func changeLog(tx *sql.Tx, s rolloutState, action string) error {
+	msg := fmt.Sprintf("ROLLOUT: %d, CDN: %s, ACTION: %s", s.ID, s.CDN, action)
+	if _, err := tx.Exec(`INSERT INTO log (level, message, tm_user) VALUES ($1, $2, (SELECT id FROM tm_user WHERE username = $3))`, api.ApiChange, msg, s.Username); err != nil {
+		return errors.New("inserting change log: " + err.Error())
+	}
+	return nil
+}
+
+// checkHealth returns whether few enough servers of the current wave of a
+// Rollout are unavailable in the given CRStates, and if not, why not. Only
+// REPORTED servers are checked, since Traffic Monitor doesn't poll others.
+func checkHealth(tx *sql.Tx, s rolloutState, crStates tc.CRStates) (bool, string, error) {
+	rows, err := tx.Query(selectMonitoredQuery, s.ID, s.Wave)
+	if err != nil {
+		return false, "", errors.New("querying monitored servers: " + err.Error())
+	}
+	defer log.Close(rows, "closing monitored server rows")
+	hostNames := []string{}
+	for rows.Next() {
+		hostName := ""
+		if err := rows.Scan(&hostName); err != nil {
+			return false, "", errors.New("scanning monitored servers: " + err.Error())
+		}
+		hostNames = append(hostNames, hostName)
+	}
+	if err := rows.Err(); err != nil {
+		return false, "", errors.New("iterating monitored servers: " + err.Error())
+	}
+	if len(hostNames) == 0 {
+		return true, "", nil
+	}
+
+	unavailable := unavailableCaches(hostNames, crStates)
+	if len(unavailable)*100 > s.MaxUnavailablePercent*len(hostNames) {
+		return false, fmt.Sprintf("%d of %d monitored servers of wave %d are unavailable, more than the maximum %d%%: %v", len(unavailable), len(hostNames), s.Wave, s.MaxUnavailablePercent, unavailable), nil
+	}
+	return true, "", nil
+}
+
+// unavailableCaches returns which of the given caches are unavailable, or
+// missing, in the given CRStates.
+func unavailableCaches(hostNames []string, crStates tc.CRStates) []string {
+	unavailable := []string{}
+	for _, hostName := range hostNames {
+		if state, ok := crStates.Caches[tc.CacheName(hostName)]; !ok || !state.IsAvailable {
+			unavailable = append(unavailable, hostName)
+		}
+	}
+	return unavailable
+}
@@ -0,0 +1,577 @@
+// Package rollout contains the handlers for Rollouts, which queue updates on
+// the cache servers of a CDN in waves, and the background job which
+// progresses them.
+package rollout
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+)
+
+const readQuery = `
+SELECT
+	r.id,
+	r.cdn,
+	r.topology,
+	r.username,
+	r.status,
+	r.canary_percent,
+	r.canary_cachegroups,
+	r.wave_percent,
+	r.wave_wait_seconds,
+	r.wave_timeout_seconds,
+	r.check_health,
+	r.max_unavailable_percent,
+	r.wave,
+	r.wave_started,
+	r.wave_completed,
+	r.message,
+	r.created,
+	r.last_updated
+FROM rollout r
+`
+
+// readWavesQuery selects the servers of the given Rollouts. A server is
+// pending if it hasn't applied its queued updates. OFFLINE servers aren't
+// expected to apply them, so are never pending.
+const readWavesQuery = `
+SELECT rs.rollout, rs.wave, s.host_name, (s.upd_pending AND st.name <> '` + string(tc.CacheStatusOffline) + `')
+FROM rollout_server rs
+JOIN server s ON s.id = rs.server
+JOIN status st ON st.id = s.status
+WHERE rs.rollout = ANY($1)
+ORDER BY rs.rollout, rs.wave, s.host_name
+`
+
+// selectServersQuery selects the cache servers of a CDN, optionally only those
+// in the Cache Groups of a Topology.
+const selectServersQuery = `
+SELECT s.id, s.host_name, cg.name
+FROM server s
+JOIN type t ON t.id = s.type
+JOIN cachegroup cg ON cg.id = s.cachegroup
+JOIN cdn ON cdn.id = s.cdn_id
+WHERE cdn.name = $1
+AND (t.name LIKE '` + tc.EdgeTypePrefix + `%' OR t.name LIKE '` + tc.MidTypePrefix + `%')
+AND ($2::text IS NULL OR cg.name IN (SELECT cachegroup FROM topology_cachegroup WHERE topology = $2))
+ORDER BY s.host_name
+`
+
+const insertQuery = `
+INSERT INTO rollout (
+	cdn,
+	topology,
+	username,
+	canary_percent,
+	canary_cachegroups,
+	wave_percent,
+	wave_wait_seconds,
+	wave_timeout_seconds,
+	check_health,
+	max_unavailable_percent,
+	wave_started
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, now())
+RETURNING id
+`
+
+const insertServersQuery = `
+INSERT INTO rollout_server (rollout, server, wave)
+SELECT $1, s.server, s.wave FROM UNNEST($2::bigint[], $3::integer[]) AS s(server, wave)
+`
+
+// queueWaveQuery queues updates on the servers of a wave of a Rollout.
+const queueWaveQuery = `
+UPDATE server SET upd_pending = TRUE
+WHERE id IN (SELECT server FROM rollout_server WHERE rollout = $1 AND wave = $2)
+`
+
+const pauseQuery = `UPDATE rollout SET status = 'paused', message = $2 WHERE id = $1`
+
+// resumeQuery resumes a Rollout, restarting the timeout of its current wave
+// if the wave hasn't completed, or its wait if it has.
+const resumeQuery = `
+UPDATE rollout SET
+	status = 'running',
+	message = $2,
+	wave_started = CASE WHEN wave_started IS NOT NULL AND wave_completed IS NULL THEN now() ELSE wave_started END,
+	wave_completed = CASE WHEN wave_completed IS NOT NULL THEN now() ELSE NULL END
+WHERE id = $1
+`
+
+const abortQuery = `UPDATE rollout SET status = 'aborted', message = $2 WHERE id = $1`
+
+// Read is the handler for GET requests to /rollouts.
+func Read(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cols := map[string]dbhelpers.WhereColumnInfo{
+		"id":     {Column: "r.id", Checker: api.IsInt},
+		"cdn":    {Column: "r.cdn", Checker: nil},
+		"status": {Column: "r.status", Checker: nil},
+	}
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, cols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+	if orderBy == "" {
+		orderBy = "\nORDER BY r.id"
+	}
+
+	rollouts, err := getRollouts(inf.Tx, readQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, rollouts)
+}
+
+// Create is the handler for POST requests to /rollouts.
+//
+// It splits the cache servers of the CDN into waves, and immediately queues
+// updates on the first.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	req := tc.RolloutRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("malformed JSON: "+err.Error()), nil)
+		return
+	}
+	if err := validateRequest(req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if req.WaveTimeoutSeconds == 0 {
+		req.WaveTimeoutSeconds = tc.DefaultRolloutWaveTimeoutSeconds
+	}
+	if req.CanaryCacheGroups == nil {
+		req.CanaryCacheGroups = []string{}
+	}
+	if userErr, sysErr, errCode := checkRequestRefs(tx, req); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserHasCdnLock(tx, req.CDN, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if id, ok, err := dbhelpers.GetActiveRolloutID(tx, req.CDN); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if ok {
+		api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("cdn '%s' already has rollout %d, which must complete or be aborted first", req.CDN, id), nil)
+		return
+	}
+
+	servers, err := getServers(tx, req.CDN, req.Topology)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if len(servers) == 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("no cache servers to roll out updates to"), nil)
+		return
+	}
+	waves := planWaves(servers, req.CanaryCacheGroups, req.CanaryPercent, req.WavePercent)
+
+	id := 0
+	if err := tx.QueryRow(insertQuery,
+		req.CDN,
+		req.Topology,
+		inf.User.UserName,
+		req.CanaryPercent,
+		pq.Array(req.CanaryCacheGroups),
+		req.WavePercent,
+		req.WaveWaitSeconds,
+		req.WaveTimeoutSeconds,
+		req.CheckHealth,
+		req.MaxUnavailablePercent,
+	).Scan(&id); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	serverIDs := []int64{}
+	serverWaves := []int64{}
+	for wave, waveServers := range waves {
+		for _, server := range waveServers {
+			serverIDs = append(serverIDs, int64(server.ID))
+			serverWaves = append(serverWaves, int64(wave))
+		}
+	}
+	if _, err := tx.Exec(insertServersQuery, id, pq.Array(serverIDs), pq.Array(serverWaves)); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("inserting rollout servers: "+err.Error()))
+		return
+	}
+	if _, err := tx.Exec(queueWaveQuery, id, 0); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("queueing updates on rollout wave 0: "+err.Error()))
+		return
+	}
+
+	rollout, ok, err := getRollout(inf.Tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("rollout %d not found after inserting it", id))
+		return
+	}
+
+	msg := fmt.Sprintf("ROLLOUT: %d, CDN: %s, ACTION: Rollout of updates to %d servers in %d waves created", id, req.CDN, len(servers), len(waves))
+	api.CreateChangeLogRawTx(api.ApiChange, msg, inf.User, tx)
+	alerts := tc.CreateAlerts(tc.SuccessLevel, fmt.Sprintf("rollout created, updates queued on %d servers of wave 0", len(waves[0])))
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, rollout)
+}
+
+// Pause is the handler for POST requests to /rollouts/{id}/pause.
+//
+// A paused Rollout doesn't queue updates on more waves until it's resumed.
+// Servers whose updates are already queued still apply them.
+func Pause(w http.ResponseWriter, r *http.Request) {
+	changeStatus(w, r, "pause", "paused", pauseQuery, true, tc.RolloutStatusRunning)
+}
+
+// Resume is the handler for POST requests to /rollouts/{id}/resume.
+//
+// A paused or failed Rollout may be resumed. The current wave's timeout, or
+// its wait if it completed, is restarted.
+func Resume(w http.ResponseWriter, r *http.Request) {
+	changeStatus(w, r, "resume", "resumed", resumeQuery, false, tc.RolloutStatusPaused, tc.RolloutStatusFailed)
+}
+
+// Abort is the handler for POST requests to /rollouts/{id}/abort.
+//
+// An aborted Rollout never queues updates on more waves. Servers whose
+// updates are already queued still apply them.
+func Abort(w http.ResponseWriter, r *http.Request) {
+	changeStatus(w, r, "abort", "aborted", abortQuery, true, tc.RolloutStatusRunning, tc.RolloutStatusPaused, tc.RolloutStatusFailed)
+}
+
+// changeStatus changes the status of the Rollout with the ID in the request
+// path with the given query, if its status is one of the given from
+// statuses. If withMessage, the message of the Rollout is set to which user
+// made the change, otherwise it's cleared.
+func changeStatus(w http.ResponseWriter, r *http.Request, action string, pastAction string, query string, withMessage bool, from ...tc.RolloutStatus) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	cdn := ""
+	status := tc.RolloutStatus("")
+	if err := tx.QueryRow(`SELECT cdn, status FROM rollout WHERE id = $1`, id).Scan(&cdn, &status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no rollout exists with id %d", id), nil)
+			return
+		}
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("querying rollout status: "+err.Error()))
+		return
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserHasCdnLock(tx, cdn, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if !statusIn(status, from) {
+		api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("rollout %d is %s, and can't be %s", id, status, pastAction), nil)
+		return
+	}
+
+	var message *string
+	if withMessage {
+		msg := pastAction + " by " + inf.User.UserName
+		message = &msg
+	}
+	if _, err := tx.Exec(query, id, message); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New(action+" rollout: "+err.Error()))
+		return
+	}
+
+	rollout, ok, err := getRollout(inf.Tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("rollout %d not found after changing its status", id))
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("ROLLOUT: %d, CDN: %s, ACTION: Rollout %s", id, cdn, pastAction), inf.User, tx)
+	api.WriteAlertsObj(w, r, http.StatusOK, tc.CreateAlerts(tc.SuccessLevel, "rollout "+pastAction), rollout)
+}
+
+func statusIn(status tc.RolloutStatus, statuses []tc.RolloutStatus) bool {
+	for _, s := range statuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRequest validates the fields of a Rollout request, without
+// checking that the things it refers to exist.
+func validateRequest(req tc.RolloutRequest) error {
+	errs := validation.Errors{
+		"cdn":                   validation.Validate(req.CDN, validation.Required),
+		"canaryPercent":         validation.Validate(req.CanaryPercent, validation.Min(0), validation.Max(100)),
+		"wavePercent":           validation.Validate(req.WavePercent, validation.Required, validation.Min(1), validation.Max(100)),
+		"waveWaitSeconds":       validation.Validate(req.WaveWaitSeconds, validation.Min(0)),
+		"waveTimeoutSeconds":    validation.Validate(req.WaveTimeoutSeconds, validation.Min(0)),
+		"maxUnavailablePercent": validation.Validate(req.MaxUnavailablePercent, validation.Min(0), validation.Max(100)),
+	}
+	if req.Topology != nil && *req.Topology == "" {
+		errs["topology"] = errors.New("must not be empty if given")
+	}
+	for _, cg := range req.CanaryCacheGroups {
+		if cg == "" {
+			errs["canaryCacheGroups"] = errors.New("must not contain empty names")
+			break
+		}
+	}
+	return util.JoinErrs(tovalidate.ToErrors(errs))
+}
+
+// checkRequestRefs checks that the CDN, Topology, and Cache Groups a Rollout
+// request refers to exist. It returns any user error, system error, and the
+// HTTP status code to return.
+func checkRequestRefs(tx *sql.Tx, req tc.RolloutRequest) (error, error, int) {
+	if _, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(req.CDN)); err != nil {
+		return nil, errors.New("checking cdn existence: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return fmt.Errorf("no cdn exists by the name of %s", req.CDN), nil, http.StatusBadRequest
+	}
+	if req.Topology != nil {
+		if ok, err := dbhelpers.TopologyExists(tx, *req.Topology); err != nil {
+			return nil, err, http.StatusInternalServerError
+		} else if !ok {
+			return fmt.Errorf("no topology exists by the name of %s", *req.Topology), nil, http.StatusBadRequest
+		}
+	}
+	if len(req.CanaryCacheGroups) == 0 {
+		return nil, nil, http.StatusOK
+	}
+
+	rows, err := tx.Query(`SELECT name FROM cachegroup WHERE name = ANY($1)`, pq.Array(req.CanaryCacheGroups))
+	if err != nil {
+		return nil, errors.New("querying canary cache groups: " + err.Error()), http.StatusInternalServerError
+	}
+	defer log.Close(rows, "closing canary cache group rows")
+	found := map[string]struct{}{}
+	for rows.Next() {
+		name := ""
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.New("scanning canary cache groups: " + err.Error()), http.StatusInternalServerError
+		}
+		found[name] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating canary cache groups: " + err.Error()), http.StatusInternalServerError
+	}
+	missing := []string{}
+	for _, cg := range req.CanaryCacheGroups {
+		if _, ok := found[cg]; !ok {
+			missing = append(missing, cg)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New("no cachegroups exist by the names: " + strings.Join(missing, ", ")), nil, http.StatusBadRequest
+	}
+	return nil, nil, http.StatusOK
+}
+
+// getServers returns the cache servers of the given CDN, sorted by host name.
+// If topology isn't nil, only servers in its Cache Groups are returned.
+func getServers(tx *sql.Tx, cdn string, topology *string) ([]rolloutServer, error) {
+	rows, err := tx.Query(selectServersQuery, cdn, topology)
+	if err != nil {
+		return nil, errors.New("querying rollout servers: " + err.Error())
+	}
+	defer log.Close(rows, "closing rollout server rows")
+	servers := []rolloutServer{}
+	for rows.Next() {
+		s := rolloutServer{}
+		if err := rows.Scan(&s.ID, &s.HostName, &s.CacheGroup); err != nil {
+			return nil, errors.New("scanning rollout servers: " + err.Error())
+		}
+		servers = append(servers, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating rollout servers: " + err.Error())
+	}
+	return servers, nil
+}
+
+// getRollout returns the Rollout with the given ID, and whether it exists.
+func getRollout(tx *sqlx.Tx, id int) (tc.Rollout, bool, error) {
+	rollouts, err := getRollouts(tx, readQuery+"WHERE r.id = :id", map[string]interface{}{"id": id})
+	if err != nil {
+		return tc.Rollout{}, false, err
+	}
+	if len(rollouts) == 0 {
+		return tc.Rollout{}, false, nil
+	}
+	return rollouts[0], true, nil
+}
+
+// getRollouts returns the Rollouts selected by the given query, a readQuery
+// with optional clauses, with their waves.
+func getRollouts(tx *sqlx.Tx, query string, queryValues map[string]interface{}) ([]tc.Rollout, error) {
+	rows, err := tx.NamedQuery(query, queryValues)
+	if err != nil {
+		return nil, errors.New("querying rollouts: " + err.Error())
+	}
+	defer log.Close(rows, "closing rollout rows")
+
+	rollouts := []tc.Rollout{}
+	for rows.Next() {
+		ro := tc.Rollout{}
+		if err := rows.Scan(
+			&ro.ID,
+			&ro.CDN,
+			&ro.Topology,
+			&ro.Username,
+			&ro.Status,
+			&ro.CanaryPercent,
+			pq.Array(&ro.CanaryCacheGroups),
+			&ro.WavePercent,
+			&ro.WaveWaitSeconds,
+			&ro.WaveTimeoutSeconds,
+			&ro.CheckHealth,
+			&ro.MaxUnavailablePercent,
+			&ro.Wave,
+			&ro.WaveStarted,
+			&ro.WaveCompleted,
+			&ro.Message,
+			&ro.Created,
+			&ro.LastUpdated,
+		); err != nil {
+			return nil, errors.New("scanning rollouts: " + err.Error())
+		}
+		ro.Waves = []tc.RolloutWave{}
+		rollouts = append(rollouts, ro)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating rollouts: " + err.Error())
+	}
+	rows.Close()
+
+	if err := getWaves(tx.Tx, rollouts); err != nil {
+		return nil, err
+	}
+	return rollouts, nil
+}
+
+// getWaves sets the waves of the given Rollouts. Servers are only counted as
+// pending in waves which have been queued.
+func getWaves(tx *sql.Tx, rollouts []tc.Rollout) error {
+	if len(rollouts) == 0 {
+		return nil
+	}
+	byID := make(map[int]*tc.Rollout, len(rollouts))
+	ids := make([]int64, 0, len(rollouts))
+	for i := range rollouts {
+		byID[rollouts[i].ID] = &rollouts[i]
+		ids = append(ids, int64(rollouts[i].ID))
+	}
+
+	rows, err := tx.Query(readWavesQuery, pq.Array(ids))
+	if err != nil {
+		return errors.New("querying rollout waves: " + err.Error())
+	}
+	defer log.Close(rows, "closing rollout wave rows")
+	for rows.Next() {
+		id := 0
+		wave := 0
+		hostName := ""
+		pending := false
+		if err := rows.Scan(&id, &wave, &hostName, &pending); err != nil {
+			return errors.New("scanning rollout waves: " + err.Error())
+		}
+		ro, ok := byID[id]
+		if !ok {
+			continue
+		}
+		for len(ro.Waves) <= wave {
+			ro.Waves = append(ro.Waves, tc.RolloutWave{Wave: len(ro.Waves), Servers: []string{}})
+		}
+		ro.Waves[wave].Servers = append(ro.Waves[wave].Servers, hostName)
+		if pending && (wave < ro.Wave || (wave == ro.Wave && ro.WaveStarted != nil)) {
+			ro.Waves[wave].Pending++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.New("iterating rollout waves: " + err.Error())
+	}
+	return nil
+}
+
+// CheckQueueAllowed checks that updates may be queued or dequeued on the
+// servers of the given CDN by means other than a Rollout, which they may not
+// while the CDN has an active Rollout. It returns any user error, system error, and the
+// HTTP status code to return.
+func CheckQueueAllowed(tx *sql.Tx, cdn string) (error, error, int) {
+	id, ok, err := dbhelpers.GetActiveRolloutID(tx, cdn)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	if ok {
+		return errors.New("cdn '" + cdn + "' has rollout " + strconv.Itoa(id) + " in progress, which must complete or be aborted before updates are queued or dequeued on its servers"), nil, http.StatusConflict
+	}
+	return nil, nil, http.StatusOK
+}
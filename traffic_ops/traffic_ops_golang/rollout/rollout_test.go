@@ -0,0 +1,141 @@
+package rollout
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestPlanWaves(t *testing.T) {
+	servers := []rolloutServer{}
+	for i, cg := range []string{"a", "b", "a", "c", "b", "a", "c", "b", "a", "c"} {
+		servers = append(servers, rolloutServer{ID: i, HostName: "edge" + string(rune('0'+i)), CacheGroup: cg})
+	}
+	waveIDs := func(waves [][]rolloutServer) [][]int {
+		ids := [][]int{}
+		for _, wave := range waves {
+			waveIDs := []int{}
+			for _, server := range wave {
+				waveIDs = append(waveIDs, server.ID)
+			}
+			ids = append(ids, waveIDs)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name          string
+		canaryCGs     []string
+		canaryPercent int
+		wavePercent   int
+		expected      [][]int
+	}{
+		{"no canary", nil, 0, 30, [][]int{{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, {9}}},
+		{"canary percent rounds up", nil, 5, 50, [][]int{{0}, {1, 2, 3, 4, 5}, {6, 7, 8, 9}}},
+		{"canary cache groups", []string{"c"}, 0, 50, [][]int{{3, 6, 9}, {0, 1, 2, 4, 5}, {7, 8}}},
+		{"canary cache groups and percent", []string{"c"}, 50, 100, [][]int{{3, 6, 9, 0, 1, 2, 4}, {5, 7, 8}}},
+		{"all canary", []string{"a", "b", "c"}, 0, 10, [][]int{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}}},
+		{"one server per wave", nil, 0, 1, [][]int{{0}, {1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}, {9}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := waveIDs(planWaves(servers, test.canaryCGs, test.canaryPercent, test.wavePercent))
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected waves %v, actual %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNextStep(t *testing.T) {
+	now := time.Now()
+	ago := func(secs int) *time.Time {
+		t := now.Add(-time.Duration(secs) * time.Second)
+		return &t
+	}
+	state := rolloutState{NumWaves: 3, WaveWaitSeconds: 60, WaveTimeoutSeconds: 600}
+
+	tests := []struct {
+		name          string
+		waveStarted   *time.Time
+		waveCompleted *time.Time
+		pending       int
+		expected      step
+	}{
+		{"not queued", nil, nil, 5, stepQueueWave},
+		{"pending", ago(10), nil, 5, stepNone},
+		{"applied", ago(10), nil, 0, stepCompleteWave},
+		{"timed out", ago(600), nil, 1, stepTimeOut},
+		{"applied after timeout", ago(700), nil, 0, stepCompleteWave},
+		{"waiting", ago(100), ago(59), 0, stepNone},
+		{"waited", ago(100), ago(60), 0, stepNextWave},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := state
+			s.WaveStarted = test.waveStarted
+			s.WaveCompleted = test.waveCompleted
+			s.Pending = test.pending
+			if actual := nextStep(s, now); actual != test.expected {
+				t.Errorf("expected step %d, actual %d", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestUnavailableCaches(t *testing.T) {
+	crStates := tc.CRStates{Caches: map[tc.CacheName]tc.IsAvailable{
+		"up":   {IsAvailable: true},
+		"down": {IsAvailable: false},
+	}}
+	actual := unavailableCaches([]string{"up", "down", "missing"}, crStates)
+	if expected := []string{"down", "missing"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected unavailable %v, actual %v", expected, actual)
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	valid := tc.RolloutRequest{CDN: "cdn1", CanaryPercent: 10, WavePercent: 25, MaxUnavailablePercent: 5}
+	if err := validateRequest(valid); err != nil {
+		t.Errorf("expected valid request to be valid, actual error: %v", err)
+	}
+
+	invalid := []tc.RolloutRequest{
+		{WavePercent: 25},
+		{CDN: "cdn1"},
+		{CDN: "cdn1", WavePercent: 101},
+		{CDN: "cdn1", WavePercent: 25, CanaryPercent: -1},
+		{CDN: "cdn1", WavePercent: 25, WaveWaitSeconds: -1},
+		{CDN: "cdn1", WavePercent: 25, WaveTimeoutSeconds: -1},
+		{CDN: "cdn1", WavePercent: 25, MaxUnavailablePercent: 200},
+		{CDN: "cdn1", WavePercent: 25, Topology: util.StrPtr("")},
+		{CDN: "cdn1", WavePercent: 25, CanaryCacheGroups: []string{"cg", ""}},
+	}
+	for _, req := range invalid {
+		if err := validateRequest(req); err == nil {
+			t.Errorf("expected request %+v to be invalid, actual valid", req)
+		}
+	}
+}
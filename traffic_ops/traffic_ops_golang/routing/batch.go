@@ -0,0 +1,382 @@
+package routing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/routing/middleware"
+)
+
+// routesContextKey is the request context key of the compiled routes, which
+// the batch handler dispatches its operations to.
+const routesContextKey = "routes"
+
+// batchPath is the API version-relative path of the batch endpoint, which
+// can't itself be an operation in a batch.
+const batchPath = "batch"
+
+// batchRefRegex matches references to the responses of earlier operations in
+// a batch, e.g. "${ds.0.id}".
+var batchRefRegex = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// batchOpHeadersIgnored are the headers of a batch request that aren't passed
+// on to its operations.
+var batchOpHeadersIgnored = []string{
+	rfc.AcceptEncoding,
+	"Content-Length",
+	rfc.IfMatch,
+	rfc.IfModifiedSince,
+	rfc.IfUnmodifiedSince,
+	middleware.RouteID,
+}
+
+// batchHandler is the handler of the /batch endpoint, which makes an ordered
+// list of requests to other endpoints in a single transaction, so either all
+// of their changes are made, or none are.
+func batchHandler(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	routes, ok := r.Context().Value(routesContextKey).(map[string][]CompiledRoute)
+	if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("request context routes missing"))
+		return
+	}
+
+	req := tc.BatchRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("malformed JSON: "+err.Error()), nil)
+		return
+	}
+	if err := validateBatchRequest(req); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	txID := int64(0)
+	if err := inf.Tx.Tx.QueryRow(`SELECT txid_current()`).Scan(&txID); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting batch transaction ID: "+err.Error()))
+		return
+	}
+	group := "BATCH " + strconv.FormatInt(txID, 10)
+	api.SetChangeLogGroup(inf.Tx.Tx, group)
+	defer api.ClearChangeLogGroup(inf.Tx.Tx)
+
+	ctx := context.WithValue(r.Context(), api.TransactionContextKey, inf.Tx)
+	versionPrefix := "/" + strings.TrimPrefix(RoutePrefix, "^") + "/" + strconv.FormatUint(inf.Version.Major, 10) + "." + strconv.FormatUint(inf.Version.Minor, 10) + "/"
+
+	results := []tc.BatchOperationResult{}
+	responses := map[string]interface{}{}
+	for i, op := range req.Operations {
+		result, response, userErr, sysErr, errCode := doBatchOperation(ctx, r, routes, versionPrefix, op, responses)
+		if userErr != nil || sysErr != nil {
+			if userErr != nil {
+				userErr = fmt.Errorf("operation %d: %v", i, userErr)
+			}
+			if sysErr != nil {
+				sysErr = fmt.Errorf("batch operation %d: %v", i, sysErr)
+			}
+			api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		results = append(results, result)
+		if result.Status >= http.StatusBadRequest {
+			inf.Tx.Tx.Rollback()
+			msg := fmt.Sprintf("operation %d", i)
+			if op.ID != "" {
+				msg += " ('" + op.ID + "')"
+			}
+			msg += fmt.Sprintf(" failed with status %d; no changes were made", result.Status)
+			api.WriteAlertsObj(w, r, result.Status, tc.CreateAlerts(tc.ErrorLevel, msg), tc.BatchResponse{Results: results})
+			return
+		}
+		// Endpoints which commit or roll back their own transaction can't be
+		// made part of a batch.
+		if _, err := inf.Tx.Tx.Exec(`SELECT 1`); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("batch operation %d %s %s ended the batch transaction: %v", i, op.Method, result.Path, err))
+			return
+		}
+		if op.ID != "" {
+			responses[op.ID] = response
+		}
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("Applied a batch of %d operations", len(req.Operations)), inf.User, inf.Tx.Tx)
+	if err := inf.Tx.Tx.Commit(); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("committing batch transaction: "+err.Error()))
+		return
+	}
+	api.WriteAlertsObj(w, r, http.StatusOK, tc.CreateAlerts(tc.SuccessLevel, fmt.Sprintf("Batch of %d operations was applied as %s", len(req.Operations), group)), tc.BatchResponse{Results: results})
+}
+
+// validateBatchRequest returns a user-safe error if the given batch is
+// invalid.
+func validateBatchRequest(req tc.BatchRequest) error {
+	if len(req.Operations) == 0 {
+		return errors.New("operations: required")
+	}
+	errs := []error{}
+	ids := map[string]int{}
+	for i, op := range req.Operations {
+		switch op.Method {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete:
+		default:
+			errs = append(errs, fmt.Errorf("operation %d: method must be one of %s, %s, %s, or %s", i, http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete))
+		}
+		path := strings.Trim(op.Path, "/")
+		if path == "" {
+			errs = append(errs, fmt.Errorf("operation %d: path: required", i))
+		} else if strings.Split(strings.Split(path, "?")[0], "/")[0] == batchPath {
+			errs = append(errs, fmt.Errorf("operation %d: batches can't be nested", i))
+		}
+		if op.ID == "" {
+			continue
+		}
+		if strings.ContainsAny(op.ID, ".{}$") {
+			errs = append(errs, fmt.Errorf("operation %d: id must not contain '.', '{', '}', or '$'", i))
+		}
+		if j, ok := ids[op.ID]; ok {
+			errs = append(errs, fmt.Errorf("operation %d: id '%s' is already the id of operation %d", i, op.ID, j))
+		}
+		ids[op.ID] = i
+	}
+	return util.JoinErrs(errs)
+}
+
+// doBatchOperation makes the request of a single batch operation, resolving
+// its references to the responses of earlier operations. It returns the
+// result, the decoded "response" of the operation's response for later
+// operations to refer to, and any errors with making the request itself.
+func doBatchOperation(ctx context.Context, r *http.Request, routes map[string][]CompiledRoute, versionPrefix string, op tc.BatchOperation, responses map[string]interface{}) (tc.BatchOperationResult, interface{}, error, error, int) {
+	result := tc.BatchOperationResult{ID: op.ID, Method: op.Method}
+
+	path, err := resolveBatchRefString(op.Path, responses)
+	if err != nil {
+		return result, nil, errors.New("path: " + err.Error()), nil, http.StatusBadRequest
+	}
+	result.Path = strings.TrimPrefix(path, "/")
+
+	body := []byte(nil)
+	if len(bytes.TrimSpace(op.Body)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(op.Body))
+		decoder.UseNumber()
+		bodyVal := interface{}(nil)
+		if err := decoder.Decode(&bodyVal); err != nil {
+			return result, nil, errors.New("body: malformed JSON: " + err.Error()), nil, http.StatusBadRequest
+		}
+		if bodyVal, err = resolveBatchRefs(bodyVal, responses); err != nil {
+			return result, nil, errors.New("body: " + err.Error()), nil, http.StatusBadRequest
+		}
+		if body, err = json.Marshal(bodyVal); err != nil {
+			return result, nil, nil, errors.New("marshalling body: " + err.Error()), http.StatusInternalServerError
+		}
+	}
+
+	opReq, err := http.NewRequest(op.Method, versionPrefix+result.Path, bytes.NewReader(body))
+	if err != nil {
+		return result, nil, errors.New("path: " + err.Error()), nil, http.StatusBadRequest
+	}
+	opReq = opReq.WithContext(ctx)
+	opReq.RemoteAddr = r.RemoteAddr
+	opReq.Header = r.Header.Clone()
+	for _, header := range batchOpHeadersIgnored {
+		opReq.Header.Del(header)
+	}
+	opReq.Header.Set(rfc.ContentType, rfc.ApplicationJSON)
+
+	rw := &batchResponseWriter{header: http.Header{}}
+	if !serveRoute(routes, rw, opReq) {
+		return result, nil, fmt.Errorf("no such endpoint: %s %s", op.Method, result.Path), nil, http.StatusNotFound
+	}
+	result.Status = rw.status
+	if result.Status == 0 {
+		result.Status = http.StatusOK
+	}
+
+	resp := struct {
+		Response json.RawMessage `json:"response"`
+		Alerts   []tc.Alert      `json:"alerts"`
+	}{}
+	if err := json.Unmarshal(rw.body.Bytes(), &resp); err != nil {
+		// not every endpoint responds with JSON; there's just nothing to refer to
+		return result, nil, nil, nil, http.StatusOK
+	}
+	result.Response = resp.Response
+	result.Alerts = resp.Alerts
+
+	response := interface{}(nil)
+	if len(resp.Response) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(resp.Response))
+		decoder.UseNumber()
+		if err := decoder.Decode(&response); err != nil {
+			return result, nil, nil, errors.New("decoding response: " + err.Error()), http.StatusInternalServerError
+		}
+	}
+	return result, response, nil, nil, http.StatusOK
+}
+
+// serveRoute serves the given request with the matching route, if any, and
+// returns whether one matched.
+func serveRoute(routes map[string][]CompiledRoute, w http.ResponseWriter, r *http.Request) bool {
+	requested := r.URL.Path[1:]
+	for _, compiledRoute := range routes[r.Method] {
+		match := compiledRoute.Regex.FindStringSubmatch(requested)
+		if len(match) == 0 {
+			continue
+		}
+		params := map[string]string{}
+		for i, v := range compiledRoute.Params {
+			params[v] = match[i+1]
+		}
+		r = r.WithContext(context.WithValue(r.Context(), api.PathParamsKey, params))
+		r.Header.Add(middleware.RouteID, strconv.Itoa(compiledRoute.ID))
+		compiledRoute.Handler(w, r)
+		return true
+	}
+	return false
+}
+
+// resolveBatchRefs returns the given decoded JSON value, with references to
+// the responses of earlier operations in its strings resolved.
+func resolveBatchRefs(val interface{}, responses map[string]interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case string:
+		return resolveBatchRef(v, responses)
+	case []interface{}:
+		for i, elem := range v {
+			resolved, err := resolveBatchRefs(elem, responses)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+	case map[string]interface{}:
+		for key, elem := range v {
+			resolved, err := resolveBatchRefs(elem, responses)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = resolved
+		}
+	}
+	return val, nil
+}
+
+// resolveBatchRef resolves the references in the given string. If the string
+// is a single reference, the referenced value itself is returned.
+func resolveBatchRef(s string, responses map[string]interface{}) (interface{}, error) {
+	if match := batchRefRegex.FindStringSubmatch(s); match != nil && match[0] == s {
+		return lookupBatchRef(match[1], responses)
+	}
+	return resolveBatchRefString(s, responses)
+}
+
+// resolveBatchRefString replaces the references in the given string with the
+// referenced values, which must be strings, numbers, or booleans.
+func resolveBatchRefString(s string, responses map[string]interface{}) (string, error) {
+	errs := []error{}
+	resolved := batchRefRegex.ReplaceAllStringFunc(s, func(ref string) string {
+		val, err := lookupBatchRef(ref[2:len(ref)-1], responses)
+		if err != nil {
+			errs = append(errs, err)
+			return ref
+		}
+		switch v := val.(type) {
+		case string:
+			return v
+		case json.Number:
+			return v.String()
+		case bool:
+			return strconv.FormatBool(v)
+		}
+		errs = append(errs, fmt.Errorf("reference '%s' is not a string, number, or boolean", ref))
+		return ref
+	})
+	return resolved, util.JoinErrs(errs)
+}
+
+// lookupBatchRef returns the value referred to by the given reference, without
+// its enclosing "${}".
+func lookupBatchRef(ref string, responses map[string]interface{}) (interface{}, error) {
+	keys := strings.Split(ref, ".")
+	val, ok := responses[keys[0]]
+	if !ok {
+		return nil, fmt.Errorf("reference '%s': no earlier operation has the id '%s'", ref, keys[0])
+	}
+	for _, key := range keys[1:] {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			if val, ok = v[key]; !ok {
+				return nil, fmt.Errorf("reference '%s': no such key '%s'", ref, key)
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("reference '%s': no such index '%s'", ref, key)
+			}
+			val = v[i]
+		default:
+			return nil, fmt.Errorf("reference '%s': '%s' of a value which is not an object or array", ref, key)
+		}
+	}
+	return val, nil
+}
+
+// batchResponseWriter is an http.ResponseWriter which keeps the response of
+// a batch operation.
+type batchResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *batchResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *batchResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *batchResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
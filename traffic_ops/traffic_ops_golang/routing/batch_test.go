@@ -0,0 +1,245 @@
+package routing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/routing/middleware"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault/backends/disabled"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestValidateBatchRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		ops   []tc.BatchOperation
+		valid bool
+	}{
+		{"valid", []tc.BatchOperation{{ID: "a", Method: http.MethodPost, Path: "things"}, {Method: http.MethodGet, Path: "things?id=${a.id}"}}, true},
+		{"empty", nil, false},
+		{"bad method", []tc.BatchOperation{{Method: http.MethodPatch, Path: "things"}}, false},
+		{"no path", []tc.BatchOperation{{Method: http.MethodGet, Path: "/"}}, false},
+		{"nested", []tc.BatchOperation{{Method: http.MethodPost, Path: "/batch"}}, false},
+		{"duplicate id", []tc.BatchOperation{{ID: "a", Method: http.MethodGet, Path: "things"}, {ID: "a", Method: http.MethodGet, Path: "things"}}, false},
+		{"bad id", []tc.BatchOperation{{ID: "a.b", Method: http.MethodGet, Path: "things"}}, false},
+	}
+	for _, test := range tests {
+		err := validateBatchRequest(tc.BatchRequest{Operations: test.ops})
+		if test.valid && err != nil {
+			t.Errorf("%s: expected no error, actual: %v", test.name, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: expected error, actual: nil", test.name)
+		}
+	}
+}
+
+func TestResolveBatchRefs(t *testing.T) {
+	responses := map[string]interface{}{
+		"ds": []interface{}{map[string]interface{}{"id": json.Number("12"), "xmlId": "demo", "active": true}},
+	}
+
+	body := map[string]interface{}{
+		"deliveryServiceId": "${ds.0.id}",
+		"names":             []interface{}{"${ds.0.xmlId}-regex"},
+		"active":            "${ds.0.active}",
+		"other":             "unchanged",
+	}
+	resolved, err := resolveBatchRefs(body, responses)
+	if err != nil {
+		t.Fatalf("expected no error, actual: %v", err)
+	}
+	expected := map[string]interface{}{
+		"deliveryServiceId": json.Number("12"),
+		"names":             []interface{}{"demo-regex"},
+		"active":            true,
+		"other":             "unchanged",
+	}
+	if !reflect.DeepEqual(resolved, expected) {
+		t.Errorf("expected %+v, actual: %+v", expected, resolved)
+	}
+
+	if path, err := resolveBatchRefString("deliveryservices/${ds.0.id}/regexes", responses); err != nil || path != "deliveryservices/12/regexes" {
+		t.Errorf("expected path 'deliveryservices/12/regexes', actual: '%s' error: %v", path, err)
+	}
+
+	for _, ref := range []string{"${nope.0.id}", "${ds.1.id}", "${ds.0.nope}", "${ds.0.id.x}", "x${ds.0}"} {
+		if _, err := resolveBatchRef(ref, responses); err == nil {
+			t.Errorf("expected an error resolving '%s', actual: nil", ref)
+		}
+	}
+}
+
+// batchTestRoutes returns the compiled routes of two test endpoints, which
+// make changes in the transaction of the request.
+func batchTestRoutes() map[string][]CompiledRoute {
+	createThing := func(w http.ResponseWriter, r *http.Request) {
+		inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		defer inf.Close()
+		if _, err := inf.Tx.Tx.Exec(`INSERT INTO thing DEFAULT VALUES`); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		api.WriteRespAlertObj(w, r, tc.SuccessLevel, "thing was created.", map[string]int{"id": 7})
+	}
+	createChild := func(w http.ResponseWriter, r *http.Request) {
+		inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		defer inf.Close()
+		child := struct {
+			Name string `json:"name"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&child); err != nil || child.Name == "" {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("name: required"), nil)
+			return
+		}
+		if _, err := inf.Tx.Tx.Exec(`INSERT INTO child (thing, name) VALUES ($1, $2)`, inf.IntParams["id"], child.Name); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		api.CreateChangeLogRawTx(api.ApiChange, "created child "+child.Name, inf.User, inf.Tx.Tx)
+		api.WriteResp(w, r, child)
+	}
+	authBase := middleware.AuthBase{Secret: "secret", Override: func(h http.HandlerFunc) http.HandlerFunc { return h }}
+	routes := []Route{
+		{api.Version{Major: 4, Minor: 0}, http.MethodPost, `things/?$`, createThing, auth.PrivLevelOperations, []string{"THING:CREATE"}, true, nil, 1},
+		{api.Version{Major: 4, Minor: 0}, http.MethodPost, `things/{id}/children/?$`, createChild, auth.PrivLevelOperations, []string{"THING:CREATE"}, true, nil, 2},
+	}
+	routeMap, _ := CreateRouteMap(routes, nil, nil, nil, authBase, 60)
+	return CompileRoutes(routeMap)
+}
+
+func batchTestRequest(t *testing.T, db *sqlx.DB, body string) *http.Request {
+	r, err := http.NewRequest(http.MethodPost, "/api/4.0/batch", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	cfg := config.NewFakeConfig()
+	cfg.DBQueryTimeoutSeconds = 20
+	ctx := r.Context()
+	ctx = context.WithValue(ctx, auth.CurrentUserKey, auth.CurrentUser{UserName: "username", ID: 1, PrivLevel: auth.PrivLevelAdmin})
+	ctx = context.WithValue(ctx, api.DBContextKey, db)
+	ctx = context.WithValue(ctx, api.ConfigContextKey, &cfg)
+	ctx = context.WithValue(ctx, api.ReqIDContextKey, uint64(0))
+	ctx = context.WithValue(ctx, api.PathParamsKey, map[string]string{})
+	var tv trafficvault.TrafficVault = &disabled.Disabled{}
+	ctx = context.WithValue(ctx, api.TrafficVaultContextKey, tv)
+	ctx = context.WithValue(ctx, routesContextKey, batchTestRoutes())
+	return r.WithContext(ctx)
+}
+
+const batchTestBody = `{"operations": [
+	{"id": "thing", "method": "POST", "path": "things"},
+	{"method": "POST", "path": "things/${thing.id}/children", "body": {"name": %s}}
+]}`
+
+func TestBatchHandler(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT txid_current\(\)`).WillReturnRows(sqlmock.NewRows([]string{"txid_current"}).AddRow(42))
+	mock.ExpectExec("INSERT INTO thing").WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO child").WithArgs(7, "a").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO log").WithArgs(api.ApiChange, "BATCH 42: created child a", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO log").WithArgs(api.ApiChange, "BATCH 42: Applied a batch of 2 operations", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	w := httptest.NewRecorder()
+	batchHandler(w, batchTestRequest(t, db, strings.Replace(batchTestBody, "%s", `"a"`, 1)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, actual: %d body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	resp := tc.BatchAPIResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(resp.Response.Results) != 2 {
+		t.Fatalf("expected 2 results, actual: %+v", resp.Response.Results)
+	}
+	if result := resp.Response.Results[1]; result.Path != "things/7/children" || result.Status != http.StatusOK || string(result.Response) != `{"name":"a"}` {
+		t.Errorf("expected the second result to be the created child of thing 7, actual: %+v", result)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected all queries to be made: %v", err)
+	}
+}
+
+func TestBatchHandlerFailedOperation(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT txid_current\(\)`).WillReturnRows(sqlmock.NewRows([]string{"txid_current"}).AddRow(42))
+	mock.ExpectExec("INSERT INTO thing").WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	w := httptest.NewRecorder()
+	batchHandler(w, batchTestRequest(t, db, strings.Replace(batchTestBody, "%s", `""`, 1)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, actual: %d body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+	resp := tc.BatchAPIResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(resp.Response.Results) != 2 || resp.Response.Results[1].Status != http.StatusBadRequest || len(resp.Response.Results[1].Alerts) != 1 {
+		t.Errorf("expected the second result to be the failure, actual: %+v", resp.Response.Results)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected the transaction to be rolled back: %v", err)
+	}
+}
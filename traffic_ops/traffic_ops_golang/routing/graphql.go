@@ -0,0 +1,143 @@
+package routing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/graphql"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/routing/middleware"
+)
+
+// graphQLHeadersIgnored are the headers of a GraphQL request that aren't
+// passed on to the requests of the API it makes.
+var graphQLHeadersIgnored = []string{
+	rfc.AcceptEncoding,
+	"Content-Length",
+	rfc.ContentType,
+	rfc.IfMatch,
+	rfc.IfNoneMatch,
+	rfc.IfModifiedSince,
+	rfc.IfUnmodifiedSince,
+	middleware.RouteID,
+}
+
+// graphQLHandler is the handler of the /graphql endpoint, which executes a
+// read-only GraphQL query of the objects of the API. Every object is fetched
+// by a GET request of the API, in a single read-only transaction, so a query
+// can only see what the user could see with those requests.
+func graphQLHandler(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	routes, ok := r.Context().Value(routesContextKey).(map[string][]CompiledRoute)
+	if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("request context routes missing"))
+		return
+	}
+
+	req, err := readGraphQLRequest(r)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	if _, err := inf.Tx.Tx.Exec(`SET TRANSACTION READ ONLY`); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("making GraphQL transaction read-only: "+err.Error()))
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), api.TransactionContextKey, inf.Tx)
+	versionPrefix := "/" + strings.TrimPrefix(RoutePrefix, "^") + "/" + strconv.FormatUint(inf.Version.Major, 10) + "." + strconv.FormatUint(inf.Version.Minor, 10) + "/"
+	fetch := func(path string, query url.Values) (int, []byte, error) {
+		target := versionPrefix + path
+		if len(query) > 0 {
+			target += "?" + query.Encode()
+		}
+		getReq, err := http.NewRequest(http.MethodGet, target, nil)
+		if err != nil {
+			return 0, nil, err
+		}
+		getReq = getReq.WithContext(ctx)
+		getReq.RemoteAddr = r.RemoteAddr
+		getReq.Header = r.Header.Clone()
+		for _, header := range graphQLHeadersIgnored {
+			getReq.Header.Del(header)
+		}
+		rw := &batchResponseWriter{header: http.Header{}}
+		if !serveRoute(routes, rw, getReq) {
+			return 0, nil, fmt.Errorf("no such endpoint: GET %s", path)
+		}
+		if rw.status == 0 {
+			rw.status = http.StatusOK
+		}
+		return rw.status, rw.body.Bytes(), nil
+	}
+
+	resp := graphql.Execute(req, fetch)
+	if resp.Data == nil {
+		w.Header().Set(rfc.ContentType, rfc.ApplicationJSON)
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	api.WriteRespRaw(w, r, resp)
+}
+
+// readGraphQLRequest returns the GraphQL request of the given HTTP request,
+// which is its JSON body if it's a POST, or else its "query",
+// "operationName", and "variables" query parameters.
+func readGraphQLRequest(r *http.Request) (tc.GraphQLRequest, error) {
+	req := tc.GraphQLRequest{}
+	if r.Method == http.MethodPost {
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&req); err != nil {
+			return req, errors.New("malformed JSON: " + err.Error())
+		}
+	} else {
+		params := r.URL.Query()
+		req.Query = params.Get("query")
+		req.OperationName = params.Get("operationName")
+		if variables := params.Get("variables"); variables != "" {
+			decoder := json.NewDecoder(strings.NewReader(variables))
+			decoder.UseNumber()
+			if err := decoder.Decode(&req.Variables); err != nil {
+				return req, errors.New("variables: malformed JSON: " + err.Error())
+			}
+		}
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		return req, errors.New("query: required")
+	}
+	return req, nil
+}
@@ -23,11 +23,14 @@ package middleware
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"crypto/sha512"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
@@ -87,8 +90,9 @@ type AuthBase struct {
 }
 
 // GetWrapper returns a Middleware which performs authentication of the current user at the given privilege level.
+// If permissionsRequired is non-nil, the user's Role must instead have all of the given permissions.
 // The returned Middleware also adds the auth.CurrentUser object to the request context, which may be retrieved by a handler via api.NewInfo or auth.GetCurrentUser.
-func (a AuthBase) GetWrapper(privLevelRequired int) Middleware {
+func (a AuthBase) GetWrapper(privLevelRequired int, permissionsRequired []string) Middleware {
 	if a.Override != nil {
 		return a.Override
 	}
@@ -99,7 +103,12 @@ func (a AuthBase) GetWrapper(privLevelRequired int) Middleware {
 				api.HandleErr(w, r, nil, errCode, userErr, sysErr)
 				return
 			}
-			if user.PrivLevel < privLevelRequired {
+			if permissionsRequired != nil {
+				if missing := user.MissingPermissions(permissionsRequired...); len(missing) > 0 {
+					api.HandleErr(w, r, nil, http.StatusForbidden, errors.New("Forbidden: missing permissions: "+strings.Join(missing, ", ")), nil)
+					return
+				}
+			} else if user.PrivLevel < privLevelRequired {
 				api.HandleErr(w, r, nil, http.StatusForbidden, errors.New("Forbidden."), nil)
 				return
 			}
@@ -122,6 +131,7 @@ func TimeOutWrapper(timeout time.Duration) Middleware {
 // WrapHeaders is a Middleware which adds common headers and behavior to the handler. It specifically:
 //  - Adds default CORS headers to the response.
 //  - Adds the Whole-Content-SHA512 checksum header to the response.
+//  - Adds the ETag header to successful GET responses, and responds 304 Not Modified if it matches the request's If-None-Match header.
 //  - Gzips the response and sets the Content-Encoding header, if the client sent an Accept-Encoding: gzip header.
 //  - Adds the Vary: Accept-Encoding header to the response
 func WrapHeaders(h http.HandlerFunc) http.HandlerFunc {
@@ -133,17 +143,58 @@ func WrapHeaders(h http.HandlerFunc) http.HandlerFunc {
 		w.Header().Set(rfc.Vary, rfc.AcceptEncoding)
 		w.Header().Set("X-Server-Name", ServerName)
 		w.Header().Set(rfc.PermissionsPolicy, "interest-cohort=()")
-		iw := &util.BodyInterceptor{W: w}
+		iw := &statusInterceptor{BodyInterceptor: util.BodyInterceptor{W: w}}
 		h(iw, r)
 
 		sha := sha512.Sum512(iw.Body())
 		w.Header().Set("Whole-Content-SHA512", base64.StdEncoding.EncodeToString(sha[:]))
 
+		if r.Method == http.MethodGet && (iw.Code == 0 || iw.Code == http.StatusOK) && len(iw.Body()) > 0 {
+			etag := RepresentationETag(iw.Body())
+			w.Header().Set(rfc.ETagHeader, etag)
+			if inm := r.Header.Get(rfc.IfNoneMatch); inm != "" && rfc.ETagsMatch(inm, etag, true) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		if iw.Code != 0 {
+			// the status is written after gzipping, so that the headers set by gzipping are sent
+			r = r.WithContext(context.WithValue(r.Context(), tc.StatusKey, iw.Code))
+		}
 		GzipResponse(w, r, iw.Body())
 
 	}
 }
 
+// statusInterceptor is a util.BodyInterceptor which also records the status
+// code written to it, rather than writing it, so that the response can be
+// changed after the handler has written it.
+type statusInterceptor struct {
+	util.BodyInterceptor
+	Code int
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (i *statusInterceptor) WriteHeader(code int) {
+	if i.Code == 0 {
+		i.Code = code
+	}
+}
+
+// RepresentationETag returns the ETag of the given response body. For
+// responses with a "response" property, only it is the representation, so
+// that alerts and summaries don't change the ETag.
+func RepresentationETag(body []byte) string {
+	resp := struct {
+		Response json.RawMessage `json:"response"`
+	}{}
+	if err := json.Unmarshal(body, &resp); err == nil && len(resp.Response) > 0 {
+		return rfc.ContentETag(resp.Response)
+	}
+	return rfc.ContentETag(body)
+}
+
 // WrapPanicRecover is a Middleware which adds a panic recover call to the given HandlerFunc h.
 // If h throws an unhandled panic, an error is logged and an Internal Server Error is returned to the client.
 func WrapPanicRecover(h http.HandlerFunc) http.HandlerFunc {
@@ -29,6 +29,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -70,6 +71,7 @@ func TestWrapHeaders(t *testing.T) {
 		"Access-Control-Allow-Origin":      nil,
 		rfc.Vary:                           {rfc.AcceptEncoding},
 		"Content-Type":                     nil,
+		"Etag":                             nil,
 		"Whole-Content-Sha512":             nil,
 		"X-Server-Name":                    nil,
 		rfc.PermissionsPolicy:              {"interest-cohort=()"},
@@ -88,6 +90,55 @@ func TestWrapHeaders(t *testing.T) {
 	}
 }
 
+// TestWrapHeadersETag checks that successful GET responses have an ETag, and
+// that requests which have it in their If-None-Match header get a 304.
+func TestWrapHeadersETag(t *testing.T) {
+	response := `{"response":[{"id":1}]}`
+	f := WrapHeaders(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(response))
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	f(w, r)
+	etag := w.Header().Get(rfc.ETagHeader)
+	if etag != rfc.ContentETag([]byte(`[{"id":1}]`)) {
+		t.Errorf("expected the ETag of the response property, actual: %s", etag)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(rfc.IfNoneMatch, etag)
+	f(w, r)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected a request with a matching If-None-Match to get a %d, actual: %d", http.StatusNotModified, w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected no body with a %d, actual: %s", http.StatusNotModified, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPut, "/", nil)
+	f(w, r)
+	if etag := w.Header().Get(rfc.ETagHeader); etag != "" {
+		t.Errorf("expected no ETag for a PUT request, actual: %s", etag)
+	}
+
+	f = WrapHeaders(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"alerts":[]}`))
+	})
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	f(w, r)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected the status written by the handler, %d, actual: %d", http.StatusNotFound, w.Code)
+	}
+	if etag := w.Header().Get(rfc.ETagHeader); etag != "" {
+		t.Errorf("expected no ETag for an unsuccessful response, actual: %s", etag)
+	}
+}
+
 // TestWrapPanicRecover checks that a recovered panic returns a 500
 func TestWrapPanicRecover(t *testing.T) {
 	f := WrapPanicRecover(func(w http.ResponseWriter, r *http.Request) {
@@ -191,7 +242,7 @@ func TestWrapAuth(t *testing.T) {
 		fmt.Fprintf(w, "%s", respBts)
 	}
 
-	authWrapper := authBase.GetWrapper(15)
+	authWrapper := authBase.GetWrapper(15, nil)
 
 	f := authWrapper(handler)
 
@@ -238,4 +289,60 @@ func TestWrapAuth(t *testing.T) {
 	}
 }
 
+func TestWrapAuthPermissions(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	userName := "user1"
+	secret := "secret"
+	cookie := tocookie.GetCookie(userName, time.Minute, secret)
+	authBase := AuthBase{secret, nil}
+
+	tests := []struct {
+		name     string
+		roleName string
+		required []string
+		allowed  bool
+	}{
+		{"has the permissions", "cache-operator", []string{"SERVER:QUEUE", "SERVER:STATUS"}, true},
+		{"missing a permission", "cache-operator", []string{"SERVER:QUEUE", "DELIVERY-SERVICE:UPDATE"}, false},
+		{"admin", auth.AdminRoleName, []string{"DELIVERY-SERVICE:UPDATE"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the priv level is high enough for anything, so only the
+			// permissions decide
+			rows := sqlmock.NewRows([]string{"priv_level", "username", "id", "tenant_id", "role_name", "capabilities"})
+			rows.AddRow(30, userName, 1, 1, test.roleName, "{SERVER:QUEUE,SERVER:STATUS}")
+			mock.ExpectQuery("SELECT").WithArgs(userName).WillReturnRows(rows)
+
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest("", "/", nil)
+			if err != nil {
+				t.Fatalf("creating request: %v", err)
+			}
+			r.Header.Add("Cookie", tocookie.Name+"="+cookie.Value)
+			r = r.WithContext(context.WithValue(context.Background(), api.DBContextKey, db))
+			r = r.WithContext(context.WithValue(r.Context(), api.ConfigContextKey, &config.Config{ConfigTrafficOpsGolang: config.ConfigTrafficOpsGolang{DBQueryTimeoutSeconds: 20}}))
+
+			called := false
+			handler := func(w http.ResponseWriter, r *http.Request) { called = true }
+			authBase.GetWrapper(30, test.required)(handler)(w, r)
+
+			if called != test.allowed {
+				t.Errorf("expected the request to be allowed: %t, actual: %t (%s)", test.allowed, called, w.Body.Bytes())
+			}
+			if !test.allowed && !strings.Contains(w.Body.String(), "missing permissions: DELIVERY-SERVICE:UPDATE") {
+				t.Errorf("expected the missing permission to be named, actual: %s", w.Body.Bytes())
+			}
+		})
+	}
+}
+
 // TODO: TestWrapAccessLog
@@ -0,0 +1,284 @@
+package routing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/routing/middleware"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// preconditionHeadersIgnored are the headers of a request that aren't passed
+// on to the request for the current representation of the resource it
+// changes.
+var preconditionHeadersIgnored = []string{
+	rfc.AcceptEncoding,
+	"Content-Length",
+	rfc.IfMatch,
+	rfc.IfNoneMatch,
+	rfc.IfModifiedSince,
+	rfc.IfUnmodifiedSince,
+	middleware.RouteID,
+}
+
+// lockRepresentationQuery serializes all conditional changes to the same
+// resource until the end of the transaction. The rows which represent a
+// resource aren't known to wrapPreconditions, so the resource is locked by
+// its path rather than by selecting its rows FOR UPDATE.
+const lockRepresentationQuery = `SELECT pg_advisory_xact_lock(hashtext('representation/' || $1))`
+
+// wrapPreconditions is a Middleware which checks the If-Match and
+// If-None-Match headers of PUT and DELETE requests against the ETag of the
+// current representation of the resource they change, which is the response
+// to a GET request of the same resource. If a precondition fails, it responds
+// 412 Precondition Failed with the current representation, rather than
+// calling the handler.
+//
+// The resource is locked, its current representation gotten, and the handler
+// called, all in the same transaction, which is committed only once the
+// handler succeeds; so concurrent requests with the same entity tag can't
+// both pass their preconditions.
+//
+// ETags of last modified times, made by rfc.ETag, are left for the handler to
+// check, as they always have been.
+func wrapPreconditions(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+			h(w, r)
+			return
+		}
+		im := r.Header.Get(rfc.IfMatch)
+		inm := r.Header.Get(rfc.IfNoneMatch)
+		if !rfc.HasContentETags(im) && !rfc.HasContentETags(inm) {
+			h(w, r)
+			return
+		}
+
+		routes, ok := r.Context().Value(routesContextKey).(map[string][]CompiledRoute)
+		if !ok {
+			api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("request context routes missing"))
+			return
+		}
+
+		// A batch's transaction is ended by the batch.
+		tx, owned := r.Context().Value(api.TransactionContextKey).(*sqlx.Tx)
+		owned = !owned || tx == nil
+		if owned {
+			var cancelTx context.CancelFunc
+			var err error
+			tx, cancelTx, err = beginPreconditionTx(r)
+			if err != nil {
+				api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("checking preconditions: "+err.Error()))
+				return
+			}
+			defer cancelTx()
+			defer func() {
+				if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+					log.Errorln("rolling back precondition transaction: " + err.Error())
+				}
+			}()
+			*r = *r.WithContext(context.WithValue(r.Context(), api.TransactionContextKey, tx))
+		}
+
+		if _, err := tx.Tx.Exec(lockRepresentationQuery, representationLockKey(r.URL.Path)); err != nil {
+			api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("locking the resource to check preconditions: "+err.Error()))
+			return
+		}
+		current, ok := getCurrentRepresentation(routes, r)
+		if !ok {
+			api.HandleErr(w, r, nil, http.StatusBadRequest, errors.New("entity tags in If-Match and If-None-Match can't be checked by this endpoint, because it has no representation to GET"), nil)
+			return
+		}
+
+		status := current.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if status != http.StatusOK && status != http.StatusNotFound {
+			// e.g. the user isn't allowed to see the resource
+			writeBatchResponse(w, current, status)
+			return
+		}
+
+		resp := struct {
+			Response json.RawMessage `json:"response"`
+		}{}
+		if status == http.StatusOK {
+			if err := json.Unmarshal(current.body.Bytes(), &resp); err != nil {
+				resp.Response = nil
+			}
+		}
+		trimmed := bytes.TrimSpace(resp.Response)
+		exists := status == http.StatusOK && len(trimmed) > 0 && !bytes.Equal(trimmed, []byte("null")) && !bytes.Equal(trimmed, []byte("[]"))
+		etag := current.header.Get(rfc.ETagHeader)
+		if exists && etag == "" {
+			etag = middleware.RepresentationETag(current.body.Bytes())
+		}
+
+		msg := ""
+		if im != "" && !exists {
+			msg = "the resource does not exist"
+		} else if im != "" && !rfc.ETagsMatch(im, etag, false) {
+			msg = "the resource was modified; its current representation is in the response"
+		} else if inm != "" && exists && rfc.ETagsMatch(inm, etag, true) {
+			msg = "the resource already exists; its current representation is in the response"
+		}
+		if msg == "" {
+			if !owned {
+				h(w, r)
+				return
+			}
+			// The response is held until the transaction is committed, so
+			// that a failure to commit isn't reported as a success.
+			rw := &batchResponseWriter{header: http.Header{}}
+			h(rw, r)
+			if rw.status == 0 {
+				rw.status = http.StatusOK
+			}
+			if rw.status < http.StatusBadRequest {
+				if err := tx.Tx.Commit(); err != nil && err != sql.ErrTxDone {
+					api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("committing precondition transaction: "+err.Error()))
+					return
+				}
+			}
+			writeBatchResponse(w, rw, rw.status)
+			return
+		}
+
+		alerts := tc.CreateAlerts(tc.ErrorLevel, "precondition failed: "+msg)
+		if !exists {
+			api.WriteAlerts(w, r, http.StatusPreconditionFailed, alerts)
+			return
+		}
+		w.Header().Set(rfc.ETagHeader, etag)
+		api.WriteAlertsObj(w, r, http.StatusPreconditionFailed, alerts, resp.Response)
+	}
+}
+
+// beginPreconditionTx begins the transaction in which the preconditions of the
+// given request are checked and its handler called, with the same timeout as
+// the transactions of api.NewInfo.
+func beginPreconditionTx(r *http.Request) (*sqlx.Tx, context.CancelFunc, error) {
+	db, err := api.GetDB(r.Context())
+	if err != nil {
+		return nil, nil, errors.New("getting db: " + err.Error())
+	}
+	cfg, err := api.GetConfig(r.Context())
+	if err != nil {
+		return nil, nil, errors.New("getting config: " + err.Error())
+	}
+	dbCtx, cancelTx := context.WithTimeout(r.Context(), time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+	tx, err := db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		cancelTx()
+		return nil, nil, errors.New("beginning transaction: " + err.Error())
+	}
+	return tx, cancelTx, nil
+}
+
+// representationLockKey returns the key by which the resource at the given
+// path is locked, which is the path without its API version, so that
+// requests of every version to the same resource exclude one another.
+func representationLockKey(path string) string {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(parts) == 3 && parts[0] == strings.TrimPrefix(RoutePrefix, "^") {
+		return parts[2]
+	}
+	return path
+}
+
+// writeBatchResponse writes the response held by the given batchResponseWriter
+// to w, with the given status.
+func writeBatchResponse(w http.ResponseWriter, rw *batchResponseWriter, status int) {
+	for k, v := range rw.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(status)
+	w.Write(rw.body.Bytes())
+}
+
+// getCurrentRepresentation returns the response to a GET request of the
+// resource which the given request changes, and whether there's an endpoint
+// to GET it from.
+//
+// The GET request is made to the same path as the given request, with its
+// last path parameter, if any, made a query parameter of the same name. This
+// is how collections of objects are filtered to a single one, e.g. the
+// current representation of a "PUT servers/1" request is the response to
+// "GET servers?id=1". If there's no such endpoint, the GET request is made to
+// the same path, unchanged.
+func getCurrentRepresentation(routes map[string][]CompiledRoute, r *http.Request) (*batchResponseWriter, bool) {
+	query := r.URL.Query()
+	paths := []string{}
+	params, _ := r.Context().Value(api.PathParamsKey).(map[string]string)
+	if i := strings.LastIndex(r.URL.Path, "/"); i >= 0 {
+		last, err := url.PathUnescape(r.URL.Path[i+1:])
+		for name, value := range params {
+			if err != nil || value != last {
+				continue
+			}
+			collectionQuery := url.Values{}
+			for k, v := range query {
+				collectionQuery[k] = v
+			}
+			collectionQuery.Set(name, value)
+			paths = append(paths, r.URL.Path[:i]+"?"+collectionQuery.Encode())
+			break
+		}
+	}
+	if len(query) > 0 {
+		paths = append(paths, r.URL.Path+"?"+query.Encode())
+	} else {
+		paths = append(paths, r.URL.Path)
+	}
+
+	for _, path := range paths {
+		getReq, err := http.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			continue
+		}
+		getReq = getReq.WithContext(r.Context())
+		getReq.RemoteAddr = r.RemoteAddr
+		getReq.Header = r.Header.Clone()
+		for _, header := range preconditionHeadersIgnored {
+			getReq.Header.Del(header)
+		}
+
+		rw := &batchResponseWriter{header: http.Header{}}
+		if serveRoute(routes, rw, getReq) {
+			return rw, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,319 @@
+package routing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/routing/middleware"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// preconditionTestRequest returns a request with the given routes and db in
+// its context.
+func preconditionTestRequest(method string, path string, routes map[string][]CompiledRoute, db *sqlx.DB) *http.Request {
+	r := httptest.NewRequest(method, path, nil)
+	cfg := config.NewFakeConfig()
+	cfg.DBQueryTimeoutSeconds = 20
+	ctx := context.WithValue(r.Context(), routesContextKey, routes)
+	ctx = context.WithValue(ctx, api.DBContextKey, db)
+	ctx = context.WithValue(ctx, api.ConfigContextKey, &cfg)
+	return r.WithContext(ctx)
+}
+
+func TestWrapPreconditions(t *testing.T) {
+	current := `[{"id":1,"name":"foo"}]`
+	updated := false
+	routes := CompileRoutes(map[string][]PathHandler{
+		http.MethodGet: {{
+			Path: `api/4.0/things/?$`,
+			Handler: middleware.WrapHeaders(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("id") != "1" {
+					w.Write([]byte(`{"response":[]}`))
+					return
+				}
+				w.Write([]byte(`{"response":` + current + `}`))
+			}),
+		}},
+		http.MethodPut: {{
+			Path: `api/4.0/things/{id}$`,
+			Handler: middleware.WrapHeaders(wrapPreconditions(func(w http.ResponseWriter, r *http.Request) {
+				updated = true
+			})),
+		}},
+		http.MethodDelete: {{
+			Path: `api/4.0/others/{id}$`,
+			Handler: middleware.WrapHeaders(wrapPreconditions(func(w http.ResponseWriter, r *http.Request) {
+				updated = true
+			})),
+		}},
+	})
+	etag := rfc.ContentETag([]byte(current))
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		header   string
+		value    string
+		expected int
+		updated  bool
+	}{
+		{"matching If-Match", http.MethodPut, "/api/4.0/things/1", rfc.IfMatch, etag, http.StatusOK, true},
+		{"If-Match of *", http.MethodPut, "/api/4.0/things/1", rfc.IfMatch, "*", http.StatusOK, true},
+		{"stale If-Match", http.MethodPut, "/api/4.0/things/1", rfc.IfMatch, rfc.ContentETag([]byte(`[]`)), http.StatusPreconditionFailed, false},
+		{"If-Match of a resource which doesn't exist", http.MethodPut, "/api/4.0/things/2", rfc.IfMatch, "*", http.StatusPreconditionFailed, false},
+		{"matching If-None-Match", http.MethodPut, "/api/4.0/things/1", rfc.IfNoneMatch, "*", http.StatusPreconditionFailed, false},
+		{"If-None-Match of a resource which doesn't exist", http.MethodPut, "/api/4.0/things/2", rfc.IfNoneMatch, "*", http.StatusOK, true},
+		{"time ETag left for the handler", http.MethodPut, "/api/4.0/things/1", rfc.IfMatch, rfc.ETag(time.Now().Add(-time.Hour)), http.StatusOK, true},
+		{"no precondition", http.MethodPut, "/api/4.0/things/1", "", "", http.StatusOK, true},
+		{"no representation to GET", http.MethodDelete, "/api/4.0/others/1", rfc.IfMatch, etag, http.StatusBadRequest, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db := sqlx.NewDb(mockDB, "sqlmock")
+			defer db.Close()
+			if rfc.HasContentETags(test.value) {
+				mock.ExpectBegin()
+				mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(strings.TrimPrefix(test.path, "/api/4.0/")).WillReturnResult(sqlmock.NewResult(0, 0))
+				if test.updated {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			updated = false
+			r := preconditionTestRequest(test.method, test.path, routes, db)
+			if test.header != "" {
+				r.Header.Set(test.header, test.value)
+			}
+			w := httptest.NewRecorder()
+			if !serveRoute(routes, w, r) {
+				t.Fatalf("expected a route to match %s %s", test.method, test.path)
+			}
+			if w.Code != test.expected {
+				t.Errorf("expected status %d, actual: %d (%s)", test.expected, w.Code, w.Body.String())
+			}
+			if updated != test.updated {
+				t.Errorf("expected the handler to be called: %t, actual: %t", test.updated, updated)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	r := preconditionTestRequest(http.MethodPut, "/api/4.0/things/1", routes, db)
+	r.Header.Set(rfc.IfMatch, `"stale"`)
+	w := httptest.NewRecorder()
+	serveRoute(routes, w, r)
+	if actual := w.Header().Get(rfc.ETagHeader); actual != etag {
+		t.Errorf("expected a failed precondition to have the current ETag %s, actual: %s", etag, actual)
+	}
+	resp := struct {
+		Response json.RawMessage `json:"response"`
+	}{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshalling failed precondition response: %v", err)
+	}
+	if string(resp.Response) != current {
+		t.Errorf("expected a failed precondition to have the current representation %s, actual: %s", current, resp.Response)
+	}
+}
+
+// lockingDriver is a database driver whose transactions do nothing but take
+// pg_advisory_xact_lock, which is a single lock held until the transaction
+// is committed or rolled back, as by PostgreSQL.
+type lockingDriver struct {
+	lock chan struct{}
+	// waiting receives when a transaction waits for the lock.
+	waiting chan struct{}
+}
+
+func (d *lockingDriver) Open(string) (driver.Conn, error) {
+	return &lockingConn{driver: d}, nil
+}
+
+func (d *lockingDriver) Connect(context.Context) (driver.Conn, error) {
+	return d.Open("")
+}
+
+func (d *lockingDriver) Driver() driver.Driver {
+	return d
+}
+
+type lockingConn struct {
+	driver *lockingDriver
+	locked bool
+}
+
+func (c *lockingConn) Prepare(query string) (driver.Stmt, error) {
+	return &lockingStmt{conn: c, query: query}, nil
+}
+
+func (c *lockingConn) Close() error {
+	return nil
+}
+
+func (c *lockingConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *lockingConn) Commit() error {
+	c.unlock()
+	return nil
+}
+
+func (c *lockingConn) Rollback() error {
+	c.unlock()
+	return nil
+}
+
+func (c *lockingConn) unlock() {
+	if c.locked {
+		c.locked = false
+		<-c.driver.lock
+	}
+}
+
+type lockingStmt struct {
+	conn  *lockingConn
+	query string
+}
+
+func (s *lockingStmt) Close() error {
+	return nil
+}
+
+func (s *lockingStmt) NumInput() int {
+	return -1
+}
+
+func (s *lockingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if strings.Contains(s.query, "pg_advisory_xact_lock") && !s.conn.locked {
+		select {
+		case s.conn.driver.lock <- struct{}{}:
+		default:
+			s.conn.driver.waiting <- struct{}{}
+			s.conn.driver.lock <- struct{}{}
+		}
+		s.conn.locked = true
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (s *lockingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("queries aren't supported")
+}
+
+func TestWrapPreconditionsInterleavedWriters(t *testing.T) {
+	d := &lockingDriver{lock: make(chan struct{}, 1), waiting: make(chan struct{})}
+	db := sqlx.NewDb(sql.OpenDB(d), "postgres")
+	defer db.Close()
+
+	current := `[{"id":1,"name":"foo"}]`
+	etag := rfc.ContentETag([]byte(current))
+	updates := 0
+	var routes map[string][]CompiledRoute
+	put := func() *httptest.ResponseRecorder {
+		r := preconditionTestRequest(http.MethodPut, "/api/4.0/things/1", routes, db)
+		r.Header.Set(rfc.IfMatch, etag)
+		w := httptest.NewRecorder()
+		serveRoute(routes, w, r)
+		return w
+	}
+
+	started := false
+	var second *httptest.ResponseRecorder
+	secondDone := make(chan struct{})
+	routes = CompileRoutes(map[string][]PathHandler{
+		http.MethodGet: {{
+			Path: `api/4.0/things/?$`,
+			Handler: middleware.WrapHeaders(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"response":` + current + `}`))
+			}),
+		}},
+		http.MethodPut: {{
+			Path: `api/4.0/things/{id}$`,
+			Handler: middleware.WrapHeaders(wrapPreconditions(func(w http.ResponseWriter, r *http.Request) {
+				if !started {
+					started = true
+					// The second writer, with the same ETag, starts while
+					// the first is writing.
+					go func() {
+						second = put()
+						close(secondDone)
+					}()
+					select {
+					case <-d.waiting:
+					case <-secondDone:
+					}
+				}
+				updates++
+				current = `[{"id":1,"name":"bar"}]`
+			})),
+		}},
+	})
+
+	if first := put(); first.Code != http.StatusOK {
+		t.Fatalf("expected the first writer to get status %d, actual: %d (%s)", http.StatusOK, first.Code, first.Body.String())
+	}
+	<-secondDone
+	if second.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected the second writer to get status %d, actual: %d (%s)", http.StatusPreconditionFailed, second.Code, second.Body.String())
+	}
+	if updates != 1 {
+		t.Errorf("expected the resource to be updated once, actual: %d", updates)
+	}
+	if actual := second.Header().Get(rfc.ETagHeader); actual != rfc.ContentETag([]byte(current)) {
+		t.Errorf("expected the second writer to get the ETag of the first writer's update %s, actual: %s", rfc.ContentETag([]byte(current)), actual)
+	}
+}
@@ -54,9 +54,12 @@ type Route struct {
 	Path              string
 	Handler           http.HandlerFunc
 	RequiredPrivLevel int
-	Authenticated     bool
-	Middlewares       []middleware.Middleware
-	ID                int // unique ID for referencing this Route
+	// RequiredPermissions are the permissions a user's Role must have to use
+	// the Route. If nil, RequiredPrivLevel is checked instead.
+	RequiredPermissions []string
+	Authenticated       bool
+	Middlewares         []middleware.Middleware
+	ID                  int // unique ID for referencing this Route
 }
 
 func (r Route) String() string {
@@ -168,7 +171,7 @@ func CreateRouteMap(rs []Route, rawRoutes []RawRoute, disabledRouteIDs []int, pe
 			}
 			vstr := strconv.FormatUint(version.Major, 10) + "." + strconv.FormatUint(version.Minor, 10)
 			path := RoutePrefix + "/" + vstr + "/" + r.Path
-			middlewares := getRouteMiddleware(r.Middlewares, authBase, r.Authenticated, r.RequiredPrivLevel, requestTimeout)
+			middlewares := getRouteMiddleware(r.Middlewares, authBase, r.Authenticated, r.RequiredPrivLevel, r.RequiredPermissions, requestTimeout)
 
 			if isDisabledRoute {
 				m[r.Method] = append(m[r.Method], PathHandler{Path: path, Handler: middleware.WrapAccessLog(authBase.Secret, middleware.DisabledRouteHandler()), ID: r.ID})
@@ -179,7 +182,7 @@ func CreateRouteMap(rs []Route, rawRoutes []RawRoute, disabledRouteIDs []int, pe
 		}
 	}
 	for _, r := range rawRoutes {
-		middlewares := getRouteMiddleware(r.Middlewares, authBase, r.Authenticated, r.RequiredPrivLevel, requestTimeout)
+		middlewares := getRouteMiddleware(r.Middlewares, authBase, r.Authenticated, r.RequiredPrivLevel, nil, requestTimeout)
 		m[r.Method] = append(m[r.Method], PathHandler{Path: r.Path, Handler: middleware.Use(r.Handler, middlewares)})
 		log.Infof("adding raw route %v %v\n", r.Method, r.Path)
 	}
@@ -191,15 +194,15 @@ func CreateRouteMap(rs []Route, rawRoutes []RawRoute, disabledRouteIDs []int, pe
 	return m, versionSet
 }
 
-func getRouteMiddleware(middlewares []middleware.Middleware, authBase middleware.AuthBase, authenticated bool, privLevel int, requestTimeout time.Duration) []middleware.Middleware {
+func getRouteMiddleware(middlewares []middleware.Middleware, authBase middleware.AuthBase, authenticated bool, privLevel int, permissions []string, requestTimeout time.Duration) []middleware.Middleware {
 	if middlewares == nil {
 		middlewares = middleware.GetDefault(authBase.Secret, requestTimeout)
 	}
 	if authenticated { // a privLevel of zero is an unauthenticated endpoint.
-		authWrapper := authBase.GetWrapper(privLevel)
+		authWrapper := authBase.GetWrapper(privLevel, permissions)
 		middlewares = append(middlewares, authWrapper)
 	}
-	return middlewares
+	return append(middlewares, wrapPreconditions)
 }
 
 // CompileRoutes - takes a map of methods to paths and handlers, and returns a map of methods to CompiledRoutes
@@ -265,26 +268,11 @@ func Handler(
 		return
 	}
 
-	requested := r.URL.Path[1:]
-	mRoutes, ok := routes[r.Method]
-	if !ok {
+	if _, ok := routes[r.Method]; !ok {
 		catchall.ServeHTTP(w, r)
 		return
 	}
-	for _, compiledRoute := range mRoutes {
-		match := compiledRoute.Regex.FindStringSubmatch(requested)
-		if len(match) == 0 {
-			continue
-		}
-		params := map[string]string{}
-		for i, v := range compiledRoute.Params {
-			params[v] = match[i+1]
-		}
-
-		routeCtx := context.WithValue(ctx, api.PathParamsKey, params)
-		r = r.WithContext(routeCtx)
-		r.Header.Add(middleware.RouteID, strconv.Itoa(compiledRoute.ID))
-		compiledRoute.Handler(w, r)
+	if serveRoute(routes, w, r.WithContext(context.WithValue(ctx, routesContextKey, routes))) {
 		return
 	}
 	if IsRequestAPIAndUnknownVersion(r, versions) {
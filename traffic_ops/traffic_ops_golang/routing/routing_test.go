@@ -27,6 +27,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
@@ -200,6 +201,44 @@ func TestRoutes(t *testing.T) {
 	}
 }
 
+// routesWithoutPermissions are the paths of the authenticated routes which
+// don't require permissions: those which are about the user themselves, and
+// those which make requests of other routes, which check their own.
+var routesWithoutPermissions = map[string]struct{}{
+	`about/?$`:                    {},
+	`system/info/?$`:              {},
+	`user/logout/?$`:              {},
+	`user/current/?$`:             {},
+	`user/current/permissions/?$`: {},
+	`batch/?$`:                    {},
+	`graphql/?$`:                  {},
+}
+
+func TestRoutePermissions(t *testing.T) {
+	fake := ServerData{Config: config.NewFakeConfig()}
+	routes, _, _, err := Routes(fake)
+	if err != nil {
+		t.Fatalf("expected: no error getting Routes, actual: %v", err)
+	}
+	permission := regexp.MustCompile(`^[A-Z]+(-[A-Z]+)*:[A-Z]+(-[A-Z]+)*$`)
+	for _, route := range routes {
+		if !route.Authenticated {
+			continue
+		}
+		if _, ok := routesWithoutPermissions[route.Path]; ok {
+			continue
+		}
+		if len(route.RequiredPermissions) == 0 {
+			t.Errorf("expected: route to require permissions, actual: none for %s", route.String())
+		}
+		for _, perm := range route.RequiredPermissions {
+			if !permission.MatchString(perm) {
+				t.Errorf("expected: permissions of the form RESOURCE:ACTION, actual: '%s' for %s", perm, route.String())
+			}
+		}
+	}
+}
+
 func TestCreateRouteMap(t *testing.T) {
 	authBase := middleware.AuthBase{Secret: "secret", Override: func(handlerFunc http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
@@ -238,11 +277,11 @@ func TestCreateRouteMap(t *testing.T) {
 	}
 
 	routes := []Route{
-		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path1`, PathOneHandler, auth.PrivLevelReadOnly, true, nil, 0},
-		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path2`, PathTwoHandler, 0, false, nil, 1},
-		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path3`, PathThreeHandler, 0, false, []middleware.Middleware{}, 2},
-		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path4`, PathFourHandler, 0, false, []middleware.Middleware{}, 3},
-		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path5`, PathFiveHandler, 0, false, []middleware.Middleware{}, 4},
+		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path1`, PathOneHandler, auth.PrivLevelReadOnly, nil, true, nil, 0},
+		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path2`, PathTwoHandler, 0, nil, false, nil, 1},
+		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path3`, PathThreeHandler, 0, nil, false, []middleware.Middleware{}, 2},
+		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path4`, PathFourHandler, 0, nil, false, []middleware.Middleware{}, 3},
+		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path5`, PathFiveHandler, 0, nil, false, []middleware.Middleware{}, 4},
 	}
 
 	disabledRoutesIDs := []int{4}
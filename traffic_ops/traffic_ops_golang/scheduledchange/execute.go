@@ -0,0 +1,333 @@
+package scheduledchange
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/cdn"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/crconfig"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/monitoring"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/server"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+)
+
+// selectDueQuery selects the Scheduled Changes whose windows have opened, in
+// the order they were scheduled to be made.
+const selectDueQuery = `
+SELECT id
+FROM scheduled_change
+WHERE status = 'scheduled' AND window_start <= now()
+ORDER BY window_start, id
+`
+
+// selectLockedQuery selects and locks a Scheduled Change to make it. Other
+// Traffic Ops instances making it at the same time skip it.
+const selectLockedQuery = readQuery + `WHERE sc.id = :id AND sc.status = 'scheduled'
+FOR UPDATE SKIP LOCKED
+`
+
+const waitQuery = `UPDATE scheduled_change SET message = $2 WHERE id = $1 AND message IS DISTINCT FROM $2`
+
+const finishQuery = `UPDATE scheduled_change SET status = $2, message = $3, executed_at = now() WHERE id = $1`
+
+// executor makes Scheduled Changes.
+type executor struct {
+	db        *sqlx.DB
+	cfg       config.Config
+	tv        trafficvault.TrafficVault
+	dbTimeout time.Duration
+}
+
+// StartExecutor starts a goroutine which makes all Scheduled Changes whose
+// windows have opened, every scheduled change interval of the given config,
+// for as long as Traffic Ops runs.
+func StartExecutor(db *sqlx.DB, cfg config.Config, tv trafficvault.TrafficVault) {
+	e := executor{
+		db:        db,
+		cfg:       cfg,
+		tv:        tv,
+		dbTimeout: time.Duration(cfg.DBQueryTimeoutSeconds) * time.Second,
+	}
+	interval := time.Duration(cfg.ScheduledChangeIntervalSeconds) * time.Second
+	go func() {
+		for range time.Tick(interval) {
+			e.executeDue()
+		}
+	}()
+}
+
+// executeDue makes all Scheduled Changes whose windows have opened, each in
+// its own transaction. Errors are logged, and the changes they occurred in
+// are tried again next time.
+func (e executor) executeDue() {
+	ids, err := e.getDueIDs()
+	if err != nil {
+		log.Errorln("scheduled change executor: " + err.Error())
+		return
+	}
+	for _, id := range ids {
+		if err := e.executeDB(id); err != nil {
+			log.Errorf("scheduled change executor: scheduled change %d: %s\n", id, err.Error())
+		}
+	}
+}
+
+func (e executor) getDueIDs() ([]int, error) {
+	dbCtx, cancel := context.WithTimeout(context.Background(), e.dbTimeout)
+	defer cancel()
+	rows, err := e.db.QueryContext(dbCtx, selectDueQuery)
+	if err != nil {
+		return nil, errors.New("querying due scheduled changes: " + err.Error())
+	}
+	defer log.Close(rows, "closing due scheduled change rows")
+	ids := []int{}
+	for rows.Next() {
+		id := 0
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.New("scanning due scheduled changes: " + err.Error())
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating due scheduled changes: " + err.Error())
+	}
+	return ids, nil
+}
+
+// executeDB creates a transaction to pass to execute, and commits it if
+// execute succeeds.
+func (e executor) executeDB(id int) error {
+	dbCtx, cancel := context.WithTimeout(context.Background(), e.dbTimeout)
+	defer cancel()
+	tx, err := e.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return errors.New("beginning tx: " + err.Error())
+	}
+	txCommit := false
+	defer dbhelpers.CommitIf(tx.Tx, &txCommit)
+	if err := e.execute(tx, id); err != nil {
+		return err
+	}
+	txCommit = true
+	return nil
+}
+
+// execute makes the Scheduled Change with the given ID, as the user who
+// scheduled it, if it's still scheduled and no other Traffic Ops is making
+// it. A change which its user can't make because its target is locked by
+// another user waits, until its window closes and it's missed. A change which
+// is no longer valid, or which can't be made because of an error, fails.
+func (e executor) execute(tx *sqlx.Tx, id int) error {
+	changes, err := getScheduledChanges(tx, selectLockedQuery, map[string]interface{}{"id": id})
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil // no longer scheduled, or locked by another Traffic Ops
+	}
+	c := changes[0]
+
+	now := time.Time{}
+	if err := tx.QueryRow(`SELECT now()`).Scan(&now); err != nil {
+		return errors.New("querying time: " + err.Error())
+	}
+	if c.WindowEnd != nil && !now.Before(*c.WindowEnd) {
+		msg := "window closed before the change could be made"
+		if c.Message != nil {
+			msg += " (" + *c.Message + ")"
+		}
+		return finish(tx, c, tc.ScheduledChangeStatusMissed, msg)
+	}
+
+	user, userErr, sysErr, _ := auth.GetCurrentUserFromDB(e.db, c.Username, e.dbTimeout)
+	if sysErr != nil {
+		return errors.New("getting user: " + sysErr.Error())
+	} else if userErr != nil {
+		return finish(tx, c, tc.ScheduledChangeStatusFailed, "getting user "+c.Username+": "+userErr.Error())
+	}
+	if missing := user.MissingPermissions(actionPermissions[c.Action]); len(missing) > 0 {
+		return finish(tx, c, tc.ScheduledChangeStatusFailed, "user "+c.Username+" is missing permissions: "+strings.Join(missing, ", "))
+	}
+	cdnName, userErr, sysErr, _ := checkTarget(tx.Tx, &user, c.ScheduledChangeRequest)
+	if sysErr != nil {
+		return sysErr
+	} else if userErr != nil {
+		return finish(tx, c, tc.ScheduledChangeStatusFailed, userErr.Error())
+	}
+	if userErr, sysErr, _ := checkBlocked(tx.Tx, c.ScheduledChangeRequest, cdnName, user.UserName); sysErr != nil {
+		return sysErr
+	} else if userErr != nil {
+		if _, err := tx.Exec(waitQuery, c.ID, "waiting: "+userErr.Error()); err != nil {
+			return errors.New("setting waiting message: " + err.Error())
+		}
+		return nil
+	}
+
+	// The change may fail part way through, so is made in a savepoint which
+	// can be rolled back, leaving the Scheduled Change to be marked failed.
+	if _, err := tx.Exec(`SAVEPOINT scheduled_change`); err != nil {
+		return errors.New("creating savepoint: " + err.Error())
+	}
+	msg, userErr, sysErr := e.makeChange(tx, &user, c, cdnName)
+	if userErr != nil || sysErr != nil {
+		if _, err := tx.Exec(`ROLLBACK TO SAVEPOINT scheduled_change`); err != nil {
+			return errors.New("rolling back to savepoint: " + err.Error())
+		}
+		if sysErr != nil {
+			// A system error would likely recur, so rather than trying the
+			// change again - forever, if it has no window end - it fails,
+			// without exposing the error to users.
+			log.Errorf("scheduled change executor: scheduled change %d: making change: %s\n", c.ID, sysErr.Error())
+			return finish(tx, c, tc.ScheduledChangeStatusFailed, "an internal error occurred while making the change")
+		}
+		return finish(tx, c, tc.ScheduledChangeStatusFailed, userErr.Error())
+	}
+	return finish(tx, c, tc.ScheduledChangeStatusCompleted, msg)
+}
+
+// makeChange makes the change of a Scheduled Change to a target in the given
+// CDN, as the given user. It returns a message describing the change, or any
+// user error and system error.
+func (e executor) makeChange(tx *sqlx.Tx, user *auth.CurrentUser, c tc.ScheduledChange, cdnName string) (string, error, error) {
+	switch c.Action {
+	case tc.ScheduledChangeActionServerStatus:
+		id, _, userErr, sysErr, _ := getServer(tx.Tx, c.Target)
+		if userErr != nil || sysErr != nil {
+			return "", userErr, sysErr
+		}
+		msg, userErr, sysErr, _ := server.UpdateStatus(tx.Tx, user, id, tc.ServerPutStatus{
+			Status:        util.JSONNameOrIDStr{Name: c.Parameters.Status},
+			OfflineReason: c.Parameters.OfflineReason,
+		})
+		return msg, userErr, sysErr
+	case tc.ScheduledChangeActionQueueUpdates:
+		id, ok, err := dbhelpers.GetCDNIDFromName(tx.Tx, tc.CDNName(cdnName))
+		if err != nil {
+			return "", nil, err
+		} else if !ok {
+			return "", errors.New("no cdn exists with name '" + cdnName + "'"), nil
+		}
+		queue := c.Parameters.Queue == nil || *c.Parameters.Queue
+		if err := cdn.QueueUpdates(tx.Tx, int64(id), queue); err != nil {
+			return "", nil, err
+		}
+		if !queue {
+			return "dequeued updates on all servers of cdn " + cdnName, nil, nil
+		}
+		return "queued updates on all servers of cdn " + cdnName, nil, nil
+	case tc.ScheduledChangeActionSnapshot:
+		crConfig, err := crconfig.Make(tx.Tx, cdnName, user.UserName, "", e.cfg.Version, false, false)
+		if err != nil {
+			return "", nil, err
+		}
+		monitoringJSON, err := monitoring.GetMonitoringJSON(tx.Tx, cdnName)
+		if err != nil {
+			return "", nil, errors.New("getting monitoring.json data: " + err.Error())
+		}
+		if err := crconfig.Snapshot(tx.Tx, crConfig, monitoringJSON); err != nil {
+			return "", nil, errors.New("snapshotting CRConfig and Monitoring: " + err.Error())
+		}
+		if err := deliveryservice.DeleteOldCerts(e.db.DB, tx.Tx, &e.cfg, tc.CDNName(cdnName), e.tv); err != nil {
+			return "", nil, errors.New("starting old certificate deletion job: " + err.Error())
+		}
+		return "Snapshot of CRConfig and Monitor", nil, nil
+	case tc.ScheduledChangeActionDeliveryServiceUpdate:
+		dses, userErr, sysErr, _ := deliveryservice.GetDeliveryServices(deliveryservice.SelectDeliveryServicesQuery+" WHERE ds.xml_id = :xmlid", map[string]interface{}{"xmlid": c.Target}, tx)
+		if userErr != nil || sysErr != nil {
+			return "", userErr, sysErr
+		}
+		if len(dses) == 0 {
+			return "", errors.New("no delivery service exists with xmlId '" + c.Target + "'"), nil
+		}
+		ds, err := applyFields(dses[0], c.Parameters.Fields)
+		if err != nil {
+			return "", err, nil
+		}
+		inf := &api.APIInfo{Tx: tx, User: user, Version: &api.Version{Major: 4}, Vault: e.tv, Config: &e.cfg}
+		if _, _, _, userErr, sysErr := deliveryservice.UpdateV4(&http.Request{Header: http.Header{}}, inf, &ds); userErr != nil || sysErr != nil {
+			return "", userErr, sysErr
+		}
+		return describe(c) + " was successful", nil, nil
+	}
+	return "", errors.New("unknown action '" + string(c.Action) + "'"), nil
+}
+
+// applyFields returns the given Delivery Service with the given fields,
+// named as in its JSON representation, set to the given values.
+func applyFields(ds tc.DeliveryServiceV4, fields map[string]json.RawMessage) (tc.DeliveryServiceV4, error) {
+	ds.RemoveLD1AndLD2()
+	bts, err := json.Marshal(ds)
+	if err != nil {
+		return tc.DeliveryServiceV4{}, errors.New("marshalling delivery service: " + err.Error())
+	}
+	obj := map[string]json.RawMessage{}
+	if err := json.Unmarshal(bts, &obj); err != nil {
+		return tc.DeliveryServiceV4{}, errors.New("unmarshalling delivery service: " + err.Error())
+	}
+	for field, val := range fields {
+		obj[field] = val
+	}
+	if bts, err = json.Marshal(obj); err != nil {
+		return tc.DeliveryServiceV4{}, errors.New("marshalling changed delivery service: " + err.Error())
+	}
+	changed := tc.DeliveryServiceV4{}
+	if err := json.Unmarshal(bts, &changed); err != nil {
+		return tc.DeliveryServiceV4{}, errors.New("invalid fields: " + err.Error())
+	}
+	return changed, nil
+}
+
+// finish gives a Scheduled Change the given final status and message.
+func finish(tx *sqlx.Tx, c tc.ScheduledChange, status tc.ScheduledChangeStatus, msg string) error {
+	if _, err := tx.Exec(finishQuery, c.ID, status, msg); err != nil {
+		return errors.New("finishing scheduled change: " + err.Error())
+	}
+	if status != tc.ScheduledChangeStatusCompleted {
+		log.Warnf("scheduled change %d of cdn %s %s: %s\n", c.ID, c.CDN, status, msg)
+	}
+	return changeLog(tx, c, fmt.Sprintf("Scheduled %s %s: %s", describe(c), status, msg))
+}
+
+// changeLog writes a change log message for a Scheduled Change, as the user
+// who scheduled it, since the executor has no user of its own.
+func changeLog(tx *sqlx.Tx, c tc.ScheduledChange, action string) error {
+	msg := fmt.Sprintf("SCHEDULED CHANGE: %d, CDN: %s, ACTION: %s", c.ID, c.CDN, action)
+	if _, err := tx.Exec(`INSERT INTO log (level, message, tm_user) VALUES ($1, $2, (SELECT id FROM tm_user WHERE username = $3))`, api.ApiChange, msg, c.Username); err != nil {
+		return errors.New("inserting change log: " + err.Error())
+	}
+	return nil
+}
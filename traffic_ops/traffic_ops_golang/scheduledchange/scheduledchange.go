@@ -0,0 +1,458 @@
+// Package scheduledchange provides handlers for Scheduled Changes, changes
+// which Traffic Ops makes during a window of time as the user who scheduled
+// them, and the executor which makes them.
+package scheduledchange
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/rollout"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+const readQuery = `
+SELECT
+	sc.id,
+	sc.action,
+	sc.cdn,
+	sc.target,
+	sc.parameters,
+	sc.window_start,
+	sc.window_end,
+	sc.username,
+	sc.status,
+	sc.comment,
+	sc.message,
+	sc.executed_at,
+	sc.created,
+	sc.last_updated
+FROM scheduled_change sc
+`
+
+const insertQuery = `
+INSERT INTO scheduled_change (
+	action,
+	cdn,
+	target,
+	parameters,
+	window_start,
+	window_end,
+	username,
+	comment
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id
+`
+
+// selectOverlappingQuery selects a Scheduled Change, not yet made, of the
+// same action and target as one being scheduled, whose window overlaps its
+// window.
+const selectOverlappingQuery = `
+SELECT id
+FROM scheduled_change
+WHERE status = 'scheduled' AND action = $1 AND target = $2 AND window_start < $4 AND window_end > $3
+ORDER BY id
+LIMIT 1
+`
+
+// selectServerQuery selects the ID and CDN of the servers with a host name.
+const selectServerQuery = `
+SELECT s.id, cdn.name
+FROM server s
+JOIN cdn ON cdn.id = s.cdn_id
+WHERE s.host_name = $1
+`
+
+const selectDeliveryServiceCDNQuery = `
+SELECT cdn.name
+FROM deliveryservice ds
+JOIN cdn ON cdn.id = ds.cdn_id
+WHERE ds.xml_id = $1
+`
+
+const cancelQuery = `UPDATE scheduled_change SET status = 'cancelled', message = $2, executed_at = now() WHERE id = $1`
+
+// actionPermissions are the Permissions a user needs to schedule each action,
+// and to have it made: those of the endpoint which makes the same change.
+var actionPermissions = map[tc.ScheduledChangeAction]string{
+	tc.ScheduledChangeActionServerStatus:          "SERVER:STATUS",
+	tc.ScheduledChangeActionQueueUpdates:          "SERVER:QUEUE",
+	tc.ScheduledChangeActionSnapshot:              "CDN:SNAPSHOT",
+	tc.ScheduledChangeActionDeliveryServiceUpdate: "DELIVERY-SERVICE:UPDATE",
+}
+
+// Read is the handler for GET requests to /scheduled_changes.
+func Read(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cols := map[string]dbhelpers.WhereColumnInfo{
+		"id":       {Column: "sc.id", Checker: api.IsInt},
+		"action":   {Column: "sc.action", Checker: nil},
+		"cdn":      {Column: "sc.cdn", Checker: nil},
+		"target":   {Column: "sc.target", Checker: nil},
+		"status":   {Column: "sc.status", Checker: nil},
+		"username": {Column: "sc.username", Checker: nil},
+	}
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, cols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+	if orderBy == "" {
+		orderBy = "\nORDER BY sc.window_start, sc.id"
+	}
+
+	changes, err := getScheduledChanges(inf.Tx, readQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, changes)
+}
+
+// Create is the handler for POST requests to /scheduled_changes.
+//
+// The user must be able to make the change now, except that its target may
+// be locked by another user, since the lock may be released by the time its
+// window opens.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	req := tc.ScheduledChangeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("malformed JSON: "+err.Error()), nil)
+		return
+	}
+	if err := req.Validate(time.Now()); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	windowEnd := req.End()
+	req.WindowEnd = &windowEnd
+	if req.Action == tc.ScheduledChangeActionQueueUpdates && req.Parameters.Queue == nil {
+		queue := true
+		req.Parameters.Queue = &queue
+	}
+
+	if missing := inf.User.MissingPermissions(actionPermissions[req.Action]); len(missing) > 0 {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("missing permissions to schedule "+string(req.Action)+": "+strings.Join(missing, ", ")), nil)
+		return
+	}
+	cdn, userErr, sysErr, errCode := checkTarget(tx, inf.User, req)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	overlapping := 0
+	if err := tx.QueryRow(selectOverlappingQuery, req.Action, req.Target, req.WindowStart, *req.WindowEnd).Scan(&overlapping); err == nil {
+		api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("scheduled change %d already makes a %s change to '%s' in an overlapping window", overlapping, req.Action, req.Target), nil)
+		return
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("querying overlapping scheduled changes: "+err.Error()))
+		return
+	}
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "scheduled change created")
+	userErr, sysErr, errCode = checkBlocked(tx, req, cdn, inf.User.UserName)
+	if sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, nil, sysErr)
+		return
+	} else if userErr != nil {
+		alerts.AddNewAlert(tc.WarnLevel, "the change can't be made now, and won't be made until it can be, within its window: "+userErr.Error())
+	}
+
+	parameters, err := json.Marshal(req.Parameters)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("marshalling scheduled change parameters: "+err.Error()))
+		return
+	}
+	id := 0
+	if err := tx.QueryRow(insertQuery,
+		req.Action,
+		cdn,
+		req.Target,
+		parameters,
+		req.WindowStart,
+		*req.WindowEnd,
+		inf.User.UserName,
+		req.Comment,
+	).Scan(&id); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	change, ok, err := getScheduledChange(inf.Tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("scheduled change %d not found after inserting it", id))
+		return
+	}
+
+	msg := fmt.Sprintf("SCHEDULED CHANGE: %d, CDN: %s, ACTION: Scheduled %s between %s and %s", id, cdn, describe(change), req.WindowStart.Format(time.RFC3339), req.WindowEnd.Format(time.RFC3339))
+	api.CreateChangeLogRawTx(api.ApiChange, msg, inf.User, tx)
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, change)
+}
+
+// Cancel is the handler for POST requests to /scheduled_changes/{id}/cancel.
+//
+// Only Scheduled Changes which haven't been made yet may be cancelled.
+func Cancel(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	change, ok, err := getScheduledChange(inf.Tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no scheduled change exists with id %d", id), nil)
+		return
+	}
+	if change.Status != tc.ScheduledChangeStatusScheduled {
+		api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("scheduled change %d is %s, and can't be cancelled", id, change.Status), nil)
+		return
+	}
+
+	if _, err := tx.Exec(cancelQuery, id, "cancelled by "+inf.User.UserName); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("cancelling scheduled change: "+err.Error()))
+		return
+	}
+	change, ok, err = getScheduledChange(inf.Tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("scheduled change %d not found after cancelling it", id))
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("SCHEDULED CHANGE: %d, CDN: %s, ACTION: Scheduled %s cancelled", id, change.CDN, describe(change)), inf.User, tx)
+	api.WriteAlertsObj(w, r, http.StatusOK, tc.CreateAlerts(tc.SuccessLevel, "scheduled change cancelled"), change)
+}
+
+// checkTarget checks that the target of a requested change exists, and that
+// the given user may change it. It returns the name of the target's CDN, or
+// any user error, system error, and the HTTP status code to return.
+func checkTarget(tx *sql.Tx, user *auth.CurrentUser, req tc.ScheduledChangeRequest) (string, error, error, int) {
+	switch req.Action {
+	case tc.ScheduledChangeActionServerStatus:
+		_, cdn, userErr, sysErr, errCode := getServer(tx, req.Target)
+		if userErr != nil || sysErr != nil {
+			return "", userErr, sysErr, errCode
+		}
+		if _, ok, err := dbhelpers.GetStatusByName(*req.Parameters.Status, tx); err != nil {
+			return "", nil, err, http.StatusInternalServerError
+		} else if !ok {
+			return "", errors.New("parameters.status: no status exists with name '" + *req.Parameters.Status + "'"), nil, http.StatusBadRequest
+		}
+		return cdn, nil, nil, http.StatusOK
+	case tc.ScheduledChangeActionQueueUpdates, tc.ScheduledChangeActionSnapshot:
+		if _, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(req.Target)); err != nil {
+			return "", nil, err, http.StatusInternalServerError
+		} else if !ok {
+			return "", errors.New("no cdn exists with name '" + req.Target + "'"), nil, http.StatusBadRequest
+		}
+		return req.Target, nil, nil, http.StatusOK
+	case tc.ScheduledChangeActionDeliveryServiceUpdate:
+		cdn := ""
+		if err := tx.QueryRow(selectDeliveryServiceCDNQuery, req.Target).Scan(&cdn); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", errors.New("no delivery service exists with xmlId '" + req.Target + "'"), nil, http.StatusBadRequest
+			}
+			return "", nil, errors.New("querying delivery service cdn: " + err.Error()), http.StatusInternalServerError
+		}
+		if userErr, sysErr, errCode := tenant.Check(user, req.Target, tx); userErr != nil || sysErr != nil {
+			return "", userErr, sysErr, errCode
+		}
+		return cdn, nil, nil, http.StatusOK
+	}
+	return "", errors.New("unknown action '" + string(req.Action) + "'"), nil, http.StatusBadRequest
+}
+
+// getServer returns the ID and CDN of the server with the given host name, or
+// any user error, system error, and the HTTP status code to return. Host names
+// aren't unique, so servers which share one can't be targeted.
+func getServer(tx *sql.Tx, hostName string) (int, string, error, error, int) {
+	rows, err := tx.Query(selectServerQuery, hostName)
+	if err != nil {
+		return 0, "", nil, errors.New("querying server: " + err.Error()), http.StatusInternalServerError
+	}
+	defer log.Close(rows, "closing server rows")
+	id := 0
+	cdn := ""
+	num := 0
+	for rows.Next() {
+		if err := rows.Scan(&id, &cdn); err != nil {
+			return 0, "", nil, errors.New("scanning server: " + err.Error()), http.StatusInternalServerError
+		}
+		num++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, "", nil, errors.New("iterating servers: " + err.Error()), http.StatusInternalServerError
+	}
+	if num == 0 {
+		return 0, "", errors.New("no server exists with host name '" + hostName + "'"), nil, http.StatusBadRequest
+	}
+	if num > 1 {
+		return 0, "", fmt.Errorf("%d servers have host name '%s'; scheduled changes can only target a server with a unique host name", num, hostName), nil, http.StatusBadRequest
+	}
+	return id, cdn, nil, nil, http.StatusOK
+}
+
+// checkBlocked checks whether the given user can't make a change to a target
+// in the given CDN right now, because it's locked by another user, or, when
+// queueing updates, because the CDN has a Rollout in progress. It returns any
+// user error, system error, and the HTTP status code to return.
+func checkBlocked(tx *sql.Tx, req tc.ScheduledChangeRequest, cdn string, username string) (error, error, int) {
+	switch req.Action {
+	case tc.ScheduledChangeActionDeliveryServiceUpdate:
+		if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(tx, cdn, username); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		return dbhelpers.CheckIfCurrentUserCanModifyDeliveryService(tx, req.Target, username)
+	case tc.ScheduledChangeActionQueueUpdates:
+		if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserHasCdnLock(tx, cdn, username); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		return rollout.CheckQueueAllowed(tx, cdn)
+	}
+	return dbhelpers.CheckIfCurrentUserHasCdnLock(tx, cdn, username)
+}
+
+// describe returns a short description of the change a Scheduled Change
+// makes, for change logs.
+func describe(c tc.ScheduledChange) string {
+	switch c.Action {
+	case tc.ScheduledChangeActionServerStatus:
+		status := ""
+		if c.Parameters.Status != nil {
+			status = *c.Parameters.Status
+		}
+		return fmt.Sprintf("status change of server %s to %s", c.Target, status)
+	case tc.ScheduledChangeActionQueueUpdates:
+		if c.Parameters.Queue != nil && !*c.Parameters.Queue {
+			return "dequeueing of updates on cdn " + c.Target
+		}
+		return "queueing of updates on cdn " + c.Target
+	case tc.ScheduledChangeActionSnapshot:
+		return "snapshot of cdn " + c.Target
+	case tc.ScheduledChangeActionDeliveryServiceUpdate:
+		fields := make([]string, 0, len(c.Parameters.Fields))
+		for field := range c.Parameters.Fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		return fmt.Sprintf("update of %s of delivery service %s", strings.Join(fields, ", "), c.Target)
+	}
+	return string(c.Action) + " of " + c.Target
+}
+
+// getScheduledChange returns the Scheduled Change with the given ID, and
+// whether it exists.
+func getScheduledChange(tx *sqlx.Tx, id int) (tc.ScheduledChange, bool, error) {
+	changes, err := getScheduledChanges(tx, readQuery+"WHERE sc.id = :id", map[string]interface{}{"id": id})
+	if err != nil {
+		return tc.ScheduledChange{}, false, err
+	}
+	if len(changes) == 0 {
+		return tc.ScheduledChange{}, false, nil
+	}
+	return changes[0], true, nil
+}
+
+// getScheduledChanges returns the Scheduled Changes selected by the given
+// query, a readQuery with optional clauses.
+func getScheduledChanges(tx *sqlx.Tx, query string, queryValues map[string]interface{}) ([]tc.ScheduledChange, error) {
+	rows, err := tx.NamedQuery(query, queryValues)
+	if err != nil {
+		return nil, errors.New("querying scheduled changes: " + err.Error())
+	}
+	defer log.Close(rows, "closing scheduled change rows")
+
+	changes := []tc.ScheduledChange{}
+	for rows.Next() {
+		c := tc.ScheduledChange{}
+		parameters := []byte{}
+		if err := rows.Scan(
+			&c.ID,
+			&c.Action,
+			&c.CDN,
+			&c.Target,
+			&parameters,
+			&c.WindowStart,
+			&c.WindowEnd,
+			&c.Username,
+			&c.Status,
+			&c.Comment,
+			&c.Message,
+			&c.ExecutedAt,
+			&c.Created,
+			&c.LastUpdated,
+		); err != nil {
+			return nil, errors.New("scanning scheduled changes: " + err.Error())
+		}
+		if err := json.Unmarshal(parameters, &c.Parameters); err != nil {
+			return nil, fmt.Errorf("unmarshalling parameters of scheduled change %d: %s", c.ID, err.Error())
+		}
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating scheduled changes: " + err.Error())
+	}
+	return changes, nil
+}
@@ -0,0 +1,85 @@
+package scheduledchange
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestApplyFields(t *testing.T) {
+	ds := tc.DeliveryServiceV4{}
+	ds.ID = util.IntPtr(1)
+	ds.XMLID = util.StrPtr("ds1")
+	ds.Active = util.BoolPtr(true)
+	ds.DisplayName = util.StrPtr("old")
+	ds.LongDesc1 = util.StrPtr("removed")
+	ds.TLSVersions = []string{"1.2"}
+
+	changed, err := applyFields(ds, map[string]json.RawMessage{
+		"active":      json.RawMessage(`false`),
+		"displayName": json.RawMessage(`"new"`),
+		"tlsVersions": json.RawMessage(`null`),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changed.ID == nil || *changed.ID != 1 || changed.XMLID == nil || *changed.XMLID != "ds1" {
+		t.Errorf("expected unchanged id and xmlId, actual %v and %v", changed.ID, changed.XMLID)
+	}
+	if changed.Active == nil || *changed.Active {
+		t.Errorf("expected active to be changed to false, actual %v", changed.Active)
+	}
+	if changed.DisplayName == nil || *changed.DisplayName != "new" {
+		t.Errorf("expected displayName to be changed to 'new', actual %v", changed.DisplayName)
+	}
+	if changed.TLSVersions != nil {
+		t.Errorf("expected tlsVersions to be changed to null, actual %v", changed.TLSVersions)
+	}
+	if changed.LongDesc1 != nil {
+		t.Errorf("expected longDesc1 to be removed, actual %v", *changed.LongDesc1)
+	}
+
+	if _, err := applyFields(ds, map[string]json.RawMessage{"active": json.RawMessage(`"yes"`)}); err == nil {
+		t.Error("expected an error changing a field to a value of the wrong type, got none")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	dequeue := false
+	tests := []struct {
+		change   tc.ScheduledChange
+		expected string
+	}{
+		{tc.ScheduledChange{ScheduledChangeRequest: tc.ScheduledChangeRequest{Action: tc.ScheduledChangeActionServerStatus, Target: "edge", Parameters: tc.ScheduledChangeParameters{Status: util.StrPtr("OFFLINE")}}}, "status change of server edge to OFFLINE"},
+		{tc.ScheduledChange{ScheduledChangeRequest: tc.ScheduledChangeRequest{Action: tc.ScheduledChangeActionQueueUpdates, Target: "cdn1"}}, "queueing of updates on cdn cdn1"},
+		{tc.ScheduledChange{ScheduledChangeRequest: tc.ScheduledChangeRequest{Action: tc.ScheduledChangeActionQueueUpdates, Target: "cdn1", Parameters: tc.ScheduledChangeParameters{Queue: &dequeue}}}, "dequeueing of updates on cdn cdn1"},
+		{tc.ScheduledChange{ScheduledChangeRequest: tc.ScheduledChangeRequest{Action: tc.ScheduledChangeActionSnapshot, Target: "cdn1"}}, "snapshot of cdn cdn1"},
+		{tc.ScheduledChange{ScheduledChangeRequest: tc.ScheduledChangeRequest{Action: tc.ScheduledChangeActionDeliveryServiceUpdate, Target: "ds1", Parameters: tc.ScheduledChangeParameters{Fields: map[string]json.RawMessage{"displayName": nil, "active": nil}}}}, "update of active, displayName of delivery service ds1"},
+	}
+	for _, test := range tests {
+		if actual := describe(test.change); actual != test.expected {
+			t.Errorf("expected description '%s', actual '%s'", test.expected, actual)
+		}
+	}
+}
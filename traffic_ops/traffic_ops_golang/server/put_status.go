@@ -32,7 +32,9 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/rollout"
 )
 
 // InvalidStatusForDeliveryServicesAlertText returns a string describing that
@@ -75,28 +77,47 @@ func UpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id := inf.IntParams["id"]
+	msg, userErr, sysErr, errCode := UpdateStatus(tx, inf.User, inf.IntParams["id"], reqObj)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, msg, inf.User, tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, msg)
+}
+
+// UpdateStatus changes the status of the server with the given ID as
+// requested, as the given user, and queues updates on its child caches if it's
+// a cache server. It returns a message describing the change, or any user
+// error, system error, and the HTTP status code to return.
+func UpdateStatus(tx *sql.Tx, user *auth.CurrentUser, id int, reqObj tc.ServerPutStatus) (string, error, error, int) {
 	serverInfo, exists, err := dbhelpers.GetServerInfo(id, tx)
 	if err != nil {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
-		return
+		return "", nil, err, http.StatusInternalServerError
 	}
 	if !exists {
-		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("server ID %d not found", id), nil)
-		return
+		return "", fmt.Errorf("server ID %d not found", id), nil, http.StatusNotFound
 	}
-	cdnName, err := dbhelpers.GetCDNNameFromServerID(inf.Tx.Tx, int64(id))
+	cdnName, err := dbhelpers.GetCDNNameFromServerID(tx, int64(id))
 	if err != nil {
-		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
-		return
+		return "", nil, err, http.StatusInternalServerError
 	}
-	userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserHasCdnLock(inf.Tx.Tx, string(cdnName), inf.User.UserName)
+	userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserHasCdnLock(tx, string(cdnName), user.UserName)
 	if statusCode == http.StatusForbidden {
 		userErr = fmt.Errorf("this action will result in server updates being queued and %v", userErr)
 	}
 	if userErr != nil || sysErr != nil {
-		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
-		return
+		return "", userErr, sysErr, statusCode
+	}
+	// updates are queued on child servers if server is ^EDGE or ^MID
+	queueChildren := strings.HasPrefix(serverInfo.Type, tc.CacheTypeEdge.String()) || strings.HasPrefix(serverInfo.Type, tc.CacheTypeMid.String())
+	if queueChildren {
+		if userErr, sysErr, statusCode := rollout.CheckQueueAllowed(tx, string(cdnName)); userErr != nil || sysErr != nil {
+			if statusCode == http.StatusConflict {
+				userErr = fmt.Errorf("this action will result in server updates being queued and %v", userErr)
+			}
+			return "", userErr, sysErr, statusCode
+		}
 	}
 	status := tc.StatusNullable{}
 	statusExists := false
@@ -105,24 +126,20 @@ func UpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
 	} else if reqObj.Status.ID != nil {
 		status, statusExists, err = dbhelpers.GetStatusByID(*reqObj.Status.ID, tx)
 	} else {
-		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("status is required"), nil)
-		return
+		return "", errors.New("status is required"), nil, http.StatusBadRequest
 	}
 	if err != nil {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
-		return
+		return "", nil, err, http.StatusInternalServerError
 	}
 	if !statusExists {
-		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("invalid status (does not exist)"), nil)
-		return
+		return "", errors.New("invalid status (does not exist)"), nil, http.StatusBadRequest
 	}
 
 	if *status.Name == tc.CacheStatusAdminDown.String() || *status.Name == tc.CacheStatusOffline.String() {
 		if reqObj.OfflineReason == nil {
-			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("offlineReason is required for "+tc.CacheStatusAdminDown.String()+" or "+tc.CacheStatusOffline.String()+" status"), nil)
-			return
+			return "", errors.New("offlineReason is required for " + tc.CacheStatusAdminDown.String() + " or " + tc.CacheStatusOffline.String() + " status"), nil, http.StatusBadRequest
 		}
-		*reqObj.OfflineReason = inf.User.UserName + ": " + *reqObj.OfflineReason
+		*reqObj.OfflineReason = user.UserName + ": " + *reqObj.OfflineReason
 	} else {
 		reqObj.OfflineReason = nil
 	}
@@ -131,19 +148,14 @@ func UpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
 	if *status.Name != string(tc.CacheStatusOnline) && *status.Name != string(tc.CacheStatusReported) && *status.ID != existingStatus {
 		dsIDs, err := getActiveDeliveryServicesThatOnlyHaveThisServerAssigned(id, tx)
 		if err != nil {
-			sysErr = fmt.Errorf("getting Delivery Services to which server #%d is assigned that have no other servers: %v", id, err)
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-			return
+			return "", nil, fmt.Errorf("getting Delivery Services to which server #%d is assigned that have no other servers: %v", id, err), http.StatusInternalServerError
 		}
 		if len(dsIDs) > 0 {
-			alertText := InvalidStatusForDeliveryServicesAlertText(*status.Name, dsIDs)
-			api.WriteAlerts(w, r, http.StatusConflict, tc.CreateAlerts(tc.ErrorLevel, alertText))
-			return
+			return "", errors.New(InvalidStatusForDeliveryServicesAlertText(*status.Name, dsIDs)), nil, http.StatusConflict
 		}
 	}
 	if err := updateServerStatusAndOfflineReason(existingStatus, *status.ID, id, existingStatusUpdatedTime, reqObj.OfflineReason, tx); err != nil {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
-		return
+		return "", nil, err, http.StatusInternalServerError
 	}
 	offlineReason := ""
 	if reqObj.OfflineReason != nil {
@@ -151,16 +163,13 @@ func UpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	msg := "Updated status [ " + *status.Name + " ] for " + serverInfo.HostName + "." + serverInfo.DomainName + " [ " + offlineReason + " ]"
 
-	// queue updates on child servers if server is ^EDGE or ^MID
-	if strings.HasPrefix(serverInfo.Type, tc.CacheTypeEdge.String()) || strings.HasPrefix(serverInfo.Type, tc.CacheTypeMid.String()) {
+	if queueChildren {
 		if err := queueUpdatesOnChildCaches(tx, serverInfo.CDNID, serverInfo.CachegroupID); err != nil {
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
-			return
+			return "", nil, err, http.StatusInternalServerError
 		}
 		msg += " and queued updates on all child caches"
 	}
-	api.CreateChangeLogRawTx(api.ApiChange, msg, inf.User, tx)
-	api.WriteRespAlert(w, r, tc.SuccessLevel, msg)
+	return msg, nil, nil, http.StatusOK
 }
 
 // queueUpdatesOnChildCaches queues updates on child caches of the given cdnID and parentCachegroupID and returns an error (if one occurs).
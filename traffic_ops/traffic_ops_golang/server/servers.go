@@ -863,7 +863,20 @@ func Read(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if version.Major >= 4 {
-		api.WriteRespWithSummary(w, r, servers, serverCount)
+		summary := tc.ResponseSummary{Count: serverCount}
+		if len(servers) > 0 && servers[len(servers)-1].ID != nil {
+			summary.NextCursor = dbhelpers.NextCursor(inf.Params, len(servers), *servers[len(servers)-1].ID)
+		}
+		if fields, ok := inf.Params[api.FieldsQueryParam]; ok {
+			selected, err := api.SelectFields(servers, fields)
+			if err != nil {
+				api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+				return
+			}
+			api.WriteRespSummary(w, r, selected, summary)
+			return
+		}
+		api.WriteRespSummary(w, r, servers, summary)
 		return
 	}
 	if version.Major >= 3 {
@@ -918,6 +931,13 @@ func getServerCount(tx *sqlx.Tx, query string, queryValues map[string]interface{
 	return serverCount, nil
 }
 
+// GetServers returns the servers matching the given query parameters, as read
+// by the servers endpoint of the given API version.
+func GetServers(params map[string]string, tx *sqlx.Tx, user *auth.CurrentUser, version api.Version) ([]tc.ServerV40, error, error, int) {
+	servers, _, userErr, sysErr, errCode, _ := getServers(nil, params, tx, user, false, version)
+	return servers, userErr, sysErr, errCode
+}
+
 func getServers(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth.CurrentUser, useIMS bool, version api.Version) ([]tc.ServerV40, uint64, error, error, int, *time.Time) {
 	var maxTime time.Time
 	var runSecond bool
@@ -935,6 +955,7 @@ func getServers(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth
 		"topology":         {Column: "tc.topology", Checker: nil},
 		"type":             {Column: "t.name", Checker: nil},
 		"dsId":             {Column: "dss.deliveryservice", Checker: nil},
+		"lastUpdated":      {Column: "s.last_updated", Checker: api.IsTime},
 	}
 
 	if version.Major >= 3 {
@@ -997,15 +1018,23 @@ func getServers(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth
 `
 	}
 
-	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(params, queryParamsToSQLCols)
-	if dsHasRequiredCapabilities {
-		where += requiredCapabilitiesCondition
-	}
+	clauses, errs := dbhelpers.BuildQueryClauses(params, queryParamsToSQLCols)
 	if len(errs) > 0 {
 		return nil, 0, util.JoinErrs(errs), nil, http.StatusBadRequest, nil
 	}
+	if _, ok := params["dsId"]; ok && clauses.Cursor {
+		// The ORG and mid-tier servers of a Delivery Service are added to its
+		// servers regardless of the page, so they can't be paged through.
+		return nil, 0, errors.New("cursor cannot be used with dsId"), nil, http.StatusBadRequest, nil
+	}
+	where, orderBy, pagination, queryValues := clauses.Where, clauses.OrderBy, clauses.Pagination, clauses.Values
+	filterWhere := clauses.FilterWhere
+	if dsHasRequiredCapabilities {
+		where += requiredCapabilitiesCondition
+		filterWhere += requiredCapabilitiesCondition
+	}
 
-	countQuery := serverCountQuery + queryAddition + where
+	countQuery := serverCountQuery + queryAddition + filterWhere
 	// If we are querying for a DS that has reqd capabilities, we need to make sure that we also include all the ORG servers directly assigned to this DS
 	if _, ok := params["dsId"]; ok && dsHasRequiredCapabilities {
 		countQuery = `SELECT (` + countQuery + `) + (` + serverCountQuery + originServerQuery + `) AS total`
@@ -34,6 +34,7 @@ import (
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 	"github.com/lib/pq"
 )
@@ -193,6 +194,14 @@ func AssignDeliveryServicesToServerHandler(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	changedDSes := dsList
+	if replace {
+		if changedDSes, err = getChangedDeliveryServices(server, dsList, tx); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting delivery services assigned to server #%d: %v", server, err))
+			return
+		}
+	}
+
 	assignedDSes, err := assignDeliveryServicesToServer(server, dsList, replace, tx)
 	if err != nil {
 		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting server name from ID: "+err.Error()))
@@ -202,6 +211,13 @@ func AssignDeliveryServicesToServerHandler(w http.ResponseWriter, r *http.Reques
 		api.HandleErr(w, r, tx, http.StatusNotFound, errors.New("no server with that ID found"), nil)
 	}
 
+	for _, dsID := range changedDSes {
+		if _, err := deliveryservice.CreateVersion(inf.Tx, dsID, inf.User); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("assigning delivery services to server: "+err.Error()))
+			return
+		}
+	}
+
 	api.CreateChangeLogRawTx(api.ApiChange, "SERVER: "+serverInfo.HostName+", ID: "+strconv.Itoa(server)+", ACTION: Assigned "+strconv.Itoa(len(assignedDSes))+" DSes to server", inf.User, tx)
 	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "successfully assigned dses to server", tc.AssignedDsResponse{ServerID: server, DSIds: assignedDSes, Replace: replace})
 }
@@ -310,6 +326,31 @@ func ValidateDSCapabilities(dsIDs []int, serverName string, tx *sql.Tx) (error,
 	return nil, nil, 0
 }
 
+// getChangedDeliveryServices returns the IDs of the Delivery Services whose
+// assignments change when the given server's assignments are replaced by the
+// given Delivery Services: those given, and those currently assigned to it.
+func getChangedDeliveryServices(server int, dses []int, tx *sql.Tx) ([]int, error) {
+	rows, err := tx.Query(`
+SELECT deliveryservice FROM deliveryservice_server WHERE server = $1
+UNION
+SELECT UNNEST($2::bigint[])
+ORDER BY 1`, server, pq.Array(dses))
+	if err != nil {
+		return nil, fmt.Errorf("querying: %v", err)
+	}
+	defer log.Close(rows, "closing changed delivery service rows")
+
+	ids := []int{}
+	for rows.Next() {
+		id := 0
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 func assignDeliveryServicesToServer(server int, dses []int, replace bool, tx *sql.Tx) ([]int, error) {
 	if replace {
 		//delete currently assigned dses from server
@@ -81,7 +81,8 @@ DELETE FROM server_capability WHERE name=:name
 
 func (v *TOServerCapability) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
-		"name": {Column: "sc.name"},
+		"lastUpdated": {Column: "sc.last_updated", Checker: api.IsTime},
+		"name":        {Column: "sc.name"},
 	}
 }
 
@@ -80,7 +80,8 @@ func (serviceCategory TOServiceCategory) GetType() string {
 
 func (serviceCategory *TOServiceCategory) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
-		"name": dbhelpers.WhereColumnInfo{Column: "sc.name"},
+		"lastUpdated": dbhelpers.WhereColumnInfo{Column: "sc.last_updated", Checker: api.IsTime},
+		"name":        dbhelpers.WhereColumnInfo{Column: "sc.name"},
 	}
 }
 
@@ -49,6 +49,7 @@ func (v *TOStaticDNSEntry) NewReadObj() interface{}       { return &tc.StaticDNS
 func (v *TOStaticDNSEntry) SelectQuery() string           { return selectQuery() }
 func (v *TOStaticDNSEntry) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
+		"lastUpdated":       dbhelpers.WhereColumnInfo{Column: "sde.last_updated", Checker: api.IsTime},
 		"address":           dbhelpers.WhereColumnInfo{Column: "sde.address"},
 		"cachegroup":        dbhelpers.WhereColumnInfo{Column: "cg.name"},
 		"cachegroupId":      dbhelpers.WhereColumnInfo{Column: "cg.id"},
@@ -153,6 +154,10 @@ func (en *TOStaticDNSEntry) Create() (error, error, int) {
 		if userErr != nil || sysErr != nil {
 			return userErr, sysErr, errCode
 		}
+		userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(en.ReqInfo.Tx.Tx, *en.DeliveryServiceID, en.ReqInfo.User.UserName)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
 	}
 	return api.GenericCreate(en)
 }
@@ -168,6 +173,10 @@ func (en *TOStaticDNSEntry) Update(h http.Header) (error, error, int) {
 		if userErr != nil || sysErr != nil {
 			return userErr, sysErr, errCode
 		}
+		userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(en.ReqInfo.Tx.Tx, *en.DeliveryServiceID, en.ReqInfo.User.UserName)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
 	}
 	return api.GenericUpdate(h, en)
 }
@@ -183,6 +192,10 @@ func (en *TOStaticDNSEntry) Delete() (error, error, int) {
 		if userErr != nil || sysErr != nil {
 			return userErr, sysErr, errCode
 		}
+		userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(en.ReqInfo.Tx.Tx, *en.DeliveryServiceID, en.ReqInfo.User.UserName)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
 	}
 	return api.GenericDelete(en)
 }
@@ -59,6 +59,7 @@ func (v *TOStatus) NewReadObj() interface{}       { return &TOStatus{} }
 func (v *TOStatus) SelectQuery() string           { return selectQuery() }
 func (v *TOStatus) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
+		"lastUpdated": dbhelpers.WhereColumnInfo{Column: "last_updated", Checker: api.IsTime},
 		"id":          dbhelpers.WhereColumnInfo{Column: "id", Checker: api.IsInt},
 		"description": dbhelpers.WhereColumnInfo{Column: "description"},
 		"name":        dbhelpers.WhereColumnInfo{Column: "name"},
@@ -211,6 +211,9 @@ func (st *TOSteeringTargetV11) Create() (error, error, int) {
 	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(st.ReqInfo.Tx.Tx, string(cdn), st.ReqInfo.User.UserName); userErr != nil || sysErr != nil {
 		return userErr, sysErr, errCode
 	}
+	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(st.ReqInfo.Tx.Tx, int(dsID), st.ReqInfo.User.UserName); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
 
 	rows, err := st.ReqInfo.Tx.NamedQuery(insertQuery(), st)
 	if err != nil {
@@ -246,6 +249,9 @@ func (st *TOSteeringTargetV11) Update(h http.Header) (error, error, int) {
 	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(st.ReqInfo.Tx.Tx, string(cdn), st.ReqInfo.User.UserName); userErr != nil || sysErr != nil {
 		return userErr, sysErr, errCode
 	}
+	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(st.ReqInfo.Tx.Tx, dsIDInt, st.ReqInfo.User.UserName); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
 
 	dsID := uint64(dsIDInt)
 	// TODO determine if the CRUDer automatically does this
@@ -327,6 +333,9 @@ func (st *TOSteeringTargetV11) Delete() (error, error, int) {
 	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(st.ReqInfo.Tx.Tx, string(cdn), st.ReqInfo.User.UserName); userErr != nil || sysErr != nil {
 		return userErr, sysErr, errCode
 	}
+	if userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyDeliveryServiceWithID(st.ReqInfo.Tx.Tx, int(*st.DeliveryServiceID), st.ReqInfo.User.UserName); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
 	result, err := st.ReqInfo.Tx.NamedExec(deleteQuery(), st)
 	if err != nil {
 		return nil, errors.New("steering target delete exec: " + err.Error()), http.StatusInternalServerError
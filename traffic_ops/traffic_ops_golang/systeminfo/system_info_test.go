@@ -26,7 +26,6 @@ import (
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/test"
 	"github.com/jmoiron/sqlx"
 
@@ -102,7 +101,7 @@ func TestGetSystemInfo(t *testing.T) {
 		t.Fatalf("creating transaction: %v", err)
 	}
 
-	sysinfo, err := getSystemInfo(tx, auth.PrivLevelReadOnly, 20*time.Second)
+	sysinfo, err := getSystemInfo(tx, false, 20*time.Second)
 	if err != nil {
 		t.Fatalf("getSystemInfo expected: nil error, actual: %v", err)
 	}
@@ -66,7 +66,7 @@ func (topology *TOTopology) ParamColumns() map[string]dbhelpers.WhereColumnInfo
 	return map[string]dbhelpers.WhereColumnInfo{
 		"name":        {Column: "t.name"},
 		"description": {Column: "t.description"},
-		"lastUpdated": {Column: "t.last_updated"},
+		"lastUpdated": {Column: "t.last_updated", Checker: api.IsTime},
 	}
 }
 
@@ -614,6 +614,10 @@ func (topology *TOTopology) Update(h http.Header) (error, error, int) {
 	if userErr != nil || sysErr != nil {
 		return userErr, sysErr, statusCode
 	}
+	userErr, sysErr, statusCode = dbhelpers.CheckIfResourceCanBeRenamed(topology.ReqInfo.Tx.Tx, tc.ResourceLockTypeTopology, topologies[0].(tc.Topology).Name, topology.Name)
+	if userErr != nil || sysErr != nil {
+		return userErr, sysErr, statusCode
+	}
 	oldTopology := TOTopology{APIInfoImpl: topology.APIInfoImpl, Topology: topologies[0].(tc.Topology)}
 
 	if err := oldTopology.removeParents(); err != nil {
@@ -836,5 +840,11 @@ func (topology *TOTopology) checkIfTopologyCanBeAlteredByCurrentUser() (error, e
 	if userErr != nil || sysErr != nil {
 		return userErr, sysErr, statusCode
 	}
+	if currentName, ok := topology.ReqInfo.Params["name"]; ok {
+		userErr, sysErr, statusCode = dbhelpers.CheckIfCurrentUserCanModifyTopology(topology.ReqInfo.Tx.Tx, currentName, topology.ReqInfo.User.UserName)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, statusCode
+		}
+	}
 	return nil, nil, http.StatusOK
 }
@@ -55,9 +55,10 @@ func (v *TOType) SelectMaxLastUpdatedQuery(where string, orderBy string, paginat
 }
 func (v *TOType) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
-		"name":       dbhelpers.WhereColumnInfo{Column: "typ.name"},
-		"id":         dbhelpers.WhereColumnInfo{Column: "typ.id", Checker: api.IsInt},
-		"useInTable": dbhelpers.WhereColumnInfo{Column: "typ.use_in_table"},
+		"lastUpdated": dbhelpers.WhereColumnInfo{Column: "typ.last_updated", Checker: api.IsTime},
+		"name":        dbhelpers.WhereColumnInfo{Column: "typ.name"},
+		"id":          dbhelpers.WhereColumnInfo{Column: "typ.id", Checker: api.IsInt},
+		"useInTable":  dbhelpers.WhereColumnInfo{Column: "typ.use_in_table"},
 	}
 }
 func (v *TOType) UpdateQuery() string { return updateQuery() }
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-util"
@@ -113,6 +114,25 @@ func Current(w http.ResponseWriter, r *http.Request) {
 	api.WriteResp(w, r, currentUser)
 }
 
+// CurrentPermissions is the handler for requests for the permissions of the
+// current user.
+func CurrentPermissions(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	permissions := append([]string{}, inf.User.Capabilities...)
+	sort.Strings(permissions)
+	api.WriteResp(w, r, tc.UserPermissions{
+		Role:        inf.User.RoleName,
+		Superuser:   inf.User.RoleName == auth.AdminRoleName,
+		Permissions: permissions,
+	})
+}
+
 func getUser(tx *sql.Tx, id int) (tc.UserCurrent, error) {
 	q := `
 SELECT
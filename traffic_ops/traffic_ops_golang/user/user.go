@@ -85,10 +85,11 @@ func (user *TOUser) NewReadObj() interface{} {
 
 func (user *TOUser) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 	return map[string]dbhelpers.WhereColumnInfo{
-		"id":       dbhelpers.WhereColumnInfo{Column: "u.id", Checker: api.IsInt},
-		"role":     dbhelpers.WhereColumnInfo{Column: "r.name"},
-		"tenant":   dbhelpers.WhereColumnInfo{Column: "t.name"},
-		"username": dbhelpers.WhereColumnInfo{Column: "u.username"},
+		"lastUpdated": dbhelpers.WhereColumnInfo{Column: "u.last_updated", Checker: api.IsTime},
+		"id":          dbhelpers.WhereColumnInfo{Column: "u.id", Checker: api.IsInt},
+		"role":        dbhelpers.WhereColumnInfo{Column: "r.name"},
+		"tenant":      dbhelpers.WhereColumnInfo{Column: "t.name"},
+		"username":    dbhelpers.WhereColumnInfo{Column: "u.username"},
 	}
 }
 
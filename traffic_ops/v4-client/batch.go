@@ -0,0 +1,32 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiBatch is the API version-relative path for the /batch API endpoint.
+const apiBatch = "/batch"
+
+// Batch makes the given operations in a single transaction, so either all of
+// their changes are made, or none are.
+func (to *Session) Batch(req tc.BatchRequest, opts RequestOptions) (tc.BatchAPIResponse, toclientlib.ReqInf, error) {
+	var data tc.BatchAPIResponse
+	reqInf, err := to.post(apiBatch, opts, req, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,67 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiDeliveryServiceVersions is the API version-relative path for the
+// /deliveryservices/{{ID}}/versions API endpoint. It is intended to be used
+// with fmt.Sprintf to insert the Delivery Service's ID.
+const apiDeliveryServiceVersions = apiDeliveryServiceID + "/versions"
+
+// GetDeliveryServiceVersions retrieves the versions of the Delivery Service
+// with the given ID, oldest first, without their data.
+func (to *Session) GetDeliveryServiceVersions(dsID int, opts RequestOptions) (tc.DeliveryServiceVersionsResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceVersionsResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceVersions, dsID), opts, &data)
+	return data, reqInf, err
+}
+
+// GetDeliveryServiceVersion retrieves the given version of the Delivery
+// Service with the given ID, with its data.
+func (to *Session) GetDeliveryServiceVersion(dsID int, version int, opts RequestOptions) (tc.DeliveryServiceVersionResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceVersionResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceVersions+"/%d", dsID, version), opts, &data)
+	return data, reqInf, err
+}
+
+// GetDeliveryServiceVersionDiff retrieves the differences between the given
+// version of the Delivery Service with the given ID and its latest version,
+// or the version given by the 'to' query parameter in opts.
+func (to *Session) GetDeliveryServiceVersionDiff(dsID int, from int, opts RequestOptions) (tc.DeliveryServiceVersionDiffResponse, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set("from", strconv.Itoa(from))
+	var data tc.DeliveryServiceVersionDiffResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceVersions+"/diff", dsID), opts, &data)
+	return data, reqInf, err
+}
+
+// RestoreDeliveryServiceVersion restores the Delivery Service with the given
+// ID to the given version, and returns it as restored.
+func (to *Session) RestoreDeliveryServiceVersion(dsID int, version int, opts RequestOptions) (tc.DeliveryServicesResponseV4, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServicesResponseV4
+	reqInf, err := to.post(fmt.Sprintf(apiDeliveryServiceVersions+"/%d/restore", dsID, version), opts, nil, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,89 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiDSRApprovalPolicies is the API version-relative path to the
+// /deliveryservice_request_approval_policies API endpoint.
+const apiDSRApprovalPolicies = "/deliveryservice_request_approval_policies"
+
+// apiDSRApprovals is the API version-relative path to the
+// /deliveryservice_requests/{{ID}}/approvals API endpoint.
+const apiDSRApprovals = "/deliveryservice_requests/%d/approvals"
+
+// GetDSRApprovalPolicies retrieves Delivery Service Request approval policies.
+func (to *Session) GetDSRApprovalPolicies(opts RequestOptions) (tc.DSRApprovalPoliciesResponse, toclientlib.ReqInf, error) {
+	var data tc.DSRApprovalPoliciesResponse
+	reqInf, err := to.get(apiDSRApprovalPolicies, opts, &data)
+	return data, reqInf, err
+}
+
+// CreateDSRApprovalPolicy creates the given Delivery Service Request approval
+// policy.
+func (to *Session) CreateDSRApprovalPolicy(policy tc.DSRApprovalPolicy, opts RequestOptions) (tc.DSRApprovalPolicyResponse, toclientlib.ReqInf, error) {
+	var resp tc.DSRApprovalPolicyResponse
+	reqInf, err := to.post(apiDSRApprovalPolicies, opts, policy, &resp)
+	return resp, reqInf, err
+}
+
+// UpdateDSRApprovalPolicy replaces the Delivery Service Request approval
+// policy with the given ID with the one passed.
+func (to *Session) UpdateDSRApprovalPolicy(id int, policy tc.DSRApprovalPolicy, opts RequestOptions) (tc.DSRApprovalPolicyResponse, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set("id", strconv.Itoa(id))
+	var resp tc.DSRApprovalPolicyResponse
+	reqInf, err := to.put(apiDSRApprovalPolicies, opts, policy, &resp)
+	return resp, reqInf, err
+}
+
+// DeleteDSRApprovalPolicy deletes the Delivery Service Request approval policy
+// with the given ID.
+func (to *Session) DeleteDSRApprovalPolicy(id int, opts RequestOptions) (tc.DSRApprovalPolicyResponse, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set("id", strconv.Itoa(id))
+	var resp tc.DSRApprovalPolicyResponse
+	reqInf, err := to.del(apiDSRApprovalPolicies, opts, &resp)
+	return resp, reqInf, err
+}
+
+// GetDeliveryServiceRequestApprovals retrieves the approvals of the Delivery
+// Service Request with the given ID, along with how many its policy requires.
+func (to *Session) GetDeliveryServiceRequestApprovals(id int, opts RequestOptions) (tc.DSRApprovalsResponse, toclientlib.ReqInf, error) {
+	var data tc.DSRApprovalsResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDSRApprovals, id), opts, &data)
+	return data, reqInf, err
+}
+
+// ApproveDeliveryServiceRequest approves the Delivery Service Request with the
+// given ID as the authenticated user. The returned Delivery Service Request
+// reflects any change of status that approval caused.
+func (to *Session) ApproveDeliveryServiceRequest(id int, approval tc.DSRApprovalRequest, opts RequestOptions) (tc.DeliveryServiceRequestResponseV4, toclientlib.ReqInf, error) {
+	var resp tc.DeliveryServiceRequestResponseV4
+	reqInf, err := to.post(fmt.Sprintf(apiDSRApprovals, id), opts, approval, &resp)
+	return resp, reqInf, err
+}
@@ -0,0 +1,31 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiGraphQL is the API version-relative path for the /graphql API endpoint.
+const apiGraphQL = "/graphql"
+
+// GraphQL executes the given read-only GraphQL query of Traffic Ops objects.
+func (to *Session) GraphQL(req tc.GraphQLRequest, opts RequestOptions) (tc.GraphQLResponse, toclientlib.ReqInf, error) {
+	var data tc.GraphQLResponse
+	reqInf, err := to.post(apiGraphQL, opts, req, &data)
+	return data, reqInf, err
+}
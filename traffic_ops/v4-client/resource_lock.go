@@ -0,0 +1,54 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiResourceLocks is the API version-relative path for the /resource_locks
+// API endpoint.
+const apiResourceLocks = "/resource_locks"
+
+// CreateResourceLock acquires a Resource Lock, or renews the one already held
+// by the authenticated user on the same resource.
+func (to *Session) CreateResourceLock(lock tc.ResourceLockRequest, opts RequestOptions) (tc.ResourceLockResponse, toclientlib.ReqInf, error) {
+	var response tc.ResourceLockResponse
+	reqInf, err := to.post(apiResourceLocks, opts, lock, &response)
+	return response, reqInf, err
+}
+
+// GetResourceLocks retrieves the unexpired Resource Locks.
+func (to *Session) GetResourceLocks(opts RequestOptions) (tc.ResourceLocksResponse, toclientlib.ReqInf, error) {
+	var data tc.ResourceLocksResponse
+	reqInf, err := to.get(apiResourceLocks, opts, &data)
+	return data, reqInf, err
+}
+
+// DeleteResourceLock releases the Resource Lock with the given ID.
+func (to *Session) DeleteResourceLock(id int, opts RequestOptions) (tc.ResourceLockResponse, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set("id", strconv.Itoa(id))
+	var data tc.ResourceLockResponse
+	reqInf, err := to.del(apiResourceLocks, opts, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,62 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiRollouts is the API version-relative path for the /rollouts API endpoint.
+const apiRollouts = "/rollouts"
+
+// GetRollouts retrieves Rollouts.
+func (to *Session) GetRollouts(opts RequestOptions) (tc.RolloutsResponse, toclientlib.ReqInf, error) {
+	var data tc.RolloutsResponse
+	reqInf, err := to.get(apiRollouts, opts, &data)
+	return data, reqInf, err
+}
+
+// CreateRollout creates a Rollout, which immediately queues updates on its
+// first wave of servers.
+func (to *Session) CreateRollout(rollout tc.RolloutRequest, opts RequestOptions) (tc.RolloutResponse, toclientlib.ReqInf, error) {
+	var data tc.RolloutResponse
+	reqInf, err := to.post(apiRollouts, opts, rollout, &data)
+	return data, reqInf, err
+}
+
+// PauseRollout pauses the Rollout with the given ID.
+func (to *Session) PauseRollout(id int, opts RequestOptions) (tc.RolloutResponse, toclientlib.ReqInf, error) {
+	return to.changeRollout(id, "pause", opts)
+}
+
+// ResumeRollout resumes the paused or failed Rollout with the given ID.
+func (to *Session) ResumeRollout(id int, opts RequestOptions) (tc.RolloutResponse, toclientlib.ReqInf, error) {
+	return to.changeRollout(id, "resume", opts)
+}
+
+// AbortRollout aborts the Rollout with the given ID.
+func (to *Session) AbortRollout(id int, opts RequestOptions) (tc.RolloutResponse, toclientlib.ReqInf, error) {
+	return to.changeRollout(id, "abort", opts)
+}
+
+func (to *Session) changeRollout(id int, action string, opts RequestOptions) (tc.RolloutResponse, toclientlib.ReqInf, error) {
+	var data tc.RolloutResponse
+	reqInf, err := to.post(fmt.Sprintf("%s/%d/%s", apiRollouts, id, action), opts, nil, &data)
+	return data, reqInf, err
+}
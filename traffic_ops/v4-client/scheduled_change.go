@@ -0,0 +1,50 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiScheduledChanges is the API version-relative path for the
+// /scheduled_changes API endpoint.
+const apiScheduledChanges = "/scheduled_changes"
+
+// GetScheduledChanges retrieves Scheduled Changes.
+func (to *Session) GetScheduledChanges(opts RequestOptions) (tc.ScheduledChangesResponse, toclientlib.ReqInf, error) {
+	var data tc.ScheduledChangesResponse
+	reqInf, err := to.get(apiScheduledChanges, opts, &data)
+	return data, reqInf, err
+}
+
+// CreateScheduledChange schedules a change to be made by Traffic Ops during
+// the requested window.
+func (to *Session) CreateScheduledChange(change tc.ScheduledChangeRequest, opts RequestOptions) (tc.ScheduledChangeResponse, toclientlib.ReqInf, error) {
+	var data tc.ScheduledChangeResponse
+	reqInf, err := to.post(apiScheduledChanges, opts, change, &data)
+	return data, reqInf, err
+}
+
+// CancelScheduledChange cancels the Scheduled Change with the given ID, which
+// must not have been made yet.
+func (to *Session) CancelScheduledChange(id int, opts RequestOptions) (tc.ScheduledChangeResponse, toclientlib.ReqInf, error) {
+	var data tc.ScheduledChangeResponse
+	reqInf, err := to.post(fmt.Sprintf("%s/%d/cancel", apiScheduledChanges, id), opts, nil, &data)
+	return data, reqInf, err
+}
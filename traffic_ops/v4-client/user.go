@@ -40,6 +40,15 @@ func (to *Session) GetUserCurrent(opts RequestOptions) (tc.UserCurrentResponse,
 	return resp, reqInf, err
 }
 
+// GetUserCurrentPermissions retrieves the permissions of the currently
+// authenticated User.
+func (to *Session) GetUserCurrentPermissions(opts RequestOptions) (tc.UserPermissionsResponse, toclientlib.ReqInf, error) {
+	route := `/user/current/permissions`
+	resp := tc.UserPermissionsResponse{}
+	reqInf, err := to.get(route, opts, &resp)
+	return resp, reqInf, err
+}
+
 // UpdateCurrentUser replaces the current user data with the provided tc.UserV40 structure.
 func (to *Session) UpdateCurrentUser(u tc.UserV40, opts RequestOptions) (tc.UpdateUserResponse, toclientlib.ReqInf, error) {
 	user := struct {
@@ -19,6 +19,14 @@
 
 var DeliveryServiceService = function($http, locationUtils, messageModel, ENV) {
 
+    // The ETags of the Delivery Services last gotten by ID, so that they
+    // aren't updated or deleted if someone else changed them in the meantime.
+    const eTags = {};
+
+    const ifMatch = function(id) {
+        return eTags[id] ? {headers: {"If-Match": eTags[id]}} : {};
+    };
+
     this.getDeliveryServices = function(queryParams) {
         return $http.get(ENV.api['root'] + 'deliveryservices', {params: queryParams}).then(
             function(result) {
@@ -33,6 +41,7 @@ var DeliveryServiceService = function($http, locationUtils, messageModel, ENV) {
     this.getDeliveryService = function(id) {
         return $http.get(ENV.api['root'] + 'deliveryservices', {params: {id: id}}).then(
             function(result) {
+                eTags[id] = result.headers("ETag");
                 return result.data.response[0];
             },
             function(err) {
@@ -59,8 +68,9 @@ var DeliveryServiceService = function($http, locationUtils, messageModel, ENV) {
         // strip out any falsy values or duplicates from consistentHashQueryParams
         ds.consistentHashQueryParams = Array.from(new Set(ds.consistentHashQueryParams)).filter(function(i){return i;});
 
-        return $http.put(ENV.api['root'] + "deliveryservices/" + ds.id, ds).then(
+        return $http.put(ENV.api['root'] + "deliveryservices/" + ds.id, ds, ifMatch(ds.id)).then(
             function(response) {
+                delete eTags[ds.id];
                 return response;
             },
             function(err) {
@@ -71,8 +81,9 @@ var DeliveryServiceService = function($http, locationUtils, messageModel, ENV) {
 
     // todo: change to use query param when it is supported
     this.deleteDeliveryService = function(ds) {
-        return $http.delete(ENV.api['root'] + "deliveryservices/" + ds.id).then(
+        return $http.delete(ENV.api['root'] + "deliveryservices/" + ds.id, ifMatch(ds.id)).then(
             function(response) {
+                delete eTags[ds.id];
                 return response;
             },
             function(err) {